@@ -61,14 +61,19 @@ func (n AccountDeleteResult) GetResponse() *api.Response {
 }
 
 type AccountListResult struct {
-	Items    []*Account
-	response *api.Response
+	Items     []*Account
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n AccountListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n AccountListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n AccountListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -2,6 +2,7 @@
 package accounts
 
 type PasswordAccountAttributes struct {
-	LoginName string `json:"login_name,omitempty"`
-	Password  string `json:"password,omitempty"`
+	LoginName              string `json:"login_name,omitempty"`
+	Password               string `json:"password,omitempty"`
+	PasswordChangeRequired bool   `json:"password_change_required,omitempty"`
 }
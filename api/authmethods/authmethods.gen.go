@@ -62,14 +62,19 @@ func (n AuthMethodDeleteResult) GetResponse() *api.Response {
 }
 
 type AuthMethodListResult struct {
-	Items    []*AuthMethod
-	response *api.Response
+	Items     []*AuthMethod
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n AuthMethodListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n AuthMethodListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n AuthMethodListResult) GetResponse() *api.Response {
 	return n.response
 }
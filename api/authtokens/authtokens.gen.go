@@ -60,14 +60,19 @@ func (n AuthTokenDeleteResult) GetResponse() *api.Response {
 }
 
 type AuthTokenListResult struct {
-	Items    []*AuthToken
-	response *api.Response
+	Items     []*AuthToken
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n AuthTokenListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n AuthTokenListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n AuthTokenListResult) GetResponse() *api.Response {
 	return n.response
 }
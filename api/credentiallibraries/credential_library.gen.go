@@ -13,17 +13,19 @@ import (
 )
 
 type CredentialLibrary struct {
-	Id                string                 `json:"id,omitempty"`
-	CredentialStoreId string                 `json:"credential_store_id,omitempty"`
-	Scope             *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name              string                 `json:"name,omitempty"`
-	Description       string                 `json:"description,omitempty"`
-	CreatedTime       time.Time              `json:"created_time,omitempty"`
-	UpdatedTime       time.Time              `json:"updated_time,omitempty"`
-	Version           uint32                 `json:"version,omitempty"`
-	Type              string                 `json:"type,omitempty"`
-	Attributes        map[string]interface{} `json:"attributes,omitempty"`
-	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
+	Id                         string                 `json:"id,omitempty"`
+	CredentialStoreId          string                 `json:"credential_store_id,omitempty"`
+	Scope                      *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name                       string                 `json:"name,omitempty"`
+	Description                string                 `json:"description,omitempty"`
+	CreatedTime                time.Time              `json:"created_time,omitempty"`
+	UpdatedTime                time.Time              `json:"updated_time,omitempty"`
+	Version                    uint32                 `json:"version,omitempty"`
+	Type                       string                 `json:"type,omitempty"`
+	Attributes                 map[string]interface{} `json:"attributes,omitempty"`
+	CredentialType             string                 `json:"credential_type,omitempty"`
+	CredentialMappingOverrides map[string]interface{} `json:"credential_mapping_overrides,omitempty"`
+	AuthorizedActions          []string               `json:"authorized_actions,omitempty"`
 
 	response *api.Response
 }
@@ -60,14 +62,19 @@ func (n CredentialLibraryDeleteResult) GetResponse() *api.Response {
 }
 
 type CredentialLibraryListResult struct {
-	Items    []*CredentialLibrary
-	response *api.Response
+	Items     []*CredentialLibrary
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n CredentialLibraryListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n CredentialLibraryListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n CredentialLibraryListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -1,6 +1,7 @@
 package credentiallibraries
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/boundary/api"
@@ -21,6 +22,8 @@ type options struct {
 	withAutomaticVersioning bool
 	withSkipCurlOutput      bool
 	withFilter              string
+	withPageSize            uint32
+	withListToken           string
 }
 
 func getDefaultOptions() options {
@@ -44,6 +47,12 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	if opts.withFilter != "" {
 		opts.queryMap["filter"] = opts.withFilter
 	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withListToken != "" {
+		opts.queryMap["list_token"] = opts.withListToken
+	}
 	return opts, apiOpts
 }
 
@@ -74,6 +83,23 @@ func WithFilter(filter string) Option {
 	}
 }
 
+// WithPageSize tells the API to return at most the given number of items
+// when listing. If there are more items, the list result contains a list
+// token which can be passed to WithListToken to fetch the next page.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.withPageSize = pageSize
+	}
+}
+
+// WithListToken tells the API to return the page of items following the page
+// the list token was returned with.
+func WithListToken(listToken string) Option {
+	return func(o *options) {
+		o.withListToken = listToken
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -86,6 +112,30 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithCredentialMappingOverrides(inCredentialMappingOverrides map[string]interface{}) Option {
+	return func(o *options) {
+		o.postMap["credential_mapping_overrides"] = inCredentialMappingOverrides
+	}
+}
+
+func DefaultCredentialMappingOverrides() Option {
+	return func(o *options) {
+		o.postMap["credential_mapping_overrides"] = nil
+	}
+}
+
+func WithCredentialType(inCredentialType string) Option {
+	return func(o *options) {
+		o.postMap["credential_type"] = inCredentialType
+	}
+}
+
+func DefaultCredentialType() Option {
+	return func(o *options) {
+		o.postMap["credential_type"] = nil
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -61,14 +61,19 @@ func (n CredentialStoreDeleteResult) GetResponse() *api.Response {
 }
 
 type CredentialStoreListResult struct {
-	Items    []*CredentialStore
-	response *api.Response
+	Items     []*CredentialStore
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n CredentialStoreListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n CredentialStoreListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n CredentialStoreListResult) GetResponse() *api.Response {
 	return n.response
 }
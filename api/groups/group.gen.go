@@ -60,14 +60,19 @@ func (n GroupDeleteResult) GetResponse() *api.Response {
 }
 
 type GroupListResult struct {
-	Items    []*Group
-	response *api.Response
+	Items     []*Group
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n GroupListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n GroupListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n GroupListResult) GetResponse() *api.Response {
 	return n.response
 }
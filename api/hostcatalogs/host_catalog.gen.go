@@ -61,14 +61,19 @@ func (n HostCatalogDeleteResult) GetResponse() *api.Response {
 }
 
 type HostCatalogListResult struct {
-	Items    []*HostCatalog
-	response *api.Response
+	Items     []*HostCatalog
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n HostCatalogListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n HostCatalogListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n HostCatalogListResult) GetResponse() *api.Response {
 	return n.response
 }
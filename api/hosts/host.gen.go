@@ -61,14 +61,19 @@ func (n HostDeleteResult) GetResponse() *api.Response {
 }
 
 type HostListResult struct {
-	Items    []*Host
-	response *api.Response
+	Items     []*Host
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n HostListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n HostListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n HostListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -61,14 +61,19 @@ func (n HostSetDeleteResult) GetResponse() *api.Response {
 }
 
 type HostSetListResult struct {
-	Items    []*HostSet
-	response *api.Response
+	Items     []*HostSet
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n HostSetListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n HostSetListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n HostSetListResult) GetResponse() *api.Response {
 	return n.response
 }
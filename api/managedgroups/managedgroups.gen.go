@@ -61,14 +61,19 @@ func (n ManagedGroupDeleteResult) GetResponse() *api.Response {
 }
 
 type ManagedGroupListResult struct {
-	Items    []*ManagedGroup
-	response *api.Response
+	Items     []*ManagedGroup
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n ManagedGroupListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n ManagedGroupListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n ManagedGroupListResult) GetResponse() *api.Response {
 	return n.response
 }
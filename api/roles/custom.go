@@ -0,0 +1,66 @@
+package roles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type ExplanationResult struct {
+	Item     *Explanation
+	response *api.Response
+}
+
+func (n ExplanationResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n ExplanationResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// Explain explains whether the grants of the roles of the user authorize the
+// action on a resource within the scope. The resource is provided using
+// WithResourceId, or WithResourceType for a collection.
+func (c *Client) Explain(ctx context.Context, scopeId, userId, action string, opt ...Option) (*ExplanationResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into Explain request")
+	}
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into Explain request")
+	}
+	if action == "" {
+		return nil, fmt.Errorf("empty action value passed into Explain request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.postMap["scope_id"] = scopeId
+	opts.postMap["user_id"] = userId
+	opts.postMap["action"] = action
+
+	req, err := c.client.NewRequest(ctx, "POST", "roles:explain", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Explain request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Explain call: %w", err)
+	}
+
+	target := new(ExplanationResult)
+	target.Item = new(Explanation)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Explain response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
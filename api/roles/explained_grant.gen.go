@@ -0,0 +1,9 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roles
+
+type ExplainedGrant struct {
+	RoleId    string `json:"role_id,omitempty"`
+	ScopeId   string `json:"scope_id,omitempty"`
+	Canonical string `json:"canonical,omitempty"`
+	Effect    string `json:"effect,omitempty"`
+}
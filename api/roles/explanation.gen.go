@@ -0,0 +1,17 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roles
+
+type Explanation struct {
+	UserId            string            `json:"user_id,omitempty"`
+	ScopeId           string            `json:"scope_id,omitempty"`
+	ResourceId        string            `json:"resource_id,omitempty"`
+	ResourceType      string            `json:"resource_type,omitempty"`
+	Pin               string            `json:"pin,omitempty"`
+	Action            string            `json:"action,omitempty"`
+	Authorized        bool              `json:"authorized,omitempty"`
+	Denied            bool              `json:"denied,omitempty"`
+	Grants            []*ExplainedGrant `json:"grants,omitempty"`
+	RoleIds           []string          `json:"role_ids,omitempty"`
+	ConsultedScopeIds []string          `json:"consulted_scope_ids,omitempty"`
+	OutputFields      []string          `json:"output_fields,omitempty"`
+}
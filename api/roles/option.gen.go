@@ -22,6 +22,8 @@ type options struct {
 	withAutomaticVersioning bool
 	withSkipCurlOutput      bool
 	withFilter              string
+	withPageSize            uint32
+	withListToken           string
 	withRecursive           bool
 }
 
@@ -46,6 +48,12 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	if opts.withFilter != "" {
 		opts.queryMap["filter"] = opts.withFilter
 	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withListToken != "" {
+		opts.queryMap["list_token"] = opts.withListToken
+	}
 	if opts.withRecursive {
 		opts.queryMap["recursive"] = strconv.FormatBool(opts.withRecursive)
 	}
@@ -79,6 +87,23 @@ func WithFilter(filter string) Option {
 	}
 }
 
+// WithPageSize tells the API to return at most the given number of items
+// when listing. If there are more items, the list result contains a list
+// token which can be passed to WithListToken to fetch the next page.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.withPageSize = pageSize
+	}
+}
+
+// WithListToken tells the API to return the page of items following the page
+// the list token was returned with.
+func WithListToken(listToken string) Option {
+	return func(o *options) {
+		o.withListToken = listToken
+	}
+}
+
 // WithRecursive tells the API to use recursion for listing operations on this
 // resource
 func WithRecursive(recurse bool) Option {
@@ -87,6 +112,18 @@ func WithRecursive(recurse bool) Option {
 	}
 }
 
+func WithClientIp(inClientIp string) Option {
+	return func(o *options) {
+		o.postMap["client_ip"] = inClientIp
+	}
+}
+
+func DefaultClientIp() Option {
+	return func(o *options) {
+		o.postMap["client_ip"] = nil
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -122,3 +159,39 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithPin(inPin string) Option {
+	return func(o *options) {
+		o.postMap["pin"] = inPin
+	}
+}
+
+func DefaultPin() Option {
+	return func(o *options) {
+		o.postMap["pin"] = nil
+	}
+}
+
+func WithResourceId(inResourceId string) Option {
+	return func(o *options) {
+		o.postMap["resource_id"] = inResourceId
+	}
+}
+
+func DefaultResourceId() Option {
+	return func(o *options) {
+		o.postMap["resource_id"] = nil
+	}
+}
+
+func WithResourceType(inResourceType string) Option {
+	return func(o *options) {
+		o.postMap["resource_type"] = inResourceType
+	}
+}
+
+func DefaultResourceType() Option {
+	return func(o *options) {
+		o.postMap["resource_type"] = nil
+	}
+}
@@ -63,14 +63,19 @@ func (n RoleDeleteResult) GetResponse() *api.Response {
 }
 
 type RoleListResult struct {
-	Items    []*Role
-	response *api.Response
+	Items     []*Role
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n RoleListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n RoleListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n RoleListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -0,0 +1,245 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// KeyVersion contains a version of a Key.
+type KeyVersion struct {
+	Id          string    `json:"id,omitempty"`
+	Version     uint32    `json:"version,omitempty"`
+	CreatedTime time.Time `json:"created_time,omitempty"`
+}
+
+// Key contains the root key or a data encryption key of a scope.
+type Key struct {
+	Id          string        `json:"id,omitempty"`
+	Scope       *ScopeInfo    `json:"scope,omitempty"`
+	Purpose     string        `json:"purpose,omitempty"`
+	CreatedTime time.Time     `json:"created_time,omitempty"`
+	Versions    []*KeyVersion `json:"versions,omitempty"`
+}
+
+// KeyVersionDestructionJob contains the progress of destroying a database
+// key version.
+type KeyVersionDestructionJob struct {
+	KeyVersionId   string     `json:"key_version_id,omitempty"`
+	Scope          *ScopeInfo `json:"scope,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	CreatedTime    time.Time  `json:"created_time,omitempty"`
+	CompletedCount int64      `json:"completed_count,omitempty"`
+	TotalCount     int64      `json:"total_count,omitempty"`
+}
+
+type RotateKeysResult struct {
+	response *api.Response
+}
+
+func (n RotateKeysResult) GetItem() interface{} {
+	return nil
+}
+
+func (n RotateKeysResult) GetResponse() *api.Response {
+	return n.response
+}
+
+type KeyListResult struct {
+	Items    []*Key
+	response *api.Response
+}
+
+func (n KeyListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n KeyListResult) GetListToken() string {
+	return ""
+}
+
+func (n KeyListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+type DestroyKeyVersionResult struct {
+	// State is "destroyed" if the key version was destroyed and "pending" if
+	// a destruction job was created for it.
+	State    string `json:"state,omitempty"`
+	response *api.Response
+}
+
+func (n DestroyKeyVersionResult) GetItem() interface{} {
+	return n.State
+}
+
+func (n DestroyKeyVersionResult) GetResponse() *api.Response {
+	return n.response
+}
+
+type KeyVersionDestructionJobListResult struct {
+	Items    []*KeyVersionDestructionJob
+	response *api.Response
+}
+
+func (n KeyVersionDestructionJobListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n KeyVersionDestructionJobListResult) GetListToken() string {
+	return ""
+}
+
+func (n KeyVersionDestructionJobListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// RotateKeys creates a new version of the root key and of the data encryption
+// keys of the scope. If rewrap is true, the existing key versions are also
+// encrypted with the current root KMS and the new root key version.
+func (c *Client) RotateKeys(ctx context.Context, scopeId string, rewrap bool, opt ...Option) (*RotateKeysResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into RotateKeys request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RotateKeys request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"rewrap": rewrap,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:rotate-keys", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RotateKeys request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RotateKeys call: %w", err)
+	}
+
+	target := new(RotateKeysResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RotateKeys response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// ListKeys returns the root key and the data encryption keys of the scope.
+func (c *Client) ListKeys(ctx context.Context, scopeId string, opt ...Option) (*KeyListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListKeys request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ListKeys request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:list-keys", scopeId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListKeys request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListKeys call: %w", err)
+	}
+
+	target := new(KeyListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListKeys response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// DestroyKeyVersion destroys a root or database key version of the scope. A
+// database key version is destroyed by a destruction job once every value it
+// encrypts has been encrypted with the current database key version.
+func (c *Client) DestroyKeyVersion(ctx context.Context, scopeId, keyVersionId string, opt ...Option) (*DestroyKeyVersionResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into DestroyKeyVersion request")
+	}
+	if keyVersionId == "" {
+		return nil, fmt.Errorf("empty keyVersionId value passed into DestroyKeyVersion request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in DestroyKeyVersion request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"key_version_id": keyVersionId,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:destroy-key-version", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DestroyKeyVersion request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during DestroyKeyVersion call: %w", err)
+	}
+
+	target := new(DestroyKeyVersionResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding DestroyKeyVersion response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// ListKeyVersionDestructionJobs returns the pending database key version
+// destruction jobs of the scope.
+func (c *Client) ListKeyVersionDestructionJobs(ctx context.Context, scopeId string, opt ...Option) (*KeyVersionDestructionJobListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListKeyVersionDestructionJobs request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ListKeyVersionDestructionJobs request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:list-key-version-destruction-jobs", scopeId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListKeyVersionDestructionJobs request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListKeyVersionDestructionJobs call: %w", err)
+	}
+
+	target := new(KeyVersionDestructionJobListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListKeyVersionDestructionJobs response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -60,14 +60,19 @@ func (n ScopeDeleteResult) GetResponse() *api.Response {
 }
 
 type ScopeListResult struct {
-	Items    []*Scope
-	response *api.Response
+	Items     []*Scope
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n ScopeListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n ScopeListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n ScopeListResult) GetResponse() *api.Response {
 	return n.response
 }
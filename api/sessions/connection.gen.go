@@ -0,0 +1,19 @@
+// Code generated by "make api"; DO NOT EDIT.
+package sessions
+
+import (
+	"time"
+)
+
+type Connection struct {
+	Id                 string    `json:"id,omitempty"`
+	Status             string    `json:"status,omitempty"`
+	ClientTcpAddress   string    `json:"client_tcp_address,omitempty"`
+	ClientTcpPort      uint32    `json:"client_tcp_port,omitempty"`
+	EndpointTcpAddress string    `json:"endpoint_tcp_address,omitempty"`
+	EndpointTcpPort    uint32    `json:"endpoint_tcp_port,omitempty"`
+	BytesUp            uint64    `json:"bytes_up,omitempty,string"`
+	BytesDown          uint64    `json:"bytes_down,omitempty,string"`
+	ClosedReason       string    `json:"closed_reason,omitempty"`
+	CreatedTime        time.Time `json:"created_time,omitempty"`
+}
@@ -0,0 +1,7 @@
+// Code generated by "make api"; DO NOT EDIT.
+package sessions
+
+type SessionRecording struct {
+	WorkerId    string `json:"worker_id,omitempty"`
+	StoragePath string `json:"storage_path,omitempty"`
+}
@@ -12,26 +12,30 @@ import (
 )
 
 type Session struct {
-	Id                string            `json:"id,omitempty"`
-	TargetId          string            `json:"target_id,omitempty"`
-	Scope             *scopes.ScopeInfo `json:"scope,omitempty"`
-	CreatedTime       time.Time         `json:"created_time,omitempty"`
-	UpdatedTime       time.Time         `json:"updated_time,omitempty"`
-	Version           uint32            `json:"version,omitempty"`
-	Type              string            `json:"type,omitempty"`
-	ExpirationTime    time.Time         `json:"expiration_time,omitempty"`
-	AuthTokenId       string            `json:"auth_token_id,omitempty"`
-	UserId            string            `json:"user_id,omitempty"`
-	HostSetId         string            `json:"host_set_id,omitempty"`
-	HostId            string            `json:"host_id,omitempty"`
-	ScopeId           string            `json:"scope_id,omitempty"`
-	Endpoint          string            `json:"endpoint,omitempty"`
-	States            []*SessionState   `json:"states,omitempty"`
-	Status            string            `json:"status,omitempty"`
-	WorkerInfo        []*WorkerInfo     `json:"worker_info,omitempty"`
-	Certificate       []byte            `json:"certificate,omitempty"`
-	TerminationReason string            `json:"termination_reason,omitempty"`
-	AuthorizedActions []string          `json:"authorized_actions,omitempty"`
+	Id                     string            `json:"id,omitempty"`
+	TargetId               string            `json:"target_id,omitempty"`
+	Scope                  *scopes.ScopeInfo `json:"scope,omitempty"`
+	CreatedTime            time.Time         `json:"created_time,omitempty"`
+	UpdatedTime            time.Time         `json:"updated_time,omitempty"`
+	Version                uint32            `json:"version,omitempty"`
+	Type                   string            `json:"type,omitempty"`
+	ExpirationTime         time.Time         `json:"expiration_time,omitempty"`
+	AuthTokenId            string            `json:"auth_token_id,omitempty"`
+	UserId                 string            `json:"user_id,omitempty"`
+	HostSetId              string            `json:"host_set_id,omitempty"`
+	HostId                 string            `json:"host_id,omitempty"`
+	ScopeId                string            `json:"scope_id,omitempty"`
+	Endpoint               string            `json:"endpoint,omitempty"`
+	States                 []*SessionState   `json:"states,omitempty"`
+	Status                 string            `json:"status,omitempty"`
+	WorkerInfo             []*WorkerInfo     `json:"worker_info,omitempty"`
+	Certificate            []byte            `json:"certificate,omitempty"`
+	TerminationReason      string            `json:"termination_reason,omitempty"`
+	Recording              *SessionRecording `json:"recording,omitempty"`
+	Connections            []*Connection     `json:"connections,omitempty"`
+	ApprovalExpirationTime time.Time         `json:"approval_expiration_time,omitempty"`
+	ApproverId             string            `json:"approver_id,omitempty"`
+	AuthorizedActions      []string          `json:"authorized_actions,omitempty"`
 
 	response *api.Response
 }
@@ -68,14 +72,19 @@ func (n SessionDeleteResult) GetResponse() *api.Response {
 }
 
 type SessionListResult struct {
-	Items    []*Session
-	response *api.Response
+	Items     []*Session
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n SessionListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n SessionListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n SessionListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -0,0 +1,47 @@
+package targets
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ListMine returns the targets, across all scopes, on which the requester is
+// allowed to authorize a session. Unlike List, it doesn't require the list
+// action to be granted.
+func (c *Client) ListMine(ctx context.Context, opt ...Option) (*TargetListResult, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", "targets:list-mine", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListMine request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListMine call: %w", err)
+	}
+
+	target := new(TargetListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListMine response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
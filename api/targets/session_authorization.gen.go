@@ -19,4 +19,5 @@ type SessionAuthorization struct {
 	AuthorizationToken string               `json:"authorization_token,omitempty"`
 	Endpoint           string               `json:"endpoint,omitempty"`
 	Credentials        []*SessionCredential `json:"credentials,omitempty"`
+	Status             string               `json:"status,omitempty"`
 }
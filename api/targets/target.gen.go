@@ -33,6 +33,8 @@ type Target struct {
 	ApplicationCredentialLibraries  []*CredentialLibrary   `json:"application_credential_libraries,omitempty"`
 	ApplicationCredentialSourceIds  []string               `json:"application_credential_source_ids,omitempty"`
 	ApplicationCredentialSources    []*CredentialSource    `json:"application_credential_sources,omitempty"`
+	EgressCredentialSourceIds       []string               `json:"egress_credential_source_ids,omitempty"`
+	EgressCredentialSources         []*CredentialSource    `json:"egress_credential_sources,omitempty"`
 	Attributes                      map[string]interface{} `json:"attributes,omitempty"`
 	AuthorizedActions               []string               `json:"authorized_actions,omitempty"`
 
@@ -71,14 +73,19 @@ func (n TargetDeleteResult) GetResponse() *api.Response {
 }
 
 type TargetListResult struct {
-	Items    []*Target
-	response *api.Response
+	Items     []*Target
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n TargetListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n TargetListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n TargetListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -2,5 +2,10 @@
 package targets
 
 type TcpTargetAttributes struct {
-	DefaultPort uint32 `json:"default_port,omitempty"`
+	DefaultPort                  uint32 `json:"default_port,omitempty"`
+	SessionRecordingEnabled      bool   `json:"session_recording_enabled,omitempty"`
+	UploadRateLimit              uint32 `json:"upload_rate_limit,omitempty"`
+	DownloadRateLimit            uint32 `json:"download_rate_limit,omitempty"`
+	SessionApprovalRequired      bool   `json:"session_approval_required,omitempty"`
+	SessionApprovalWindowSeconds uint32 `json:"session_approval_window_seconds,omitempty"`
 }
@@ -64,14 +64,19 @@ func (n UserDeleteResult) GetResponse() *api.Response {
 }
 
 type UserListResult struct {
-	Items    []*User
-	response *api.Response
+	Items     []*User
+	ListToken string `json:"list_token,omitempty"`
+	response  *api.Response
 }
 
 func (n UserListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n UserListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n UserListResult) GetResponse() *api.Response {
 	return n.response
 }
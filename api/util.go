@@ -19,5 +19,6 @@ type GenericDeleteResult interface {
 
 type GenericListResult interface {
 	GetItems() interface{}
+	GetListToken() string
 	GetResponse() *Response
 }
@@ -42,6 +42,8 @@ const (
 	TerminationReasonField               = "termination_reason"
 	StatusField                          = "status"
 	StatesField                          = "states"
+	RecordingField                       = "recording"
+	ConnectionsField                     = "connections"
 	SessionConnectionLimitField          = "session_connection_limit"
 	SessionMaxSecondsField               = "session_max_seconds"
 	WorkerFilterField                    = "worker_filter"
@@ -54,8 +56,26 @@ const (
 	ManagedGroupIdsField                 = "managed_group_ids"
 	FilterField                          = "filter"
 	CredentialStoreIdField               = "credential_store_id"
+	CredentialTypeField                  = "credential_type"
+	CredentialMappingOverridesField      = "credential_mapping_overrides"
 	ApplicationCredentialLibraryIdsField = "application_credential_library_ids"
 	ApplicationCredentialLibrariesField  = "application_credential_libraries"
 	ApplicationCredentialSourceIdsField  = "application_credential_source_ids"
 	ApplicationCredentialSourcesField    = "application_credential_sources"
+	EgressCredentialSourceIdsField       = "egress_credential_source_ids"
+	EgressCredentialSourcesField         = "egress_credential_sources"
+	MaxActiveSessionsPerUserField        = "max_active_sessions_per_user"
+	MaxDailySessionSecondsPerUserField   = "max_daily_session_seconds_per_user"
+	SessionIdField                       = "session_id"
+	ApprovalExpirationTimeField          = "approval_expiration_time"
+	ApproverIdField                      = "approver_id"
+	ActivationTokenField                 = "activation_token"
+	ActivationTokenExpirationTimeField   = "activation_token_expiration_time"
+	ActivationTimeField                  = "activation_time"
+	AddressField                         = "address"
+	LastStatusTimeField                  = "last_status_time"
+	ConfigTagsField                      = "config_tags"
+	ApiTagsField                         = "api_tags"
+	CanonicalTagsField                   = "canonical_tags"
+	RecordingPublicKeyField              = "recording_public_key"
 )
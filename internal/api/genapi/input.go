@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentials"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentialstores"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/groups"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostcatalogs"
@@ -16,9 +17,11 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/managedgroups"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessionpolicies"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/workers"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -41,6 +44,8 @@ type fieldInfo struct {
 	SubtypeName       string
 	Query             bool
 	SkipDefault       bool
+	// JsonString is set for 64-bit integers, which are encoded as JSON strings
+	JsonString bool
 }
 
 type structInfo struct {
@@ -240,6 +245,16 @@ var inputStructs = []*structInfo{
 		outFile:     "roles/grant_json.gen.go",
 		skipOptions: true,
 	},
+	{
+		inProto:     &roles.ExplainedGrant{},
+		outFile:     "roles/explained_grant.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto:     &roles.Explanation{},
+		outFile:     "roles/explanation.gen.go",
+		skipOptions: true,
+	},
 	{
 		inProto: &roles.Role{},
 		outFile: "roles/role.gen.go",
@@ -261,6 +276,28 @@ var inputStructs = []*structInfo{
 				VarName:   "grantStrings",
 			},
 		},
+		extraOptions: []fieldInfo{
+			{
+				Name:      "ResourceId",
+				ProtoName: "resource_id",
+				FieldType: "string",
+			},
+			{
+				Name:      "ResourceType",
+				ProtoName: "resource_type",
+				FieldType: "string",
+			},
+			{
+				Name:      "Pin",
+				ProtoName: "pin",
+				FieldType: "string",
+			},
+			{
+				Name:      "ClientIp",
+				ProtoName: "client_ip",
+				FieldType: "string",
+			},
+		},
 		pluralResourceName:  "roles",
 		versionEnabled:      true,
 		createResponseTypes: true,
@@ -282,6 +319,11 @@ var inputStructs = []*structInfo{
 		outFile:     "authmethods/oidc_auth_method_authenticate_start_response.gen.go",
 		subtypeName: "OidcAuthMethod",
 	},
+	{
+		inProto:     &authmethods.LdapAuthMethodAttributes{},
+		outFile:     "authmethods/ldap_auth_method_attributes.gen.go",
+		subtypeName: "LdapAuthMethod",
+	},
 	{
 		inProto: &authmethods.AuthMethod{},
 		outFile: "authmethods/authmethods.gen.go",
@@ -310,6 +352,11 @@ var inputStructs = []*structInfo{
 		outFile:     "accounts/oidc_account_attributes.gen.go",
 		subtypeName: "OidcAccount",
 	},
+	{
+		inProto:     &accounts.LdapAccountAttributes{},
+		outFile:     "accounts/ldap_account_attributes.gen.go",
+		subtypeName: "LdapAccount",
+	},
 	{
 		inProto: &accounts.Account{},
 		outFile: "accounts/account.gen.go",
@@ -338,6 +385,17 @@ var inputStructs = []*structInfo{
 			},
 		},
 	},
+	{
+		inProto:     &managedgroups.LdapManagedGroupAttributes{},
+		outFile:     "managedgroups/ldap_managed_group_attributes.gen.go",
+		subtypeName: "LdapManagedGroup",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "GroupNames",
+				SkipDefault: true,
+			},
+		},
+	},
 	{
 		inProto: &managedgroups.ManagedGroup{},
 		outFile: "managedgroups/managedgroups.gen.go",
@@ -429,6 +487,45 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
+	{
+		inProto:     &credentials.UsernamePasswordAttributes{},
+		outFile:     "credentials/username_password_attributes.gen.go",
+		subtypeName: "UsernamePasswordCredential",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Password",
+				SkipDefault: true,
+			},
+		},
+	},
+	{
+		inProto:     &credentials.SshPrivateKeyAttributes{},
+		outFile:     "credentials/ssh_private_key_attributes.gen.go",
+		subtypeName: "SshPrivateKeyCredential",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "PrivateKey",
+				SkipDefault: true,
+			},
+		},
+	},
+	{
+		inProto: &credentials.Credential{},
+		outFile: "credentials/credential.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "credentials",
+		parentTypeName:      "credential-store",
+		typeOnCreate:        true,
+		versionEnabled:      true,
+		createResponseTypes: true,
+	},
 	// Credentials
 	{
 		inProto:     &credentialstores.VaultCredentialStoreAttributes{},
@@ -596,6 +693,16 @@ var inputStructs = []*structInfo{
 		outFile:     "targets/tcp_target_attributes.gen.go",
 		subtypeName: "TcpTarget",
 	},
+	{
+		inProto:     &targets.SshTargetAttributes{},
+		outFile:     "targets/ssh_target_attributes.gen.go",
+		subtypeName: "SshTarget",
+	},
+	{
+		inProto:     &targets.UdpTargetAttributes{},
+		outFile:     "targets/udp_target_attributes.gen.go",
+		subtypeName: "UdpTarget",
+	},
 	{
 		inProto: &targets.Target{},
 		outFile: "targets/target.gen.go",
@@ -627,6 +734,12 @@ var inputStructs = []*structInfo{
 				FieldType:   "string",
 				SkipDefault: true,
 			},
+			{
+				Name:        "SessionId",
+				ProtoName:   "session_id",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
 			{
 				Name:        "ScopeId",
 				ProtoName:   "scope_id",
@@ -649,6 +762,11 @@ var inputStructs = []*structInfo{
 				ProtoName: "application_credential_source_ids",
 				FieldType: "[]string",
 			},
+			{
+				Name:      "EgressCredentialSourceIds",
+				ProtoName: "egress_credential_source_ids",
+				FieldType: "[]string",
+			},
 		},
 		versionEnabled:      true,
 		typeOnCreate:        true,
@@ -663,6 +781,14 @@ var inputStructs = []*structInfo{
 		inProto: &sessions.WorkerInfo{},
 		outFile: "sessions/workers.gen.go",
 	},
+	{
+		inProto: &sessions.SessionRecording{},
+		outFile: "sessions/recording.gen.go",
+	},
+	{
+		inProto: &sessions.Connection{},
+		outFile: "sessions/connection.gen.go",
+	},
 	{
 		inProto: &sessions.Session{},
 		outFile: "sessions/session.gen.go",
@@ -676,4 +802,42 @@ var inputStructs = []*structInfo{
 		fieldFilter:         []string{"private_key"},
 		recursiveListing:    true,
 	},
+	// Session policy related resources
+	{
+		inProto: &sessionpolicies.SessionPolicy{},
+		outFile: "sessionpolicies/session_policy.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "session-policies",
+		versionEnabled:      true,
+		createResponseTypes: true,
+		recursiveListing:    true,
+	},
+	// Worker related resources
+	{
+		inProto: &workers.Worker{},
+		outFile: "workers/worker.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		sliceSubtypes: map[string]sliceSubtypeInfo{
+			"WorkerTags": {
+				SliceType: "map[string][]string",
+				VarName:   "apiTags",
+			},
+		},
+		pluralResourceName:  "workers",
+		versionEnabled:      true,
+		createResponseTypes: true,
+	},
 }
@@ -65,14 +65,19 @@ func parsePBs() {
 				if pkg != "" && pkg != in.generatedStructure.pkg {
 					name = fmt.Sprintf("%s.%s", pkg, name)
 				}
-				switch name {
-				case "v1.AuthorizedCollectionActionsEntry":
+				switch {
+				case name == "v1.AuthorizedCollectionActionsEntry",
+					fd.IsMap() && fd.MapValue().Kind() == protoreflect.MessageKind && fd.MapValue().Message().FullName() == listValueName:
 					fi.FieldType = "map[string][]string"
 				default:
 					fi.FieldType = sliceText + ptr + name
 				}
 			case protoreflect.BytesKind:
 				fi.FieldType = "[]byte"
+			case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+				protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+				fi.FieldType = sliceText + k.String()
+				fi.JsonString = sliceText == ""
 			default:
 				fi.FieldType = sliceText + k.String()
 			}
@@ -100,6 +105,7 @@ var (
 	structValueName = (&_struct.Struct{}).ProtoReflect().Descriptor().FullName()
 	timestampName   = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
 	valueName       = (&_struct.Value{}).ProtoReflect().Descriptor().FullName()
+	listValueName   = (&_struct.ListValue{}).ProtoReflect().Descriptor().FullName()
 )
 
 func messageKind(fd protoreflect.FieldDescriptor) (ptr, pkg, name string) {
@@ -117,7 +117,10 @@ func fillTemplates() {
 			for _, val := range input.Fields {
 				if val.GenerateSdkOption {
 					val.SubtypeName = in.subtypeName
-					pkgOptionMap[val.Name] = val
+					// Options for subtype attributes are prefixed with the
+					// subtype name, so different subtypes may define fields
+					// with the same name.
+					pkgOptionMap[val.SubtypeName+val.Name] = val
 				}
 			}
 			optionMap := optionsMap[input.Package]
@@ -146,9 +149,12 @@ func fillTemplates() {
 				inOpts := optionsMap[input.Package]
 				if inOpts != nil {
 					if override.SkipDefault {
-						fieldInfo := inOpts[override.Name]
-						fieldInfo.SkipDefault = true
-						inOpts[override.Name] = fieldInfo
+						for k, fieldInfo := range inOpts {
+							if fieldInfo.Name == override.Name {
+								fieldInfo.SkipDefault = true
+								inOpts[k] = fieldInfo
+							}
+						}
 					}
 				}
 			}
@@ -173,14 +179,20 @@ func fillTemplates() {
 	for pkg, options := range optionsMap {
 		outBuf := new(bytes.Buffer)
 
-		var fieldNames []string
-		for _, v := range options {
-			fieldNames = append(fieldNames, v.Name)
+		var optionNames []string
+		for k := range options {
+			optionNames = append(optionNames, k)
 		}
-		sort.Strings(fieldNames)
+		sort.Slice(optionNames, func(i, j int) bool {
+			ni, nj := options[optionNames[i]].Name, options[optionNames[j]].Name
+			if ni != nj {
+				return ni < nj
+			}
+			return optionNames[i] < optionNames[j]
+		})
 
 		var fields []fieldInfo
-		for _, v := range fieldNames {
+		for _, v := range optionNames {
 			fields = append(fields, options[v])
 		}
 
@@ -576,7 +588,7 @@ import (
 )
 
 type {{ .Name }} struct { {{ range .Fields }}
-{{ .Name }}  {{ .FieldType }} `, "`json:\"{{ .ProtoName }},omitempty\"`", `{{ end }}
+{{ .Name }}  {{ .FieldType }} `, "`json:\"{{ .ProtoName }},omitempty{{ if .JsonString }},string{{ end }}\"`", `{{ end }}
 {{ if .CreateResponseTypes }}
 	response *api.Response
 {{ else if ( eq .Name "Error" ) }}
@@ -616,6 +628,7 @@ func (n {{ .Name }}DeleteResult) GetResponse() *api.Response {
 
 type {{ .Name }}ListResult struct {
 	Items []*{{ .Name }}
+	ListToken string ` + "`json:\"list_token,omitempty\"`" + `
 	response *api.Response
 }
 
@@ -623,6 +636,10 @@ func (n {{ .Name }}ListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n {{ .Name }}ListResult) GetListToken() string {
+	return n.ListToken
+}
+
 func (n {{ .Name }}ListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -673,6 +690,8 @@ type options struct {
 	withAutomaticVersioning bool
 	withSkipCurlOutput bool
 	withFilter string
+	withPageSize uint32
+	withListToken string
 	{{ if .RecursiveListing }} withRecursive bool {{ end }}
 }
 
@@ -696,6 +715,12 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	}
 	if opts.withFilter != "" {
 		opts.queryMap["filter"] = opts.withFilter
+	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withListToken != "" {
+		opts.queryMap["list_token"] = opts.withListToken
 	}{{ if .RecursiveListing }}
 	if opts.withRecursive {
 		opts.queryMap["recursive"] = strconv.FormatBool(opts.withRecursive)
@@ -729,6 +754,23 @@ func WithFilter(filter string) Option {
 		o.withFilter = strings.TrimSpace(filter)
 	}
 }
+
+// WithPageSize tells the API to return at most the given number of items
+// when listing. If there are more items, the list result contains a list
+// token which can be passed to WithListToken to fetch the next page.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.withPageSize = pageSize
+	}
+}
+
+// WithListToken tells the API to return the page of items following the page
+// the list token was returned with.
+func WithListToken(listToken string) Option {
+	return func(o *options) {
+		o.withListToken = listToken
+	}
+}
 {{ if .RecursiveListing }}
 // WithRecursive tells the API to use recursion for listing operations on this
 // resource
@@ -213,6 +213,7 @@ func TestSelfReadingDifferentOutputFields(t *testing.T) {
 	s, err := authmethodsservice.NewService(tc.Kms(),
 		tc.Controller().PasswordAuthRepoFn,
 		tc.Controller().OidcRepoFn,
+		tc.Controller().LdapRepoFn,
 		tc.Controller().IamRepoFn,
 		tc.Controller().AuthTokenRepoFn)
 	require.NoError(t, err)
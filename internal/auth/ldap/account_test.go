@@ -0,0 +1,94 @@
+package ldap
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	tests := []struct {
+		name            string
+		authMethodId    string
+		loginName       string
+		opts            []Option
+		wantLoginName   string
+		wantErrMatch    *errors.Template
+		wantErrContains string
+	}{
+		{
+			name:          "valid",
+			authMethodId:  "amldap_1234567890",
+			loginName:     " Alice ",
+			opts:          []Option{WithName("name"), WithDescription("desc"), WithFullName("Alice Smith"), WithEmail("alice@example.org")},
+			wantLoginName: "alice",
+		},
+		{
+			name:            "missing-auth-method",
+			loginName:       "alice",
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing auth method id",
+		},
+		{
+			name:            "missing-login-name",
+			authMethodId:    "amldap_1234567890",
+			loginName:       "  ",
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing login name",
+		},
+		{
+			name:            "email-too-long",
+			authMethodId:    "amldap_1234567890",
+			loginName:       "alice",
+			opts:            []Option{WithEmail(strings.Repeat("a", 320) + "@example.org")},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "email address is too long",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewAccount(ctx, tt.authMethodId, tt.loginName, tt.opts...)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "want err code: %q got: %q", tt.wantErrMatch.Code, err)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.authMethodId, got.AuthMethodId)
+			assert.Equal(tt.wantLoginName, got.LoginName)
+			assert.Equal("Alice Smith", got.FullName)
+			assert.Equal("alice@example.org", got.Email)
+			assert.True(proto.Equal(got.Account, got.Clone().Account))
+		})
+	}
+}
+
+func TestAccount_MemberOfGroupNames(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	assert, require := assert.New(t), require.New(t)
+	a := AllocAccount()
+	groups, err := a.MemberOfGroupNames(ctx)
+	require.NoError(err)
+	assert.Empty(groups)
+
+	a.MemberOfGroups = `["admins","developers"]`
+	groups, err = a.MemberOfGroupNames(ctx)
+	require.NoError(err)
+	assert.Equal([]string{"admins", "developers"}, groups)
+
+	a.MemberOfGroups = "admins"
+	_, err = a.MemberOfGroupNames(ctx)
+	require.Error(err)
+}
@@ -0,0 +1,278 @@
+package ldap
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultAuthMethodTableName defines the default table name for an AuthMethod
+const defaultAuthMethodTableName = "auth_ldap_method"
+
+// AuthMethod contains an LDAP auth method configuration. It is owned by a
+// scope. AuthMethods can have Accounts, ManagedGroups, Urls and Certificates.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string
+}
+
+// NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
+//
+// Urls are the ldap or ldaps urls of the directory servers. Connections are
+// attempted in the order the urls are given.
+//
+// When a BindDn and BindPassword are provided with WithBindCredential, they
+// are used when searching the directory for the user and the user's groups.
+// The bind password will be encrypted when stored in the database and an hmac
+// representation will also be stored whenever the password changes. The
+// password is not returned via the API, the hmac is returned so callers can
+// determine if it's been updated.
+//
+// Supports the options of WithName, WithDescription, WithUrls,
+// WithCertificates, WithStartTls, WithInsecureTls, WithDiscoverDn,
+// WithAnonGroupSearch, WithUpnDomain, WithUserDn, WithUserAttr,
+// WithUserFilter, WithGroupDn, WithGroupAttr, WithGroupFilter and
+// WithBindCredential and all other options are ignored.
+func NewAuthMethod(ctx context.Context, scopeId string, opt ...Option) (*AuthMethod, error) {
+	const op = "ldap.NewAuthMethod"
+	opts := getOpts(opt...)
+	a := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ScopeId:         scopeId,
+			Name:            opts.withName,
+			Description:     opts.withDescription,
+			StartTls:        opts.withStartTls,
+			InsecureTls:     opts.withInsecureTls,
+			DiscoverDn:      opts.withDiscoverDn,
+			AnonGroupSearch: opts.withAnonGroupSearch,
+			UpnDomain:       opts.withUpnDomain,
+			UserDn:          opts.withUserDn,
+			UserAttr:        opts.withUserAttr,
+			UserFilter:      opts.withUserFilter,
+			GroupDn:         opts.withGroupDn,
+			GroupAttr:       opts.withGroupAttr,
+			GroupFilter:     opts.withGroupFilter,
+			BindDn:          opts.withBindDn,
+			BindPassword:    opts.withBindPassword,
+		},
+	}
+	if len(opts.withUrls) > 0 {
+		a.Urls = make([]string, 0, len(opts.withUrls))
+		for _, u := range opts.withUrls {
+			if u == nil {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, "nil url")
+			}
+			a.Urls = append(a.Urls, u.String())
+		}
+	}
+	if len(opts.withCertificates) > 0 {
+		pems, err := encodeCertificates(ctx, opts.withCertificates...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		a.Certificates = pems
+	}
+	if err := a.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped.
+	}
+	return a, nil
+}
+
+// validate the AuthMethod. On success, it will return nil.
+func (a *AuthMethod) validate(ctx context.Context, caller errors.Op) error {
+	if a.ScopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing scope id")
+	}
+	for _, u := range a.Urls {
+		if err := validateUrl(ctx, u); err != nil {
+			return errors.Wrap(ctx, err, caller)
+		}
+	}
+	if len(strutil.RemoveDuplicates(a.Urls, false)) != len(a.Urls) {
+		return errors.New(ctx, errors.InvalidParameter, caller, "duplicate urls")
+	}
+	if len(a.Certificates) > 0 {
+		if _, err := ParseCertificates(ctx, a.Certificates...); err != nil {
+			return errors.Wrap(ctx, err, caller)
+		}
+	}
+	if a.BindPassword != "" && a.BindDn == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "bind password requires a bind dn")
+	}
+	return nil
+}
+
+// AllocAuthMethod makes an empty one in memory
+func AllocAuthMethod() AuthMethod {
+	return AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+// Clone an AuthMethod.
+func (a *AuthMethod) Clone() *AuthMethod {
+	cp := proto.Clone(a.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+// TableName returns the table name.
+func (a *AuthMethod) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return defaultAuthMethodTableName
+}
+
+// SetTableName sets the table name.
+func (a *AuthMethod) SetTableName(n string) {
+	a.tableName = n
+}
+
+// oplog will create oplog metadata for the AuthMethod.
+func (a *AuthMethod) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"ldap auth method"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{a.ScopeId},
+	}
+	return metadata
+}
+
+// encrypt the auth method's bind password before writing it to the db. It's
+// a no-op when the auth method has no bind password.
+func (a *AuthMethod) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "ldap.(AuthMethod).encrypt"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	if a.BindPassword == "" {
+		return nil
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	a.KeyId = cipher.KeyID()
+	if err := a.hmacBindPassword(ctx, cipher); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// decrypt the auth method's bind password after reading it from the db. It's
+// a no-op when the auth method has no encrypted bind password.
+func (a *AuthMethod) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "ldap.(AuthMethod).decrypt"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	if len(a.CtBindPassword) == 0 {
+		return nil
+	}
+	if err := structwrapping.UnwrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// hmacBindPassword before writing it to the db
+func (a *AuthMethod) hmacBindPassword(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "ldap.(AuthMethod).hmacBindPassword"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	reader, err := kms.NewDerivedReader(cipher, 32, []byte(a.PublicId), nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	key, _, err := ed25519.GenerateKey(reader)
+	if err != nil {
+		return errors.New(ctx, errors.Encrypt, op, "unable to generate derived key")
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(a.BindPassword))
+	a.BindPasswordHmac = base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	return nil
+}
+
+type convertedValues struct {
+	Urls  []interface{}
+	Certs []interface{}
+}
+
+// convertValueObjects converts the embedded value objects. It will return an
+// error if the AuthMethod's public id is not set.
+func (a *AuthMethod) convertValueObjects(ctx context.Context) (*convertedValues, error) {
+	const op = "ldap.(AuthMethod).convertValueObjects"
+	if a.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	urls, err := a.convertUrls(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	certs, err := a.convertCertificates(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &convertedValues{
+		Urls:  urls,
+		Certs: certs,
+	}, nil
+}
+
+// convertUrls converts the embedded urls from []string to []interface{} where
+// each slice element is a *Url with a connection priority matching its
+// position. It will return an error if the AuthMethod's public id is not set.
+func (a *AuthMethod) convertUrls(ctx context.Context) ([]interface{}, error) {
+	const op = "ldap.(AuthMethod).convertUrls"
+	if a.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]interface{}, 0, len(a.Urls))
+	for priority, u := range a.Urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "unable to parse url", errors.WithWrap(err))
+		}
+		obj, err := NewUrl(ctx, a.PublicId, priority+1, parsed)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// convertCertificates converts the embedded certificates from []string to
+// []interface{} where each slice element is a *Certificate. It will return an
+// error if the AuthMethod's public id is not set.
+func (a *AuthMethod) convertCertificates(ctx context.Context) ([]interface{}, error) {
+	const op = "ldap.(AuthMethod).convertCertificates"
+	if a.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]interface{}, 0, len(a.Certificates))
+	for _, cert := range a.Certificates {
+		obj, err := NewCertificate(ctx, a.PublicId, cert)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
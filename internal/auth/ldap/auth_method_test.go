@@ -0,0 +1,183 @@
+package ldap
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewAuthMethod(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	d := StartTestDirectoryWithTls(t)
+
+	tests := []struct {
+		name            string
+		scopeId         string
+		opts            []Option
+		wantErrMatch    *errors.Template
+		wantErrContains string
+		check           func(*testing.T, *AuthMethod)
+	}{
+		{
+			name:    "valid",
+			scopeId: "o_1234567890",
+			opts: []Option{
+				WithName("alice's ldap"),
+				WithDescription("the directory"),
+				WithUrls(TestConvertToUrls(t, "ldaps://ldap1.example.org", "ldap://ldap2.example.org")...),
+				WithCertificates(d.Cert()),
+				WithStartTls(),
+				WithUserDn("ou=people,dc=example,dc=org"),
+				WithUserAttr("uid"),
+				WithGroupDn("ou=groups,dc=example,dc=org"),
+				WithBindCredential("cn=admin,dc=example,dc=org", "admin-password"),
+			},
+			check: func(t *testing.T, am *AuthMethod) {
+				assert := assert.New(t)
+				assert.Equal("alice's ldap", am.Name)
+				assert.Equal("the directory", am.Description)
+				assert.Equal([]string{"ldaps://ldap1.example.org", "ldap://ldap2.example.org"}, am.Urls)
+				assert.Equal(TestEncodeCertificates(t, d.Cert()), am.Certificates)
+				assert.True(am.StartTls)
+				assert.False(am.InsecureTls)
+				assert.Equal("uid", am.UserAttr)
+				assert.Equal("cn=admin,dc=example,dc=org", am.BindDn)
+				assert.Equal("admin-password", am.BindPassword)
+			},
+		},
+		{
+			name:            "missing-scope",
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing scope id",
+		},
+		{
+			name:            "bad-url-scheme",
+			scopeId:         "o_1234567890",
+			opts:            []Option{WithUrls(TestConvertToUrls(t, "ldap://ldap.example.org")[0], &url.URL{Scheme: "https", Host: "ldap.example.org"})},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "scheme must be ldap or ldaps",
+		},
+		{
+			name:            "duplicate-urls",
+			scopeId:         "o_1234567890",
+			opts:            []Option{WithUrls(TestConvertToUrls(t, "ldap://ldap.example.org", "ldap://ldap.example.org")...)},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "duplicate urls",
+		},
+		{
+			name:            "bind-password-without-bind-dn",
+			scopeId:         "o_1234567890",
+			opts:            []Option{WithBindCredential("", "admin-password")},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "bind password requires a bind dn",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewAuthMethod(ctx, tt.scopeId, tt.opts...)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "want err code: %q got: %q", tt.wantErrMatch.Code, err)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.scopeId, got.ScopeId)
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestAuthMethod_Clone(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	assert, require := assert.New(t), require.New(t)
+	am, err := NewAuthMethod(ctx, "o_1234567890", WithName("test"), WithUrls(TestConvertToUrls(t, "ldap://ldap.example.org")...))
+	require.NoError(err)
+	cp := am.Clone()
+	assert.True(proto.Equal(am.AuthMethod, cp.AuthMethod))
+	cp.Urls[0] = "ldap://changed.example.org"
+	assert.Equal("ldap://ldap.example.org", am.Urls[0])
+}
+
+func TestAuthMethod_SetTableName(t *testing.T) {
+	t.Parallel()
+	am := AllocAuthMethod()
+	assert.Equal(t, defaultAuthMethodTableName, am.TableName())
+	am.SetTableName("custom")
+	assert.Equal(t, "custom", am.TableName())
+	am.SetTableName("")
+	assert.Equal(t, defaultAuthMethodTableName, am.TableName())
+}
+
+func Test_encrypt_decrypt_hmac(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	assert, require := assert.New(t), require.New(t)
+	wrapper := db.TestWrapper(t)
+
+	am, err := NewAuthMethod(ctx, "o_1234567890", WithBindCredential("cn=admin,dc=example,dc=org", "admin-password"))
+	require.NoError(err)
+	am.PublicId = "amldap_1234567890"
+	require.NoError(am.encrypt(ctx, wrapper))
+	assert.NotEmpty(am.CtBindPassword)
+	assert.NotEmpty(am.BindPasswordHmac)
+	assert.Equal(wrapper.KeyID(), am.KeyId)
+
+	cp := am.Clone()
+	cp.BindPassword = ""
+	require.NoError(cp.decrypt(ctx, wrapper))
+	assert.Equal("admin-password", cp.BindPassword)
+
+	// no bind password is a no-op
+	noPw, err := NewAuthMethod(ctx, "o_1234567890")
+	require.NoError(err)
+	require.NoError(noPw.encrypt(ctx, wrapper))
+	assert.Empty(noPw.CtBindPassword)
+	assert.Empty(noPw.BindPasswordHmac)
+	require.NoError(noPw.decrypt(ctx, wrapper))
+
+	err = am.encrypt(ctx, nil)
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+}
+
+func Test_convertValueObjects(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	assert, require := assert.New(t), require.New(t)
+	d := StartTestDirectoryWithTls(t)
+
+	am, err := NewAuthMethod(ctx, "o_1234567890",
+		WithUrls(TestConvertToUrls(t, "ldaps://ldap1.example.org", "ldaps://ldap2.example.org")...),
+		WithCertificates(d.Cert()))
+	require.NoError(err)
+
+	_, err = am.convertValueObjects(ctx)
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+
+	am.PublicId = "amldap_1234567890"
+	vo, err := am.convertValueObjects(ctx)
+	require.NoError(err)
+	require.Len(vo.Urls, 2)
+	for i, u := range vo.Urls {
+		assert.Equal(am.Urls[i], u.(*Url).GetUrl())
+		assert.Equal(uint32(i+1), u.(*Url).GetConnectionPriority())
+		assert.Equal(am.PublicId, u.(*Url).GetLdapMethodId())
+	}
+	require.Len(vo.Certs, 1)
+	assert.Equal(am.Certificates[0], vo.Certs[0].(*Certificate).GetCert())
+}
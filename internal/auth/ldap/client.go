@@ -0,0 +1,387 @@
+package ldap
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+const (
+	// DefaultUserAttr is the attribute matched against the login name when
+	// the auth method doesn't specify a UserAttr.
+	DefaultUserAttr = "cn"
+
+	// DefaultGroupAttr is the attribute of the group entries that's used as
+	// the group's name when the auth method doesn't specify a GroupAttr.
+	DefaultGroupAttr = "cn"
+
+	// DefaultUserFilter is the filter used to search for the user's entry
+	// when the auth method doesn't specify a UserFilter.
+	DefaultUserFilter = "({{.UserAttr}}={{.Username}})"
+
+	// DefaultGroupFilter is the filter used to search for the user's groups
+	// when the auth method doesn't specify a GroupFilter.
+	DefaultGroupFilter = "(|(memberUid={{.Username}})(member={{.UserDN}})(uniqueMember={{.UserDN}}))"
+
+	// dialTimeout is the timeout for establishing a connection with each of the
+	// auth method's urls.
+	dialTimeout = 10 * time.Second
+)
+
+// directoryUser is the information retrieved from the directory for a user
+// that successfully authenticated.
+type directoryUser struct {
+	Dn       string
+	FullName string
+	Email    string
+	Groups   []string
+}
+
+// filterData is the data available to the user and group filter templates.
+type filterData struct {
+	UserAttr string
+	Username string
+	UserDN   string
+}
+
+// authenticateUser authenticates the loginName and password against the
+// directory configured by the auth method and, when successful, returns the
+// user's entry along with the names of the groups the user belongs to. If the
+// user is not found or the password does not match, it returns nil, nil.
+//
+// The user's DN is determined by, in order of precedence:
+//
+// * searching the UserDn with the UserFilter when a BindDn is configured.
+//
+// * searching the UserDn with the UserFilter after an anonymous bind when
+// DiscoverDn is set.
+//
+// * binding with the userPrincipalName of loginName@UpnDomain when the
+// UpnDomain is set.
+//
+// * composing the DN as UserAttr=loginName,UserDn.
+//
+// The user's groups are found by searching the GroupDn with the GroupFilter
+// when the GroupDn is set; otherwise the memberOf attribute of the user's
+// entry is used.
+func authenticateUser(ctx context.Context, am *AuthMethod, loginName, password string) (*directoryUser, error) {
+	const op = "ldap.authenticateUser"
+	switch {
+	case am == nil || am.AuthMethod == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	case len(am.Urls) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "auth method has no urls")
+	case loginName == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing login name")
+	case password == "":
+		// an empty password would result in an unauthenticated bind which
+		// many directories treat as a successful bind.
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing password")
+	}
+
+	conn, err := dial(ctx, am)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer conn.Close()
+
+	userAttr := am.UserAttr
+	if userAttr == "" {
+		userAttr = DefaultUserAttr
+	}
+
+	var userDn string
+	switch {
+	case am.BindDn != "" || am.DiscoverDn:
+		if am.BindDn != "" {
+			if err := conn.Bind(am.BindDn, am.BindPassword); err != nil {
+				return nil, errors.New(ctx, errors.Unknown, op, "unable to bind with the bind dn", errors.WithWrap(err))
+			}
+		} else {
+			if err := conn.UnauthenticatedBind(""); err != nil {
+				return nil, errors.New(ctx, errors.Unknown, op, "unable to bind anonymously to discover the user dn", errors.WithWrap(err))
+			}
+		}
+		userDn, err = searchUserDn(ctx, conn, am, userAttr, loginName)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if userDn == "" {
+			// authentication failed, user not found
+			return nil, nil
+		}
+	case am.UpnDomain != "":
+		userDn = fmt.Sprintf("%s@%s", escapeDnValue(loginName), am.UpnDomain)
+	default:
+		userDn = fmt.Sprintf("%s=%s", userAttr, escapeDnValue(loginName))
+		if am.UserDn != "" {
+			userDn = fmt.Sprintf("%s,%s", userDn, am.UserDn)
+		}
+	}
+
+	if err := conn.Bind(userDn, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			// authentication failed, password does not match
+			return nil, nil
+		}
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to bind as user", errors.WithWrap(err))
+	}
+
+	user := &directoryUser{
+		Dn: userDn,
+	}
+	// a upn isn't a DN, so the user's entry can't be read directly and the
+	// entry's attributes are only available when it can be searched for.
+	if am.UpnDomain == "" || am.BindDn != "" || am.DiscoverDn {
+		entry, err := readEntry(ctx, conn, userDn, "cn", "displayName", "mail", "memberOf")
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if entry != nil {
+			user.FullName = entry.GetAttributeValue("displayName")
+			if user.FullName == "" {
+				user.FullName = entry.GetAttributeValue("cn")
+			}
+			user.Email = entry.GetAttributeValue("mail")
+			if am.GroupDn == "" {
+				for _, memberOf := range entry.GetAttributeValues("memberOf") {
+					if name := firstRdnValue(memberOf); name != "" {
+						user.Groups = append(user.Groups, name)
+					}
+				}
+			}
+		}
+	}
+
+	if am.GroupDn != "" {
+		switch {
+		case am.AnonGroupSearch:
+			if err := conn.UnauthenticatedBind(""); err != nil {
+				return nil, errors.New(ctx, errors.Unknown, op, "unable to bind anonymously to search for groups", errors.WithWrap(err))
+			}
+		case am.BindDn != "":
+			if err := conn.Bind(am.BindDn, am.BindPassword); err != nil {
+				return nil, errors.New(ctx, errors.Unknown, op, "unable to bind with the bind dn to search for groups", errors.WithWrap(err))
+			}
+		}
+		user.Groups, err = searchGroups(ctx, conn, am, userDn, loginName)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	return user, nil
+}
+
+// dial connects to the first of the auth method's urls that accepts a
+// connection, upgrading ldap urls with StartTLS when configured.
+func dial(ctx context.Context, am *AuthMethod) (*ldap.Conn, error) {
+	const op = "ldap.dial"
+	var pool *x509.CertPool
+	if len(am.Certificates) > 0 {
+		certs, err := ParseCertificates(ctx, am.Certificates...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		pool = x509.NewCertPool()
+		for _, c := range certs {
+			pool.AddCert(c)
+		}
+	}
+
+	var lastErr error
+	for _, rawUrl := range am.Urls {
+		u, err := url.Parse(rawUrl)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		tlsConfig := &tls.Config{
+			ServerName:         u.Hostname(),
+			RootCAs:            pool,
+			InsecureSkipVerify: am.InsecureTls,
+			MinVersion:         tls.VersionTLS12,
+		}
+		conn, err := ldap.DialURL(
+			rawUrl,
+			ldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}),
+			ldap.DialWithTLSConfig(tlsConfig),
+		)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if am.StartTls && strings.EqualFold(u.Scheme, "ldap") {
+			if err := conn.StartTLS(tlsConfig); err != nil {
+				conn.Close()
+				lastErr = err
+				continue
+			}
+		}
+		return conn, nil
+	}
+	return nil, errors.New(ctx, errors.Unavailable, op, "unable to connect to any of the auth method's urls", errors.WithWrap(lastErr))
+}
+
+// searchUserDn searches the auth method's UserDn for the DN of the user
+// with the loginName. It returns an empty DN when the user is not found.
+func searchUserDn(ctx context.Context, conn *ldap.Conn, am *AuthMethod, userAttr, loginName string) (string, error) {
+	const op = "ldap.searchUserDn"
+	userFilter := am.UserFilter
+	if userFilter == "" {
+		userFilter = DefaultUserFilter
+	}
+	filter, err := renderFilter(ctx, userFilter, filterData{
+		UserAttr: userAttr,
+		Username: ldap.EscapeFilter(loginName),
+	})
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	result, err := conn.Search(ldap.NewSearchRequest(
+		am.UserDn,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		[]string{"dn"},
+		nil,
+	))
+	if err != nil {
+		return "", errors.New(ctx, errors.Unknown, op, "unable to search for user", errors.WithWrap(err))
+	}
+	switch len(result.Entries) {
+	case 0:
+		return "", nil
+	case 1:
+		return result.Entries[0].DN, nil
+	default:
+		return "", errors.New(ctx, errors.MultipleRecords, op, "user search matched more than one entry")
+	}
+}
+
+// searchGroups searches the auth method's GroupDn for the groups the user
+// belongs to and returns their names.
+func searchGroups(ctx context.Context, conn *ldap.Conn, am *AuthMethod, userDn, loginName string) ([]string, error) {
+	const op = "ldap.searchGroups"
+	groupFilter := am.GroupFilter
+	if groupFilter == "" {
+		groupFilter = DefaultGroupFilter
+	}
+	groupAttr := am.GroupAttr
+	if groupAttr == "" {
+		groupAttr = DefaultGroupAttr
+	}
+	filter, err := renderFilter(ctx, groupFilter, filterData{
+		Username: ldap.EscapeFilter(loginName),
+		UserDN:   ldap.EscapeFilter(userDn),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	result, err := conn.Search(ldap.NewSearchRequest(
+		am.GroupDn,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		[]string{groupAttr},
+		nil,
+	))
+	if err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to search for groups", errors.WithWrap(err))
+	}
+	groups := make([]string, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		if name := e.GetAttributeValue(groupAttr); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// readEntry reads the entry with the dn. It returns nil when the entry is not
+// readable by the currently bound user.
+func readEntry(ctx context.Context, conn *ldap.Conn, dn string, attrs ...string) (*ldap.Entry, error) {
+	const op = "ldap.readEntry"
+	result, err := conn.Search(ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		attrs,
+		nil,
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) || ldap.IsErrorWithCode(err, ldap.LDAPResultInsufficientAccessRights) {
+			return nil, nil
+		}
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to read user entry", errors.WithWrap(err))
+	}
+	if len(result.Entries) != 1 {
+		return nil, nil
+	}
+	return result.Entries[0], nil
+}
+
+// renderFilter renders the filter template with the data.
+func renderFilter(ctx context.Context, filter string, data filterData) (string, error) {
+	const op = "ldap.renderFilter"
+	t, err := template.New("filter").Parse(filter)
+	if err != nil {
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unable to parse filter template %q", filter), errors.WithWrap(err))
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unable to render filter template %q", filter), errors.WithWrap(err))
+	}
+	return buf.String(), nil
+}
+
+// escapeDnValue escapes the special characters of an attribute value so it
+// can be used in a DN as described in RFC 4514.
+func escapeDnValue(v string) string {
+	var b strings.Builder
+	for i, r := range v {
+		switch {
+		case r == ' ' && (i == 0 || i == len(v)-1):
+			b.WriteString(`\ `)
+		case r == '#' && i == 0:
+			b.WriteString(`\#`)
+		case strings.ContainsRune(`,+"\<>;=`, r):
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// firstRdnValue returns the value of the first RDN of the dn, which is
+// commonly the name of a group in a memberOf attribute.
+func firstRdnValue(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) == 0 || len(parsed.RDNs[0].Attributes) == 0 {
+		return ""
+	}
+	return parsed.RDNs[0].Attributes[0].Value
+}
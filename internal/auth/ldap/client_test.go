@@ -0,0 +1,302 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_authenticateUser(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	const (
+		userDn  = "ou=people,dc=example,dc=org"
+		groupDn = "ou=groups,dc=example,dc=org"
+		bindDn  = "cn=admin,dc=example,dc=org"
+		bindPw  = "admin-password"
+	)
+	setup := func(d *TestDirectory) {
+		d.AddUser(bindDn, bindPw, nil)
+		d.AddUser("cn=alice,"+userDn, "alice-password", map[string][]string{
+			"cn":                {"alice"},
+			"uid":               {"alice.smith"},
+			"displayName":       {"Alice Smith"},
+			"mail":              {"alice@example.org"},
+			"userPrincipalName": {"alice@example.org"},
+			"memberOf":          {"cn=admins," + groupDn, "cn=developers," + groupDn},
+		})
+		d.AddUser("cn=bob,"+userDn, "bob-password", map[string][]string{
+			"cn":  {"bob"},
+			"uid": {"bob.jones"},
+		})
+		d.AddEntry("cn=admins,"+groupDn, map[string][]string{
+			"cn":     {"admins"},
+			"member": {"cn=alice," + userDn},
+		})
+		d.AddEntry("cn=operators,"+groupDn, map[string][]string{
+			"cn":        {"operators"},
+			"memberUid": {"alice.smith", "bob.jones"},
+		})
+	}
+	plain := StartTestDirectory(t)
+	setup(plain)
+	anon := StartTestDirectory(t)
+	anon.AllowAnonymousBind(true)
+	setup(anon)
+	secure := StartTestDirectoryWithTls(t)
+	setup(secure)
+
+	testAm := func(urls []string, fn func(*store.AuthMethod)) *AuthMethod {
+		am := &AuthMethod{AuthMethod: &store.AuthMethod{Urls: urls, UserDn: userDn}}
+		if fn != nil {
+			fn(am.AuthMethod)
+		}
+		return am
+	}
+
+	tests := []struct {
+		name            string
+		am              *AuthMethod
+		loginName       string
+		password        string
+		want            *directoryUser
+		wantNil         bool
+		wantErrMatch    *errors.Template
+		wantErrContains string
+	}{
+		{
+			name:      "composed-dn-with-member-of-groups",
+			am:        testAm([]string{plain.Url()}, nil),
+			loginName: "alice",
+			password:  "alice-password",
+			want: &directoryUser{
+				Dn:       "cn=alice," + userDn,
+				FullName: "Alice Smith",
+				Email:    "alice@example.org",
+				Groups:   []string{"admins", "developers"},
+			},
+		},
+		{
+			name:      "composed-dn-bad-password",
+			am:        testAm([]string{plain.Url()}, nil),
+			loginName: "alice",
+			password:  "bad-password",
+			wantNil:   true,
+		},
+		{
+			name:      "composed-dn-unknown-user",
+			am:        testAm([]string{plain.Url()}, nil),
+			loginName: "eve",
+			password:  "eve-password",
+			wantNil:   true,
+		},
+		{
+			name: "bind-dn-search-with-group-dn",
+			am: testAm([]string{plain.Url()}, func(am *store.AuthMethod) {
+				am.UserAttr = "uid"
+				am.GroupDn = groupDn
+				am.BindDn = bindDn
+				am.BindPassword = bindPw
+			}),
+			loginName: "alice.smith",
+			password:  "alice-password",
+			want: &directoryUser{
+				Dn:       "cn=alice," + userDn,
+				FullName: "Alice Smith",
+				Email:    "alice@example.org",
+				Groups:   []string{"admins", "operators"},
+			},
+		},
+		{
+			name: "bind-dn-search-user-not-found",
+			am: testAm([]string{plain.Url()}, func(am *store.AuthMethod) {
+				am.UserAttr = "uid"
+				am.BindDn = bindDn
+				am.BindPassword = bindPw
+			}),
+			loginName: "eve",
+			password:  "eve-password",
+			wantNil:   true,
+		},
+		{
+			name: "bind-dn-bad-bind-password",
+			am: testAm([]string{plain.Url()}, func(am *store.AuthMethod) {
+				am.BindDn = bindDn
+				am.BindPassword = "bad-password"
+			}),
+			loginName:       "alice",
+			password:        "alice-password",
+			wantErrMatch:    errors.T(errors.Unknown),
+			wantErrContains: "unable to bind with the bind dn",
+		},
+		{
+			name: "user-filter-and-group-filter",
+			am: testAm([]string{plain.Url()}, func(am *store.AuthMethod) {
+				am.UserFilter = "(&(objectClass=*)(uid={{.Username}}))"
+				am.GroupDn = groupDn
+				am.GroupFilter = "(memberUid={{.Username}})"
+				am.BindDn = bindDn
+				am.BindPassword = bindPw
+			}),
+			loginName: "bob.jones",
+			password:  "bob-password",
+			want: &directoryUser{
+				Dn:       "cn=bob," + userDn,
+				FullName: "bob",
+				Groups:   []string{"operators"},
+			},
+		},
+		{
+			name: "discover-dn-with-anonymous-group-search",
+			am: testAm([]string{anon.Url()}, func(am *store.AuthMethod) {
+				am.UserAttr = "uid"
+				am.DiscoverDn = true
+				am.GroupDn = groupDn
+				am.AnonGroupSearch = true
+			}),
+			loginName: "bob.jones",
+			password:  "bob-password",
+			want: &directoryUser{
+				Dn:       "cn=bob," + userDn,
+				FullName: "bob",
+				Groups:   []string{"operators"},
+			},
+		},
+		{
+			name: "discover-dn-without-anonymous-bind",
+			am: testAm([]string{plain.Url()}, func(am *store.AuthMethod) {
+				am.DiscoverDn = true
+			}),
+			loginName:       "alice",
+			password:        "alice-password",
+			wantErrMatch:    errors.T(errors.Unknown),
+			wantErrContains: "unable to bind anonymously",
+		},
+		{
+			name: "upn-domain",
+			am: testAm([]string{plain.Url()}, func(am *store.AuthMethod) {
+				am.UpnDomain = "example.org"
+			}),
+			loginName: "alice",
+			password:  "alice-password",
+			want: &directoryUser{
+				Dn: "alice@example.org",
+			},
+		},
+		{
+			name: "ldaps-with-certificate",
+			am: testAm([]string{secure.Url()}, func(am *store.AuthMethod) {
+				am.Certificates = TestEncodeCertificates(t, secure.Cert())
+			}),
+			loginName: "bob",
+			password:  "bob-password",
+			want: &directoryUser{
+				Dn:       "cn=bob," + userDn,
+				FullName: "bob",
+			},
+		},
+		{
+			name: "ldaps-insecure-tls",
+			am: testAm([]string{secure.Url()}, func(am *store.AuthMethod) {
+				am.InsecureTls = true
+			}),
+			loginName: "bob",
+			password:  "bob-password",
+			want: &directoryUser{
+				Dn:       "cn=bob," + userDn,
+				FullName: "bob",
+			},
+		},
+		{
+			name:            "ldaps-untrusted-certificate",
+			am:              testAm([]string{secure.Url()}, nil),
+			loginName:       "bob",
+			password:        "bob-password",
+			wantErrMatch:    errors.T(errors.Unavailable),
+			wantErrContains: "unable to connect",
+		},
+		{
+			name:      "failover-to-second-url",
+			am:        testAm([]string{"ldap://127.0.0.1:1", plain.Url()}, nil),
+			loginName: "bob",
+			password:  "bob-password",
+			want: &directoryUser{
+				Dn:       "cn=bob," + userDn,
+				FullName: "bob",
+			},
+		},
+		{
+			name:            "missing-password",
+			am:              testAm([]string{plain.Url()}, nil),
+			loginName:       "alice",
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing password",
+		},
+		{
+			name:            "missing-urls",
+			am:              testAm(nil, nil),
+			loginName:       "alice",
+			password:        "alice-password",
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "auth method has no urls",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := authenticateUser(ctx, tt.am, tt.loginName, tt.password)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "want err code: %q got: %q", tt.wantErrMatch.Code, err)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			if tt.wantNil {
+				assert.Nil(got)
+				return
+			}
+			require.NotNil(got)
+			assert.Equal(tt.want.Dn, got.Dn)
+			assert.Equal(tt.want.FullName, got.FullName)
+			assert.Equal(tt.want.Email, got.Email)
+			assert.ElementsMatch(tt.want.Groups, got.Groups)
+		})
+	}
+}
+
+func Test_escapeDnValue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "alice", want: "alice"},
+		{in: "smith, alice", want: `smith\, alice`},
+		{in: " alice ", want: `\ alice\ `},
+		{in: "#alice", want: `\#alice`},
+		{in: `a+b="c"<d>;e\f`, want: `a\+b\=\"c\"\<d\>\;e\\f`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, escapeDnValue(tt.in))
+	}
+}
+
+func Test_renderFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	got, err := renderFilter(ctx, DefaultUserFilter, filterData{UserAttr: "uid", Username: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "(uid=alice)", got)
+
+	_, err = renderFilter(ctx, "({{.Bad", filterData{})
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
@@ -0,0 +1,40 @@
+package ldap
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Ids(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	t.Run(AuthMethodPrefix, func(t *testing.T) {
+		id, err := newAuthMethodId(ctx)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, AuthMethodPrefix+"_"))
+	})
+	t.Run(AccountPrefix, func(t *testing.T) {
+		id, err := newAccountId(ctx, "public-id", "test-login-name")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, AccountPrefix+"_"))
+
+		again, err := newAccountId(ctx, "public-id", "test-login-name")
+		require.NoError(t, err)
+		assert.Equal(t, id, again)
+
+		_, err = newAccountId(ctx, "", "test-login-name")
+		assert.Error(t, err)
+		_, err = newAccountId(ctx, "public-id", "")
+		assert.Error(t, err)
+	})
+	t.Run(intglobals.LdapManagedGroupPrefix, func(t *testing.T) {
+		id, err := newManagedGroupId(ctx)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, intglobals.LdapManagedGroupPrefix+"_"))
+	})
+}
@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultManagedGroupTableName defines the default table name for a Managed Group
+const defaultManagedGroupTableName = "auth_ldap_managed_group"
+
+// ManagedGroup contains an LDAP managed group. It is assigned to an LDAP
+// AuthMethod and updates/deletes to that AuthMethod are cascaded to its
+// Managed Groups. An account is a member of the managed group when the
+// account belongs to any of the managed group's directory GroupNames.
+type ManagedGroup struct {
+	*store.ManagedGroup
+	tableName string
+}
+
+// NewManagedGroup creates a new in memory ManagedGroup assigned to LDAP
+// AuthMethod. Supported options are WithName and WithDescription.
+func NewManagedGroup(ctx context.Context, authMethodId string, groupNames []string, opt ...Option) (*ManagedGroup, error) {
+	const op = "ldap.NewManagedGroup"
+	opts := getOpts(opt...)
+	mg := &ManagedGroup{
+		ManagedGroup: &store.ManagedGroup{
+			AuthMethodId: authMethodId,
+			Name:         opts.withName,
+			Description:  opts.withDescription,
+		},
+	}
+	if err := mg.SetGroupNames(ctx, groupNames); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := mg.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped.
+	}
+	return mg, nil
+}
+
+// validate the Managed Group.  On success, it will return nil.
+func (mg *ManagedGroup) validate(ctx context.Context, caller errors.Op) error {
+	if mg.AuthMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing auth method id")
+	}
+	names, err := mg.GroupNameList(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
+	if len(names) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing group names")
+	}
+	return nil
+}
+
+// SetGroupNames encodes the directory group names of the managed group.
+func (mg *ManagedGroup) SetGroupNames(ctx context.Context, groupNames []string) error {
+	const op = "ldap.(ManagedGroup).SetGroupNames"
+	if len(groupNames) == 0 {
+		mg.GroupNames = ""
+		return nil
+	}
+	for _, n := range groupNames {
+		if strings.TrimSpace(n) == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "empty group name")
+		}
+	}
+	encoded, err := json.Marshal(groupNames)
+	if err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "unable to encode group names", errors.WithWrap(err))
+	}
+	mg.GroupNames = string(encoded)
+	return nil
+}
+
+// GroupNameList returns the decoded directory group names of the managed
+// group.
+func (mg *ManagedGroup) GroupNameList(ctx context.Context) ([]string, error) {
+	const op = "ldap.(ManagedGroup).GroupNameList"
+	if mg.GroupNames == "" {
+		return nil, nil
+	}
+	var names []string
+	if err := json.Unmarshal([]byte(mg.GroupNames), &names); err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "unable to decode group names", errors.WithWrap(err))
+	}
+	return names, nil
+}
+
+// matches reports whether any of the directory groups match one of the
+// managed group's group names. Group names are compared case insensitively.
+func (mg *ManagedGroup) matches(ctx context.Context, groups []string) (bool, error) {
+	const op = "ldap.(ManagedGroup).matches"
+	names, err := mg.GroupNameList(ctx)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	for _, n := range names {
+		for _, g := range groups {
+			if strings.EqualFold(n, g) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// AllocManagedGroup makes an empty one in memory
+func AllocManagedGroup() *ManagedGroup {
+	return &ManagedGroup{
+		ManagedGroup: &store.ManagedGroup{},
+	}
+}
+
+// Clone a ManagedGroup.
+func (mg *ManagedGroup) Clone() *ManagedGroup {
+	cp := proto.Clone(mg.ManagedGroup)
+	return &ManagedGroup{
+		ManagedGroup: cp.(*store.ManagedGroup),
+	}
+}
+
+// TableName returns the table name.
+func (mg *ManagedGroup) TableName() string {
+	if mg.tableName != "" {
+		return mg.tableName
+	}
+	return defaultManagedGroupTableName
+}
+
+// SetTableName sets the table name.
+func (mg *ManagedGroup) SetTableName(n string) {
+	mg.tableName = n
+}
+
+// oplog will create oplog metadata for the ManagedGroup.
+func (mg *ManagedGroup) oplog(op oplog.OpType, authMethodScopeId string) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{mg.GetPublicId()},
+		"resource-type":      []string{"ldap managed group"},
+		"op-type":            []string{op.String()},
+	}
+	if mg.AuthMethodId != "" {
+		metadata["auth-method-id"] = []string{mg.AuthMethodId}
+	}
+	if authMethodScopeId != "" {
+		metadata["scope-id"] = []string{authMethodScopeId}
+	}
+	return metadata
+}
@@ -0,0 +1,67 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ManagedGroups_RepoValidate(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	assert := assert.New(t)
+	const op = "test"
+	mg := AllocManagedGroup()
+	t.Run("empty auth method", func(t *testing.T) {
+		assert.Contains(mg.validate(ctx, op).Error(), errors.New(ctx, errors.InvalidParameter, op, "missing auth method id").Error(), errors.WithoutEvent())
+	})
+	t.Run("empty group names", func(t *testing.T) {
+		mg.AuthMethodId = "amldap_1234567890"
+		assert.Contains(mg.validate(ctx, op).Error(), errors.New(ctx, errors.InvalidParameter, op, "missing group names").Error(), errors.WithoutEvent())
+	})
+	t.Run("bad group names", func(t *testing.T) {
+		mg.AuthMethodId = "amldap_1234567890"
+		mg.GroupNames = "admins"
+		assert.Contains(mg.validate(ctx, op).Error(), "unable to decode group names")
+	})
+	t.Run("valid", func(t *testing.T) {
+		mg.AuthMethodId = "amldap_1234567890"
+		assert.NoError(mg.SetGroupNames(ctx, []string{"admins"}))
+		assert.NoError(mg.validate(ctx, op))
+	})
+}
+
+func TestManagedGroup_GroupNames(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	assert, require := assert.New(t), require.New(t)
+
+	mg, err := NewManagedGroup(ctx, "amldap_1234567890", []string{"admins", "Developers"}, WithName("name"), WithDescription("desc"))
+	require.NoError(err)
+	assert.Equal("name", mg.Name)
+	assert.Equal("desc", mg.Description)
+	names, err := mg.GroupNameList(ctx)
+	require.NoError(err)
+	assert.Equal([]string{"admins", "Developers"}, names)
+
+	match, err := mg.matches(ctx, []string{"operators", "developers"})
+	require.NoError(err)
+	assert.True(match)
+	match, err = mg.matches(ctx, []string{"operators"})
+	require.NoError(err)
+	assert.False(match)
+	match, err = mg.matches(ctx, nil)
+	require.NoError(err)
+	assert.False(match)
+
+	err = mg.SetGroupNames(ctx, []string{"admins", " "})
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	_, err = NewManagedGroup(ctx, "amldap_1234567890", nil)
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+}
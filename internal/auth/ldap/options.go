@@ -0,0 +1,229 @@
+package ldap
+
+import (
+	"crypto/x509"
+	"net/url"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName               string
+	withDescription        string
+	withLimit              int
+	withStartPageAfterItem *string
+	withOrderByCreateTime  bool
+	ascending              bool
+	withPublicId           string
+	withUrls               []*url.URL
+	withCertificates       []*x509.Certificate
+	withStartTls           bool
+	withInsecureTls        bool
+	withDiscoverDn         bool
+	withAnonGroupSearch    bool
+	withUpnDomain          string
+	withUserDn             string
+	withUserAttr           string
+	withUserFilter         string
+	withGroupDn            string
+	withGroupAttr          string
+	withGroupFilter        string
+	withBindDn             string
+	withBindPassword       string
+	withEmail              string
+	withFullName           string
+	withReader             db.Reader
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
+// WithOrderByCreateTime provides an option to specify ordering by the
+// CreateTime field.
+func WithOrderByCreateTime(ascending bool) Option {
+	return func(o *options) {
+		o.withOrderByCreateTime = true
+		o.ascending = ascending
+	}
+}
+
+// WithPublicId provides an option for passing a public id to the operation
+func WithPublicId(publicId string) Option {
+	return func(o *options) {
+		o.withPublicId = publicId
+	}
+}
+
+// WithUrls provides optional ldap server urls, in the order connections to
+// them should be attempted.
+func WithUrls(urls ...*url.URL) Option {
+	return func(o *options) {
+		o.withUrls = urls
+	}
+}
+
+// WithCertificates provides optional certificates.
+func WithCertificates(certs ...*x509.Certificate) Option {
+	return func(o *options) {
+		o.withCertificates = certs
+	}
+}
+
+// WithStartTls provides an option to issue a StartTLS command after
+// establishing an unencrypted connection.
+func WithStartTls() Option {
+	return func(o *options) {
+		o.withStartTls = true
+	}
+}
+
+// WithInsecureTls provides an option to skip verification of the server's
+// certificate.
+func WithInsecureTls() Option {
+	return func(o *options) {
+		o.withInsecureTls = true
+	}
+}
+
+// WithDiscoverDn provides an option to use an anonymous bind to discover the
+// user's distinguished name.
+func WithDiscoverDn() Option {
+	return func(o *options) {
+		o.withDiscoverDn = true
+	}
+}
+
+// WithAnonGroupSearch provides an option to use an anonymous bind when
+// searching for the user's groups.
+func WithAnonGroupSearch() Option {
+	return func(o *options) {
+		o.withAnonGroupSearch = true
+	}
+}
+
+// WithUpnDomain provides an optional userPrincipalName domain.
+func WithUpnDomain(domain string) Option {
+	return func(o *options) {
+		o.withUpnDomain = domain
+	}
+}
+
+// WithUserDn provides an optional base dn for user searches.
+func WithUserDn(dn string) Option {
+	return func(o *options) {
+		o.withUserDn = dn
+	}
+}
+
+// WithUserAttr provides an optional user attribute which is matched against
+// the login name.
+func WithUserAttr(attr string) Option {
+	return func(o *options) {
+		o.withUserAttr = attr
+	}
+}
+
+// WithUserFilter provides an optional user search filter template.
+func WithUserFilter(filter string) Option {
+	return func(o *options) {
+		o.withUserFilter = filter
+	}
+}
+
+// WithGroupDn provides an optional base dn for group searches.
+func WithGroupDn(dn string) Option {
+	return func(o *options) {
+		o.withGroupDn = dn
+	}
+}
+
+// WithGroupAttr provides an optional group attribute which names the group.
+func WithGroupAttr(attr string) Option {
+	return func(o *options) {
+		o.withGroupAttr = attr
+	}
+}
+
+// WithGroupFilter provides an optional group search filter template.
+func WithGroupFilter(filter string) Option {
+	return func(o *options) {
+		o.withGroupFilter = filter
+	}
+}
+
+// WithBindCredential provides optional credentials used when searching the
+// directory.
+func WithBindCredential(dn, password string) Option {
+	return func(o *options) {
+		o.withBindDn = dn
+		o.withBindPassword = password
+	}
+}
+
+// WithEmail provides an optional email address for the account.
+func WithEmail(email string) Option {
+	return func(o *options) {
+		o.withEmail = email
+	}
+}
+
+// WithFullName provides an optional full name for the account.
+func WithFullName(n string) Option {
+	return func(o *options) {
+		o.withFullName = n
+	}
+}
+
+// WithReader provides an option for specifying a reader to use for the
+// operation.
+func WithReader(reader db.Reader) Option {
+	return func(o *options) {
+		o.withReader = reader
+	}
+}
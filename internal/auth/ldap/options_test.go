@@ -0,0 +1,195 @@
+package ldap
+
+import (
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_GetOpts provides unit tests for GetOpts and all the options
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithName", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		// test default of 0
+		opts := getOpts()
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 0
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithLimit(-1))
+		testOpts = getDefaultOptions()
+		testOpts.withLimit = -1
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithLimit(1))
+		testOpts = getDefaultOptions()
+		testOpts.withLimit = 1
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterItem("s_1234567890"))
+		testOpts := getDefaultOptions()
+		id := "s_1234567890"
+		testOpts.withStartPageAfterItem = &id
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithOrderByCreateTime", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithOrderByCreateTime(true))
+		testOpts := getDefaultOptions()
+		testOpts.withOrderByCreateTime = true
+		testOpts.ascending = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithPublicId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithPublicId("amldap_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "amldap_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUrls", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		u, err := url.Parse("ldaps://ldap.example.org")
+		require.NoError(err)
+		opts := getOpts(WithUrls(u))
+		testOpts := getDefaultOptions()
+		testOpts.withUrls = []*url.URL{u}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithCertificates", func(t *testing.T) {
+		assert := assert.New(t)
+		cert := &x509.Certificate{}
+		opts := getOpts(WithCertificates(cert))
+		testOpts := getDefaultOptions()
+		testOpts.withCertificates = []*x509.Certificate{cert}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithStartTls", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartTls())
+		testOpts := getDefaultOptions()
+		testOpts.withStartTls = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithInsecureTls", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithInsecureTls())
+		testOpts := getDefaultOptions()
+		testOpts.withInsecureTls = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDiscoverDn", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDiscoverDn())
+		testOpts := getDefaultOptions()
+		testOpts.withDiscoverDn = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAnonGroupSearch", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAnonGroupSearch())
+		testOpts := getDefaultOptions()
+		testOpts.withAnonGroupSearch = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUpnDomain", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithUpnDomain("example.org"))
+		testOpts := getDefaultOptions()
+		testOpts.withUpnDomain = "example.org"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUserDn", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithUserDn("ou=people,dc=example,dc=org"))
+		testOpts := getDefaultOptions()
+		testOpts.withUserDn = "ou=people,dc=example,dc=org"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUserAttr", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithUserAttr("uid"))
+		testOpts := getDefaultOptions()
+		testOpts.withUserAttr = "uid"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUserFilter", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithUserFilter("(uid={{.Username}})"))
+		testOpts := getDefaultOptions()
+		testOpts.withUserFilter = "(uid={{.Username}})"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithGroupDn", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithGroupDn("ou=groups,dc=example,dc=org"))
+		testOpts := getDefaultOptions()
+		testOpts.withGroupDn = "ou=groups,dc=example,dc=org"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithGroupAttr", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithGroupAttr("cn"))
+		testOpts := getDefaultOptions()
+		testOpts.withGroupAttr = "cn"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithGroupFilter", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithGroupFilter("(member={{.UserDN}})"))
+		testOpts := getDefaultOptions()
+		testOpts.withGroupFilter = "(member={{.UserDN}})"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithBindCredential", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithBindCredential("cn=admin,dc=example,dc=org", "password"))
+		testOpts := getDefaultOptions()
+		testOpts.withBindDn = "cn=admin,dc=example,dc=org"
+		testOpts.withBindPassword = "password"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEmail", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithEmail("alice@example.org"))
+		testOpts := getDefaultOptions()
+		testOpts.withEmail = "alice@example.org"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithFullName", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithFullName("Alice Smith"))
+		testOpts := getDefaultOptions()
+		testOpts.withFullName = "Alice Smith"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithReader", func(t *testing.T) {
+		assert := assert.New(t)
+		testOpts := getDefaultOptions()
+		assert.Nil(testOpts.withReader)
+		var r db.Reader
+		opts := getOpts(WithReader(r))
+		assert.Equal(r, opts.withReader)
+	})
+}
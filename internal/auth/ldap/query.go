@@ -0,0 +1,23 @@
+package ldap
+
+const (
+	acctUpsertQuery = `
+	insert into auth_ldap_account
+			(%s)
+	values
+			(%s)
+	on conflict on constraint 
+			auth_ldap_account_auth_method_id_login_name_uq
+	do update set
+			%s
+	returning public_id, version
+       `
+
+	rewrapAuthMethodQuery = `
+update auth_ldap_method
+   set bind_password = @bind_password,
+       bind_password_hmac = @bind_password_hmac,
+       key_id = @key_id
+ where public_id = @public_id;
+`
+)
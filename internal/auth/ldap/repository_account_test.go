@@ -0,0 +1,75 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Account(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, []string{"ldap://ldap.example.org"})
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+
+	a, err := NewAccount(ctx, am.PublicId, "Alice", WithName("alice"), WithDescription("desc"))
+	require.NoError(err)
+	created, err := repo.CreateAccount(ctx, org.PublicId, a)
+	require.NoError(err)
+	assert.NotEmpty(created.PublicId)
+	assert.Equal("alice", created.LoginName)
+	assert.Equal(uint32(1), created.Version)
+
+	// the login name must be unique within the auth method
+	dup, err := NewAccount(ctx, am.PublicId, "alice")
+	require.NoError(err)
+	_, err = repo.CreateAccount(ctx, org.PublicId, dup)
+	require.Error(err)
+
+	_, err = repo.CreateAccount(ctx, org.PublicId, a, WithPublicId("bad_1234567890"))
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	found, err := repo.LookupAccount(ctx, created.PublicId)
+	require.NoError(err)
+	assert.Equal(created.PublicId, found.PublicId)
+
+	TestAccount(t, conn, am, "bob")
+	accts, err := repo.ListAccounts(ctx, am.PublicId)
+	require.NoError(err)
+	assert.Len(accts, 2)
+
+	created.Name = "updated"
+	updated, rowsUpdated, err := repo.UpdateAccount(ctx, org.PublicId, created, created.Version, []string{NameField})
+	require.NoError(err)
+	assert.Equal(1, rowsUpdated)
+	assert.Equal("updated", updated.Name)
+
+	_, _, err = repo.UpdateAccount(ctx, org.PublicId, updated, updated.Version, []string{"LoginName"})
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidFieldMask), err))
+
+	deleted, err := repo.DeleteAccount(ctx, org.PublicId, created.PublicId)
+	require.NoError(err)
+	assert.Equal(1, deleted)
+	found, err = repo.LookupAccount(ctx, created.PublicId)
+	require.NoError(err)
+	assert.Nil(found)
+}
@@ -0,0 +1,212 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+)
+
+// LookupAuthMethod will lookup an auth method in the repo, along with its
+// associated Value Objects of Urls and Certificates. If it's not found, it
+// will return nil, nil. All options are ignored.
+func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, _ ...Option) (*AuthMethod, error) {
+	const op = "ldap.(Repository).LookupAuthMethod"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	am, err := r.lookupAuthMethod(ctx, publicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return am, nil
+}
+
+// ListAuthMethods returns a slice of AuthMethods for the scopeIds. The
+// WithLimit, WithOrderByCreateTime and WithStartPageAfterItem options are
+// supported and all other options are ignored.
+func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
+	const op = "ldap.(Repository).ListAuthMethods"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope IDs")
+	}
+	authMethods, err := r.getAuthMethods(ctx, "", scopeIds, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return authMethods, nil
+}
+
+// lookupAuthMethod will lookup a single auth method
+func (r *Repository) lookupAuthMethod(ctx context.Context, authMethodId string, opt ...Option) (*AuthMethod, error) {
+	const op = "ldap.(Repository).lookupAuthMethod"
+	ams, err := r.getAuthMethods(ctx, authMethodId, nil, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case len(ams) == 0:
+		return nil, nil // not an error to return no rows for a "lookup"
+	case len(ams) > 1:
+		return nil, errors.New(ctx, errors.NotSpecificIntegrity, op, fmt.Sprintf("%s matched more than 1 ", authMethodId))
+	default:
+		return ams[0], nil
+	}
+}
+
+// getAuthMethods allows the caller to either lookup a specific AuthMethod via
+// its id or search for a set AuthMethods within a set of scopes. Passing both
+// scopeIds and an authMethodId is an error. The WithLimit,
+// WithOrderByCreateTime and WithStartPageAfterItem options are supported and
+// all other options are ignored.
+//
+// The AuthMethod returned has its value objects populated (Urls and
+// Certificates) and its IsPrimaryAuthMethod bool set.
+//
+// When no record is found it returns nil, nil
+func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
+	const op = "ldap.(Repository).getAuthMethods"
+	if authMethodId == "" && len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing search criteria: both auth method id and Scope IDs are empty")
+	}
+	if authMethodId != "" && len(scopeIds) > 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "searching for both an auth method id and Scope IDs is not supported")
+	}
+
+	const (
+		aggregateDelimiter = "|"
+		priorityDelimiter  = "="
+	)
+
+	dbArgs := []db.Option{}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	dbArgs = append(dbArgs, db.WithLimit(limit))
+	if opts.withStartPageAfterItem != nil {
+		dbArgs = append(dbArgs, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
+	if opts.withOrderByCreateTime {
+		if opts.ascending {
+			dbArgs = append(dbArgs, db.WithOrder("create_time asc"))
+		} else {
+			dbArgs = append(dbArgs, db.WithOrder("create_time"))
+		}
+	}
+
+	var where string
+	var args []interface{}
+	switch {
+	case authMethodId != "":
+		where, args = "public_id = ?", append(args, authMethodId)
+	default:
+		where, args = "scope_id in(?)", append(args, scopeIds)
+	}
+
+	var aggAuthMethods []*authMethodAgg
+	if err := r.reader.SearchWhere(ctx, &aggAuthMethods, where, args, dbArgs...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	if len(aggAuthMethods) == 0 { // we're done if nothing is found.
+		return nil, nil
+	}
+
+	authMethods := make([]*AuthMethod, 0, len(aggAuthMethods))
+	for _, agg := range aggAuthMethods {
+		if len(agg.CtBindPassword) > 0 {
+			databaseWrapper, err := r.kms.GetWrapper(ctx, agg.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(agg.KeyId))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+			}
+			if err := structwrapping.UnwrapStruct(ctx, databaseWrapper, agg, nil); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+			}
+		}
+		am := AllocAuthMethod()
+		am.PublicId = agg.PublicId
+		am.ScopeId = agg.ScopeId
+		am.IsPrimaryAuthMethod = agg.IsPrimaryAuthMethod
+		am.Name = agg.Name
+		am.Description = agg.Description
+		am.CreateTime = agg.CreateTime
+		am.UpdateTime = agg.UpdateTime
+		am.Version = agg.Version
+		am.StartTls = agg.StartTls
+		am.InsecureTls = agg.InsecureTls
+		am.DiscoverDn = agg.DiscoverDn
+		am.AnonGroupSearch = agg.AnonGroupSearch
+		am.UpnDomain = agg.UpnDomain
+		am.UserDn = agg.UserDn
+		am.UserAttr = agg.UserAttr
+		am.UserFilter = agg.UserFilter
+		am.GroupDn = agg.GroupDn
+		am.GroupAttr = agg.GroupAttr
+		am.GroupFilter = agg.GroupFilter
+		am.BindDn = agg.BindDn
+		am.CtBindPassword = agg.CtBindPassword
+		am.BindPassword = agg.BindPassword
+		am.BindPasswordHmac = agg.BindPasswordHmac
+		am.KeyId = agg.KeyId
+		if agg.Urls != "" {
+			// the view prefixes each url with its zero padded connection
+			// priority, so the aggregated urls are already in priority order.
+			prioritizedUrls := strings.Split(agg.Urls, aggregateDelimiter)
+			am.Urls = make([]string, 0, len(prioritizedUrls))
+			for _, pu := range prioritizedUrls {
+				parts := strings.SplitN(pu, priorityDelimiter, 2)
+				if len(parts) != 2 {
+					return nil, errors.New(ctx, errors.NotSpecificIntegrity, op, fmt.Sprintf("invalid url %q for auth method %s", pu, agg.PublicId))
+				}
+				am.Urls = append(am.Urls, parts[1])
+			}
+		}
+		if agg.Certs != "" {
+			am.Certificates = strings.Split(agg.Certs, aggregateDelimiter)
+		}
+		authMethods = append(authMethods, &am)
+	}
+	return authMethods, nil
+}
+
+// authMethodAgg is a view that aggregates the auth method's value objects in to
+// string fields delimited with the aggregateDelimiter of "|"
+type authMethodAgg struct {
+	PublicId            string `gorm:"primary_key"`
+	ScopeId             string
+	IsPrimaryAuthMethod bool
+	Name                string
+	Description         string
+	CreateTime          *timestamp.Timestamp
+	UpdateTime          *timestamp.Timestamp
+	Version             uint32
+	StartTls            bool
+	InsecureTls         bool
+	DiscoverDn          bool
+	AnonGroupSearch     bool
+	UpnDomain           string
+	UserDn              string
+	UserAttr            string
+	UserFilter          string
+	GroupDn             string
+	GroupAttr           string
+	GroupFilter         string
+	BindDn              string
+	CtBindPassword      []byte `gorm:"column:bind_password" wrapping:"ct,bind_password"`
+	BindPassword        string `gorm:"-" wrapping:"pt,bind_password"`
+	BindPasswordHmac    string
+	KeyId               string
+	Urls                string
+	Certs               string
+}
+
+// TableName returns the table name for gorm
+func (agg *authMethodAgg) TableName() string { return "ldap_auth_method_with_value_obj" }
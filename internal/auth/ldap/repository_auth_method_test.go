@@ -0,0 +1,340 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateAuthMethod(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	tests := []struct {
+		name         string
+		am           func() *AuthMethod
+		opt          []Option
+		wantErrMatch *errors.Template
+	}{
+		{
+			name: "valid",
+			am: func() *AuthMethod {
+				am, err := NewAuthMethod(ctx, org.PublicId,
+					WithName("valid"),
+					WithDescription("desc"),
+					WithUrls(TestConvertToUrls(t, "ldaps://ldap1.example.org", "ldap://ldap2.example.org")...),
+					WithStartTls(),
+					WithDiscoverDn(),
+					WithUserDn("ou=people,dc=example,dc=org"),
+					WithUserAttr("uid"),
+					WithGroupDn("ou=groups,dc=example,dc=org"),
+					WithBindCredential("cn=admin,dc=example,dc=org", "admin-password"),
+				)
+				require.NoError(t, err)
+				return am
+			},
+		},
+		{
+			name: "valid-with-public-id",
+			am: func() *AuthMethod {
+				am, err := NewAuthMethod(ctx, org.PublicId, WithUrls(TestConvertToUrls(t, "ldap://ldap.example.org")...))
+				require.NoError(t, err)
+				return am
+			},
+			opt: []Option{WithPublicId(AuthMethodPrefix + "_1234567890")},
+		},
+		{
+			name: "bad-public-id-prefix",
+			am: func() *AuthMethod {
+				am, err := NewAuthMethod(ctx, org.PublicId, WithUrls(TestConvertToUrls(t, "ldap://ldap.example.org")...))
+				require.NoError(t, err)
+				return am
+			},
+			opt:          []Option{WithPublicId("bad_1234567890")},
+			wantErrMatch: errors.T(errors.InvalidParameter),
+		},
+		{
+			name: "missing-scope",
+			am: func() *AuthMethod {
+				am := AllocAuthMethod()
+				am.Urls = []string{"ldap://ldap.example.org"}
+				return &am
+			},
+			wantErrMatch: errors.T(errors.InvalidParameter),
+		},
+		{
+			name: "public-id-not-empty",
+			am: func() *AuthMethod {
+				am, err := NewAuthMethod(ctx, org.PublicId)
+				require.NoError(t, err)
+				am.PublicId = AuthMethodPrefix + "_1234567890"
+				return am
+			},
+			wantErrMatch: errors.T(errors.InvalidParameter),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			repo, err := NewRepository(ctx, rw, rw, kmsCache)
+			require.NoError(err)
+			am := tt.am()
+			got, err := repo.CreateAuthMethod(ctx, am, tt.opt...)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "want err code: %q got: %q", tt.wantErrMatch.Code, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.NotEmpty(got.PublicId)
+			assert.Equal(uint32(1), got.Version)
+			assert.Equal(am.Name, got.Name)
+			assert.Equal(am.Description, got.Description)
+			assert.Equal(am.Urls, got.Urls)
+			assert.Equal(am.BindDn, got.BindDn)
+			assert.Equal(am.BindPassword, got.BindPassword)
+			assert.NotEmpty(got.BindPasswordHmac)
+
+			err = db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second))
+			require.NoError(err)
+		})
+	}
+}
+
+func TestRepository_LookupAuthMethod(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, []string{"ldaps://ldap1.example.org", "ldap://ldap2.example.org"},
+		WithBindCredential("cn=admin,dc=example,dc=org", "admin-password"))
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.LookupAuthMethod(ctx, am.PublicId)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(am.PublicId, got.PublicId)
+		assert.Equal([]string{"ldaps://ldap1.example.org", "ldap://ldap2.example.org"}, got.Urls)
+		assert.Equal("cn=admin,dc=example,dc=org", got.BindDn)
+		assert.Equal("admin-password", got.BindPassword)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		id, err := newAuthMethodId(ctx)
+		require.NoError(err)
+		got, err := repo.LookupAuthMethod(ctx, id)
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("missing-public-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.LookupAuthMethod(ctx, "")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_ListAuthMethods(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org1, _ := iam.TestScopes(t, iamRepo)
+	org2, _ := iam.TestScopes(t, iamRepo)
+
+	var want1, want2 []*AuthMethod
+	for i := 0; i < 3; i++ {
+		w1, err := kmsCache.GetWrapper(ctx, org1.PublicId, kms.KeyPurposeDatabase)
+		require.NoError(t, err)
+		want1 = append(want1, TestAuthMethod(t, conn, w1, org1.PublicId, []string{"ldap://ldap.example.org"}))
+		w2, err := kmsCache.GetWrapper(ctx, org2.PublicId, kms.KeyPurposeDatabase)
+		require.NoError(t, err)
+		want2 = append(want2, TestAuthMethod(t, conn, w2, org2.PublicId, []string{"ldap://ldap.example.org"}))
+	}
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		scopeIds     []string
+		opt          []Option
+		wantCnt      int
+		wantErrMatch *errors.Template
+	}{
+		{name: "one-scope", scopeIds: []string{org1.PublicId}, wantCnt: len(want1)},
+		{name: "two-scopes", scopeIds: []string{org1.PublicId, org2.PublicId}, wantCnt: len(want1) + len(want2)},
+		{name: "with-limit", scopeIds: []string{org1.PublicId, org2.PublicId}, opt: []Option{WithLimit(2)}, wantCnt: 2},
+		{name: "no-scopes", wantErrMatch: errors.T(errors.InvalidParameter)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.ListAuthMethods(ctx, tt.scopeIds, tt.opt...)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "want err code: %q got: %q", tt.wantErrMatch.Code, err)
+				return
+			}
+			require.NoError(err)
+			assert.Len(got, tt.wantCnt)
+		})
+	}
+}
+
+func TestRepository_UpdateAuthMethod(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		update       func(*AuthMethod) *AuthMethod
+		mask         []string
+		version      uint32
+		want         func(*testing.T, *AuthMethod)
+		wantErrMatch *errors.Template
+	}{
+		{
+			name: "name-and-description",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.Name = "updated"
+				am.Description = "updated desc"
+				return am
+			},
+			mask: []string{NameField, DescriptionField},
+			want: func(t *testing.T, got *AuthMethod) {
+				assert.Equal(t, "updated", got.Name)
+				assert.Equal(t, "updated desc", got.Description)
+			},
+		},
+		{
+			name: "replace-urls",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.Urls = []string{"ldaps://ldap3.example.org", "ldaps://ldap4.example.org"}
+				return am
+			},
+			mask: []string{UrlsField},
+			want: func(t *testing.T, got *AuthMethod) {
+				assert.Equal(t, []string{"ldaps://ldap3.example.org", "ldaps://ldap4.example.org"}, got.Urls)
+			},
+		},
+		{
+			name: "bind-credential",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.BindDn = "cn=other,dc=example,dc=org"
+				am.BindPassword = "other-password"
+				return am
+			},
+			mask: []string{BindDnField, BindPasswordField},
+			want: func(t *testing.T, got *AuthMethod) {
+				assert.Equal(t, "cn=other,dc=example,dc=org", got.BindDn)
+				assert.Equal(t, "other-password", got.BindPassword)
+			},
+		},
+		{
+			name: "bool-fields",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.StartTls = true
+				am.InsecureTls = true
+				return am
+			},
+			mask: []string{StartTlsField, InsecureTlsField},
+			want: func(t *testing.T, got *AuthMethod) {
+				assert.True(t, got.StartTls)
+				assert.True(t, got.InsecureTls)
+			},
+		},
+		{
+			name:         "bad-field-mask",
+			update:       func(am *AuthMethod) *AuthMethod { return am },
+			mask:         []string{"ScopeId"},
+			wantErrMatch: errors.T(errors.InvalidParameter),
+		},
+		{
+			name:         "empty-field-mask",
+			update:       func(am *AuthMethod) *AuthMethod { return am },
+			wantErrMatch: errors.T(errors.EmptyFieldMask),
+		},
+		{
+			name: "bad-version",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.Name = "bad-version"
+				return am
+			},
+			mask:         []string{NameField},
+			version:      100,
+			wantErrMatch: errors.T(errors.VersionMismatch),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, []string{"ldap://ldap1.example.org", "ldap://ldap2.example.org"})
+			version := orig.Version
+			if tt.version != 0 {
+				version = tt.version
+			}
+			updateWith := tt.update(orig.Clone())
+			got, rowsUpdated, err := repo.UpdateAuthMethod(ctx, updateWith, version, tt.mask)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "want err code: %q got: %q", tt.wantErrMatch.Code, err)
+				assert.Equal(0, rowsUpdated)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, rowsUpdated)
+			assert.Equal(orig.Version+1, got.Version)
+			tt.want(t, got)
+
+			found, err := repo.LookupAuthMethod(ctx, orig.PublicId)
+			require.NoError(err)
+			tt.want(t, found)
+
+			err = db.TestVerifyOplog(t, rw, orig.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second))
+			require.NoError(err)
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+)
+
+const (
+	VersionField          = "Version"
+	NameField             = "Name"
+	DescriptionField      = "Description"
+	UrlsField             = "Urls"
+	CertificatesField     = "Certificates"
+	StartTlsField         = "StartTls"
+	InsecureTlsField      = "InsecureTls"
+	DiscoverDnField       = "DiscoverDn"
+	AnonGroupSearchField  = "AnonGroupSearch"
+	UpnDomainField        = "UpnDomain"
+	UserDnField           = "UserDn"
+	UserAttrField         = "UserAttr"
+	UserFilterField       = "UserFilter"
+	GroupDnField          = "GroupDn"
+	GroupAttrField        = "GroupAttr"
+	GroupFilterField      = "GroupFilter"
+	BindDnField           = "BindDn"
+	BindPasswordField     = "BindPassword"
+	CtBindPasswordField   = "CtBindPassword"
+	BindPasswordHmacField = "BindPasswordHmac"
+	KeyIdField            = "KeyId"
+	GroupNamesField       = "GroupNames"
+)
+
+// UpdateAuthMethod will retrieve the auth method from the repository,
+// and update it based on the field masks provided.
+//
+// fieldMaskPaths provides field_mask.proto paths for fields that should
+// be updated.  Fields will be set to NULL if the field is a
+// zero value and included in fieldMask. Name, Description, StartTls,
+// InsecureTls, DiscoverDn, AnonGroupSearch, UpnDomain, UserDn, UserAttr,
+// UserFilter, GroupDn, GroupAttr, GroupFilter, BindDn and BindPassword are all
+// updatable fields.  The AuthMethod's Value Objects of Urls and Certificates
+// are also updatable and are replaced in their entirety when included in the
+// fieldMaskPaths. If no updatable fields are included in the fieldMaskPaths,
+// then an error is returned.
+//
+// No options are currently supported.
+func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, version uint32, fieldMaskPaths []string, _ ...Option) (*AuthMethod, int, error) {
+	const op = "ldap.(Repository).UpdateAuthMethod"
+	if am == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if am.AuthMethod == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method store")
+	}
+	if am.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+
+	if err := validateFieldMask(ctx, fieldMaskPaths); err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			NameField:            am.Name,
+			DescriptionField:     am.Description,
+			UrlsField:            am.Urls,
+			CertificatesField:    am.Certificates,
+			StartTlsField:        am.StartTls,
+			InsecureTlsField:     am.InsecureTls,
+			DiscoverDnField:      am.DiscoverDn,
+			AnonGroupSearchField: am.AnonGroupSearch,
+			UpnDomainField:       am.UpnDomain,
+			UserDnField:          am.UserDn,
+			UserAttrField:        am.UserAttr,
+			UserFilterField:      am.UserFilter,
+			GroupDnField:         am.GroupDn,
+			GroupAttrField:       am.GroupAttr,
+			GroupFilterField:     am.GroupFilter,
+			BindDnField:          am.BindDn,
+			BindPasswordField:    am.BindPassword,
+		},
+		fieldMaskPaths,
+		// the tls and search flags are not null columns, so a false value is
+		// written rather than nulled.
+		[]string{StartTlsField, InsecureTlsField, DiscoverDnField, AnonGroupSearchField},
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	origAm, err := r.lookupAuthMethod(ctx, am.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if origAm == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s", am.PublicId))
+	}
+	// there's no reason to continue if another controller has already updated this auth method.
+	if origAm.Version != version {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.VersionMismatch, op, fmt.Sprintf("update version %d doesn't match db version %d", version, origAm.Version))
+	}
+
+	updated := applyUpdate(am, origAm, fieldMaskPaths)
+	if err := updated.validate(ctx, op); err != nil {
+		return nil, db.NoRowsAffected, err // validate properly sets the op to the caller, the code and the msg, so just return it.
+	}
+
+	// the urls and certificates are replaced in their entirety, since the
+	// order of the urls determines their connection priority.
+	var addUrls, deleteUrls, addCerts, deleteCerts []interface{}
+	if strutil.StrListContains(dbMask, UrlsField) || strutil.StrListContains(nullFields, UrlsField) {
+		if deleteUrls, err = origAm.convertUrls(ctx); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+		if addUrls, err = updated.convertUrls(ctx); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+	}
+	if strutil.StrListContains(dbMask, CertificatesField) || strutil.StrListContains(nullFields, CertificatesField) {
+		if deleteCerts, err = origAm.convertCertificates(ctx); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+		if addCerts, err = updated.convertCertificates(ctx); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	var filteredDbMask, filteredNullFields []string
+	for _, f := range dbMask {
+		switch f {
+		case UrlsField, CertificatesField:
+			continue
+		default:
+			filteredDbMask = append(filteredDbMask, f)
+		}
+	}
+	for _, f := range nullFields {
+		switch f {
+		case UrlsField, CertificatesField:
+			continue
+		default:
+			filteredNullFields = append(filteredNullFields, f)
+		}
+	}
+
+	// BindPassword is a bit odd, because it uses the Struct wrapping, we need
+	// to add the encrypted fields to the dbMask or nullFields
+	if strutil.StrListContains(filteredDbMask, BindPasswordField) {
+		filteredDbMask = append(filteredDbMask, CtBindPasswordField, BindPasswordHmacField, KeyIdField)
+	}
+	if strutil.StrListContains(filteredNullFields, BindPasswordField) {
+		filteredNullFields = append(filteredNullFields, CtBindPasswordField, BindPasswordHmacField, KeyIdField)
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, origAm.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := am.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, origAm.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var updatedAm *AuthMethod
+	var rowsUpdated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 5) // AuthMethod, Urls*2, Certs*2
+			ticket, err := w.GetTicket(am)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			var authMethodOplogMsg oplog.Message
+			switch {
+			case len(filteredDbMask) == 0 && len(filteredNullFields) == 0:
+				// the auth method's fields are not being updated, just it's value objects, so we need to just update the auth
+				// method's version.
+				updatedAm = am.Clone()
+				updatedAm.Version = uint32(version) + 1
+				rowsUpdated, err = w.Update(ctx, updatedAm, []string{VersionField}, nil, db.NewOplogMsg(&authMethodOplogMsg), db.WithVersion(&version))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth method version"))
+				}
+				if rowsUpdated != 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated auth method version and %d rows updated", rowsUpdated))
+				}
+			default:
+				updatedAm = am.Clone()
+				rowsUpdated, err = w.Update(ctx, updatedAm, filteredDbMask, filteredNullFields, db.NewOplogMsg(&authMethodOplogMsg), db.WithVersion(&version))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth method"))
+				}
+				if rowsUpdated != 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated auth method and %d rows updated", rowsUpdated))
+				}
+			}
+			msgs = append(msgs, &authMethodOplogMsg)
+
+			if len(deleteUrls) > 0 {
+				deleteUrlOplogMsgs := make([]*oplog.Message, 0, len(deleteUrls))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteUrls, db.NewOplogMsgs(&deleteUrlOplogMsgs))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete urls"))
+				}
+				if rowsDeleted != len(deleteUrls) {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("urls deleted %d did not match request for %d", rowsDeleted, len(deleteUrls)))
+				}
+				msgs = append(msgs, deleteUrlOplogMsgs...)
+			}
+			if len(addUrls) > 0 {
+				addUrlOplogMsgs := make([]*oplog.Message, 0, len(addUrls))
+				if err := w.CreateItems(ctx, addUrls, db.NewOplogMsgs(&addUrlOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add urls"))
+				}
+				msgs = append(msgs, addUrlOplogMsgs...)
+			}
+
+			if len(deleteCerts) > 0 {
+				deleteCertOplogMsgs := make([]*oplog.Message, 0, len(deleteCerts))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteCerts, db.NewOplogMsgs(&deleteCertOplogMsgs))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete certificates"))
+				}
+				if rowsDeleted != len(deleteCerts) {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("certificates deleted %d did not match request for %d", rowsDeleted, len(deleteCerts)))
+				}
+				msgs = append(msgs, deleteCertOplogMsgs...)
+			}
+			if len(addCerts) > 0 {
+				addCertOplogMsgs := make([]*oplog.Message, 0, len(addCerts))
+				if err := w.CreateItems(ctx, addCerts, db.NewOplogMsgs(&addCertOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add certificates"))
+				}
+				msgs = append(msgs, addCertOplogMsgs...)
+			}
+
+			metadata := updatedAm.oplog(oplog.OpType_OP_TYPE_UPDATE)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			// we need a new repo, that's using the same reader/writer as this TxHandler
+			txRepo := &Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+			}
+			updatedAm, err = txRepo.lookupAuthMethod(ctx, updatedAm.PublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup auth method after update"))
+			}
+			if updatedAm == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, "unable to lookup auth method after update")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("auth method %s already exists in scope %s", am.Name, origAm.ScopeId), errors.WithWrap(err))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return updatedAm, rowsUpdated, nil
+}
+
+// validateFieldMask check the field mask to ensure all the fields are updatable
+func validateFieldMask(ctx context.Context, fieldMaskPaths []string) error {
+	const op = "ldap.validateFieldMask"
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(NameField, f):
+		case strings.EqualFold(DescriptionField, f):
+		case strings.EqualFold(UrlsField, f):
+		case strings.EqualFold(CertificatesField, f):
+		case strings.EqualFold(StartTlsField, f):
+		case strings.EqualFold(InsecureTlsField, f):
+		case strings.EqualFold(DiscoverDnField, f):
+		case strings.EqualFold(AnonGroupSearchField, f):
+		case strings.EqualFold(UpnDomainField, f):
+		case strings.EqualFold(UserDnField, f):
+		case strings.EqualFold(UserAttrField, f):
+		case strings.EqualFold(UserFilterField, f):
+		case strings.EqualFold(GroupDnField, f):
+		case strings.EqualFold(GroupAttrField, f):
+		case strings.EqualFold(GroupFilterField, f):
+		case strings.EqualFold(BindDnField, f):
+		case strings.EqualFold(BindPasswordField, f):
+		default:
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	return nil
+}
+
+// applyUpdate takes the new and applies it to the orig using the field masks
+func applyUpdate(new, orig *AuthMethod, fieldMaskPaths []string) *AuthMethod {
+	cp := orig.Clone()
+	for _, f := range fieldMaskPaths {
+		switch f {
+		case NameField:
+			cp.Name = new.Name
+		case DescriptionField:
+			cp.Description = new.Description
+		case StartTlsField:
+			cp.StartTls = new.StartTls
+		case InsecureTlsField:
+			cp.InsecureTls = new.InsecureTls
+		case DiscoverDnField:
+			cp.DiscoverDn = new.DiscoverDn
+		case AnonGroupSearchField:
+			cp.AnonGroupSearch = new.AnonGroupSearch
+		case UpnDomainField:
+			cp.UpnDomain = new.UpnDomain
+		case UserDnField:
+			cp.UserDn = new.UserDn
+		case UserAttrField:
+			cp.UserAttr = new.UserAttr
+		case UserFilterField:
+			cp.UserFilter = new.UserFilter
+		case GroupDnField:
+			cp.GroupDn = new.GroupDn
+		case GroupAttrField:
+			cp.GroupAttr = new.GroupAttr
+		case GroupFilterField:
+			cp.GroupFilter = new.GroupFilter
+		case BindDnField:
+			cp.BindDn = new.BindDn
+		case BindPasswordField:
+			cp.BindPassword = new.BindPassword
+		case UrlsField:
+			switch {
+			case len(new.Urls) == 0:
+				cp.Urls = nil
+			default:
+				cp.Urls = make([]string, 0, len(new.Urls))
+				cp.Urls = append(cp.Urls, new.Urls...)
+			}
+		case CertificatesField:
+			switch {
+			case len(new.Certificates) == 0:
+				cp.Certificates = nil
+			default:
+				cp.Certificates = make([]string, 0, len(new.Certificates))
+				cp.Certificates = append(cp.Certificates, new.Certificates...)
+			}
+		}
+	}
+	return cp
+}
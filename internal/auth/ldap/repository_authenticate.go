@@ -0,0 +1,255 @@
+package ldap
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// Account must implement oplog.Replayable for upsertAccount to work
+var _ oplog.ReplayableMessage = (*Account)(nil)
+
+// Account must implement proto.Message for upsertAccount to work
+var _ proto.Message = (*Account)(nil)
+
+// Authenticate authenticates loginName and password against the directory
+// configured by the auth method authMethodId. If successful, the account for
+// loginName is created or updated with the user's directory entry and the
+// account's managed group memberships are set from the user's directory
+// groups. The authenticated Account is returned.
+//
+// If the user is not found in the directory or the password does not match,
+// it returns nil, nil. All options are ignored.
+func (r *Repository) Authenticate(ctx context.Context, authMethodId, loginName, password string, _ ...Option) (*Account, error) {
+	const op = "ldap.(Repository).Authenticate"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id", errors.WithoutEvent())
+	}
+	if loginName == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing login name", errors.WithoutEvent())
+	}
+	if password == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing password", errors.WithoutEvent())
+	}
+	loginName = strings.ToLower(strings.TrimSpace(loginName))
+
+	am, err := r.lookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s not found", authMethodId))
+	}
+
+	user, err := authenticateUser(ctx, am, loginName, password)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	acct, err := r.upsertAccount(ctx, am, loginName, user)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	// Get the set of all managed groups so we can match the user's groups
+	mgs, err := r.ListManagedGroups(ctx, am.GetPublicId(), WithLimit(-1))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(mgs) > 0 {
+		matchedMgs := make([]*ManagedGroup, 0, len(mgs))
+		for _, mg := range mgs {
+			match, err := mg.matches(ctx, user.Groups)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			if match {
+				matchedMgs = append(matchedMgs, mg)
+			}
+		}
+		// We always pass it in, even if none match, because in that case we
+		// need to remove any mappings that exist
+		if _, _, err := r.SetManagedGroupMemberships(ctx, am, acct, matchedMgs); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	return acct, nil
+}
+
+// upsertAccount will create/update the account for loginName using the
+// user's directory entry.
+func (r *Repository) upsertAccount(ctx context.Context, am *AuthMethod, loginName string, user *directoryUser) (*Account, error) {
+	const op = "ldap.(Repository).upsertAccount"
+	if am == nil || am.AuthMethod == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if loginName == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing login name")
+	}
+	if user == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing directory user")
+	}
+
+	pubId, err := newAccountId(ctx, am.GetPublicId(), loginName)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	columns := []string{"public_id", "auth_method_id", "login_name", "dn"}
+	values := []interface{}{
+		sql.Named("1", pubId),
+		sql.Named("2", am.PublicId),
+		sql.Named("3", loginName),
+		sql.Named("4", user.Dn),
+	}
+	conflictClauses := []string{"dn = @4"}
+	fieldMasks := []string{"Dn"}
+	var nullMasks []string
+
+	acctForOplog := AllocAccount()
+	acctForOplog.Dn = user.Dn
+
+	if len(user.Groups) > 0 {
+		marshaledGroups, err := json.Marshal(user.Groups)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+		}
+		acctForOplog.MemberOfGroups = string(marshaledGroups)
+		columns, values = append(columns, "member_of_groups"), append(values, sql.Named(fmt.Sprintf("%d", len(values)+1), acctForOplog.MemberOfGroups))
+		conflictClauses = append(conflictClauses, fmt.Sprintf("member_of_groups = @%d", len(values)))
+		fieldMasks = append(fieldMasks, "MemberOfGroups")
+	} else {
+		conflictClauses = append(conflictClauses, "member_of_groups = NULL")
+		nullMasks = append(nullMasks, "MemberOfGroups")
+	}
+
+	if user.FullName != "" {
+		acctForOplog.FullName = user.FullName
+		columns, values = append(columns, "full_name"), append(values, sql.Named(fmt.Sprintf("%d", len(values)+1), user.FullName))
+		conflictClauses = append(conflictClauses, fmt.Sprintf("full_name = @%d", len(values)))
+		fieldMasks = append(fieldMasks, "FullName")
+	} else {
+		conflictClauses = append(conflictClauses, "full_name = NULL")
+		nullMasks = append(nullMasks, "FullName")
+	}
+
+	if user.Email != "" {
+		acctForOplog.Email = user.Email
+		columns, values = append(columns, "email"), append(values, sql.Named(fmt.Sprintf("%d", len(values)+1), user.Email))
+		conflictClauses = append(conflictClauses, fmt.Sprintf("email = @%d", len(values)))
+		fieldMasks = append(fieldMasks, "Email")
+	} else {
+		conflictClauses = append(conflictClauses, "email = NULL")
+		nullMasks = append(nullMasks, "Email")
+	}
+
+	placeHolders := make([]string, 0, len(columns))
+	for colNum := range columns {
+		placeHolders = append(placeHolders, fmt.Sprintf("@%d", colNum+1))
+	}
+	query := fmt.Sprintf(acctUpsertQuery, strings.Join(columns, ", "), strings.Join(placeHolders, ", "), strings.Join(conflictClauses, ", "))
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	updatedAcct := AllocAccount()
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rows, err := w.Query(ctx, query, values)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to insert/update auth ldap account"))
+			}
+			defer rows.Close()
+			result := struct {
+				PublicId string
+				Version  int
+			}{}
+			var rowCnt int
+			for rows.Next() {
+				rowCnt += 1
+				if err := r.reader.ScanRows(rows, &result); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan rows for account"))
+				}
+			}
+			if rowCnt > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("expected 1 row but got: %d", rowCnt))
+			}
+			if err := reader.LookupWhere(ctx, &updatedAcct, "auth_method_id = ? and login_name = ?", am.PublicId, loginName); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to look up auth ldap account for: %s / %s", am.PublicId, loginName)))
+			}
+			// include the version incase of predictable account public ids based on a calculation using authmethod id and login name
+			if result.Version == 1 && updatedAcct.PublicId == pubId {
+				if err := upsertOplog(ctx, w, oplogWrapper, oplog.OpType_OP_TYPE_CREATE, am.ScopeId, updatedAcct, nil, nil); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write create oplog for account"))
+				}
+			} else {
+				acctForOplog.PublicId = updatedAcct.PublicId
+				if err := upsertOplog(ctx, w, oplogWrapper, oplog.OpType_OP_TYPE_UPDATE, am.ScopeId, acctForOplog, fieldMasks, nullMasks); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write update oplog for account"))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return updatedAcct, nil
+}
+
+// upsertOplog will write oplog msgs for account upserts. The db.Writer needs to be the writer for the current
+// transaction that's executing the upsert. Both fieldMasks and nullMasks are allowed to be nil for update operations.
+func upsertOplog(ctx context.Context, w db.Writer, oplogWrapper wrapping.Wrapper, operation oplog.OpType, scopeId string, acct *Account, fieldMasks, nullMasks []string) error {
+	const op = "ldap.upsertOplog"
+	if w == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing db writer")
+	}
+	if oplogWrapper == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing oplog wrapper")
+	}
+	if operation != oplog.OpType_OP_TYPE_CREATE && operation != oplog.OpType_OP_TYPE_UPDATE {
+		return errors.New(ctx, errors.Internal, op, fmt.Sprintf("not a supported operation: %s", operation))
+	}
+	if scopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if acct == nil || acct.Account == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing account")
+	}
+	if operation == oplog.OpType_OP_TYPE_UPDATE && len(fieldMasks) == 0 && len(nullMasks) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "update operations must specify field masks and/or null masks")
+	}
+	ticket, err := w.GetTicket(acct)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+	}
+	metadata := acct.oplog(operation, scopeId)
+	msg := oplog.Message{
+		Message:        acct,
+		TypeName:       acct.TableName(),
+		OpType:         operation,
+		FieldMaskPaths: fieldMasks,
+		SetToNullPaths: nullMasks,
+	}
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, []*oplog.Message{&msg}); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Authenticate(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	const (
+		userDn  = "ou=people,dc=example,dc=org"
+		groupDn = "ou=groups,dc=example,dc=org"
+	)
+	d := StartTestDirectory(t)
+	d.AddUser("cn=alice,"+userDn, "alice-password", map[string][]string{
+		"cn":          {"alice"},
+		"displayName": {"Alice Smith"},
+		"mail":        {"alice@example.org"},
+		"memberOf":    {"cn=admins," + groupDn},
+	})
+
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, []string{d.Url()}, WithUserDn(userDn))
+	admins := TestManagedGroup(t, conn, am, []string{"admins"})
+	developers := TestManagedGroup(t, conn, am, []string{"developers"})
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, err := repo.Authenticate(ctx, am.PublicId, "Alice", "alice-password")
+		require.NoError(err)
+		require.NotNil(acct)
+		assert.Equal("alice", acct.LoginName)
+		assert.Equal("cn=alice,"+userDn, acct.Dn)
+		assert.Equal("Alice Smith", acct.FullName)
+		assert.Equal("alice@example.org", acct.Email)
+
+		memberships, err := repo.ListManagedGroupMembershipsByMember(ctx, acct.PublicId)
+		require.NoError(err)
+		require.Len(memberships, 1)
+		assert.Equal(admins.PublicId, memberships[0].ManagedGroupId)
+		assert.NotEqual(developers.PublicId, memberships[0].ManagedGroupId)
+
+		// authenticating again updates the existing account
+		again, err := repo.Authenticate(ctx, am.PublicId, "alice", "alice-password")
+		require.NoError(err)
+		assert.Equal(acct.PublicId, again.PublicId)
+	})
+	t.Run("bad-password", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, err := repo.Authenticate(ctx, am.PublicId, "alice", "bad-password")
+		require.NoError(err)
+		assert.Nil(acct)
+	})
+	t.Run("unknown-auth-method", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		id, err := newAuthMethodId(ctx)
+		require.NoError(err)
+		acct, err := repo.Authenticate(ctx, id, "alice", "alice-password")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+		assert.Nil(acct)
+	})
+	t.Run("missing-password", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, err := repo.Authenticate(ctx, am.PublicId, "alice", "")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		assert.Nil(acct)
+	})
+}
@@ -0,0 +1,75 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ManagedGroup(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, []string{"ldap://ldap.example.org"})
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+
+	mg, err := NewManagedGroup(ctx, am.PublicId, []string{"admins"}, WithName("admins"))
+	require.NoError(err)
+	created, err := repo.CreateManagedGroup(ctx, org.PublicId, mg)
+	require.NoError(err)
+	assert.NotEmpty(created.PublicId)
+	assert.Equal(uint32(1), created.Version)
+
+	_, err = repo.CreateManagedGroup(ctx, org.PublicId, AllocManagedGroup())
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	found, err := repo.LookupManagedGroup(ctx, created.PublicId)
+	require.NoError(err)
+	assert.Equal(created.GroupNames, found.GroupNames)
+
+	TestManagedGroup(t, conn, am, []string{"developers"})
+	mgs, err := repo.ListManagedGroups(ctx, am.PublicId)
+	require.NoError(err)
+	assert.Len(mgs, 2)
+
+	require.NoError(created.SetGroupNames(ctx, []string{"admins", "operators"}))
+	updated, rowsUpdated, err := repo.UpdateManagedGroup(ctx, org.PublicId, created, created.Version, []string{GroupNamesField})
+	require.NoError(err)
+	assert.Equal(1, rowsUpdated)
+	names, err := updated.GroupNameList(ctx)
+	require.NoError(err)
+	assert.Equal([]string{"admins", "operators"}, names)
+
+	acct := TestAccount(t, conn, am, "alice")
+	memberships, _, err := repo.SetManagedGroupMemberships(ctx, am, acct, []*ManagedGroup{updated})
+	require.NoError(err)
+	assert.Len(memberships, 1)
+	byGroup, err := repo.ListManagedGroupMembershipsByGroup(ctx, updated.PublicId)
+	require.NoError(err)
+	require.Len(byGroup, 1)
+	assert.Equal(acct.PublicId, byGroup[0].MemberId)
+
+	deleted, err := repo.DeleteManagedGroup(ctx, org.PublicId, updated.PublicId)
+	require.NoError(err)
+	assert.Equal(1, deleted)
+	byMember, err := repo.ListManagedGroupMembershipsByMember(ctx, acct.PublicId)
+	require.NoError(err)
+	assert.Empty(byMember)
+}
@@ -0,0 +1,132 @@
+package ldap
+
+import (
+	"context"
+	"crypto/x509"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuthMethod creates a test ldap auth method with the urls. All the
+// options supported by NewAuthMethod are supported.
+func TestAuthMethod(
+	t *testing.T,
+	conn *db.DB,
+	databaseWrapper wrapping.Wrapper,
+	scopeId string,
+	urls []string,
+	opt ...Option) *AuthMethod {
+	t.Helper()
+	ctx := context.TODO()
+	require := require.New(t)
+	rw := db.New(conn)
+
+	opt = append(opt, WithUrls(TestConvertToUrls(t, urls...)...))
+	authMethod, err := NewAuthMethod(ctx, scopeId, opt...)
+	require.NoError(err)
+	id, err := newAuthMethodId(ctx)
+	require.NoError(err)
+	authMethod.PublicId = id
+	require.NoError(authMethod.encrypt(ctx, databaseWrapper))
+	require.NoError(rw.Create(ctx, authMethod))
+
+	vo, err := authMethod.convertValueObjects(ctx)
+	require.NoError(err)
+	if len(vo.Urls) > 0 {
+		require.NoError(rw.CreateItems(ctx, vo.Urls))
+	}
+	if len(vo.Certs) > 0 {
+		require.NoError(rw.CreateItems(ctx, vo.Certs))
+	}
+	return authMethod
+}
+
+// TestSortAuthMethods will sort the provided auth methods by public id and it
+// will sort each auth method's certificates. The urls are not sorted, since
+// their order is their connection priority.
+func TestSortAuthMethods(t *testing.T, methods []*AuthMethod) {
+	sort.Slice(methods, func(a, b int) bool {
+		return methods[a].PublicId < methods[b].PublicId
+	})
+	for _, am := range methods {
+		sort.Strings(am.Certificates)
+	}
+}
+
+// TestAccount creates a test ldap auth account.
+func TestAccount(t *testing.T, conn *db.DB, am *AuthMethod, loginName string, opt ...Option) *Account {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	ctx := context.Background()
+
+	a, err := NewAccount(ctx, am.PublicId, loginName, opt...)
+	require.NoError(err)
+
+	id, err := newAccountId(ctx, am.GetPublicId(), a.LoginName)
+	require.NoError(err)
+	a.PublicId = id
+
+	require.NoError(rw.Create(ctx, a))
+	return a
+}
+
+// TestManagedGroup creates a test ldap managed group.
+func TestManagedGroup(t *testing.T, conn *db.DB, am *AuthMethod, groupNames []string, opt ...Option) *ManagedGroup {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	ctx := context.Background()
+
+	mg, err := NewManagedGroup(ctx, am.PublicId, groupNames, opt...)
+	require.NoError(err)
+
+	id, err := newManagedGroupId(ctx)
+	require.NoError(err)
+	mg.PublicId = id
+
+	require.NoError(rw.Create(ctx, mg))
+	return mg
+}
+
+// TestManagedGroupMember adds given account IDs to a managed group
+func TestManagedGroupMember(t *testing.T, conn *db.DB, managedGroupId, memberId string, opt ...Option) *ManagedGroupMemberAccount {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	ctx := context.Background()
+
+	mg, err := NewManagedGroupMemberAccount(ctx, managedGroupId, memberId, opt...)
+	require.NoError(err)
+
+	require.NoError(rw.Create(ctx, mg))
+	return mg
+}
+
+// TestConvertToUrls will convert URL string representations to a slice of
+// *url.URL
+func TestConvertToUrls(t *testing.T, urls ...string) []*url.URL {
+	t.Helper()
+	require := require.New(t)
+	var convertedUrls []*url.URL
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		require.NoError(err)
+		require.Contains([]string{"ldap", "ldaps"}, parsed.Scheme)
+		convertedUrls = append(convertedUrls, parsed)
+	}
+	return convertedUrls
+}
+
+// TestEncodeCertificates will PEM encode the certificates
+func TestEncodeCertificates(t *testing.T, certs ...*x509.Certificate) []string {
+	t.Helper()
+	pems, err := encodeCertificates(context.Background(), certs...)
+	require.NoError(t, err)
+	return pems
+}
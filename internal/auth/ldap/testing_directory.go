@@ -0,0 +1,476 @@
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/stretchr/testify/require"
+)
+
+// ldap protocol operations, result codes and filter choices used by the
+// TestDirectory. See RFC 4511.
+const (
+	testApplicationBindRequest       = 0
+	testApplicationBindResponse      = 1
+	testApplicationUnbindRequest     = 2
+	testApplicationSearchRequest     = 3
+	testApplicationSearchResultEntry = 4
+	testApplicationSearchResultDone  = 5
+	testApplicationExtendedRequest   = 23
+	testApplicationExtendedResponse  = 24
+
+	testResultSuccess                 = 0
+	testResultProtocolError           = 2
+	testResultNoSuchObject            = 32
+	testResultInvalidCredentials      = 49
+	testResultInsufficientAccessRight = 50
+	testResultUnwillingToPerform      = 53
+
+	testFilterAnd        = 0
+	testFilterOr         = 1
+	testFilterNot        = 2
+	testFilterEquality   = 3
+	testFilterSubstrings = 4
+	testFilterPresent    = 7
+
+	testScopeBaseObject   = 0
+	testScopeSingleLevel  = 1
+	testScopeWholeSubtree = 2
+)
+
+// TestDirectoryEntry is an entry in a TestDirectory. The userPassword
+// attribute of an entry is the password used to bind as the entry and it's
+// never returned by searches.
+type TestDirectoryEntry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// TestDirectory is a minimal in memory LDAP directory server for tests. It
+// supports simple binds, searches with and, or, not, equality, substrings and
+// present filters, and unbinds. Searches require a bound connection, and
+// anonymous binds are only allowed when enabled with AllowAnonymousBind.
+type TestDirectory struct {
+	listener net.Listener
+	cert     *x509.Certificate
+	scheme   string
+
+	mu             sync.Mutex
+	entries        []*TestDirectoryEntry
+	allowAnonymous bool
+
+	done     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// StartTestDirectory starts a TestDirectory listening on an ldap url. The
+// directory is stopped when the test completes.
+func StartTestDirectory(t *testing.T) *TestDirectory {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return startTestDirectory(t, l, "ldap", nil)
+}
+
+// StartTestDirectoryWithTls starts a TestDirectory listening on an ldaps url
+// using a self signed certificate, which is available via Cert. The
+// directory is stopped when the test completes.
+func StartTestDirectoryWithTls(t *testing.T) *TestDirectory {
+	t.Helper()
+	require := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test directory"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(err)
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	})
+	require.NoError(err)
+	return startTestDirectory(t, l, "ldaps", cert)
+}
+
+func startTestDirectory(t *testing.T, l net.Listener, scheme string, cert *x509.Certificate) *TestDirectory {
+	d := &TestDirectory{
+		listener: l,
+		cert:     cert,
+		scheme:   scheme,
+		done:     make(chan struct{}),
+	}
+	d.wg.Add(1)
+	go d.serve()
+	t.Cleanup(d.Stop)
+	return d
+}
+
+// Url returns the url of the directory.
+func (d *TestDirectory) Url() string {
+	return fmt.Sprintf("%s://%s", d.scheme, d.listener.Addr().String())
+}
+
+// Cert returns the certificate of a directory started with
+// StartTestDirectoryWithTls.
+func (d *TestDirectory) Cert() *x509.Certificate {
+	return d.cert
+}
+
+// AllowAnonymousBind sets whether the directory allows anonymous binds.
+func (d *TestDirectory) AllowAnonymousBind(allow bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.allowAnonymous = allow
+}
+
+// AddEntry adds an entry to the directory.
+func (d *TestDirectory) AddEntry(dn string, attributes map[string][]string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = append(d.entries, &TestDirectoryEntry{DN: dn, Attributes: attributes})
+}
+
+// AddUser adds a user entry with the password to the directory.
+func (d *TestDirectory) AddUser(dn, password string, attributes map[string][]string) {
+	attrs := make(map[string][]string, len(attributes)+1)
+	for k, v := range attributes {
+		attrs[k] = v
+	}
+	attrs["userPassword"] = []string{password}
+	d.AddEntry(dn, attrs)
+}
+
+// Stop the directory.
+func (d *TestDirectory) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.done)
+		_ = d.listener.Close()
+	})
+	d.wg.Wait()
+}
+
+func (d *TestDirectory) serve() {
+	defer d.wg.Done()
+	for {
+		conn, err := d.listener.Accept()
+		if err != nil {
+			return
+		}
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.handle(conn)
+		}()
+	}
+}
+
+func (d *TestDirectory) handle(conn net.Conn) {
+	defer conn.Close()
+	// the directory may be stopped before the client disconnects
+	go func() {
+		<-d.done
+		_ = conn.Close()
+	}()
+	var bound bool
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		if len(packet.Children) < 2 {
+			return
+		}
+		msgId, ok := packet.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+		req := packet.Children[1]
+		switch req.Tag {
+		case testApplicationBindRequest:
+			code := d.bind(req)
+			bound = code == testResultSuccess
+			d.write(conn, testResponse(msgId, testApplicationBindResponse, code))
+		case testApplicationSearchRequest:
+			if !bound {
+				d.write(conn, testResponse(msgId, testApplicationSearchResultDone, testResultInsufficientAccessRight))
+				continue
+			}
+			entries, code := d.search(req)
+			for _, e := range entries {
+				d.write(conn, e.packet(msgId))
+			}
+			d.write(conn, testResponse(msgId, testApplicationSearchResultDone, code))
+		case testApplicationExtendedRequest:
+			// StartTLS and the other extended operations are not supported
+			d.write(conn, testResponse(msgId, testApplicationExtendedResponse, testResultProtocolError))
+		case testApplicationUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (d *TestDirectory) write(w io.Writer, p *ber.Packet) {
+	_, _ = w.Write(p.Bytes())
+}
+
+// bind returns the result code of the bind request. The bind name is matched
+// against the DN and the userPrincipalName of the entries.
+func (d *TestDirectory) bind(req *ber.Packet) int64 {
+	if len(req.Children) < 3 {
+		return testResultProtocolError
+	}
+	name, _ := req.Children[1].Value.(string)
+	password := req.Children[2].Data.String()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if password == "" {
+		if d.allowAnonymous {
+			return testResultSuccess
+		}
+		return testResultUnwillingToPerform
+	}
+	for _, e := range d.entries {
+		if !testDnEqual(e.DN, name) && !testContainsFold(e.Attributes["userPrincipalName"], name) {
+			continue
+		}
+		for _, p := range e.Attributes["userPassword"] {
+			if p == password {
+				return testResultSuccess
+			}
+		}
+	}
+	return testResultInvalidCredentials
+}
+
+// search returns the entries matching the search request.
+func (d *TestDirectory) search(req *ber.Packet) ([]*TestDirectoryEntry, int64) {
+	if len(req.Children) < 8 {
+		return nil, testResultProtocolError
+	}
+	base, _ := req.Children[0].Value.(string)
+	scope, _ := req.Children[1].Value.(int64)
+	filter := req.Children[6]
+	var attrs []string
+	for _, a := range req.Children[7].Children {
+		if s, ok := a.Value.(string); ok {
+			attrs = append(attrs, s)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var baseFound bool
+	var found []*TestDirectoryEntry
+	for _, e := range d.entries {
+		if testDnEqual(e.DN, base) {
+			baseFound = true
+		}
+		switch scope {
+		case testScopeBaseObject:
+			if !testDnEqual(e.DN, base) {
+				continue
+			}
+		case testScopeSingleLevel:
+			parts := strings.SplitN(e.DN, ",", 2)
+			if len(parts) != 2 || !testDnEqual(parts[1], base) {
+				continue
+			}
+		case testScopeWholeSubtree:
+			if base != "" && !testDnEqual(e.DN, base) && !strings.HasSuffix(testNormalizeDn(e.DN), ","+testNormalizeDn(base)) {
+				continue
+			}
+		}
+		if !testMatchFilter(e, filter) {
+			continue
+		}
+		found = append(found, e.withAttributes(attrs))
+	}
+	if scope == testScopeBaseObject && !baseFound {
+		return nil, testResultNoSuchObject
+	}
+	return found, testResultSuccess
+}
+
+// withAttributes returns a copy of the entry with only the requested
+// attributes. The userPassword is never returned.
+func (e *TestDirectoryEntry) withAttributes(attrs []string) *TestDirectoryEntry {
+	cp := &TestDirectoryEntry{DN: e.DN, Attributes: map[string][]string{}}
+	all := len(attrs) == 0 || testContainsFold(attrs, "*")
+	for k, v := range e.Attributes {
+		if strings.EqualFold(k, "userPassword") {
+			continue
+		}
+		if all || testContainsFold(attrs, k) {
+			cp.Attributes[k] = v
+		}
+	}
+	return cp
+}
+
+func (e *TestDirectoryEntry) packet(msgId int64) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgId, "MessageID"))
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, testApplicationSearchResultEntry, nil, "Search Result Entry")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "Object Name"))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for k, vals := range e.Attributes {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, k, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range vals {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	entry.AppendChild(attrs)
+	p.AppendChild(entry)
+	return p
+}
+
+func testResponse(msgId int64, application ber.Tag, code int64) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgId, "MessageID"))
+	resp := ber.Encode(ber.ClassApplication, ber.TypeConstructed, application, nil, "Response")
+	resp.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	resp.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	resp.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	p.AppendChild(resp)
+	return p
+}
+
+func testMatchFilter(e *TestDirectoryEntry, f *ber.Packet) bool {
+	switch f.Tag {
+	case testFilterAnd:
+		for _, c := range f.Children {
+			if !testMatchFilter(e, c) {
+				return false
+			}
+		}
+		return true
+	case testFilterOr:
+		for _, c := range f.Children {
+			if testMatchFilter(e, c) {
+				return true
+			}
+		}
+		return false
+	case testFilterNot:
+		return len(f.Children) == 1 && !testMatchFilter(e, f.Children[0])
+	case testFilterEquality:
+		if len(f.Children) != 2 {
+			return false
+		}
+		attr, _ := f.Children[0].Value.(string)
+		val := f.Children[1].Data.String()
+		for k, vals := range e.Attributes {
+			if strings.EqualFold(k, attr) && testContainsFold(vals, val) {
+				return true
+			}
+		}
+		return false
+	case testFilterSubstrings:
+		if len(f.Children) != 2 {
+			return false
+		}
+		attr, _ := f.Children[0].Value.(string)
+		for k, vals := range e.Attributes {
+			if !strings.EqualFold(k, attr) {
+				continue
+			}
+			for _, v := range vals {
+				if testMatchSubstrings(strings.ToLower(v), f.Children[1].Children) {
+					return true
+				}
+			}
+		}
+		return false
+	case testFilterPresent:
+		attr := f.Data.String()
+		if strings.EqualFold(attr, "objectClass") {
+			return true
+		}
+		for k := range e.Attributes {
+			if strings.EqualFold(k, attr) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func testMatchSubstrings(v string, subs []*ber.Packet) bool {
+	for _, s := range subs {
+		sub := strings.ToLower(s.Data.String())
+		switch s.Tag {
+		case 0: // initial
+			if !strings.HasPrefix(v, sub) {
+				return false
+			}
+			v = v[len(sub):]
+		case 1: // any
+			i := strings.Index(v, sub)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(sub):]
+		case 2: // final
+			if !strings.HasSuffix(v, sub) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func testNormalizeDn(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, p := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(p))
+	}
+	return strings.Join(parts, ",")
+}
+
+func testDnEqual(a, b string) bool {
+	return testNormalizeDn(a) == testNormalizeDn(b)
+}
+
+func testContainsFold(vals []string, v string) bool {
+	for _, s := range vals {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultUrlTableName defines the default table name for a Url
+const defaultUrlTableName = "auth_ldap_url"
+
+// Url defines an LDAP server url for an LDAP AuthMethod. Connections to the
+// urls of an AuthMethod are attempted in ascending ConnectionPriority order.
+// Urls are value objects of an AuthMethod, therefore there's no need for
+// oplog metadata, since only the AuthMethod will have metadata because it's
+// the root aggregate.
+type Url struct {
+	*store.Url
+	tableName string
+}
+
+// NewUrl creates a new in memory url assigned to an LDAP auth method.
+func NewUrl(ctx context.Context, authMethodId string, priority int, u *url.URL) (*Url, error) {
+	const op = "ldap.NewUrl"
+	if u == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing url")
+	}
+	if priority < 1 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "priority must be greater than zero")
+	}
+	l := &Url{
+		Url: &store.Url{
+			LdapMethodId:       authMethodId,
+			Url:                u.String(),
+			ConnectionPriority: uint32(priority),
+		},
+	}
+	if err := l.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return l, nil
+}
+
+// validate the Url and on success return nil
+func (l *Url) validate(ctx context.Context, caller errors.Op) error {
+	if l.LdapMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing ldap auth method id")
+	}
+	if err := validateUrl(ctx, l.Url.Url); err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
+	return nil
+}
+
+// validateUrl ensures u is an ldap or ldaps url
+func validateUrl(ctx context.Context, u string) error {
+	const op = "ldap.validateUrl"
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a valid url", u), errors.WithWrap(err))
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "ldap", "ldaps":
+	default:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q scheme must be ldap or ldaps", u))
+	}
+	if parsed.Host == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is missing a host", u))
+	}
+	return nil
+}
+
+// AllocUrl makes an empty one in memory
+func AllocUrl() Url {
+	return Url{
+		Url: &store.Url{},
+	}
+}
+
+// Clone a Url
+func (l *Url) Clone() *Url {
+	cp := proto.Clone(l.Url)
+	return &Url{
+		Url: cp.(*store.Url),
+	}
+}
+
+// TableName returns the table name.
+func (l *Url) TableName() string {
+	if l.tableName != "" {
+		return l.tableName
+	}
+	return defaultUrlTableName
+}
+
+// SetTableName sets the table name.
+func (l *Url) SetTableName(n string) {
+	l.tableName = n
+}
@@ -0,0 +1,95 @@
+package ldap
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewUrl(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	tests := []struct {
+		name            string
+		authMethodId    string
+		priority        int
+		url             *url.URL
+		wantErrMatch    *errors.Template
+		wantErrContains string
+	}{
+		{
+			name:         "valid-ldap",
+			authMethodId: "amldap_1234567890",
+			priority:     1,
+			url:          TestConvertToUrls(t, "ldap://ldap.example.org")[0],
+		},
+		{
+			name:         "valid-ldaps",
+			authMethodId: "amldap_1234567890",
+			priority:     2,
+			url:          TestConvertToUrls(t, "ldaps://ldap.example.org:636")[0],
+		},
+		{
+			name:            "missing-auth-method",
+			priority:        1,
+			url:             TestConvertToUrls(t, "ldap://ldap.example.org")[0],
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing ldap auth method id",
+		},
+		{
+			name:            "missing-url",
+			authMethodId:    "amldap_1234567890",
+			priority:        1,
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing url",
+		},
+		{
+			name:            "zero-priority",
+			authMethodId:    "amldap_1234567890",
+			url:             TestConvertToUrls(t, "ldap://ldap.example.org")[0],
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "priority must be greater than zero",
+		},
+		{
+			name:            "bad-scheme",
+			authMethodId:    "amldap_1234567890",
+			priority:        1,
+			url:             &url.URL{Scheme: "http", Host: "ldap.example.org"},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "scheme must be ldap or ldaps",
+		},
+		{
+			name:            "missing-host",
+			authMethodId:    "amldap_1234567890",
+			priority:        1,
+			url:             &url.URL{Scheme: "ldap"},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "is missing a host",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewUrl(ctx, tt.authMethodId, tt.priority, tt.url)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "want err code: %q got: %q", tt.wantErrMatch.Code, err)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.authMethodId, got.LdapMethodId)
+			assert.Equal(tt.url.String(), got.GetUrl())
+			assert.Equal(uint32(tt.priority), got.ConnectionPriority)
+			assert.Equal(defaultUrlTableName, got.TableName())
+			assert.True(proto.Equal(got.Url, got.Clone().Url))
+		})
+	}
+}
@@ -24,6 +24,7 @@ type options struct {
 	withName                string
 	withDescription         string
 	withLimit               int
+	withStartPageAfterItem  *string
 	withMaxAge              int
 	withApiUrl              *url.URL
 	withCertificates        []*x509.Certificate
@@ -76,6 +77,15 @@ func WithLimit(l int) Option {
 	}
 }
 
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
 // WithMaxAge provides an optional max age.   Specifies the allowable elapsed
 // time in seconds since the last time the End-User was actively authenticated
 // by the OP. If the elapsed time is greater than this value, the OP MUST
@@ -45,6 +45,14 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 1
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterItem("s_1234567890"))
+		testOpts := getDefaultOptions()
+		id := "s_1234567890"
+		testOpts.withStartPageAfterItem = &id
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithMaxAge", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithMaxAge(1000))
@@ -12,4 +12,12 @@ const (
 			%s
 	returning public_id, version
        `
+
+	rewrapAuthMethodQuery = `
+update auth_oidc_method
+   set client_secret = @client_secret,
+       client_secret_hmac = @client_secret_hmac,
+       key_id = @key_id
+ where public_id = @public_id;
+`
 )
@@ -129,7 +129,8 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 	return a, nil
 }
 
-// ListAccounts in an auth method and supports WithLimit option.
+// ListAccounts in an auth method and supports WithLimit and
+// WithStartPageAfterItem options.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	const op = "oidc.(Repository).ListAccounts"
 	if withAuthMethodId == "" {
@@ -141,8 +142,12 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
 	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []interface{}{withAuthMethodId}, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -27,8 +27,8 @@ func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, opt
 }
 
 // ListAuthMethods returns a slice of AuthMethods for the scopeId. The
-// WithUnauthenticatedUser, WithLimit and WithOrder options are supported and
-//  all other options are ignored.
+// WithUnauthenticatedUser, WithLimit, WithOrder and WithStartPageAfterItem
+// options are supported and all other options are ignored.
 func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
 	const op = "oidc.(Repository).ListAuthMethods"
 	if len(scopeIds) == 0 {
@@ -89,6 +89,9 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		limit = opts.withLimit
 	}
 	dbArgs = append(dbArgs, db.WithLimit(limit))
+	if opts.withStartPageAfterItem != nil {
+		dbArgs = append(dbArgs, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
 
 	if opts.withOrderByCreateTime {
 		if opts.ascending {
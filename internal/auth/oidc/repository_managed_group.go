@@ -93,7 +93,8 @@ func (r *Repository) LookupManagedGroup(ctx context.Context, withPublicId string
 	return a, nil
 }
 
-// ListManagedGroups in an auth method and supports WithLimit option.
+// ListManagedGroups in an auth method and supports WithLimit and
+// WithStartPageAfterItem options.
 func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*ManagedGroup, error) {
 	const op = "oidc.(Repository).ListManagedGroups"
 	if withAuthMethodId == "" {
@@ -105,8 +106,12 @@ func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId str
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
 	var mgs []*ManagedGroup
-	err := r.reader.SearchWhere(ctx, &mgs, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &mgs, "auth_method_id = ?", []interface{}{withAuthMethodId}, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -0,0 +1,60 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func init() {
+	if err := kms.RegisterTableRewrapFn(defaultAuthMethodTableName, rewrapAuthMethods); err != nil {
+		panic(err)
+	}
+}
+
+// rewrapAuthMethods encrypts the client secrets of the auth methods encrypted
+// by the database key version dataKeyVersionId with the current database key
+// version of scopeId and recomputes their hmacs.
+func rewrapAuthMethods(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "oidc.rewrapAuthMethods"
+	var methods []*AuthMethod
+	if err := reader.SearchWhere(ctx, &methods, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(methods) == 0 {
+		return nil
+	}
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for key version"))
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	for _, am := range methods {
+		// the hmac is null when the auth method has no client secret
+		var secretHmac interface{}
+		if len(am.CtClientSecret) > 0 {
+			if err := am.decrypt(ctx, oldWrapper); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if err := am.encrypt(ctx, wrapper); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			secretHmac = am.ClientSecretHmac
+		}
+		if _, err := writer.Exec(ctx, rewrapAuthMethodQuery, []interface{}{
+			sql.Named("client_secret", am.CtClientSecret),
+			sql.Named("client_secret_hmac", secretHmac),
+			sql.Named("key_id", wrapper.KeyID()),
+			sql.Named("public_id", am.PublicId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth method"))
+		}
+	}
+	return nil
+}
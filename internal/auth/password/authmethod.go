@@ -23,7 +23,8 @@ func allocAuthMethod() AuthMethod {
 // NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
 // Name and description are the only valid options. All other options are
 // ignored.  MinLoginNameLength and MinPasswordLength are pre-set to the
-// default values of 5 and 8 respectively.
+// default values of 5 and 8 respectively. LockoutDuration is pre-set to the
+// default value of 900 seconds.
 func NewAuthMethod(scopeId string, opt ...Option) (*AuthMethod, error) {
 	const op = "password.NewAuthMethod"
 	if scopeId == "" {
@@ -38,6 +39,7 @@ func NewAuthMethod(scopeId string, opt ...Option) (*AuthMethod, error) {
 			Description:        opts.withDescription,
 			MinLoginNameLength: 3,
 			MinPasswordLength:  8,
+			LockoutDuration:    900,
 		},
 	}
 	return a, nil
@@ -14,16 +14,19 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName              string
-	withDescription       string
-	withLoginName         string
-	withLimit             int
-	withConfig            Configuration
-	withPublicId          string
-	password              string
-	withPassword          bool
-	withOrderByCreateTime bool
-	ascending             bool
+	withName               string
+	withDescription        string
+	withLoginName          string
+	withLimit              int
+	withStartPageAfterItem *string
+	withConfig             Configuration
+	withPublicId           string
+	password               string
+	withPassword           bool
+	withNewPassword        string
+	withTotpCode           string
+	withOrderByCreateTime  bool
+	ascending              bool
 }
 
 func getDefaultOptions() options {
@@ -69,6 +72,15 @@ func WithLimit(l int) Option {
 	}
 }
 
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
 // WithPassword provides an optional password.
 func WithPassword(password string) Option {
 	return func(o *options) {
@@ -77,6 +89,23 @@ func WithPassword(password string) Option {
 	}
 }
 
+// WithNewPassword provides an optional new password, used to change the
+// password of an account which is required to change its password when it
+// authenticates.
+func WithNewPassword(password string) Option {
+	return func(o *options) {
+		o.withNewPassword = password
+	}
+}
+
+// WithTotpCode provides an optional TOTP code, used to authenticate an
+// account which has a confirmed TOTP secret.
+func WithTotpCode(code string) Option {
+	return func(o *options) {
+		o.withTotpCode = code
+	}
+}
+
 // WithConfiguration provides an optional configuration.
 func WithConfiguration(config Configuration) Option {
 	return func(o *options) {
@@ -39,6 +39,14 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 5
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterItem("s_1234567890"))
+		testOpts := getDefaultOptions()
+		id := "s_1234567890"
+		testOpts.withStartPageAfterItem = &id
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithPassword", func(t *testing.T) {
 		opts := getOpts(WithPassword("test password"))
 		testOpts := getDefaultOptions()
@@ -46,6 +54,18 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPassword = true
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithNewPassword", func(t *testing.T) {
+		opts := getOpts(WithNewPassword("new password"))
+		testOpts := getDefaultOptions()
+		testOpts.withNewPassword = "new password"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithTotpCode", func(t *testing.T) {
+		opts := getOpts(WithTotpCode("123456"))
+		testOpts := getDefaultOptions()
+		testOpts.withTotpCode = "123456"
+		assert.Equal(t, opts, testOpts)
+	})
 	t.Run("WithConfiguration", func(t *testing.T) {
 		conf := NewArgon2Configuration()
 		conf.KeyLength = conf.KeyLength * 2
@@ -0,0 +1,54 @@
+package password
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// validatePassword checks password against the length and character class
+// requirements of the auth method. Returns an error with code
+// PasswordTooShort if password is shorter than MinPasswordLength and an
+// error with code PasswordTooWeak if password does not contain enough
+// characters of each class.
+func (c *currentConfig) validatePassword(ctx context.Context, password string) error {
+	const op = "password.(currentConfig).validatePassword"
+	if c.MinPasswordLength > len(password) {
+		return errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be at least %d", c.MinPasswordLength))
+	}
+
+	var upper, lower, digits, symbols int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsDigit(r):
+			digits++
+		case unicode.IsPunct(r), unicode.IsSymbol(r), unicode.IsSpace(r):
+			symbols++
+		}
+	}
+
+	var missing []string
+	if upper < c.MinUppercase {
+		missing = append(missing, fmt.Sprintf("%d uppercase letters", c.MinUppercase))
+	}
+	if lower < c.MinLowercase {
+		missing = append(missing, fmt.Sprintf("%d lowercase letters", c.MinLowercase))
+	}
+	if digits < c.MinDigits {
+		missing = append(missing, fmt.Sprintf("%d digits", c.MinDigits))
+	}
+	if symbols < c.MinSymbols {
+		missing = append(missing, fmt.Sprintf("%d symbols", c.MinSymbols))
+	}
+	if len(missing) > 0 {
+		return errors.New(ctx, errors.PasswordTooWeak, op, fmt.Sprintf("must contain at least %s", strings.Join(missing, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentConfig_ValidatePassword(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		conf      currentConfig
+		password  string
+		wantIsErr errors.Code
+	}{
+		{
+			name:     "no-policy",
+			conf:     currentConfig{MinPasswordLength: 8},
+			password: "password",
+		},
+		{
+			name:      "too-short",
+			conf:      currentConfig{MinPasswordLength: 8},
+			password:  "passwd",
+			wantIsErr: errors.PasswordTooShort,
+		},
+		{
+			name:      "missing-uppercase",
+			conf:      currentConfig{MinPasswordLength: 8, MinUppercase: 1},
+			password:  "password",
+			wantIsErr: errors.PasswordTooWeak,
+		},
+		{
+			name:      "missing-lowercase",
+			conf:      currentConfig{MinPasswordLength: 8, MinLowercase: 2},
+			password:  "PASSWORd",
+			wantIsErr: errors.PasswordTooWeak,
+		},
+		{
+			name:      "missing-digits",
+			conf:      currentConfig{MinPasswordLength: 8, MinDigits: 2},
+			password:  "password1",
+			wantIsErr: errors.PasswordTooWeak,
+		},
+		{
+			name:      "missing-symbols",
+			conf:      currentConfig{MinPasswordLength: 8, MinSymbols: 1},
+			password:  "Password1",
+			wantIsErr: errors.PasswordTooWeak,
+		},
+		{
+			name: "meets-policy",
+			conf: currentConfig{
+				MinPasswordLength: 8,
+				MinUppercase:      1,
+				MinLowercase:      2,
+				MinDigits:         2,
+				MinSymbols:        1,
+			},
+			password: "Password12!",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			err := tt.conf.validatePassword(ctx, tt.password)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
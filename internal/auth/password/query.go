@@ -11,6 +11,7 @@ select acct.name,                        -- Account.Name
        acct.create_time,                 -- Account.CreateTime
        acct.update_time,                 -- Account.UpdateTime
        acct.version,                     -- Account.Version
+       acct.password_change_required,    -- Account.PasswordChangeRequired
        cred.private_id as credential_id, -- Account.CredentialId
        cred.private_id,                  -- Argon2Credential.PrivateId
        cred.password_conf_id,            -- Argon2Credential.PasswordConfId
@@ -20,16 +21,106 @@ select acct.name,                        -- Account.Name
        conf.iterations,                  -- Argon2Configuration.Iterations
        conf.memory,                      -- Argon2Configuration.Memory
        conf.threads,                     -- Argon2Configuration.Threads
-       meth.password_conf_id = cred.password_conf_id as is_current_conf
+       meth.password_conf_id = cred.password_conf_id as is_current_conf,
+       meth.max_failed_attempts,
+       meth.lockout_duration,
+       meth.totp_required,
+       coalesce(lockout.locked_until > current_timestamp, false) as is_locked
   from auth_password_argon2_cred cred,
        auth_password_argon2_conf conf,
-       auth_password_account acct,
+       auth_password_account acct
+       left join auth_password_account_lockout lockout
+              on acct.public_id = lockout.password_account_id,
        auth_password_method meth
  where acct.auth_method_id = @auth_method_id
    and acct.login_name = @login_name
    and cred.password_conf_id = conf.private_id
    and cred.password_account_id = acct.public_id
    and acct.auth_method_id = meth.public_id ;
+`
+	passwordHistoryQuery = `
+with
+history (private_id, password_conf_id, salt, derived_key, key_id) as (
+    select private_id, password_conf_id, salt, derived_key, key_id
+      from auth_password_argon2_cred
+     where password_account_id = @account_id
+  union all (
+    select private_id, password_conf_id, salt, derived_key, key_id
+      from auth_password_argon2_cred_history
+     where password_account_id = @account_id
+  order by create_time desc
+     limit @history_limit
+  )
+)
+select history.private_id,         -- Argon2Credential.PrivateId
+       history.password_conf_id,   -- Argon2Credential.PasswordConfId
+       history.salt,               -- Argon2Credential.CtSalt/Salt
+       history.derived_key,        -- Argon2Credential.DerivedKey
+       history.key_id,             -- Argon2Credential.KeyId
+       conf.key_length,            -- Argon2Configuration.KeyLength
+       conf.iterations,            -- Argon2Configuration.Iterations
+       conf.memory,                -- Argon2Configuration.Memory
+       conf.threads                -- Argon2Configuration.Threads
+  from history
+  join auth_password_argon2_conf conf
+    on history.password_conf_id = conf.private_id;
+`
+	recordFailedAttemptQuery = `
+insert into auth_password_account_lockout as lockout
+       (password_account_id, failed_attempts, last_failed_time)
+values (@account_id, 1, current_timestamp)
+    on conflict (password_account_id) do update
+   set failed_attempts  = lockout.failed_attempts + 1,
+       last_failed_time = current_timestamp;
+`
+	lockAccountQuery = `
+update auth_password_account_lockout
+   set failed_attempts = 0,
+       locked_until    = current_timestamp + make_interval(secs => @lockout_duration)
+ where password_account_id = @account_id
+   and failed_attempts >= @max_failed_attempts;
+`
+	clearLockoutQuery = `
+delete from auth_password_account_lockout
+ where password_account_id = @account_id;
+`
+	useTotpCodeQuery = `
+update auth_password_totp
+   set last_used_step  = @step,
+       failed_attempts = 0
+ where password_account_id = @account_id
+   and confirmed
+   and last_used_step < @step
+   and (locked_until is null or locked_until <= current_timestamp);
+`
+	// confirmTotpCodeQuery confirms the secret of @account_id when its first
+	// code is used to authenticate.
+	confirmTotpCodeQuery = `
+update auth_password_totp
+   set confirmed       = true,
+       last_used_step  = @step,
+       failed_attempts = 0
+ where password_account_id = @account_id
+   and not confirmed
+   and last_used_step < @step
+   and (locked_until is null or locked_until <= current_timestamp);
+`
+	// recordTotpFailureQuery counts an invalid code for @account_id and
+	// locks its secret for @lockout_duration seconds once
+	// @max_failed_attempts codes have failed. Codes tried while the secret
+	// is locked are not counted, so the lock is not extended by them.
+	recordTotpFailureQuery = `
+update auth_password_totp
+   set failed_attempts = case when failed_attempts + 1 >= @max_failed_attempts
+                              then 0
+                              else failed_attempts + 1
+                          end,
+       locked_until    = case when failed_attempts + 1 >= @max_failed_attempts
+                              then current_timestamp + make_interval(secs => @lockout_duration)
+                              else locked_until
+                          end
+ where password_account_id = @account_id
+   and (locked_until is null or locked_until <= current_timestamp);
 `
 	currentConfigForAccountQuery = `
 select *
@@ -40,5 +131,28 @@ select *
          from auth_password_account
         where public_id = @public_id
     );
+`
+	// argon2CredentialKeyIdQuery selects the salts of the credentials of a
+	// table encrypted by a key version. The table is formatted into the
+	// query.
+	argon2CredentialKeyIdQuery = `
+select private_id, salt, key_id
+  from %s
+ where key_id = @key_id;
+`
+	// rewrapArgon2CredentialQuery updates the salt of a credential of a table
+	// and the key version which encrypts it. The table is formatted into the
+	// query.
+	rewrapArgon2CredentialQuery = `
+update %s
+   set salt = @salt,
+       key_id = @key_id
+ where private_id = @private_id;
+`
+	rewrapTotpQuery = `
+update auth_password_totp
+   set secret = @secret,
+       key_id = @key_id
+ where password_account_id = @account_id;
 `
 )
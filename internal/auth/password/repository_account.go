@@ -75,8 +75,8 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 
 	var cred *Argon2Credential
 	if opts.withPassword {
-		if cc.MinPasswordLength > len(opts.password) {
-			return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be longer than %v", cc.MinPasswordLength))
+		if err := cc.validatePassword(ctx, opts.password); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
 		}
 		if cred, err = newArgon2Credential(a.PublicId, opts.password, cc.argon2()); err != nil {
 			return nil, errors.Wrap(ctx, err, op)
@@ -142,7 +142,8 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 	return a, nil
 }
 
-// ListAccounts in an auth method and supports WithLimit option.
+// ListAccounts in an auth method and supports WithLimit and
+// WithStartPageAfterItem options.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	const op = "password.(Repository).ListAccounts"
 	if withAuthMethodId == "" {
@@ -154,8 +155,12 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
 	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []interface{}{withAuthMethodId}, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -220,14 +225,16 @@ func validLoginName(u string) bool {
 // Account containing the updated values and a count of the number of
 // records updated. a is not changed.
 //
-// a must contain a valid PublicId. Only a.Name, a.Description and
-// a.LoginName can be updated. If a.Name is set to a non-empty string, it
-// must be unique within a.AuthMethodId. If a.LoginName is set to a
-// non-empty string, it must be unique within a.AuthMethodId.
+// a must contain a valid PublicId. Only a.Name, a.Description,
+// a.LoginName and a.PasswordChangeRequired can be updated. If a.Name is set
+// to a non-empty string, it must be unique within a.AuthMethodId. If
+// a.LoginName is set to a non-empty string, it must be unique within
+// a.AuthMethodId.
 //
 // An attribute of a will be set to NULL in the database if the attribute
 // in a is the zero value and it is included in fieldMaskPaths. a.LoginName
-// cannot be set to NULL.
+// cannot be set to NULL. a.PasswordChangeRequired is set to false instead of
+// NULL.
 func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Account, version uint32, fieldMaskPaths []string, opt ...Option) (*Account, int, error) {
 	const op = "password.(Repository).UpdateAccount"
 	if a == nil {
@@ -257,6 +264,7 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 					fmt.Sprintf("invalid username: must be all-lowercase alphanumeric, period or hyphen, got %s", a.LoginName))
 			}
 			changeLoginName = true
+		case strings.EqualFold("PasswordChangeRequired", f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -264,12 +272,13 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":        a.Name,
-			"Description": a.Description,
-			"LoginName":   a.LoginName,
+			"Name":                   a.Name,
+			"Description":            a.Description,
+			"LoginName":              a.LoginName,
+			"PasswordChangeRequired": a.PasswordChangeRequired,
 		},
 		fieldMaskPaths,
-		nil,
+		[]string{"PasswordChangeRequired"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
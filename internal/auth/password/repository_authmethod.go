@@ -108,8 +108,8 @@ func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, _ ..
 	return r.lookupAuthMethod(ctx, publicId)
 }
 
-// ListAuthMethods returns a slice of AuthMethods for the scopeId. WithLimit and
-// WithOrder options are the only option supported.
+// ListAuthMethods returns a slice of AuthMethods for the scopeId. WithLimit,
+// WithOrder and WithStartPageAfterItem options are the only option supported.
 func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
 	const op = "password.(Repository).ListAuthMethods"
 	if len(scopeIds) == 0 {
@@ -173,9 +173,14 @@ func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId str
 // not be set to null, but instead use the default values returned by
 // NewAuthMethod.  fieldMaskPaths provides field_mask.proto paths for fields
 // that should be updated.  Fields will be set to NULL if the field is a zero
-// value and included in fieldMask. Name, Description, MinPasswordLength,
-// and MinLoginNameLength are the only updatable fields, If no updatable fields
-// are included in the fieldMaskPaths, then an error is returned.
+// value and included in fieldMask. The password policy fields MinUppercase,
+// MinLowercase, MinDigits, MinSymbols, PasswordHistoryCount and
+// MaxFailedAttempts are set to zero instead, which disables that part of the
+// policy, and TotpRequired is set to false. LockoutDuration should not be set
+// to null, but instead use the default value returned by NewAuthMethod.
+// Name, Description, MinPasswordLength, MinLoginNameLength, TotpRequired and
+// the password policy fields are the only updatable fields, If no updatable
+// fields are included in the fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
 	const op = "password.(Repository).UpdateAuthMethod"
 	if authMethod == nil {
@@ -193,6 +198,14 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 		case strings.EqualFold("Description", f):
 		case strings.EqualFold("MinLoginNameLength", f):
 		case strings.EqualFold("MinPasswordLength", f):
+		case strings.EqualFold("MinUppercase", f):
+		case strings.EqualFold("MinLowercase", f):
+		case strings.EqualFold("MinDigits", f):
+		case strings.EqualFold("MinSymbols", f):
+		case strings.EqualFold("PasswordHistoryCount", f):
+		case strings.EqualFold("MaxFailedAttempts", f):
+		case strings.EqualFold("LockoutDuration", f):
+		case strings.EqualFold("TotpRequired", f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -200,13 +213,21 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":               authMethod.Name,
-			"Description":        authMethod.Description,
-			"MinPasswordLength":  authMethod.MinPasswordLength,
-			"MinLoginNameLength": authMethod.MinLoginNameLength,
+			"Name":                 authMethod.Name,
+			"Description":          authMethod.Description,
+			"MinPasswordLength":    authMethod.MinPasswordLength,
+			"MinLoginNameLength":   authMethod.MinLoginNameLength,
+			"MinUppercase":         authMethod.MinUppercase,
+			"MinLowercase":         authMethod.MinLowercase,
+			"MinDigits":            authMethod.MinDigits,
+			"MinSymbols":           authMethod.MinSymbols,
+			"PasswordHistoryCount": authMethod.PasswordHistoryCount,
+			"MaxFailedAttempts":    authMethod.MaxFailedAttempts,
+			"LockoutDuration":      authMethod.LockoutDuration,
+			"TotpRequired":         authMethod.TotpRequired,
 		},
 		fieldMaskPaths,
-		nil,
+		[]string{"MinUppercase", "MinLowercase", "MinDigits", "MinSymbols", "PasswordHistoryCount", "MaxFailedAttempts", "TotpRequired"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "field mask must not be empty")
@@ -314,6 +335,9 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		limit = opts.withLimit
 	}
 	dbArgs = append(dbArgs, db.WithLimit(limit))
+	if opts.withStartPageAfterItem != nil {
+		dbArgs = append(dbArgs, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
 
 	if opts.withOrderByCreateTime {
 		if opts.ascending {
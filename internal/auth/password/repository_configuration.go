@@ -120,9 +120,16 @@ func (r *Repository) setArgon2Conf(ctx context.Context, scopeId string, c *Argon
 }
 
 type currentConfig struct {
-	ConfType           string
-	MinLoginNameLength int
-	MinPasswordLength  int
+	ConfType             string
+	MinLoginNameLength   int
+	MinPasswordLength    int
+	MinUppercase         int
+	MinLowercase         int
+	MinDigits            int
+	MinSymbols           int
+	PasswordHistoryCount int
+	MaxFailedAttempts    int
+	LockoutDuration      int
 
 	*Argon2Configuration
 }
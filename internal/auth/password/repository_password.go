@@ -18,7 +18,22 @@ type authAccount struct {
 	*Account
 	*Argon2Credential
 	*Argon2Configuration
-	IsCurrentConf bool
+	IsCurrentConf     bool
+	IsLocked          bool
+	MaxFailedAttempts int
+	LockoutDuration   int
+	TotpRequired      bool
+}
+
+type historyCredential struct {
+	*Argon2Credential
+	*Argon2Configuration
+}
+
+// passwordMatches reports whether password derives the same key as cred.
+func passwordMatches(password string, cred *Argon2Credential, conf *Argon2Configuration) bool {
+	inputKey := argon2.IDKey([]byte(password), cred.Salt, conf.Iterations, conf.Memory, uint8(conf.Threads), conf.KeyLength)
+	return subtle.ConstantTimeCompare(inputKey, cred.DerivedKey) == 1
 }
 
 // Authenticate authenticates loginName and password match for loginName in
@@ -32,7 +47,30 @@ type authAccount struct {
 // Authenticate will update the stored values for password to the current
 // password settings for authMethodId if authentication is successful and
 // the stored values are not using the current password settings.
-func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*Account, error) {
+//
+// If the auth method has MaxFailedAttempts set, consecutive failed attempts
+// are recorded for the account and the account is locked for
+// LockoutDuration seconds once MaxFailedAttempts is reached. Authenticate
+// returns nil for a locked account. A successful authentication clears the
+// failed attempts.
+//
+// If the account has PasswordChangeRequired set, an error with code
+// PasswordChangeRequired is returned unless WithNewPassword is provided, in
+// which case the password for the account is changed to the new password
+// and PasswordChangeRequired is cleared. WithNewPassword is ignored for
+// accounts without PasswordChangeRequired set.
+//
+// If the account has a confirmed TOTP secret, an error with code
+// TotpRequired is returned unless WithTotpCode is provided. An invalid or
+// reused TOTP code is treated as a failed attempt, and is also counted
+// against the TOTP secret, which accepts no code for a while after too many
+// invalid codes even if the auth method has no MaxFailedAttempts. A valid
+// code for an unconfirmed TOTP secret confirms it. If the auth method has
+// TotpRequired set and the account has no confirmed TOTP secret, an error
+// with code TotpNotEnrolled is returned unless WithTotpCode is provided for
+// an unconfirmed secret. WithNewPassword and WithTotpCode are the only valid
+// options.
+func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string, opt ...Option) (*Account, error) {
 	const op = "password.(Repository).Authenticate"
 	if authMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing authMethodId", errors.WithoutEvent())
@@ -52,13 +90,47 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
 	}
 
-	acct, err := r.authenticate(ctx, scopeId, authMethodId, loginName, password)
+	acct, err := r.lookupAuthAccount(ctx, scopeId, authMethodId, loginName)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	if acct == nil {
+	if acct == nil || acct.IsLocked {
 		return nil, nil
 	}
+	if !passwordMatches(password, acct.Argon2Credential, acct.Argon2Configuration) {
+		if err := r.recordFailedAttempt(ctx, acct); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return nil, nil
+	}
+	opts := getOpts(opt...)
+	ok, err := r.verifyTotp(ctx, scopeId, acct, opts.withTotpCode)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if !ok {
+		if err := r.recordFailedAttempt(ctx, acct); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return nil, nil
+	}
+	if _, err := r.writer.Exec(ctx, clearLockoutQuery, []interface{}{sql.Named("account_id", acct.PublicId)}); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to clear failed attempts"))
+	}
+
+	if acct.PasswordChangeRequired {
+		if opts.withNewPassword == "" {
+			return nil, errors.New(ctx, errors.PasswordChangeRequired, op, "a new password must be provided", errors.WithoutEvent())
+		}
+		if password == opts.withNewPassword {
+			return nil, errors.New(ctx, errors.PasswordsEqual, op, "passwords must not equal", errors.WithoutEvent())
+		}
+		updatedAccount, err := r.replaceCredential(ctx, scopeId, acct, opts.withNewPassword, acct.Version)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return updatedAccount, nil
+	}
 
 	if !acct.IsCurrentConf {
 		cc, err := r.currentConfig(ctx, authMethodId)
@@ -110,6 +182,12 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 // Returns nil, db.ErrorRecordNotFound if the account doesn't exist.
 // Returns nil, nil if old does not match the stored password for accountId.
 // Returns nil, error with code PasswordsEqual if old and new are equal.
+// Returns nil, error with code PasswordTooShort or PasswordTooWeak if new
+// does not meet the password policy of the auth method and code
+// PasswordReused if new matches a recent password for accountId.
+//
+// PasswordChangeRequired is cleared for the account if the password is
+// successfully changed.
 func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old, new string, version uint32) (*Account, error) {
 	const op = "password.(Repository).ChangePassword"
 	if accountId == "" {
@@ -139,15 +217,6 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 		return nil, errors.New(ctx, errors.RecordNotFound, op, "account not found")
 	}
 
-	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
-	}
-	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
-	}
-
 	acct, err := r.authenticate(ctx, scopeId, authAccount.GetAuthMethodId(), authAccount.GetLoginName(), old)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -156,18 +225,43 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 		return nil, nil
 	}
 
-	cc, err := r.currentConfig(ctx, authAccount.GetAuthMethodId())
+	updatedAccount, err := r.replaceCredential(ctx, scopeId, acct, new, version)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return updatedAccount, nil
+}
+
+// replaceCredential replaces the current credential of acct with a new
+// credential for password, after checking password against the password
+// policy and password history of the auth method. PasswordChangeRequired is
+// cleared for the account. The account is returned with its new
+// CredentialId.
+func (r *Repository) replaceCredential(ctx context.Context, scopeId string, acct *authAccount, password string, version uint32) (*Account, error) {
+	const op = "password.(Repository).replaceCredential"
+	cc, err := r.currentConfig(ctx, acct.GetAuthMethodId())
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("retrieve current password configuration"))
 	}
-	if cc.MinPasswordLength > len(new) {
-		return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be at least %d", cc.MinPasswordLength))
+	if err := cc.validatePassword(ctx, password); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := r.checkPasswordHistory(ctx, scopeId, acct.PublicId, password, cc.PasswordHistoryCount); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
 	}
-	newCred, err := newArgon2Credential(accountId, new, cc.argon2())
+	newCred, err := newArgon2Credential(acct.PublicId, password, cc.argon2())
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	}
 	if err := newCred.encrypt(ctx, databaseWrapper); err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
 	}
@@ -178,9 +272,9 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) error {
 			updatedAccount = allocAccount()
-			updatedAccount.PublicId = accountId
+			updatedAccount.PublicId = acct.PublicId
 			updatedAccount.Version = version + 1
-			rowsUpdated, err := w.Update(ctx, updatedAccount, []string{"Version"}, nil, db.WithOplog(oplogWrapper, acct.Account.oplog(oplog.OpType_OP_TYPE_UPDATE)), db.WithVersion(&version))
+			rowsUpdated, err := w.Update(ctx, updatedAccount, []string{"Version", "PasswordChangeRequired"}, nil, db.WithOplog(oplogWrapper, acct.Account.oplog(oplog.OpType_OP_TYPE_UPDATE)), db.WithVersion(&version))
 			if err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update account version"))
 			}
@@ -210,8 +304,30 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	return updatedAccount, nil
 }
 
+// authenticate returns the account for loginName in authMethodId if
+// password matches the account's current password. Returns nil if the
+// passwords do not match or the account is locked.
 func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*authAccount, error) {
 	const op = "password.(Repository).authenticate"
+	acct, err := r.lookupAuthAccount(ctx, scopeId, authMethodId, loginName)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil || acct.IsLocked {
+		return nil, nil
+	}
+	if !passwordMatches(password, acct.Argon2Credential, acct.Argon2Configuration) {
+		// authentication failed, password does not match
+		return nil, nil
+	}
+	return acct, nil
+}
+
+// lookupAuthAccount returns the account for loginName in authMethodId with
+// its current credential decrypted. Returns nil if the account does not
+// exist or does not have a password.
+func (r *Repository) lookupAuthAccount(ctx context.Context, scopeId, authMethodId, loginName string) (*authAccount, error) {
+	const op = "password.(Repository).lookupAuthAccount"
 	var accts []authAccount
 
 	rows, err := r.reader.Query(ctx, authenticateQuery, []interface{}{sql.Named("auth_method_id", authMethodId), sql.Named("login_name", loginName)})
@@ -248,17 +364,87 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 	if err := acct.decrypt(ctx, databaseWrapper); err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt credential"))
 	}
+	return &acct, nil
+}
 
-	inputKey := argon2.IDKey([]byte(password), acct.Salt, acct.Iterations, acct.Memory, uint8(acct.Threads), acct.KeyLength)
-	if subtle.ConstantTimeCompare(inputKey, acct.DerivedKey) == 0 {
-		// authentication failed, password does not match
-		return nil, nil
+// recordFailedAttempt records a failed authentication attempt for acct and
+// locks acct if it has reached the maximum number of failed attempts for its
+// auth method. It does nothing if lockout is disabled for the auth method.
+func (r *Repository) recordFailedAttempt(ctx context.Context, acct *authAccount) error {
+	const op = "password.(Repository).recordFailedAttempt"
+	if acct.MaxFailedAttempts == 0 {
+		return nil
 	}
-	return &acct, nil
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, recordFailedAttemptQuery, []interface{}{
+				sql.Named("account_id", acct.PublicId),
+			}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to record failed attempt"))
+			}
+			if _, err := w.Exec(ctx, lockAccountQuery, []interface{}{
+				sql.Named("account_id", acct.PublicId),
+				sql.Named("max_failed_attempts", acct.MaxFailedAttempts),
+				sql.Named("lockout_duration", acct.LockoutDuration),
+			}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lock account"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// checkPasswordHistory returns an error with code PasswordReused if
+// password matches the current password of accountId or one of its
+// previous passwords. historyCount is the number of passwords checked,
+// including the current password. It does nothing if historyCount is 0.
+func (r *Repository) checkPasswordHistory(ctx context.Context, scopeId, accountId, password string, historyCount int) error {
+	const op = "password.(Repository).checkPasswordHistory"
+	if historyCount == 0 {
+		return nil
+	}
+
+	var creds []historyCredential
+	rows, err := r.reader.Query(ctx, passwordHistoryQuery, []interface{}{
+		sql.Named("account_id", accountId),
+		sql.Named("history_limit", historyCount-1),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hc historyCredential
+		if err := r.reader.ScanRows(rows, &hc); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		creds = append(creds, hc)
+	}
+
+	for _, hc := range creds {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(hc.GetKeyId()))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := hc.decrypt(ctx, databaseWrapper); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt credential"))
+		}
+		if passwordMatches(password, hc.Argon2Credential, hc.Argon2Configuration) {
+			return errors.New(ctx, errors.PasswordReused, op, fmt.Sprintf("must not match any of the last %d passwords", historyCount))
+		}
+	}
+	return nil
 }
 
 // SetPassword sets the password for accountId to password. If password
 // contains an empty string, the password for accountId will be deleted.
+// password must meet the password policy of the auth method and must not
+// match a recent password for accountId. Any failed authentication attempts
+// recorded for accountId are cleared, unlocking the account.
 func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, password string, version uint32) (*Account, error) {
 	const op = "password.(Repository).SetPassword"
 	if accountId == "" {
@@ -289,8 +475,11 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 		if cc == nil {
 			return nil, errors.New(ctx, errors.RecordNotFound, op, "unable to retrieve current configuration")
 		}
-		if cc.MinPasswordLength > len(password) {
-			return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("password must be at least %v", cc.MinPasswordLength))
+		if err := cc.validatePassword(ctx, password); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if err := r.checkPasswordHistory(ctx, scopeId, accountId, password, cc.PasswordHistoryCount); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
 		}
 		newCred, err = newArgon2Credential(accountId, password, cc.argon2())
 		if err != nil {
@@ -316,6 +505,10 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 			}
 			acct = updatedAccount
 
+			if _, err := w.Exec(ctx, clearLockoutQuery, []interface{}{sql.Named("account_id", accountId)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to clear failed attempts"))
+			}
+
 			oldCred := allocCredential()
 			if err := rr.LookupWhere(ctx, &oldCred, "password_account_id = ?", accountId); err != nil {
 				if !errors.IsNotFoundError(err) {
@@ -554,3 +554,147 @@ func TestRepository_SetPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_AuthenticateLockout(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	authMethod.MaxFailedAttempts = 3
+	authMethod, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version, []string{"MaxFailedAttempts"})
+	require.NoError(t, err)
+
+	passwd := "12345678"
+	acct := TestAccount(t, conn, authMethod.PublicId, "kazmierczak")
+	acct, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, passwd, acct.Version)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+	for i := 0; i < 2; i++ {
+		got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", "wrong-password")
+		require.NoError(err)
+		assert.Nil(got)
+	}
+	// A successful attempt clears the failed attempts.
+	got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	require.NoError(err)
+	assert.NotNil(got)
+
+	for i := 0; i < 3; i++ {
+		got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", "wrong-password")
+		require.NoError(err)
+		assert.Nil(got)
+	}
+	// The account is now locked, even for the correct password.
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	require.NoError(err)
+	assert.Nil(got)
+
+	// Setting the password unlocks the account.
+	acct, err = repo.LookupAccount(ctx, acct.PublicId)
+	require.NoError(err)
+	_, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "abcdefgh", acct.Version)
+	require.NoError(err)
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", "abcdefgh")
+	require.NoError(err)
+	assert.NotNil(got)
+}
+
+func TestRepository_AuthenticatePasswordChangeRequired(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	passwd := "12345678"
+	acct := TestAccount(t, conn, authMethod.PublicId, "kazmierczak")
+	acct, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, passwd, acct.Version)
+	require.NoError(t, err)
+	acct.PasswordChangeRequired = true
+	acct, _, err = repo.UpdateAccount(ctx, o.GetPublicId(), acct, acct.Version, []string{"PasswordChangeRequired"})
+	require.NoError(t, err)
+	require.True(t, acct.PasswordChangeRequired)
+
+	assert, require := assert.New(t), require.New(t)
+	got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordChangeRequired), err), "Unexpected error %s", err)
+	assert.Nil(got)
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd, WithNewPassword("short"))
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordTooShort), err), "Unexpected error %s", err)
+	assert.Nil(got)
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd, WithNewPassword("abcdefgh"))
+	require.NoError(err)
+	require.NotNil(got)
+	assert.False(got.PasswordChangeRequired)
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", "abcdefgh")
+	require.NoError(err)
+	assert.NotNil(got)
+}
+
+func TestRepository_PasswordPolicy(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	authMethod.MinUppercase = 1
+	authMethod.MinDigits = 1
+	authMethod.PasswordHistoryCount = 3
+	authMethod, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version, []string{"MinUppercase", "MinDigits", "PasswordHistoryCount"})
+	require.NoError(t, err)
+
+	acct := TestAccount(t, conn, authMethod.PublicId, "kazmierczak")
+
+	assert, require := assert.New(t), require.New(t)
+	_, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "abcdefgh", acct.Version)
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordTooWeak), err), "Unexpected error %s", err)
+
+	acct, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "Password1", acct.Version)
+	require.NoError(err)
+	acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Password1", "Password2", acct.Version)
+	require.NoError(err)
+	require.NotNil(acct)
+	acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Password2", "Password3", acct.Version)
+	require.NoError(err)
+	require.NotNil(acct)
+
+	// The last three passwords cannot be reused.
+	_, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Password3", "Password1", acct.Version)
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordReused), err), "Unexpected error %s", err)
+
+	acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Password3", "Password4", acct.Version)
+	require.NoError(err)
+	require.NotNil(acct)
+	acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Password4", "Password1", acct.Version)
+	require.NoError(err)
+	assert.NotNil(acct)
+}
@@ -0,0 +1,322 @@
+package password
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// EnrollTotp generates a new TOTP secret for accountId and returns an
+// otpauth URL for the secret and the secret encoded as base32. The secret
+// is not used to authenticate accountId until it has been confirmed with
+// ConfirmTotp. An unconfirmed secret for accountId is replaced.
+//
+// Returns an error with code RecordNotFound if the account does not exist
+// and code TotpAlreadyEnrolled if accountId already has a confirmed secret.
+func (r *Repository) EnrollTotp(ctx context.Context, scopeId, accountId string) (string, string, error) {
+	const op = "password.(Repository).EnrollTotp"
+	if accountId == "" {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, "missing accountId")
+	}
+	if scopeId == "" {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, "missing scopeId")
+	}
+
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return "", "", errors.New(ctx, errors.RecordNotFound, op, "account not found")
+	}
+
+	oldTotp, err := r.lookupTotp(ctx, accountId)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op)
+	}
+	if oldTotp != nil && oldTotp.Confirmed {
+		return "", "", errors.New(ctx, errors.TotpAlreadyEnrolled, op, "account already has a confirmed totp secret")
+	}
+
+	totp, err := newTotp(ctx, accountId)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := totp.encrypt(ctx, databaseWrapper); err != nil {
+		return "", "", errors.Wrap(ctx, err, op)
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if oldTotp != nil {
+				dTotp := oldTotp.clone()
+				rowsDeleted, err := w.Delete(ctx, dTotp, db.WithOplog(oplogWrapper, oldTotp.oplog(oplog.OpType_OP_TYPE_DELETE)))
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if rowsDeleted > 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+				}
+			}
+			if err := w.Create(ctx, totp, db.WithOplog(oplogWrapper, totp.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create totp secret"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op)
+	}
+	return totp.url(acct.LoginName), totp.encodedSecret(), nil
+}
+
+// EnrollTotpWithPassword authenticates loginName and password in
+// authMethodId and enrolls a new TOTP secret for the account as EnrollTotp
+// does. It lets the user of an account enroll without an auth token, which
+// cannot be issued to an account without a confirmed secret if its auth
+// method requires TOTP. The secret is confirmed the first time the account
+// authenticates with a valid code.
+//
+// A failed authentication is recorded as for Authenticate and empty strings
+// are returned. Returns an error with code TotpAlreadyEnrolled if the
+// account already has a confirmed secret.
+func (r *Repository) EnrollTotpWithPassword(ctx context.Context, scopeId, authMethodId, loginName, password string) (string, string, error) {
+	const op = "password.(Repository).EnrollTotpWithPassword"
+	if authMethodId == "" {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, "missing authMethodId", errors.WithoutEvent())
+	}
+	if loginName == "" {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, "missing loginName", errors.WithoutEvent())
+	}
+	if password == "" {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, "missing password", errors.WithoutEvent())
+	}
+	if scopeId == "" {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, "missing scopeId", errors.WithoutEvent())
+	}
+
+	acct, err := r.lookupAuthAccount(ctx, scopeId, authMethodId, loginName)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op)
+	}
+	if acct == nil || acct.IsLocked {
+		return "", "", nil
+	}
+	if !passwordMatches(password, acct.Argon2Credential, acct.Argon2Configuration) {
+		if err := r.recordFailedAttempt(ctx, acct); err != nil {
+			return "", "", errors.Wrap(ctx, err, op)
+		}
+		return "", "", nil
+	}
+	url, secret, err := r.EnrollTotp(ctx, scopeId, acct.PublicId)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op)
+	}
+	return url, secret, nil
+}
+
+// ConfirmTotp confirms the unconfirmed TOTP secret for accountId if code is
+// valid for the secret. Once confirmed, a TOTP code is required to
+// authenticate accountId.
+//
+// Returns an error with code RecordNotFound if accountId has no TOTP secret,
+// code TotpAlreadyEnrolled if the secret has already been confirmed and
+// code InvalidParameter if code is not valid.
+func (r *Repository) ConfirmTotp(ctx context.Context, scopeId, accountId, code string) error {
+	const op = "password.(Repository).ConfirmTotp"
+	if accountId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing accountId")
+	}
+	if code == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing code")
+	}
+	if scopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scopeId")
+	}
+
+	totp, err := r.lookupTotp(ctx, accountId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case totp == nil:
+		return errors.New(ctx, errors.RecordNotFound, op, "account has no totp secret")
+	case totp.Confirmed:
+		return errors.New(ctx, errors.TotpAlreadyEnrolled, op, "totp secret already confirmed")
+	}
+	if err := r.decryptTotp(ctx, scopeId, totp); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	step, ok := totp.verify(code, time.Now())
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "invalid code", errors.WithoutEvent())
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	updatedTotp := allocTotp()
+	updatedTotp.PasswordAccountId = accountId
+	updatedTotp.Confirmed = true
+	updatedTotp.LastUsedStep = step
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			rowsUpdated, err := w.Update(ctx, updatedTotp, []string{"Confirmed", "LastUsedStep"}, nil, db.WithOplog(oplogWrapper, updatedTotp.oplog(oplog.OpType_OP_TYPE_UPDATE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// DeleteTotp deletes the TOTP secret for accountId, confirmed or not,
+// returning a count of the number of records deleted.
+func (r *Repository) DeleteTotp(ctx context.Context, scopeId, accountId string) (int, error) {
+	const op = "password.(Repository).DeleteTotp"
+	if accountId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing accountId")
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scopeId")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	totp := allocTotp()
+	totp.PasswordAccountId = accountId
+	var rowsDeleted int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			dTotp := totp.clone()
+			rowsDeleted, err = w.Delete(ctx, dTotp, db.WithOplog(oplogWrapper, totp.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(accountId))
+	}
+	return rowsDeleted, nil
+}
+
+// verifyTotp checks the TOTP secret of acct before it is allowed to
+// authenticate. It does nothing if acct has no confirmed secret, code is
+// empty and its auth method does not require TOTP.
+//
+// A valid code for an unconfirmed secret confirms the secret, so an account
+// enrolled with EnrollTotpWithPassword completes its enrollment the first
+// time it authenticates with a code.
+//
+// Invalid and reused codes are counted for acct whatever the lockout
+// settings of its auth method: once totpMaxFailedAttempts codes have
+// failed, no code is accepted for acct for totpLockoutDuration seconds.
+//
+// Returns an error with code TotpRequired if acct has a confirmed secret
+// and code is empty, and code TotpNotEnrolled if the auth method requires
+// TOTP, acct has no confirmed secret and code is empty. Returns false if
+// code is not valid, has already been used or acct's secret is locked.
+func (r *Repository) verifyTotp(ctx context.Context, scopeId string, acct *authAccount, code string) (bool, error) {
+	const op = "password.(Repository).verifyTotp"
+	totp, err := r.lookupTotp(ctx, acct.PublicId)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case totp == nil || (!totp.Confirmed && code == ""):
+		if acct.TotpRequired {
+			return false, errors.New(ctx, errors.TotpNotEnrolled, op, "auth method requires totp", errors.WithoutEvent())
+		}
+		return true, nil
+	case code == "":
+		return false, errors.New(ctx, errors.TotpRequired, op, "a totp code must be provided", errors.WithoutEvent())
+	}
+
+	if err := r.decryptTotp(ctx, scopeId, totp); err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	if step, ok := totp.verify(code, time.Now()); ok {
+		query := useTotpCodeQuery
+		if !totp.Confirmed {
+			query = confirmTotpCodeQuery
+		}
+		// Recording the step prevents the same code from being used twice,
+		// even by concurrent requests. No row is updated if the secret is
+		// locked.
+		rowsUpdated, err := r.writer.Exec(ctx, query, []interface{}{
+			sql.Named("account_id", acct.PublicId),
+			sql.Named("step", step),
+		})
+		if err != nil {
+			return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to record totp code"))
+		}
+		if rowsUpdated == 1 {
+			return true, nil
+		}
+	}
+	if _, err := r.writer.Exec(ctx, recordTotpFailureQuery, []interface{}{
+		sql.Named("account_id", acct.PublicId),
+		sql.Named("max_failed_attempts", totpMaxFailedAttempts),
+		sql.Named("lockout_duration", totpLockoutDuration),
+	}); err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to record failed totp code"))
+	}
+	return false, nil
+}
+
+// lookupTotp returns the TOTP secret for accountId without decrypting it.
+// Returns nil if accountId has no TOTP secret.
+func (r *Repository) lookupTotp(ctx context.Context, accountId string) (*Totp, error) {
+	const op = "password.(Repository).lookupTotp"
+	totp := allocTotp()
+	if err := r.reader.LookupWhere(ctx, totp, "password_account_id = ?", accountId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return totp, nil
+}
+
+func (r *Repository) decryptTotp(ctx context.Context, scopeId string, totp *Totp) error {
+	const op = "password.(Repository).decryptTotp"
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(totp.GetKeyId()))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := totp.decrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
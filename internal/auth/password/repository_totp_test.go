@@ -0,0 +1,119 @@
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Totp(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	passwd := "12345678"
+	acct := TestAccount(t, conn, authMethod.PublicId, "kazmierczak")
+	_, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, passwd, acct.Version)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+
+	// Enrolling twice replaces the unconfirmed secret.
+	_, _, err = repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+	require.NoError(err)
+	url, secret, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+	require.NoError(err)
+	assert.Contains(url, "otpauth://totp/Boundary:kazmierczak")
+	assert.Contains(url, secret)
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(err)
+
+	// An unconfirmed secret is not required to authenticate.
+	got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	require.NoError(err)
+	assert.NotNil(got)
+
+	err = repo.ConfirmTotp(ctx, o.GetPublicId(), acct.PublicId, "000000")
+	assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+
+	step := totpStep(time.Now())
+	require.NoError(repo.ConfirmTotp(ctx, o.GetPublicId(), acct.PublicId, totpCode(key, step)))
+
+	_, _, err = repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+	assert.Truef(errors.Match(errors.T(errors.TotpAlreadyEnrolled), err), "Unexpected error %s", err)
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	assert.Truef(errors.Match(errors.T(errors.TotpRequired), err), "Unexpected error %s", err)
+	assert.Nil(got)
+
+	// The code used to confirm the secret cannot be used again.
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd, WithTotpCode(totpCode(key, step)))
+	require.NoError(err)
+	assert.Nil(got)
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd, WithTotpCode(totpCode(key, step+1)))
+	require.NoError(err)
+	assert.NotNil(got)
+
+	deleted, err := repo.DeleteTotp(ctx, o.GetPublicId(), acct.PublicId)
+	require.NoError(err)
+	assert.Equal(1, deleted)
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	require.NoError(err)
+	assert.NotNil(got)
+
+	// Requiring TOTP for the auth method prevents accounts without a
+	// confirmed secret from authenticating.
+	authMethod.TotpRequired = true
+	_, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version, []string{"TotpRequired"})
+	require.NoError(err)
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	assert.Truef(errors.Match(errors.T(errors.TotpNotEnrolled), err), "Unexpected error %s", err)
+	assert.Nil(got)
+
+	// The account can enroll with its password alone.
+	url, secret, err = repo.EnrollTotpWithPassword(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", "wrong password")
+	require.NoError(err)
+	assert.Empty(url)
+	assert.Empty(secret)
+	url, secret, err = repo.EnrollTotpWithPassword(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	require.NoError(err)
+	assert.Contains(url, secret)
+	key, err = totpEncoding.DecodeString(secret)
+	require.NoError(err)
+
+	// The first valid code confirms the secret.
+	step = totpStep(time.Now())
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd, WithTotpCode(totpCode(key, step)))
+	require.NoError(err)
+	assert.NotNil(got)
+	_, _, err = repo.EnrollTotpWithPassword(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd)
+	assert.Truef(errors.Match(errors.T(errors.TotpAlreadyEnrolled), err), "Unexpected error %s", err)
+
+	// Invalid codes lock the secret even though the auth method does not
+	// lock accounts, and then a valid code is rejected too.
+	for i := 0; i < totpMaxFailedAttempts; i++ {
+		got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd, WithTotpCode("000000"))
+		require.NoError(err)
+		assert.Nil(got)
+	}
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, "kazmierczak", passwd, WithTotpCode(totpCode(key, step+1)))
+	require.NoError(err)
+	assert.Nil(got)
+}
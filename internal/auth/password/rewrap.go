@@ -0,0 +1,117 @@
+package password
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	for _, table := range []string{"auth_password_argon2_cred", "auth_password_argon2_cred_history"} {
+		if err := kms.RegisterTableRewrapFn(table, rewrapArgon2Credentials(table)); err != nil {
+			panic(err)
+		}
+	}
+	if err := kms.RegisterTableRewrapFn("auth_password_totp", rewrapTotps); err != nil {
+		panic(err)
+	}
+}
+
+// rewrapWrappers returns the database wrapper for the key version
+// dataKeyVersionId and the current database wrapper of scopeId.
+func rewrapWrappers(ctx context.Context, dataKeyVersionId, scopeId string, kmsCache *kms.Kms) (wrapping.Wrapper, wrapping.Wrapper, error) {
+	const op = "password.rewrapWrappers"
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for key version"))
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	return oldWrapper, wrapper, nil
+}
+
+// rewrapArgon2Credentials returns a kms.RewrapFn which encrypts the salts of
+// the credentials in table encrypted by the database key version
+// dataKeyVersionId with the current database key version of scopeId.
+func rewrapArgon2Credentials(table string) kms.RewrapFn {
+	return func(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+		const op = "password.rewrapArgon2Credentials"
+		rows, err := reader.Query(ctx, fmt.Sprintf(argon2CredentialKeyIdQuery, table), []interface{}{sql.Named("key_id", dataKeyVersionId)})
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		defer rows.Close()
+		var creds []*Argon2Credential
+		for rows.Next() {
+			c := &Argon2Credential{Argon2Credential: &store.Argon2Credential{}}
+			if err := reader.ScanRows(rows, c); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			creds = append(creds, c)
+		}
+		if len(creds) == 0 {
+			return nil
+		}
+		oldWrapper, wrapper, err := rewrapWrappers(ctx, dataKeyVersionId, scopeId, kmsCache)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		for _, c := range creds {
+			if err := c.decrypt(ctx, oldWrapper); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if err := c.encrypt(ctx, wrapper); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if _, err := writer.Exec(ctx, fmt.Sprintf(rewrapArgon2CredentialQuery, table), []interface{}{
+				sql.Named("salt", c.CtSalt),
+				sql.Named("key_id", c.KeyId),
+				sql.Named("private_id", c.PrivateId),
+			}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update credential in %s", table)))
+			}
+		}
+		return nil
+	}
+}
+
+// rewrapTotps encrypts the TOTP secrets encrypted by the database key version
+// dataKeyVersionId with the current database key version of scopeId.
+func rewrapTotps(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "password.rewrapTotps"
+	var totps []*Totp
+	if err := reader.SearchWhere(ctx, &totps, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(totps) == 0 {
+		return nil
+	}
+	oldWrapper, wrapper, err := rewrapWrappers(ctx, dataKeyVersionId, scopeId, kmsCache)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, t := range totps {
+		if err := t.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := t.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapTotpQuery, []interface{}{
+			sql.Named("secret", t.CtSecret),
+			sql.Named("key_id", t.KeyId),
+			sql.Named("account_id", t.PasswordAccountId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update totp"))
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/stretchr/testify/assert"
@@ -95,3 +96,13 @@ func TestAccount(t *testing.T, conn *db.DB, authMethodId, loginName string, opt
 	require.NoError(err2)
 	return cat
 }
+
+// TestTotpCode returns the TOTP code at now for secret, a base32 encoded
+// secret as returned by EnrollTotp. If secret is not valid base32, the test
+// will fail.
+func TestTotpCode(t *testing.T, secret string, now time.Time) string {
+	t.Helper()
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	return totpCode(key, totpStep(now))
+}
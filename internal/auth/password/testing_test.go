@@ -2,6 +2,7 @@ package password
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -43,3 +44,9 @@ func Test_TestMultipleAccounts(t *testing.T) {
 		assert.NotEmpty(a.GetPublicId())
 	}
 }
+
+func Test_TestTotpCode(t *testing.T) {
+	// RFC 6238 SHA-1 test vector, truncated to six digits.
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	assert.Equal(t, "050471", TestTotpCode(t, secret, time.Unix(1111111111, 0)))
+}
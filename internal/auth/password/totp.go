@@ -0,0 +1,186 @@
+package password
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// TOTP parameters as described in RFC 6238. These are the defaults used by
+// common authenticator applications.
+const (
+	totpIssuer       = "Boundary"
+	totpSecretLength = 20 // bytes, the size of a SHA-1 HMAC key
+	totpDigits       = 6
+	totpPeriod       = 30 // seconds
+
+	// totpSkew is the number of time steps before and after the current
+	// time step in which a code is accepted, to allow for clock drift.
+	totpSkew = 1
+
+	// totpMaxFailedAttempts is the number of consecutive invalid codes
+	// after which no code is accepted for an account for
+	// totpLockoutDuration seconds. Unlike the lockout of an auth method,
+	// this throttling cannot be disabled, since a code is short enough to
+	// be guessed otherwise.
+	totpMaxFailedAttempts = 5
+	totpLockoutDuration   = 15 * 60
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// A Totp contains the TOTP secret for an Account. It is owned by an Account.
+// The secret is only used to verify codes once it has been confirmed.
+type Totp struct {
+	*store.Totp
+	tableName string
+}
+
+func allocTotp() *Totp {
+	return &Totp{
+		Totp: &store.Totp{},
+	}
+}
+
+func newTotp(ctx context.Context, accountId string) (*Totp, error) {
+	const op = "password.newTotp"
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing accountId")
+	}
+	secret := make([]byte, totpSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	return &Totp{
+		Totp: &store.Totp{
+			PasswordAccountId: accountId,
+			Secret:            secret,
+		},
+	}, nil
+}
+
+func (t *Totp) clone() *Totp {
+	cp := proto.Clone(t.Totp)
+	return &Totp{
+		Totp: cp.(*store.Totp),
+	}
+}
+
+// TableName returns the table name.
+func (t *Totp) TableName() string {
+	if t != nil && t.tableName != "" {
+		return t.tableName
+	}
+	return "auth_password_totp"
+}
+
+// SetTableName sets the table name.
+func (t *Totp) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *Totp) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(Totp).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, t.Totp, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	t.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (t *Totp) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(Totp).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, t.Totp, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func (t *Totp) oplog(op oplog.OpType) oplog.Metadata {
+	return oplog.Metadata{
+		"resource-public-id":  []string{t.PasswordAccountId},
+		"resource-type":       []string{"password totp"},
+		"op-type":             []string{op.String()},
+		"password-account-id": []string{t.PasswordAccountId},
+	}
+}
+
+// encodedSecret returns the unencrypted secret encoded as base32, the format
+// expected by authenticator applications.
+func (t *Totp) encodedSecret() string {
+	return totpEncoding.EncodeToString(t.Secret)
+}
+
+// url returns an otpauth URL for the unencrypted secret which can be
+// imported into an authenticator application, usually as a QR code.
+func (t *Totp) url(loginName string) string {
+	v := url.Values{}
+	v.Set("secret", t.encodedSecret())
+	v.Set("issuer", totpIssuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(totpPeriod))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + totpIssuer + ":" + loginName,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+// verify returns the time step of code if code is valid for the unencrypted
+// secret at now and its time step is after LastUsedStep. Returns false if
+// code is not valid or has already been used.
+func (t *Totp) verify(code string, now time.Time) (uint64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	current := totpStep(now)
+	for i := -totpSkew; i <= totpSkew; i++ {
+		step := uint64(int64(current) + int64(i))
+		if step <= t.LastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(t.Secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpStep returns the RFC 6238 time step for now.
+func totpStep(now time.Time) uint64 {
+	return uint64(now.Unix()) / totpPeriod
+}
+
+// totpCode returns the RFC 4226 HOTP value of secret for step.
+func totpCode(secret []byte, step uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], step)
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
@@ -0,0 +1,115 @@
+package password
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotp_Code(t *testing.T) {
+	t.Parallel()
+	// Test vectors from RFC 6238 Appendix B for SHA-1, truncated to six
+	// digits.
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, totpCode(secret, totpStep(time.Unix(tt.unix, 0))))
+		})
+	}
+}
+
+func TestTotp_Verify(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1111111111, 0)
+	step := totpStep(now)
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		name         string
+		code         string
+		lastUsedStep uint64
+		wantStep     uint64
+		wantOk       bool
+	}{
+		{
+			name:     "current-step",
+			code:     totpCode(secret, step),
+			wantStep: step,
+			wantOk:   true,
+		},
+		{
+			name:     "previous-step",
+			code:     totpCode(secret, step-1),
+			wantStep: step - 1,
+			wantOk:   true,
+		},
+		{
+			name:     "next-step",
+			code:     totpCode(secret, step+1),
+			wantStep: step + 1,
+			wantOk:   true,
+		},
+		{
+			name: "outside-skew",
+			code: totpCode(secret, step-2),
+		},
+		{
+			name:         "already-used",
+			code:         totpCode(secret, step),
+			lastUsedStep: step,
+		},
+		{
+			name: "wrong-length",
+			code: "12345",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			totp := &Totp{
+				Totp: &store.Totp{
+					Secret:       secret,
+					LastUsedStep: tt.lastUsedStep,
+				},
+			}
+			gotStep, gotOk := totp.verify(tt.code, now)
+			assert.Equal(tt.wantOk, gotOk)
+			assert.Equal(tt.wantStep, gotStep)
+		})
+	}
+}
+
+func TestTotp_Url(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	totp, err := newTotp(context.Background(), "acctpw_1234567890")
+	require.NoError(err)
+	assert.Len(totp.Secret, totpSecretLength)
+
+	u, err := url.Parse(totp.url("alice"))
+	require.NoError(err)
+	assert.Equal("otpauth", u.Scheme)
+	assert.Equal("totp", u.Host)
+	assert.Equal("/Boundary:alice", u.Path)
+	assert.Equal(totp.encodedSecret(), u.Query().Get("secret"))
+	assert.Equal("Boundary", u.Query().Get("issuer"))
+	assert.Equal("6", u.Query().Get("digits"))
+	assert.Equal("30", u.Query().Get("period"))
+}
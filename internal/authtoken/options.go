@@ -29,6 +29,7 @@ type options struct {
 	withTokenTimeToLiveDuration  time.Duration
 	withTokenTimeToStaleDuration time.Duration
 	withLimit                    int
+	withStartPageAfterItem       *string
 	withStatus                   Status
 	withPublicId                 string
 }
@@ -78,6 +79,15 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
 // WithStatus allows setting of the auth token's Status.
 func WithStatus(status Status) Option {
 	return func(o *options) {
@@ -288,7 +288,7 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 }
 
 // ListAuthTokens lists auth tokens in the given scopes and supports the
-// WithLimit option.
+// WithLimit and WithStartPageAfterItem options.
 func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string, opt ...Option) ([]*AuthToken, error) {
 	const op = "authtoken.(Repository).ListAuthTokens"
 	if len(withScopeIds) == 0 {
@@ -296,10 +296,15 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string,
 	}
 	opts := getOpts(opt...)
 
+	dbOpts := []db.Option{db.WithLimit(opts.withLimit)}
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
+
 	// use the view, to bring in the required account columns. Just don't forget
 	// to convert them before returning them
 	var atvs []*authTokenView
-	if err := r.reader.SearchWhere(ctx, &atvs, "auth_account_id in (select public_id from auth_account where scope_id in (?))", []interface{}{withScopeIds}, db.WithLimit(opts.withLimit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &atvs, "auth_account_id in (select public_id from auth_account where scope_id in (?))", []interface{}{withScopeIds}, dbOpts...); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	authTokens := make([]*AuthToken, 0, len(atvs))
@@ -0,0 +1,60 @@
+package authtoken
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+const rewrapAuthTokenQuery = `
+update auth_token
+   set token = @token,
+       key_id = @key_id
+ where public_id = @public_id;
+`
+
+func init() {
+	if err := kms.RegisterTableRewrapFn(defaultAuthTokenTableName, rewrapAuthTokens); err != nil {
+		panic(err)
+	}
+}
+
+// rewrapAuthTokens encrypts the auth tokens encrypted by the database key
+// version dataKeyVersionId with the current database key version of scopeId.
+func rewrapAuthTokens(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "authtoken.rewrapAuthTokens"
+	var tokens []*AuthToken
+	if err := reader.SearchWhere(ctx, &tokens, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for key version"))
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	for _, at := range tokens {
+		if err := at.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := at.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapAuthTokenQuery, []interface{}{
+			sql.Named("token", at.CtToken),
+			sql.Named("key_id", at.KeyId),
+			sql.Named("public_id", at.PublicId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth token"))
+		}
+	}
+	return nil
+}
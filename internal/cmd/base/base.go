@@ -82,6 +82,8 @@ type Command struct {
 	FlagVersion           int
 	FlagRecursive         bool
 	FlagFilter            string
+	FlagPageSize          uint
+	FlagListToken         string
 
 	client *api.Client
 }
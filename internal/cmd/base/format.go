@@ -299,7 +299,8 @@ func (c *Command) PrintJsonItems(result api.GenericListResult) bool {
 	// {"items": []}}. However, we decode into a RawMessage which makes it much
 	// more efficient on both the decoding and encoding side.
 	type inMsg struct {
-		Items json.RawMessage `json:"items"`
+		Items     json.RawMessage `json:"items"`
+		ListToken string          `json:"list_token"`
 	}
 	var input inMsg
 	if resp.Body.Bytes() != nil {
@@ -311,9 +312,11 @@ func (c *Command) PrintJsonItems(result api.GenericListResult) bool {
 	output := struct {
 		StatusCode int             `json:"status_code"`
 		Items      json.RawMessage `json:"items"`
+		ListToken  string          `json:"list_token,omitempty"`
 	}{
 		StatusCode: resp.HttpResponse().StatusCode,
 		Items:      input.Items,
+		ListToken:  input.ListToken,
 	}
 	b, err := JsonFormatter{}.Format(output)
 	if err != nil {
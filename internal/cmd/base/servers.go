@@ -74,6 +74,7 @@ type Server struct {
 	Eventer    *event.Eventer
 
 	RootKms            wrapping.Wrapper
+	PreviousRootKms    wrapping.Wrapper
 	WorkerAuthKms      wrapping.Wrapper
 	RecoveryKms        wrapping.Wrapper
 	Kms                *kms.Kms
@@ -471,7 +472,7 @@ func (b *Server) SetupKMSes(ui cli.Ui, config *config.Config) error {
 			switch purpose {
 			case "":
 				return errors.New("KMS block missing 'purpose'")
-			case "root", "worker-auth", "config":
+			case "root", "previous-root", "worker-auth", "config":
 			case "recovery":
 				if config.Controller != nil && config.DevRecoveryKey != "" {
 					kms.Config["key"] = config.DevRecoveryKey
@@ -498,6 +499,8 @@ func (b *Server) SetupKMSes(ui cli.Ui, config *config.Config) error {
 			switch purpose {
 			case "root":
 				b.RootKms = wrapper
+			case "previous-root":
+				b.PreviousRootKms = wrapper
 			case "worker-auth":
 				b.WorkerAuthKms = wrapper
 			case "recovery":
@@ -93,7 +93,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"auth-method-id", "filter"},
+	"list": {"auth-method-id", "filter", "page-size", "list-token"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -179,6 +179,14 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, accounts.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, accounts.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, accounts.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
@@ -269,6 +277,9 @@ func (c *Command) Run(args []string) int {
 		case "table":
 			listedItems := listResult.GetItems().([]*accounts.Account)
 			c.UI.Output(c.printListTable(listedItems))
+			if listResult.GetListToken() != "" {
+				c.UI.Output(fmt.Sprintf("\nMore items are available. Use -list-token %s to list the next page.", listResult.GetListToken()))
+			}
 		}
 
 		return base.CommandSuccess
@@ -19,18 +19,24 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
 	flagPassword        string
 	flagCurrentPassword string
 	flagNewPassword     string
+	flagCode            string
+	totpEnrollment      *accounts.TotpEnrollmentResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
 		"change-password": {"id", "current-password", "new-password", "version"},
 		"set-password":    {"id", "password", "version"},
+		"enroll-totp":     {"id"},
+		"confirm-totp":    {"id", "code"},
+		"remove-totp":     {"id"},
 	}
 }
 
@@ -42,6 +48,15 @@ func extraSynopsisFuncImpl(c *Command) string {
 	case "set-password":
 		return "Directly set the password on an account"
 
+	case "enroll-totp":
+		return "Generate a TOTP secret for an account"
+
+	case "confirm-totp":
+		return "Confirm the TOTP secret of an account"
+
+	case "remove-totp":
+		return "Remove the TOTP secret from an account"
+
 	default:
 		return ""
 	}
@@ -86,6 +101,42 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "enroll-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts enroll-totp [options] [args]",
+			"",
+			"  This command generates a TOTP secret for a password-type account. The secret must be added to an authenticator application and confirmed with the confirm-totp command before it is required to authenticate. Example:",
+			"",
+			"    Generate a TOTP secret for a password-type account:",
+			"",
+			`      $ boundary accounts enroll-totp -id acctpw_1234567890`,
+			"",
+			"",
+		})
+	case "confirm-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts confirm-totp [options] [args]",
+			"",
+			"  This command confirms the TOTP secret of a password-type account using a code from an authenticator application. Once confirmed, a TOTP code is required to authenticate. Example:",
+			"",
+			"    Confirm the TOTP secret of a password-type account:",
+			"",
+			`      $ boundary accounts confirm-totp -id acctpw_1234567890 -code 123456`,
+			"",
+			"",
+		})
+	case "remove-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts remove-totp [options] [args]",
+			"",
+			"  This command removes the TOTP secret from a password-type account. Example:",
+			"",
+			"    Remove the TOTP secret from a password-type account:",
+			"",
+			`      $ boundary accounts remove-totp -id acctpw_1234567890`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
@@ -111,6 +162,12 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagNewPassword,
 				Usage:  "The new password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case "code":
+			f.StringVar(&base.StringVar{
+				Name:   "code",
+				Target: &c.flagCode,
+				Usage:  "The current code from the authenticator application. If not specified, the command will prompt for the code.",
+			})
 		}
 	}
 }
@@ -171,6 +228,17 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]accounts.O
 		c.flagNewPassword = strings.TrimSpace(value)
 	}
 
+	if strutil.StrListContains(flagsMap[c.Func], "code") && c.flagCode == "" {
+		fmt.Print("TOTP code is not set as flag, please enter it now: ")
+		value, err := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the code. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+			return false
+		}
+		c.flagCode = strings.TrimSpace(value)
+	}
+
 	return true
 }
 
@@ -180,10 +248,51 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return accountClient.SetPassword(c.Context, c.FlagId, c.flagPassword, version, opts...)
 	case "change-password":
 		return accountClient.ChangePassword(c.Context, c.FlagId, c.flagCurrentPassword, c.flagNewPassword, version, opts...)
+	case "enroll-totp":
+		var err error
+		c.totpEnrollment, err = accountClient.EnrollTotp(c.Context, c.FlagId, opts...)
+		return nil, err
+	case "confirm-totp":
+		return accountClient.ConfirmTotp(c.Context, c.FlagId, c.flagCode, opts...)
+	case "remove-totp":
+		return accountClient.RemoveTotp(c.Context, c.FlagId, opts...)
 	}
 	return origResult, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "enroll-totp":
+		item := c.totpEnrollment.GetItem().(*accounts.TotpEnrollment)
+
+		switch base.Format(c.UI) {
+		case "table":
+			nonAttributeMap := map[string]interface{}{
+				"Secret": item.Secret,
+				"URL":    item.Url,
+			}
+			maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+			ret := []string{
+				"",
+				"TOTP enrollment:",
+				base.WrapMap(2, maxLength+2, nonAttributeMap),
+				"",
+				"  Add the secret to an authenticator application, then confirm it using",
+				"  the confirm-totp command. The secret will not be shown again.",
+			}
+			c.UI.Output(base.WrapForHelpText(ret))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.totpEnrollment); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*accounts.Account) string {
 	if len(items) == 0 {
 		return "No accounts found"
@@ -3,6 +3,7 @@ package accountscmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/boundary/api/accounts"
@@ -19,14 +20,15 @@ func init() {
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"login-name", "password"},
-		"update": {"login-name"},
+		"create": {"login-name", "password", "password-change-required"},
+		"update": {"login-name", "password-change-required"},
 	}
 }
 
 type extraPasswordCmdVars struct {
-	flagLoginName string
-	flagPassword  string
+	flagLoginName              string
+	flagPassword               string
+	flagPasswordChangeRequired string
 }
 
 func (c *PasswordCommand) extraPasswordHelpFunc(helpMap map[string]func() string) string {
@@ -74,6 +76,12 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagPassword,
 				Usage:  "The password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case "password-change-required":
+			f.StringVar(&base.StringVar{
+				Name:   "password-change-required",
+				Target: &c.flagPasswordChangeRequired,
+				Usage:  `If "true", the account must choose a new password the next time it authenticates`,
+			})
 		}
 	}
 }
@@ -92,6 +100,19 @@ func extraPasswordFlagsHandlingFuncImpl(c *PasswordCommand, _ *base.FlagSets, op
 		*opts = append(*opts, accounts.WithPasswordAccountLoginName(c.flagLoginName))
 	}
 
+	switch c.flagPasswordChangeRequired {
+	case "":
+	case "null":
+		*opts = append(*opts, accounts.DefaultPasswordAccountPasswordChangeRequired())
+	default:
+		required, err := strconv.ParseBool(c.flagPasswordChangeRequired)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagPasswordChangeRequired, err))
+			return false
+		}
+		*opts = append(*opts, accounts.WithPasswordAccountPasswordChangeRequired(required))
+	}
+
 	if strutil.StrListContains(flagsPasswordMap[c.Func], "password") {
 		switch c.flagPassword {
 		case "":
@@ -21,15 +21,20 @@ var (
 )
 
 var (
-	envPassword  = "BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD"
-	envLoginName = "BOUNDARY_AUTHENTICATE_PASSWORD_LOGIN_NAME"
+	envPassword    = "BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD"
+	envLoginName   = "BOUNDARY_AUTHENTICATE_PASSWORD_LOGIN_NAME"
+	envNewPassword = "BOUNDARY_AUTHENTICATE_PASSWORD_NEW_PASSWORD"
+	envTotpCode    = "BOUNDARY_AUTHENTICATE_PASSWORD_TOTP_CODE"
 )
 
 type PasswordCommand struct {
 	*base.Command
 
-	flagLoginName string
-	flagPassword  string
+	flagLoginName   string
+	flagPassword    string
+	flagNewPassword string
+	flagTotpCode    string
+	flagEnrollTotp  bool
 }
 
 func (c *PasswordCommand) Synopsis() string {
@@ -44,6 +49,14 @@ func (c *PasswordCommand) Help() string {
 		"",
 		`    $ boundary authenticate password -auth-method-id ampw_1234567890 -login-name foo -password "bar"`,
 		"",
+		"  If the account has enrolled a TOTP secret and -totp-code is not provided, the command will prompt for the code.",
+		"",
+		"  If the auth method requires TOTP, an account without a TOTP secret can enroll one using its password:",
+		"",
+		`    $ boundary authenticate password -auth-method-id ampw_1234567890 -login-name foo -enroll-totp`,
+		"",
+		"  The secret is confirmed by the first authentication with a TOTP code for it.",
+		"",
 		"",
 	}) + c.Flags().Help()
 }
@@ -66,6 +79,26 @@ func (c *PasswordCommand) Flags() *base.FlagSets {
 		Usage:  "The password associated with the login name",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:   "new-password",
+		Target: &c.flagNewPassword,
+		EnvVar: envNewPassword,
+		Usage:  "A new password for the account, used when the account is required to change its password",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "totp-code",
+		Target: &c.flagTotpCode,
+		EnvVar: envTotpCode,
+		Usage:  "The current TOTP code for the account, used when the account has enrolled a TOTP secret",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "enroll-totp",
+		Target: &c.flagEnrollTotp,
+		Usage:  "Enroll a TOTP secret for the account instead of authenticating. The secret is confirmed by the first authentication with a TOTP code for it",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:   "auth-method-id",
 		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
@@ -133,11 +166,34 @@ func (c *PasswordCommand) Run(args []string) int {
 		return base.CommandCliError
 	}
 
-	result, err := authmethods.NewClient(client).Authenticate(c.Context, c.FlagAuthMethodId, "login",
-		map[string]interface{}{
-			"login_name": c.flagLoginName,
-			"password":   c.flagPassword,
-		})
+	attrs := map[string]interface{}{
+		"login_name": c.flagLoginName,
+		"password":   c.flagPassword,
+	}
+	amClient := authmethods.NewClient(client)
+	if c.flagEnrollTotp {
+		return c.enrollTotp(amClient, attrs)
+	}
+	if c.flagNewPassword != "" {
+		attrs["new_password"] = c.flagNewPassword
+	}
+	if c.flagTotpCode != "" {
+		attrs["totp_code"] = c.flagTotpCode
+	}
+	result, err := amClient.Authenticate(c.Context, c.FlagAuthMethodId, "login", attrs)
+	if apiErr := api.AsServerError(err); apiErr != nil && c.flagTotpCode == "" && totpCodeRequired(apiErr) {
+		// The password was accepted but the account requires a second
+		// factor, so prompt for the code and try again.
+		fmt.Print("TOTP code is not set as flag or in env, please enter it now: ")
+		value, readErr := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if readErr != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the TOTP code. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", readErr.Error()))
+			return base.CommandUserError
+		}
+		attrs["totp_code"] = strings.TrimSpace(value)
+		result, err = amClient.Authenticate(c.Context, c.FlagAuthMethodId, "login", attrs)
+	}
 	if err != nil {
 		if apiErr := api.AsServerError(err); apiErr != nil {
 			c.PrintApiError(apiErr, "Error from controller when performing authentication")
@@ -149,3 +205,56 @@ func (c *PasswordCommand) Run(args []string) int {
 
 	return saveAndOrPrintToken(c.Command, result)
 }
+
+// enrollTotp enrolls a TOTP secret for the account authenticated by attrs
+// and prints the secret.
+func (c *PasswordCommand) enrollTotp(amClient *authmethods.Client, attrs map[string]interface{}) int {
+	result, err := amClient.Authenticate(c.Context, c.FlagAuthMethodId, "enroll-totp", attrs)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when enrolling a TOTP secret")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to enroll a TOTP secret: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if ok := c.PrintJsonItem(&dummyGenericResponse{
+			item:     result.Attributes,
+			response: result.GetResponse(),
+		}); !ok {
+			return base.CommandCliError
+		}
+	default:
+		nonAttributeMap := map[string]interface{}{
+			"Secret": result.Attributes["secret"],
+			"URL":    result.Attributes["url"],
+		}
+		maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+		c.UI.Output(base.WrapForHelpText([]string{
+			"",
+			"TOTP enrollment:",
+			base.WrapMap(2, maxLength+2, nonAttributeMap),
+			"",
+			"  Add the secret to an authenticator application, then authenticate with",
+			"  a code for it to confirm it. The secret will not be shown again.",
+		}))
+	}
+	return base.CommandSuccess
+}
+
+// totpCodeRequired reports whether apiErr indicates that a TOTP code must be
+// provided to authenticate.
+func totpCodeRequired(apiErr *api.Error) bool {
+	if apiErr.Details == nil {
+		return false
+	}
+	for _, f := range apiErr.Details.RequestFields {
+		if f.Name == "attributes.totp_code" {
+			return true
+		}
+	}
+	return false
+}
@@ -91,7 +91,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"scope-id", "filter", "recursive"},
+	"list": {"scope-id", "filter", "page-size", "list-token", "recursive"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -166,6 +166,14 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, authmethods.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, authmethods.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, authmethods.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	if ok := extraFlagsHandlingFunc(c, f, &opts); !ok {
@@ -236,6 +244,9 @@ func (c *Command) Run(args []string) int {
 		case "table":
 			listedItems := listResult.GetItems().([]*authmethods.AuthMethod)
 			c.UI.Output(c.printListTable(listedItems))
+			if listResult.GetListToken() != "" {
+				c.UI.Output(fmt.Sprintf("\nMore items are available. Use -list-token %s to list the next page.", listResult.GetListToken()))
+			}
 		}
 
 		return base.CommandSuccess
@@ -0,0 +1,293 @@
+package authmethodscmd
+
+import (
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraLdapActionsFlagsMapFunc = extraLdapActionsFlagsMapFuncImpl
+	extraLdapFlagsFunc = extraLdapFlagsFuncImpl
+	extraLdapFlagsHandlingFunc = extraLdapFlagHandlingFuncImpl
+}
+
+type extraLdapCmdVars struct {
+	flagUrls            []string
+	flagStartTls        bool
+	flagInsecureTls     bool
+	flagDiscoverDn      bool
+	flagAnonGroupSearch bool
+	flagUpnDomain       string
+	flagUserDn          string
+	flagUserAttr        string
+	flagUserFilter      string
+	flagGroupDn         string
+	flagGroupAttr       string
+	flagGroupFilter     string
+	flagCertificates    []string
+	flagBindDn          string
+	flagBindPassword    string
+}
+
+const (
+	urlsFlagName            = "url"
+	startTlsFlagName        = "start-tls"
+	insecureTlsFlagName     = "insecure-tls"
+	discoverDnFlagName      = "discover-dn"
+	anonGroupSearchFlagName = "anon-group-search"
+	upnDomainFlagName       = "upn-domain"
+	userDnFlagName          = "user-dn"
+	userAttrFlagName        = "user-attr"
+	userFilterFlagName      = "user-filter"
+	groupDnFlagName         = "group-dn"
+	groupAttrFlagName       = "group-attr"
+	groupFilterFlagName     = "group-filter"
+	certificatesFlagName    = "certificate"
+	bindDnFlagName          = "bind-dn"
+	bindPasswordFlagName    = "bind-password"
+)
+
+func extraLdapActionsFlagsMapFuncImpl() map[string][]string {
+	flags := map[string][]string{
+		"create": {
+			urlsFlagName,
+			startTlsFlagName,
+			insecureTlsFlagName,
+			discoverDnFlagName,
+			anonGroupSearchFlagName,
+			upnDomainFlagName,
+			userDnFlagName,
+			userAttrFlagName,
+			userFilterFlagName,
+			groupDnFlagName,
+			groupAttrFlagName,
+			groupFilterFlagName,
+			certificatesFlagName,
+			bindDnFlagName,
+			bindPasswordFlagName,
+		},
+	}
+	flags["update"] = flags["create"]
+	return flags
+}
+
+func extraLdapFlagsFuncImpl(c *LdapCommand, set *base.FlagSets, _ *base.FlagSet) {
+	f := set.NewFlagSet("LDAP Auth Method Options")
+
+	for _, name := range flagsLdapMap[c.Func] {
+		switch name {
+		case urlsFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   urlsFlagName,
+				Target: &c.flagUrls,
+				Usage:  "The LDAP URL of the directory server, using the ldap or ldaps scheme. May be specified multiple times; the servers are tried in order.",
+			})
+		case startTlsFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   startTlsFlagName,
+				Target: &c.flagStartTls,
+				Usage:  "Issue a StartTLS command after connecting to an ldap URL.",
+			})
+		case insecureTlsFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   insecureTlsFlagName,
+				Target: &c.flagInsecureTls,
+				Usage:  "Skip verification of the directory server's certificate. Not recommended.",
+			})
+		case discoverDnFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   discoverDnFlagName,
+				Target: &c.flagDiscoverDn,
+				Usage:  "Search the directory for the user's entry before binding, instead of building its DN from the user DN and user attribute.",
+			})
+		case anonGroupSearchFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   anonGroupSearchFlagName,
+				Target: &c.flagAnonGroupSearch,
+				Usage:  "Search for groups using an anonymous bind instead of the bind DN or the user's own credentials.",
+			})
+		case upnDomainFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   upnDomainFlagName,
+				Target: &c.flagUpnDomain,
+				Usage:  "The userPrincipalName domain; when set, users bind as login-name@upn-domain.",
+			})
+		case userDnFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   userDnFlagName,
+				Target: &c.flagUserDn,
+				Usage:  "The base DN under which user entries are found.",
+			})
+		case userAttrFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   userAttrFlagName,
+				Target: &c.flagUserAttr,
+				Usage:  `The attribute on user entries matching the login name. Defaults to "cn".`,
+			})
+		case userFilterFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   userFilterFlagName,
+				Target: &c.flagUserFilter,
+				Usage:  "A Go template used to build the filter when searching for the user's entry.",
+			})
+		case groupDnFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   groupDnFlagName,
+				Target: &c.flagGroupDn,
+				Usage:  "The base DN under which group entries are found.",
+			})
+		case groupAttrFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   groupAttrFlagName,
+				Target: &c.flagGroupAttr,
+				Usage:  `The attribute on group entries holding the group's name. Defaults to "cn".`,
+			})
+		case groupFilterFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   groupFilterFlagName,
+				Target: &c.flagGroupFilter,
+				Usage:  "A Go template used to build the filter when searching for the user's groups.",
+			})
+		case certificatesFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   certificatesFlagName,
+				Target: &c.flagCertificates,
+				Usage:  "Optional PEM-encoded X.509 CA certificate used to verify the directory server. May be specified multiple times.",
+			})
+		case bindDnFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   bindDnFlagName,
+				Target: &c.flagBindDn,
+				Usage:  "The DN used to bind when searching the directory.",
+			})
+		case bindPasswordFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   bindPasswordFlagName,
+				Target: &c.flagBindPassword,
+				Usage:  "The password used with the bind DN.",
+			})
+		}
+	}
+}
+
+func (c *LdapCommand) extraLdapHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods create ldap [options] [args]",
+			"",
+			"  Create an ldap-type auth method. Example:",
+			"",
+			`    $ boundary auth-methods create ldap -name prodops -url ldaps://ldap.example.com -user-dn "ou=people,dc=example,dc=com" -group-dn "ou=groups,dc=example,dc=com"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods update ldap [options] [args]",
+			"",
+			"  Update an ldap-type auth method given its ID. Example:",
+			"",
+			`    $ boundary auth-methods update ldap -id amldap_1234567890 -name "devops" -description "Ldap auth-method for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraLdapFlagHandlingFuncImpl(c *LdapCommand, f *base.FlagSets, opts *[]authmethods.Option) bool {
+	switch {
+	case len(c.flagUrls) == 0:
+	case len(c.flagUrls) == 1 && c.flagUrls[0] == "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUrls())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUrls(c.flagUrls))
+	}
+	if c.flagStartTls {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodStartTls(c.flagStartTls))
+	}
+	if c.flagInsecureTls {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodInsecureTls(c.flagInsecureTls))
+	}
+	if c.flagDiscoverDn {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodDiscoverDn(c.flagDiscoverDn))
+	}
+	if c.flagAnonGroupSearch {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodAnonGroupSearch(c.flagAnonGroupSearch))
+	}
+	switch c.flagUpnDomain {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUpnDomain())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUpnDomain(c.flagUpnDomain))
+	}
+	switch c.flagUserDn {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUserDn())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUserDn(c.flagUserDn))
+	}
+	switch c.flagUserAttr {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUserAttr())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUserAttr(c.flagUserAttr))
+	}
+	switch c.flagUserFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUserFilter())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUserFilter(c.flagUserFilter))
+	}
+	switch c.flagGroupDn {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodGroupDn())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodGroupDn(c.flagGroupDn))
+	}
+	switch c.flagGroupAttr {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodGroupAttr())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodGroupAttr(c.flagGroupAttr))
+	}
+	switch c.flagGroupFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodGroupFilter())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodGroupFilter(c.flagGroupFilter))
+	}
+	switch {
+	case len(c.flagCertificates) == 0:
+	case len(c.flagCertificates) == 1 && c.flagCertificates[0] == "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodCertificates())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodCertificates(c.flagCertificates))
+	}
+	switch c.flagBindDn {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodBindDn())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodBindDn(c.flagBindDn))
+	}
+	switch c.flagBindPassword {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodBindPassword())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodBindPassword(c.flagBindPassword))
+	}
+
+	return true
+}
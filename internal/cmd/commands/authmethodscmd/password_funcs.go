@@ -15,15 +15,35 @@ func init() {
 }
 
 type extraPasswordCmdVars struct {
-	flagMinLoginNameLength string
-	flagMinPasswordLength  string
+	flagMinLoginNameLength   string
+	flagMinPasswordLength    string
+	flagMinUppercase         string
+	flagMinLowercase         string
+	flagMinDigits            string
+	flagMinSymbols           string
+	flagPasswordHistoryCount string
+	flagMaxFailedAttempts    string
+	flagLockoutDuration      string
+	flagTotpRequired         string
 }
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
-	return map[string][]string{
-		"create": {"min-login-name-length", "min-password-length"},
-		"update": {"min-login-name-length", "min-password-length"},
+	flags := map[string][]string{
+		"create": {
+			"min-login-name-length",
+			"min-password-length",
+			"min-uppercase",
+			"min-lowercase",
+			"min-digits",
+			"min-symbols",
+			"password-history-count",
+			"max-failed-attempts",
+			"lockout-duration",
+			"totp-required",
+		},
 	}
+	flags["update"] = flags["create"]
+	return flags
 }
 
 func (c *PasswordCommand) extraPasswordHelpFunc(helpMap map[string]func() string) string {
@@ -71,6 +91,54 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagMinPasswordLength,
 				Usage:  "The minimum length of passwords",
 			})
+		case "min-uppercase":
+			f.StringVar(&base.StringVar{
+				Name:   "min-uppercase",
+				Target: &c.flagMinUppercase,
+				Usage:  "The minimum number of uppercase letters in passwords",
+			})
+		case "min-lowercase":
+			f.StringVar(&base.StringVar{
+				Name:   "min-lowercase",
+				Target: &c.flagMinLowercase,
+				Usage:  "The minimum number of lowercase letters in passwords",
+			})
+		case "min-digits":
+			f.StringVar(&base.StringVar{
+				Name:   "min-digits",
+				Target: &c.flagMinDigits,
+				Usage:  "The minimum number of digits in passwords",
+			})
+		case "min-symbols":
+			f.StringVar(&base.StringVar{
+				Name:   "min-symbols",
+				Target: &c.flagMinSymbols,
+				Usage:  "The minimum number of symbols (characters that are not letters or digits) in passwords",
+			})
+		case "password-history-count":
+			f.StringVar(&base.StringVar{
+				Name:   "password-history-count",
+				Target: &c.flagPasswordHistoryCount,
+				Usage:  "The number of previous passwords an account may not reuse, at most 24",
+			})
+		case "max-failed-attempts":
+			f.StringVar(&base.StringVar{
+				Name:   "max-failed-attempts",
+				Target: &c.flagMaxFailedAttempts,
+				Usage:  "The number of consecutive failed authentication attempts after which an account is locked; 0 disables lockout",
+			})
+		case "lockout-duration":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-duration",
+				Target: &c.flagLockoutDuration,
+				Usage:  "The number of seconds an account stays locked after reaching the maximum failed attempts",
+			})
+		case "totp-required":
+			f.StringVar(&base.StringVar{
+				Name:   "totp-required",
+				Target: &c.flagTotpRequired,
+				Usage:  "If set to true, every account must provide a TOTP code when authenticating; accounts without a confirmed TOTP secret will be unable to authenticate",
+			})
 		}
 	}
 }
@@ -109,6 +177,45 @@ func extraPasswordFlagHandlingFuncImpl(c *PasswordCommand, _ *base.FlagSets, opt
 		addAttribute("min_password_length", uint32(length))
 	}
 
+	for _, attr := range []struct {
+		name  string
+		value string
+	}{
+		{"min_uppercase", c.flagMinUppercase},
+		{"min_lowercase", c.flagMinLowercase},
+		{"min_digits", c.flagMinDigits},
+		{"min_symbols", c.flagMinSymbols},
+		{"password_history_count", c.flagPasswordHistoryCount},
+		{"max_failed_attempts", c.flagMaxFailedAttempts},
+		{"lockout_duration_seconds", c.flagLockoutDuration},
+	} {
+		switch attr.value {
+		case "":
+		case "null":
+			addAttribute(attr.name, nil)
+		default:
+			val, err := strconv.ParseUint(attr.value, 10, 32)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", attr.value, err))
+				return false
+			}
+			addAttribute(attr.name, uint32(val))
+		}
+	}
+
+	switch c.flagTotpRequired {
+	case "":
+	case "null":
+		addAttribute("totp_required", nil)
+	default:
+		required, err := strconv.ParseBool(c.flagTotpRequired)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagTotpRequired, err))
+			return false
+		}
+		addAttribute("totp_required", required)
+	}
+
 	if attributes != nil {
 		*opts = append(*opts, authmethods.WithAttributes(attributes))
 	}
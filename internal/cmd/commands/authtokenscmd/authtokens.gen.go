@@ -91,7 +91,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"scope-id", "filter", "recursive"},
+	"list": {"scope-id", "filter", "page-size", "list-token", "recursive"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -157,6 +157,14 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, authtokens.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, authtokens.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, authtokens.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	if ok := extraFlagsHandlingFunc(c, f, &opts); !ok {
@@ -227,6 +235,9 @@ func (c *Command) Run(args []string) int {
 		case "table":
 			listedItems := listResult.GetItems().([]*authtokens.AuthToken)
 			c.UI.Output(c.printListTable(listedItems))
+			if listResult.GetListToken() != "" {
+				c.UI.Output(fmt.Sprintf("\nMore items are available. Use -list-token %s to list the next page.", listResult.GetListToken()))
+			}
 		}
 
 		return base.CommandSuccess
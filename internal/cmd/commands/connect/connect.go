@@ -71,6 +71,7 @@ type Command struct {
 	flagTargetId   string
 	flagTargetName string
 	flagHostId     string
+	flagSessionId  string
 	flagExec       string
 	flagUsername   string
 	flagDbname     string
@@ -98,7 +99,9 @@ type Command struct {
 	connWg             *sync.WaitGroup
 	listenerCloseOnce  sync.Once
 	listener           *net.TCPListener
-	listenerAddr       *net.TCPAddr
+	udpListener        *net.UDPConn
+	listenerIp         net.IP
+	listenerPort       int
 	connsLeftCh        chan int32
 	connectionsLeft    *atomic.Int32
 	expiration         time.Time
@@ -183,6 +186,12 @@ func (c *Command) Flags() *base.FlagSets {
 		Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:   "session-id",
+		Target: &c.flagSessionId,
+		Usage:  "The ID of an approved session of the target to connect with, for targets which require session approval.",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:       "exec",
 		Target:     &c.flagExec,
@@ -373,6 +382,9 @@ func (c *Command) Run(args []string) (retCode int) {
 		if len(c.flagHostId) != 0 {
 			opts = append(opts, targets.WithHostId(c.flagHostId))
 		}
+		if len(c.flagSessionId) != 0 {
+			opts = append(opts, targets.WithSessionId(c.flagSessionId))
+		}
 		if len(c.flagTargetName) > 0 {
 			opts = append(opts, targets.WithName(c.flagTargetName))
 		}
@@ -393,6 +405,10 @@ func (c *Command) Run(args []string) (retCode int) {
 			return base.CommandCliError
 		}
 		c.sessionAuthz = sar.GetItem().(*targets.SessionAuthorization)
+		if c.sessionAuthz.Status != "" {
+			c.UI.Output(fmt.Sprintf("Session %s is %s. Once another user approves it, connect with -session-id %s.", c.sessionAuthz.SessionId, c.sessionAuthz.Status, c.sessionAuthz.SessionId))
+			return base.CommandSuccess
+		}
 		authzString = c.sessionAuthz.AuthorizationToken
 	}
 
@@ -462,10 +478,36 @@ func (c *Command) Run(args []string) (retCode int) {
 	// hijacked, just setting for completeness
 	transport.IdleConnTimeout = 0
 
-	c.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
-		IP:   listenAddr,
-		Port: c.flagListenPort,
-	})
+	// A udp session is proxied from a local udp listener, which none of the
+	// clients run by the subcommands can use.
+	isUdp := c.sessionAuthzData.GetType() == "udp"
+	if isUdp && c.Func != "connect" {
+		c.PrintCliError(fmt.Errorf("The %s subcommand cannot be used with a udp target", c.Func))
+		return base.CommandUserError
+	}
+
+	var listener io.Closer
+	if isUdp {
+		c.udpListener, err = net.ListenUDP("udp", &net.UDPAddr{
+			IP:   listenAddr,
+			Port: c.flagListenPort,
+		})
+		if err == nil {
+			listener = c.udpListener
+			addr := c.udpListener.LocalAddr().(*net.UDPAddr)
+			c.listenerIp, c.listenerPort = addr.IP, addr.Port
+		}
+	} else {
+		c.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
+			IP:   listenAddr,
+			Port: c.flagListenPort,
+		})
+		if err == nil {
+			listener = c.listener
+			addr := c.listener.Addr().(*net.TCPAddr)
+			c.listenerIp, c.listenerPort = addr.IP, addr.Port
+		}
+	}
 	if err != nil {
 		c.PrintCliError(fmt.Errorf("Error starting listening port: %w", err))
 		return base.CommandCliError
@@ -474,7 +516,7 @@ func (c *Command) Run(args []string) (retCode int) {
 	listenerCloseFunc := func() {
 		// Forces the for loop to exist instead of spinning on errors
 		c.connectionsLeft.Store(0)
-		if err := c.listener.Close(); err != nil {
+		if err := listener.Close(); err != nil {
 			c.PrintCliError(fmt.Errorf("Error closing listener on shutdown: %w", err))
 			retCode = 2
 		}
@@ -485,8 +527,6 @@ func (c *Command) Run(args []string) (retCode int) {
 		c.listenerCloseOnce.Do(listenerCloseFunc)
 	}()
 
-	c.listenerAddr = c.listener.Addr().(*net.TCPAddr)
-
 	var creds []*targets.SessionCredential
 	if c.sessionAuthz != nil && len(c.sessionAuthz.Credentials) > 0 {
 		creds = c.sessionAuthz.Credentials
@@ -502,8 +542,8 @@ func (c *Command) Run(args []string) (retCode int) {
 		// connecting directly to the port and address we report to them here.
 		sessInfo := SessionInfo{
 			Protocol:        c.sessionAuthzData.GetType(),
-			Address:         c.listenerAddr.IP.String(),
-			Port:            c.listenerAddr.Port,
+			Address:         c.listenerIp.String(),
+			Port:            c.listenerPort,
 			Expiration:      c.expiration,
 			ConnectionLimit: c.sessionAuthzData.GetConnectionLimit(),
 			SessionId:       c.sessionAuthzData.GetSessionId(),
@@ -544,44 +584,11 @@ func (c *Command) Run(args []string) (retCode int) {
 	c.connWg = new(sync.WaitGroup)
 
 	c.connWg.Add(1)
-	go func() {
-		defer c.connWg.Done()
-		for {
-			listeningConn, err := c.listener.AcceptTCP()
-			if err != nil {
-				select {
-				case <-c.proxyCtx.Done():
-					return
-				case <-c.Context.Done():
-					return
-				default:
-					// When this hits zero we trigger listener close so this
-					// isn't actually an error condition
-					if c.connectionsLeft.Load() == 0 {
-						return
-					}
-					c.PrintCliError(fmt.Errorf("Error accepting connection: %w", err))
-					continue
-				}
-			}
-			c.connWg.Add(1)
-			go func() {
-				defer listeningConn.Close()
-				defer c.connWg.Done()
-				wsConn, err := c.getWsConn(
-					c.proxyCtx,
-					workerAddr,
-					transport)
-				if err != nil {
-					c.PrintCliError(err)
-				} else {
-					if err := c.runTcpProxyV1(wsConn, listeningConn, tofuToken); err != nil {
-						c.PrintCliError(err)
-					}
-				}
-			}()
-		}
-	}()
+	if isUdp {
+		go c.runUdpListener(workerAddr, transport, tofuToken)
+	} else {
+		go c.runTcpListener(workerAddr, transport, tofuToken)
+	}
 
 	timer := time.NewTimer(time.Until(c.expiration))
 	c.connWg.Add(1)
@@ -676,6 +683,47 @@ func (c *Command) Run(args []string) (retCode int) {
 	return
 }
 
+// runTcpListener proxies each connection accepted by the tcp listener over
+// its own websocket connection to the worker until the listener is closed.
+func (c *Command) runTcpListener(workerAddr string, transport *http.Transport, tofuToken string) {
+	defer c.connWg.Done()
+	for {
+		listeningConn, err := c.listener.AcceptTCP()
+		if err != nil {
+			select {
+			case <-c.proxyCtx.Done():
+				return
+			case <-c.Context.Done():
+				return
+			default:
+				// When this hits zero we trigger listener close so this
+				// isn't actually an error condition
+				if c.connectionsLeft.Load() == 0 {
+					return
+				}
+				c.PrintCliError(fmt.Errorf("Error accepting connection: %w", err))
+				continue
+			}
+		}
+		c.connWg.Add(1)
+		go func() {
+			defer listeningConn.Close()
+			defer c.connWg.Done()
+			wsConn, err := c.getWsConn(
+				c.proxyCtx,
+				workerAddr,
+				transport)
+			if err != nil {
+				c.PrintCliError(err)
+			} else {
+				if err := c.runTcpProxyV1(wsConn, listeningConn, tofuToken); err != nil {
+					c.PrintCliError(err)
+				}
+			}
+		}()
+	}
+}
+
 func (c *Command) getWsConn(
 	ctx context.Context,
 	workerAddr string,
@@ -729,9 +777,10 @@ func (c *Command) sendSessionTeardown(
 	return nil
 }
 
-func (c *Command) runTcpProxyV1(
+// sendHandshake authorizes the connection of wsConn with the worker and
+// reports the number of connections left in the session.
+func (c *Command) sendHandshake(
 	wsConn *websocket.Conn,
-	listeningConn *net.TCPConn,
 	tofuToken string) error {
 	handshake := proxy.ClientHandshake{TofuToken: tofuToken}
 	if err := wspb.Write(c.proxyCtx, wsConn, &handshake); err != nil {
@@ -759,6 +808,16 @@ func (c *Command) runTcpProxyV1(
 	if handshakeResult.GetConnectionsLeft() != -1 {
 		c.connsLeftCh <- handshakeResult.GetConnectionsLeft()
 	}
+	return nil
+}
+
+func (c *Command) runTcpProxyV1(
+	wsConn *websocket.Conn,
+	listeningConn *net.TCPConn,
+	tofuToken string) error {
+	if err := c.sendHandshake(wsConn, tofuToken); err != nil {
+		return err
+	}
 
 	// Get a wrapped net.Conn so we can use io.Copy
 	netConn := websocket.NetConn(c.proxyCtx, wsConn, websocket.MessageBinary)
@@ -808,9 +867,9 @@ func (c *Command) handleExec(passthroughArgs []string) {
 	defer c.connWg.Done()
 	defer c.proxyCancel()
 
-	port := strconv.Itoa(c.listenerAddr.Port)
-	ip := c.listenerAddr.IP.String()
-	addr := c.listenerAddr.String()
+	port := strconv.Itoa(c.listenerPort)
+	ip := c.listenerIp.String()
+	addr := net.JoinHostPort(ip, port)
 
 	var args []string
 	var envs []string
@@ -839,7 +898,12 @@ func (c *Command) handleExec(passthroughArgs []string) {
 		args = append(args, c.rdpFlags.buildArgs(c, port, ip, addr)...)
 
 	case "ssh":
-		args = append(args, c.sshFlags.buildArgs(c, port, ip, addr)...)
+		sshArgs, sshErr := c.sshFlags.buildArgs(c, port, ip, addr)
+		if sshErr != nil {
+			argsErr = sshErr
+			break
+		}
+		args = append(args, sshArgs...)
 
 	case "kube":
 		kubeArgs, err := c.kubeFlags.buildArgs(c, port, ip, addr)
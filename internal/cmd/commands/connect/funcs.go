@@ -58,10 +58,19 @@ func generateCredentialTableOutputSlice(prefixIndent int, creds []*targets.Sessi
 		if crd.CredentialLibrary.Description != "" {
 			libMap["Credential Library Description"] = crd.CredentialLibrary.Description
 		}
+		if crd.CredentialSource != nil && crd.CredentialSource.CredentialType != "" {
+			libMap["Credential Type"] = crd.CredentialSource.CredentialType
+		}
 		maxLength := base.MaxAttributesLength(libMap, nil, nil)
 		ret = append(ret,
 			fmt.Sprintf("%sCredentials:", prefixString),
-			base.WrapMap(2+prefixIndent, maxLength, libMap),
+			base.WrapMap(2+prefixIndent, maxLength, libMap))
+		if len(crd.Credential) > 0 {
+			ret = append(ret,
+				fmt.Sprintf("%s  Credential:", prefixString),
+				base.WrapMap(4+prefixIndent, 0, crd.Credential))
+		}
+		ret = append(ret,
 			fmt.Sprintf("%s  Secret:", prefixString))
 		ret = append(ret,
 			fmtSecretForTable(2+prefixIndent, crd)...,
@@ -71,11 +80,26 @@ func generateCredentialTableOutputSlice(prefixIndent int, creds []*targets.Sessi
 	return ret
 }
 
+// typedCredential returns the typed credential of the first of creds whose
+// credential source provides credentials of credentialType, or nil if there
+// is none.
+func typedCredential(creds []*targets.SessionCredential, credentialType string) map[string]interface{} {
+	for _, crd := range creds {
+		if crd.CredentialSource == nil || crd.CredentialSource.CredentialType != credentialType {
+			continue
+		}
+		if len(crd.Credential) > 0 {
+			return crd.Credential
+		}
+	}
+	return nil
+}
+
 func fmtSecretForTable(indent int, sc *targets.SessionCredential) []string {
 	prefixStr := strings.Repeat(" ", indent)
 	origSecret := []string{fmt.Sprintf("%s    %s", prefixStr, sc.Secret.Raw)}
 	switch sc.CredentialLibrary.Type {
-	case "vault":
+	case "vault", "static":
 		in, err := base64.StdEncoding.DecodeString(strings.Trim(string(sc.Secret.Raw), `"`))
 		if err != nil {
 			return origSecret
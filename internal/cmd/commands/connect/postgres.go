@@ -60,7 +60,17 @@ type postgresCredentials struct {
 func (p *postgresFlags) buildArgs(c *Command, port, ip, addr string) (args, envs []string, retErr error) {
 	var creds postgresCredentials
 	if c.sessionAuthz != nil && len(c.sessionAuthz.Credentials) > 0 {
+		if up := typedCredential(c.sessionAuthz.Credentials, "username_password"); up != nil {
+			if err := mapstructure.Decode(up, &creds); err != nil {
+				return nil, nil, fmt.Errorf("Error interpreting username and password credential: %w", err)
+			}
+		}
+		// Fall back to guessing from the secrets of credential libraries
+		// which do not declare a credential type.
 		for _, cred := range c.sessionAuthz.Credentials {
+			if creds.Username != "" && creds.Password != "" {
+				break
+			}
 			if cred.Secret == nil || cred.Secret.Decoded == nil {
 				continue
 			}
@@ -72,12 +82,6 @@ func (p *postgresFlags) buildArgs(c *Command, port, ip, addr string) (args, envs
 					return nil, nil, fmt.Errorf("Error interpreting Vault secret: %w", err)
 				}
 			}
-
-			if creds.Username != "" && creds.Password != "" {
-				// In the future we can look for other types if we support other
-				// authentication mechanisms
-				break
-			}
 		}
 	}
 
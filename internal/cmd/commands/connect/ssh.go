@@ -1,11 +1,17 @@
 package connect
 
 import (
+	"crypto/ed25519"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/mapstructure"
 	"github.com/posener/complete"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
@@ -29,10 +35,16 @@ func sshOptions(c *Command, set *base.FlagSets) {
 		Target:     &c.flagUsername,
 		EnvVar:     "BOUNDARY_CONNECT_USERNAME",
 		Completion: complete.PredictNothing,
-		Usage:      `Specifies the username to pass through to the client`,
+		Usage:      `Specifies the username to pass through to the client. May be overridden by credentials sourced from a credential store.`,
 	})
 }
 
+type sshCredentials struct {
+	Username    string `mapstructure:"username"`
+	PrivateKey  string `mapstructure:"private_key"`
+	Certificate string `mapstructure:"certificate"`
+}
+
 type sshFlags struct {
 	flagSshStyle string
 }
@@ -41,18 +53,103 @@ func (s *sshFlags) defaultExec() string {
 	return strings.ToLower(s.flagSshStyle)
 }
 
-func (s *sshFlags) buildArgs(c *Command, port, ip, addr string) []string {
+func (s *sshFlags) buildArgs(c *Command, port, ip, addr string) ([]string, error) {
 	// Might want -t for ssh or -tt but seems fine without it for now...
 	var args []string
+	username := c.flagUsername
+	var keyPair sshCredentials
+	if c.sessionAuthz != nil && len(c.sessionAuthz.Credentials) > 0 {
+		// An SSH certificate is preferred over a plain SSH private key.
+		if sc := typedCredential(c.sessionAuthz.Credentials, "ssh_certificate"); sc != nil {
+			if err := mapstructure.Decode(sc, &keyPair); err != nil {
+				return nil, fmt.Errorf("Error interpreting SSH certificate credential: %w", err)
+			}
+		} else if kp := typedCredential(c.sessionAuthz.Credentials, "ssh_private_key"); kp != nil {
+			if err := mapstructure.Decode(kp, &keyPair); err != nil {
+				return nil, fmt.Errorf("Error interpreting SSH private key credential: %w", err)
+			}
+		}
+		if keyPair.Username != "" {
+			username = keyPair.Username
+		}
+	}
+
 	switch s.flagSshStyle {
 	case "ssh":
 		args = append(args, "-p", port, ip)
 		args = append(args, "-o", fmt.Sprintf("HostKeyAlias=%s", c.sessionAuthzData.HostId))
+		if c.sessionAuthzData.GetType() == "ssh" {
+			// The worker of an ssh target presents the session's key as
+			// its host key, which changes with every session, so it is
+			// pinned in a known hosts file for the session only instead of
+			// the user's.
+			knownHosts, err := c.sessionKnownHostsFile()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, "-o", fmt.Sprintf("UserKnownHostsFile=%s", knownHosts), "-o", "StrictHostKeyChecking=yes")
+		}
+		if keyPair.PrivateKey != "" {
+			// The private key must end with a newline for ssh to load it.
+			key := strings.TrimRight(keyPair.PrivateKey, "\n") + "\n"
+			keyfile, err := c.writeTempFile("SSH private key", key)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, "-i", keyfile, "-o", "IdentitiesOnly=yes")
+			if keyPair.Certificate != "" {
+				certfile, err := c.writeTempFile("SSH certificate", strings.TrimRight(keyPair.Certificate, "\n")+"\n")
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, "-o", fmt.Sprintf("CertificateFile=%s", certfile))
+			}
+		}
 	case "putty":
 		args = append(args, "-P", port, ip)
+		if keyPair.PrivateKey != "" {
+			c.UI.Warn("An SSH private key is being brokered but cannot be passed to putty; it is shown in the credential output instead.")
+		}
+	}
+	if username != "" {
+		args = append(args, "-l", username)
+	}
+	return args, nil
+}
+
+// sessionKnownHostsFile writes a known hosts file listing the public key of
+// the session as the host key of the session's host and returns its name.
+func (c *Command) sessionKnownHostsFile() (string, error) {
+	key := c.sessionAuthzData.GetPrivateKey()
+	if len(key) != ed25519.PrivateKeySize {
+		return "", errors.New("Session private key is missing or invalid")
+	}
+	pub, err := ssh.NewPublicKey(ed25519.PrivateKey(key).Public())
+	if err != nil {
+		return "", fmt.Errorf("Error deriving session host key: %w", err)
+	}
+	line := fmt.Sprintf("%s %s", c.sessionAuthzData.GetHostId(), ssh.MarshalAuthorizedKey(pub))
+	return c.writeTempFile("SSH known hosts", line)
+}
+
+// writeTempFile writes content, described by what, to a new temporary file
+// which is removed when the command exits. It returns the name of the file.
+func (c *Command) writeTempFile(what, content string) (string, error) {
+	f, err := ioutil.TempFile("", "*")
+	if err != nil {
+		return "", fmt.Errorf("Error saving %s to tmp file: %w", what, err)
+	}
+	c.cleanupFuncs = append(c.cleanupFuncs, func() error {
+		if err := os.Remove(f.Name()); err != nil {
+			return fmt.Errorf("Error removing temporary %s file; consider removing %s manually: %w", what, f.Name(), err)
+		}
+		return nil
+	})
+	if _, err := f.WriteString(content); err != nil {
+		return "", fmt.Errorf("Error writing %s file to %s: %w", what, f.Name(), err)
 	}
-	if c.flagUsername != "" {
-		args = append(args, "-l", c.flagUsername)
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("Error closing %s file after writing to %s: %w", what, f.Name(), err)
 	}
-	return args
+	return f.Name(), nil
 }
@@ -0,0 +1,156 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"nhooyr.io/websocket"
+)
+
+const (
+	// maxDatagramSize is the largest payload of a UDP datagram.
+	maxDatagramSize = 65535
+
+	// udpFlowQueueLen is the number of datagrams queued for a flow while its
+	// connection is being established or is behind.
+	udpFlowQueueLen = 64
+)
+
+// udpFlow is the datagrams sent to the udp listener from a single client
+// address. Each flow is proxied over its own websocket connection, and so
+// is one connection of the session.
+type udpFlow struct {
+	addr      *net.UDPAddr
+	datagrams chan []byte
+}
+
+// runUdpListener reads datagrams from the udp listener until it is closed
+// and sends each to the flow of its client address, starting a flow if
+// there is not one. A flow ends when the worker closes its connection,
+// which it does after the flow is idle; the next datagram from the same
+// client address starts a new flow.
+func (c *Command) runUdpListener(workerAddr string, transport *http.Transport, tofuToken string) {
+	defer c.connWg.Done()
+
+	var flowsMu sync.Mutex
+	flows := make(map[string]*udpFlow)
+
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := c.udpListener.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-c.proxyCtx.Done():
+				return
+			case <-c.Context.Done():
+				return
+			default:
+				// When this hits zero we trigger listener close so this
+				// isn't actually an error condition
+				if c.connectionsLeft.Load() == 0 {
+					return
+				}
+				c.PrintCliError(fmt.Errorf("Error reading datagram: %w", err))
+				continue
+			}
+		}
+
+		key := addr.String()
+		flowsMu.Lock()
+		flow, ok := flows[key]
+		if !ok {
+			flow = &udpFlow{
+				addr:      addr,
+				datagrams: make(chan []byte, udpFlowQueueLen),
+			}
+			flows[key] = flow
+			c.connWg.Add(1)
+			go func() {
+				defer c.connWg.Done()
+				defer func() {
+					flowsMu.Lock()
+					delete(flows, key)
+					flowsMu.Unlock()
+				}()
+				wsConn, err := c.getWsConn(
+					c.proxyCtx,
+					workerAddr,
+					transport)
+				if err != nil {
+					c.PrintCliError(err)
+				} else {
+					if err := c.runUdpProxyV1(wsConn, flow, tofuToken); err != nil {
+						c.PrintCliError(err)
+					}
+				}
+			}()
+		}
+		// Like a full socket buffer, a flow which cannot keep up drops the
+		// datagram.
+		select {
+		case flow.datagrams <- append([]byte(nil), buf[:n]...):
+		default:
+		}
+		flowsMu.Unlock()
+	}
+}
+
+// runUdpProxyV1 sends each datagram of flow as a binary message over wsConn
+// and each binary message received as a datagram to the client address of
+// flow, until either fails or wsConn is closed.
+func (c *Command) runUdpProxyV1(
+	wsConn *websocket.Conn,
+	flow *udpFlow,
+	tofuToken string) error {
+	if err := c.sendHandshake(wsConn, tofuToken); err != nil {
+		return err
+	}
+
+	// Each message is exactly one datagram, which can be larger than the
+	// default read limit of the websocket.
+	wsConn.SetReadLimit(maxDatagramSize)
+
+	ctx, cancel := context.WithCancel(c.proxyCtx)
+	defer cancel()
+
+	localWg := new(sync.WaitGroup)
+	localWg.Add(2)
+
+	go func() {
+		defer localWg.Done()
+		defer cancel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case d := <-flow.datagrams:
+				if err := wsConn.Write(ctx, websocket.MessageBinary, d); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	go func() {
+		defer localWg.Done()
+		defer cancel()
+		for {
+			typ, d, err := wsConn.Read(ctx)
+			if err != nil {
+				return
+			}
+			if typ != websocket.MessageBinary {
+				continue
+			}
+			if _, err := c.udpListener.WriteToUDP(d, flow.addr); err != nil {
+				return
+			}
+		}
+	}()
+	localWg.Wait()
+	wsConn.Close(websocket.StatusNormalClosure, "")
+
+	return nil
+}
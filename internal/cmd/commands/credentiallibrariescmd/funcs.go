@@ -135,6 +135,9 @@ func printItemTable(result api.GenericResult) string {
 	if item.Type != "" {
 		nonAttributeMap["Type"] = item.Type
 	}
+	if item.CredentialType != "" {
+		nonAttributeMap["Credential Type"] = item.CredentialType
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
 
@@ -168,6 +171,14 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.CredentialMappingOverrides) > 0 {
+		ret = append(ret,
+			"",
+			"  Credential Mapping Overrides:",
+			base.WrapMap(4, maxLength, item.CredentialMappingOverrides),
+		)
+	}
+
 	return base.WrapForHelpText(ret)
 }
 
@@ -1,6 +1,9 @@
 package credentiallibrariescmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/hashicorp/boundary/api/credentiallibraries"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
@@ -16,12 +19,16 @@ const (
 	pathFlagName            = "vault-path"
 	httpMethodFlagName      = "vault-http-method"
 	httpRequestBodyFlagName = "vault-http-request-body"
+	credentialTypeFlagName  = "credential-type"
+	mappingOverrideFlagName = "credential-mapping-override"
 )
 
 type extraVaultCmdVars struct {
-	flagPath            string
-	flagHttpMethod      string
-	flagHttpRequestBody string
+	flagPath             string
+	flagHttpMethod       string
+	flagHttpRequestBody  string
+	flagCredentialType   string
+	flagMappingOverrides []string
 }
 
 func extraVaultActionsFlagsMapFuncImpl() map[string][]string {
@@ -30,9 +37,16 @@ func extraVaultActionsFlagsMapFuncImpl() map[string][]string {
 			pathFlagName,
 			httpMethodFlagName,
 			httpRequestBodyFlagName,
+			credentialTypeFlagName,
+			mappingOverrideFlagName,
+		},
+		"update": {
+			pathFlagName,
+			httpMethodFlagName,
+			httpRequestBodyFlagName,
+			mappingOverrideFlagName,
 		},
 	}
-	flags["update"] = flags["create"]
 	return flags
 }
 
@@ -45,7 +59,7 @@ func extraVaultFlagsFuncImpl(c *VaultCommand, set *base.FlagSets, _ *base.FlagSe
 			f.StringVar(&base.StringVar{
 				Name:   pathFlagName,
 				Target: &c.flagPath,
-				Usage:  "The path in vault to request credentials from.",
+				Usage:  "The path in vault to request credentials from. This is a Go template which can use the data of the session, such as {{.User.Name}}.",
 			})
 		case httpMethodFlagName:
 			f.StringVar(&base.StringVar{
@@ -57,7 +71,19 @@ func extraVaultFlagsFuncImpl(c *VaultCommand, set *base.FlagSets, _ *base.FlagSe
 			f.StringVar(&base.StringVar{
 				Name:   httpRequestBodyFlagName,
 				Target: &c.flagHttpRequestBody,
-				Usage:  "The http request body the library uses to communicate with vault. This can be the value itself, refer to a file on disk (file://) from which the value will be read, or an env var (env://) from which the value will be read.",
+				Usage:  "The http request body the library uses to communicate with vault. This can be the value itself, refer to a file on disk (file://) from which the value will be read, or an env var (env://) from which the value will be read. This is a Go template which can use the data of the session, such as {{.User.Name}}.",
+			})
+		case credentialTypeFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   credentialTypeFlagName,
+				Target: &c.flagCredentialType,
+				Usage:  `The type of the credentials the library provides: "username_password", "ssh_private_key", "certificate", or "ssh_certificate". If not set, the secret is returned as is.`,
+			})
+		case mappingOverrideFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   mappingOverrideFlagName,
+				Target: &c.flagMappingOverrides,
+				Usage:  `The name of the field in the secret holding a part of the credential, in the form "key=value" where key is one of "username_attribute", "password_attribute", "private_key_attribute", or "certificate_attribute". A value of "null" restores the default field name. May be specified multiple times.`,
 			})
 		}
 	}
@@ -84,6 +110,28 @@ func extraVaultFlagHandlingFuncImpl(c *VaultCommand, f *base.FlagSets, opts *[]c
 		rb, _ := parseutil.ParsePath(c.flagHttpRequestBody)
 		*opts = append(*opts, credentiallibraries.WithVaultCredentialLibraryHttpRequestBody(rb))
 	}
+	switch c.flagCredentialType {
+	case "":
+	default:
+		*opts = append(*opts, credentiallibraries.WithCredentialType(c.flagCredentialType))
+	}
+	if len(c.flagMappingOverrides) > 0 {
+		overrides := make(map[string]interface{}, len(c.flagMappingOverrides))
+		for _, mo := range c.flagMappingOverrides {
+			kv := strings.SplitN(mo, "=", 2)
+			if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+				c.UI.Error(fmt.Sprintf("Credential mapping override %q is not in the form \"key=value\"", mo))
+				return false
+			}
+			switch kv[1] {
+			case "null":
+				overrides[kv[0]] = nil
+			default:
+				overrides[kv[0]] = kv[1]
+			}
+		}
+		*opts = append(*opts, credentiallibraries.WithCredentialMappingOverrides(overrides))
+	}
 
 	return true
 }
@@ -99,6 +147,10 @@ func (c *VaultCommand) extraVaultHelpFunc(helpMap map[string]func() string) stri
 			"",
 			`    $ boundary credential-libraries create vault -credential-store-id csvlt_1234567890 -vault-path "/some/path"`,
 			"",
+			"  Create a vault-type credential library providing username and password credentials from a KV secret with non-default field names. Example:",
+			"",
+			`    $ boundary credential-libraries create vault -credential-store-id csvlt_1234567890 -vault-path "secret/data/db" -credential-type username_password -credential-mapping-override username_attribute=login -credential-mapping-override password_attribute=secret`,
+			"",
 			"",
 		})
 
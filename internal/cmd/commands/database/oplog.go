@@ -0,0 +1,324 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*OplogCommand)(nil)
+	_ cli.CommandAutocomplete = (*OplogCommand)(nil)
+)
+
+type OplogCommand struct {
+	*base.Command
+}
+
+func (c *OplogCommand) Synopsis() string {
+	return "Verify and export Boundary's oplog"
+}
+
+func (c *OplogCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database oplog [sub command] [options] [args]",
+		"",
+		"  This command allows operations on the oplog, the encrypted history of the changes made to Boundary's resources. Example:",
+		"",
+		"    Verify the oplog:",
+		"",
+		`      $ boundary database oplog verify -config=/etc/boundary/controller.hcl`,
+		"",
+		"    Export the entries of a day as JSON lines:",
+		"",
+		`      $ boundary database oplog export -config=/etc/boundary/controller.hcl -start-time=2021-11-01T00:00:00Z -end-time=2021-11-02T00:00:00Z`,
+		"",
+		"  Please see the oplog subcommand help for detailed usage information.",
+	})
+}
+
+func (c *OplogCommand) Flags() *base.FlagSets {
+	return nil
+}
+
+func (c *OplogCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *OplogCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *OplogCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// oplogFlags are the flags shared by the oplog commands.
+type oplogFlags struct {
+	flagConfig    string
+	flagConfigKms string
+	flagLogLevel  string
+	flagLogFormat string
+	flagStartTime string
+	flagEndTime   string
+	flagAggregate string
+}
+
+func (o *oplogFlags) addFlags(set *base.FlagSets) {
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &o.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &o.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &o.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &o.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f = set.NewFlagSet("Range Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "start-time",
+		Target: &o.flagStartTime,
+		Usage:  "If set, only entries created at or after this time are used. The time must be in RFC 3339 format.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "end-time",
+		Target: &o.flagEndTime,
+		Usage:  "If set, only entries created before this time are used. The time must be in RFC 3339 format.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "aggregate",
+		Target: &o.flagAggregate,
+		Usage:  `If set, only entries of this aggregate are used. The aggregate of an entry is the table of the resource it changed, for example "iam_scope".`,
+	})
+}
+
+// listOptions returns the oplog options selecting the range of entries set by
+// the flags.
+func (o *oplogFlags) listOptions() ([]oplog.Option, error) {
+	var opts []oplog.Option
+	if o.flagStartTime != "" {
+		t, err := time.Parse(time.RFC3339, o.flagStartTime)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing start time: %w", err)
+		}
+		opts = append(opts, oplog.WithStartTime(t))
+	}
+	if o.flagEndTime != "" {
+		t, err := time.Parse(time.RFC3339, o.flagEndTime)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing end time: %w", err)
+		}
+		opts = append(opts, oplog.WithEndTime(t))
+	}
+	if o.flagAggregate != "" {
+		opts = append(opts, oplog.WithAggregateName(o.flagAggregate))
+	}
+	return opts, nil
+}
+
+// oplogReader reads and decrypts the oplog entries of the database configured
+// for the controller.
+type oplogReader struct {
+	srv      *base.Server
+	kms      *kms.Kms
+	types    *oplog.TypeCatalog
+	wrappers map[string]wrapping.Wrapper
+}
+
+// open parses the flags and the configuration, connects to the database and
+// sets up the kms used to decrypt the oplog. The returned cleanup function
+// must be called even if an error code is returned.
+func (o *oplogFlags) open(c *base.Command, args []string, set *base.FlagSets) (*oplogReader, func(), int) {
+	cleanup := func() {}
+	if err := set.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return nil, cleanup, base.CommandUserError
+	}
+	if len(o.flagConfig) == 0 {
+		c.UI.Error("Must specify a config file using -config")
+		return nil, cleanup, base.CommandUserError
+	}
+
+	wrapperPath := o.flagConfig
+	if o.flagConfigKms != "" {
+		wrapperPath = o.flagConfigKms
+	}
+	configWrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return nil, cleanup, base.CommandUserError
+	}
+	if configWrapper != nil {
+		if err := configWrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return nil, cleanup, base.CommandUserError
+		}
+		cleanup = func() {
+			if err := configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}
+	}
+	conf, err := config.LoadFile(o.flagConfig, configWrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return nil, cleanup, base.CommandUserError
+	}
+
+	srv := base.NewServer(&base.Command{UI: c.UI})
+	if err := srv.SetupLogging(o.flagLogLevel, o.flagLogFormat, conf.LogLevel, conf.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return nil, cleanup, base.CommandCliError
+	}
+	serverName := "boundary-database-oplog"
+	if conf.Controller != nil {
+		if _, err := conf.Controller.InitNameIfEmpty(); err != nil {
+			c.UI.Error(err.Error())
+			return nil, cleanup, base.CommandCliError
+		}
+		serverName = conf.Controller.Name + "/" + serverName
+	}
+	if err := srv.SetupEventing(srv.Logger, srv.StderrLock, serverName, base.WithEventerConfig(conf.Eventing)); err != nil {
+		c.UI.Error(err.Error())
+		return nil, cleanup, base.CommandCliError
+	}
+
+	if conf.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return nil, cleanup, base.CommandUserError
+	}
+	if conf.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return nil, cleanup, base.CommandUserError
+	}
+
+	if err := srv.SetupKMSes(c.UI, conf); err != nil {
+		c.UI.Error(err.Error())
+		return nil, cleanup, base.CommandUserError
+	}
+	configCleanup := cleanup
+	cleanup = func() {
+		if err := srv.RunShutdownFuncs(); err != nil {
+			c.UI.Warn(fmt.Errorf("Error running shutdown functions: %w", err).Error())
+		}
+		configCleanup()
+	}
+	if srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return nil, cleanup, base.CommandUserError
+	}
+
+	urlToParse := conf.Controller.Database.Url
+	if urlToParse == "" {
+		c.UI.Error(`"url" not specified in "database" config block`)
+		return nil, cleanup, base.CommandUserError
+	}
+	srv.DatabaseUrl, err = parseutil.ParsePath(urlToParse)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return nil, cleanup, base.CommandUserError
+	}
+	if err := srv.ConnectToDatabase(c.Context, "postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return nil, cleanup, base.CommandCliError
+	}
+
+	rw := db.New(srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return nil, cleanup, base.CommandCliError
+	}
+	kmsCache, err := kms.NewKms(kmsRepo)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return nil, cleanup, base.CommandCliError
+	}
+	if err := kmsCache.AddExternalWrappers(
+		kms.WithRootWrapper(srv.RootKms),
+		kms.WithPreviousRootWrapper(srv.PreviousRootKms),
+	); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding root kms to kms cache: %w", err).Error())
+		return nil, cleanup, base.CommandCliError
+	}
+
+	types, err := newOplogTypeCatalog()
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating oplog type catalog: %w", err).Error())
+		return nil, cleanup, base.CommandCliError
+	}
+
+	return &oplogReader{
+		srv:      srv,
+		kms:      kmsCache,
+		types:    types,
+		wrappers: make(map[string]wrapping.Wrapper),
+	}, cleanup, base.CommandSuccess
+}
+
+// decrypt decrypts the data of the entry with the oplog key version which
+// encrypted it.
+func (r *oplogReader) decrypt(ctx context.Context, e *oplog.Entry) error {
+	const op = "database.(oplogReader).decrypt"
+	keyId, err := e.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	w, ok := r.wrappers[keyId]
+	if !ok {
+		w, err = r.kms.OplogWrapper(ctx, keyId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		r.wrappers[keyId] = w
+	}
+	e.Cipherer = w
+	if err := e.DecryptData(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
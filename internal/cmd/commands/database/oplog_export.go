@@ -0,0 +1,142 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	_ cli.Command             = (*OplogExportCommand)(nil)
+	_ cli.CommandAutocomplete = (*OplogExportCommand)(nil)
+)
+
+type OplogExportCommand struct {
+	*base.Command
+
+	oplogFlags
+}
+
+func (c *OplogExportCommand) Synopsis() string {
+	return "Export the entries of Boundary's oplog as JSON lines"
+}
+
+func (c *OplogExportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database oplog export [options]",
+		"",
+		"  Export the entries of the scopes aggregate:",
+		"",
+		"    $ boundary database oplog export -config=/etc/boundary/controller.hcl -aggregate=iam_scope",
+		"",
+		"  Every entry in the range is decrypted and written as a line of JSON containing its metadata and its decoded messages, ordered by entry id.",
+		"",
+		"  The messages contain the resources as they were written, which can include sensitive values; the export should be protected accordingly.",
+	}) + c.Flags().Help()
+}
+
+func (c *OplogExportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetNone)
+	c.oplogFlags.addFlags(set)
+	return set
+}
+
+func (c *OplogExportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OplogExportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+// exportedEntry is the JSON representation of an oplog entry.
+type exportedEntry struct {
+	Id            uint32              `json:"id"`
+	CreateTime    time.Time           `json:"create_time"`
+	Version       string              `json:"version"`
+	AggregateName string              `json:"aggregate_name"`
+	KeyId         string              `json:"key_id"`
+	Metadata      map[string][]string `json:"metadata,omitempty"`
+	Messages      []*exportedMessage  `json:"messages"`
+}
+
+// exportedMessage is the JSON representation of a message of an oplog entry.
+type exportedMessage struct {
+	TypeName       string          `json:"type_name"`
+	OpType         string          `json:"op_type"`
+	FieldMaskPaths []string        `json:"field_mask_paths,omitempty"`
+	SetToNullPaths []string        `json:"set_to_null_paths,omitempty"`
+	Value          json.RawMessage `json:"value"`
+}
+
+func (c *OplogExportCommand) Run(args []string) int {
+	r, cleanup, code := c.oplogFlags.open(c.Command, args, c.Flags())
+	defer cleanup()
+	if code != base.CommandSuccess {
+		return code
+	}
+	listOpts, err := c.oplogFlags.listOptions()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	entries, err := oplog.ListEntries(c.Context, r.srv.Database.DB, listOpts...)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error listing oplog entries: %w", err).Error())
+		return base.CommandCliError
+	}
+	marshaler := protojson.MarshalOptions{UseProtoNames: true}
+	for _, e := range entries {
+		if err := r.decrypt(c.Context, e); err != nil {
+			c.UI.Error(fmt.Errorf("Error decrypting oplog entry %d: %w", e.Id, err).Error())
+			return base.CommandCliError
+		}
+		msgs, err := e.UnmarshalData(r.types)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error decoding oplog entry %d: %w", e.Id, err).Error())
+			return base.CommandCliError
+		}
+		keyId, _ := e.KeyId(c.Context)
+		out := &exportedEntry{
+			Id:            e.Id,
+			CreateTime:    e.CreateTime.AsTime(),
+			Version:       e.Version,
+			AggregateName: e.AggregateName,
+			KeyId:         keyId,
+		}
+		if len(e.Metadata) > 0 {
+			out.Metadata = make(map[string][]string, len(e.Metadata))
+			for _, md := range e.Metadata {
+				out.Metadata[md.Key] = append(out.Metadata[md.Key], md.Value)
+			}
+		}
+		for _, m := range msgs {
+			value, err := marshaler.Marshal(m.Message)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error encoding message of oplog entry %d: %w", e.Id, err).Error())
+				return base.CommandCliError
+			}
+			out.Messages = append(out.Messages, &exportedMessage{
+				TypeName:       m.TypeName,
+				OpType:         m.OpType.String(),
+				FieldMaskPaths: m.FieldMaskPaths,
+				SetToNullPaths: m.SetToNullPaths,
+				Value:          value,
+			})
+		}
+		b, err := json.Marshal(out)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error encoding oplog entry %d: %w", e.Id, err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	}
+	return base.CommandSuccess
+}
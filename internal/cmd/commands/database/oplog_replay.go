@@ -0,0 +1,190 @@
+package database
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"gorm.io/gorm"
+)
+
+var (
+	_ cli.Command             = (*OplogReplayCommand)(nil)
+	_ cli.CommandAutocomplete = (*OplogReplayCommand)(nil)
+)
+
+// errReplayRollback rolls back the replay transaction once the replay tables
+// have been compared.
+var errReplayRollback = stderrors.New("rollback replay")
+
+type OplogReplayCommand struct {
+	*base.Command
+
+	oplogFlags
+
+	flagTableSuffix   string
+	flagIgnoreColumns string
+	flagKeepTables    bool
+}
+
+func (c *OplogReplayCommand) Synopsis() string {
+	return "Replay entries of Boundary's oplog and compare the result with the database"
+}
+
+func (c *OplogReplayCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database oplog replay [options]",
+		"",
+		"  Replay the whole oplog:",
+		"",
+		"    $ boundary database oplog replay -config=/etc/boundary/controller.hcl",
+		"",
+		"  The entries in the range are decrypted and replayed, in order, into tables named like the tables of the resources they changed with the table suffix appended. Every replayed table is then compared with its original table and the number of replayed rows which don't match a row of the original table is reported.",
+		"",
+		"  Rows changed by entries outside of the range also don't match, so the range should start with the first entry of the aggregates replayed and end now for the replay to match the database. The command exits with a non-zero code if any row doesn't match.",
+		"",
+		"  The replay tables are dropped once compared, unless -keep-tables is set.",
+	}) + c.Flags().Help()
+}
+
+func (c *OplogReplayCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	c.oplogFlags.addFlags(set)
+
+	f := set.NewFlagSet("Replay Options")
+
+	f.StringVar(&base.StringVar{
+		Name:    "table-suffix",
+		Target:  &c.flagTableSuffix,
+		Default: "_replay",
+		Usage:   "The suffix appended to the names of the tables replayed into.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:    "ignore-columns",
+		Target:  &c.flagIgnoreColumns,
+		Default: "create_time,update_time,version",
+		Usage:   "A comma-separated list of columns which are not compared, because they are set by the database rather than recorded in the oplog.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "keep-tables",
+		Target: &c.flagKeepTables,
+		Usage:  "If set, the replay tables are kept for inspection. They are dropped by the next replay with the same table suffix.",
+	})
+
+	return set
+}
+
+func (c *OplogReplayCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OplogReplayCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+// replayedTable is the result of comparing a replay table with its original
+// table.
+type replayedTable struct {
+	Table      string `json:"table"`
+	Rows       int64  `json:"rows"`
+	Mismatched int64  `json:"mismatched"`
+}
+
+func (c *OplogReplayCommand) Run(args []string) int {
+	r, cleanup, code := c.oplogFlags.open(c.Command, args, c.Flags())
+	defer cleanup()
+	if code != base.CommandSuccess {
+		return code
+	}
+	listOpts, err := c.oplogFlags.listOptions()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	if c.flagTableSuffix == "" {
+		c.UI.Error("Table suffix must not be empty")
+		return base.CommandUserError
+	}
+	var ignoreColumns []string
+	for _, col := range strings.Split(c.flagIgnoreColumns, ",") {
+		if col = strings.TrimSpace(col); col != "" {
+			ignoreColumns = append(ignoreColumns, col)
+		}
+	}
+
+	entries, err := oplog.ListEntries(c.Context, r.srv.Database.DB, listOpts...)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error listing oplog entries: %w", err).Error())
+		return base.CommandCliError
+	}
+	for _, e := range entries {
+		if err := r.decrypt(c.Context, e); err != nil {
+			c.UI.Error(fmt.Errorf("Error decrypting oplog entry %d: %w", e.Id, err).Error())
+			return base.CommandCliError
+		}
+	}
+
+	var results []*replayedTable
+	err = r.srv.Database.DB.Transaction(func(tx *gorm.DB) error {
+		tables, err := oplog.ReplayEntries(c.Context, tx, r.types, c.flagTableSuffix, entries...)
+		if err != nil {
+			return err
+		}
+		for _, t := range tables {
+			rows, mismatched, err := oplog.CompareReplayTable(c.Context, tx, t, c.flagTableSuffix, ignoreColumns...)
+			if err != nil {
+				return err
+			}
+			results = append(results, &replayedTable{Table: t, Rows: rows, Mismatched: mismatched})
+		}
+		if !c.flagKeepTables {
+			return errReplayRollback
+		}
+		return nil
+	})
+	if err != nil && !stderrors.Is(err, errReplayRollback) {
+		c.UI.Error(fmt.Errorf("Error replaying oplog entries: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	var mismatched int64
+	for _, res := range results {
+		mismatched += res.Mismatched
+	}
+	switch base.Format(c.UI) {
+	case "json":
+		out := struct {
+			EntriesReplayed int              `json:"entries_replayed"`
+			Tables          []*replayedTable `json:"tables,omitempty"`
+		}{
+			EntriesReplayed: len(entries),
+			Tables:          results,
+		}
+		b, err := base.JsonFormatter{}.Format(out)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Output(fmt.Sprintf("Entries replayed: %d", len(entries)))
+		if len(results) > 0 {
+			c.UI.Output("")
+			c.UI.Output("Tables:")
+			for _, res := range results {
+				c.UI.Output(fmt.Sprintf("  %s: %d rows replayed, %d mismatched", res.Table, res.Rows, res.Mismatched))
+			}
+		}
+	}
+
+	if mismatched > 0 {
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
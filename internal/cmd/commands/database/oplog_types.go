@@ -0,0 +1,98 @@
+package database
+
+import (
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/credential/vault"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+// oplogTypes are the resources written to the oplog. The type name of an
+// oplog message is the table name of its resource.
+var oplogTypes = []oplog.ReplayableMessage{
+	&iam.Scope{},
+	&iam.User{},
+	&iam.Group{},
+	&iam.GroupMemberUser{},
+	&iam.Role{},
+	&iam.RoleGrant{},
+	&iam.UserRole{},
+	&iam.GroupRole{},
+	&iam.ManagedGroupRole{},
+
+	&authtoken.AuthToken{},
+
+	&password.AuthMethod{},
+	&password.Account{},
+	&password.Argon2Configuration{},
+	&password.Argon2Credential{},
+	&password.Totp{},
+
+	&oidc.AuthMethod{},
+	&oidc.Account{},
+	&oidc.ManagedGroup{},
+	&oidc.ManagedGroupMemberAccount{},
+	&oidc.AudClaim{},
+	&oidc.Certificate{},
+	&oidc.SigningAlg{},
+	&oidc.ClaimsScope{},
+	&oidc.AccountClaimMap{},
+
+	&ldap.AuthMethod{},
+	&ldap.Account{},
+	&ldap.ManagedGroup{},
+	&ldap.ManagedGroupMemberAccount{},
+	&ldap.Url{},
+	&ldap.Certificate{},
+
+	&static.HostCatalog{},
+	&static.Host{},
+	&static.HostSet{},
+	&static.HostSetMember{},
+
+	&plugin.HostCatalog{},
+	&plugin.Host{},
+	&plugin.HostSet{},
+	&plugin.HostSetMember{},
+
+	&target.TcpTarget{},
+	&target.SshTarget{},
+	&target.UdpTarget{},
+	&target.TargetHostSet{},
+	&target.CredentialLibrary{},
+
+	&vault.CredentialStore{},
+	&vault.CredentialLibrary{},
+	&vault.Token{},
+	&vault.ClientCertificate{},
+
+	&kms.RootKey{},
+	&kms.RootKeyVersion{},
+	&kms.DatabaseKey{},
+	&kms.DatabaseKeyVersion{},
+	&kms.OplogKey{},
+	&kms.OplogKeyVersion{},
+	&kms.TokenKey{},
+	&kms.TokenKeyVersion{},
+	&kms.SessionKey{},
+	&kms.SessionKeyVersion{},
+	&kms.OidcKey{},
+	&kms.OidcKeyVersion{},
+}
+
+// newOplogTypeCatalog returns a catalog of the resources written to the
+// oplog, used to unmarshal the messages of oplog entries.
+func newOplogTypeCatalog() (*oplog.TypeCatalog, error) {
+	types := make([]oplog.Type, 0, len(oplogTypes))
+	for _, t := range oplogTypes {
+		types = append(types, oplog.Type{Interface: t, Name: t.TableName()})
+	}
+	return oplog.NewTypeCatalog(types...)
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewOplogTypeCatalog(t *testing.T) {
+	types, err := newOplogTypeCatalog()
+	require.NoError(t, err)
+
+	names := make(map[string]bool, len(oplogTypes))
+	for _, m := range oplogTypes {
+		name := m.TableName()
+		assert.Falsef(t, names[name], "duplicate type name %s", name)
+		names[name] = true
+
+		// Every type must be decodable from the catalog, which requires its
+		// storage message to be allocated.
+		v, err := types.Get(name)
+		require.NoError(t, err, name)
+		pm, ok := v.(proto.Message)
+		require.Truef(t, ok, "%T is not a proto message", v)
+		require.NoError(t, proto.Unmarshal(nil, pm), name)
+		_, ok = v.(oplog.ReplayableMessage)
+		assert.Truef(t, ok, "%T is not replayable", v)
+	}
+}
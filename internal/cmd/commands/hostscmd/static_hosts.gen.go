@@ -163,6 +163,14 @@ func (c *StaticCommand) Run(args []string) int {
 		opts = append(opts, hosts.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, hosts.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, hosts.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
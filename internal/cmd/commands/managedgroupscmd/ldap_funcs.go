@@ -0,0 +1,87 @@
+package managedgroupscmd
+
+import (
+	"github.com/hashicorp/boundary/api/managedgroups"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+const (
+	groupNameFlagName = "group-name"
+)
+
+func init() {
+	extraLdapFlagsFunc = extraLdapFlagsFuncImpl
+	extraLdapActionsFlagsMapFunc = extraLdapActionsFlagsMapFuncImpl
+	extraLdapFlagsHandlingFunc = extraLdapFlagsHandlingFuncImpl
+}
+
+func extraLdapActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {groupNameFlagName},
+		"update": {groupNameFlagName},
+	}
+}
+
+type extraLdapCmdVars struct {
+	flagGroupNames []string
+}
+
+func (c *LdapCommand) extraLdapHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary managed-groups create ldap [options] [args]",
+			"",
+			"  Create an ldap-type managed group. Example:",
+			"",
+			`    $ boundary managed-groups create ldap -group-name admins -group-name operators -description "Ldap managed group for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary managed-groups update ldap [options] [args]",
+			"",
+			"  Update an ldap-type managed group given its ID. Example:",
+			"",
+			`    $ boundary managed-groups update ldap -id mgldap_1234567890 -name "devops" -description "Ldap managed group for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraLdapFlagsFuncImpl(c *LdapCommand, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsLdapMap[c.Func] {
+		switch name {
+		case groupNameFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   groupNameFlagName,
+				Target: &c.flagGroupNames,
+				Usage:  "The name of a directory group whose members belong to this managed group. May be specified multiple times.",
+			})
+		}
+	}
+}
+
+func extraLdapFlagsHandlingFuncImpl(c *LdapCommand, _ *base.FlagSets, opts *[]managedgroups.Option) bool {
+	switch {
+	case len(c.flagGroupNames) == 1 && c.flagGroupNames[0] == "null":
+		c.UI.Error("Group names must be defined, and cannot be cleared.")
+		return false
+
+	case len(c.flagGroupNames) == 0:
+		if c.Func == "create" {
+			c.UI.Error("The -group-name flag must be provided at least once when creating a managed group.")
+			return false
+		}
+
+	default:
+		*opts = append(*opts, managedgroups.WithLdapManagedGroupGroupNames(c.flagGroupNames))
+	}
+
+	return true
+}
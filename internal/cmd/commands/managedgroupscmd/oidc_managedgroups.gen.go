@@ -163,6 +163,14 @@ func (c *OidcCommand) Run(args []string) int {
 		opts = append(opts, managedgroups.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, managedgroups.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, managedgroups.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
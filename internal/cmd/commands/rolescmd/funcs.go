@@ -18,12 +18,20 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
 	flagGrantScopeId string
 	flagPrincipals   []string
 	flagGrants       []string
+	flagUserId       string
+	flagResourceId   string
+	flagResourceType string
+	flagPin          string
+	flagAction       string
+	flagClientIp     string
+	explanation      *roles.ExplanationResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
@@ -36,6 +44,7 @@ func extraActionsFlagsMapFuncImpl() map[string][]string {
 		"add-grants":        {"id", "grant", "version"},
 		"set-grants":        {"id", "grant", "version"},
 		"remove-grants":     {"id", "grant", "version"},
+		"explain":           {"scope-id", "user-id", "resource-id", "resource-type", "pin", "action", "client-ip"},
 	}
 }
 
@@ -45,6 +54,8 @@ func extraSynopsisFuncImpl(c *Command) string {
 		return c.principalsGrantsSynopsisFunc(c.Func, true)
 	case "add-grants", "set-grants", "remove-grants":
 		return c.principalsGrantsSynopsisFunc(c.Func, false)
+	case "explain":
+		return "Explain whether the roles of a user authorize an action on a resource"
 	}
 
 	return ""
@@ -136,6 +147,19 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "explain":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles explain [options] [args]",
+			"",
+			`  Explains whether the grants of the roles of a user authorize an action on a resource, without performing the action. The grants which matched and the roles providing them are shown, including any deny grant. The scope ID is the scope containing the resource. If the resource ID is not given, the action is explained for the collection of the resource type. Example:`,
+			"",
+			`    $ boundary roles explain -scope-id p_1234567890 -user-id u_1234567890 -resource-id ttcp_1234567890 -action authorize-session`,
+			"",
+			"  A collection within another resource, such as the hosts of a host catalog, also requires the ID of the containing resource via -pin. For a resource ID, the containing resource is looked up.",
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -163,6 +187,42 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagGrants,
 				Usage:  "The grants to add, remove, or set. May be specified multiple times. Can be in compact string format or JSON (be sure to escape JSON properly).",
 			})
+		case "user-id":
+			f.StringVar(&base.StringVar{
+				Name:   "user-id",
+				Target: &c.flagUserId,
+				Usage:  "The ID of the user to explain the authorization for.",
+			})
+		case "resource-id":
+			f.StringVar(&base.StringVar{
+				Name:   "resource-id",
+				Target: &c.flagResourceId,
+				Usage:  "The ID of the resource. If not set, the action is explained for the collection of the resource type.",
+			})
+		case "resource-type":
+			f.StringVar(&base.StringVar{
+				Name:   "resource-type",
+				Target: &c.flagResourceType,
+				Usage:  `The type of the resource, e.g. "target". Required if it can't be determined from the resource ID.`,
+			})
+		case "pin":
+			f.StringVar(&base.StringVar{
+				Name:   "pin",
+				Target: &c.flagPin,
+				Usage:  "The ID of the resource containing the collection, e.g. the host catalog of the hosts. Ignored if a resource ID is given.",
+			})
+		case "action":
+			f.StringVar(&base.StringVar{
+				Name:   "action",
+				Target: &c.flagAction,
+				Usage:  `The action to explain, e.g. "read".`,
+			})
+		case "client-ip":
+			f.StringVar(&base.StringVar{
+				Name:   "client-ip",
+				Target: &c.flagClientIp,
+				Usage:  "The IP address of the client to use when evaluating grant conditions.",
+			})
 		}
 	}
 }
@@ -189,6 +249,34 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]roles.Opti
 			return false
 		}
 
+	case "explain":
+		switch {
+		case c.FlagScopeId == "":
+			c.UI.Error("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID")
+			return false
+		case c.flagUserId == "":
+			c.UI.Error("No user ID supplied via -user-id")
+			return false
+		case c.flagAction == "":
+			c.UI.Error("No action supplied via -action")
+			return false
+		case c.flagResourceId == "" && c.flagResourceType == "":
+			c.UI.Error("One of -resource-id or -resource-type must be supplied")
+			return false
+		}
+		if c.flagResourceId != "" {
+			*opts = append(*opts, roles.WithResourceId(c.flagResourceId))
+		}
+		if c.flagResourceType != "" {
+			*opts = append(*opts, roles.WithResourceType(c.flagResourceType))
+		}
+		if c.flagPin != "" {
+			*opts = append(*opts, roles.WithPin(c.flagPin))
+		}
+		if c.flagClientIp != "" {
+			*opts = append(*opts, roles.WithClientIp(c.flagClientIp))
+		}
+
 	case "set-principals":
 		switch len(c.flagPrincipals) {
 		case 0:
@@ -239,6 +327,11 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return roleClient.SetGrants(c.Context, c.FlagId, version, c.flagGrants, opts...)
 	case "remove-grants":
 		return roleClient.RemoveGrants(c.Context, c.FlagId, version, c.flagGrants, opts...)
+	case "explain":
+		var err error
+		c.plural = "role authorization"
+		c.explanation, err = roleClient.Explain(c.Context, c.FlagScopeId, c.flagUserId, c.flagAction, opts...)
+		return nil, err
 	}
 	return origResult, origError
 }
@@ -373,3 +466,85 @@ func printItemTable(result api.GenericResult) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "explain":
+		item := c.explanation.GetItem().(*roles.Explanation)
+
+		switch base.Format(c.UI) {
+		case "table":
+			var decision string
+			switch {
+			case item.Authorized:
+				decision = "allowed"
+			case item.Denied:
+				decision = "denied by a deny grant"
+			default:
+				decision = "not allowed, no grants matched"
+			}
+			nonAttributeMap := map[string]interface{}{
+				"User ID":  item.UserId,
+				"Scope ID": item.ScopeId,
+				"Action":   item.Action,
+				"Type":     item.ResourceType,
+				"Decision": decision,
+			}
+			if item.ResourceId != "" {
+				nonAttributeMap["Resource ID"] = item.ResourceId
+			}
+			if item.Pin != "" {
+				nonAttributeMap["Pin"] = item.Pin
+			}
+
+			maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+			ret := []string{
+				"",
+				"Authorization explanation:",
+				base.WrapMap(2, maxLength+2, nonAttributeMap),
+			}
+
+			if len(item.Grants) > 0 {
+				ret = append(ret,
+					"",
+					fmt.Sprintf("  Matched Grants: %s", ""),
+				)
+			}
+			for _, grant := range item.Grants {
+				ret = append(ret,
+					fmt.Sprintf("    %s", grant.Canonical),
+					fmt.Sprintf("      Effect:     %s", grant.Effect),
+					fmt.Sprintf("      Role ID:    %s", grant.RoleId),
+					fmt.Sprintf("      Scope ID:   %s", grant.ScopeId),
+				)
+			}
+
+			if len(item.ConsultedScopeIds) > 0 {
+				ret = append(ret,
+					"",
+					"  Consulted Scope IDs:",
+					base.WrapSlice(4, item.ConsultedScopeIds),
+				)
+			}
+
+			if len(item.OutputFields) > 0 {
+				ret = append(ret,
+					"",
+					"  Output Fields:",
+					base.WrapSlice(4, item.OutputFields),
+				)
+			}
+
+			c.UI.Output(base.WrapForHelpText(ret))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.explanation); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
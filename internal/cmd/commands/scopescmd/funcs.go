@@ -14,18 +14,27 @@ const (
 	flagPrimaryAuthMethodIdName     = "primary-auth-method-id"
 	flagSkipAdminRoleCreationName   = "skip-admin-role-creation"
 	flagSkipDefaultRoleCreationName = "skip-default-role-creation"
+	flagRewrapName                  = "rewrap"
+	flagKeyVersionIdName            = "key-version-id"
 )
 
 func init() {
 	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
-		"update": {flagPrimaryAuthMethodIdName},
+		"create":                            {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
+		"update":                            {flagPrimaryAuthMethodIdName},
+		"rotate-keys":                       {"id", flagRewrapName},
+		"list-keys":                         {"id"},
+		"destroy-key-version":               {"id", flagKeyVersionIdName},
+		"list-key-version-destruction-jobs": {"id"},
 	}
 }
 
@@ -33,6 +42,91 @@ type extraCmdVars struct {
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
 	flagPrimaryAuthMethodId     string
+	flagRewrap                  bool
+	flagKeyVersionId            string
+
+	rotateKeysResult        *scopes.RotateKeysResult
+	keyListResult           *scopes.KeyListResult
+	destroyKeyVersionResult *scopes.DestroyKeyVersionResult
+	destructionJobsResult   *scopes.KeyVersionDestructionJobListResult
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "rotate-keys":
+		return "Rotate the keys of a scope"
+
+	case "list-keys":
+		return "List the keys of a scope"
+
+	case "destroy-key-version":
+		return "Destroy a key version of a scope"
+
+	case "list-key-version-destruction-jobs":
+		return "List the key version destruction jobs of a scope"
+
+	default:
+		return ""
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return helpMap["base"]()
+	case "rotate-keys":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes rotate-keys [options] [args]",
+			"",
+			"  This command creates a new version of the root key and of the data encryption keys of a scope. New values are encrypted with the new key versions. Example:",
+			"",
+			"    Rotate the keys of a scope and rewrap the existing key versions:",
+			"",
+			`      $ boundary scopes rotate-keys -id o_1234567890 -rewrap`,
+			"",
+			"",
+		})
+	case "list-keys":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes list-keys [options] [args]",
+			"",
+			"  This command lists the root key and the data encryption keys of a scope with their versions. Example:",
+			"",
+			"    List the keys of a scope:",
+			"",
+			`      $ boundary scopes list-keys -id o_1234567890`,
+			"",
+			"",
+		})
+	case "destroy-key-version":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes destroy-key-version [options] [args]",
+			"",
+			"  This command destroys a version of the root key or of the database key of a scope. The current version of a key cannot be destroyed. A database key version is destroyed by a background job once every value it encrypts has been rewrapped. Example:",
+			"",
+			"    Destroy a database key version of a scope:",
+			"",
+			`      $ boundary scopes destroy-key-version -id o_1234567890 -key-version-id kdkv_1234567890`,
+			"",
+			"",
+		})
+	case "list-key-version-destruction-jobs":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes list-key-version-destruction-jobs [options] [args]",
+			"",
+			"  This command lists the pending database key version destruction jobs of a scope and their progress. Example:",
+			"",
+			"    List the key version destruction jobs of a scope:",
+			"",
+			`      $ boundary scopes list-key-version-destruction-jobs -id o_1234567890`,
+			"",
+			"",
+		})
+	default:
+		return helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -56,6 +150,18 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagPrimaryAuthMethodId,
 				Usage:  "If set, the primary auth method id for the scope.  A primary auth method is allowed to create users on first login and is also used as a source for account full name and email for a scope's users",
 			})
+		case flagRewrapName:
+			f.BoolVar(&base.BoolVar{
+				Name:   flagRewrapName,
+				Target: &c.flagRewrap,
+				Usage:  "If set, the existing key versions are encrypted again with the current root KMS and the new root key version.",
+			})
+		case flagKeyVersionIdName:
+			f.StringVar(&base.StringVar{
+				Name:   flagKeyVersionIdName,
+				Target: &c.flagKeyVersionId,
+				Usage:  "The ID of the key version to destroy.",
+			})
 		}
 	}
 }
@@ -74,6 +180,148 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]scopes.Opt
 	return true
 }
 
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, scopeClient *scopes.Client, version uint32, opts []scopes.Option) (api.GenericResult, error) {
+	var err error
+	switch c.Func {
+	case "rotate-keys":
+		c.rotateKeysResult, err = scopeClient.RotateKeys(c.Context, c.FlagId, c.flagRewrap, opts...)
+		return nil, err
+	case "list-keys":
+		c.keyListResult, err = scopeClient.ListKeys(c.Context, c.FlagId, opts...)
+		return nil, err
+	case "destroy-key-version":
+		if c.flagKeyVersionId == "" {
+			return nil, fmt.Errorf("Key version ID must be passed in via -%s", flagKeyVersionIdName)
+		}
+		c.destroyKeyVersionResult, err = scopeClient.DestroyKeyVersion(c.Context, c.FlagId, c.flagKeyVersionId, opts...)
+		return nil, err
+	case "list-key-version-destruction-jobs":
+		c.destructionJobsResult, err = scopeClient.ListKeyVersionDestructionJobs(c.Context, c.FlagId, opts...)
+		return nil, err
+	}
+	return origResult, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "rotate-keys":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output("The rotate-keys operation completed successfully.")
+		case "json":
+			if ok := c.PrintJsonItem(c.rotateKeysResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
+
+	case "list-keys":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printKeyListTable(c.keyListResult.Items))
+		case "json":
+			if ok := c.PrintJsonItems(c.keyListResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
+
+	case "destroy-key-version":
+		switch base.Format(c.UI) {
+		case "table":
+			switch c.destroyKeyVersionResult.State {
+			case "destroyed":
+				c.UI.Output("The key version was destroyed.")
+			default:
+				c.UI.Output("The key version will be destroyed once every value it encrypts has been rewrapped. Use list-key-version-destruction-jobs to follow the progress.")
+			}
+		case "json":
+			if ok := c.PrintJsonItem(c.destroyKeyVersionResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
+
+	case "list-key-version-destruction-jobs":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printDestructionJobListTable(c.destructionJobsResult.Items))
+		case "json":
+			if ok := c.PrintJsonItems(c.destructionJobsResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func printKeyListTable(items []*scopes.Key) string {
+	if len(items) == 0 {
+		return "No keys found"
+	}
+	output := []string{
+		"",
+		"Key information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:                %s", item.Id),
+			fmt.Sprintf("    Purpose:         %s", item.Purpose),
+		)
+		if !item.CreatedTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Created Time:    %s", item.CreatedTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if len(item.Versions) > 0 {
+			output = append(output, "    Versions:")
+			for _, v := range item.Versions {
+				output = append(output,
+					fmt.Sprintf("      ID:            %s", v.Id),
+					fmt.Sprintf("        Version:     %d", v.Version),
+				)
+				if !v.CreatedTime.IsZero() {
+					output = append(output,
+						fmt.Sprintf("        Created Time: %s", v.CreatedTime.Local().Format(time.RFC1123)),
+					)
+				}
+			}
+		}
+	}
+	return base.WrapForHelpText(output)
+}
+
+func printDestructionJobListTable(items []*scopes.KeyVersionDestructionJob) string {
+	if len(items) == 0 {
+		return "No key version destruction jobs found"
+	}
+	output := []string{
+		"",
+		"Key version destruction job information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Key Version ID:    %s", item.KeyVersionId),
+			fmt.Sprintf("    Status:          %s", item.Status),
+			fmt.Sprintf("    Completed Count: %d", item.CompletedCount),
+			fmt.Sprintf("    Total Count:     %d", item.TotalCount),
+		)
+		if !item.CreatedTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Created Time:    %s", item.CreatedTime.Local().Format(time.RFC1123)),
+			)
+		}
+	}
+	return base.WrapForHelpText(output)
+}
+
 func (c *Command) printListTable(items []*scopes.Scope) string {
 	if len(items) == 0 {
 		return "No child scopes found"
@@ -190,9 +190,11 @@ func (c *Command) Run(args []string) int {
 			return base.CommandUserError
 		}
 	}
-	if c.WorkerAuthKms == nil {
-		c.UI.Error("Worker Auth KMS not found after parsing KMS blocks")
-		return base.CommandUserError
+	if c.Config.Worker != nil {
+		if c.WorkerAuthKms == nil && c.Config.Worker.AuthStoragePath == "" {
+			c.UI.Error("Worker Auth KMS not found after parsing KMS blocks and no worker auth storage path configured")
+			return base.CommandUserError
+		}
 	}
 
 	if c.Config.DefaultMaxRequestDuration != 0 {
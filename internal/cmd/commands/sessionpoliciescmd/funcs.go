@@ -0,0 +1,222 @@
+package sessionpoliciescmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/sessionpolicies"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+}
+
+type extraCmdVars struct {
+	flagMaxActiveSessionsPerUser      string
+	flagMaxDailySessionSecondsPerUser string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"max-active-sessions-per-user", "max-daily-session-seconds-per-user"},
+		"update": {"max-active-sessions-per-user", "max-daily-session-seconds-per-user"},
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary session-policies [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary session policy resources. A session policy limits the number of active sessions and the total session time per day for each user within the scope it is defined in. Example:",
+			"",
+			"    Create a session policy:",
+			"",
+			`      $ boundary session-policies create -scope-id o_1234567890 -max-active-sessions-per-user 5`,
+			"",
+			"  Please see the session-policies subcommand help for detailed usage information.",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "max-active-sessions-per-user":
+			f.StringVar(&base.StringVar{
+				Name:   "max-active-sessions-per-user",
+				Target: &c.flagMaxActiveSessionsPerUser,
+				Usage:  `The maximum number of sessions a user may have active at the same time. Use "null" to remove the limit.`,
+			})
+		case "max-daily-session-seconds-per-user":
+			f.StringVar(&base.StringVar{
+				Name:   "max-daily-session-seconds-per-user",
+				Target: &c.flagMaxDailySessionSecondsPerUser,
+				Usage:  `The maximum total session time a user may use within a 24 hour window. Can be specified as an integer number of seconds or a duration string. Use "null" to remove the limit.`,
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]sessionpolicies.Option) bool {
+	switch c.flagMaxActiveSessionsPerUser {
+	case "":
+	case "null":
+		*opts = append(*opts, sessionpolicies.DefaultMaxActiveSessionsPerUser())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxActiveSessionsPerUser, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxActiveSessionsPerUser, err))
+			return false
+		}
+		*opts = append(*opts, sessionpolicies.WithMaxActiveSessionsPerUser(uint32(limit)))
+	}
+
+	switch c.flagMaxDailySessionSecondsPerUser {
+	case "":
+	case "null":
+		*opts = append(*opts, sessionpolicies.DefaultMaxDailySessionSecondsPerUser())
+	default:
+		var final uint32
+		secs, err := strconv.ParseUint(c.flagMaxDailySessionSecondsPerUser, 10, 32)
+		if err == nil {
+			final = uint32(secs)
+		} else {
+			dur, err := time.ParseDuration(c.flagMaxDailySessionSecondsPerUser)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxDailySessionSecondsPerUser, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, sessionpolicies.WithMaxDailySessionSecondsPerUser(final))
+	}
+
+	return true
+}
+
+func (c *Command) printListTable(items []*sessionpolicies.SessionPolicy) string {
+	if len(items) == 0 {
+		return "No session policies found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Session Policy information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:                            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:                             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:                         %s", item.Description),
+			)
+		}
+		if item.MaxActiveSessionsPerUser > 0 {
+			output = append(output,
+				fmt.Sprintf("    Max Active Sessions Per User:        %d", item.MaxActiveSessionsPerUser),
+			)
+		}
+		if item.MaxDailySessionSecondsPerUser > 0 {
+			output = append(output,
+				fmt.Sprintf("    Max Daily Session Seconds Per User:  %d", item.MaxDailySessionSecondsPerUser),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(result api.GenericResult) string {
+	item := result.GetItem().(*sessionpolicies.SessionPolicy)
+	nonAttributeMap := map[string]interface{}{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.MaxActiveSessionsPerUser > 0 {
+		nonAttributeMap["Max Active Sessions Per User"] = item.MaxActiveSessionsPerUser
+	}
+	if item.MaxDailySessionSecondsPerUser > 0 {
+		nonAttributeMap["Max Daily Session Seconds Per User"] = item.MaxDailySessionSecondsPerUser
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Session Policy information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -12,12 +12,31 @@ import (
 
 func init() {
 	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	listMineResult *sessions.SessionListResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"cancel": {"id"},
+		"cancel":    {"id"},
+		"approve":   {"id"},
+		"deny":      {"id"},
+		"list-mine": {"filter", "page-size", "list-token"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "list-mine":
+		return "List your active sessions"
+
+	default:
+		return ""
 	}
 }
 
@@ -48,6 +67,39 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "approve":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions approve [options] [args]",
+			"",
+			"  Approve the session specified by ID, which must be pending approval. A session cannot be approved by its own user. Once approved, the user of the session retrieves its authorization with the -session-id flag of \"boundary targets authorize-session\" before the approval expiration time of the session. Example:",
+			"",
+			`    $ boundary sessions approve -id s_1234567890`,
+			"",
+			"",
+		})
+
+	case "deny":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions deny [options] [args]",
+			"",
+			"  Deny the session specified by ID, which must be pending approval. The session is terminated. A session cannot be denied by its own user. Example:",
+			"",
+			`    $ boundary sessions deny -id s_1234567890`,
+			"",
+			"",
+		})
+
+	case "list-mine":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions list-mine [options] [args]",
+			"",
+			"  List your sessions which are not terminated, in every scope, with their connections. Unlike list, this command doesn't require permission to list sessions. Example:",
+			"",
+			`    $ boundary sessions list-mine`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -59,10 +111,38 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 	switch c.Func {
 	case "cancel":
 		return sessionClient.Cancel(c.Context, c.FlagId, version, opts...)
+	case "approve":
+		return sessionClient.Approve(c.Context, c.FlagId, version, opts...)
+	case "deny":
+		return sessionClient.Deny(c.Context, c.FlagId, version, opts...)
+	case "list-mine":
+		var err error
+		c.plural = "sessions"
+		c.listMineResult, err = sessionClient.ListMine(c.Context, opts...)
+		return nil, err
 	}
 	return origResult, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "list-mine":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(c.printListTable(c.listMineResult.Items))
+			if c.listMineResult.GetListToken() != "" {
+				c.UI.Output(fmt.Sprintf("\nMore items are available. Use -list-token %s to list the next page.", c.listMineResult.GetListToken()))
+			}
+		case "json":
+			if ok := c.PrintJsonItems(c.listMineResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*sessions.Session) string {
 	if len(items) == 0 {
 		return "No sessions found"
@@ -85,7 +165,7 @@ func (c *Command) printListTable(items []*sessions.Session) string {
 				fmt.Sprintf("  ID:                    %s", "(not available)"),
 			)
 		}
-		if c.FlagRecursive && item.ScopeId != "" {
+		if (c.FlagRecursive || c.Func == "list-mine") && item.ScopeId != "" {
 			output = append(output,
 				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
 			)
@@ -120,6 +200,23 @@ func (c *Command) printListTable(items []*sessions.Session) string {
 				fmt.Sprintf("    Target ID:           %s", item.TargetId),
 			)
 		}
+		if len(item.Connections) > 0 {
+			output = append(output, "    Connections:")
+			for _, conn := range item.Connections {
+				output = append(output,
+					fmt.Sprintf("      ID:                %s", conn.Id),
+					fmt.Sprintf("        Status:          %s", conn.Status),
+					fmt.Sprintf("        Client:          %s:%d", conn.ClientTcpAddress, conn.ClientTcpPort),
+					fmt.Sprintf("        Bytes Up:        %d", conn.BytesUp),
+					fmt.Sprintf("        Bytes Down:      %d", conn.BytesDown),
+				)
+				if conn.ClosedReason != "" {
+					output = append(output,
+						fmt.Sprintf("        Closed Reason:   %s", conn.ClosedReason),
+					)
+				}
+			}
+		}
 		if len(item.AuthorizedActions) > 0 {
 			output = append(output,
 				"    Authorized Actions:",
@@ -176,6 +273,12 @@ func printItemTable(result api.GenericResult) string {
 	if len(strings.TrimSpace(item.TerminationReason)) > 0 {
 		nonAttributeMap["Termination Reason"] = item.TerminationReason
 	}
+	if !item.ApprovalExpirationTime.IsZero() {
+		nonAttributeMap["Approval Expiration Time"] = item.ApprovalExpirationTime.Local().Format(time.RFC1123)
+	}
+	if item.ApproverId != "" {
+		nonAttributeMap["Approver ID"] = item.ApproverId
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -209,6 +312,20 @@ func printItemTable(result api.GenericResult) string {
 		}
 	}
 
+	var recordingMap map[string]interface{}
+	if item.Recording != nil {
+		recordingMap = map[string]interface{}{}
+		if item.Recording.WorkerId != "" {
+			recordingMap["Worker ID"] = item.Recording.WorkerId
+		}
+		if item.Recording.StoragePath != "" {
+			recordingMap["Storage Path"] = item.Recording.StoragePath
+		}
+		if l := len("Storage Path"); l > maxLength {
+			maxLength = l
+		}
+	}
+
 	ret := []string{
 		"",
 		"Session information:",
@@ -256,5 +373,21 @@ func printItemTable(result api.GenericResult) string {
 		}
 	}
 
+	if item.Recording != nil {
+		ret = append(ret,
+			"  Recording:",
+		)
+		if len(recordingMap) > 0 {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, recordingMap),
+			)
+		} else {
+			ret = append(ret,
+				"    Pending activation by a worker",
+			)
+		}
+		ret = append(ret, "")
+	}
+
 	return base.WrapForHelpText(ret)
 }
@@ -33,13 +33,16 @@ type extraCmdVars struct {
 	flagHostSources                    []string
 	flagApplicationCredentialLibraries []string
 	flagApplicationCredentialSources   []string
+	flagEgressCredentialSources        []string
 	flagHostId                         string
+	flagSessionId                      string
 	sar                                *targets.SessionAuthorizationResult
+	listMineResult                     *targets.TargetListResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"authorize-session":           {"id", "host-id"},
+		"authorize-session":           {"id", "host-id", "session-id"},
 		"add-host-sets":               {"id", "host-set", "version"},
 		"remove-host-sets":            {"id", "host-set", "version"},
 		"set-host-sets":               {"id", "host-set", "version"},
@@ -49,9 +52,10 @@ func extraActionsFlagsMapFuncImpl() map[string][]string {
 		"add-credential-libraries":    {"id", "application-credential-library", "version"},
 		"remove-credential-libraries": {"id", "application-credential-library", "version"},
 		"set-credential-libraries":    {"id", "application-credential-library", "version"},
-		"add-credential-sources":      {"id", "application-credential-source", "version"},
-		"remove-credential-sources":   {"id", "application-credential-source", "version"},
-		"set-credential-sources":      {"id", "application-credential-source", "version"},
+		"add-credential-sources":      {"id", "application-credential-source", "egress-credential-source", "version"},
+		"remove-credential-sources":   {"id", "application-credential-source", "egress-credential-source", "version"},
+		"set-credential-sources":      {"id", "application-credential-source", "egress-credential-source", "version"},
+		"list-mine":                   {"filter", "page-size", "list-token"},
 	}
 }
 
@@ -108,6 +112,9 @@ func extraSynopsisFuncImpl(c *Command) string {
 	case "authorize-session":
 		return "Request session authorization against the target"
 
+	case "list-mine":
+		return "List the targets you can connect to"
+
 	default:
 		return ""
 	}
@@ -282,6 +289,10 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			`      $ boundary targets add-credential-sources -id ttcp_1234567890 -application-credential-source clvlt_1234567890 -application-credential-source clvlt_0987654321`,
 			"",
+			"    Add an egress credential source to an ssh-type target:",
+			"",
+			`      $ boundary targets add-credential-sources -id tssh_1234567890 -egress-credential-source clvlt_1234567890`,
+			"",
 			"",
 		})
 	case "remove-credential-sources":
@@ -322,6 +333,20 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			`      $ boundary targets authorize-session -scope-id o_1234567890 -name prod-ssh`,
 			"",
+			"    Retrieve the authorization of a session which required approval, once approved:",
+			"",
+			`      $ boundary targets authorize-session -id ttcp_1234567890 -session-id s_1234567890`,
+			"",
+			"",
+		})
+	case "list-mine":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets list-mine [options] [args]",
+			"",
+			"  List the targets, in every scope, against which you can request an authorized session. Unlike list, this command doesn't require permission to list targets. Example:",
+			"",
+			`    $ boundary targets list-mine`,
+			"",
 			"",
 		})
 	}
@@ -349,6 +374,12 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagHostId,
 				Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
 			})
+		case "session-id":
+			f.StringVar(&base.StringVar{
+				Name:   "session-id",
+				Target: &c.flagSessionId,
+				Usage:  "The ID of an approved session of the target, to retrieve its authorization instead of requesting a new session. The authorization of a session can only be retrieved once.",
+			})
 		case "application-credential-library":
 			f.StringSliceVar(&base.StringSliceVar{
 				Name:   "application-credential-library",
@@ -361,6 +392,12 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagApplicationCredentialSources,
 				Usage:  "The credential source for application purpose to add, set, or remove.  May be specified multiple times.",
 			})
+		case "egress-credential-source":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "egress-credential-source",
+				Target: &c.flagEgressCredentialSources,
+				Usage:  "The credential source for egress purpose to add, set, or remove. Only supported by ssh targets.  May be specified multiple times.",
+			})
 		}
 	}
 
@@ -468,12 +505,16 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]targets.Op
 		*opts = append(*opts, targets.WithApplicationCredentialLibraryIds(c.flagApplicationCredentialLibraries))
 
 	case "add-credential-sources", "remove-credential-sources":
-		// TODO: As we add other purposes, add them to this check
-		if len(c.flagApplicationCredentialSources) == 0 {
-			c.UI.Error("No credential sources supplied via -application-credential-source")
+		if len(c.flagApplicationCredentialSources) == 0 && len(c.flagEgressCredentialSources) == 0 {
+			c.UI.Error("No credential sources supplied via -application-credential-source or -egress-credential-source")
 			return false
 		}
-		*opts = append(*opts, targets.WithApplicationCredentialSourceIds(c.flagApplicationCredentialSources))
+		if len(c.flagApplicationCredentialSources) > 0 {
+			*opts = append(*opts, targets.WithApplicationCredentialSourceIds(c.flagApplicationCredentialSources))
+		}
+		if len(c.flagEgressCredentialSources) > 0 {
+			*opts = append(*opts, targets.WithEgressCredentialSourceIds(c.flagEgressCredentialSources))
+		}
 
 	case "set-credential-libraries":
 		// TODO: As we add other purposes, add them to this check
@@ -492,11 +533,12 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]targets.Op
 		}
 
 	case "set-credential-sources":
-		// TODO: As we add other purposes, add them to this check
+		if len(c.flagApplicationCredentialSources) == 0 && len(c.flagEgressCredentialSources) == 0 {
+			c.UI.Error("No credential sources supplied via -application-credential-source or -egress-credential-source")
+			return false
+		}
 		switch len(c.flagApplicationCredentialSources) {
 		case 0:
-			c.UI.Error("No credential sources supplied via -application-credential-source")
-			return false
 		case 1:
 			if c.flagApplicationCredentialSources[0] == "null" {
 				*opts = append(*opts, targets.DefaultApplicationCredentialSourceIds())
@@ -506,11 +548,25 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]targets.Op
 		default:
 			*opts = append(*opts, targets.WithApplicationCredentialSourceIds(c.flagApplicationCredentialSources))
 		}
+		switch len(c.flagEgressCredentialSources) {
+		case 0:
+		case 1:
+			if c.flagEgressCredentialSources[0] == "null" {
+				*opts = append(*opts, targets.DefaultEgressCredentialSourceIds())
+				break
+			}
+			fallthrough
+		default:
+			*opts = append(*opts, targets.WithEgressCredentialSourceIds(c.flagEgressCredentialSources))
+		}
 
 	case "authorize-session":
 		if len(c.flagHostId) != 0 {
 			*opts = append(*opts, targets.WithHostId(c.flagHostId))
 		}
+		if len(c.flagSessionId) != 0 {
+			*opts = append(*opts, targets.WithSessionId(c.flagSessionId))
+		}
 	}
 
 	return true
@@ -547,6 +603,11 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		c.plural = "a session against target"
 		c.sar, err = targetClient.AuthorizeSession(c.Context, c.FlagId, opts...)
 		return nil, err
+	case "list-mine":
+		var err error
+		c.plural = "targets"
+		c.listMineResult, err = targetClient.ListMine(c.Context, opts...)
+		return nil, err
 	}
 	return origResult, origError
 }
@@ -573,7 +634,7 @@ func (c *Command) printListTable(items []*targets.Target) string {
 				fmt.Sprintf("  ID:                    %s", "(not available)"),
 			)
 		}
-		if c.FlagRecursive && item.ScopeId != "" {
+		if (c.FlagRecursive || c.Func == "list-mine") && item.ScopeId != "" {
 			output = append(output,
 				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
 			)
@@ -709,6 +770,24 @@ func printItemTable(result api.GenericResult) string {
 		}
 	}
 
+	if len(item.EgressCredentialSources) > 0 {
+		if credentialSourceMaps == nil {
+			credentialSourceMaps = make(map[credential.Purpose][]map[string]interface{})
+		}
+		var egressCredentialSourceMaps []map[string]interface{}
+		for _, lib := range item.EgressCredentialSources {
+			m := map[string]interface{}{
+				"ID":                  lib.Id,
+				"Credential Store ID": lib.CredentialStoreId,
+			}
+			egressCredentialSourceMaps = append(egressCredentialSourceMaps, m)
+		}
+		credentialSourceMaps[credential.EgressPurpose] = egressCredentialSourceMaps
+		if l := len("Credential Store ID"); l > maxLength {
+			maxLength = l
+		}
+	}
+
 	ret := []string{
 		"",
 		"Target information:",
@@ -761,6 +840,18 @@ func printItemTable(result api.GenericResult) string {
 		}
 	}
 
+	if egressMap := credentialSourceMaps[credential.EgressPurpose]; len(egressMap) > 0 {
+		ret = append(ret,
+			"  Egress Credential Sources:",
+		)
+		for _, m := range egressMap {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, m),
+				"",
+			)
+		}
+	}
+
 	if len(item.Attributes) > 0 {
 		ret = append(ret,
 			"  Attributes:",
@@ -791,6 +882,12 @@ func printCustomActionOutputImpl(c *Command) (bool, error) {
 				"Type":                item.Type,
 				"Authorization Token": item.AuthorizationToken,
 			}
+			if item.Status != "" {
+				// The session must be approved before its authorization
+				// token can be retrieved.
+				delete(nonAttributeMap, "Authorization Token")
+				nonAttributeMap["Status"] = item.Status
+			}
 
 			maxLength := 0
 			for k := range nonAttributeMap {
@@ -864,6 +961,16 @@ func printCustomActionOutputImpl(c *Command) (bool, error) {
 				}
 			}
 
+			if item.Status != "" {
+				ret = append(ret,
+					"  The session must be approved by another user. Once approved, retrieve its",
+					"  authorization before its approval expires with:",
+					"",
+					fmt.Sprintf("    boundary targets authorize-session -id %s -session-id %s", item.TargetId, item.SessionId),
+					"",
+				)
+			}
+
 			c.UI.Output(base.WrapForHelpText(ret))
 			return true, nil
 
@@ -873,13 +980,31 @@ func printCustomActionOutputImpl(c *Command) (bool, error) {
 			}
 			return true, nil
 		}
+
+	case "list-mine":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(c.printListTable(c.listMineResult.Items))
+			if c.listMineResult.GetListToken() != "" {
+				c.UI.Output(fmt.Sprintf("\nMore items are available. Use -list-token %s to list the next page.", c.listMineResult.GetListToken()))
+			}
+		case "json":
+			if ok := c.PrintJsonItems(c.listMineResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
 	}
 
 	return false, nil
 }
 
 var keySubstMap = map[string]string{
-	"default_port": "Default Port",
+	"default_port":                    "Default Port",
+	"upload_rate_limit":               "Upload Rate Limit",
+	"download_rate_limit":             "Download Rate Limit",
+	"session_approval_required":       "Session Approval Required",
+	"session_approval_window_seconds": "Session Approval Window Seconds",
 }
 
 func exampleOutput() string {
@@ -18,8 +18,8 @@ func init() {
 
 func extraTcpActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter"},
-		"update": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter"},
+		"create": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "session-recording-enabled", "upload-rate-limit", "download-rate-limit", "session-approval-required", "session-approval-window-seconds"},
+		"update": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "session-recording-enabled", "upload-rate-limit", "download-rate-limit", "session-approval-required", "session-approval-window-seconds"},
 	}
 }
 
@@ -28,6 +28,11 @@ type extraTcpCmdVars struct {
 	flagSessionMaxSeconds      string
 	flagSessionConnectionLimit string
 	flagWorkerFilter           string
+	flagSessionRecording       string
+	flagUploadRateLimit        string
+	flagDownloadRateLimit      string
+	flagApprovalRequired       string
+	flagApprovalWindowSeconds  string
 }
 
 func (c *TcpCommand) extraTcpHelpFunc(helpMap map[string]func() string) string {
@@ -87,6 +92,36 @@ func extraTcpFlagsFuncImpl(c *TcpCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagWorkerFilter,
 				Usage:  "A boolean expression to filter which workers can handle sessions for this target.",
 			})
+		case "session-recording-enabled":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-recording-enabled",
+				Target: &c.flagSessionRecording,
+				Usage:  `Whether the connections of sessions for this target are recorded by the worker. Only workers with a recording storage path can handle sessions for the target when enabled. Can be "true", "false", or "null".`,
+			})
+		case "upload-rate-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "upload-rate-limit",
+				Target: &c.flagUploadRateLimit,
+				Usage:  `The max rate, in bytes per second, at which the worker sends data from the client to the endpoint of a connection. Use "null" to remove the limit.`,
+			})
+		case "download-rate-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "download-rate-limit",
+				Target: &c.flagDownloadRateLimit,
+				Usage:  `The max rate, in bytes per second, at which the worker sends data from the endpoint to the client of a connection. Use "null" to remove the limit.`,
+			})
+		case "session-approval-required":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-approval-required",
+				Target: &c.flagApprovalRequired,
+				Usage:  `Whether sessions for this target must be approved by a user other than the one who requested them before they can be used. Can be "true", "false", or "null".`,
+			})
+		case "session-approval-window-seconds":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-approval-window-seconds",
+				Target: &c.flagApprovalWindowSeconds,
+				Usage:  `The time in which a session must be approved and its authorization retrieved. Can be specified as an integer number of seconds or a duration string. Use "null" to allow until the session expires.`,
+			})
 		}
 	}
 }
@@ -150,5 +185,77 @@ func extraTcpFlagsHandlingFuncImpl(c *TcpCommand, _ *base.FlagSets, opts *[]targ
 		*opts = append(*opts, targets.WithWorkerFilter(c.flagWorkerFilter))
 	}
 
+	switch c.flagSessionRecording {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultTcpTargetSessionRecordingEnabled())
+	default:
+		enabled, err := strconv.ParseBool(c.flagSessionRecording)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionRecording, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithTcpTargetSessionRecordingEnabled(enabled))
+	}
+
+	switch c.flagUploadRateLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultTcpTargetUploadRateLimit())
+	default:
+		limit, err := strconv.ParseUint(c.flagUploadRateLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagUploadRateLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithTcpTargetUploadRateLimit(uint32(limit)))
+	}
+
+	switch c.flagDownloadRateLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultTcpTargetDownloadRateLimit())
+	default:
+		limit, err := strconv.ParseUint(c.flagDownloadRateLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDownloadRateLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithTcpTargetDownloadRateLimit(uint32(limit)))
+	}
+
+	switch c.flagApprovalRequired {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultTcpTargetSessionApprovalRequired())
+	default:
+		required, err := strconv.ParseBool(c.flagApprovalRequired)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagApprovalRequired, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithTcpTargetSessionApprovalRequired(required))
+	}
+
+	switch c.flagApprovalWindowSeconds {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultTcpTargetSessionApprovalWindowSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagApprovalWindowSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagApprovalWindowSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagApprovalWindowSeconds, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithTcpTargetSessionApprovalWindowSeconds(final))
+	}
+
 	return true
 }
@@ -0,0 +1,338 @@
+package workerscmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/workers"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+type extraCmdVars struct {
+	flagTags []string
+
+	// tags is flagTags parsed into a map of keys to values.
+	tags map[string][]string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"add-worker-tags":    {"id", "tag", "version"},
+		"set-worker-tags":    {"id", "tag", "version"},
+		"remove-worker-tags": {"id", "tag", "version"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "add-worker-tags", "set-worker-tags", "remove-worker-tags":
+		var in string
+		switch {
+		case strings.HasPrefix(c.Func, "add"):
+			in = "Add API tags to"
+		case strings.HasPrefix(c.Func, "set"):
+			in = "Set the full contents of the API tags on"
+		case strings.HasPrefix(c.Func, "remove"):
+			in = "Remove API tags from"
+		}
+		return wordwrap.WrapString(fmt.Sprintf("%s a worker", in), base.TermWidth)
+
+	default:
+		return ""
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary workers [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary worker resources. A worker created here authenticates to the controllers with its own credential instead of the shared worker-auth KMS. Example:",
+			"",
+			"    Create a worker and print its one-time activation token:",
+			"",
+			`      $ boundary workers create -name worker-1`,
+			"",
+			"  The name must match the name the worker is configured with. Set the activation token as the worker's activation_token; the worker presents it on its first contact with a controller and is issued its own credential. Deleting the worker revokes it.",
+			"",
+			"  Workers which authenticate with the shared worker-auth KMS are added here when they first report their status. The tags of a worker are the tags in its configuration merged with the API tags set here, and are what worker filters of targets match against.",
+			"",
+			"  Please see the workers subcommand help for detailed usage information.",
+		})
+
+	case "add-worker-tags":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary workers add-worker-tags [options] [args]",
+			"",
+			`  Adds API tags to a worker given its ID. The "tag" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary workers add-worker-tags -id w_1234567890 -tag region=us-east-1 -tag type=prod`,
+			"",
+			"",
+		})
+
+	case "set-worker-tags":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary workers set-worker-tags [options] [args]",
+			"",
+			`  Sets the complete set of API tags on a worker given its ID. The "tag" flag can be specified multiple times; specify "null" to remove all API tags. Tags in the configuration of the worker are not affected. Example:`,
+			"",
+			`    $ boundary workers set-worker-tags -id w_1234567890 -tag region=us-east-1`,
+			"",
+			"",
+		})
+
+	case "remove-worker-tags":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary workers remove-worker-tags [options] [args]",
+			"",
+			`  Removes API tags from a worker given its ID. The "tag" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary workers remove-worker-tags -id w_1234567890 -tag type=prod`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "tag":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "tag",
+				Target: &c.flagTags,
+				Usage:  `The API tags to add, remove, or set, in the form "key=value". May be specified multiple times.`,
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]workers.Option) bool {
+	switch c.Func {
+	case "add-worker-tags", "remove-worker-tags":
+		if len(c.flagTags) == 0 {
+			c.UI.Error("No tags supplied via -tag")
+			return false
+		}
+
+	case "set-worker-tags":
+		switch len(c.flagTags) {
+		case 0:
+			c.UI.Error("No tags supplied via -tag")
+			return false
+		case 1:
+			if c.flagTags[0] == "null" {
+				c.tags = map[string][]string{}
+				return true
+			}
+		}
+
+	default:
+		return true
+	}
+
+	c.tags = make(map[string][]string, len(c.flagTags))
+	for _, t := range c.flagTags {
+		kv := strings.SplitN(t, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			c.UI.Error(fmt.Sprintf("Tag %q is not in the form \"key=value\"", t))
+			return false
+		}
+		c.tags[kv[0]] = append(c.tags[kv[0]], kv[1])
+	}
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, workerClient *workers.Client, version uint32, opts []workers.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "add-worker-tags":
+		return workerClient.AddWorkerTags(c.Context, c.FlagId, version, c.tags, opts...)
+	case "set-worker-tags":
+		return workerClient.SetWorkerTags(c.Context, c.FlagId, version, c.tags, opts...)
+	case "remove-worker-tags":
+		return workerClient.RemoveWorkerTags(c.Context, c.FlagId, version, c.tags, opts...)
+	}
+	return origResult, origError
+}
+
+func (c *Command) printListTable(items []*workers.Worker) string {
+	if len(items) == 0 {
+		return "No workers found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Worker information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if !item.ActivationTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Activation Time:     %s", item.ActivationTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if item.Address != "" {
+			output = append(output,
+				fmt.Sprintf("    Address:             %s", item.Address),
+			)
+		}
+		if !item.LastStatusTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Last Status Time:    %s", item.LastStatusTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(result api.GenericResult) string {
+	item := result.GetItem().(*workers.Worker)
+	nonAttributeMap := map[string]interface{}{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.ActivationToken != "" {
+		nonAttributeMap["Activation Token"] = item.ActivationToken
+	}
+	if !item.ActivationTokenExpirationTime.IsZero() {
+		nonAttributeMap["Activation Token Expiration Time"] = item.ActivationTokenExpirationTime.Local().Format(time.RFC1123)
+	}
+	if !item.ActivationTime.IsZero() {
+		nonAttributeMap["Activation Time"] = item.ActivationTime.Local().Format(time.RFC1123)
+	}
+	if item.Address != "" {
+		nonAttributeMap["Address"] = item.Address
+	}
+	if !item.LastStatusTime.IsZero() {
+		nonAttributeMap["Last Status Time"] = item.LastStatusTime.Local().Format(time.RFC1123)
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Worker information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	for _, tags := range []struct {
+		title string
+		tags  map[string][]string
+	}{
+		{"Configuration Tags", item.ConfigTags},
+		{"API Tags", item.ApiTags},
+		{"Canonical Tags", item.CanonicalTags},
+	} {
+		if len(tags.tags) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(tags.tags))
+		for k := range tags.tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		ret = append(ret, "", fmt.Sprintf("  %s:", tags.title))
+		for _, key := range keys {
+			ret = append(ret,
+				fmt.Sprintf("    %s:", key),
+				base.WrapSlice(6, tags.tags[key]),
+			)
+		}
+	}
+
+	if item.RecordingPublicKey != "" {
+		ret = append(ret, "", "  Recording Public Key:")
+		for _, line := range strings.Split(strings.TrimSpace(item.RecordingPublicKey), "\n") {
+			ret = append(ret, "    "+line)
+		}
+	}
+
+	if item.ActivationToken != "" {
+		ret = append(ret,
+			"",
+			"  The activation token is only shown once. Set it as the activation_token of the worker before it expires.",
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -80,7 +80,7 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 			})
 		}
 	}
-	if command == "list" {
+	if command == "list" || command == "list-mine" {
 		for _, name := range flagNames[command] {
 			switch name {
 			case "filter":
@@ -89,6 +89,18 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 					Target: &c.FlagFilter,
 					Usage:  "If set, the list operation will be filtered before being returned. The filter operates against each item in the list. Using single quotes is recommended as filters contain double quotes. See https://www.boundaryproject.io/docs/concepts/filtering/resource-listing for details.",
 				})
+			case "page-size":
+				f.UintVar(&base.UintVar{
+					Name:   "page-size",
+					Target: &c.FlagPageSize,
+					Usage:  "If set, the list operation will return at most this many items, along with a list token which can be used to fetch the next page.",
+				})
+			case "list-token":
+				f.StringVar(&base.StringVar{
+					Name:   "list-token",
+					Target: &c.FlagListToken,
+					Usage:  "The list token returned by a previous paginated list operation. If set, the list operation will return the next page of items.",
+				})
 			}
 		}
 	}
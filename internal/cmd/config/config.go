@@ -152,6 +152,27 @@ type Worker struct {
 	TagsRaw interface{}         `hcl:"tags"`
 	Tags    map[string][]string `hcl:"-"`
 
+	// RecordingStoragePath is the directory the connections of sessions with
+	// recording enabled are recorded to. A worker without it refuses to proxy
+	// sessions which require recording.
+	RecordingStoragePath string `hcl:"recording_storage_path"`
+
+	// RecordingKeyPath is the file the worker stores the key it signs its
+	// recordings with in. It defaults to a file in AuthStoragePath and must
+	// not be within RecordingStoragePath.
+	RecordingKeyPath string `hcl:"recording_key_path"`
+
+	// ActivationToken is the one-time token created for the worker through
+	// the controller API. The worker presents it on its first contact with a
+	// controller to be issued its own credential, which is stored in
+	// AuthStoragePath. It is not needed once the worker has registered.
+	ActivationToken string `hcl:"activation_token"`
+
+	// AuthStoragePath is the directory the worker stores its credential in.
+	// It is required unless the worker authenticates with the shared
+	// worker-auth KMS.
+	AuthStoragePath string `hcl:"auth_storage_path"`
+
 	// StatusGracePeriod represents the period of time (as a duration) that the
 	// worker will wait before disconnecting connections if it cannot make a
 	// status report to a controller.
@@ -324,6 +345,10 @@ func Parse(d string) (*Config, error) {
 		if !strutil.Printable(result.Worker.Name) {
 			return nil, errors.New("Worker name contains non-printable characters")
 		}
+		result.Worker.ActivationToken, err = parseutil.ParsePath(result.Worker.ActivationToken)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			return nil, fmt.Errorf("Error parsing worker activation token: %w", err)
+		}
 		if result.Worker.TagsRaw != nil {
 			switch t := result.Worker.TagsRaw.(type) {
 			// HCL allows multiple labeled blocks with the same name, turning it
@@ -461,6 +486,10 @@ func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 				s.Type = event.StderrSink
 			case s.FileConfig != nil:
 				s.Type = event.FileSink
+			case s.HttpConfig != nil:
+				s.Type = event.HttpSink
+			case s.SyslogConfig != nil:
+				s.Type = event.SyslogSink
 			default:
 				return nil, fmt.Errorf("sink type could not be determined")
 			}
@@ -482,6 +511,22 @@ func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 			}
 		}
 
+		// parse the duration strings specified in a http config into a time.Duration
+		if s.HttpConfig != nil && s.HttpConfig.FlushIntervalHCL != "" {
+			var err error
+			s.HttpConfig.FlushInterval, err = parseutil.ParseDurationSecond(s.HttpConfig.FlushIntervalHCL)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse flush interval %s", s.HttpConfig.FlushIntervalHCL)
+			}
+		}
+		if s.HttpConfig != nil && s.HttpConfig.TimeoutHCL != "" {
+			var err error
+			s.HttpConfig.Timeout, err = parseutil.ParseDurationSecond(s.HttpConfig.TimeoutHCL)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse timeout %s", s.HttpConfig.TimeoutHCL)
+			}
+		}
+
 		if err := s.Validate(); err != nil {
 			return nil, err
 		}
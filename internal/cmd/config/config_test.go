@@ -218,6 +218,48 @@ func TestDevWorker(t *testing.T) {
 	assert.Equal(t, exp, actual)
 	exp.Worker.Tags = prevTags
 
+	// Set the recording storage path
+	devWorkerKeyValueConfig = `
+	listener "tcp" {
+		purpose = "proxy"
+	}
+
+	worker {
+		name = "dev-worker"
+		description = "A default worker created in dev mode"
+		controllers = ["127.0.0.1"]
+		tags = ["type=dev", "type=local"]
+		recording_storage_path = "/var/lib/boundary/recordings"
+		recording_key_path = "/etc/boundary/recording_key"
+	}
+	`
+
+	actual, err = Parse(devConfig + devWorkerKeyValueConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "/var/lib/boundary/recordings", actual.Worker.RecordingStoragePath)
+	assert.Equal(t, "/etc/boundary/recording_key", actual.Worker.RecordingKeyPath)
+
+	// Set the activation token and auth storage path
+	os.Setenv("BOUNDARY_WORKER_ACTIVATION_TOKEN", "w_1234567890_secret")
+	defer os.Unsetenv("BOUNDARY_WORKER_ACTIVATION_TOKEN")
+	devWorkerKeyValueConfig = `
+	listener "tcp" {
+		purpose = "proxy"
+	}
+
+	worker {
+		name = "dev-worker"
+		controllers = ["127.0.0.1"]
+		activation_token = "env://BOUNDARY_WORKER_ACTIVATION_TOKEN"
+		auth_storage_path = "/var/lib/boundary/worker-auth"
+	}
+	`
+
+	actual, err = Parse(devConfig + devWorkerKeyValueConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "w_1234567890_secret", actual.Worker.ActivationToken)
+	assert.Equal(t, "/var/lib/boundary/worker-auth", actual.Worker.AuthStoragePath)
+
 	// Redo it with non-lower-cased keys
 	devWorkerKeyValueConfig = `
 	listener "tcp" {
@@ -492,6 +534,138 @@ func TestController_EventingConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "http-sink-configured",
+			config: []string{
+				`events {
+				audit_enabled = true
+				audit_delivery = "enforced"
+				sink "http" {
+					format = "cloudevents-json"
+					name = "siem-sink"
+					event_types = [ "audit" ]
+					http {
+						url = "https://siem.example.com/events"
+						headers = {
+							Authorization = "Bearer token"
+						}
+						batch_size = 50
+						flush_interval = "10s"
+						timeout = "30s"
+						tls_skip_verify = true
+					}
+				}
+			}`,
+				`{
+					"events": {
+						"audit_enabled": true,
+						"audit_delivery": "enforced",
+						"sink": [
+							{
+								"format": "cloudevents-json",
+								"name": "siem-sink",
+								"event_types": ["audit"],
+								"http": {
+									"url": "https://siem.example.com/events",
+									"headers": {
+										"Authorization": "Bearer token"
+									},
+									"batch_size": 50,
+									"flush_interval": "10s",
+									"timeout": "30s",
+									"tls_skip_verify": true
+								}
+							}
+						]
+					}
+				}`,
+			},
+			wantEventerConfig: &event.EventerConfig{
+				AuditEnabled:  true,
+				AuditDelivery: event.Enforced,
+				Sinks: []*event.SinkConfig{
+					{
+						Type:       "http",
+						Name:       "siem-sink",
+						Format:     "cloudevents-json",
+						EventTypes: []event.Type{"audit"},
+						HttpConfig: &event.HttpSinkTypeConfig{
+							Url:              "https://siem.example.com/events",
+							Headers:          map[string]string{"Authorization": "Bearer token"},
+							BatchSize:        50,
+							FlushIntervalHCL: "10s",
+							FlushInterval:    10 * time.Second,
+							TimeoutHCL:       "30s",
+							Timeout:          30 * time.Second,
+							TlsSkipVerify:    true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "syslog-sink-configured",
+			config: []string{
+				`events {
+				sink "syslog" {
+					format = "hclog-text"
+					name = "syslog-sink"
+					event_types = [ "*" ]
+					syslog {
+						network = "udp"
+						address = "127.0.0.1:514"
+						facility = "local3"
+						app_name = "boundary-controller"
+					}
+				}
+			}`,
+				`events {
+				sink {
+					format = "hclog-text"
+					name = "syslog-sink"
+					event_types = [ "*" ]
+					syslog {
+						network = "udp"
+						address = "127.0.0.1:514"
+						facility = "local3"
+						app_name = "boundary-controller"
+					}
+				}
+			}`,
+			},
+			wantEventerConfig: &event.EventerConfig{
+				Sinks: []*event.SinkConfig{
+					{
+						Type:       "syslog",
+						Name:       "syslog-sink",
+						Format:     "hclog-text",
+						EventTypes: []event.Type{"*"},
+						SyslogConfig: &event.SyslogSinkTypeConfig{
+							Network:  "udp",
+							Address:  "127.0.0.1:514",
+							Facility: "local3",
+							AppName:  "boundary-controller",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "http-sink-missing-url",
+			config: []string{
+				`events {
+				sink "http" {
+					format = "cloudevents-json"
+					name = "siem-sink"
+					event_types = [ "audit" ]
+					http {
+						batch_size = 50
+					}
+				}
+			}`,
+			},
+			wantErr: `error parsing "events": event.(SinkConfig).Validate: event.(HttpSinkTypeConfig).Validate: missing url: invalid parameter`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
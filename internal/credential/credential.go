@@ -4,6 +4,7 @@ package credential
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/boundary"
 )
@@ -23,6 +24,7 @@ type Store interface {
 type Library interface {
 	boundary.Resource
 	GetStoreId() string
+	CredentialType() Type
 }
 
 // Purpose is the purpose of the credential.
@@ -45,6 +47,37 @@ const (
 	EgressPurpose Purpose = "egress"
 )
 
+// Type is the type of the credentials provided by a library.
+type Type string
+
+// Credential type values.
+const (
+	// UnspecifiedType is the type of credentials of a library which does
+	// not declare a type. The secret is returned as is.
+	UnspecifiedType Type = "unspecified"
+
+	// UsernamePasswordType is the type of UserPassword credentials.
+	UsernamePasswordType Type = "username_password"
+
+	// SshPrivateKeyType is the type of KeyPair credentials.
+	SshPrivateKeyType Type = "ssh_private_key"
+
+	// CertificateType is the type of Certificate credentials.
+	CertificateType Type = "certificate"
+
+	// SshCertificateType is the type of SshCertificate credentials.
+	SshCertificateType Type = "ssh_certificate"
+)
+
+// ValidType reports whether t is a known credential type.
+func ValidType(t Type) bool {
+	switch t {
+	case UnspecifiedType, UsernamePasswordType, SshPrivateKeyType, CertificateType, SshCertificateType:
+		return true
+	}
+	return false
+}
+
 // SecretData represents secret data.
 type SecretData interface{}
 
@@ -62,6 +95,13 @@ type Dynamic interface {
 	Purpose() Purpose
 }
 
+// Static is a credential held by a credential store. The same secret is
+// returned for every session the credential is used in.
+type Static interface {
+	Credential
+	GetStoreId() string
+}
+
 // A Request represents a request for a credential from the SourceId for
 // the given purpose. For dynamic credentials, the SourceId is the PublicId
 // of a credential library.
@@ -70,16 +110,38 @@ type Request struct {
 	Purpose  Purpose
 }
 
+// SessionData contains the data about a session a library can use to
+// issue credentials scoped to the session.
+type SessionData struct {
+	UserId   string
+	UserName string
+	// AccountId, LoginName, FullName and Email are from the user's account
+	// in the primary auth method of the user's scope. The LoginName of an
+	// OIDC account is its subject.
+	AccountId   string
+	LoginName   string
+	FullName    string
+	Email       string
+	TargetId    string
+	TargetName  string
+	HostId      string
+	HostAddress string
+	// Ttl is the time remaining until the session expires.
+	Ttl time.Duration
+}
+
 // Issuer issues dynamic credentials.
 type Issuer interface {
 	// Issue issues dynamic credentials for a session from the requested
 	// libraries and for the requested purposes. The sessionId must be a
 	// valid sessionId. The SourceId in each request must be the public id
-	// of a library the Issuer can issue credentials from.
+	// of a library the Issuer can issue credentials from. The
+	// WithSessionData option provides the data about the session to
+	// libraries which need it.
 	//
 	// If Issue encounters an error, it returns no credentials and revokes
 	// any credentials issued before encountering the error.
-	Issue(ctx context.Context, sessionId string, requests []Request) ([]Dynamic, error)
+	Issue(ctx context.Context, sessionId string, requests []Request, opt ...Option) ([]Dynamic, error)
 }
 
 // Revoker revokes dynamic credentials.
@@ -115,3 +177,14 @@ type Certificate interface {
 	Certificate() []byte
 	Private() PrivateKey
 }
+
+// SshCertificate is a credential containing a username, a private key and
+// an SSH certificate for the public key of the private key. An
+// SshCertificate is also a KeyPair and a Certificate, so type switches
+// must check for it first.
+type SshCertificate interface {
+	Credential
+	Username() string
+	Private() PrivateKey
+	Certificate() []byte
+}
@@ -0,0 +1,31 @@
+package credential
+
+// GetOpts - iterate the inbound Options and return a struct.
+func GetOpts(opt ...Option) Options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*Options)
+
+// Options - how Options are represented.
+type Options struct {
+	// WithSessionData must be accessible from other packages.
+	WithSessionData *SessionData
+}
+
+func getDefaultOptions() Options {
+	return Options{}
+}
+
+// WithSessionData provides the data about the session credentials are
+// issued for.
+func WithSessionData(d *SessionData) Option {
+	return func(o *Options) {
+		o.WithSessionData = d
+	}
+}
@@ -22,6 +22,13 @@ func (s Password) MarshalJSON() ([]byte, error) {
 	return json.Marshal(redactedPassword)
 }
 
+// MarshalText returns the password redacted. It keeps the password out of
+// encoders which use encoding.TextMarshaler, such as the keys of maps
+// encoded as JSON.
+func (s Password) MarshalText() ([]byte, error) {
+	return []byte(redactedPassword), nil
+}
+
 // String returns a string with the private key redacted.
 func (s PrivateKey) String() string {
 	return redactedPrivateKey
@@ -37,3 +44,9 @@ func (s PrivateKey) GoString() string {
 func (s PrivateKey) MarshalJSON() ([]byte, error) {
 	return json.Marshal([]byte(redactedPrivateKey))
 }
+
+// MarshalText returns the private key redacted. It keeps the private key
+// out of encoders which use encoding.TextMarshaler.
+func (s PrivateKey) MarshalText() ([]byte, error) {
+	return []byte(redactedPrivateKey), nil
+}
@@ -70,6 +70,30 @@ func TestPassword_MarshalJSON(t *testing.T) {
 	})
 }
 
+func TestPassword_MarshalText(t *testing.T) {
+	t.Parallel()
+	t.Run("redacted", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		passwd := Password("normal secret")
+		got, err := passwd.MarshalText()
+		require.NoError(err)
+		assert.Equalf([]byte(redactedPassword), got, "Password.MarshalText() = %s, want %s", got, redactedPassword)
+	})
+	t.Run("within-secret-map", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		const testB = "my secret"
+		secret := map[string]interface{}{"username": "user", "password": Password(testB)}
+		m, err := json.Marshal(secret)
+		require.NoError(err)
+		assert.NotContains(string(m), testB)
+
+		keyed := map[Password]string{Password(testB): "value"}
+		m, err = json.Marshal(keyed)
+		require.NoError(err)
+		assert.NotContains(string(m), testB)
+	})
+}
+
 func TestPrivateKey_String(t *testing.T) {
 	t.Parallel()
 	t.Run("redacted", func(t *testing.T) {
@@ -130,3 +154,23 @@ func TestPrivateKey_MarshalJSON(t *testing.T) {
 		assert.Equal(testB, sec.B)
 	})
 }
+
+func TestPrivateKey_MarshalText(t *testing.T) {
+	t.Parallel()
+	t.Run("redacted", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pk := PrivateKey("normal secret")
+		got, err := pk.MarshalText()
+		require.NoError(err)
+		assert.Equalf([]byte(redactedPrivateKey), got, "PrivateKey.MarshalText() = %s, want %s", got, redactedPrivateKey)
+	})
+	t.Run("within-secret-map", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		testB := []byte("my secret")
+		secret := map[string]interface{}{"username": "user", "private_key": PrivateKey(testB)}
+		m, err := json.Marshal(secret)
+		require.NoError(err)
+		assert.NotContains(string(m), string(testB))
+		assert.NotContains(fmt.Sprintf("%v", secret), string(testB))
+	})
+}
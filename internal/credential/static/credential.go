@@ -0,0 +1,48 @@
+package static
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+
+	"github.com/hashicorp/boundary/internal/boundary"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// A Credential is a static credential owned by a credential store. The
+// secret of a Credential returned from the repository is always encrypted;
+// only its hmac can be read.
+type Credential interface {
+	boundary.Resource
+	GetStoreId() string
+	CredentialType() credential.Type
+}
+
+var (
+	_ Credential = (*UsernamePasswordCredential)(nil)
+	_ Credential = (*SshPrivateKeyCredential)(nil)
+)
+
+// hmacSecret returns an hmac of secret computed with a key derived from
+// cipher and storeId.
+func hmacSecret(ctx context.Context, cipher wrapping.Wrapper, storeId string, secret []byte) ([]byte, error) {
+	const op = "static.hmacSecret"
+	if cipher == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	reader, err := kms.NewDerivedReader(cipher, 32, []byte(storeId), nil)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	key, _, err := ed25519.GenerateKey(reader)
+	if err != nil {
+		return nil, errors.New(ctx, errors.Encrypt, op, "unable to generate derived key")
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(secret)
+	return mac.Sum(nil), nil
+}
@@ -0,0 +1,19 @@
+// Package static provides a credential store which holds static credentials
+// for Boundary sessions.
+//
+// A static credential store is owned by a scope and contains username and
+// password credentials and SSH private key credentials. The secret part of
+// each credential, the password or the private key, is encrypted with the
+// database key of the scope of its credential store before it is stored. Only
+// an HMAC of the secret is ever returned from the repository to be shown to
+// users. Deleting a credential store deletes all of the credentials it
+// contains.
+//
+// Repository
+//
+// A repository provides methods for creating, updating, retrieving, and
+// deleting credential stores and credentials. The decrypted secrets of
+// credentials are only returned by Retrieve, which is used to hand the
+// credentials of a target to a session. A new repository should be created
+// for each transaction.
+package static
@@ -0,0 +1,17 @@
+package static
+
+// These constants are the field names used in the static related field masks.
+const (
+	nameField        = "Name"
+	descriptionField = "Description"
+
+	usernameField   = "Username"
+	passwordField   = "Password"
+	privateKeyField = "PrivateKey"
+
+	ctPasswordField     = "CtPassword"
+	passwordHmacField   = "PasswordHmac"
+	ctPrivateKeyField   = "CtPrivateKey"
+	privateKeyHmacField = "PrivateKeyHmac"
+	keyIdField          = "KeyId"
+)
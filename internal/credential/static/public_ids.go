@@ -0,0 +1,62 @@
+package static
+
+import (
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/subtypes"
+)
+
+func init() {
+	if err := credential.Register(Subtype, CredentialStorePrefix, UsernamePasswordCredentialPrefix, SshPrivateKeyCredentialPrefix); err != nil {
+		panic(err)
+	}
+}
+
+// PublicId prefixes for the resources in the static package.
+const (
+	CredentialStorePrefix            = "csst"
+	UsernamePasswordCredentialPrefix = "credup"
+	SshPrivateKeyCredentialPrefix    = "credspk"
+
+	Subtype = subtypes.Subtype("static")
+)
+
+// CredentialTypeFromId returns the type of the static credential with the
+// id, based on the prefix of the id, or credential.UnspecifiedType if the id
+// is not the id of a static credential.
+func CredentialTypeFromId(id string) credential.Type {
+	switch {
+	case strings.HasPrefix(id, UsernamePasswordCredentialPrefix+"_"):
+		return credential.UsernamePasswordType
+	case strings.HasPrefix(id, SshPrivateKeyCredentialPrefix+"_"):
+		return credential.SshPrivateKeyType
+	}
+	return credential.UnspecifiedType
+}
+
+func newCredentialStoreId() (string, error) {
+	id, err := db.NewPublicId(CredentialStorePrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "static.newCredentialStoreId")
+	}
+	return id, nil
+}
+
+func newUsernamePasswordCredentialId() (string, error) {
+	id, err := db.NewPublicId(UsernamePasswordCredentialPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "static.newUsernamePasswordCredentialId")
+	}
+	return id, nil
+}
+
+func newSshPrivateKeyCredentialId() (string, error) {
+	id, err := db.NewPublicId(SshPrivateKeyCredentialPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "static.newSshPrivateKeyCredentialId")
+	}
+	return id, nil
+}
@@ -0,0 +1,19 @@
+package static
+
+const (
+	rewrapUsernamePasswordCredentialQuery = `
+update credential_static_username_password_credential
+   set password      = @password,
+       password_hmac = @password_hmac,
+       key_id        = @key_id
+ where public_id = @public_id;
+`
+
+	rewrapSshPrivateKeyCredentialQuery = `
+update credential_static_ssh_private_key_credential
+   set private_key      = @private_key,
+       private_key_hmac = @private_key_hmac,
+       key_id           = @key_id
+ where public_id = @public_id;
+`
+)
@@ -0,0 +1,611 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateUsernamePasswordCredential inserts c into the repository and
+// returns a new UsernamePasswordCredential containing the credential's
+// PublicId. c is not changed. c must contain a valid StoreId, a username,
+// and a password. c must not contain a PublicId. The PublicId is generated
+// and assigned by this method.
+//
+// The password is encrypted with the database key of scopeId, which must
+// be the scope of the credential store. The returned credential only
+// contains the hmac of the password.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.StoreId. Both c.CreateTime and c.UpdateTime are ignored.
+func (r *Repository) CreateUsernamePasswordCredential(ctx context.Context, scopeId string, c *UsernamePasswordCredential, _ ...Option) (*UsernamePasswordCredential, error) {
+	const op = "static.(Repository).CreateUsernamePasswordCredential"
+	if c == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil UsernamePasswordCredential")
+	}
+	if c.UsernamePasswordCredential == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded UsernamePasswordCredential")
+	}
+	if c.StoreId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no store id")
+	}
+	if c.Username == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no username")
+	}
+	if len(c.password) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no password")
+	}
+	if c.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	c = c.clone()
+
+	id, err := newUsernamePasswordCredentialId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.PublicId = id
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := c.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.password = ""
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var newCredential *UsernamePasswordCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newCredential = c.clone()
+			err := w.Create(ctx, newCredential, db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_CREATE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in credential store: %s: name %s already exists", c.StoreId, c.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in credential store: %s", c.StoreId)))
+	}
+	return newCredential, nil
+}
+
+// CreateSshPrivateKeyCredential inserts c into the repository and returns
+// a new SshPrivateKeyCredential containing the credential's PublicId. c is
+// not changed. c must contain a valid StoreId, a username, and a private
+// key. c must not contain a PublicId. The PublicId is generated and
+// assigned by this method.
+//
+// The private key is encrypted with the database key of scopeId, which
+// must be the scope of the credential store. The returned credential only
+// contains the hmac of the private key.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.StoreId. Both c.CreateTime and c.UpdateTime are ignored.
+func (r *Repository) CreateSshPrivateKeyCredential(ctx context.Context, scopeId string, c *SshPrivateKeyCredential, _ ...Option) (*SshPrivateKeyCredential, error) {
+	const op = "static.(Repository).CreateSshPrivateKeyCredential"
+	if c == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil SshPrivateKeyCredential")
+	}
+	if c.SshPrivateKeyCredential == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded SshPrivateKeyCredential")
+	}
+	if c.StoreId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no store id")
+	}
+	if c.Username == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no username")
+	}
+	if len(c.privateKey) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no private key")
+	}
+	if c.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	c = c.clone()
+
+	id, err := newSshPrivateKeyCredentialId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.PublicId = id
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := c.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.privateKey = nil
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var newCredential *SshPrivateKeyCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newCredential = c.clone()
+			err := w.Create(ctx, newCredential, db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_CREATE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in credential store: %s: name %s already exists", c.StoreId, c.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in credential store: %s", c.StoreId)))
+	}
+	return newCredential, nil
+}
+
+// UpdateUsernamePasswordCredential updates the repository entry for
+// c.PublicId with the values in c for the fields listed in fieldMaskPaths.
+// It returns a new UsernamePasswordCredential containing the updated
+// values and a count of the number of records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only Name, Description, Username, and
+// Password can be updated. If c.Name is set to a non-empty string, it must
+// be unique within the credential store. Username and Password cannot be
+// set to NULL. An updated password is encrypted with the current database
+// key of scopeId.
+//
+// An attribute of c will be set to NULL in the database if the attribute
+// in c is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateUsernamePasswordCredential(ctx context.Context, scopeId string, c *UsernamePasswordCredential, version uint32, fieldMaskPaths []string, _ ...Option) (*UsernamePasswordCredential, int, error) {
+	const op = "static.(Repository).UpdateUsernamePasswordCredential"
+	if c == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing UsernamePasswordCredential")
+	}
+	if c.UsernamePasswordCredential == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded UsernamePasswordCredential")
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if scopeId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	c = c.clone()
+
+	var updatePassword bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(nameField, f):
+		case strings.EqualFold(descriptionField, f):
+		case strings.EqualFold(usernameField, f):
+		case strings.EqualFold(passwordField, f):
+			updatePassword = true
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			nameField:        c.Name,
+			descriptionField: c.Description,
+			usernameField:    c.Username,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	for _, f := range nullFields {
+		if strings.EqualFold(usernameField, f) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "username cannot be removed")
+		}
+	}
+	if updatePassword {
+		if len(c.password) == 0 {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "password cannot be removed")
+		}
+		storeId, err := r.lookupStoreId(ctx, c)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+		c.StoreId = storeId
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := c.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+		dbMask = append(dbMask, ctPasswordField, passwordHmacField, keyIdField)
+	}
+	c.password = ""
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedCredential *UsernamePasswordCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedCredential = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedCredential, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op,
+				fmt.Sprintf("name %s already exists: %s", c.Name, c.PublicId))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(c.PublicId))
+	}
+
+	return returnedCredential, rowsUpdated, nil
+}
+
+// UpdateSshPrivateKeyCredential updates the repository entry for
+// c.PublicId with the values in c for the fields listed in fieldMaskPaths.
+// It returns a new SshPrivateKeyCredential containing the updated values
+// and a count of the number of records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only Name, Description, Username, and
+// PrivateKey can be updated. If c.Name is set to a non-empty string, it
+// must be unique within the credential store. Username and PrivateKey
+// cannot be set to NULL. An updated private key is encrypted with the
+// current database key of scopeId.
+//
+// An attribute of c will be set to NULL in the database if the attribute
+// in c is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateSshPrivateKeyCredential(ctx context.Context, scopeId string, c *SshPrivateKeyCredential, version uint32, fieldMaskPaths []string, _ ...Option) (*SshPrivateKeyCredential, int, error) {
+	const op = "static.(Repository).UpdateSshPrivateKeyCredential"
+	if c == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing SshPrivateKeyCredential")
+	}
+	if c.SshPrivateKeyCredential == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded SshPrivateKeyCredential")
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if scopeId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	c = c.clone()
+
+	var updatePrivateKey bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(nameField, f):
+		case strings.EqualFold(descriptionField, f):
+		case strings.EqualFold(usernameField, f):
+		case strings.EqualFold(privateKeyField, f):
+			updatePrivateKey = true
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			nameField:        c.Name,
+			descriptionField: c.Description,
+			usernameField:    c.Username,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	for _, f := range nullFields {
+		if strings.EqualFold(usernameField, f) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "username cannot be removed")
+		}
+	}
+	if updatePrivateKey {
+		if len(c.privateKey) == 0 {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "private key cannot be removed")
+		}
+		storeId, err := r.lookupStoreId(ctx, c)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+		c.StoreId = storeId
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := c.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+		dbMask = append(dbMask, ctPrivateKeyField, privateKeyHmacField, keyIdField)
+	}
+	c.privateKey = nil
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedCredential *SshPrivateKeyCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedCredential = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedCredential, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op,
+				fmt.Sprintf("name %s already exists: %s", c.Name, c.PublicId))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(c.PublicId))
+	}
+
+	return returnedCredential, rowsUpdated, nil
+}
+
+// lookupStoreId returns the id of the credential store of the stored
+// credential with the public id of c. The hmac of an updated secret is
+// keyed by the id of the credential store.
+func (r *Repository) lookupStoreId(ctx context.Context, c Credential) (string, error) {
+	const op = "static.(Repository).lookupStoreId"
+	current, err := r.LookupCredential(ctx, c.GetPublicId())
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	if current == nil {
+		return "", errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("credential %s not found", c.GetPublicId()))
+	}
+	return current.GetStoreId(), nil
+}
+
+// allocCredential returns an empty credential of the type of the static
+// credential with publicId or nil if publicId is not the id of a static
+// credential.
+func allocCredential(publicId string) Credential {
+	switch CredentialTypeFromId(publicId) {
+	case credential.UsernamePasswordType:
+		c := allocUsernamePasswordCredential()
+		c.PublicId = publicId
+		return c
+	case credential.SshPrivateKeyType:
+		c := allocSshPrivateKeyCredential()
+		c.PublicId = publicId
+		return c
+	}
+	return nil
+}
+
+// LookupCredential returns the Credential for publicId. Returns nil, nil
+// if no Credential is found for publicId. The secret of the returned
+// Credential is not decrypted.
+func (r *Repository) LookupCredential(ctx context.Context, publicId string, _ ...Option) (Credential, error) {
+	const op = "static.(Repository).LookupCredential"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	c := allocCredential(publicId)
+	if c == nil {
+		return nil, nil
+	}
+	if err := r.reader.LookupByPublicId(ctx, c); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
+	}
+	return c, nil
+}
+
+// DeleteCredential deletes publicId from the repository and returns the
+// number of records deleted.
+func (r *Repository) DeleteCredential(ctx context.Context, scopeId string, publicId string, _ ...Option) (int, error) {
+	const op = "static.(Repository).DeleteCredential"
+	if publicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+
+	var c interface {
+		Credential
+		oplog(oplog.OpType) oplog.Metadata
+	}
+	switch CredentialTypeFromId(publicId) {
+	case credential.UsernamePasswordType:
+		up := allocUsernamePasswordCredential()
+		up.PublicId = publicId
+		c = up
+	case credential.SshPrivateKeyType:
+		spk := allocSshPrivateKeyCredential()
+		spk.PublicId = publicId
+		c = spk
+	default:
+		return db.NoRowsAffected, nil
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			rowsDeleted, err = w.Delete(ctx, c, db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 Credential would have been deleted")
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", publicId)))
+	}
+
+	return rowsDeleted, nil
+}
+
+// ListCredentials returns a slice of the Credentials for the storeId
+// ordered by public id. The secrets of the returned Credentials are not
+// decrypted. WithLimit and WithStartPageAfterItem are the only options
+// supported.
+func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ...Option) ([]Credential, error) {
+	const op = "static.(Repository).ListCredentials"
+	if storeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no storeId")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
+
+	var ups []*UsernamePasswordCredential
+	if err := r.reader.SearchWhere(ctx, &ups, "store_id = ?", []interface{}{storeId}, dbOpts...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var spks []*SshPrivateKeyCredential
+	if err := r.reader.SearchWhere(ctx, &spks, "store_id = ?", []interface{}{storeId}, dbOpts...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	creds := make([]Credential, 0, len(ups)+len(spks))
+	for _, c := range ups {
+		creds = append(creds, c)
+	}
+	for _, c := range spks {
+		creds = append(creds, c)
+	}
+	// Each table is limited separately so the merged list is sorted and
+	// limited again to return the same page a single table would.
+	sort.Slice(creds, func(i, j int) bool {
+		return creds[i].GetPublicId() < creds[j].GetPublicId()
+	})
+	if limit > 0 && len(creds) > limit {
+		creds = creds[:limit]
+	}
+	return creds, nil
+}
+
+// Retrieve returns the static credentials for ids with their secrets
+// decrypted. An error is returned if any of the ids is not the id of a
+// static credential.
+func (r *Repository) Retrieve(ctx context.Context, ids []string, _ ...Option) ([]credential.Static, error) {
+	const op = "static.(Repository).Retrieve"
+	if len(ids) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no ids")
+	}
+
+	storeScopes := make(map[string]string)
+	scopeOf := func(storeId string) (string, error) {
+		if scopeId, ok := storeScopes[storeId]; ok {
+			return scopeId, nil
+		}
+		cs, err := r.LookupCredentialStore(ctx, storeId)
+		if err != nil {
+			return "", err
+		}
+		if cs == nil {
+			return "", errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("credential store %s not found", storeId))
+		}
+		storeScopes[storeId] = cs.ScopeId
+		return cs.ScopeId, nil
+	}
+
+	var out []credential.Static
+	for _, id := range ids {
+		c, err := r.LookupCredential(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if c == nil {
+			return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("credential %s not found", id))
+		}
+		scopeId, err := scopeOf(c.GetStoreId())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		switch c := c.(type) {
+		case *UsernamePasswordCredential:
+			databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(c.KeyId))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+			}
+			if err := c.decrypt(ctx, databaseWrapper); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			out = append(out, &usernamePasswordCredential{UsernamePasswordCredential: c})
+		case *SshPrivateKeyCredential:
+			databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(c.KeyId))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+			}
+			if err := c.decrypt(ctx, databaseWrapper); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			out = append(out, &sshPrivateKeyCredential{SshPrivateKeyCredential: c})
+		}
+	}
+	return out, nil
+}
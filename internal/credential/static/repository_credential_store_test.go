@@ -0,0 +1,265 @@
+package static
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestRepository_CreateCredentialStore(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	tests := []struct {
+		name    string
+		in      *CredentialStore
+		wantErr errors.Code
+	}{
+		{
+			name:    "nil-CredentialStore",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "nil-embedded-CredentialStore",
+			in:      &CredentialStore{},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-no-scope-id",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-public-id-set",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					ScopeId:  prj.GetPublicId(),
+					PublicId: "abcd_OOOOOOOOOO",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid-no-options",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					ScopeId: prj.GetPublicId(),
+				},
+			},
+		},
+		{
+			name: "valid-with-name-and-description",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					ScopeId:     prj.GetPublicId(),
+					Name:        "test-name-repo",
+					Description: "test-description-repo",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			require.NotNil(repo)
+			got, err := repo.CreateCredentialStore(ctx, tt.in)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.True(strings.HasPrefix(got.GetPublicId(), CredentialStorePrefix+"_"))
+			assert.NotSame(tt.in, got)
+			assert.Equal(tt.in.Name, got.Name)
+			assert.Equal(tt.in.Description, got.Description)
+			assert.Equal(got.CreateTime, got.UpdateTime)
+
+			found, err := repo.LookupCredentialStore(ctx, got.GetPublicId())
+			require.NoError(err)
+			assert.Empty(cmp.Diff(got, found, protocmp.Transform()))
+		})
+	}
+
+	t.Run("duplicate-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+		kms := kms.TestKms(t, conn, wrapper)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		in, err := NewCredentialStore(prj.GetPublicId(), WithName("duplicate"))
+		require.NoError(err)
+		_, err = repo.CreateCredentialStore(ctx, in)
+		require.NoError(err)
+		got, err := repo.CreateCredentialStore(ctx, in)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err code: %v got err: %v", errors.NotUnique, err)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_UpdateCredentialStore(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	tests := []struct {
+		name      string
+		orig      []Option
+		chgName   string
+		chgDesc   string
+		masks     []string
+		wantName  string
+		wantDesc  string
+		wantCount int
+		wantErr   errors.Code
+	}{
+		{
+			name:      "change-name",
+			orig:      []Option{WithName("name"), WithDescription("desc")},
+			chgName:   "new-name",
+			masks:     []string{nameField},
+			wantName:  "new-name",
+			wantDesc:  "desc",
+			wantCount: 1,
+		},
+		{
+			name:      "delete-description",
+			orig:      []Option{WithName("name"), WithDescription("desc")},
+			masks:     []string{descriptionField},
+			wantName:  "name",
+			wantCount: 1,
+		},
+		{
+			name:    "invalid-field-mask",
+			orig:    []Option{WithName("name")},
+			masks:   []string{"ScopeId"},
+			wantErr: errors.InvalidFieldMask,
+		},
+		{
+			name:    "empty-field-mask",
+			orig:    []Option{WithName("name")},
+			wantErr: errors.EmptyFieldMask,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+
+			opts := append([]Option{}, tt.orig...)
+			cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId(), opts...)
+
+			in := cs.clone()
+			in.Name = tt.chgName
+			in.Description = tt.chgDesc
+			got, gotCount, err := repo.UpdateCredentialStore(ctx, in, cs.Version, tt.masks)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantCount, gotCount)
+			assert.Equal(tt.wantName, got.Name)
+			assert.Equal(tt.wantDesc, got.Description)
+		})
+	}
+}
+
+func TestRepository_DeleteCredentialStore(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	c := TestUsernamePasswordCredential(t, conn, wrapper, prj.GetPublicId(), cs.GetPublicId(), "user", "pass")
+
+	ctx := context.Background()
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	_, err = repo.DeleteCredentialStore(ctx, "")
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+	got, err := repo.DeleteCredentialStore(ctx, cs.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+
+	found, err := repo.LookupCredential(ctx, c.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, found, "credentials should be deleted with their credential store")
+
+	got, err = repo.DeleteCredentialStore(ctx, cs.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 0, got)
+}
+
+func TestRepository_ListCredentialStores(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	const count = 5
+	css := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), count)
+
+	ctx := context.Background()
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	_, err = repo.ListCredentialStores(ctx, nil)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+	got, err := repo.ListCredentialStores(ctx, []string{prj.GetPublicId()})
+	require.NoError(t, err)
+	opts := []cmp.Option{
+		cmpopts.SortSlices(func(x, y *CredentialStore) bool { return x.PublicId < y.PublicId }),
+		protocmp.Transform(),
+	}
+	assert.Empty(t, cmp.Diff(css, got, opts...))
+
+	got, err = repo.ListCredentialStores(ctx, []string{prj.GetPublicId()}, WithLimit(2))
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	rest, err := repo.ListCredentialStores(ctx, []string{prj.GetPublicId()}, WithStartPageAfterItem(got[1].GetPublicId()))
+	require.NoError(t, err)
+	assert.Len(t, rest, count-2)
+}
@@ -0,0 +1,315 @@
+package static
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateUsernamePasswordCredential(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+
+	tests := []struct {
+		name     string
+		username string
+		password credential.Password
+		storeId  string
+		opts     []Option
+		wantErr  errors.Code
+	}{
+		{
+			name:     "valid",
+			username: "user",
+			password: "pass",
+			storeId:  cs.GetPublicId(),
+			opts:     []Option{WithName("name"), WithDescription("desc")},
+		},
+		{
+			name:     "no-store-id",
+			username: "user",
+			password: "pass",
+			wantErr:  errors.InvalidParameter,
+		},
+		{
+			name:     "no-username",
+			password: "pass",
+			storeId:  cs.GetPublicId(),
+			wantErr:  errors.InvalidParameter,
+		},
+		{
+			name:     "no-password",
+			username: "user",
+			storeId:  cs.GetPublicId(),
+			wantErr:  errors.InvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+
+			in, err := NewUsernamePasswordCredential(tt.storeId, tt.username, tt.password, tt.opts...)
+			require.NoError(err)
+			got, err := repo.CreateUsernamePasswordCredential(ctx, prj.GetPublicId(), in)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.True(strings.HasPrefix(got.GetPublicId(), UsernamePasswordCredentialPrefix+"_"))
+			assert.Equal(tt.username, got.Username)
+			assert.Empty(got.password)
+			assert.Empty(got.Password)
+			assert.NotEmpty(got.CtPassword)
+			assert.NotEmpty(got.PasswordHmac)
+			assert.NotEmpty(got.KeyId)
+			assert.Equal(tt.password, in.password, "input should not be changed")
+
+			creds, err := repo.Retrieve(ctx, []string{got.GetPublicId()})
+			require.NoError(err)
+			require.Len(creds, 1)
+			up, ok := creds[0].(credential.UserPassword)
+			require.True(ok)
+			assert.Equal(tt.username, up.Username())
+			assert.Equal(tt.password, up.Password())
+		})
+	}
+}
+
+func TestRepository_CreateSshPrivateKeyCredential(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+
+	ctx := context.Background()
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	in, err := NewSshPrivateKeyCredential(cs.GetPublicId(), "user", nil)
+	require.NoError(t, err)
+	_, err = repo.CreateSshPrivateKeyCredential(ctx, prj.GetPublicId(), in)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+	in, err = NewSshPrivateKeyCredential(cs.GetPublicId(), "user", credential.PrivateKey("private key"))
+	require.NoError(t, err)
+	got, err := repo.CreateSshPrivateKeyCredential(ctx, prj.GetPublicId(), in)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(got.GetPublicId(), SshPrivateKeyCredentialPrefix+"_"))
+	assert.Empty(t, got.privateKey)
+	assert.Empty(t, got.PrivateKey)
+	assert.NotEmpty(t, got.CtPrivateKey)
+	assert.NotEmpty(t, got.PrivateKeyHmac)
+
+	creds, err := repo.Retrieve(ctx, []string{got.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, creds, 1)
+	kp, ok := creds[0].(credential.KeyPair)
+	require.True(t, ok)
+	assert.Equal(t, "user", kp.Username())
+	assert.Equal(t, credential.PrivateKey("private key"), kp.Private())
+}
+
+func TestRepository_UpdateUsernamePasswordCredential(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+
+	tests := []struct {
+		name         string
+		chgUsername  string
+		chgPassword  credential.Password
+		masks        []string
+		wantUsername string
+		wantPassword credential.Password
+		wantErr      errors.Code
+	}{
+		{
+			name:         "change-username",
+			chgUsername:  "new-user",
+			masks:        []string{usernameField},
+			wantUsername: "new-user",
+			wantPassword: "pass",
+		},
+		{
+			name:         "change-password",
+			chgPassword:  "new-pass",
+			masks:        []string{passwordField},
+			wantUsername: "user",
+			wantPassword: "new-pass",
+		},
+		{
+			name:    "delete-username",
+			masks:   []string{usernameField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "delete-password",
+			masks:   []string{passwordField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-field-mask",
+			masks:   []string{"StoreId"},
+			wantErr: errors.InvalidFieldMask,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+
+			orig := TestUsernamePasswordCredential(t, conn, wrapper, prj.GetPublicId(), cs.GetPublicId(), "user", "pass")
+
+			in, err := NewUsernamePasswordCredential("", tt.chgUsername, tt.chgPassword)
+			require.NoError(err)
+			in.PublicId = orig.GetPublicId()
+			got, gotCount, err := repo.UpdateUsernamePasswordCredential(ctx, prj.GetPublicId(), in, orig.Version, tt.masks)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, gotCount)
+			assert.Empty(got.password)
+
+			creds, err := repo.Retrieve(ctx, []string{orig.GetPublicId()})
+			require.NoError(err)
+			require.Len(creds, 1)
+			up := creds[0].(credential.UserPassword)
+			assert.Equal(tt.wantUsername, up.Username())
+			assert.Equal(tt.wantPassword, up.Password())
+		})
+	}
+}
+
+func TestRepository_DeleteCredential(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	up := TestUsernamePasswordCredential(t, conn, wrapper, prj.GetPublicId(), cs.GetPublicId(), "user", "pass")
+	spk := TestSshPrivateKeyCredential(t, conn, wrapper, prj.GetPublicId(), cs.GetPublicId(), "user", "key")
+
+	ctx := context.Background()
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	for _, id := range []string{up.GetPublicId(), spk.GetPublicId()} {
+		got, err := repo.DeleteCredential(ctx, prj.GetPublicId(), id)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got)
+		found, err := repo.LookupCredential(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	}
+
+	_, err = repo.DeleteCredential(ctx, prj.GetPublicId(), "")
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+}
+
+func TestRepository_ListCredentials(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	const count = 3
+	TestUsernamePasswordCredentials(t, conn, wrapper, prj.GetPublicId(), cs.GetPublicId(), count)
+	for i := 0; i < count; i++ {
+		TestSshPrivateKeyCredential(t, conn, wrapper, prj.GetPublicId(), cs.GetPublicId(), "user", "key")
+	}
+
+	ctx := context.Background()
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	got, err := repo.ListCredentials(ctx, cs.GetPublicId())
+	require.NoError(t, err)
+	assert.Len(t, got, 2*count)
+	for i := 1; i < len(got); i++ {
+		assert.Less(t, got[i-1].GetPublicId(), got[i].GetPublicId())
+	}
+
+	page, err := repo.ListCredentials(ctx, cs.GetPublicId(), WithLimit(4))
+	require.NoError(t, err)
+	assert.Equal(t, got[:4], page)
+
+	page, err = repo.ListCredentials(ctx, cs.GetPublicId(), WithStartPageAfterItem(got[3].GetPublicId()))
+	require.NoError(t, err)
+	assert.Equal(t, got[4:], page)
+}
+
+func TestRepository_Retrieve(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	up := TestUsernamePasswordCredential(t, conn, wrapper, prj.GetPublicId(), cs.GetPublicId(), "user", "pass")
+
+	ctx := context.Background()
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	_, err = repo.Retrieve(ctx, nil)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+	badId, err := newUsernamePasswordCredentialId()
+	require.NoError(t, err)
+	_, err = repo.Retrieve(ctx, []string{up.GetPublicId(), badId})
+	assert.Truef(t, errors.Match(errors.T(errors.RecordNotFound), err), "want err: %q got: %q", errors.RecordNotFound, err)
+
+	got, err := repo.Retrieve(ctx, []string{up.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, cs.GetPublicId(), got[0].GetStoreId())
+	assert.Equal(t, map[string]interface{}{
+		"username": "user",
+		"password": credential.Password("pass"),
+	}, got[0].Secret())
+}
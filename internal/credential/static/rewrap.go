@@ -0,0 +1,105 @@
+package static
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	if err := kms.RegisterTableRewrapFn("credential_static_username_password_credential", rewrapUsernamePasswordCredentials); err != nil {
+		panic(err)
+	}
+	if err := kms.RegisterTableRewrapFn("credential_static_ssh_private_key_credential", rewrapSshPrivateKeyCredentials); err != nil {
+		panic(err)
+	}
+}
+
+// rewrapWrappers returns the database wrapper for the key version
+// dataKeyVersionId and the current database wrapper of scopeId.
+func rewrapWrappers(ctx context.Context, dataKeyVersionId, scopeId string, kmsCache *kms.Kms) (wrapping.Wrapper, wrapping.Wrapper, error) {
+	const op = "static.rewrapWrappers"
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for key version"))
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	return oldWrapper, wrapper, nil
+}
+
+// rewrapUsernamePasswordCredentials encrypts the passwords encrypted by the
+// database key version dataKeyVersionId with the current database key
+// version of scopeId and recomputes their hmacs.
+func rewrapUsernamePasswordCredentials(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "static.rewrapUsernamePasswordCredentials"
+	var creds []*UsernamePasswordCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(creds) == 0 {
+		return nil
+	}
+	oldWrapper, wrapper, err := rewrapWrappers(ctx, dataKeyVersionId, scopeId, kmsCache)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, c := range creds {
+		if err := c.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapUsernamePasswordCredentialQuery, []interface{}{
+			sql.Named("password", c.CtPassword),
+			sql.Named("password_hmac", c.PasswordHmac),
+			sql.Named("key_id", c.KeyId),
+			sql.Named("public_id", c.PublicId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update username password credential"))
+		}
+	}
+	return nil
+}
+
+// rewrapSshPrivateKeyCredentials encrypts the private keys encrypted by the
+// database key version dataKeyVersionId with the current database key
+// version of scopeId and recomputes their hmacs.
+func rewrapSshPrivateKeyCredentials(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "static.rewrapSshPrivateKeyCredentials"
+	var creds []*SshPrivateKeyCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(creds) == 0 {
+		return nil
+	}
+	oldWrapper, wrapper, err := rewrapWrappers(ctx, dataKeyVersionId, scopeId, kmsCache)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, c := range creds {
+		if err := c.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapSshPrivateKeyCredentialQuery, []interface{}{
+			sql.Named("private_key", c.CtPrivateKey),
+			sql.Named("private_key_hmac", c.PrivateKeyHmac),
+			sql.Named("key_id", c.KeyId),
+			sql.Named("public_id", c.PublicId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update ssh private key credential"))
+		}
+	}
+	return nil
+}
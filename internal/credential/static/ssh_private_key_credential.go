@@ -0,0 +1,154 @@
+package static
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// A SshPrivateKeyCredential contains a username and an SSH private key. It is
+// owned by a credential store.
+type SshPrivateKeyCredential struct {
+	*store.SshPrivateKeyCredential
+	tableName string `gorm:"-"`
+
+	// privateKey is the plaintext private key. It is only set on
+	// credentials passed to the repository to be stored and on credentials
+	// returned by Retrieve.
+	privateKey credential.PrivateKey `gorm:"-"`
+}
+
+// NewSshPrivateKeyCredential creates a new in memory
+// SshPrivateKeyCredential with username and privateKey assigned to
+// storeId. Name and description are the only valid options. All other
+// options are ignored.
+func NewSshPrivateKeyCredential(storeId string, username string, privateKey credential.PrivateKey, opt ...Option) (*SshPrivateKeyCredential, error) {
+	opts := getOpts(opt...)
+	c := &SshPrivateKeyCredential{
+		SshPrivateKeyCredential: &store.SshPrivateKeyCredential{
+			StoreId:     storeId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			Username:    username,
+		},
+		privateKey: privateKey,
+	}
+	return c, nil
+}
+
+func allocSshPrivateKeyCredential() *SshPrivateKeyCredential {
+	return &SshPrivateKeyCredential{
+		SshPrivateKeyCredential: &store.SshPrivateKeyCredential{},
+	}
+}
+
+func (c *SshPrivateKeyCredential) clone() *SshPrivateKeyCredential {
+	cp := proto.Clone(c.SshPrivateKeyCredential)
+	return &SshPrivateKeyCredential{
+		SshPrivateKeyCredential: cp.(*store.SshPrivateKeyCredential),
+		privateKey:              copyPrivateKey(c.privateKey),
+	}
+}
+
+// TableName returns the table name.
+func (c *SshPrivateKeyCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "credential_static_ssh_private_key_credential"
+}
+
+// SetTableName sets the table name.
+func (c *SshPrivateKeyCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+// CredentialType returns the type of the credential.
+func (c *SshPrivateKeyCredential) CredentialType() credential.Type {
+	return credential.SshPrivateKeyType
+}
+
+// encrypt encrypts the plaintext private key of c with cipher and computes
+// the hmac of the private key. The plaintext is not kept in the embedded
+// store.SshPrivateKeyCredential.
+func (c *SshPrivateKeyCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(SshPrivateKeyCredential).encrypt"
+	if len(c.privateKey) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "no private key defined")
+	}
+	c.PrivateKey = copyPrivateKey(c.privateKey)
+	defer func() { c.PrivateKey = nil }()
+	if err := structwrapping.WrapStruct(ctx, cipher, c.SshPrivateKeyCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	c.KeyId = cipher.KeyID()
+	hm, err := hmacSecret(ctx, cipher, c.StoreId, c.PrivateKey)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	c.PrivateKeyHmac = hm
+	return nil
+}
+
+// decrypt decrypts the private key of c with cipher.
+func (c *SshPrivateKeyCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(SshPrivateKeyCredential).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c.SshPrivateKeyCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	c.privateKey = credential.PrivateKey(c.PrivateKey)
+	c.PrivateKey = nil
+	return nil
+}
+
+func (c *SshPrivateKeyCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.PublicId},
+		"resource-type":      []string{"credential-static-ssh-private-key-credential"},
+		"op-type":            []string{op.String()},
+	}
+	if c.StoreId != "" {
+		metadata["store-id"] = []string{c.StoreId}
+	}
+	return metadata
+}
+
+var _ credential.KeyPair = (*sshPrivateKeyCredential)(nil)
+
+// sshPrivateKeyCredential is a SshPrivateKeyCredential with a
+// decrypted private key. It is returned by Retrieve.
+type sshPrivateKeyCredential struct {
+	*SshPrivateKeyCredential
+}
+
+func (c *sshPrivateKeyCredential) Username() string {
+	return c.SshPrivateKeyCredential.Username
+}
+
+func (c *sshPrivateKeyCredential) Private() credential.PrivateKey {
+	return c.privateKey
+}
+
+// Secret returns the username and private key of c. The private key is a
+// credential.PrivateKey so it is redacted when c is logged or marshaled.
+func (c *sshPrivateKeyCredential) Secret() credential.SecretData {
+	return map[string]interface{}{
+		"username":    c.Username(),
+		"private_key": c.Private(),
+	}
+}
+
+func copyPrivateKey(k credential.PrivateKey) credential.PrivateKey {
+	if k == nil {
+		return nil
+	}
+	cp := make(credential.PrivateKey, len(k))
+	copy(cp, k)
+	return cp
+}
@@ -0,0 +1,112 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCredentialStore creates a static credential store in the provided DB
+// with the provided scope id and any values passed in through the Options
+// vargs. If any errors are encountered during the creation of the store,
+// the test will fail.
+func TestCredentialStore(t *testing.T, conn *db.DB, wrapper wrapping.Wrapper, scopeId string, opts ...Option) *CredentialStore {
+	t.Helper()
+	ctx := context.Background()
+	w := db.New(conn)
+
+	cs, err := NewCredentialStore(scopeId, opts...)
+	require.NoError(t, err)
+	id, err := newCredentialStoreId()
+	require.NoError(t, err)
+	cs.PublicId = id
+
+	require.NoError(t, w.Create(ctx, cs))
+	return cs
+}
+
+// TestCredentialStores creates count number of static credential stores in
+// the provided DB with the provided scope id. If any errors are
+// encountered during the creation of the credential stores, the test will
+// fail.
+func TestCredentialStores(t *testing.T, conn *db.DB, wrapper wrapping.Wrapper, scopeId string, count int) []*CredentialStore {
+	t.Helper()
+	var css []*CredentialStore
+	for i := 0; i < count; i++ {
+		css = append(css, TestCredentialStore(t, conn, wrapper, scopeId))
+	}
+	return css
+}
+
+// TestUsernamePasswordCredential creates a username and password
+// credential in the provided DB with the provided scope id, store id,
+// username, and password and any values passed in through the Options
+// vargs. The password is encrypted with the database key of scopeId. If
+// any errors are encountered during the creation of the credential, the
+// test will fail.
+func TestUsernamePasswordCredential(t *testing.T, conn *db.DB, wrapper wrapping.Wrapper, scopeId, storeId, username, password string, opts ...Option) *UsernamePasswordCredential {
+	t.Helper()
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	w := db.New(conn)
+
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	c, err := NewUsernamePasswordCredential(storeId, username, credential.Password(password), opts...)
+	require.NoError(t, err)
+	id, err := newUsernamePasswordCredentialId()
+	require.NoError(t, err)
+	c.PublicId = id
+	require.NoError(t, c.encrypt(ctx, databaseWrapper))
+	c.password = ""
+
+	require.NoError(t, w.Create(ctx, c))
+	return c
+}
+
+// TestSshPrivateKeyCredential creates an SSH private key credential in the
+// provided DB with the provided scope id, store id, username, and private
+// key and any values passed in through the Options vargs. The private key
+// is encrypted with the database key of scopeId. If any errors are
+// encountered during the creation of the credential, the test will fail.
+func TestSshPrivateKeyCredential(t *testing.T, conn *db.DB, wrapper wrapping.Wrapper, scopeId, storeId, username, privateKey string, opts ...Option) *SshPrivateKeyCredential {
+	t.Helper()
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	w := db.New(conn)
+
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	c, err := NewSshPrivateKeyCredential(storeId, username, credential.PrivateKey(privateKey), opts...)
+	require.NoError(t, err)
+	id, err := newSshPrivateKeyCredentialId()
+	require.NoError(t, err)
+	c.PublicId = id
+	require.NoError(t, c.encrypt(ctx, databaseWrapper))
+	c.privateKey = nil
+
+	require.NoError(t, w.Create(ctx, c))
+	return c
+}
+
+// TestUsernamePasswordCredentials creates count number of username and
+// password credentials in the provided DB with the provided scope id and
+// store id. If any errors are encountered during the creation of the
+// credentials, the test will fail.
+func TestUsernamePasswordCredentials(t *testing.T, conn *db.DB, wrapper wrapping.Wrapper, scopeId, storeId string, count int) []*UsernamePasswordCredential {
+	t.Helper()
+	var creds []*UsernamePasswordCredential
+	for i := 0; i < count; i++ {
+		creds = append(creds, TestUsernamePasswordCredential(t, conn, wrapper, scopeId, storeId,
+			fmt.Sprintf("user%d", i), fmt.Sprintf("password%d", i)))
+	}
+	return creds
+}
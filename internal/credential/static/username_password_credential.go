@@ -0,0 +1,145 @@
+package static
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// A UsernamePasswordCredential contains a username and a password. It is
+// owned by a credential store.
+type UsernamePasswordCredential struct {
+	*store.UsernamePasswordCredential
+	tableName string `gorm:"-"`
+
+	// password is the plaintext password. It is only set on credentials
+	// passed to the repository to be stored and on credentials returned by
+	// Retrieve.
+	password credential.Password `gorm:"-"`
+}
+
+// NewUsernamePasswordCredential creates a new in memory
+// UsernamePasswordCredential with username and password assigned to
+// storeId. Name and description are the only valid options. All other
+// options are ignored.
+func NewUsernamePasswordCredential(storeId string, username string, password credential.Password, opt ...Option) (*UsernamePasswordCredential, error) {
+	opts := getOpts(opt...)
+	c := &UsernamePasswordCredential{
+		UsernamePasswordCredential: &store.UsernamePasswordCredential{
+			StoreId:     storeId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			Username:    username,
+		},
+		password: password,
+	}
+	return c, nil
+}
+
+func allocUsernamePasswordCredential() *UsernamePasswordCredential {
+	return &UsernamePasswordCredential{
+		UsernamePasswordCredential: &store.UsernamePasswordCredential{},
+	}
+}
+
+func (c *UsernamePasswordCredential) clone() *UsernamePasswordCredential {
+	cp := proto.Clone(c.UsernamePasswordCredential)
+	return &UsernamePasswordCredential{
+		UsernamePasswordCredential: cp.(*store.UsernamePasswordCredential),
+		password:                   c.password,
+	}
+}
+
+// TableName returns the table name.
+func (c *UsernamePasswordCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "credential_static_username_password_credential"
+}
+
+// SetTableName sets the table name.
+func (c *UsernamePasswordCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+// CredentialType returns the type of the credential.
+func (c *UsernamePasswordCredential) CredentialType() credential.Type {
+	return credential.UsernamePasswordType
+}
+
+// encrypt encrypts the plaintext password of c with cipher and computes
+// the hmac of the password. The plaintext is not kept in the embedded
+// store.UsernamePasswordCredential.
+func (c *UsernamePasswordCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(UsernamePasswordCredential).encrypt"
+	if len(c.password) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "no password defined")
+	}
+	c.Password = []byte(c.password)
+	defer func() { c.Password = nil }()
+	if err := structwrapping.WrapStruct(ctx, cipher, c.UsernamePasswordCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	c.KeyId = cipher.KeyID()
+	hm, err := hmacSecret(ctx, cipher, c.StoreId, c.Password)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	c.PasswordHmac = hm
+	return nil
+}
+
+// decrypt decrypts the password of c with cipher.
+func (c *UsernamePasswordCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(UsernamePasswordCredential).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c.UsernamePasswordCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	c.password = credential.Password(c.Password)
+	c.Password = nil
+	return nil
+}
+
+func (c *UsernamePasswordCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.PublicId},
+		"resource-type":      []string{"credential-static-username-password-credential"},
+		"op-type":            []string{op.String()},
+	}
+	if c.StoreId != "" {
+		metadata["store-id"] = []string{c.StoreId}
+	}
+	return metadata
+}
+
+var _ credential.UserPassword = (*usernamePasswordCredential)(nil)
+
+// usernamePasswordCredential is a UsernamePasswordCredential with a
+// decrypted password. It is returned by Retrieve.
+type usernamePasswordCredential struct {
+	*UsernamePasswordCredential
+}
+
+func (c *usernamePasswordCredential) Username() string {
+	return c.UsernamePasswordCredential.Username
+}
+
+func (c *usernamePasswordCredential) Password() credential.Password {
+	return c.password
+}
+
+// Secret returns the username and password of c. The password is a
+// credential.Password so it is redacted when c is logged or marshaled.
+func (c *usernamePasswordCredential) Secret() credential.SecretData {
+	return map[string]interface{}{
+		"username": c.Username(),
+		"password": c.Password(),
+	}
+}
@@ -25,7 +25,8 @@ type CredentialLibrary struct {
 
 // NewCredentialLibrary creates a new in memory CredentialLibrary
 // for a Vault backend at vaultPath assigned to storeId.
-// Name, description, method, and request body are the only valid options.
+// Name, description, method, request body, credential type, and mapping
+// override are the only valid options.
 // All other options are ignored.
 func NewCredentialLibrary(storeId string, vaultPath string, opt ...Option) (*CredentialLibrary, error) {
 	const op = "vault.NewCredentialLibrary"
@@ -39,12 +40,42 @@ func NewCredentialLibrary(storeId string, vaultPath string, opt ...Option) (*Cre
 			VaultPath:       vaultPath,
 			HttpRequestBody: opts.withRequestBody,
 			HttpMethod:      string(opts.withMethod),
+			CredentialType:  string(opts.withCredentialType),
 		},
 	}
+	if m := opts.withMappingOverride; m != nil {
+		l.UsernameAttribute = m.UsernameAttribute
+		l.PasswordAttribute = m.PasswordAttribute
+		l.PrivateKeyAttribute = m.PrivateKeyAttribute
+		l.CertificateAttribute = m.CertificateAttribute
+	}
 
 	return l, nil
 }
 
+// CredentialType returns the type of the credentials the library provides.
+func (l *CredentialLibrary) CredentialType() credential.Type {
+	if l.GetCredentialType() == "" {
+		return credential.UnspecifiedType
+	}
+	return credential.Type(l.GetCredentialType())
+}
+
+// MappingOverride returns the MappingOverride of the library or nil if the
+// library uses the default field names.
+func (l *CredentialLibrary) MappingOverride() *MappingOverride {
+	m := &MappingOverride{
+		UsernameAttribute:    l.GetUsernameAttribute(),
+		PasswordAttribute:    l.GetPasswordAttribute(),
+		PrivateKeyAttribute:  l.GetPrivateKeyAttribute(),
+		CertificateAttribute: l.GetCertificateAttribute(),
+	}
+	if *m == (MappingOverride{}) {
+		return nil
+	}
+	return m
+}
+
 func allocCredentialLibrary() *CredentialLibrary {
 	return &CredentialLibrary{
 		CredentialLibrary: &store.CredentialLibrary{},
@@ -9,6 +9,11 @@ const (
 	httpMethodField      = "HttpMethod"
 	httpRequestBodyField = "HttpRequestBody"
 
+	usernameAttributeField    = "UsernameAttribute"
+	passwordAttributeField    = "PasswordAttribute"
+	privateKeyAttributeField  = "PrivateKeyAttribute"
+	certificateAttributeField = "CertificateAttribute"
+
 	certificateField    = "Certificate"
 	certificateKeyField = "CertificateKey"
 	vaultAddressField   = "VaultAddress"
@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential"
+)
+
+// The default names of the fields in a Vault secret which hold the parts of
+// a typed credential.
+const (
+	defaultUsernameAttribute    = "username"
+	defaultPasswordAttribute    = "password"
+	defaultPrivateKeyAttribute  = "private_key"
+	defaultCertificateAttribute = "certificate"
+)
+
+// A MappingOverride overrides the names of the fields in a Vault secret
+// which hold the parts of a typed credential. An empty field name means
+// the default name is used.
+type MappingOverride struct {
+	UsernameAttribute    string
+	PasswordAttribute    string
+	PrivateKeyAttribute  string
+	CertificateAttribute string
+}
+
+// validFor returns an error if m overrides a field which credentials of
+// type t do not have.
+func (m *MappingOverride) validFor(t credential.Type) error {
+	if m == nil {
+		return nil
+	}
+	var hasUsername, hasPassword, hasPrivateKey, hasCertificate bool
+	switch t {
+	case credential.UsernamePasswordType:
+		hasUsername, hasPassword = true, true
+	case credential.SshPrivateKeyType:
+		hasUsername, hasPrivateKey = true, true
+	case credential.CertificateType:
+		hasCertificate, hasPrivateKey = true, true
+	}
+	switch {
+	case m.UsernameAttribute != "" && !hasUsername:
+		return fmt.Errorf("username attribute cannot be set for credential type %q", t)
+	case m.PasswordAttribute != "" && !hasPassword:
+		return fmt.Errorf("password attribute cannot be set for credential type %q", t)
+	case m.PrivateKeyAttribute != "" && !hasPrivateKey:
+		return fmt.Errorf("private key attribute cannot be set for credential type %q", t)
+	case m.CertificateAttribute != "" && !hasCertificate:
+		return fmt.Errorf("certificate attribute cannot be set for credential type %q", t)
+	}
+	return nil
+}
+
+var (
+	_ credential.UserPassword = (*usernamePasswordCredential)(nil)
+	_ credential.KeyPair      = (*sshPrivateKeyCredential)(nil)
+	_ credential.Certificate  = (*certificateCredential)(nil)
+)
+
+type usernamePasswordCredential struct {
+	*actualCredential
+	username string
+	password credential.Password
+}
+
+func (c *usernamePasswordCredential) Username() string              { return c.username }
+func (c *usernamePasswordCredential) Password() credential.Password { return c.password }
+
+type sshPrivateKeyCredential struct {
+	*actualCredential
+	username   string
+	privateKey credential.PrivateKey
+}
+
+func (c *sshPrivateKeyCredential) Username() string               { return c.username }
+func (c *sshPrivateKeyCredential) Private() credential.PrivateKey { return c.privateKey }
+
+type certificateCredential struct {
+	*actualCredential
+	certificate []byte
+	privateKey  credential.PrivateKey
+}
+
+func (c *certificateCredential) Certificate() []byte            { return c.certificate }
+func (c *certificateCredential) Private() credential.PrivateKey { return c.privateKey }
+
+// typed returns ac as a credential of the type of its library, with the
+// parts of the credential taken from the fields of the secret named by the
+// library. The secret of a KV version 2 secrets engine is unwrapped from
+// its data field. An SSH certificate credential is made up of the signed
+// key in the secret and the private key generated by Boundary. If the
+// library does not declare a credential type or the secret does not
+// contain the fields, ac is returned unchanged.
+func (ac *actualCredential) typed() credential.Dynamic {
+	data := ac.secretData
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	field := func(override, def string) string {
+		name := def
+		if override != "" {
+			name = override
+		}
+		v, _ := data[name].(string)
+		return v
+	}
+
+	lib := ac.lib
+	switch lib.CredentialType() {
+	case credential.UsernamePasswordType:
+		username := field(lib.UsernameAttribute, defaultUsernameAttribute)
+		password := field(lib.PasswordAttribute, defaultPasswordAttribute)
+		if username != "" && password != "" {
+			return &usernamePasswordCredential{
+				actualCredential: ac,
+				username:         username,
+				password:         credential.Password(password),
+			}
+		}
+	case credential.SshPrivateKeyType:
+		username := field(lib.UsernameAttribute, defaultUsernameAttribute)
+		privateKey := field(lib.PrivateKeyAttribute, defaultPrivateKeyAttribute)
+		if username != "" && privateKey != "" {
+			return &sshPrivateKeyCredential{
+				actualCredential: ac,
+				username:         username,
+				privateKey:       credential.PrivateKey(privateKey),
+			}
+		}
+	case credential.SshCertificateType:
+		certificate := field("", sshSignedKeyField)
+		if certificate != "" && len(ac.privateKey) > 0 {
+			return &sshCertificateCredential{
+				actualCredential: ac,
+				username:         sshCertificateUsername([]byte(certificate)),
+				privateKey:       ac.privateKey,
+				certificate:      []byte(certificate),
+			}
+		}
+	case credential.CertificateType:
+		certificate := field(lib.CertificateAttribute, defaultCertificateAttribute)
+		privateKey := field(lib.PrivateKeyAttribute, defaultPrivateKeyAttribute)
+		if certificate != "" && privateKey != "" {
+			return &certificateCredential{
+				actualCredential: ac,
+				certificate:      []byte(certificate),
+				privateKey:       credential.PrivateKey(privateKey),
+			}
+		}
+	}
+	return ac
+}
@@ -0,0 +1,192 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMappingOverride_validFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *MappingOverride
+		ct      credential.Type
+		wantErr bool
+	}{
+		{
+			name: "nil",
+			ct:   credential.UnspecifiedType,
+		},
+		{
+			name: "username-password",
+			m:    &MappingOverride{UsernameAttribute: "user", PasswordAttribute: "pass"},
+			ct:   credential.UsernamePasswordType,
+		},
+		{
+			name: "ssh-private-key",
+			m:    &MappingOverride{UsernameAttribute: "user", PrivateKeyAttribute: "key"},
+			ct:   credential.SshPrivateKeyType,
+		},
+		{
+			name: "certificate",
+			m:    &MappingOverride{CertificateAttribute: "cert", PrivateKeyAttribute: "key"},
+			ct:   credential.CertificateType,
+		},
+		{
+			name:    "unspecified",
+			m:       &MappingOverride{UsernameAttribute: "user"},
+			ct:      credential.UnspecifiedType,
+			wantErr: true,
+		},
+		{
+			name:    "password-for-ssh-private-key",
+			m:       &MappingOverride{PasswordAttribute: "pass"},
+			ct:      credential.SshPrivateKeyType,
+			wantErr: true,
+		},
+		{
+			name:    "private-key-for-username-password",
+			m:       &MappingOverride{PrivateKeyAttribute: "key"},
+			ct:      credential.UsernamePasswordType,
+			wantErr: true,
+		},
+		{
+			name:    "username-for-certificate",
+			m:       &MappingOverride{UsernameAttribute: "user"},
+			ct:      credential.CertificateType,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.validFor(tt.ct)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestActualCredential_typed(t *testing.T) {
+	tests := []struct {
+		name       string
+		lib        *privateLibrary
+		secret     map[string]interface{}
+		privateKey credential.PrivateKey
+		want       credential.Dynamic
+	}{
+		{
+			name:   "unspecified",
+			lib:    &privateLibrary{},
+			secret: map[string]interface{}{"username": "user", "password": "pass"},
+		},
+		{
+			name:   "username-password",
+			lib:    &privateLibrary{CredType: string(credential.UsernamePasswordType)},
+			secret: map[string]interface{}{"username": "user", "password": "pass"},
+			want: &usernamePasswordCredential{
+				username: "user",
+				password: "pass",
+			},
+		},
+		{
+			name: "username-password-kv2-with-overrides",
+			lib: &privateLibrary{
+				CredType:          string(credential.UsernamePasswordType),
+				UsernameAttribute: "login",
+				PasswordAttribute: "secret",
+			},
+			secret: map[string]interface{}{
+				"metadata": map[string]interface{}{"version": 1},
+				"data": map[string]interface{}{
+					"login":    "user",
+					"secret":   "pass",
+					"password": "not-this-one",
+				},
+			},
+			want: &usernamePasswordCredential{
+				username: "user",
+				password: "pass",
+			},
+		},
+		{
+			name:   "username-password-missing-password",
+			lib:    &privateLibrary{CredType: string(credential.UsernamePasswordType)},
+			secret: map[string]interface{}{"username": "user"},
+		},
+		{
+			name:   "ssh-private-key",
+			lib:    &privateLibrary{CredType: string(credential.SshPrivateKeyType), PrivateKeyAttribute: "key"},
+			secret: map[string]interface{}{"username": "user", "key": "private key"},
+			want: &sshPrivateKeyCredential{
+				username:   "user",
+				privateKey: credential.PrivateKey("private key"),
+			},
+		},
+		{
+			name:   "certificate",
+			lib:    &privateLibrary{CredType: string(credential.CertificateType)},
+			secret: map[string]interface{}{"certificate": "cert", "private_key": "private key", "issuing_ca": "ca"},
+			want: &certificateCredential{
+				certificate: []byte("cert"),
+				privateKey:  credential.PrivateKey("private key"),
+			},
+		},
+		{
+			name:   "certificate-non-string-field",
+			lib:    &privateLibrary{CredType: string(credential.CertificateType)},
+			secret: map[string]interface{}{"certificate": 1, "private_key": "private key"},
+		},
+		{
+			name:       "ssh-certificate",
+			lib:        &privateLibrary{CredType: string(credential.SshCertificateType)},
+			secret:     map[string]interface{}{"serial_number": "1", "signed_key": "cert"},
+			privateKey: credential.PrivateKey("private key"),
+			want: &sshCertificateCredential{
+				privateKey:  credential.PrivateKey("private key"),
+				certificate: []byte("cert"),
+			},
+		},
+		{
+			name:   "ssh-certificate-missing-private-key",
+			lib:    &privateLibrary{CredType: string(credential.SshCertificateType)},
+			secret: map[string]interface{}{"signed_key": "cert"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ac := &actualCredential{
+				id:         "credvlt_1234567890",
+				lib:        tt.lib,
+				secretData: tt.secret,
+				privateKey: tt.privateKey,
+			}
+			got := ac.typed()
+			require.NotNil(got)
+			assert.Equal(tt.secret, got.Secret())
+			switch want := tt.want.(type) {
+			case nil:
+				assert.Same(ac, got)
+			case *usernamePasswordCredential:
+				want.actualCredential = ac
+				assert.Equal(want, got)
+			case *sshPrivateKeyCredential:
+				want.actualCredential = ac
+				assert.Equal(want, got)
+			case *certificateCredential:
+				want.actualCredential = ac
+				assert.Equal(want, got)
+			case *sshCertificateCredential:
+				want.actualCredential = ac
+				assert.Equal(want, got)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package vault
 
+import "github.com/hashicorp/boundary/internal/credential"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -14,16 +16,19 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName          string
-	withDescription   string
-	withLimit         int
-	withCACert        []byte
-	withNamespace     string
-	withTlsServerName string
-	withTlsSkipVerify bool
-	withClientCert    *ClientCertificate
-	withMethod        Method
-	withRequestBody   []byte
+	withName               string
+	withDescription        string
+	withLimit              int
+	withStartPageAfterItem *string
+	withCACert             []byte
+	withNamespace          string
+	withTlsServerName      string
+	withTlsSkipVerify      bool
+	withClientCert         *ClientCertificate
+	withMethod             Method
+	withRequestBody        []byte
+	withCredentialType     credential.Type
+	withMappingOverride    *MappingOverride
 }
 
 func getDefaultOptions() options {
@@ -53,6 +58,15 @@ func WithLimit(l int) Option {
 	}
 }
 
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
 // WithCACert provides an optional PEM-encoded certificate
 // to verify the Vault server's SSL certificate.
 func WithCACert(cert []byte) Option {
@@ -109,3 +123,19 @@ func WithRequestBody(b []byte) Option {
 		o.withRequestBody = b
 	}
 }
+
+// WithCredentialType provides an optional credential type to declare for
+// the credentials a library provides.
+func WithCredentialType(t credential.Type) Option {
+	return func(o *options) {
+		o.withCredentialType = t
+	}
+}
+
+// WithMappingOverride provides an optional MappingOverride naming the
+// fields in a Vault secret which hold the parts of a typed credential.
+func WithMappingOverride(m *MappingOverride) Option {
+	return func(o *options) {
+		o.withMappingOverride = m
+	}
+}
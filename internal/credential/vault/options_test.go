@@ -27,6 +27,14 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 5
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterItem("s_1234567890"))
+		testOpts := getDefaultOptions()
+		id := "s_1234567890"
+		testOpts.withStartPageAfterItem = &id
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithCACert", func(t *testing.T) {
 		opts := getOpts(WithCACert([]byte("test cert")))
 		testOpts := getDefaultOptions()
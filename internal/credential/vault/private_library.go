@@ -23,6 +23,9 @@ type actualCredential struct {
 	lib        *privateLibrary
 	secretData map[string]interface{}
 	purpose    credential.Purpose
+	// privateKey is the private key Boundary generated for the credential,
+	// if any. It is not part of the secret returned by Vault.
+	privateKey credential.PrivateKey
 }
 
 func (ac *actualCredential) GetPublicId() string           { return ac.id }
@@ -34,62 +37,72 @@ func (ac *actualCredential) Purpose() credential.Purpose   { return ac.purpose }
 var _ credential.Library = (*privateLibrary)(nil)
 
 type privateLibrary struct {
-	PublicId        string `gorm:"primary_key"`
-	StoreId         string
-	Name            string
-	Description     string
-	CreateTime      *timestamp.Timestamp
-	UpdateTime      *timestamp.Timestamp
-	Version         uint32
-	ScopeId         string
-	VaultPath       string
-	HttpMethod      string
-	HttpRequestBody []byte
-	VaultAddress    string
-	Namespace       string
-	CaCert          []byte
-	TlsServerName   string
-	TlsSkipVerify   bool
-	TokenHmac       []byte
-	Token           TokenSecret
-	CtToken         []byte
-	TokenKeyId      string
-	ClientCert      []byte
-	ClientKey       KeySecret
-	CtClientKey     []byte
-	ClientKeyId     string
-	Purpose         credential.Purpose `gorm:"-"`
+	PublicId             string `gorm:"primary_key"`
+	StoreId              string
+	Name                 string
+	Description          string
+	CreateTime           *timestamp.Timestamp
+	UpdateTime           *timestamp.Timestamp
+	Version              uint32
+	ScopeId              string
+	VaultPath            string
+	HttpMethod           string
+	HttpRequestBody      []byte
+	VaultAddress         string
+	Namespace            string
+	CaCert               []byte
+	TlsServerName        string
+	TlsSkipVerify        bool
+	TokenHmac            []byte
+	Token                TokenSecret
+	CtToken              []byte
+	TokenKeyId           string
+	ClientCert           []byte
+	ClientKey            KeySecret
+	CtClientKey          []byte
+	ClientKeyId          string
+	CredType             string `gorm:"column:credential_type"`
+	UsernameAttribute    string
+	PasswordAttribute    string
+	PrivateKeyAttribute  string
+	CertificateAttribute string
+	Purpose              credential.Purpose `gorm:"-"`
 }
 
 func (pl *privateLibrary) clone() *privateLibrary {
 	// The 'append(a[:0:0], a...)' comes from
 	// https://github.com/go101/go101/wiki/How-to-perfectly-clone-a-slice%3F
 	return &privateLibrary{
-		PublicId:        pl.PublicId,
-		StoreId:         pl.StoreId,
-		Name:            pl.Name,
-		Description:     pl.Description,
-		CreateTime:      proto.Clone(pl.CreateTime).(*timestamp.Timestamp),
-		UpdateTime:      proto.Clone(pl.UpdateTime).(*timestamp.Timestamp),
-		Version:         pl.Version,
-		ScopeId:         pl.ScopeId,
-		VaultPath:       pl.VaultPath,
-		HttpMethod:      pl.HttpMethod,
-		HttpRequestBody: append(pl.HttpRequestBody[:0:0], pl.HttpRequestBody...),
-		VaultAddress:    pl.VaultAddress,
-		Namespace:       pl.Namespace,
-		CaCert:          append(pl.CaCert[:0:0], pl.CaCert...),
-		TlsServerName:   pl.TlsServerName,
-		TlsSkipVerify:   pl.TlsSkipVerify,
-		TokenHmac:       append(pl.TokenHmac[:0:0], pl.TokenHmac...),
-		Token:           append(pl.Token[:0:0], pl.Token...),
-		CtToken:         append(pl.CtToken[:0:0], pl.CtToken...),
-		TokenKeyId:      pl.TokenKeyId,
-		ClientCert:      append(pl.ClientCert[:0:0], pl.ClientCert...),
-		ClientKey:       append(pl.ClientKey[:0:0], pl.ClientKey...),
-		CtClientKey:     append(pl.CtClientKey[:0:0], pl.CtClientKey...),
-		ClientKeyId:     pl.ClientKeyId,
-		Purpose:         pl.Purpose,
+		PublicId:             pl.PublicId,
+		StoreId:              pl.StoreId,
+		Name:                 pl.Name,
+		Description:          pl.Description,
+		CreateTime:           proto.Clone(pl.CreateTime).(*timestamp.Timestamp),
+		UpdateTime:           proto.Clone(pl.UpdateTime).(*timestamp.Timestamp),
+		Version:              pl.Version,
+		ScopeId:              pl.ScopeId,
+		VaultPath:            pl.VaultPath,
+		HttpMethod:           pl.HttpMethod,
+		HttpRequestBody:      append(pl.HttpRequestBody[:0:0], pl.HttpRequestBody...),
+		VaultAddress:         pl.VaultAddress,
+		Namespace:            pl.Namespace,
+		CaCert:               append(pl.CaCert[:0:0], pl.CaCert...),
+		TlsServerName:        pl.TlsServerName,
+		TlsSkipVerify:        pl.TlsSkipVerify,
+		TokenHmac:            append(pl.TokenHmac[:0:0], pl.TokenHmac...),
+		Token:                append(pl.Token[:0:0], pl.Token...),
+		CtToken:              append(pl.CtToken[:0:0], pl.CtToken...),
+		TokenKeyId:           pl.TokenKeyId,
+		ClientCert:           append(pl.ClientCert[:0:0], pl.ClientCert...),
+		ClientKey:            append(pl.ClientKey[:0:0], pl.ClientKey...),
+		CtClientKey:          append(pl.CtClientKey[:0:0], pl.CtClientKey...),
+		ClientKeyId:          pl.ClientKeyId,
+		CredType:             pl.CredType,
+		UsernameAttribute:    pl.UsernameAttribute,
+		PasswordAttribute:    pl.PasswordAttribute,
+		PrivateKeyAttribute:  pl.PrivateKeyAttribute,
+		CertificateAttribute: pl.CertificateAttribute,
+		Purpose:              pl.Purpose,
 	}
 }
 
@@ -101,6 +114,13 @@ func (pl *privateLibrary) GetVersion() uint32                  { return pl.Versi
 func (pl *privateLibrary) GetCreateTime() *timestamp.Timestamp { return pl.CreateTime }
 func (pl *privateLibrary) GetUpdateTime() *timestamp.Timestamp { return pl.UpdateTime }
 
+func (pl *privateLibrary) CredentialType() credential.Type {
+	if pl.CredType == "" {
+		return credential.UnspecifiedType
+	}
+	return credential.Type(pl.CredType)
+}
+
 func (pl *privateLibrary) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
 	const op = "vault.(privateLibrary).decrypt"
 
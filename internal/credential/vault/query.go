@@ -116,6 +116,21 @@ update credential_vault_token
  where token_hmac = ?;
 `
 
+	rewrapTokenQuery = `
+update credential_vault_token
+   set token  = @token,
+       key_id = @key_id
+ where token_hmac = @token_hmac;
+`
+
+	rewrapClientCertQuery = `
+update credential_vault_client_certificate
+   set certificate_key      = @certificate_key,
+       certificate_key_hmac = @certificate_key_hmac,
+       key_id               = @key_id
+ where store_id = @store_id;
+`
+
 	tokenRenewalNextRunInQuery = `
 select extract(epoch from (last_renewal_time + (expiration_time - last_renewal_time) / 2) - now())::int as renewal_in
   from credential_vault_token
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -21,6 +22,16 @@ import (
 // Both l.Name and l.Description are optional. If l.Name is set, it must be
 // unique within l.StoreId.
 //
+// l.VaultPath and l.HttpRequestBody are templates which are executed with
+// the data of the session when credentials are issued; they must be valid
+// templates.
+//
+// l.CredentialType is optional and defaults to credential.UnspecifiedType.
+// The attribute names of the mapping override can only be set for the
+// parts of the credential l.CredentialType has. A library with a
+// CredentialType of credential.SshCertificateType must use the POST
+// HttpMethod.
+//
 // Both l.CreateTime and l.UpdateTime are ignored.
 func (r *Repository) CreateCredentialLibrary(ctx context.Context, scopeId string, l *CredentialLibrary, _ ...Option) (*CredentialLibrary, error) {
 	const op = "vault.(Repository).CreateCredentialLibrary"
@@ -47,6 +58,22 @@ func (r *Repository) CreateCredentialLibrary(ctx context.Context, scopeId string
 	if l.HttpMethod == "" {
 		l.HttpMethod = string(MethodGet)
 	}
+	if !credential.ValidType(l.CredentialType()) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown credential type: %s", l.GetCredentialType()))
+	}
+	l.CredentialLibrary.CredentialType = string(l.CredentialType())
+	if err := l.MappingOverride().validFor(l.CredentialType()); err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, err.Error())
+	}
+	if l.CredentialType() == credential.SshCertificateType && Method(l.HttpMethod) != MethodPost {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "ssh certificate credential libraries must use the POST http method")
+	}
+	if err := validTemplate(l.VaultPath); err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("vault path: %s", err))
+	}
+	if err := validTemplate(string(l.HttpRequestBody)); err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("http request body: %s", err))
+	}
 
 	id, err := newCredentialLibraryId()
 	if err != nil {
@@ -86,14 +113,19 @@ func (r *Repository) CreateCredentialLibrary(ctx context.Context, scopeId string
 // number of records updated. l is not changed.
 //
 // l must contain a valid PublicId. Only Name, Description, VaultPath,
-// HttpMethod, and HttpRequestBody can be updated. If l.Name is set to a
-// non-empty string, it must be unique within l.StoreId.
+// HttpMethod, HttpRequestBody, and the attribute names of the mapping
+// override can be updated. If l.Name is set to a non-empty string, it must
+// be unique within l.StoreId. The update fails if an attribute name is set
+// for a part of the credential the library's CredentialType does not have.
 //
 // An attribute of l will be set to NULL in the database if the attribute
 // in l is the zero value and it is included in fieldMaskPaths except for
 // HttpMethod.  If HttpMethod is in the fieldMaskPath but l.HttpMethod
 // is not set it will be set to the value "GET".  If storage has a value
 // for HttpRequestBody when l.HttpMethod is set to GET the update will fail.
+// The update also fails if HttpMethod is set to anything other than POST
+// for a library with a CredentialType of credential.SshCertificateType, or
+// if VaultPath or HttpRequestBody is not a valid template.
 func (r *Repository) UpdateCredentialLibrary(ctx context.Context, scopeId string, l *CredentialLibrary, version uint32, fieldMaskPaths []string, _ ...Option) (*CredentialLibrary, int, error) {
 	const op = "vault.(Repository).UpdateCredentialLibrary"
 	if l == nil {
@@ -120,6 +152,10 @@ func (r *Repository) UpdateCredentialLibrary(ctx context.Context, scopeId string
 		case strings.EqualFold(vaultPathField, f):
 		case strings.EqualFold(httpMethodField, f):
 		case strings.EqualFold(httpRequestBodyField, f):
+		case strings.EqualFold(usernameAttributeField, f):
+		case strings.EqualFold(passwordAttributeField, f):
+		case strings.EqualFold(privateKeyAttributeField, f):
+		case strings.EqualFold(certificateAttributeField, f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -132,6 +168,11 @@ func (r *Repository) UpdateCredentialLibrary(ctx context.Context, scopeId string
 			vaultPathField:       l.VaultPath,
 			httpMethodField:      l.HttpMethod,
 			httpRequestBodyField: l.HttpRequestBody,
+
+			usernameAttributeField:    l.UsernameAttribute,
+			passwordAttributeField:    l.PasswordAttribute,
+			privateKeyAttributeField:  l.PrivateKeyAttribute,
+			certificateAttributeField: l.CertificateAttribute,
 		},
 		fieldMaskPaths,
 		nil,
@@ -146,6 +187,16 @@ func (r *Repository) UpdateCredentialLibrary(ctx context.Context, scopeId string
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
 	}
+	if strutil.StrListContains(dbMask, vaultPathField) {
+		if err := validTemplate(l.VaultPath); err != nil {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("vault path: %s", err))
+		}
+	}
+	if strutil.StrListContains(dbMask, httpRequestBodyField) {
+		if err := validTemplate(string(l.HttpRequestBody)); err != nil {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("http request body: %s", err))
+		}
+	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
 	if err != nil {
@@ -238,7 +289,8 @@ func (r *Repository) DeleteCredentialLibrary(ctx context.Context, scopeId string
 }
 
 // ListCredentialLibraries returns a slice of CredentialLibraries for the
-// storeId. WithLimit is the only option supported.
+// storeId. WithLimit and WithStartPageAfterItem are the only options
+// supported.
 func (r *Repository) ListCredentialLibraries(ctx context.Context, storeId string, opt ...Option) ([]*CredentialLibrary, error) {
 	const op = "vault.(Repository).ListCredentialLibraries"
 	if storeId == "" {
@@ -250,8 +302,12 @@ func (r *Repository) ListCredentialLibraries(ctx context.Context, storeId string
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
 	var libs []*CredentialLibrary
-	err := r.reader.SearchWhere(ctx, &libs, "store_id = ?", []interface{}{storeId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &libs, "store_id = ?", []interface{}{storeId}, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/credential/vault/store"
 	"github.com/hashicorp/boundary/internal/db"
 	dbassert "github.com/hashicorp/boundary/internal/db/assert"
@@ -166,6 +167,93 @@ func TestRepository_CreateCredentialLibrary(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid-credential-type-with-mapping-override",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:           cs.GetPublicId(),
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.UsernamePasswordType),
+					UsernameAttribute: "user",
+					PasswordAttribute: "pass",
+				},
+			},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:           cs.GetPublicId(),
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.UsernamePasswordType),
+					UsernameAttribute: "user",
+					PasswordAttribute: "pass",
+				},
+			},
+		},
+		{
+			name: "invalid-unknown-credential-type",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:        cs.GetPublicId(),
+					VaultPath:      "/some/path",
+					CredentialType: "unknown",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-mapping-override-for-credential-type",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:              cs.GetPublicId(),
+					VaultPath:            "/some/path",
+					CredentialType:       string(credential.SshPrivateKeyType),
+					CertificateAttribute: "cert",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid-templates",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					HttpMethod:      "POST",
+					VaultPath:       "/database/creds/{{.Account.LoginName}}",
+					HttpRequestBody: []byte(`{"ttl": "{{.Ttl}}s"}`),
+				},
+			},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					HttpMethod:      "POST",
+					VaultPath:       "/database/creds/{{.Account.LoginName}}",
+					HttpRequestBody: []byte(`{"ttl": "{{.Ttl}}s"}`),
+				},
+			},
+		},
+		{
+			name: "invalid-vault-path-template",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:   cs.GetPublicId(),
+					VaultPath: "/database/creds/{{.User.Name",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-http-request-body-template",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					HttpMethod:      "POST",
+					VaultPath:       "/some/path",
+					HttpRequestBody: []byte(`{"user": "{{.User.Phone}}"}`),
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
 	}
 
 	for _, tt := range tests {
@@ -191,6 +279,8 @@ func TestRepository_CreateCredentialLibrary(t *testing.T) {
 			assert.NotSame(tt.in, got)
 			assert.Equal(tt.want.Name, got.Name)
 			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(tt.want.CredentialType(), got.CredentialType())
+			assert.Equal(tt.want.MappingOverride(), got.MappingOverride())
 			assert.Equal(got.CreateTime, got.UpdateTime)
 			assert.NoError(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
 		})
@@ -322,6 +412,13 @@ func TestRepository_UpdateCredentialLibrary(t *testing.T) {
 		}
 	}
 
+	changeUsernameAttribute := func(a string) func(*CredentialLibrary) *CredentialLibrary {
+		return func(l *CredentialLibrary) *CredentialLibrary {
+			l.UsernameAttribute = a
+			return l
+		}
+	}
+
 	makeNil := func() func(*CredentialLibrary) *CredentialLibrary {
 		return func(l *CredentialLibrary) *CredentialLibrary {
 			return nil
@@ -738,6 +835,19 @@ func TestRepository_UpdateCredentialLibrary(t *testing.T) {
 			masks:   []string{httpMethodField},
 			wantErr: errors.CheckConstraint,
 		},
+		{
+			name: "change-http-request-body-invalid-template",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:      "POST",
+					VaultPath:       "/some/path",
+					HttpRequestBody: []byte("old request body"),
+				},
+			},
+			chgFn:   changeHttpRequestBody([]byte("{{.User.Name")),
+			masks:   []string{httpRequestBodyField},
+			wantErr: errors.InvalidParameter,
+		},
 		{
 			name: "change-method-to-POST-add-request-body",
 			orig: &CredentialLibrary{
@@ -757,6 +867,73 @@ func TestRepository_UpdateCredentialLibrary(t *testing.T) {
 			},
 			wantCount: 1,
 		},
+		{
+			name: "change-username-attribute",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.SshPrivateKeyType),
+					UsernameAttribute: "user",
+				},
+			},
+			chgFn: changeUsernameAttribute("login"),
+			masks: []string{usernameAttributeField},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.SshPrivateKeyType),
+					UsernameAttribute: "login",
+				},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "delete-username-attribute",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.SshPrivateKeyType),
+					UsernameAttribute: "user",
+				},
+			},
+			chgFn: changeUsernameAttribute(""),
+			masks: []string{usernameAttributeField},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:     "GET",
+					VaultPath:      "/some/path",
+					CredentialType: string(credential.SshPrivateKeyType),
+				},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "username-attribute-for-certificate-type",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod:     "GET",
+					VaultPath:      "/some/path",
+					CredentialType: string(credential.CertificateType),
+				},
+			},
+			chgFn:   changeUsernameAttribute("user"),
+			masks:   []string{usernameAttributeField},
+			wantErr: errors.CheckConstraint,
+		},
+		{
+			name: "read-only-credential-type-in-field-mask",
+			orig: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					HttpMethod: "GET",
+					VaultPath:  "/some/path",
+				},
+			},
+			masks:   []string{"CredentialType"},
+			wantErr: errors.InvalidFieldMask,
+		},
 	}
 
 	for _, tt := range tests {
@@ -798,6 +975,7 @@ func TestRepository_UpdateCredentialLibrary(t *testing.T) {
 			underlyingDB, err := conn.SqlDB(ctx)
 			require.NoError(err)
 			dbassert := dbassert.New(t, underlyingDB)
+			assert.Equal(tt.want.MappingOverride(), got.MappingOverride())
 			if tt.want.Name == "" {
 				dbassert.IsNull(got, "name")
 				return
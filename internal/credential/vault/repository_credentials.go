@@ -15,7 +15,12 @@ var _ credential.Issuer = (*Repository)(nil)
 
 // Issue issues and returns dynamic credentials from Vault for all of the
 // requests and assigns them to sessionId.
-func (r *Repository) Issue(ctx context.Context, sessionId string, requests []credential.Request) ([]credential.Dynamic, error) {
+//
+// The path and request body of each library are executed as templates with
+// sessionId and the session data from the WithSessionData option. For a
+// library which provides ssh_certificate credentials, Issue generates a key
+// pair and has Vault sign the public key.
+func (r *Repository) Issue(ctx context.Context, sessionId string, requests []credential.Request, opt ...credential.Option) ([]credential.Dynamic, error) {
 	const op = "vault.(Repository).Issue"
 	if sessionId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no session id")
@@ -24,6 +29,8 @@ func (r *Repository) Issue(ctx context.Context, sessionId string, requests []cre
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no requests")
 	}
 
+	opts := credential.GetOpts(opt...)
+
 	libs, err := r.getPrivateLibraries(ctx, requests)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -48,12 +55,34 @@ func (r *Repository) Issue(ctx context.Context, sessionId string, requests []cre
 			return nil, errors.Wrap(ctx, err, op)
 		}
 
+		data := newTemplateData(sessionId, opts.WithSessionData)
+		path, err := lib.requestPath(data)
+		if err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("library: %s: %s", lib.PublicId, err))
+		}
+		body, err := lib.requestBody(data)
+		if err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("library: %s: %s", lib.PublicId, err))
+		}
+
 		var secret *vault.Secret
-		switch Method(lib.HttpMethod) {
-		case MethodGet:
-			secret, err = client.get(lib.VaultPath)
-		case MethodPost:
-			secret, err = client.post(lib.VaultPath, lib.HttpRequestBody)
+		var privateKey credential.PrivateKey
+		switch {
+		case lib.CredentialType() == credential.SshCertificateType:
+			var publicKey string
+			privateKey, publicKey, err = generateSshKey()
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("library: %s", lib.PublicId)))
+			}
+			body, err = sshSignRequestBody(body, publicKey)
+			if err != nil {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("library: %s: %s", lib.PublicId, err))
+			}
+			secret, err = client.post(path, body)
+		case Method(lib.HttpMethod) == MethodGet:
+			secret, err = client.get(path)
+		case Method(lib.HttpMethod) == MethodPost:
+			secret, err = client.post(path, body)
 		default:
 			return nil, errors.New(ctx, errors.Internal, op, fmt.Sprintf("unknown http method: library: %s", lib.PublicId))
 		}
@@ -102,13 +131,15 @@ func (r *Repository) Issue(ctx context.Context, sessionId string, requests []cre
 			return nil, errors.Wrap(ctx, err, op)
 		}
 
-		creds = append(creds, &actualCredential{
+		ac := &actualCredential{
 			id:         cred.PublicId,
 			sessionId:  cred.SessionId,
 			lib:        lib,
 			secretData: secret.Data,
 			purpose:    lib.Purpose,
-		})
+			privateKey: privateKey,
+		}
+		creds = append(creds, ac.typed())
 	}
 
 	// Best effort update next run time of credential renewal job, but an error should not
@@ -0,0 +1,103 @@
+package vault
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	if err := kms.RegisterTableRewrapFn("credential_vault_token", rewrapTokens); err != nil {
+		panic(err)
+	}
+	if err := kms.RegisterTableRewrapFn("credential_vault_client_certificate", rewrapClientCertificates); err != nil {
+		panic(err)
+	}
+}
+
+// rewrapWrappers returns the database wrapper for the key version
+// dataKeyVersionId and the current database wrapper of scopeId.
+func rewrapWrappers(ctx context.Context, dataKeyVersionId, scopeId string, kmsCache *kms.Kms) (wrapping.Wrapper, wrapping.Wrapper, error) {
+	const op = "vault.rewrapWrappers"
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for key version"))
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	return oldWrapper, wrapper, nil
+}
+
+// rewrapTokens encrypts the Vault tokens encrypted by the database key
+// version dataKeyVersionId with the current database key version of scopeId.
+func rewrapTokens(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "vault.rewrapTokens"
+	var tokens []*Token
+	if err := reader.SearchWhere(ctx, &tokens, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	oldWrapper, wrapper, err := rewrapWrappers(ctx, dataKeyVersionId, scopeId, kmsCache)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, t := range tokens {
+		if err := t.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := t.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapTokenQuery, []interface{}{
+			sql.Named("token", t.CtToken),
+			sql.Named("key_id", t.KeyId),
+			sql.Named("token_hmac", t.TokenHmac),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update token"))
+		}
+	}
+	return nil
+}
+
+// rewrapClientCertificates encrypts the keys of the client certificates
+// encrypted by the database key version dataKeyVersionId with the current
+// database key version of scopeId and recomputes their hmacs.
+func rewrapClientCertificates(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "vault.rewrapClientCertificates"
+	var certs []*ClientCertificate
+	if err := reader.SearchWhere(ctx, &certs, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(certs) == 0 {
+		return nil
+	}
+	oldWrapper, wrapper, err := rewrapWrappers(ctx, dataKeyVersionId, scopeId, kmsCache)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, c := range certs {
+		if err := c.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapClientCertQuery, []interface{}{
+			sql.Named("certificate_key", c.CtCertificateKey),
+			sql.Named("certificate_key_hmac", c.CertificateKeyHmac),
+			sql.Named("key_id", c.KeyId),
+			sql.Named("store_id", c.StoreId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update client certificate"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"golang.org/x/crypto/ssh"
+)
+
+// The names of the fields in the request to and the response from the sign
+// endpoint of a Vault SSH secrets engine.
+const (
+	sshPublicKeyField = "public_key"
+	sshSignedKeyField = "signed_key"
+)
+
+var _ credential.SshCertificate = (*sshCertificateCredential)(nil)
+
+type sshCertificateCredential struct {
+	*actualCredential
+	username    string
+	privateKey  credential.PrivateKey
+	certificate []byte
+}
+
+func (c *sshCertificateCredential) Username() string               { return c.username }
+func (c *sshCertificateCredential) Private() credential.PrivateKey { return c.privateKey }
+func (c *sshCertificateCredential) Certificate() []byte            { return c.certificate }
+
+// generateSshKey generates an ephemeral key pair for an SSH certificate. It
+// returns the PEM encoded private key and the public key in the
+// authorized_keys format.
+func generateSshKey() (credential.PrivateKey, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("generating key: %w", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshaling private key: %w", err)
+	}
+	pub, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("converting public key: %w", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub)))
+	return privateKey, publicKey, nil
+}
+
+// sshSignRequestBody returns the body of the request to sign publicKey.
+// The executed request body of the library must be empty or a JSON object.
+// The public key is added to the object, replacing any public key in the
+// body.
+func sshSignRequestBody(body []byte, publicKey string) ([]byte, error) {
+	fields := map[string]interface{}{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, fmt.Errorf("request body is not a JSON object: %w", err)
+		}
+	}
+	fields[sshPublicKeyField] = publicKey
+	return json.Marshal(fields)
+}
+
+// sshCertificateUsername returns the first valid principal of the SSH
+// certificate cert. It returns an empty string if cert cannot be parsed or
+// has no principals.
+func sshCertificateUsername(cert []byte) string {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(cert)
+	if err != nil {
+		return ""
+	}
+	c, ok := pub.(*ssh.Certificate)
+	if !ok || len(c.ValidPrincipals) == 0 {
+		return ""
+	}
+	return c.ValidPrincipals[0]
+}
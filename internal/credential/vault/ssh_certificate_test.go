@@ -0,0 +1,135 @@
+package vault
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerateSshKey(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	privateKey, publicKey, err := generateSshKey()
+	require.NoError(err)
+
+	block, _ := pem.Decode(privateKey)
+	require.NotNil(block)
+	assert.Equal("EC PRIVATE KEY", block.Type)
+
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	require.NoError(err)
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	require.NoError(err)
+	assert.Equal(signer.PublicKey().Marshal(), pub.Marshal())
+
+	_, otherPublicKey, err := generateSshKey()
+	require.NoError(err)
+	assert.NotEqual(publicKey, otherPublicKey)
+}
+
+func TestSshSignRequestBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "empty-body",
+			want: map[string]interface{}{"public_key": "key"},
+		},
+		{
+			name: "body",
+			body: `{"valid_principals": "ubuntu", "ttl": "3600s"}`,
+			want: map[string]interface{}{"valid_principals": "ubuntu", "ttl": "3600s", "public_key": "key"},
+		},
+		{
+			name: "replaces-public-key",
+			body: `{"public_key": "other"}`,
+			want: map[string]interface{}{"public_key": "key"},
+		},
+		{
+			name:    "not-an-object",
+			body:    `["ubuntu"]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := sshSignRequestBody([]byte(tt.body), "key")
+			if tt.wantErr {
+				assert.Error(err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			var gotFields map[string]interface{}
+			require.NoError(json.Unmarshal(got, &gotFields))
+			assert.Equal(tt.want, gotFields)
+		})
+	}
+}
+
+func TestSshCertificateUsername(t *testing.T) {
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ca, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+	_, publicKey, err := generateSshKey()
+	require.NoError(t, err)
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	require.NoError(t, err)
+
+	signedKey := func(principals ...string) []byte {
+		cert := &ssh.Certificate{
+			Key:             pub,
+			CertType:        ssh.UserCert,
+			ValidPrincipals: principals,
+			ValidBefore:     ssh.CertTimeInfinity,
+		}
+		require.NoError(t, cert.SignCert(rand.Reader, ca))
+		return ssh.MarshalAuthorizedKey(cert)
+	}
+
+	tests := []struct {
+		name string
+		cert []byte
+		want string
+	}{
+		{
+			name: "one-principal",
+			cert: signedKey("alice"),
+			want: "alice",
+		},
+		{
+			name: "many-principals",
+			cert: signedKey("alice", "bob"),
+			want: "alice",
+		},
+		{
+			name: "no-principals",
+			cert: signedKey(),
+		},
+		{
+			name: "not-a-certificate",
+			cert: []byte(publicKey),
+		},
+		{
+			name: "invalid",
+			cert: []byte("cert"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sshCertificateUsername(tt.cert))
+		})
+	}
+}
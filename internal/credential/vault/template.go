@@ -0,0 +1,150 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/boundary/internal/credential"
+)
+
+// templateData is the data available to the path and request body
+// templates of a library.
+type templateData struct {
+	User struct {
+		Id   string
+		Name string
+	}
+	// Account is the account of the user in the primary auth method of
+	// the user's scope. LoginName is the subject of an OIDC account.
+	Account struct {
+		Id        string
+		LoginName string
+		FullName  string
+		Email     string
+	}
+	Target struct {
+		Id   string
+		Name string
+	}
+	Session struct {
+		Id string
+	}
+	Host struct {
+		Id      string
+		Address string
+	}
+	// Ttl is the number of seconds until the session expires.
+	Ttl int64
+}
+
+func newTemplateData(sessionId string, sd *credential.SessionData) *templateData {
+	d := &templateData{}
+	d.Session.Id = sessionId
+	if sd == nil {
+		return d
+	}
+	d.User.Id, d.User.Name = sd.UserId, sd.UserName
+	d.Account.Id, d.Account.LoginName = sd.AccountId, sd.LoginName
+	d.Account.FullName, d.Account.Email = sd.FullName, sd.Email
+	d.Target.Id, d.Target.Name = sd.TargetId, sd.TargetName
+	d.Host.Id, d.Host.Address = sd.HostId, sd.HostAddress
+	d.Ttl = int64(sd.Ttl.Seconds())
+	return d
+}
+
+// values returns pointers to every string of d.
+func (d *templateData) values() []*string {
+	return []*string{
+		&d.User.Id, &d.User.Name,
+		&d.Account.Id, &d.Account.LoginName, &d.Account.FullName, &d.Account.Email,
+		&d.Target.Id, &d.Target.Name,
+		&d.Session.Id,
+		&d.Host.Id, &d.Host.Address,
+	}
+}
+
+// escaped returns a copy of d with every string replaced by escape of it.
+func (d *templateData) escaped(escape func(string) string) *templateData {
+	e := *d
+	for _, s := range e.values() {
+		*s = escape(*s)
+	}
+	return &e
+}
+
+// jsonEscaped returns a copy of d with every string escaped for use in a
+// JSON string, so the values cannot add fields to the request body. Many of
+// the values, such as the name and email of the account, are chosen by the
+// user or their identity provider.
+func (d *templateData) jsonEscaped() *templateData {
+	return d.escaped(func(s string) string {
+		b, _ := json.Marshal(s)
+		return string(b[1 : len(b)-1])
+	})
+}
+
+// executeTemplate executes the text/template tmpl with data.
+func executeTemplate(tmpl string, data *templateData) ([]byte, error) {
+	t, err := template.New("").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("executing template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// validTemplate returns an error if tmpl is not a template which can be
+// executed with the data of a session.
+func validTemplate(tmpl string) error {
+	_, err := executeTemplate(tmpl, &templateData{})
+	return err
+}
+
+// requestPath returns the path of the library l executed with data. The
+// path is not escaped here since the Vault client escapes it when sending
+// the request. Instead, a path is rejected if a value in it contains "/",
+// so the values cannot add segments to the path, or if it contains the
+// segments "." or "..".
+func (l *privateLibrary) requestPath(data *templateData) (string, error) {
+	p, err := executeTemplate(l.VaultPath, data)
+	if err != nil {
+		return "", fmt.Errorf("path: %w", err)
+	}
+	// Executing the template with "/" removed from the values only changes
+	// the number of separators if a value in the path contains one.
+	stripped, err := executeTemplate(l.VaultPath, data.escaped(func(s string) string {
+		return strings.ReplaceAll(s, "/", "")
+	}))
+	if err != nil {
+		return "", fmt.Errorf("path: %w", err)
+	}
+	if bytes.Count(p, []byte("/")) != bytes.Count(stripped, []byte("/")) {
+		return "", fmt.Errorf("path: %q contains a value with a path separator", p)
+	}
+	for _, seg := range strings.Split(string(p), "/") {
+		if seg == "." || seg == ".." {
+			return "", fmt.Errorf("path: %q contains a relative path segment", p)
+		}
+	}
+	return string(p), nil
+}
+
+// requestBody returns the request body of the library l executed with
+// data. The values of data are escaped for use in JSON strings. It returns
+// nil if l does not have a request body.
+func (l *privateLibrary) requestBody(data *templateData) ([]byte, error) {
+	if len(l.HttpRequestBody) == 0 {
+		return nil, nil
+	}
+	b, err := executeTemplate(string(l.HttpRequestBody), data.jsonEscaped())
+	if err != nil {
+		return nil, fmt.Errorf("request body: %w", err)
+	}
+	return b, nil
+}
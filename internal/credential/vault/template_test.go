@@ -0,0 +1,182 @@
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateLibrary_requestPathAndBody(t *testing.T) {
+	sd := &credential.SessionData{
+		UserId:      "u_1234567890",
+		UserName:    "alice",
+		AccountId:   "acctoidc_1234567890",
+		LoginName:   "alice@example.com",
+		FullName:    "Alice Smith",
+		Email:       "alice@example.com",
+		TargetId:    "ttcp_1234567890",
+		TargetName:  "prod",
+		HostId:      "hst_1234567890",
+		HostAddress: "10.0.0.1",
+		Ttl:         8 * time.Hour,
+	}
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		sd       *credential.SessionData
+		wantPath string
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "static",
+			path:     "database/creds/opened",
+			body:     `{"common_name": "boundary.com"}`,
+			sd:       sd,
+			wantPath: "database/creds/opened",
+			wantBody: `{"common_name": "boundary.com"}`,
+		},
+		{
+			name:     "no-body",
+			path:     "database/creds/{{.User.Name}}",
+			sd:       sd,
+			wantPath: "database/creds/alice",
+		},
+		{
+			name:     "all-values",
+			path:     "secret/{{.Target.Name}}",
+			body:     `{{.User.Id}} {{.User.Name}} {{.Account.Id}} {{.Account.LoginName}} {{.Account.FullName}} {{.Account.Email}} {{.Target.Id}} {{.Target.Name}} {{.Session.Id}} {{.Host.Id}} {{.Host.Address}} {{.Ttl}}`,
+			sd:       sd,
+			wantPath: "secret/prod",
+			wantBody: "u_1234567890 alice acctoidc_1234567890 alice@example.com Alice Smith alice@example.com ttcp_1234567890 prod s_1234567890 hst_1234567890 10.0.0.1 28800",
+		},
+		{
+			name:     "path-values-not-escaped",
+			path:     "database/creds/{{.Account.FullName}}",
+			body:     `{"name": "{{.Account.FullName}}"}`,
+			sd:       &credential.SessionData{FullName: "Alice Smith"},
+			wantPath: "database/creds/Alice Smith",
+			wantBody: `{"name": "Alice Smith"}`,
+		},
+		{
+			name:    "path-value-separator",
+			path:    "database/creds/{{.Account.LoginName}}",
+			sd:      &credential.SessionData{LoginName: "../../sys/seal"},
+			wantErr: true,
+		},
+		{
+			name:     "unused-value-separator",
+			path:     "database/creds/{{.User.Name}}",
+			sd:       &credential.SessionData{UserName: "alice", FullName: "Smith/Alice"},
+			wantPath: "database/creds/alice",
+		},
+		{
+			name:     "body-values-escaped",
+			path:     "ssh/sign/user",
+			body:     `{"valid_principals": "{{.Account.FullName}}"}`,
+			sd:       &credential.SessionData{FullName: `alice", "valid_principals": "root`},
+			wantPath: "ssh/sign/user",
+			wantBody: `{"valid_principals": "alice\", \"valid_principals\": \"root"}`,
+		},
+		{
+			name:    "dot-dot-segment",
+			path:    "database/creds/{{.User.Name}}/reset",
+			sd:      &credential.SessionData{UserName: ".."},
+			wantErr: true,
+		},
+		{
+			name:    "dot-segment",
+			path:    "database/{{.User.Name}}/creds",
+			sd:      &credential.SessionData{UserName: "."},
+			wantErr: true,
+		},
+		{
+			name:     "nil-session-data",
+			path:     "database/creds/{{.User.Name}}",
+			body:     `{"session": "{{.Session.Id}}"}`,
+			wantPath: "database/creds/",
+			wantBody: `{"session": "s_1234567890"}`,
+		},
+		{
+			name:    "invalid-path",
+			path:    "database/creds/{{.User.Name",
+			sd:      sd,
+			wantErr: true,
+		},
+		{
+			name:    "unknown-field",
+			path:    "database/creds/opened",
+			body:    `{"user": "{{.User.Phone}}"}`,
+			sd:      sd,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			lib := &privateLibrary{VaultPath: tt.path, HttpRequestBody: []byte(tt.body)}
+			data := newTemplateData("s_1234567890", tt.sd)
+			gotPath, err := lib.requestPath(data)
+			var gotBody []byte
+			if err == nil {
+				gotBody, err = lib.requestBody(data)
+			}
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantPath, gotPath)
+			assert.Equal(tt.wantBody, string(gotBody))
+		})
+	}
+}
+
+func TestValidTemplate(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(validTemplate(""))
+	assert.NoError(validTemplate("database/creds/opened"))
+	assert.NoError(validTemplate(`{"valid_principals": "{{.User.Name}}", "ttl": "{{.Ttl}}s"}`))
+	assert.Error(validTemplate("database/creds/{{.User.Name"))
+	assert.Error(validTemplate("database/creds/{{.User.Phone}}"))
+	assert.Error(validTemplate("database/creds/{{.Unknown}}"))
+}
+
+func TestPrivateLibrary_requestPathSent(t *testing.T) {
+	var gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.RequestURI
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	lib := &privateLibrary{VaultPath: "database/creds/{{.Account.FullName}}"}
+	data := newTemplateData("s_1234567890", &credential.SessionData{FullName: "Alice Smith"})
+	client, err := newClient(&clientConfig{Addr: srv.URL, Token: TokenSecret("token")})
+	require.NoError(t, err)
+
+	t.Run("get", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		path, err := lib.requestPath(data)
+		require.NoError(err)
+		_, err = client.get(path)
+		require.NoError(err)
+		assert.Equal("/v1/database/creds/Alice%20Smith", gotURI)
+	})
+	t.Run("post", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		path, err := lib.requestPath(data)
+		require.NoError(err)
+		_, err = client.post(path, nil)
+		require.NoError(err)
+		assert.Equal("/v1/database/creds/Alice%20Smith", gotURI)
+	})
+}
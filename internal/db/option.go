@@ -42,6 +42,8 @@ type Options struct {
 	withWhereClauseArgs []interface{}
 	withOrder           string
 
+	withStartPageAfterItem *string
+
 	// withPrngValues is used to switch the ID generation to a pseudo-random mode
 	withPrngValues []string
 
@@ -163,6 +165,16 @@ func WithOrder(withOrder string) Option {
 	}
 }
 
+// WithStartPageAfterItem provides an option to page through the results of a
+// search. The results are ordered by public_id and only the resources with a
+// public_id after publicId are returned. An empty publicId returns the first
+// page. It cannot be used together with WithOrder.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *Options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
 // WithPrngValues provides an option to provide values to seed an PRNG when generating IDs
 func WithPrngValues(withPrngValues []string) Option {
 	return func(o *Options) {
@@ -176,6 +176,18 @@ func Test_getOpts(t *testing.T) {
 		testOpts.withOrder = "version desc"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		// test default of nil
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+
+		opts = GetOpts(WithStartPageAfterItem("s_1234567890"))
+		id := "s_1234567890"
+		testOpts.withStartPageAfterItem = &id
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithGormFormatter", func(t *testing.T) {
 		assert := assert.New(t)
 		// test default of false
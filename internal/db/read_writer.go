@@ -1057,11 +1057,20 @@ func (rw *Db) SearchWhere(ctx context.Context, resources interface{}, where stri
 	if reflect.ValueOf(resources).Kind() != reflect.Ptr {
 		return errors.New(ctx, errors.InvalidParameter, op, "interface parameter must to be a pointer")
 	}
+	if opts.withOrder != "" && opts.withStartPageAfterItem != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "cannot use both an order and a page start")
+	}
 	var err error
 	db := rw.underlying.WithContext(ctx)
 	if opts.withOrder != "" {
 		db = db.Order(opts.withOrder)
 	}
+	if opts.withStartPageAfterItem != nil {
+		db = db.Order("public_id asc")
+		if *opts.withStartPageAfterItem != "" {
+			db = db.Where("public_id > ?", *opts.withStartPageAfterItem)
+		}
+	}
 	// Perform limiting
 	switch {
 	case opts.WithLimit < 0: // any negative number signals unlimited results
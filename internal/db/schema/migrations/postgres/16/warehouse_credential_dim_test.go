@@ -180,7 +180,7 @@ func testSessionCredentialParams(t *testing.T, conn *db.DB, kms *kms.Kms, wrappe
 
 	targetRepo, err := target.NewRepository(rw, rw, kms)
 	require.NoError(t, err)
-	_, _, _, err = targetRepo.AddTargetCredentialSources(ctx, tar.GetPublicId(), tar.GetVersion(), []string{libs[0].PublicId, libs[1].PublicId})
+	_, _, _, err = targetRepo.AddTargetCredentialSources(ctx, tar.GetPublicId(), tar.GetVersion(), target.CredentialSources{ApplicationCredentialIds: []string{libs[0].PublicId, libs[1].PublicId}})
 	require.NoError(t, err)
 	creds := []*session.DynamicCredential{
 		session.NewDynamicCredential(libs[0].GetPublicId(), credential.ApplicationPurpose),
@@ -0,0 +1,203 @@
+begin;
+
+  create table target_ssh (
+    public_id wt_public_id primary key
+      constraint target_fkey
+        references target (public_id)
+        on delete cascade
+        on update cascade,
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+        check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+        check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter wt_bexprfilter,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint target_ssh_scope_id_name_uq
+      unique(scope_id, name) -- name must be unique within a scope
+  );
+  comment on table target_ssh is
+    'target_ssh is a table where each row is a resource that represents an ssh target. '
+    'The worker terminates the ssh session of the client and opens the session to the '
+    'endpoint using the egress credentials of the session.';
+
+  create trigger insert_target_subtype before insert on target_ssh
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_ssh
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_ssh
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_ssh
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_ssh
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_ssh
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_ssh
+    for each row execute procedure target_scope_valid();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_ssh', 1);
+
+  -- replaces view from 1/01_server_tags_migrations.up.sql
+  drop view target_all_subtypes;
+  create view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type
+    from target_ssh;
+
+  -- replaces view from 15/02_wh_rename_host_dimension_org.up.sql
+  drop view whx_host_dimension_source;
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as organization_id,
+         coalesce(o.name, 'None')        as organization_name,
+         coalesce(o.description, 'None') as organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  -- replaces view from 16/02_wh_credential_dimension.up.sql
+  drop view whx_credential_dimension_source;
+  create view whx_credential_dimension_source as
+       select -- id is the first column in the target view
+              s.public_id                              as session_id,
+              coalesce(scd.credential_purpose, 'None') as credential_purpose,
+              cl.public_id                             as credential_library_id,
+              case
+                when vcl is null then 'None'
+                else 'vault credential library'
+                end                                    as credential_library_type,
+              coalesce(vcl.name, 'None')               as credential_library_name,
+              coalesce(vcl.description, 'None')        as credential_library_description,
+              coalesce(vcl.vault_path, 'None')         as credential_library_vault_path,
+              coalesce(vcl.http_method, 'None')        as credential_library_vault_http_method,
+              coalesce(vcl.http_request_body, 'None')  as credential_library_vault_http_request_body,
+              cs.public_id                             as credential_store_id,
+              case
+                when vcs is null then 'None'
+                else 'vault credential store'
+                end                                    as credential_store_type,
+              coalesce(vcs.name, 'None')               as credential_store_name,
+              coalesce(vcs.description, 'None')        as credential_store_description,
+              coalesce(vcs.namespace, 'None')          as credential_store_vault_namespace,
+              coalesce(vcs.vault_address, 'None')      as credential_store_vault_address,
+              t.public_id                              as target_id,
+              tt.type || ' target'                     as target_type,
+              coalesce(tt.name, 'None')                as target_name,
+              coalesce(tt.description, 'None')         as target_description,
+              coalesce(tt.default_port, 0)             as target_default_port_number,
+              tt.session_max_seconds                   as target_session_max_seconds,
+              tt.session_connection_limit              as target_session_connection_limit,
+              p.public_id                              as project_id,
+              coalesce(p.name, 'None')                 as project_name,
+              coalesce(p.description, 'None')          as project_description,
+              o.public_id                              as organization_id,
+              coalesce(o.name, 'None')                 as organization_name,
+              coalesce(o.description, 'None')          as organization_description
+       from session_credential_dynamic as scd,
+            session as s,
+            credential_library as cl,
+            credential_store as cs,
+            credential_vault_library as vcl,
+            credential_vault_store as vcs,
+            target as t,
+            target_all_subtypes as tt,
+            iam_scope as p,
+            iam_scope as o
+      where scd.library_id = cl.public_id
+        and cl.store_id = cs.public_id
+        and vcl.public_id = cl.public_id
+        and vcs.public_id = cs.public_id
+        and s.public_id = scd.session_id
+        and s.target_id = t.public_id
+        and t.public_id = tt.public_id
+        and p.public_id = t.scope_id
+        and p.type = 'project'
+        and o.public_id = p.parent_id
+        and o.type = 'org';
+
+commit;
@@ -0,0 +1,51 @@
+begin;
+
+  create table session_credential (
+    session_id wt_public_id not null
+      constraint session_fkey
+        references session (public_id)
+        on delete cascade
+        on update cascade,
+    credential bytea not null -- encrypted value
+      constraint credential_must_not_be_empty
+        check(length(credential) > 0),
+    key_id text not null
+      constraint kms_database_key_version_fkey
+        references kms_database_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    credential_sha256 bytea not null -- digest of the plaintext value
+      constraint credential_sha256_must_not_be_empty
+        check(length(credential_sha256) > 0),
+    create_time wt_timestamp,
+    constraint session_credential_session_id_credential_sha256_uq
+      unique(session_id, credential_sha256)
+  );
+  comment on table session_credential is
+    'session_credential is a table where each row contains an encrypted credential '
+    'that the worker handling a session uses to secure the connection between the worker '
+    'and the endpoint. These credentials are never returned to the user.';
+
+  create trigger default_create_time_column before insert on session_credential
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on session_credential
+    for each row execute procedure immutable_columns('session_id', 'credential', 'key_id', 'credential_sha256', 'create_time');
+
+  -- delete_session_credentials deletes the credentials for a session when
+  -- the session enters the canceling or terminated states.
+  create function delete_session_credentials()
+    returns trigger
+  as $$
+  begin
+    if new.state in ('canceling', 'terminated') then
+      delete from session_credential
+       where session_id = new.session_id;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  create trigger delete_session_credentials after insert on session_state
+    for each row execute procedure delete_session_credentials();
+
+commit;
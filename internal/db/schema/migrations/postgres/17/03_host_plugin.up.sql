@@ -0,0 +1,303 @@
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌─────────────────────┐
+  │      host       │          │  host_plugin_host   │
+  ├─────────────────┤          ├─────────────────────┤
+  │ public_id  (pk) │          │ public_id  (pk)     │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id (fk)     │┼┼─────────────────────┐
+  │                 │          │ external_id         │             ◀fk1      │
+  └─────────────────┘          │ address             │                       │
+          ╲│╱                  └─────────────────────┘                       │
+           ○                             ╲│╱                                 │
+           │                              ○                                  │
+           ┼                              │                                  ○
+           ┼                              ┼                                 ╱│╲
+  ┌─────────────────┐          ┌─────────────────────┐          ┌────────────────────────┐
+  │  host_catalog   │          │ host_plugin_catalog │          │ host_plugin_set_member │
+  ├─────────────────┤          ├─────────────────────┤          ├────────────────────────┤
+  │ public_id (pk)  │          │ public_id (pk)      │          │ host_id    (pk,fk1)    │
+  │ scope_id  (fk)  │┼┼──────○┼│ scope_id  (fk)      │          │ set_id     (pk,fk2)    │
+  │                 │          │ plugin_name         │          │ catalog_id (fk1,fk2)   │
+  └─────────────────┘          │ attributes          │          └────────────────────────┘
+           ┼                   └─────────────────────┘                      ╲│╱
+           ┼                              ┼                                  ○
+           │                              ┼                                  │
+           ○                              │                                  │
+          ╱│╲                             ○                                  │
+  ┌─────────────────┐                    ╱│╲                                 │
+  │    host_set     │          ┌─────────────────────┐                       │
+  ├─────────────────┤          │   host_plugin_set   │                       │
+  │ public_id  (pk) │          ├─────────────────────┤                       │
+  │ catalog_id (fk) │┼┼──────○┼│ public_id  (pk)     │             ◀fk2      │
+  │                 │          │ catalog_id (fk)     │┼┼─────────────────────┘
+  └─────────────────┘          │ attributes          │
+                               │ last_sync_time      │
+                               │ need_sync           │
+                               └─────────────────────┘
+
+*/
+
+  create table host_plugin_catalog (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    plugin_name text not null
+      constraint plugin_name_must_not_be_empty
+        check(length(trim(plugin_name)) > 0),
+    attributes bytea,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    foreign key (scope_id, public_id)
+      references host_catalog (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on host_plugin_catalog
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_catalog
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_catalog
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_catalog
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'plugin_name', 'create_time');
+
+  create trigger insert_host_catalog_subtype before insert on host_plugin_catalog
+    for each row execute procedure insert_host_catalog_subtype();
+
+  create trigger delete_host_catalog_subtype after delete on host_plugin_catalog
+    for each row execute procedure delete_host_catalog_subtype();
+
+  create table host_plugin_host (
+    public_id wt_public_id primary key,
+    catalog_id wt_public_id not null
+      references host_plugin_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    external_id text not null
+      constraint external_id_must_not_be_empty
+        check(length(trim(external_id)) > 0),
+    address text not null
+      constraint address_must_be_more_than_2_characters
+        check(length(trim(address)) > 2)
+      constraint address_must_be_less_than_256_characters
+        check(length(trim(address)) < 256),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, external_id),
+
+    foreign key (catalog_id, public_id)
+      references host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+
+    -- The order of columns is important for performance. See:
+    -- https://dba.stackexchange.com/questions/58970/enforcing-constraints-two-tables-away/58972#58972
+    -- https://dba.stackexchange.com/questions/27481/is-a-composite-index-also-good-for-queries-on-the-first-field
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on host_plugin_host
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_host
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_host
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_host
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'external_id', 'create_time');
+
+  create trigger insert_host_subtype before insert on host_plugin_host
+    for each row execute procedure insert_host_subtype();
+
+  create trigger delete_host_subtype after delete on host_plugin_host
+    for each row execute procedure delete_host_subtype();
+
+  create table host_plugin_set (
+    public_id wt_public_id primary key,
+    catalog_id wt_public_id not null
+      references host_plugin_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    attributes bytea,
+    last_sync_time timestamp with time zone,
+    need_sync boolean not null default true,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, name),
+    foreign key (catalog_id, public_id)
+      references host_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on host_plugin_set
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_set
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_set
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_set
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'create_time');
+
+  create trigger insert_host_set_subtype before insert on host_plugin_set
+    for each row execute procedure insert_host_set_subtype();
+
+  create trigger delete_host_set_subtype after delete on host_plugin_set
+    for each row execute procedure delete_host_set_subtype();
+
+  create table host_plugin_set_member (
+    host_id wt_public_id not null,
+    set_id wt_public_id not null,
+    catalog_id wt_public_id not null,
+    primary key(host_id, set_id),
+    foreign key (catalog_id, host_id) -- fk1
+      references host_plugin_host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (catalog_id, set_id) -- fk2
+      references host_plugin_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade
+  );
+
+  create trigger immutable_columns before update on host_plugin_set_member
+    for each row execute procedure immutable_columns('host_id', 'set_id', 'catalog_id');
+
+  create or replace function insert_host_plugin_set_member()
+    returns trigger
+  as $$
+  begin
+    select host_plugin_set.catalog_id
+      into new.catalog_id
+    from host_plugin_set
+    where host_plugin_set.public_id = new.set_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_host_plugin_set_member before insert on host_plugin_set_member
+    for each row execute procedure insert_host_plugin_set_member();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('host_plugin_catalog', 1),
+    ('host_plugin_host', 1),
+    ('host_plugin_set', 1),
+    ('host_plugin_set_member', 1);
+
+  -- replaces view from 17/01_target_ssh.up.sql
+  drop view whx_host_dimension_source;
+  create view whx_host_dimension_source as
+  with
+  host_members (host_id, host_type, host_name, host_description, host_address,
+                host_set_id, host_set_type, host_set_name, host_set_description,
+                host_catalog_id, host_catalog_type, host_catalog_name, host_catalog_description) as (
+    select h.public_id,
+           'static host',
+           h.name,
+           h.description,
+           h.address,
+           s.public_id,
+           'static host set',
+           s.name,
+           s.description,
+           c.public_id,
+           'static host catalog',
+           c.name,
+           c.description
+      from static_host as h,
+           static_host_catalog as c,
+           static_host_set_member as m,
+           static_host_set as s
+     where h.catalog_id = c.public_id
+       and h.public_id = m.host_id
+       and s.public_id = m.set_id
+     union
+    select h.public_id,
+           'plugin host',
+           h.name,
+           h.description,
+           h.address,
+           s.public_id,
+           'plugin host set',
+           s.name,
+           s.description,
+           c.public_id,
+           'plugin host catalog',
+           c.name,
+           c.description
+      from host_plugin_host as h,
+           host_plugin_catalog as c,
+           host_plugin_set_member as m,
+           host_plugin_set as s
+     where h.catalog_id = c.public_id
+       and h.public_id = m.host_id
+       and s.public_id = m.set_id
+  )
+  select -- id is the first column in the target view
+         h.host_id                                as host_id,
+         h.host_type                              as host_type,
+         coalesce(h.host_name, 'None')            as host_name,
+         coalesce(h.host_description, 'None')     as host_description,
+         coalesce(h.host_address, 'Unknown')      as host_address,
+         h.host_set_id                            as host_set_id,
+         h.host_set_type                          as host_set_type,
+         coalesce(h.host_set_name, 'None')        as host_set_name,
+         coalesce(h.host_set_description, 'None') as host_set_description,
+         h.host_catalog_id                        as host_catalog_id,
+         h.host_catalog_type                      as host_catalog_type,
+         coalesce(h.host_catalog_name, 'None')    as host_catalog_name,
+         coalesce(h.host_catalog_description, 'None') as host_catalog_description,
+         t.public_id                              as target_id,
+         t.type || ' target'                      as target_type,
+         coalesce(t.name, 'None')                 as target_name,
+         coalesce(t.description, 'None')          as target_description,
+         coalesce(t.default_port, 0)              as target_default_port_number,
+         t.session_max_seconds                    as target_session_max_seconds,
+         t.session_connection_limit               as target_session_connection_limit,
+         p.public_id                              as project_id,
+         coalesce(p.name, 'None')                 as project_name,
+         coalesce(p.description, 'None')          as project_description,
+         o.public_id                              as organization_id,
+         coalesce(o.name, 'None')                 as organization_name,
+         coalesce(o.description, 'None')          as organization_description
+    from host_members as h,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where t.public_id = ts.target_id
+     and h.host_set_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+commit;
@@ -0,0 +1,91 @@
+begin;
+
+  alter table target_tcp
+    add column session_recording_enabled boolean not null default false;
+
+  alter table session
+    add column recording_enabled boolean not null default false,
+    add column recording_path text
+      constraint recording_path_must_not_be_empty
+        check(length(trim(recording_path)) > 0);
+
+  comment on column session.recording_path is
+    'recording_path is the path of the directory on the worker which the '
+    'connections of the session are recorded to. It is set when a worker '
+    'activates a session which has recording enabled.';
+
+  -- replaces the trigger from 1/01_server_tags_migrations.up.sql to add
+  -- recording_enabled
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'recording_enabled');
+
+  -- replaces view from 17/01_target_ssh.up.sql to add
+  -- session_recording_enabled. The view is replaced rather than dropped since
+  -- the warehouse views depend on it, which requires the new column to be last.
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled
+    from target_ssh;
+
+  -- replaces view from 1/01_server_tags_migrations.up.sql to add
+  -- recording_enabled and recording_path
+  drop view session_with_state;
+  create view session_with_state as
+  select s.public_id,
+         s.user_id,
+         s.host_id,
+         s.server_id,
+         s.server_type,
+         s.target_id,
+         s.host_set_id,
+         s.auth_token_id,
+         s.scope_id,
+         s.certificate,
+         s.expiration_time,
+         s.connection_limit,
+         s.tofu_token,
+         s.key_id,
+         s.termination_reason,
+         s.version,
+         s.create_time,
+         s.update_time,
+         s.endpoint,
+         s.worker_filter,
+         s.recording_enabled,
+         s.recording_path,
+         ss.state,
+         ss.previous_end_time,
+         ss.start_time,
+         ss.end_time
+    from session s,
+         session_state ss
+   where s.public_id = ss.session_id;
+
+commit;
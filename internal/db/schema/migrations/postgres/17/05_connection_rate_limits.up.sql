@@ -0,0 +1,118 @@
+begin;
+
+  -- upload_rate_limit and download_rate_limit are in bytes per second, and 0
+  -- means the rate is unlimited.
+  alter table target_tcp
+    add column upload_rate_limit bigint not null default 0
+      constraint upload_rate_limit_must_not_be_negative
+        check(upload_rate_limit >= 0),
+    add column download_rate_limit bigint not null default 0
+      constraint download_rate_limit_must_not_be_negative
+        check(download_rate_limit >= 0);
+
+  alter table session
+    add column upload_rate_limit bigint not null default 0
+      constraint upload_rate_limit_must_not_be_negative
+        check(upload_rate_limit >= 0),
+    add column download_rate_limit bigint not null default 0
+      constraint download_rate_limit_must_not_be_negative
+        check(download_rate_limit >= 0);
+
+  comment on column session.upload_rate_limit is
+    'upload_rate_limit is the max rate, in bytes per second, at which the '
+    'worker sends data from the client to the endpoint of a connection of the '
+    'session. 0 means unlimited.';
+  comment on column session.download_rate_limit is
+    'download_rate_limit is the max rate, in bytes per second, at which the '
+    'worker sends data from the endpoint to the client of a connection of the '
+    'session. 0 means unlimited.';
+
+  -- replaces the trigger from 17/04_session_recording.up.sql to add
+  -- upload_rate_limit and download_rate_limit
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'recording_enabled', 'upload_rate_limit', 'download_rate_limit');
+
+  -- replaces view from 17/04_session_recording.up.sql to add
+  -- upload_rate_limit and download_rate_limit.
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled,
+         upload_rate_limit,
+         download_rate_limit
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit
+    from target_ssh;
+
+  -- replaces view from 17/04_session_recording.up.sql to add
+  -- upload_rate_limit and download_rate_limit
+  drop view session_with_state;
+  create view session_with_state as
+  select s.public_id,
+         s.user_id,
+         s.host_id,
+         s.server_id,
+         s.server_type,
+         s.target_id,
+         s.host_set_id,
+         s.auth_token_id,
+         s.scope_id,
+         s.certificate,
+         s.expiration_time,
+         s.connection_limit,
+         s.tofu_token,
+         s.key_id,
+         s.termination_reason,
+         s.version,
+         s.create_time,
+         s.update_time,
+         s.endpoint,
+         s.worker_filter,
+         s.recording_enabled,
+         s.recording_path,
+         s.upload_rate_limit,
+         s.download_rate_limit,
+         ss.state,
+         ss.previous_end_time,
+         ss.start_time,
+         ss.end_time
+    from session s,
+         session_state ss
+   where s.public_id = ss.session_id;
+
+  comment on column session_connection.bytes_up is
+    'bytes_up is the number of bytes received by the worker from the client '
+    'and sent to the endpoint. It is updated with the running count while the '
+    'connection is open, and with the final count when it is closed.';
+  comment on column session_connection.bytes_down is
+    'bytes_down is the number of bytes received by the worker from the '
+    'endpoint and sent to the client. It is updated with the running count '
+    'while the connection is open, and with the final count when it is closed.';
+
+commit;
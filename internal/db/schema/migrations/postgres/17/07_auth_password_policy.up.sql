@@ -0,0 +1,163 @@
+begin;
+
+-- Password policy settings for password auth methods. A value of 0 for any
+-- of the minimum character class counts, the history count or the maximum
+-- failed attempts disables that part of the policy.
+alter table auth_password_method
+  add column min_uppercase int not null default 0
+    constraint min_uppercase_not_negative
+      check(min_uppercase >= 0),
+  add column min_lowercase int not null default 0
+    constraint min_lowercase_not_negative
+      check(min_lowercase >= 0),
+  add column min_digits int not null default 0
+    constraint min_digits_not_negative
+      check(min_digits >= 0),
+  add column min_symbols int not null default 0
+    constraint min_symbols_not_negative
+      check(min_symbols >= 0),
+  add column password_history_count int not null default 0
+    constraint password_history_count_in_range
+      check(password_history_count between 0 and 24),
+  add column max_failed_attempts int not null default 0
+    constraint max_failed_attempts_not_negative
+      check(max_failed_attempts >= 0),
+  add column lockout_duration int not null default 900 -- seconds
+    constraint lockout_duration_must_be_greater_than_0
+      check(lockout_duration > 0);
+
+-- password_change_required is set by an administrator to force the account
+-- to choose a new password the next time it authenticates.
+alter table auth_password_account
+  add column password_change_required bool not null default false;
+
+-- Replaces view from 2/20_pass.up.sql
+drop view auth_password_method_with_is_primary;
+create view auth_password_method_with_is_primary as
+select
+  case when s.primary_auth_method_id is not null then
+    true
+  else false end
+  as is_primary_auth_method,
+  am.public_id,
+  am.scope_id,
+  am.password_conf_id,
+  am.name,
+  am.description,
+  am.create_time,
+  am.update_time,
+  am.version,
+  am.min_login_name_length,
+  am.min_password_length,
+  am.min_uppercase,
+  am.min_lowercase,
+  am.min_digits,
+  am.min_symbols,
+  am.password_history_count,
+  am.max_failed_attempts,
+  am.lockout_duration
+from
+  auth_password_method am
+  left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+comment on view auth_password_method_with_is_primary is
+'password auth method with an is_primary_auth_method bool';
+
+-- Replaces view from 0/14_auth_password_views.up.sql
+drop view auth_password_current_conf;
+create view auth_password_current_conf as
+    select pm.min_login_name_length, pm.min_password_length,
+           pm.min_uppercase, pm.min_lowercase, pm.min_digits, pm.min_symbols,
+           pm.password_history_count, pm.max_failed_attempts, pm.lockout_duration,
+           c.*
+      from auth_password_method pm
+inner join auth_password_conf_union c
+        on pm.password_conf_id = c.password_conf_id;
+
+-- auth_password_account_lockout tracks failed authentication attempts for a
+-- password account. It is kept separate from auth_password_account so
+-- recording a failed attempt does not change the version of the account.
+create table auth_password_account_lockout (
+  password_account_id wt_public_id primary key
+    constraint auth_password_account_fkey
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+  failed_attempts int not null default 0
+    constraint failed_attempts_not_negative
+      check(failed_attempts >= 0),
+  last_failed_time wt_timestamp,
+  locked_until timestamp with time zone
+);
+comment on table auth_password_account_lockout is
+'auth_password_account_lockout is a table where each row contains the failed authentication attempts for a password account. '
+'An account is locked while locked_until is in the future.';
+
+-- auth_password_argon2_cred_history contains the previous argon2 credentials
+-- for a password account. Rows are inserted when a credential is deleted.
+create table auth_password_argon2_cred_history (
+  private_id wt_private_id primary key,
+  password_account_id wt_public_id not null
+    constraint auth_password_account_fkey
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+  password_conf_id wt_private_id not null
+    constraint auth_password_argon2_conf_fkey
+      references auth_password_argon2_conf (private_id)
+      on delete cascade
+      on update cascade,
+  salt bytea not null, -- encrypted
+  derived_key bytea not null,
+  key_id text not null
+    constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+  create_time wt_timestamp
+);
+comment on table auth_password_argon2_cred_history is
+'auth_password_argon2_cred_history is a table where each row contains a previous argon2 credential for a password account. '
+'Only the 24 most recent credentials are kept for an account.';
+
+create index auth_password_argon2_cred_history_account_time_idx
+  on auth_password_argon2_cred_history (password_account_id, create_time desc);
+
+create trigger default_create_time_column before insert on auth_password_argon2_cred_history
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on auth_password_argon2_cred_history
+  for each row execute procedure immutable_columns('private_id', 'password_account_id', 'password_conf_id', 'salt', 'derived_key', 'key_id', 'create_time');
+
+create function insert_auth_password_argon2_cred_history() returns trigger
+as $$
+begin
+  -- The credential is also deleted when its account is deleted, in which
+  -- case there is no history to keep.
+  perform from auth_password_account where public_id = old.password_account_id;
+  if not found then
+    return null;
+  end if;
+
+  insert into auth_password_argon2_cred_history
+    (private_id, password_account_id, password_conf_id, salt, derived_key, key_id)
+  values
+    (old.private_id, old.password_account_id, old.password_conf_id, old.salt, old.derived_key, old.key_id);
+
+  delete from auth_password_argon2_cred_history
+   where password_account_id = old.password_account_id
+     and private_id not in (
+           select private_id
+             from auth_password_argon2_cred_history
+            where password_account_id = old.password_account_id
+         order by create_time desc
+            limit 24
+         );
+  return null;
+end;
+$$ language plpgsql;
+comment on function insert_auth_password_argon2_cred_history() is
+'insert_auth_password_argon2_cred_history() is an after delete trigger function that copies the deleted credential '
+'into auth_password_argon2_cred_history and keeps only the 24 most recent credentials for the account.';
+
+create trigger insert_auth_password_argon2_cred_history after delete on auth_password_argon2_cred
+  for each row execute procedure insert_auth_password_argon2_cred_history();
+
+commit;
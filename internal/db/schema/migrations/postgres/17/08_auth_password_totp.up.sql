@@ -0,0 +1,80 @@
+begin;
+
+-- totp_required requires every account of the password auth method to
+-- authenticate with a TOTP code in addition to its password.
+alter table auth_password_method
+  add column totp_required bool not null default false;
+
+-- Replaces view from 17/07_auth_password_policy.up.sql
+drop view auth_password_method_with_is_primary;
+create view auth_password_method_with_is_primary as
+select
+  case when s.primary_auth_method_id is not null then
+    true
+  else false end
+  as is_primary_auth_method,
+  am.public_id,
+  am.scope_id,
+  am.password_conf_id,
+  am.name,
+  am.description,
+  am.create_time,
+  am.update_time,
+  am.version,
+  am.min_login_name_length,
+  am.min_password_length,
+  am.min_uppercase,
+  am.min_lowercase,
+  am.min_digits,
+  am.min_symbols,
+  am.password_history_count,
+  am.max_failed_attempts,
+  am.lockout_duration,
+  am.totp_required
+from
+  auth_password_method am
+  left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+comment on view auth_password_method_with_is_primary is
+'password auth method with an is_primary_auth_method bool';
+
+-- auth_password_totp contains the TOTP secret for a password account. The
+-- secret is only used to verify codes once the enrollment has been confirmed
+-- with a valid code.
+create table auth_password_totp (
+  password_account_id wt_public_id primary key
+    constraint auth_password_account_fkey
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+  secret bytea not null, -- encrypted
+  key_id text not null
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version (private_id)
+      on delete restrict
+      on update cascade,
+  confirmed bool not null default false,
+  -- last_used_step is the time step of the last code accepted for the
+  -- account, used to prevent a code from being used more than once.
+  last_used_step bigint not null default 0
+    constraint last_used_step_not_negative
+      check(last_used_step >= 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+comment on table auth_password_totp is
+'auth_password_totp is a table where each row contains the encrypted TOTP secret for a password account.';
+
+create trigger default_create_time_column before insert on auth_password_totp
+  for each row execute procedure default_create_time();
+
+create trigger update_time_column before update on auth_password_totp
+  for each row execute procedure update_time_column();
+
+create trigger immutable_columns before update on auth_password_totp
+  for each row execute procedure immutable_columns('password_account_id', 'secret', 'key_id', 'create_time');
+
+insert into oplog_ticket (name, version)
+values
+  ('auth_password_totp', 1);
+
+commit;
@@ -0,0 +1,133 @@
+begin;
+
+-- The key of a key version is rewrapped when the keys of its scope are
+-- rotated or when the root kms changes, so the key and the root key version
+-- which encrypts it are no longer immutable.
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_root_key_version;
+create trigger immutable_columns before update on kms_root_key_version
+  for each row execute procedure immutable_columns('private_id', 'root_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_database_key_version;
+create trigger immutable_columns before update on kms_database_key_version
+  for each row execute procedure immutable_columns('private_id', 'database_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_oplog_key_version;
+create trigger immutable_columns before update on kms_oplog_key_version
+  for each row execute procedure immutable_columns('private_id', 'oplog_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_session_key_version;
+create trigger immutable_columns before update on kms_session_key_version
+  for each row execute procedure immutable_columns('private_id', 'session_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_token_key_version;
+create trigger immutable_columns before update on kms_token_key_version
+  for each row execute procedure immutable_columns('private_id', 'token_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 1/03_kms.up.sql
+drop trigger immutable_columns on kms_oidc_key_version;
+create trigger immutable_columns before update on kms_oidc_key_version
+  for each row execute procedure immutable_columns('private_id', 'oidc_key_id', 'version', 'create_time');
+
+-- Values encrypted by a database key version are rewrapped with the current
+-- database key version before the old version is destroyed, so the encrypted
+-- values and their key ids are no longer immutable.
+
+-- Replaces trigger from 17/02_session_credential.up.sql
+drop trigger immutable_columns on session_credential;
+create trigger immutable_columns before update on session_credential
+  for each row execute procedure immutable_columns('session_id', 'credential_sha256', 'create_time');
+
+-- Replaces trigger from 17/07_auth_password_policy.up.sql
+drop trigger immutable_columns on auth_password_argon2_cred_history;
+create trigger immutable_columns before update on auth_password_argon2_cred_history
+  for each row execute procedure immutable_columns('private_id', 'password_account_id', 'password_conf_id', 'derived_key', 'create_time');
+
+-- Replaces trigger from 17/08_auth_password_totp.up.sql
+drop trigger immutable_columns on auth_password_totp;
+create trigger immutable_columns before update on auth_password_totp
+  for each row execute procedure immutable_columns('password_account_id', 'create_time');
+
+-- Replaces trigger from 10/04_vault_credential.up.sql
+drop trigger immutable_columns on credential_vault_token;
+create trigger immutable_columns before update on credential_vault_token
+  for each row execute procedure immutable_columns('token_hmac', 'store_id','create_time');
+
+-- kms_data_key_version_destruction_job contains the database key versions
+-- which are being destroyed. A database key version is destroyed once every
+-- value it encrypts has been rewrapped with the current database key version.
+create table kms_data_key_version_destruction_job (
+  key_id wt_private_id primary key
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version (private_id)
+      on delete cascade
+      on update cascade,
+  create_time wt_timestamp
+);
+comment on table kms_data_key_version_destruction_job is
+'kms_data_key_version_destruction_job is a table where each row represents a database key version which is being destroyed.';
+
+create trigger default_create_time_column before insert on kms_data_key_version_destruction_job
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on kms_data_key_version_destruction_job
+  for each row execute procedure immutable_columns('key_id', 'create_time');
+
+-- kms_data_key_version_destruction_job_run tracks the progress of rewrapping
+-- the values of one table which are encrypted by the database key version
+-- being destroyed.
+create table kms_data_key_version_destruction_job_run (
+  key_id wt_private_id not null
+    constraint kms_data_key_version_destruction_job_fkey
+      references kms_data_key_version_destruction_job (key_id)
+      on delete cascade
+      on update cascade,
+  table_name text not null
+    constraint table_name_must_not_be_empty
+      check(length(trim(table_name)) > 0),
+  total_count bigint not null
+    constraint total_count_must_not_be_negative
+      check(total_count >= 0),
+  completed_count bigint not null default 0
+    constraint completed_count_must_be_between_zero_and_total_count
+      check(completed_count >= 0 and completed_count <= total_count),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  primary key(key_id, table_name)
+);
+comment on table kms_data_key_version_destruction_job_run is
+'kms_data_key_version_destruction_job_run is a table where each row contains the progress of rewrapping the values of a table '
+'which are encrypted by a database key version that is being destroyed.';
+
+create trigger default_create_time_column before insert on kms_data_key_version_destruction_job_run
+  for each row execute procedure default_create_time();
+
+create trigger update_time_column before update on kms_data_key_version_destruction_job_run
+  for each row execute procedure update_time_column();
+
+create trigger immutable_columns before update on kms_data_key_version_destruction_job_run
+  for each row execute procedure immutable_columns('key_id', 'table_name', 'total_count', 'create_time');
+
+create view kms_data_key_version_destruction_job_progress as
+select
+  j.key_id,
+  rk.scope_id,
+  j.create_time,
+  coalesce(sum(r.completed_count), 0) as completed_count,
+  coalesce(sum(r.total_count), 0)     as total_count
+from
+  kms_data_key_version_destruction_job j
+  join kms_database_key_version dkv on dkv.private_id = j.key_id
+  join kms_database_key dk          on dk.private_id = dkv.database_key_id
+  join kms_root_key rk              on rk.private_id = dk.root_key_id
+  left outer join kms_data_key_version_destruction_job_run r on r.key_id = j.key_id
+group by j.key_id, rk.scope_id, j.create_time;
+comment on view kms_data_key_version_destruction_job_progress is
+'kms_data_key_version_destruction_job_progress contains the scope and the overall progress of each database key version destruction job.';
+
+commit;
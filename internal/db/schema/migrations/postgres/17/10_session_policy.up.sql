@@ -0,0 +1,72 @@
+begin;
+
+-- session_policy entries limit the sessions users can have on the targets of
+-- an org or project scope. The policies of an org apply to the sessions of all
+-- the projects of the org, counted together. A null limit means the policy
+-- does not limit that aspect of the sessions.
+create table session_policy (
+  public_id wt_public_id
+    primary key,
+  scope_id wt_scope_id
+    not null
+    constraint iam_scope_fkey
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+  name wt_name,
+  description wt_description,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  max_active_sessions_per_user int
+    constraint max_active_sessions_per_user_must_be_greater_than_zero
+      check(max_active_sessions_per_user > 0),
+  max_daily_session_seconds_per_user int
+    constraint max_daily_session_seconds_per_user_must_be_greater_than_zero
+      check(max_daily_session_seconds_per_user > 0),
+  constraint session_policy_scope_id_name_uq
+    unique(scope_id, name)
+);
+comment on table session_policy is
+'session_policy entries limit the sessions users can have on the targets of an org or project scope.';
+
+create trigger update_version_column after update on session_policy
+  for each row execute procedure update_version_column();
+
+create trigger update_time_column before update on session_policy
+  for each row execute procedure update_time_column();
+
+create trigger default_create_time_column before insert on session_policy
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on session_policy
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+-- session_policy_scope_valid is a before insert trigger function for
+-- session_policy which ensures the policy is owned by an org or a project.
+create or replace function
+  session_policy_scope_valid()
+  returns trigger
+as $$
+begin
+  perform from iam_scope
+   where public_id = new.scope_id
+     and type in ('org', 'project');
+  if not found then
+    raise exception 'invalid scope type for session policy';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  session_policy_scope_valid
+before insert on session_policy
+  for each row execute procedure session_policy_scope_valid();
+
+-- The session policies are checked against the sessions of a user each time
+-- the user authorizes a session.
+create index session_user_id_scope_id_ix
+  on session (user_id, scope_id);
+
+commit;
@@ -0,0 +1,248 @@
+begin;
+
+  -- session_approval_required makes the sessions of a target wait for the
+  -- approval of a user other than the one who requested the session.
+  -- session_approval_window_seconds is the time in which a session must be
+  -- approved and its authorization retrieved; 0 means the window ends when the
+  -- session expires.
+  alter table target_tcp
+    add column session_approval_required boolean not null default false,
+    add column session_approval_window_seconds int not null default 0
+      constraint session_approval_window_seconds_must_not_be_negative
+        check(session_approval_window_seconds >= 0);
+
+  alter table session
+    add column approval_expiration_time timestamp with time zone;
+
+  comment on column session.approval_expiration_time is
+    'approval_expiration_time is set when the session requires approval. The '
+    'session must be approved and its authorization retrieved before this time. '
+    'Null means the session does not require approval.';
+
+  -- replaces the trigger from 17/05_connection_rate_limits.up.sql to add
+  -- approval_expiration_time
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'recording_enabled', 'upload_rate_limit', 'download_rate_limit', 'approval_expiration_time');
+
+  alter table session_state_enm
+    drop constraint only_predefined_session_states_allowed;
+  alter table session_state_enm
+    add constraint only_predefined_session_states_allowed
+      check (
+        name in ('pending', 'active', 'canceling', 'terminated', 'pending-approval')
+      );
+  insert into session_state_enm (name)
+  values
+    ('pending-approval');
+
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled',
+          'approval denied',
+          'approval expired'
+        )
+      );
+  insert into session_termination_reason_enm (name)
+  values
+    ('approval denied'),
+    ('approval expired');
+
+  -- replaces function from 0/50_session.up.sql. A session which requires
+  -- approval starts in the pending-approval state, and only moves to the
+  -- pending state, from which a worker can activate it, once approved.
+  create or replace function
+    insert_new_session_state()
+    returns trigger
+  as $$
+  begin
+    if new.approval_expiration_time is not null then
+      insert into session_state (session_id, state)
+      values
+        (new.public_id, 'pending-approval');
+      return new;
+    end if;
+    insert into session_state (session_id, state)
+    values
+      (new.public_id, 'pending');
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- session_approval records the decision on a session which requires
+  -- approval. There is at most one decision per session.
+  create table session_approval (
+    session_id wt_public_id primary key
+      references session (public_id)
+      on delete cascade
+      on update cascade,
+    -- the user who approved or denied the session
+    approver_id text
+      references iam_user (public_id)
+      on delete set null
+      on update cascade,
+    decision text not null
+      constraint session_approval_decision_valid
+        check(decision in ('approved', 'denied')),
+    -- the time the user of an approved session retrieved its authorization.
+    -- null until retrieved.
+    authorization_time timestamp with time zone,
+    create_time wt_timestamp
+  );
+
+  comment on table session_approval is
+    'session_approval entries record the decision on a session which requires approval.';
+
+  create trigger default_create_time_column before insert on session_approval
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on session_approval
+    for each row execute procedure immutable_columns('session_id', 'approver_id', 'decision', 'create_time');
+
+  -- insert_session_approval is a before insert trigger function for
+  -- session_approval. It ensures the session is waiting for approval within
+  -- its approval window and that the approver is not the user of the session.
+  create or replace function
+    insert_session_approval()
+    returns trigger
+  as $$
+  begin
+    perform from session s
+      join session_state ss on ss.session_id = s.public_id
+     where s.public_id = new.session_id
+       and ss.state = 'pending-approval'
+       and ss.end_time is null
+       and s.approval_expiration_time > now();
+    if not found then
+      raise exception 'session % is not pending approval', new.session_id;
+    end if;
+    perform from session
+     where public_id = new.session_id
+       and user_id = new.approver_id;
+    if found then
+      raise exception 'session % cannot be approved by its own user', new.session_id;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_session_approval before insert on session_approval
+    for each row execute procedure insert_session_approval();
+
+  -- apply_session_approval is an after insert trigger function for
+  -- session_approval. An approved session moves to the pending state, a denied
+  -- session is terminated.
+  create or replace function
+    apply_session_approval()
+    returns trigger
+  as $$
+  begin
+    if new.decision = 'approved' then
+      insert into session_state (session_id, state)
+      values
+        (new.session_id, 'pending');
+      return new;
+    end if;
+    update session
+       set termination_reason = 'approval denied'
+     where public_id = new.session_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger apply_session_approval after insert on session_approval
+    for each row execute procedure apply_session_approval();
+
+  -- replaces view from 17/05_connection_rate_limits.up.sql to add
+  -- session_approval_required and session_approval_window_seconds
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled,
+         upload_rate_limit,
+         download_rate_limit,
+         session_approval_required,
+         session_approval_window_seconds
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds
+    from target_ssh;
+
+  -- replaces view from 17/05_connection_rate_limits.up.sql to add
+  -- approval_expiration_time and the approver of the session
+  drop view session_with_state;
+  create view session_with_state as
+  select s.public_id,
+         s.user_id,
+         s.host_id,
+         s.server_id,
+         s.server_type,
+         s.target_id,
+         s.host_set_id,
+         s.auth_token_id,
+         s.scope_id,
+         s.certificate,
+         s.expiration_time,
+         s.connection_limit,
+         s.tofu_token,
+         s.key_id,
+         s.termination_reason,
+         s.version,
+         s.create_time,
+         s.update_time,
+         s.endpoint,
+         s.worker_filter,
+         s.recording_enabled,
+         s.recording_path,
+         s.upload_rate_limit,
+         s.download_rate_limit,
+         s.approval_expiration_time,
+         sa.approver_id,
+         ss.state,
+         ss.previous_end_time,
+         ss.start_time,
+         ss.end_time
+    from session s
+    join session_state ss
+      on s.public_id = ss.session_id
+    left join session_approval sa
+      on s.public_id = sa.session_id;
+
+commit;
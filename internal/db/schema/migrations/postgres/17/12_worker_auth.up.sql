@@ -0,0 +1,97 @@
+begin;
+
+-- server_worker entries are the workers which authenticate to the controllers
+-- with their own credentials instead of the shared worker-auth KMS. A worker is
+-- created with a one-time activation token. The worker presents the token on
+-- its first contact with a controller, which issues the worker's first
+-- credential and removes the token.
+create table server_worker (
+  public_id wt_public_id
+    primary key,
+  scope_id wt_scope_id
+    not null
+    default 'global'
+    constraint scope_id_must_be_global
+      check(scope_id = 'global')
+    constraint iam_scope_global_fkey
+      references iam_scope_global (scope_id)
+      on delete cascade
+      on update cascade,
+  name wt_name
+    not null
+    constraint server_worker_name_must_be_lowercase
+      check(lower(trim(name)) = name)
+    constraint server_worker_name_uq
+      unique,
+  description wt_description,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  -- the activation token, encrypted by the database key version key_id. Both
+  -- are null once the worker is activated.
+  ct_activation_token bytea,
+  key_id text
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version (private_id)
+      on delete restrict
+      on update cascade,
+  activation_token_expiration_time timestamp with time zone,
+  -- the time the worker presented its activation token. Null until then.
+  activation_time timestamp with time zone,
+  constraint activation_token_and_key_id_set_together
+    check(
+      (ct_activation_token is null and key_id is null)
+      or
+      (ct_activation_token is not null and key_id is not null)
+    )
+);
+comment on table server_worker is
+'server_worker entries are the workers which authenticate to the controllers with their own credentials.';
+
+create trigger update_version_column after update on server_worker
+  for each row execute procedure update_version_column();
+
+create trigger update_time_column before update on server_worker
+  for each row execute procedure update_time_column();
+
+create trigger default_create_time_column before insert on server_worker
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on server_worker
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'name', 'create_time');
+
+-- server_worker_credential entries are the credentials issued to the workers.
+-- A worker encrypts the information it authenticates to a controller with
+-- using the key of one of its credentials. A worker usually has a single
+-- credential; the previous one is kept until it expires shortly after the
+-- credential is rotated.
+create table server_worker_credential (
+  public_id wt_public_id
+    primary key,
+  worker_id wt_public_id
+    not null
+    constraint server_worker_fkey
+      references server_worker (public_id)
+      on delete cascade
+      on update cascade,
+  -- the AES-256 key of the credential, encrypted by the database key version
+  -- key_id
+  ct_key bytea not null,
+  key_id text not null
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version (private_id)
+      on delete restrict
+      on update cascade,
+  expiration_time timestamp with time zone not null,
+  create_time wt_timestamp
+);
+comment on table server_worker_credential is
+'server_worker_credential entries are the credentials issued to the workers which authenticate with their own credentials.';
+
+create trigger default_create_time_column before insert on server_worker_credential
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on server_worker_credential
+  for each row execute procedure immutable_columns('public_id', 'worker_id', 'create_time');
+
+commit;
@@ -0,0 +1,62 @@
+begin;
+
+-- server_worker_tag entries are the tags of a worker set through the
+-- controller API. They are merged with the tags in the configuration of the
+-- worker, which are stored in server_tag, when the worker filter of a target
+-- is evaluated.
+create table server_worker_tag (
+  worker_id wt_public_id
+    constraint server_worker_fkey
+      references server_worker (public_id)
+      on delete cascade
+      on update cascade,
+  key wt_tagpair,
+  value wt_tagpair,
+  create_time wt_timestamp,
+  primary key(worker_id, key, value)
+);
+comment on table server_worker_tag is
+'server_worker_tag entries are the tags of a worker set through the controller API.';
+
+create trigger default_create_time_column before insert on server_worker_tag
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on server_worker_tag
+  for each row execute procedure immutable_columns('worker_id', 'key', 'value', 'create_time');
+
+-- server_worker_aggregate contains the workers along with the address they
+-- last reported to a controller and the time of their last status report.
+-- The name of a worker is its server id.
+create view server_worker_aggregate as
+select w.public_id,
+       w.scope_id,
+       w.name,
+       w.description,
+       w.create_time,
+       w.update_time,
+       w.version,
+       w.activation_token_expiration_time,
+       w.activation_time,
+       s.address,
+       s.update_time as last_status_time
+  from server_worker w
+  left join server s
+    on s.private_id = w.name
+   and s.type = 'worker';
+
+-- server_worker_canonical_tag contains the tags of the workers from their
+-- configuration and the tags set through the controller API, by server id.
+create view server_worker_canonical_tag as
+select server_id,
+       key,
+       value
+  from server_tag
+ union
+select w.name as server_id,
+       t.key,
+       t.value
+  from server_worker_tag t
+  join server_worker w
+    on w.public_id = t.worker_id;
+
+commit;
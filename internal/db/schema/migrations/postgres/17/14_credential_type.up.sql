@@ -0,0 +1,98 @@
+begin;
+
+  create table credential_type_enm (
+    name text primary key
+      constraint only_predefined_credential_types_allowed
+      check (
+        name in (
+          'unspecified',
+          'username_password',
+          'ssh_private_key',
+          'certificate'
+        )
+      )
+  );
+  comment on table credential_type_enm is
+    'credential_type_enm is an enumeration table for the types of credentials a credential library provides.';
+
+  insert into credential_type_enm (name)
+  values
+    ('unspecified'),
+    ('username_password'),
+    ('ssh_private_key'),
+    ('certificate');
+
+  -- The *_attribute columns override the name of the field in the vault
+  -- secret which holds each part of the typed credential. They can only be
+  -- set for the credential types which have that part.
+  alter table credential_vault_library
+    add column credential_type text not null default 'unspecified'
+      constraint credential_type_enm_fkey
+        references credential_type_enm (name)
+        on delete restrict
+        on update cascade,
+    add column username_attribute text
+      constraint username_attribute_must_not_be_empty
+        check(length(trim(username_attribute)) > 0),
+    add column password_attribute text
+      constraint password_attribute_must_not_be_empty
+        check(length(trim(password_attribute)) > 0),
+    add column private_key_attribute text
+      constraint private_key_attribute_must_not_be_empty
+        check(length(trim(private_key_attribute)) > 0),
+    add column certificate_attribute text
+      constraint certificate_attribute_must_not_be_empty
+        check(length(trim(certificate_attribute)) > 0),
+    add constraint mapping_overrides_must_match_credential_type
+      check(
+        (username_attribute is null or credential_type in ('username_password', 'ssh_private_key'))
+        and
+        (password_attribute is null or credential_type = 'username_password')
+        and
+        (private_key_attribute is null or credential_type in ('ssh_private_key', 'certificate'))
+        and
+        (certificate_attribute is null or credential_type = 'certificate')
+      );
+
+  drop trigger immutable_columns on credential_vault_library;
+  create trigger immutable_columns before update on credential_vault_library
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'credential_type', 'create_time');
+
+  drop view credential_vault_library_private;
+     create view credential_vault_library_private as
+     select library.public_id             as public_id,
+            library.store_id              as store_id,
+            library.name                  as name,
+            library.description           as description,
+            library.create_time           as create_time,
+            library.update_time           as update_time,
+            library.version               as version,
+            library.vault_path            as vault_path,
+            library.http_method           as http_method,
+            library.http_request_body     as http_request_body,
+            library.credential_type       as credential_type,
+            library.username_attribute    as username_attribute,
+            library.password_attribute    as password_attribute,
+            library.private_key_attribute as private_key_attribute,
+            library.certificate_attribute as certificate_attribute,
+            store.scope_id                as scope_id,
+            store.vault_address           as vault_address,
+            store.namespace               as namespace,
+            store.ca_cert                 as ca_cert,
+            store.tls_server_name         as tls_server_name,
+            store.tls_skip_verify         as tls_skip_verify,
+            store.token_hmac              as token_hmac,
+            store.ct_token                as ct_token, -- encrypted
+            store.token_key_id            as token_key_id,
+            store.client_cert             as client_cert,
+            store.ct_client_key           as ct_client_key, -- encrypted
+            store.client_key_id           as client_key_id
+       from credential_vault_library library
+       join credential_vault_store_private store
+         on library.store_id = store.public_id
+        and store.token_status = 'current';
+  comment on view credential_vault_library_private is
+    'credential_vault_library_private is a view where each row contains a credential library and the credential library''s data needed to connect to Vault. '
+    'Each row may contain encrypted data. This view should not be used to retrieve data which will be returned external to boundary.';
+
+commit;
@@ -0,0 +1,28 @@
+begin;
+
+  alter table credential_type_enm
+    drop constraint only_predefined_credential_types_allowed;
+  alter table credential_type_enm
+    add constraint only_predefined_credential_types_allowed
+      check (
+        name in (
+          'unspecified',
+          'username_password',
+          'ssh_private_key',
+          'certificate',
+          'ssh_certificate'
+        )
+      );
+
+  insert into credential_type_enm (name)
+  values
+    ('ssh_certificate');
+
+  -- A library which provides ssh_certificate credentials sends a generated
+  -- public key to the sign endpoint of a Vault SSH secrets engine, which only
+  -- accepts POST requests.
+  alter table credential_vault_library
+    add constraint ssh_certificate_requires_post
+      check(credential_type <> 'ssh_certificate' or http_method = 'POST');
+
+commit;
@@ -0,0 +1,123 @@
+begin;
+
+  create table target_udp (
+    public_id wt_public_id primary key
+      constraint target_fkey
+        references target (public_id)
+        on delete cascade
+        on update cascade,
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+        check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+        check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter wt_bexprfilter,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint target_udp_scope_id_name_uq
+      unique(scope_id, name) -- name must be unique within a scope
+  );
+  comment on table target_udp is
+    'target_udp is a table where each row is a resource that represents a udp target. '
+    'The worker proxies the datagrams of the client to the endpoint. Each flow of '
+    'datagrams from a client address is a connection of the session which is closed '
+    'when the flow is idle.';
+
+  create trigger insert_target_subtype before insert on target_udp
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_udp
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_udp
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_udp
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_udp
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_udp
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_udp
+    for each row execute procedure target_scope_valid();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_udp', 1);
+
+  -- replaces view from 17/11_session_approval.up.sql to add target_udp
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled,
+         upload_rate_limit,
+         download_rate_limit,
+         session_approval_required,
+         session_approval_window_seconds
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds
+    from target_ssh
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'udp' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds
+    from target_udp;
+
+commit;
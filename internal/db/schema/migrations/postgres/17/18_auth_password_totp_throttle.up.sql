@@ -0,0 +1,13 @@
+begin;
+
+-- failed_attempts and locked_until throttle the TOTP codes tried for an
+-- account, independently of the lockout settings of its auth method: once
+-- failed_attempts reaches the limit enforced by the controller, no code is
+-- accepted for the account until locked_until has passed.
+alter table auth_password_totp
+  add column failed_attempts integer not null default 0
+    constraint failed_attempts_not_negative
+      check(failed_attempts >= 0),
+  add column locked_until timestamp with time zone;
+
+commit;
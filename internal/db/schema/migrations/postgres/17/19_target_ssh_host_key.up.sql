@@ -0,0 +1,127 @@
+begin;
+
+  -- host_key is the public key, in the authorized_keys format, which the
+  -- endpoint of an ssh target must present as its host key. The worker refuses
+  -- to connect to the endpoint while it is null.
+  alter table target_ssh
+    add column host_key text
+      constraint host_key_must_not_be_empty
+        check(length(trim(host_key)) > 0);
+
+  alter table session
+    add column endpoint_host_key text;
+
+  comment on column session.endpoint_host_key is
+    'endpoint_host_key is the public key, in the authorized_keys format, which '
+    'the endpoint of the session must present as its ssh host key. It is only '
+    'set for the sessions of ssh targets.';
+
+  -- replaces the trigger from 17/11_session_approval.up.sql to add
+  -- endpoint_host_key
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'recording_enabled', 'upload_rate_limit', 'download_rate_limit', 'approval_expiration_time', 'endpoint_host_key');
+
+  -- replaces view from 17/17_target_udp.up.sql to add host_key
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled,
+         upload_rate_limit,
+         download_rate_limit,
+         session_approval_required,
+         session_approval_window_seconds,
+         null as host_key
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds,
+         host_key
+    from target_ssh
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'udp' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds,
+         null as host_key
+    from target_udp;
+
+  -- replaces view from 17/11_session_approval.up.sql to add endpoint_host_key
+  drop view session_with_state;
+  create view session_with_state as
+  select s.public_id,
+         s.user_id,
+         s.host_id,
+         s.server_id,
+         s.server_type,
+         s.target_id,
+         s.host_set_id,
+         s.auth_token_id,
+         s.scope_id,
+         s.certificate,
+         s.expiration_time,
+         s.connection_limit,
+         s.tofu_token,
+         s.key_id,
+         s.termination_reason,
+         s.version,
+         s.create_time,
+         s.update_time,
+         s.endpoint,
+         s.worker_filter,
+         s.recording_enabled,
+         s.recording_path,
+         s.upload_rate_limit,
+         s.download_rate_limit,
+         s.approval_expiration_time,
+         s.endpoint_host_key,
+         sa.approver_id,
+         ss.state,
+         ss.previous_end_time,
+         ss.start_time,
+         ss.end_time
+    from session s
+    join session_state ss
+      on s.public_id = ss.session_id
+    left join session_approval sa
+      on s.public_id = sa.session_id;
+
+commit;
@@ -0,0 +1,32 @@
+begin;
+
+  alter table server
+    add column recording_public_key bytea;
+
+  comment on column server.recording_public_key is
+    'recording_public_key is the PEM encoded public key which verifies the '
+    'recordings made by a worker, as last reported by the worker. The worker '
+    'keeps its signing key outside of the directory it stores the recordings '
+    'in, so the recordings are verified with this key rather than with a key '
+    'found next to them.';
+
+  -- replaces view from 17/13_worker_tags.up.sql to add recording_public_key
+  create or replace view server_worker_aggregate as
+  select w.public_id,
+         w.scope_id,
+         w.name,
+         w.description,
+         w.create_time,
+         w.update_time,
+         w.version,
+         w.activation_token_expiration_time,
+         w.activation_time,
+         s.address,
+         s.update_time as last_status_time,
+         s.recording_public_key
+    from server_worker w
+    left join server s
+      on s.private_id = w.name
+     and s.type = 'worker';
+
+commit;
@@ -0,0 +1,10 @@
+begin;
+
+  -- Every flow of datagrams from a client address is a connection of the
+  -- session, and a flow is closed once it is idle, so a limit of 1 stops a
+  -- session of a udp target from working after its first flow. The session
+  -- connection limit of udp targets defaults to -1 (unlimited) instead.
+  alter table target_udp
+    alter column session_connection_limit set default -1;
+
+commit;
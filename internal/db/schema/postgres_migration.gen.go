@@ -4,7 +4,7 @@ package schema
 
 func init() {
 	migrationStates["postgres"] = migrationState{
-		binarySchemaVersion: 16005,
+		binarySchemaVersion: 17021,
 		upMigrations: map[int][]byte{
 			1: []byte(`
 create domain wt_public_id as text
@@ -7464,6 +7464,2694 @@ alter table wh_session_connection_accumulating_fact
     after insert on session_connection
     for each row
     execute function wh_insert_session_connection();
+`),
+			17001: []byte(`
+create table target_ssh (
+    public_id wt_public_id primary key
+      constraint target_fkey
+        references target (public_id)
+        on delete cascade
+        on update cascade,
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+        check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+        check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter wt_bexprfilter,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint target_ssh_scope_id_name_uq
+      unique(scope_id, name) -- name must be unique within a scope
+  );
+  comment on table target_ssh is
+    'target_ssh is a table where each row is a resource that represents an ssh target. '
+    'The worker terminates the ssh session of the client and opens the session to the '
+    'endpoint using the egress credentials of the session.';
+
+  create trigger insert_target_subtype before insert on target_ssh
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_ssh
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_ssh
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_ssh
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_ssh
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_ssh
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_ssh
+    for each row execute procedure target_scope_valid();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_ssh', 1);
+
+  -- replaces view from 1/01_server_tags_migrations.up.sql
+  drop view target_all_subtypes;
+  create view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type
+    from target_ssh;
+
+  -- replaces view from 15/02_wh_rename_host_dimension_org.up.sql
+  drop view whx_host_dimension_source;
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as organization_id,
+         coalesce(o.name, 'None')        as organization_name,
+         coalesce(o.description, 'None') as organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  -- replaces view from 16/02_wh_credential_dimension.up.sql
+  drop view whx_credential_dimension_source;
+  create view whx_credential_dimension_source as
+       select -- id is the first column in the target view
+              s.public_id                              as session_id,
+              coalesce(scd.credential_purpose, 'None') as credential_purpose,
+              cl.public_id                             as credential_library_id,
+              case
+                when vcl is null then 'None'
+                else 'vault credential library'
+                end                                    as credential_library_type,
+              coalesce(vcl.name, 'None')               as credential_library_name,
+              coalesce(vcl.description, 'None')        as credential_library_description,
+              coalesce(vcl.vault_path, 'None')         as credential_library_vault_path,
+              coalesce(vcl.http_method, 'None')        as credential_library_vault_http_method,
+              coalesce(vcl.http_request_body, 'None')  as credential_library_vault_http_request_body,
+              cs.public_id                             as credential_store_id,
+              case
+                when vcs is null then 'None'
+                else 'vault credential store'
+                end                                    as credential_store_type,
+              coalesce(vcs.name, 'None')               as credential_store_name,
+              coalesce(vcs.description, 'None')        as credential_store_description,
+              coalesce(vcs.namespace, 'None')          as credential_store_vault_namespace,
+              coalesce(vcs.vault_address, 'None')      as credential_store_vault_address,
+              t.public_id                              as target_id,
+              tt.type || ' target'                     as target_type,
+              coalesce(tt.name, 'None')                as target_name,
+              coalesce(tt.description, 'None')         as target_description,
+              coalesce(tt.default_port, 0)             as target_default_port_number,
+              tt.session_max_seconds                   as target_session_max_seconds,
+              tt.session_connection_limit              as target_session_connection_limit,
+              p.public_id                              as project_id,
+              coalesce(p.name, 'None')                 as project_name,
+              coalesce(p.description, 'None')          as project_description,
+              o.public_id                              as organization_id,
+              coalesce(o.name, 'None')                 as organization_name,
+              coalesce(o.description, 'None')          as organization_description
+       from session_credential_dynamic as scd,
+            session as s,
+            credential_library as cl,
+            credential_store as cs,
+            credential_vault_library as vcl,
+            credential_vault_store as vcs,
+            target as t,
+            target_all_subtypes as tt,
+            iam_scope as p,
+            iam_scope as o
+      where scd.library_id = cl.public_id
+        and cl.store_id = cs.public_id
+        and vcl.public_id = cl.public_id
+        and vcs.public_id = cs.public_id
+        and s.public_id = scd.session_id
+        and s.target_id = t.public_id
+        and t.public_id = tt.public_id
+        and p.public_id = t.scope_id
+        and p.type = 'project'
+        and o.public_id = p.parent_id
+        and o.type = 'org';
+`),
+			17002: []byte(`
+create table session_credential (
+    session_id wt_public_id not null
+      constraint session_fkey
+        references session (public_id)
+        on delete cascade
+        on update cascade,
+    credential bytea not null -- encrypted value
+      constraint credential_must_not_be_empty
+        check(length(credential) > 0),
+    key_id text not null
+      constraint kms_database_key_version_fkey
+        references kms_database_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    credential_sha256 bytea not null -- digest of the plaintext value
+      constraint credential_sha256_must_not_be_empty
+        check(length(credential_sha256) > 0),
+    create_time wt_timestamp,
+    constraint session_credential_session_id_credential_sha256_uq
+      unique(session_id, credential_sha256)
+  );
+  comment on table session_credential is
+    'session_credential is a table where each row contains an encrypted credential '
+    'that the worker handling a session uses to secure the connection between the worker '
+    'and the endpoint. These credentials are never returned to the user.';
+
+  create trigger default_create_time_column before insert on session_credential
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on session_credential
+    for each row execute procedure immutable_columns('session_id', 'credential', 'key_id', 'credential_sha256', 'create_time');
+
+  -- delete_session_credentials deletes the credentials for a session when
+  -- the session enters the canceling or terminated states.
+  create function delete_session_credentials()
+    returns trigger
+  as $$
+  begin
+    if new.state in ('canceling', 'terminated') then
+      delete from session_credential
+       where session_id = new.session_id;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  create trigger delete_session_credentials after insert on session_state
+    for each row execute procedure delete_session_credentials();
+`),
+			17003: []byte(`
+/*
+
+  ┌─────────────────┐          ┌─────────────────────┐
+  │      host       │          │  host_plugin_host   │
+  ├─────────────────┤          ├─────────────────────┤
+  │ public_id  (pk) │          │ public_id  (pk)     │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id (fk)     │┼┼─────────────────────┐
+  │                 │          │ external_id         │             ◀fk1      │
+  └─────────────────┘          │ address             │                       │
+          ╲│╱                  └─────────────────────┘                       │
+           ○                             ╲│╱                                 │
+           │                              ○                                  │
+           ┼                              │                                  ○
+           ┼                              ┼                                 ╱│╲
+  ┌─────────────────┐          ┌─────────────────────┐          ┌────────────────────────┐
+  │  host_catalog   │          │ host_plugin_catalog │          │ host_plugin_set_member │
+  ├─────────────────┤          ├─────────────────────┤          ├────────────────────────┤
+  │ public_id (pk)  │          │ public_id (pk)      │          │ host_id    (pk,fk1)    │
+  │ scope_id  (fk)  │┼┼──────○┼│ scope_id  (fk)      │          │ set_id     (pk,fk2)    │
+  │                 │          │ plugin_name         │          │ catalog_id (fk1,fk2)   │
+  └─────────────────┘          │ attributes          │          └────────────────────────┘
+           ┼                   └─────────────────────┘                      ╲│╱
+           ┼                              ┼                                  ○
+           │                              ┼                                  │
+           ○                              │                                  │
+          ╱│╲                             ○                                  │
+  ┌─────────────────┐                    ╱│╲                                 │
+  │    host_set     │          ┌─────────────────────┐                       │
+  ├─────────────────┤          │   host_plugin_set   │                       │
+  │ public_id  (pk) │          ├─────────────────────┤                       │
+  │ catalog_id (fk) │┼┼──────○┼│ public_id  (pk)     │             ◀fk2      │
+  │                 │          │ catalog_id (fk)     │┼┼─────────────────────┘
+  └─────────────────┘          │ attributes          │
+                               │ last_sync_time      │
+                               │ need_sync           │
+                               └─────────────────────┘
+
+*/
+
+  create table host_plugin_catalog (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    plugin_name text not null
+      constraint plugin_name_must_not_be_empty
+        check(length(trim(plugin_name)) > 0),
+    attributes bytea,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    foreign key (scope_id, public_id)
+      references host_catalog (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on host_plugin_catalog
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_catalog
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_catalog
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_catalog
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'plugin_name', 'create_time');
+
+  create trigger insert_host_catalog_subtype before insert on host_plugin_catalog
+    for each row execute procedure insert_host_catalog_subtype();
+
+  create trigger delete_host_catalog_subtype after delete on host_plugin_catalog
+    for each row execute procedure delete_host_catalog_subtype();
+
+  create table host_plugin_host (
+    public_id wt_public_id primary key,
+    catalog_id wt_public_id not null
+      references host_plugin_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    external_id text not null
+      constraint external_id_must_not_be_empty
+        check(length(trim(external_id)) > 0),
+    address text not null
+      constraint address_must_be_more_than_2_characters
+        check(length(trim(address)) > 2)
+      constraint address_must_be_less_than_256_characters
+        check(length(trim(address)) < 256),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, external_id),
+
+    foreign key (catalog_id, public_id)
+      references host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+
+    -- The order of columns is important for performance. See:
+    -- https://dba.stackexchange.com/questions/58970/enforcing-constraints-two-tables-away/58972#58972
+    -- https://dba.stackexchange.com/questions/27481/is-a-composite-index-also-good-for-queries-on-the-first-field
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on host_plugin_host
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_host
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_host
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_host
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'external_id', 'create_time');
+
+  create trigger insert_host_subtype before insert on host_plugin_host
+    for each row execute procedure insert_host_subtype();
+
+  create trigger delete_host_subtype after delete on host_plugin_host
+    for each row execute procedure delete_host_subtype();
+
+  create table host_plugin_set (
+    public_id wt_public_id primary key,
+    catalog_id wt_public_id not null
+      references host_plugin_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    attributes bytea,
+    last_sync_time timestamp with time zone,
+    need_sync boolean not null default true,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, name),
+    foreign key (catalog_id, public_id)
+      references host_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on host_plugin_set
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_set
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_set
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_set
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'create_time');
+
+  create trigger insert_host_set_subtype before insert on host_plugin_set
+    for each row execute procedure insert_host_set_subtype();
+
+  create trigger delete_host_set_subtype after delete on host_plugin_set
+    for each row execute procedure delete_host_set_subtype();
+
+  create table host_plugin_set_member (
+    host_id wt_public_id not null,
+    set_id wt_public_id not null,
+    catalog_id wt_public_id not null,
+    primary key(host_id, set_id),
+    foreign key (catalog_id, host_id) -- fk1
+      references host_plugin_host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (catalog_id, set_id) -- fk2
+      references host_plugin_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade
+  );
+
+  create trigger immutable_columns before update on host_plugin_set_member
+    for each row execute procedure immutable_columns('host_id', 'set_id', 'catalog_id');
+
+  create or replace function insert_host_plugin_set_member()
+    returns trigger
+  as $$
+  begin
+    select host_plugin_set.catalog_id
+      into new.catalog_id
+    from host_plugin_set
+    where host_plugin_set.public_id = new.set_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_host_plugin_set_member before insert on host_plugin_set_member
+    for each row execute procedure insert_host_plugin_set_member();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('host_plugin_catalog', 1),
+    ('host_plugin_host', 1),
+    ('host_plugin_set', 1),
+    ('host_plugin_set_member', 1);
+
+  -- replaces view from 17/01_target_ssh.up.sql
+  drop view whx_host_dimension_source;
+  create view whx_host_dimension_source as
+  with
+  host_members (host_id, host_type, host_name, host_description, host_address,
+                host_set_id, host_set_type, host_set_name, host_set_description,
+                host_catalog_id, host_catalog_type, host_catalog_name, host_catalog_description) as (
+    select h.public_id,
+           'static host',
+           h.name,
+           h.description,
+           h.address,
+           s.public_id,
+           'static host set',
+           s.name,
+           s.description,
+           c.public_id,
+           'static host catalog',
+           c.name,
+           c.description
+      from static_host as h,
+           static_host_catalog as c,
+           static_host_set_member as m,
+           static_host_set as s
+     where h.catalog_id = c.public_id
+       and h.public_id = m.host_id
+       and s.public_id = m.set_id
+     union
+    select h.public_id,
+           'plugin host',
+           h.name,
+           h.description,
+           h.address,
+           s.public_id,
+           'plugin host set',
+           s.name,
+           s.description,
+           c.public_id,
+           'plugin host catalog',
+           c.name,
+           c.description
+      from host_plugin_host as h,
+           host_plugin_catalog as c,
+           host_plugin_set_member as m,
+           host_plugin_set as s
+     where h.catalog_id = c.public_id
+       and h.public_id = m.host_id
+       and s.public_id = m.set_id
+  )
+  select -- id is the first column in the target view
+         h.host_id                                as host_id,
+         h.host_type                              as host_type,
+         coalesce(h.host_name, 'None')            as host_name,
+         coalesce(h.host_description, 'None')     as host_description,
+         coalesce(h.host_address, 'Unknown')      as host_address,
+         h.host_set_id                            as host_set_id,
+         h.host_set_type                          as host_set_type,
+         coalesce(h.host_set_name, 'None')        as host_set_name,
+         coalesce(h.host_set_description, 'None') as host_set_description,
+         h.host_catalog_id                        as host_catalog_id,
+         h.host_catalog_type                      as host_catalog_type,
+         coalesce(h.host_catalog_name, 'None')    as host_catalog_name,
+         coalesce(h.host_catalog_description, 'None') as host_catalog_description,
+         t.public_id                              as target_id,
+         t.type || ' target'                      as target_type,
+         coalesce(t.name, 'None')                 as target_name,
+         coalesce(t.description, 'None')          as target_description,
+         coalesce(t.default_port, 0)              as target_default_port_number,
+         t.session_max_seconds                    as target_session_max_seconds,
+         t.session_connection_limit               as target_session_connection_limit,
+         p.public_id                              as project_id,
+         coalesce(p.name, 'None')                 as project_name,
+         coalesce(p.description, 'None')          as project_description,
+         o.public_id                              as organization_id,
+         coalesce(o.name, 'None')                 as organization_name,
+         coalesce(o.description, 'None')          as organization_description
+    from host_members as h,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where t.public_id = ts.target_id
+     and h.host_set_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+`),
+			17004: []byte(`
+alter table target_tcp
+    add column session_recording_enabled boolean not null default false;
+
+  alter table session
+    add column recording_enabled boolean not null default false,
+    add column recording_path text
+      constraint recording_path_must_not_be_empty
+        check(length(trim(recording_path)) > 0);
+
+  comment on column session.recording_path is
+    'recording_path is the path of the directory on the worker which the '
+    'connections of the session are recorded to. It is set when a worker '
+    'activates a session which has recording enabled.';
+
+  -- replaces the trigger from 1/01_server_tags_migrations.up.sql to add
+  -- recording_enabled
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'recording_enabled');
+
+  -- replaces view from 17/01_target_ssh.up.sql to add
+  -- session_recording_enabled. The view is replaced rather than dropped since
+  -- the warehouse views depend on it, which requires the new column to be last.
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled
+    from target_ssh;
+
+  -- replaces view from 1/01_server_tags_migrations.up.sql to add
+  -- recording_enabled and recording_path
+  drop view session_with_state;
+  create view session_with_state as
+  select s.public_id,
+         s.user_id,
+         s.host_id,
+         s.server_id,
+         s.server_type,
+         s.target_id,
+         s.host_set_id,
+         s.auth_token_id,
+         s.scope_id,
+         s.certificate,
+         s.expiration_time,
+         s.connection_limit,
+         s.tofu_token,
+         s.key_id,
+         s.termination_reason,
+         s.version,
+         s.create_time,
+         s.update_time,
+         s.endpoint,
+         s.worker_filter,
+         s.recording_enabled,
+         s.recording_path,
+         ss.state,
+         ss.previous_end_time,
+         ss.start_time,
+         ss.end_time
+    from session s,
+         session_state ss
+   where s.public_id = ss.session_id;
+`),
+			17005: []byte(`
+-- upload_rate_limit and download_rate_limit are in bytes per second, and 0
+  -- means the rate is unlimited.
+  alter table target_tcp
+    add column upload_rate_limit bigint not null default 0
+      constraint upload_rate_limit_must_not_be_negative
+        check(upload_rate_limit >= 0),
+    add column download_rate_limit bigint not null default 0
+      constraint download_rate_limit_must_not_be_negative
+        check(download_rate_limit >= 0);
+
+  alter table session
+    add column upload_rate_limit bigint not null default 0
+      constraint upload_rate_limit_must_not_be_negative
+        check(upload_rate_limit >= 0),
+    add column download_rate_limit bigint not null default 0
+      constraint download_rate_limit_must_not_be_negative
+        check(download_rate_limit >= 0);
+
+  comment on column session.upload_rate_limit is
+    'upload_rate_limit is the max rate, in bytes per second, at which the '
+    'worker sends data from the client to the endpoint of a connection of the '
+    'session. 0 means unlimited.';
+  comment on column session.download_rate_limit is
+    'download_rate_limit is the max rate, in bytes per second, at which the '
+    'worker sends data from the endpoint to the client of a connection of the '
+    'session. 0 means unlimited.';
+
+  -- replaces the trigger from 17/04_session_recording.up.sql to add
+  -- upload_rate_limit and download_rate_limit
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'recording_enabled', 'upload_rate_limit', 'download_rate_limit');
+
+  -- replaces view from 17/04_session_recording.up.sql to add
+  -- upload_rate_limit and download_rate_limit.
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled,
+         upload_rate_limit,
+         download_rate_limit
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit
+    from target_ssh;
+
+  -- replaces view from 17/04_session_recording.up.sql to add
+  -- upload_rate_limit and download_rate_limit
+  drop view session_with_state;
+  create view session_with_state as
+  select s.public_id,
+         s.user_id,
+         s.host_id,
+         s.server_id,
+         s.server_type,
+         s.target_id,
+         s.host_set_id,
+         s.auth_token_id,
+         s.scope_id,
+         s.certificate,
+         s.expiration_time,
+         s.connection_limit,
+         s.tofu_token,
+         s.key_id,
+         s.termination_reason,
+         s.version,
+         s.create_time,
+         s.update_time,
+         s.endpoint,
+         s.worker_filter,
+         s.recording_enabled,
+         s.recording_path,
+         s.upload_rate_limit,
+         s.download_rate_limit,
+         ss.state,
+         ss.previous_end_time,
+         ss.start_time,
+         ss.end_time
+    from session s,
+         session_state ss
+   where s.public_id = ss.session_id;
+
+  comment on column session_connection.bytes_up is
+    'bytes_up is the number of bytes received by the worker from the client '
+    'and sent to the endpoint. It is updated with the running count while the '
+    'connection is open, and with the final count when it is closed.';
+  comment on column session_connection.bytes_down is
+    'bytes_down is the number of bytes received by the worker from the '
+    'endpoint and sent to the client. It is updated with the running count '
+    'while the connection is open, and with the final count when it is closed.';
+`),
+			17006: []byte(`
+-- auth_ldap_method entries are the current ldap auth methods configured for
+-- existing scopes.
+create table auth_ldap_method (
+  public_id wt_public_id
+    primary key,
+  scope_id wt_scope_id
+    not null,
+  name wt_name,
+  description wt_description,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  start_tls bool not null default false,
+  insecure_tls bool not null default false,
+  discover_dn bool not null default false,
+  anon_group_search bool not null default false,
+  upn_domain text
+    constraint upn_domain_not_empty
+      check(length(trim(upn_domain)) > 0),
+  user_dn text
+    constraint user_dn_not_empty
+      check(length(trim(user_dn)) > 0),
+  user_attr text
+    constraint user_attr_not_empty
+      check(length(trim(user_attr)) > 0),
+  user_filter text
+    constraint user_filter_not_empty
+      check(length(trim(user_filter)) > 0),
+  group_dn text
+    constraint group_dn_not_empty
+      check(length(trim(group_dn)) > 0),
+  group_attr text
+    constraint group_attr_not_empty
+      check(length(trim(group_attr)) > 0),
+  group_filter text
+    constraint group_filter_not_empty
+      check(length(trim(group_filter)) > 0),
+  bind_dn text
+    constraint bind_dn_not_empty
+      check(length(trim(bind_dn)) > 0),
+  bind_password bytea, -- encrypted password used with bind_dn when searching the directory.
+  bind_password_hmac text
+    constraint bind_password_hmac_not_empty
+      check(length(trim(bind_password_hmac)) > 0),
+  key_id wt_private_id -- key used to encrypt entries via wrapping wrapper.
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version(private_id)
+      on delete restrict
+      on update cascade,
+  constraint bind_password_requires_bind_dn
+    check(bind_password is null or bind_dn is not null),
+  constraint auth_method_fkey
+    foreign key (scope_id, public_id)
+      references auth_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+  constraint auth_ldap_method_scope_id_name_uq
+    unique(scope_id, name),
+  constraint auth_ldap_method_scope_id_public_id_uq
+    unique(scope_id, public_id)
+);
+comment on table auth_ldap_method is
+'auth_ldap_method entries are the current ldap auth methods configured for existing scopes.';
+
+-- auth_ldap_url entries are the ldap server urls for an ldap auth method.
+-- Connections are attempted in ascending connection_priority order.
+create table auth_ldap_url (
+  create_time wt_timestamp,
+  ldap_method_id wt_public_id
+    constraint auth_ldap_method_fkey
+      references auth_ldap_method(public_id)
+      on delete cascade
+      on update cascade,
+  connection_priority int not null
+    constraint connection_priority_must_be_greater_than_zero
+      check(connection_priority > 0),
+  url wt_url not null
+    constraint url_must_use_ldap_or_ldaps
+      check(url ~ '^ldaps?://'),
+  primary key(ldap_method_id, url),
+  constraint auth_ldap_url_ldap_method_id_connection_priority_uq
+    unique(ldap_method_id, connection_priority)
+);
+comment on table auth_ldap_url is
+'auth_ldap_url entries are the ldap server urls for an ldap auth method.  Connections are attempted in ascending connection_priority order.';
+
+-- auth_ldap_certificate entries are optional PEM encoded x509 certificates.
+-- If an auth method has any cert entries, they are used as trust anchors when
+-- connecting to the auth method's ldap servers (instead of the host system's
+-- cert chain).
+create table auth_ldap_certificate (
+  create_time wt_timestamp,
+  ldap_method_id wt_public_id
+    constraint auth_ldap_method_fkey
+      references auth_ldap_method(public_id)
+      on delete cascade
+      on update cascade,
+  certificate bytea not null,
+  primary key(ldap_method_id, certificate)
+);
+comment on table auth_ldap_certificate is
+'auth_ldap_certificate entries are optional PEM encoded x509 certificates used as trust anchors when connecting to the auth methods ldap servers.';
+
+-- auth_ldap_account entries are subtypes of auth_account and represent an
+-- ldap account.
+create table auth_ldap_account (
+  public_id wt_public_id
+    primary key,
+  auth_method_id wt_public_id
+    not null,
+  -- The scope_id type is not wt_scope_id because the domain check is executed
+  -- before the insert trigger which retrieves the scope_id causing an insert
+  -- to fail.
+  scope_id text not null,
+  name wt_name,
+  description wt_description,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  login_name text not null
+    constraint login_name_must_not_be_empty
+      check(length(trim(login_name)) > 0)
+    constraint login_name_must_be_lowercase
+      check(lower(trim(login_name)) = login_name),
+  full_name wt_full_name, -- may be null and is set from the user's directory entry
+  email wt_email, -- may be null and is set from the user's directory entry
+  dn text -- the distinguished name of the user's directory entry
+    constraint dn_must_not_be_empty
+      check(length(trim(dn)) > 0),
+  member_of_groups text -- json array of the group names found for the user
+    constraint member_of_groups_must_not_be_empty
+      check(length(trim(member_of_groups)) > 0),
+  constraint auth_ldap_method_fkey
+    foreign key (scope_id, auth_method_id)
+      references auth_ldap_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+  constraint auth_account_fkey
+    foreign key (scope_id, auth_method_id, public_id)
+      references auth_account (scope_id, auth_method_id, public_id)
+      on delete cascade
+      on update cascade,
+  constraint auth_ldap_account_auth_method_id_name_uq
+    unique(auth_method_id, name),
+  -- ###############################################################
+  -- any change to this constraints name must be aligned with the
+  -- acctUpsertQuery const in internal/auth/ldap/query.go
+  -- ###############################################################
+  constraint auth_ldap_account_auth_method_id_login_name_uq
+    unique(auth_method_id, login_name),
+  constraint auth_ldap_account_auth_method_id_public_id_uq
+    unique(auth_method_id, public_id)
+);
+comment on table auth_ldap_account is
+'auth_ldap_account entries are subtypes of auth_account and represent an ldap account.';
+
+-- auth_ldap_method triggers
+create trigger
+  insert_auth_method_subtype
+before insert on auth_ldap_method
+  for each row execute procedure insert_auth_method_subtype();
+
+create trigger
+  update_auth_method_subtype
+before update on auth_ldap_method
+  for each row execute procedure update_auth_method_subtype();
+
+create trigger
+  delete_auth_method_subtype
+after delete on auth_ldap_method
+  for each row execute procedure delete_auth_method_subtype();
+
+create trigger
+  update_time_column
+before update on auth_ldap_method
+  for each row execute procedure update_time_column();
+
+create trigger
+  immutable_columns
+before update on auth_ldap_method
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before insert on auth_ldap_method
+  for each row execute procedure default_create_time();
+
+create trigger
+  update_version_column
+after update on auth_ldap_method
+  for each row execute procedure update_version_column();
+
+-- auth_ldap_method children table triggers
+create trigger
+  default_create_time_column
+before insert on auth_ldap_url
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before update on auth_ldap_url
+  for each row execute procedure immutable_columns('ldap_method_id', 'url', 'create_time');
+
+create trigger
+  default_create_time_column
+before insert on auth_ldap_certificate
+  for each row execute procedure default_create_time();
+
+-- auth_ldap_account triggers
+create trigger
+  update_time_column
+before update on auth_ldap_account
+  for each row execute procedure update_time_column();
+
+create trigger
+  immutable_columns
+before update on auth_ldap_account
+  for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'scope_id', 'create_time', 'login_name');
+
+create trigger
+  default_create_time_column
+before insert on auth_ldap_account
+  for each row execute procedure default_create_time();
+
+create trigger
+  update_version_column
+after update on auth_ldap_account
+  for each row execute procedure update_version_column();
+
+-- insert_auth_ldap_account_subtype is intended as a before insert trigger on
+-- auth_ldap_account. Like insert_auth_oidc_account_subtype, it does nothing on
+-- conflicts with the base auth_account, because ldap accounts use predictable
+-- public ids and are upserted during authentication.
+create or replace function
+  insert_auth_ldap_account_subtype()
+  returns trigger
+as $$
+begin
+  select auth_method.scope_id
+    into new.scope_id
+  from auth_method
+  where auth_method.public_id = new.auth_method_id;
+
+  insert into auth_account
+    (public_id, auth_method_id, scope_id)
+  values
+    (new.public_id, new.auth_method_id, new.scope_id)
+  on conflict do nothing;
+
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  insert_auth_ldap_account_subtype
+before insert on auth_ldap_account
+  for each row execute procedure insert_auth_ldap_account_subtype();
+
+-- ldap_auth_method_with_value_obj is useful for reading an ldap auth method
+-- with its associated value objects (urls and certs) as columns with |
+-- delimited values.  The urls are aggregated in connection_priority order. The
+-- view also includes an is_primary_auth_method bool
+create view ldap_auth_method_with_value_obj as
+select
+  case when s.primary_auth_method_id is not null then
+    true
+  else false end
+  as is_primary_auth_method,
+  am.public_id,
+  am.scope_id,
+  am.name,
+  am.description,
+  am.create_time,
+  am.update_time,
+  am.version,
+  am.start_tls,
+  am.insecure_tls,
+  am.discover_dn,
+  am.anon_group_search,
+  am.upn_domain,
+  am.user_dn,
+  am.user_attr,
+  am.user_filter,
+  am.group_dn,
+  am.group_attr,
+  am.group_filter,
+  am.bind_dn,
+  am.bind_password,
+  am.bind_password_hmac,
+  am.key_id,
+  -- the string_agg(..) column will be null if there are no associated value objects
+  string_agg(distinct concat_ws('=', lpad(url.connection_priority::text, 10, '0'), url.url), '|') as urls,
+  string_agg(distinct cert.certificate, '|') as certs
+from
+  auth_ldap_method am
+  left outer join iam_scope             s     on am.public_id = s.primary_auth_method_id
+  left outer join auth_ldap_url         url   on am.public_id = url.ldap_method_id
+  left outer join auth_ldap_certificate cert  on am.public_id = cert.ldap_method_id
+group by am.public_id, is_primary_auth_method; -- there can be only one public_id + is_primary_auth_method, so group by isn't a problem.
+comment on view ldap_auth_method_with_value_obj is
+'ldap auth method with its associated value objects (urls, certs) as columns with | delimited values';
+
+-- auth_ldap_managed_group entries are subtypes of auth_managed_group. Accounts
+-- are members when their directory group names include one of group_names.
+create table auth_ldap_managed_group (
+  public_id wt_public_id
+    primary key,
+  auth_method_id wt_public_id
+    not null,
+  name wt_name,
+  description wt_description,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  group_names text not null -- json array of directory group names
+    constraint group_names_must_not_be_empty
+      check(length(trim(group_names)) > 0),
+  -- Ensure that this managed group relates to an ldap auth method, as opposed
+  -- to other types
+  constraint auth_ldap_method_fkey
+    foreign key (auth_method_id) -- fk1
+      references auth_ldap_method (public_id)
+      on delete cascade
+      on update cascade,
+  -- Ensure it relates to an abstract managed group
+  constraint auth_managed_group_fkey
+    foreign key (auth_method_id, public_id) -- fk2
+      references auth_managed_group (auth_method_id, public_id)
+      on delete cascade
+      on update cascade,
+  constraint auth_ldap_managed_group_auth_method_id_name_uq
+    unique(auth_method_id, name)
+);
+comment on table auth_ldap_managed_group is
+'auth_ldap_managed_group entries are subtypes of auth_managed_group and represent an ldap managed group.';
+
+create trigger
+  immutable_columns
+before update on auth_ldap_managed_group
+  for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before insert on auth_ldap_managed_group
+  for each row execute procedure default_create_time();
+
+create trigger
+  update_time_column
+before update on auth_ldap_managed_group
+  for each row execute procedure update_time_column();
+
+create trigger
+  update_version_column
+after update on auth_ldap_managed_group
+  for each row execute procedure update_version_column();
+
+create trigger
+  insert_managed_group_subtype
+before insert on auth_ldap_managed_group
+  for each row execute procedure insert_managed_group_subtype();
+
+create trigger
+  delete_managed_group_subtype
+after delete on auth_ldap_managed_group
+  for each row execute procedure delete_managed_group_subtype();
+
+-- Mappings of account to ldap managed groups, which are set when an account
+-- authenticates.
+create table auth_ldap_managed_group_member_account (
+  create_time wt_timestamp,
+  managed_group_id wt_public_id
+    references auth_ldap_managed_group(public_id)
+    on delete cascade
+    on update cascade,
+  member_id wt_public_id
+    references auth_ldap_account(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (managed_group_id, member_id)
+);
+comment on table auth_ldap_managed_group_member_account is
+'auth_ldap_managed_group_member_account is the join table for managed ldap groups and accounts.';
+
+-- auth_immutable_managed_ldap_group_member_account() ensures that group members are immutable.
+create or replace function
+  auth_immutable_managed_ldap_group_member_account()
+  returns trigger
+as $$
+begin
+  raise exception 'managed ldap group members are immutable';
+end;
+$$ language plpgsql;
+
+create trigger
+  default_create_time_column
+before insert on auth_ldap_managed_group_member_account
+  for each row execute procedure default_create_time();
+
+create trigger
+  auth_immutable_managed_ldap_group_member_account
+before update on auth_ldap_managed_group_member_account
+  for each row execute procedure auth_immutable_managed_ldap_group_member_account();
+
+-- replaces view from 9/03_oidc_managed_group_member.up.sql
+create or replace view auth_managed_group_member_account as
+select
+  oidc.create_time,
+  oidc.managed_group_id,
+  oidc.member_id
+from
+  auth_oidc_managed_group_member_account oidc
+union
+select
+  ldap.create_time,
+  ldap.managed_group_id,
+  ldap.member_id
+from
+  auth_ldap_managed_group_member_account ldap;
+
+-- replaces view from 4/01_iam.up.sql
+create or replace view iam_acct_info as
+select
+    aa.iam_user_id,
+    oa.subject as login_name,
+    oa.public_id as primary_account_id,
+    oa.full_name as full_name,
+    oa.email as email
+from
+    iam_scope s,
+    auth_account aa,
+    auth_oidc_account oa
+where
+    aa.public_id = oa.public_id and
+    aa.auth_method_id = s.primary_auth_method_id
+union
+select
+    aa.iam_user_id,
+    pa.login_name,
+    pa.public_id as primary_account_id,
+    '' as full_name,
+    '' as email
+from
+    iam_scope s,
+    auth_account aa,
+    auth_password_account pa
+where
+    aa.public_id = pa.public_id and
+    aa.auth_method_id = s.primary_auth_method_id
+union
+select
+    aa.iam_user_id,
+    la.login_name,
+    la.public_id as primary_account_id,
+    la.full_name as full_name,
+    la.email as email
+from
+    iam_scope s,
+    auth_account aa,
+    auth_ldap_account la
+where
+    aa.public_id = la.public_id and
+    aa.auth_method_id = s.primary_auth_method_id;
+
+insert into oplog_ticket (name, version)
+values
+  ('auth_ldap_method', 1), -- auth method is the root aggregate itself and all of its value objects.
+  ('auth_ldap_account', 1),
+  ('auth_ldap_managed_group', 1);
+`),
+			17007: []byte(`
+-- Password policy settings for password auth methods. A value of 0 for any
+-- of the minimum character class counts, the history count or the maximum
+-- failed attempts disables that part of the policy.
+alter table auth_password_method
+  add column min_uppercase int not null default 0
+    constraint min_uppercase_not_negative
+      check(min_uppercase >= 0),
+  add column min_lowercase int not null default 0
+    constraint min_lowercase_not_negative
+      check(min_lowercase >= 0),
+  add column min_digits int not null default 0
+    constraint min_digits_not_negative
+      check(min_digits >= 0),
+  add column min_symbols int not null default 0
+    constraint min_symbols_not_negative
+      check(min_symbols >= 0),
+  add column password_history_count int not null default 0
+    constraint password_history_count_in_range
+      check(password_history_count between 0 and 24),
+  add column max_failed_attempts int not null default 0
+    constraint max_failed_attempts_not_negative
+      check(max_failed_attempts >= 0),
+  add column lockout_duration int not null default 900 -- seconds
+    constraint lockout_duration_must_be_greater_than_0
+      check(lockout_duration > 0);
+
+-- password_change_required is set by an administrator to force the account
+-- to choose a new password the next time it authenticates.
+alter table auth_password_account
+  add column password_change_required bool not null default false;
+
+-- Replaces view from 2/20_pass.up.sql
+drop view auth_password_method_with_is_primary;
+create view auth_password_method_with_is_primary as
+select
+  case when s.primary_auth_method_id is not null then
+    true
+  else false end
+  as is_primary_auth_method,
+  am.public_id,
+  am.scope_id,
+  am.password_conf_id,
+  am.name,
+  am.description,
+  am.create_time,
+  am.update_time,
+  am.version,
+  am.min_login_name_length,
+  am.min_password_length,
+  am.min_uppercase,
+  am.min_lowercase,
+  am.min_digits,
+  am.min_symbols,
+  am.password_history_count,
+  am.max_failed_attempts,
+  am.lockout_duration
+from
+  auth_password_method am
+  left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+comment on view auth_password_method_with_is_primary is
+'password auth method with an is_primary_auth_method bool';
+
+-- Replaces view from 0/14_auth_password_views.up.sql
+drop view auth_password_current_conf;
+create view auth_password_current_conf as
+    select pm.min_login_name_length, pm.min_password_length,
+           pm.min_uppercase, pm.min_lowercase, pm.min_digits, pm.min_symbols,
+           pm.password_history_count, pm.max_failed_attempts, pm.lockout_duration,
+           c.*
+      from auth_password_method pm
+inner join auth_password_conf_union c
+        on pm.password_conf_id = c.password_conf_id;
+
+-- auth_password_account_lockout tracks failed authentication attempts for a
+-- password account. It is kept separate from auth_password_account so
+-- recording a failed attempt does not change the version of the account.
+create table auth_password_account_lockout (
+  password_account_id wt_public_id primary key
+    constraint auth_password_account_fkey
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+  failed_attempts int not null default 0
+    constraint failed_attempts_not_negative
+      check(failed_attempts >= 0),
+  last_failed_time wt_timestamp,
+  locked_until timestamp with time zone
+);
+comment on table auth_password_account_lockout is
+'auth_password_account_lockout is a table where each row contains the failed authentication attempts for a password account. '
+'An account is locked while locked_until is in the future.';
+
+-- auth_password_argon2_cred_history contains the previous argon2 credentials
+-- for a password account. Rows are inserted when a credential is deleted.
+create table auth_password_argon2_cred_history (
+  private_id wt_private_id primary key,
+  password_account_id wt_public_id not null
+    constraint auth_password_account_fkey
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+  password_conf_id wt_private_id not null
+    constraint auth_password_argon2_conf_fkey
+      references auth_password_argon2_conf (private_id)
+      on delete cascade
+      on update cascade,
+  salt bytea not null, -- encrypted
+  derived_key bytea not null,
+  key_id text not null
+    constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+  create_time wt_timestamp
+);
+comment on table auth_password_argon2_cred_history is
+'auth_password_argon2_cred_history is a table where each row contains a previous argon2 credential for a password account. '
+'Only the 24 most recent credentials are kept for an account.';
+
+create index auth_password_argon2_cred_history_account_time_idx
+  on auth_password_argon2_cred_history (password_account_id, create_time desc);
+
+create trigger default_create_time_column before insert on auth_password_argon2_cred_history
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on auth_password_argon2_cred_history
+  for each row execute procedure immutable_columns('private_id', 'password_account_id', 'password_conf_id', 'salt', 'derived_key', 'key_id', 'create_time');
+
+create function insert_auth_password_argon2_cred_history() returns trigger
+as $$
+begin
+  -- The credential is also deleted when its account is deleted, in which
+  -- case there is no history to keep.
+  perform from auth_password_account where public_id = old.password_account_id;
+  if not found then
+    return null;
+  end if;
+
+  insert into auth_password_argon2_cred_history
+    (private_id, password_account_id, password_conf_id, salt, derived_key, key_id)
+  values
+    (old.private_id, old.password_account_id, old.password_conf_id, old.salt, old.derived_key, old.key_id);
+
+  delete from auth_password_argon2_cred_history
+   where password_account_id = old.password_account_id
+     and private_id not in (
+           select private_id
+             from auth_password_argon2_cred_history
+            where password_account_id = old.password_account_id
+         order by create_time desc
+            limit 24
+         );
+  return null;
+end;
+$$ language plpgsql;
+comment on function insert_auth_password_argon2_cred_history() is
+'insert_auth_password_argon2_cred_history() is an after delete trigger function that copies the deleted credential '
+'into auth_password_argon2_cred_history and keeps only the 24 most recent credentials for the account.';
+
+create trigger insert_auth_password_argon2_cred_history after delete on auth_password_argon2_cred
+  for each row execute procedure insert_auth_password_argon2_cred_history();
+`),
+			17008: []byte(`
+-- totp_required requires every account of the password auth method to
+-- authenticate with a TOTP code in addition to its password.
+alter table auth_password_method
+  add column totp_required bool not null default false;
+
+-- Replaces view from 17/07_auth_password_policy.up.sql
+drop view auth_password_method_with_is_primary;
+create view auth_password_method_with_is_primary as
+select
+  case when s.primary_auth_method_id is not null then
+    true
+  else false end
+  as is_primary_auth_method,
+  am.public_id,
+  am.scope_id,
+  am.password_conf_id,
+  am.name,
+  am.description,
+  am.create_time,
+  am.update_time,
+  am.version,
+  am.min_login_name_length,
+  am.min_password_length,
+  am.min_uppercase,
+  am.min_lowercase,
+  am.min_digits,
+  am.min_symbols,
+  am.password_history_count,
+  am.max_failed_attempts,
+  am.lockout_duration,
+  am.totp_required
+from
+  auth_password_method am
+  left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+comment on view auth_password_method_with_is_primary is
+'password auth method with an is_primary_auth_method bool';
+
+-- auth_password_totp contains the TOTP secret for a password account. The
+-- secret is only used to verify codes once the enrollment has been confirmed
+-- with a valid code.
+create table auth_password_totp (
+  password_account_id wt_public_id primary key
+    constraint auth_password_account_fkey
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+  secret bytea not null, -- encrypted
+  key_id text not null
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version (private_id)
+      on delete restrict
+      on update cascade,
+  confirmed bool not null default false,
+  -- last_used_step is the time step of the last code accepted for the
+  -- account, used to prevent a code from being used more than once.
+  last_used_step bigint not null default 0
+    constraint last_used_step_not_negative
+      check(last_used_step >= 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+comment on table auth_password_totp is
+'auth_password_totp is a table where each row contains the encrypted TOTP secret for a password account.';
+
+create trigger default_create_time_column before insert on auth_password_totp
+  for each row execute procedure default_create_time();
+
+create trigger update_time_column before update on auth_password_totp
+  for each row execute procedure update_time_column();
+
+create trigger immutable_columns before update on auth_password_totp
+  for each row execute procedure immutable_columns('password_account_id', 'secret', 'key_id', 'create_time');
+
+insert into oplog_ticket (name, version)
+values
+  ('auth_password_totp', 1);
+`),
+			17009: []byte(`
+-- The key of a key version is rewrapped when the keys of its scope are
+-- rotated or when the root kms changes, so the key and the root key version
+-- which encrypts it are no longer immutable.
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_root_key_version;
+create trigger immutable_columns before update on kms_root_key_version
+  for each row execute procedure immutable_columns('private_id', 'root_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_database_key_version;
+create trigger immutable_columns before update on kms_database_key_version
+  for each row execute procedure immutable_columns('private_id', 'database_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_oplog_key_version;
+create trigger immutable_columns before update on kms_oplog_key_version
+  for each row execute procedure immutable_columns('private_id', 'oplog_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_session_key_version;
+create trigger immutable_columns before update on kms_session_key_version
+  for each row execute procedure immutable_columns('private_id', 'session_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 0/31_keys.up.sql
+drop trigger immutable_columns on kms_token_key_version;
+create trigger immutable_columns before update on kms_token_key_version
+  for each row execute procedure immutable_columns('private_id', 'token_key_id', 'version', 'create_time');
+
+-- Replaces trigger from 1/03_kms.up.sql
+drop trigger immutable_columns on kms_oidc_key_version;
+create trigger immutable_columns before update on kms_oidc_key_version
+  for each row execute procedure immutable_columns('private_id', 'oidc_key_id', 'version', 'create_time');
+
+-- Values encrypted by a database key version are rewrapped with the current
+-- database key version before the old version is destroyed, so the encrypted
+-- values and their key ids are no longer immutable.
+
+-- Replaces trigger from 17/02_session_credential.up.sql
+drop trigger immutable_columns on session_credential;
+create trigger immutable_columns before update on session_credential
+  for each row execute procedure immutable_columns('session_id', 'credential_sha256', 'create_time');
+
+-- Replaces trigger from 17/07_auth_password_policy.up.sql
+drop trigger immutable_columns on auth_password_argon2_cred_history;
+create trigger immutable_columns before update on auth_password_argon2_cred_history
+  for each row execute procedure immutable_columns('private_id', 'password_account_id', 'password_conf_id', 'derived_key', 'create_time');
+
+-- Replaces trigger from 17/08_auth_password_totp.up.sql
+drop trigger immutable_columns on auth_password_totp;
+create trigger immutable_columns before update on auth_password_totp
+  for each row execute procedure immutable_columns('password_account_id', 'create_time');
+
+-- Replaces trigger from 10/04_vault_credential.up.sql
+drop trigger immutable_columns on credential_vault_token;
+create trigger immutable_columns before update on credential_vault_token
+  for each row execute procedure immutable_columns('token_hmac', 'store_id','create_time');
+
+-- kms_data_key_version_destruction_job contains the database key versions
+-- which are being destroyed. A database key version is destroyed once every
+-- value it encrypts has been rewrapped with the current database key version.
+create table kms_data_key_version_destruction_job (
+  key_id wt_private_id primary key
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version (private_id)
+      on delete cascade
+      on update cascade,
+  create_time wt_timestamp
+);
+comment on table kms_data_key_version_destruction_job is
+'kms_data_key_version_destruction_job is a table where each row represents a database key version which is being destroyed.';
+
+create trigger default_create_time_column before insert on kms_data_key_version_destruction_job
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on kms_data_key_version_destruction_job
+  for each row execute procedure immutable_columns('key_id', 'create_time');
+
+-- kms_data_key_version_destruction_job_run tracks the progress of rewrapping
+-- the values of one table which are encrypted by the database key version
+-- being destroyed.
+create table kms_data_key_version_destruction_job_run (
+  key_id wt_private_id not null
+    constraint kms_data_key_version_destruction_job_fkey
+      references kms_data_key_version_destruction_job (key_id)
+      on delete cascade
+      on update cascade,
+  table_name text not null
+    constraint table_name_must_not_be_empty
+      check(length(trim(table_name)) > 0),
+  total_count bigint not null
+    constraint total_count_must_not_be_negative
+      check(total_count >= 0),
+  completed_count bigint not null default 0
+    constraint completed_count_must_be_between_zero_and_total_count
+      check(completed_count >= 0 and completed_count <= total_count),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  primary key(key_id, table_name)
+);
+comment on table kms_data_key_version_destruction_job_run is
+'kms_data_key_version_destruction_job_run is a table where each row contains the progress of rewrapping the values of a table '
+'which are encrypted by a database key version that is being destroyed.';
+
+create trigger default_create_time_column before insert on kms_data_key_version_destruction_job_run
+  for each row execute procedure default_create_time();
+
+create trigger update_time_column before update on kms_data_key_version_destruction_job_run
+  for each row execute procedure update_time_column();
+
+create trigger immutable_columns before update on kms_data_key_version_destruction_job_run
+  for each row execute procedure immutable_columns('key_id', 'table_name', 'total_count', 'create_time');
+
+create view kms_data_key_version_destruction_job_progress as
+select
+  j.key_id,
+  rk.scope_id,
+  j.create_time,
+  coalesce(sum(r.completed_count), 0) as completed_count,
+  coalesce(sum(r.total_count), 0)     as total_count
+from
+  kms_data_key_version_destruction_job j
+  join kms_database_key_version dkv on dkv.private_id = j.key_id
+  join kms_database_key dk          on dk.private_id = dkv.database_key_id
+  join kms_root_key rk              on rk.private_id = dk.root_key_id
+  left outer join kms_data_key_version_destruction_job_run r on r.key_id = j.key_id
+group by j.key_id, rk.scope_id, j.create_time;
+comment on view kms_data_key_version_destruction_job_progress is
+'kms_data_key_version_destruction_job_progress contains the scope and the overall progress of each database key version destruction job.';
+`),
+			17010: []byte(`
+-- session_policy entries limit the sessions users can have on the targets of
+-- an org or project scope. The policies of an org apply to the sessions of all
+-- the projects of the org, counted together. A null limit means the policy
+-- does not limit that aspect of the sessions.
+create table session_policy (
+  public_id wt_public_id
+    primary key,
+  scope_id wt_scope_id
+    not null
+    constraint iam_scope_fkey
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+  name wt_name,
+  description wt_description,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  max_active_sessions_per_user int
+    constraint max_active_sessions_per_user_must_be_greater_than_zero
+      check(max_active_sessions_per_user > 0),
+  max_daily_session_seconds_per_user int
+    constraint max_daily_session_seconds_per_user_must_be_greater_than_zero
+      check(max_daily_session_seconds_per_user > 0),
+  constraint session_policy_scope_id_name_uq
+    unique(scope_id, name)
+);
+comment on table session_policy is
+'session_policy entries limit the sessions users can have on the targets of an org or project scope.';
+
+create trigger update_version_column after update on session_policy
+  for each row execute procedure update_version_column();
+
+create trigger update_time_column before update on session_policy
+  for each row execute procedure update_time_column();
+
+create trigger default_create_time_column before insert on session_policy
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on session_policy
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+-- session_policy_scope_valid is a before insert trigger function for
+-- session_policy which ensures the policy is owned by an org or a project.
+create or replace function
+  session_policy_scope_valid()
+  returns trigger
+as $$
+begin
+  perform from iam_scope
+   where public_id = new.scope_id
+     and type in ('org', 'project');
+  if not found then
+    raise exception 'invalid scope type for session policy';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  session_policy_scope_valid
+before insert on session_policy
+  for each row execute procedure session_policy_scope_valid();
+
+-- The session policies are checked against the sessions of a user each time
+-- the user authorizes a session.
+create index session_user_id_scope_id_ix
+  on session (user_id, scope_id);
+`),
+			17011: []byte(`
+-- session_approval_required makes the sessions of a target wait for the
+  -- approval of a user other than the one who requested the session.
+  -- session_approval_window_seconds is the time in which a session must be
+  -- approved and its authorization retrieved; 0 means the window ends when the
+  -- session expires.
+  alter table target_tcp
+    add column session_approval_required boolean not null default false,
+    add column session_approval_window_seconds int not null default 0
+      constraint session_approval_window_seconds_must_not_be_negative
+        check(session_approval_window_seconds >= 0);
+
+  alter table session
+    add column approval_expiration_time timestamp with time zone;
+
+  comment on column session.approval_expiration_time is
+    'approval_expiration_time is set when the session requires approval. The '
+    'session must be approved and its authorization retrieved before this time. '
+    'Null means the session does not require approval.';
+
+  -- replaces the trigger from 17/05_connection_rate_limits.up.sql to add
+  -- approval_expiration_time
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'recording_enabled', 'upload_rate_limit', 'download_rate_limit', 'approval_expiration_time');
+
+  alter table session_state_enm
+    drop constraint only_predefined_session_states_allowed;
+  alter table session_state_enm
+    add constraint only_predefined_session_states_allowed
+      check (
+        name in ('pending', 'active', 'canceling', 'terminated', 'pending-approval')
+      );
+  insert into session_state_enm (name)
+  values
+    ('pending-approval');
+
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled',
+          'approval denied',
+          'approval expired'
+        )
+      );
+  insert into session_termination_reason_enm (name)
+  values
+    ('approval denied'),
+    ('approval expired');
+
+  -- replaces function from 0/50_session.up.sql. A session which requires
+  -- approval starts in the pending-approval state, and only moves to the
+  -- pending state, from which a worker can activate it, once approved.
+  create or replace function
+    insert_new_session_state()
+    returns trigger
+  as $$
+  begin
+    if new.approval_expiration_time is not null then
+      insert into session_state (session_id, state)
+      values
+        (new.public_id, 'pending-approval');
+      return new;
+    end if;
+    insert into session_state (session_id, state)
+    values
+      (new.public_id, 'pending');
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- session_approval records the decision on a session which requires
+  -- approval. There is at most one decision per session.
+  create table session_approval (
+    session_id wt_public_id primary key
+      references session (public_id)
+      on delete cascade
+      on update cascade,
+    -- the user who approved or denied the session
+    approver_id text
+      references iam_user (public_id)
+      on delete set null
+      on update cascade,
+    decision text not null
+      constraint session_approval_decision_valid
+        check(decision in ('approved', 'denied')),
+    -- the time the user of an approved session retrieved its authorization.
+    -- null until retrieved.
+    authorization_time timestamp with time zone,
+    create_time wt_timestamp
+  );
+
+  comment on table session_approval is
+    'session_approval entries record the decision on a session which requires approval.';
+
+  create trigger default_create_time_column before insert on session_approval
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on session_approval
+    for each row execute procedure immutable_columns('session_id', 'approver_id', 'decision', 'create_time');
+
+  -- insert_session_approval is a before insert trigger function for
+  -- session_approval. It ensures the session is waiting for approval within
+  -- its approval window and that the approver is not the user of the session.
+  create or replace function
+    insert_session_approval()
+    returns trigger
+  as $$
+  begin
+    perform from session s
+      join session_state ss on ss.session_id = s.public_id
+     where s.public_id = new.session_id
+       and ss.state = 'pending-approval'
+       and ss.end_time is null
+       and s.approval_expiration_time > now();
+    if not found then
+      raise exception 'session % is not pending approval', new.session_id;
+    end if;
+    perform from session
+     where public_id = new.session_id
+       and user_id = new.approver_id;
+    if found then
+      raise exception 'session % cannot be approved by its own user', new.session_id;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_session_approval before insert on session_approval
+    for each row execute procedure insert_session_approval();
+
+  -- apply_session_approval is an after insert trigger function for
+  -- session_approval. An approved session moves to the pending state, a denied
+  -- session is terminated.
+  create or replace function
+    apply_session_approval()
+    returns trigger
+  as $$
+  begin
+    if new.decision = 'approved' then
+      insert into session_state (session_id, state)
+      values
+        (new.session_id, 'pending');
+      return new;
+    end if;
+    update session
+       set termination_reason = 'approval denied'
+     where public_id = new.session_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger apply_session_approval after insert on session_approval
+    for each row execute procedure apply_session_approval();
+
+  -- replaces view from 17/05_connection_rate_limits.up.sql to add
+  -- session_approval_required and session_approval_window_seconds
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled,
+         upload_rate_limit,
+         download_rate_limit,
+         session_approval_required,
+         session_approval_window_seconds
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds
+    from target_ssh;
+
+  -- replaces view from 17/05_connection_rate_limits.up.sql to add
+  -- approval_expiration_time and the approver of the session
+  drop view session_with_state;
+  create view session_with_state as
+  select s.public_id,
+         s.user_id,
+         s.host_id,
+         s.server_id,
+         s.server_type,
+         s.target_id,
+         s.host_set_id,
+         s.auth_token_id,
+         s.scope_id,
+         s.certificate,
+         s.expiration_time,
+         s.connection_limit,
+         s.tofu_token,
+         s.key_id,
+         s.termination_reason,
+         s.version,
+         s.create_time,
+         s.update_time,
+         s.endpoint,
+         s.worker_filter,
+         s.recording_enabled,
+         s.recording_path,
+         s.upload_rate_limit,
+         s.download_rate_limit,
+         s.approval_expiration_time,
+         sa.approver_id,
+         ss.state,
+         ss.previous_end_time,
+         ss.start_time,
+         ss.end_time
+    from session s
+    join session_state ss
+      on s.public_id = ss.session_id
+    left join session_approval sa
+      on s.public_id = sa.session_id;
+`),
+			17012: []byte(`
+-- server_worker entries are the workers which authenticate to the controllers
+-- with their own credentials instead of the shared worker-auth KMS. A worker is
+-- created with a one-time activation token. The worker presents the token on
+-- its first contact with a controller, which issues the worker's first
+-- credential and removes the token.
+create table server_worker (
+  public_id wt_public_id
+    primary key,
+  scope_id wt_scope_id
+    not null
+    default 'global'
+    constraint scope_id_must_be_global
+      check(scope_id = 'global')
+    constraint iam_scope_global_fkey
+      references iam_scope_global (scope_id)
+      on delete cascade
+      on update cascade,
+  name wt_name
+    not null
+    constraint server_worker_name_must_be_lowercase
+      check(lower(trim(name)) = name)
+    constraint server_worker_name_uq
+      unique,
+  description wt_description,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  -- the activation token, encrypted by the database key version key_id. Both
+  -- are null once the worker is activated.
+  ct_activation_token bytea,
+  key_id text
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version (private_id)
+      on delete restrict
+      on update cascade,
+  activation_token_expiration_time timestamp with time zone,
+  -- the time the worker presented its activation token. Null until then.
+  activation_time timestamp with time zone,
+  constraint activation_token_and_key_id_set_together
+    check(
+      (ct_activation_token is null and key_id is null)
+      or
+      (ct_activation_token is not null and key_id is not null)
+    )
+);
+comment on table server_worker is
+'server_worker entries are the workers which authenticate to the controllers with their own credentials.';
+
+create trigger update_version_column after update on server_worker
+  for each row execute procedure update_version_column();
+
+create trigger update_time_column before update on server_worker
+  for each row execute procedure update_time_column();
+
+create trigger default_create_time_column before insert on server_worker
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on server_worker
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'name', 'create_time');
+
+-- server_worker_credential entries are the credentials issued to the workers.
+-- A worker encrypts the information it authenticates to a controller with
+-- using the key of one of its credentials. A worker usually has a single
+-- credential; the previous one is kept until it expires shortly after the
+-- credential is rotated.
+create table server_worker_credential (
+  public_id wt_public_id
+    primary key,
+  worker_id wt_public_id
+    not null
+    constraint server_worker_fkey
+      references server_worker (public_id)
+      on delete cascade
+      on update cascade,
+  -- the AES-256 key of the credential, encrypted by the database key version
+  -- key_id
+  ct_key bytea not null,
+  key_id text not null
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version (private_id)
+      on delete restrict
+      on update cascade,
+  expiration_time timestamp with time zone not null,
+  create_time wt_timestamp
+);
+comment on table server_worker_credential is
+'server_worker_credential entries are the credentials issued to the workers which authenticate with their own credentials.';
+
+create trigger default_create_time_column before insert on server_worker_credential
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on server_worker_credential
+  for each row execute procedure immutable_columns('public_id', 'worker_id', 'create_time');
+`),
+			17013: []byte(`
+-- server_worker_tag entries are the tags of a worker set through the
+-- controller API. They are merged with the tags in the configuration of the
+-- worker, which are stored in server_tag, when the worker filter of a target
+-- is evaluated.
+create table server_worker_tag (
+  worker_id wt_public_id
+    constraint server_worker_fkey
+      references server_worker (public_id)
+      on delete cascade
+      on update cascade,
+  key wt_tagpair,
+  value wt_tagpair,
+  create_time wt_timestamp,
+  primary key(worker_id, key, value)
+);
+comment on table server_worker_tag is
+'server_worker_tag entries are the tags of a worker set through the controller API.';
+
+create trigger default_create_time_column before insert on server_worker_tag
+  for each row execute procedure default_create_time();
+
+create trigger immutable_columns before update on server_worker_tag
+  for each row execute procedure immutable_columns('worker_id', 'key', 'value', 'create_time');
+
+-- server_worker_aggregate contains the workers along with the address they
+-- last reported to a controller and the time of their last status report.
+-- The name of a worker is its server id.
+create view server_worker_aggregate as
+select w.public_id,
+       w.scope_id,
+       w.name,
+       w.description,
+       w.create_time,
+       w.update_time,
+       w.version,
+       w.activation_token_expiration_time,
+       w.activation_time,
+       s.address,
+       s.update_time as last_status_time
+  from server_worker w
+  left join server s
+    on s.private_id = w.name
+   and s.type = 'worker';
+
+-- server_worker_canonical_tag contains the tags of the workers from their
+-- configuration and the tags set through the controller API, by server id.
+create view server_worker_canonical_tag as
+select server_id,
+       key,
+       value
+  from server_tag
+ union
+select w.name as server_id,
+       t.key,
+       t.value
+  from server_worker_tag t
+  join server_worker w
+    on w.public_id = t.worker_id;
+`),
+			17014: []byte(`
+create table credential_type_enm (
+    name text primary key
+      constraint only_predefined_credential_types_allowed
+      check (
+        name in (
+          'unspecified',
+          'username_password',
+          'ssh_private_key',
+          'certificate'
+        )
+      )
+  );
+  comment on table credential_type_enm is
+    'credential_type_enm is an enumeration table for the types of credentials a credential library provides.';
+
+  insert into credential_type_enm (name)
+  values
+    ('unspecified'),
+    ('username_password'),
+    ('ssh_private_key'),
+    ('certificate');
+
+  -- The *_attribute columns override the name of the field in the vault
+  -- secret which holds each part of the typed credential. They can only be
+  -- set for the credential types which have that part.
+  alter table credential_vault_library
+    add column credential_type text not null default 'unspecified'
+      constraint credential_type_enm_fkey
+        references credential_type_enm (name)
+        on delete restrict
+        on update cascade,
+    add column username_attribute text
+      constraint username_attribute_must_not_be_empty
+        check(length(trim(username_attribute)) > 0),
+    add column password_attribute text
+      constraint password_attribute_must_not_be_empty
+        check(length(trim(password_attribute)) > 0),
+    add column private_key_attribute text
+      constraint private_key_attribute_must_not_be_empty
+        check(length(trim(private_key_attribute)) > 0),
+    add column certificate_attribute text
+      constraint certificate_attribute_must_not_be_empty
+        check(length(trim(certificate_attribute)) > 0),
+    add constraint mapping_overrides_must_match_credential_type
+      check(
+        (username_attribute is null or credential_type in ('username_password', 'ssh_private_key'))
+        and
+        (password_attribute is null or credential_type = 'username_password')
+        and
+        (private_key_attribute is null or credential_type in ('ssh_private_key', 'certificate'))
+        and
+        (certificate_attribute is null or credential_type = 'certificate')
+      );
+
+  drop trigger immutable_columns on credential_vault_library;
+  create trigger immutable_columns before update on credential_vault_library
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'credential_type', 'create_time');
+
+  drop view credential_vault_library_private;
+     create view credential_vault_library_private as
+     select library.public_id             as public_id,
+            library.store_id              as store_id,
+            library.name                  as name,
+            library.description           as description,
+            library.create_time           as create_time,
+            library.update_time           as update_time,
+            library.version               as version,
+            library.vault_path            as vault_path,
+            library.http_method           as http_method,
+            library.http_request_body     as http_request_body,
+            library.credential_type       as credential_type,
+            library.username_attribute    as username_attribute,
+            library.password_attribute    as password_attribute,
+            library.private_key_attribute as private_key_attribute,
+            library.certificate_attribute as certificate_attribute,
+            store.scope_id                as scope_id,
+            store.vault_address           as vault_address,
+            store.namespace               as namespace,
+            store.ca_cert                 as ca_cert,
+            store.tls_server_name         as tls_server_name,
+            store.tls_skip_verify         as tls_skip_verify,
+            store.token_hmac              as token_hmac,
+            store.ct_token                as ct_token, -- encrypted
+            store.token_key_id            as token_key_id,
+            store.client_cert             as client_cert,
+            store.ct_client_key           as ct_client_key, -- encrypted
+            store.client_key_id           as client_key_id
+       from credential_vault_library library
+       join credential_vault_store_private store
+         on library.store_id = store.public_id
+        and store.token_status = 'current';
+  comment on view credential_vault_library_private is
+    'credential_vault_library_private is a view where each row contains a credential library and the credential library''s data needed to connect to Vault. '
+    'Each row may contain encrypted data. This view should not be used to retrieve data which will be returned external to boundary.';
+`),
+			17015: []byte(`
+create table credential_static_store (
+    public_id wt_public_id primary key,
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint credential_store_fkey
+      foreign key (scope_id, public_id)
+      references credential_store (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    constraint credential_static_store_scope_id_name_uq
+      unique(scope_id, name)
+  );
+  comment on table credential_static_store is
+    'credential_static_store is a table where each row is a resource that represents a static credential store. '
+    'It is a credential_store subtype.';
+
+  create trigger update_version_column after update on credential_static_store
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_store
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_store
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_credential_store_subtype before insert on credential_static_store
+    for each row execute procedure insert_credential_store_subtype();
+
+  create trigger delete_credential_store_subtype after delete on credential_static_store
+    for each row execute procedure delete_credential_store_subtype();
+
+  create table credential_static_username_password_credential (
+    public_id wt_public_id primary key,
+    store_id wt_public_id not null
+      constraint credential_static_store_fkey
+        references credential_static_store (public_id)
+        on delete cascade
+        on update cascade,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    username text not null
+      constraint username_must_not_be_empty
+        check(length(trim(username)) > 0),
+    password bytea not null -- encrypted value
+      constraint password_must_not_be_empty
+        check(length(password) > 0),
+    password_hmac bytea not null
+      constraint password_hmac_must_not_be_empty
+        check(length(password_hmac) > 0),
+    key_id text not null
+      constraint kms_database_key_version_fkey
+        references kms_database_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    constraint credential_static_fkey
+      foreign key (store_id, public_id)
+      references credential_static (store_id, public_id)
+      on delete cascade
+      on update cascade,
+    constraint credential_static_username_password_credential_store_id_name_uq
+      unique(store_id, name)
+  );
+  comment on table credential_static_username_password_credential is
+    'credential_static_username_password_credential is a table where each row is a resource that represents a static username and password credential. '
+    'It is a credential_static subtype. The password is encrypted with the database key of the scope of the credential store.';
+
+  create trigger update_version_column after update on credential_static_username_password_credential
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_username_password_credential
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_username_password_credential
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_username_password_credential
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'create_time');
+
+  create trigger insert_credential_static_subtype before insert on credential_static_username_password_credential
+    for each row execute procedure insert_credential_static_subtype();
+
+  create trigger delete_credential_static_subtype after delete on credential_static_username_password_credential
+    for each row execute procedure delete_credential_static_subtype();
+
+  create table credential_static_ssh_private_key_credential (
+    public_id wt_public_id primary key,
+    store_id wt_public_id not null
+      constraint credential_static_store_fkey
+        references credential_static_store (public_id)
+        on delete cascade
+        on update cascade,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    username text not null
+      constraint username_must_not_be_empty
+        check(length(trim(username)) > 0),
+    private_key bytea not null -- encrypted value
+      constraint private_key_must_not_be_empty
+        check(length(private_key) > 0),
+    private_key_hmac bytea not null
+      constraint private_key_hmac_must_not_be_empty
+        check(length(private_key_hmac) > 0),
+    key_id text not null
+      constraint kms_database_key_version_fkey
+        references kms_database_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    constraint credential_static_fkey
+      foreign key (store_id, public_id)
+      references credential_static (store_id, public_id)
+      on delete cascade
+      on update cascade,
+    constraint credential_static_ssh_private_key_credential_store_id_name_uq
+      unique(store_id, name)
+  );
+  comment on table credential_static_ssh_private_key_credential is
+    'credential_static_ssh_private_key_credential is a table where each row is a resource that represents a static ssh private key credential. '
+    'It is a credential_static subtype. The private key is encrypted with the database key of the scope of the credential store.';
+
+  create trigger update_version_column after update on credential_static_ssh_private_key_credential
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_ssh_private_key_credential
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_ssh_private_key_credential
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_ssh_private_key_credential
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'create_time');
+
+  create trigger insert_credential_static_subtype before insert on credential_static_ssh_private_key_credential
+    for each row execute procedure insert_credential_static_subtype();
+
+  create trigger delete_credential_static_subtype after delete on credential_static_ssh_private_key_credential
+    for each row execute procedure delete_credential_static_subtype();
+
+  create table target_static_credential (
+    target_id wt_public_id not null
+      constraint target_fkey
+        references target (public_id)
+        on delete cascade
+        on update cascade,
+    credential_static_id wt_public_id not null
+      constraint credential_static_fkey
+        references credential_static (public_id)
+        on delete cascade
+        on update cascade,
+    credential_purpose text not null
+      constraint credential_purpose_enm_fkey
+        references credential_purpose_enm (name)
+        on delete restrict
+        on update cascade,
+    create_time wt_timestamp,
+    primary key(target_id, credential_static_id, credential_purpose)
+  );
+  comment on table target_static_credential is
+    'target_static_credential is a join table between the target and credential_static tables. '
+    'It also contains the credential purpose the relationship represents.';
+
+  create trigger default_create_time_column before insert on target_static_credential
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on target_static_credential
+    for each row execute procedure immutable_columns('target_id', 'credential_static_id', 'credential_purpose', 'create_time');
+
+  -- target_credential_source contains the credential libraries and the static
+  -- credentials of the targets along with the id of the credential store each
+  -- is a part of.
+  create view target_credential_source as
+  select tcl.target_id,
+         tcl.credential_library_id as credential_source_id,
+         tcl.credential_purpose,
+         cl.store_id
+    from target_credential_library tcl
+    join credential_library cl
+      on cl.public_id = tcl.credential_library_id
+   union
+  select tsc.target_id,
+         tsc.credential_static_id as credential_source_id,
+         tsc.credential_purpose,
+         cs.store_id
+    from target_static_credential tsc
+    join credential_static cs
+      on cs.public_id = tsc.credential_static_id;
+`),
+			17016: []byte(`
+alter table credential_type_enm
+    drop constraint only_predefined_credential_types_allowed;
+  alter table credential_type_enm
+    add constraint only_predefined_credential_types_allowed
+      check (
+        name in (
+          'unspecified',
+          'username_password',
+          'ssh_private_key',
+          'certificate',
+          'ssh_certificate'
+        )
+      );
+
+  insert into credential_type_enm (name)
+  values
+    ('ssh_certificate');
+
+  -- A library which provides ssh_certificate credentials sends a generated
+  -- public key to the sign endpoint of a Vault SSH secrets engine, which only
+  -- accepts POST requests.
+  alter table credential_vault_library
+    add constraint ssh_certificate_requires_post
+      check(credential_type <> 'ssh_certificate' or http_method = 'POST');
+`),
+			17017: []byte(`
+create table target_udp (
+    public_id wt_public_id primary key
+      constraint target_fkey
+        references target (public_id)
+        on delete cascade
+        on update cascade,
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+        check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+        check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter wt_bexprfilter,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint target_udp_scope_id_name_uq
+      unique(scope_id, name) -- name must be unique within a scope
+  );
+  comment on table target_udp is
+    'target_udp is a table where each row is a resource that represents a udp target. '
+    'The worker proxies the datagrams of the client to the endpoint. Each flow of '
+    'datagrams from a client address is a connection of the session which is closed '
+    'when the flow is idle.';
+
+  create trigger insert_target_subtype before insert on target_udp
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_udp
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_udp
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_udp
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_udp
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_udp
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_udp
+    for each row execute procedure target_scope_valid();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_udp', 1);
+
+  -- replaces view from 17/11_session_approval.up.sql to add target_udp
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled,
+         upload_rate_limit,
+         download_rate_limit,
+         session_approval_required,
+         session_approval_window_seconds
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds
+    from target_ssh
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'udp' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds
+    from target_udp;
+`),
+			17018: []byte(`
+-- failed_attempts and locked_until throttle the TOTP codes tried for an
+-- account, independently of the lockout settings of its auth method: once
+-- failed_attempts reaches the limit enforced by the controller, no code is
+-- accepted for the account until locked_until has passed.
+alter table auth_password_totp
+  add column failed_attempts integer not null default 0
+    constraint failed_attempts_not_negative
+      check(failed_attempts >= 0),
+  add column locked_until timestamp with time zone;
+`),
+			17019: []byte(`
+-- host_key is the public key, in the authorized_keys format, which the
+  -- endpoint of an ssh target must present as its host key. The worker refuses
+  -- to connect to the endpoint while it is null.
+  alter table target_ssh
+    add column host_key text
+      constraint host_key_must_not_be_empty
+        check(length(trim(host_key)) > 0);
+
+  alter table session
+    add column endpoint_host_key text;
+
+  comment on column session.endpoint_host_key is
+    'endpoint_host_key is the public key, in the authorized_keys format, which '
+    'the endpoint of the session must present as its ssh host key. It is only '
+    'set for the sessions of ssh targets.';
+
+  -- replaces the trigger from 17/11_session_approval.up.sql to add
+  -- endpoint_host_key
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter', 'recording_enabled', 'upload_rate_limit', 'download_rate_limit', 'approval_expiration_time', 'endpoint_host_key');
+
+  -- replaces view from 17/17_target_udp.up.sql to add host_key
+  create or replace view target_all_subtypes as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         session_recording_enabled,
+         upload_rate_limit,
+         download_rate_limit,
+         session_approval_required,
+         session_approval_window_seconds,
+         null as host_key
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'ssh' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds,
+         host_key
+    from target_ssh
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'udp' as type,
+         false as session_recording_enabled,
+         0 as upload_rate_limit,
+         0 as download_rate_limit,
+         false as session_approval_required,
+         0 as session_approval_window_seconds,
+         null as host_key
+    from target_udp;
+
+  -- replaces view from 17/11_session_approval.up.sql to add endpoint_host_key
+  drop view session_with_state;
+  create view session_with_state as
+  select s.public_id,
+         s.user_id,
+         s.host_id,
+         s.server_id,
+         s.server_type,
+         s.target_id,
+         s.host_set_id,
+         s.auth_token_id,
+         s.scope_id,
+         s.certificate,
+         s.expiration_time,
+         s.connection_limit,
+         s.tofu_token,
+         s.key_id,
+         s.termination_reason,
+         s.version,
+         s.create_time,
+         s.update_time,
+         s.endpoint,
+         s.worker_filter,
+         s.recording_enabled,
+         s.recording_path,
+         s.upload_rate_limit,
+         s.download_rate_limit,
+         s.approval_expiration_time,
+         s.endpoint_host_key,
+         sa.approver_id,
+         ss.state,
+         ss.previous_end_time,
+         ss.start_time,
+         ss.end_time
+    from session s
+    join session_state ss
+      on s.public_id = ss.session_id
+    left join session_approval sa
+      on s.public_id = sa.session_id;
+`),
+			17020: []byte(`
+alter table server
+    add column recording_public_key bytea;
+
+  comment on column server.recording_public_key is
+    'recording_public_key is the PEM encoded public key which verifies the '
+    'recordings made by a worker, as last reported by the worker. The worker '
+    'keeps its signing key outside of the directory it stores the recordings '
+    'in, so the recordings are verified with this key rather than with a key '
+    'found next to them.';
+
+  -- replaces view from 17/13_worker_tags.up.sql to add recording_public_key
+  create or replace view server_worker_aggregate as
+  select w.public_id,
+         w.scope_id,
+         w.name,
+         w.description,
+         w.create_time,
+         w.update_time,
+         w.version,
+         w.activation_token_expiration_time,
+         w.activation_time,
+         s.address,
+         s.update_time as last_status_time,
+         s.recording_public_key
+    from server_worker w
+    left join server s
+      on s.private_id = w.name
+     and s.type = 'worker';
+`),
+			17021: []byte(`
+-- Every flow of datagrams from a client address is a connection of the
+  -- session, and a flow is closed once it is idle, so a limit of 1 stops a
+  -- session of a udp target from working after its first flow. The session
+  -- connection limit of udp targets defaults to -1 (unlimited) instead.
+  alter table target_udp
+    alter column session_connection_limit set default -1;
 `),
 			2001: []byte(`
 -- log_migration entries represent logs generated during migrations
@@ -40,6 +40,9 @@ const (
 	InvalidDynamicCredential Code = 116 // InvalidDynamicCredential represents that a dynamic credential for a session was in an invalid state
 	JobAlreadyRunning        Code = 117 // JobAlreadyRunning represents that a Job is already running when an attempt to run again was made
 	SubtypeAlreadyRegistered Code = 118 // SubtypeAlreadyRegistered represents that a value has already been registered in the subtype registry system.
+	SessionLimitExceeded     Code = 119 // SessionLimitExceeded represents that a session policy limit on the number of active sessions of a user was reached
+	SessionTimeLimitExceeded Code = 120 // SessionTimeLimitExceeded represents that a session policy limit on the daily session time of a user was reached
+	SessionSelfApproval      Code = 121 // SessionSelfApproval represents that the user of a session attempted to approve or deny it
 
 	AuthAttemptExpired Code = 198 // AuthAttemptExpired represents an expired authentication attempt
 	AuthMethodInactive Code = 199 // AuthMethodInactive represents an error that means the auth method is not active.
@@ -59,6 +62,30 @@ const (
 	// new passwords are equal.
 	PasswordsEqual Code = 203
 
+	// PasswordTooWeak results from attempting to set a password which does
+	// not meet the complexity requirements of the auth method.
+	PasswordTooWeak Code = 204
+
+	// PasswordReused results from attempting to set a password which matches
+	// one of the account's recent passwords.
+	PasswordReused Code = 205
+
+	// PasswordChangeRequired is returned from Authenticate when the account
+	// must choose a new password before it can authenticate.
+	PasswordChangeRequired Code = 206
+
+	// TotpRequired is returned from Authenticate when the account has a
+	// confirmed TOTP secret and no TOTP code was provided.
+	TotpRequired Code = 207
+
+	// TotpNotEnrolled is returned from Authenticate when the auth method
+	// requires TOTP and the account has not confirmed a TOTP secret.
+	TotpNotEnrolled Code = 208
+
+	// TotpAlreadyEnrolled results from attempting to enroll a TOTP secret for
+	// an account which already has a confirmed TOTP secret.
+	TotpAlreadyEnrolled Code = 209
+
 	Encrypt Code = 300 // Encrypt represents an error occurred during the underlying encryption process
 	Decrypt Code = 301 // Decrypt represents an error occurred during the underlying decryption process
 	Encode  Code = 302 // Encode represents an error occurred during the underlying encoding/marshaling process
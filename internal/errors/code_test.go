@@ -117,6 +117,21 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    SubtypeAlreadyRegistered,
 			want: SubtypeAlreadyRegistered,
 		},
+		{
+			name: "SessionLimitExceeded",
+			c:    SessionLimitExceeded,
+			want: SessionLimitExceeded,
+		},
+		{
+			name: "SessionTimeLimitExceeded",
+			c:    SessionTimeLimitExceeded,
+			want: SessionTimeLimitExceeded,
+		},
+		{
+			name: "SessionSelfApproval",
+			c:    SessionSelfApproval,
+			want: SessionSelfApproval,
+		},
 		{
 			name: "InvalidDynamicCredential",
 			c:    InvalidDynamicCredential,
@@ -162,6 +177,36 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    PasswordsEqual,
 			want: PasswordsEqual,
 		},
+		{
+			name: "PasswordTooWeak",
+			c:    PasswordTooWeak,
+			want: PasswordTooWeak,
+		},
+		{
+			name: "PasswordReused",
+			c:    PasswordReused,
+			want: PasswordReused,
+		},
+		{
+			name: "PasswordChangeRequired",
+			c:    PasswordChangeRequired,
+			want: PasswordChangeRequired,
+		},
+		{
+			name: "TotpRequired",
+			c:    TotpRequired,
+			want: TotpRequired,
+		},
+		{
+			name: "TotpNotEnrolled",
+			c:    TotpNotEnrolled,
+			want: TotpNotEnrolled,
+		},
+		{
+			name: "TotpAlreadyEnrolled",
+			c:    TotpAlreadyEnrolled,
+			want: TotpAlreadyEnrolled,
+		},
 		{
 			name: "Encrypt",
 			c:    Encrypt,
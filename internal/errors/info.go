@@ -96,6 +96,18 @@ var errorCodeInfo = map[Code]Info{
 		Message: "subtype already registered",
 		Kind:    Parameter,
 	},
+	SessionLimitExceeded: {
+		Message: "session limit exceeded",
+		Kind:    State,
+	},
+	SessionTimeLimitExceeded: {
+		Message: "session time limit exceeded",
+		Kind:    State,
+	},
+	SessionSelfApproval: {
+		Message: "session cannot be approved by its own user",
+		Kind:    Parameter,
+	},
 	InvalidDynamicCredential: {
 		Message: "dynamic credential for session is in an invalid state",
 		Kind:    Integrity,
@@ -116,6 +128,30 @@ var errorCodeInfo = map[Code]Info{
 		Message: "old and new password are equal",
 		Kind:    Password,
 	},
+	PasswordTooWeak: {
+		Message: "does not meet complexity requirements",
+		Kind:    Password,
+	},
+	PasswordReused: {
+		Message: "password was used recently",
+		Kind:    Password,
+	},
+	PasswordChangeRequired: {
+		Message: "password change required",
+		Kind:    Password,
+	},
+	TotpRequired: {
+		Message: "totp code required",
+		Kind:    Password,
+	},
+	TotpNotEnrolled: {
+		Message: "totp not enrolled",
+		Kind:    Password,
+	},
+	TotpAlreadyEnrolled: {
+		Message: "totp already enrolled",
+		Kind:    Password,
+	},
 	Encrypt: {
 		Message: "error occurred during encrypt",
 		Kind:    Encryption,
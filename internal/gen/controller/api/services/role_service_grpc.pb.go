@@ -79,6 +79,13 @@ type RoleServiceClient interface {
 	// grants will be removed. If missing, malformed, or references a non-existing
 	// resource, an error is returned.
 	RemoveRoleGrants(ctx context.Context, in *RemoveRoleGrantsRequest, opts ...grpc.CallOption) (*RemoveRoleGrantsResponse, error)
+	// ExplainRoles explains whether the grants of the Roles of a User authorize an
+	// action on a resource, without performing the action. The provided request
+	// must include the scope ID containing the resource, the User ID and the
+	// action. If the resource ID is missing the explanation is for the collection
+	// of the resource type. The response includes the decision along with the
+	// grants and Roles which matched.
+	ExplainRoles(ctx context.Context, in *ExplainRolesRequest, opts ...grpc.CallOption) (*ExplainRolesResponse, error)
 }
 
 type roleServiceClient struct {
@@ -188,6 +195,15 @@ func (c *roleServiceClient) RemoveRoleGrants(ctx context.Context, in *RemoveRole
 	return out, nil
 }
 
+func (c *roleServiceClient) ExplainRoles(ctx context.Context, in *ExplainRolesRequest, opts ...grpc.CallOption) (*ExplainRolesResponse, error) {
+	out := new(ExplainRolesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/ExplainRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoleServiceServer is the server API for RoleService service.
 // All implementations must embed UnimplementedRoleServiceServer
 // for forward compatibility
@@ -253,6 +269,13 @@ type RoleServiceServer interface {
 	// grants will be removed. If missing, malformed, or references a non-existing
 	// resource, an error is returned.
 	RemoveRoleGrants(context.Context, *RemoveRoleGrantsRequest) (*RemoveRoleGrantsResponse, error)
+	// ExplainRoles explains whether the grants of the Roles of a User authorize an
+	// action on a resource, without performing the action. The provided request
+	// must include the scope ID containing the resource, the User ID and the
+	// action. If the resource ID is missing the explanation is for the collection
+	// of the resource type. The response includes the decision along with the
+	// grants and Roles which matched.
+	ExplainRoles(context.Context, *ExplainRolesRequest) (*ExplainRolesResponse, error)
 	mustEmbedUnimplementedRoleServiceServer()
 }
 
@@ -293,6 +316,9 @@ func (UnimplementedRoleServiceServer) SetRoleGrants(context.Context, *SetRoleGra
 func (UnimplementedRoleServiceServer) RemoveRoleGrants(context.Context, *RemoveRoleGrantsRequest) (*RemoveRoleGrantsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleGrants not implemented")
 }
+func (UnimplementedRoleServiceServer) ExplainRoles(context.Context, *ExplainRolesRequest) (*ExplainRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainRoles not implemented")
+}
 func (UnimplementedRoleServiceServer) mustEmbedUnimplementedRoleServiceServer() {}
 
 // UnsafeRoleServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -504,6 +530,24 @@ func _RoleService_RemoveRoleGrants_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoleService_ExplainRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).ExplainRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/ExplainRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).ExplainRoles(ctx, req.(*ExplainRolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoleService_ServiceDesc is the grpc.ServiceDesc for RoleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -555,6 +599,10 @@ var RoleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRoleGrants",
 			Handler:    _RoleService_RemoveRoleGrants_Handler,
 		},
+		{
+			MethodName: "ExplainRoles",
+			Handler:    _RoleService_ExplainRoles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/role_service.proto",
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package services
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SessionPolicyServiceClient is the client API for SessionPolicyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SessionPolicyServiceClient interface {
+	// GetSessionPolicy returns a stored Session Policy if present. The provided
+	// request must include the Session Policy id and if it is missing, malformed
+	// or referencing a non existing resource an error is returned.
+	GetSessionPolicy(ctx context.Context, in *GetSessionPolicyRequest, opts ...grpc.CallOption) (*GetSessionPolicyResponse, error)
+	// ListSessionPolicies returns a list of stored Session Policies which exist
+	// inside the provided scope id. If that id is missing, malformed, or
+	// references a non-existing scope, an error is returned.
+	ListSessionPolicies(ctx context.Context, in *ListSessionPoliciesRequest, opts ...grpc.CallOption) (*ListSessionPoliciesResponse, error)
+	// CreateSessionPolicy creates and stores a Session Policy in boundary. The
+	// provided request must include the org or project scope ID in which the
+	// Session Policy will be created. If the scope ID is missing, malformed or
+	// references a non existing resource, an error is returned. If a name is
+	// provided that is in use in another Session Policy in the same scope, an
+	// error is returned.
+	CreateSessionPolicy(ctx context.Context, in *CreateSessionPolicyRequest, opts ...grpc.CallOption) (*CreateSessionPolicyResponse, error)
+	// UpdateSessionPolicy updates an existing Session Policy in boundary. The
+	// provided Session Policy must not have any read only fields set. The update
+	// mask must be included in the request and contain at least 1 mutable
+	// field. To unset a field's value, include the field in the update mask and
+	// don't set it in the provided Session Policy. An error is returned if the
+	// Session Policy id is missing or reference a non-existing resource. An
+	// error is also returned if the request attempts to update the name to one
+	// that is already used by another Session Policy in the same scope.
+	UpdateSessionPolicy(ctx context.Context, in *UpdateSessionPolicyRequest, opts ...grpc.CallOption) (*UpdateSessionPolicyResponse, error)
+	// DeleteSessionPolicy removes a Session Policy from Boundary. If the
+	// provided Session Policy ID is malformed or not provided an error is
+	// returned.
+	DeleteSessionPolicy(ctx context.Context, in *DeleteSessionPolicyRequest, opts ...grpc.CallOption) (*DeleteSessionPolicyResponse, error)
+}
+
+type sessionPolicyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSessionPolicyServiceClient(cc grpc.ClientConnInterface) SessionPolicyServiceClient {
+	return &sessionPolicyServiceClient{cc}
+}
+
+func (c *sessionPolicyServiceClient) GetSessionPolicy(ctx context.Context, in *GetSessionPolicyRequest, opts ...grpc.CallOption) (*GetSessionPolicyResponse, error) {
+	out := new(GetSessionPolicyResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionPolicyService/GetSessionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionPolicyServiceClient) ListSessionPolicies(ctx context.Context, in *ListSessionPoliciesRequest, opts ...grpc.CallOption) (*ListSessionPoliciesResponse, error) {
+	out := new(ListSessionPoliciesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionPolicyService/ListSessionPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionPolicyServiceClient) CreateSessionPolicy(ctx context.Context, in *CreateSessionPolicyRequest, opts ...grpc.CallOption) (*CreateSessionPolicyResponse, error) {
+	out := new(CreateSessionPolicyResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionPolicyService/CreateSessionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionPolicyServiceClient) UpdateSessionPolicy(ctx context.Context, in *UpdateSessionPolicyRequest, opts ...grpc.CallOption) (*UpdateSessionPolicyResponse, error) {
+	out := new(UpdateSessionPolicyResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionPolicyService/UpdateSessionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionPolicyServiceClient) DeleteSessionPolicy(ctx context.Context, in *DeleteSessionPolicyRequest, opts ...grpc.CallOption) (*DeleteSessionPolicyResponse, error) {
+	out := new(DeleteSessionPolicyResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionPolicyService/DeleteSessionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SessionPolicyServiceServer is the server API for SessionPolicyService service.
+// All implementations must embed UnimplementedSessionPolicyServiceServer
+// for forward compatibility
+type SessionPolicyServiceServer interface {
+	// GetSessionPolicy returns a stored Session Policy if present. The provided
+	// request must include the Session Policy id and if it is missing, malformed
+	// or referencing a non existing resource an error is returned.
+	GetSessionPolicy(context.Context, *GetSessionPolicyRequest) (*GetSessionPolicyResponse, error)
+	// ListSessionPolicies returns a list of stored Session Policies which exist
+	// inside the provided scope id. If that id is missing, malformed, or
+	// references a non-existing scope, an error is returned.
+	ListSessionPolicies(context.Context, *ListSessionPoliciesRequest) (*ListSessionPoliciesResponse, error)
+	// CreateSessionPolicy creates and stores a Session Policy in boundary. The
+	// provided request must include the org or project scope ID in which the
+	// Session Policy will be created. If the scope ID is missing, malformed or
+	// references a non existing resource, an error is returned. If a name is
+	// provided that is in use in another Session Policy in the same scope, an
+	// error is returned.
+	CreateSessionPolicy(context.Context, *CreateSessionPolicyRequest) (*CreateSessionPolicyResponse, error)
+	// UpdateSessionPolicy updates an existing Session Policy in boundary. The
+	// provided Session Policy must not have any read only fields set. The update
+	// mask must be included in the request and contain at least 1 mutable
+	// field. To unset a field's value, include the field in the update mask and
+	// don't set it in the provided Session Policy. An error is returned if the
+	// Session Policy id is missing or reference a non-existing resource. An
+	// error is also returned if the request attempts to update the name to one
+	// that is already used by another Session Policy in the same scope.
+	UpdateSessionPolicy(context.Context, *UpdateSessionPolicyRequest) (*UpdateSessionPolicyResponse, error)
+	// DeleteSessionPolicy removes a Session Policy from Boundary. If the
+	// provided Session Policy ID is malformed or not provided an error is
+	// returned.
+	DeleteSessionPolicy(context.Context, *DeleteSessionPolicyRequest) (*DeleteSessionPolicyResponse, error)
+	mustEmbedUnimplementedSessionPolicyServiceServer()
+}
+
+// UnimplementedSessionPolicyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSessionPolicyServiceServer struct {
+}
+
+func (UnimplementedSessionPolicyServiceServer) GetSessionPolicy(context.Context, *GetSessionPolicyRequest) (*GetSessionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionPolicy not implemented")
+}
+func (UnimplementedSessionPolicyServiceServer) ListSessionPolicies(context.Context, *ListSessionPoliciesRequest) (*ListSessionPoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessionPolicies not implemented")
+}
+func (UnimplementedSessionPolicyServiceServer) CreateSessionPolicy(context.Context, *CreateSessionPolicyRequest) (*CreateSessionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSessionPolicy not implemented")
+}
+func (UnimplementedSessionPolicyServiceServer) UpdateSessionPolicy(context.Context, *UpdateSessionPolicyRequest) (*UpdateSessionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSessionPolicy not implemented")
+}
+func (UnimplementedSessionPolicyServiceServer) DeleteSessionPolicy(context.Context, *DeleteSessionPolicyRequest) (*DeleteSessionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSessionPolicy not implemented")
+}
+func (UnimplementedSessionPolicyServiceServer) mustEmbedUnimplementedSessionPolicyServiceServer() {}
+
+// UnsafeSessionPolicyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SessionPolicyServiceServer will
+// result in compilation errors.
+type UnsafeSessionPolicyServiceServer interface {
+	mustEmbedUnimplementedSessionPolicyServiceServer()
+}
+
+func RegisterSessionPolicyServiceServer(s grpc.ServiceRegistrar, srv SessionPolicyServiceServer) {
+	s.RegisterService(&SessionPolicyService_ServiceDesc, srv)
+}
+
+func _SessionPolicyService_GetSessionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionPolicyServiceServer).GetSessionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionPolicyService/GetSessionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionPolicyServiceServer).GetSessionPolicy(ctx, req.(*GetSessionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionPolicyService_ListSessionPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionPolicyServiceServer).ListSessionPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionPolicyService/ListSessionPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionPolicyServiceServer).ListSessionPolicies(ctx, req.(*ListSessionPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionPolicyService_CreateSessionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionPolicyServiceServer).CreateSessionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionPolicyService/CreateSessionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionPolicyServiceServer).CreateSessionPolicy(ctx, req.(*CreateSessionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionPolicyService_UpdateSessionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSessionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionPolicyServiceServer).UpdateSessionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionPolicyService/UpdateSessionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionPolicyServiceServer).UpdateSessionPolicy(ctx, req.(*UpdateSessionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionPolicyService_DeleteSessionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSessionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionPolicyServiceServer).DeleteSessionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionPolicyService/DeleteSessionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionPolicyServiceServer).DeleteSessionPolicy(ctx, req.(*DeleteSessionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SessionPolicyService_ServiceDesc is the grpc.ServiceDesc for SessionPolicyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SessionPolicyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "controller.api.services.v1.SessionPolicyService",
+	HandlerType: (*SessionPolicyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSessionPolicy",
+			Handler:    _SessionPolicyService_GetSessionPolicy_Handler,
+		},
+		{
+			MethodName: "ListSessionPolicies",
+			Handler:    _SessionPolicyService_ListSessionPolicies_Handler,
+		},
+		{
+			MethodName: "CreateSessionPolicy",
+			Handler:    _SessionPolicyService_CreateSessionPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateSessionPolicy",
+			Handler:    _SessionPolicyService_UpdateSessionPolicy_Handler,
+		},
+		{
+			MethodName: "DeleteSessionPolicy",
+			Handler:    _SessionPolicyService_DeleteSessionPolicy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "controller/api/services/v1/session_policy_service.proto",
+}
@@ -28,10 +28,22 @@ type SessionServiceClient interface {
 	// the Sessions being retrieved. If the scope ID is missing, malformed, or
 	// reference a non existing scope, an error is returned.
 	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	// ListMySessions returns the Sessions of the authenticated user which are
+	// not terminated, across all scopes, with their connections. Unlike
+	// ListSessions it doesn't require a grant for the list action.
+	ListMySessions(ctx context.Context, in *ListMySessionsRequest, opts ...grpc.CallOption) (*ListMySessionsResponse, error)
 	// CancelSession cancels an existing Session in boundary.  An error
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error)
+	// ApproveSession approves a Session which is pending approval. An
+	// error is returned if the Session is not pending approval or if the
+	// request is made by the User of the Session.
+	ApproveSession(ctx context.Context, in *ApproveSessionRequest, opts ...grpc.CallOption) (*ApproveSessionResponse, error)
+	// DenySession denies a Session which is pending approval, which
+	// terminates it. An error is returned if the Session is not pending
+	// approval or if the request is made by the User of the Session.
+	DenySession(ctx context.Context, in *DenySessionRequest, opts ...grpc.CallOption) (*DenySessionResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -60,6 +72,15 @@ func (c *sessionServiceClient) ListSessions(ctx context.Context, in *ListSession
 	return out, nil
 }
 
+func (c *sessionServiceClient) ListMySessions(ctx context.Context, in *ListMySessionsRequest, opts ...grpc.CallOption) (*ListMySessionsResponse, error) {
+	out := new(ListMySessionsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/ListMySessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sessionServiceClient) CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error) {
 	out := new(CancelSessionResponse)
 	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/CancelSession", in, out, opts...)
@@ -69,6 +90,24 @@ func (c *sessionServiceClient) CancelSession(ctx context.Context, in *CancelSess
 	return out, nil
 }
 
+func (c *sessionServiceClient) ApproveSession(ctx context.Context, in *ApproveSessionRequest, opts ...grpc.CallOption) (*ApproveSessionResponse, error) {
+	out := new(ApproveSessionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/ApproveSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) DenySession(ctx context.Context, in *DenySessionRequest, opts ...grpc.CallOption) (*DenySessionResponse, error) {
+	out := new(DenySessionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/DenySession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -83,10 +122,22 @@ type SessionServiceServer interface {
 	// the Sessions being retrieved. If the scope ID is missing, malformed, or
 	// reference a non existing scope, an error is returned.
 	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	// ListMySessions returns the Sessions of the authenticated user which are
+	// not terminated, across all scopes, with their connections. Unlike
+	// ListSessions it doesn't require a grant for the list action.
+	ListMySessions(context.Context, *ListMySessionsRequest) (*ListMySessionsResponse, error)
 	// CancelSession cancels an existing Session in boundary.  An error
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error)
+	// ApproveSession approves a Session which is pending approval. An
+	// error is returned if the Session is not pending approval or if the
+	// request is made by the User of the Session.
+	ApproveSession(context.Context, *ApproveSessionRequest) (*ApproveSessionResponse, error)
+	// DenySession denies a Session which is pending approval, which
+	// terminates it. An error is returned if the Session is not pending
+	// approval or if the request is made by the User of the Session.
+	DenySession(context.Context, *DenySessionRequest) (*DenySessionResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -100,9 +151,18 @@ func (UnimplementedSessionServiceServer) GetSession(context.Context, *GetSession
 func (UnimplementedSessionServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
 }
+func (UnimplementedSessionServiceServer) ListMySessions(context.Context, *ListMySessionsRequest) (*ListMySessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMySessions not implemented")
+}
 func (UnimplementedSessionServiceServer) CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelSession not implemented")
 }
+func (UnimplementedSessionServiceServer) ApproveSession(context.Context, *ApproveSessionRequest) (*ApproveSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveSession not implemented")
+}
+func (UnimplementedSessionServiceServer) DenySession(context.Context, *DenySessionRequest) (*DenySessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenySession not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +212,24 @@ func _SessionService_ListSessions_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_ListMySessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMySessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ListMySessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionService/ListMySessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ListMySessions(ctx, req.(*ListMySessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SessionService_CancelSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelSessionRequest)
 	if err := dec(in); err != nil {
@@ -170,6 +248,42 @@ func _SessionService_CancelSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_ApproveSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ApproveSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionService/ApproveSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ApproveSession(ctx, req.(*ApproveSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_DenySession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenySessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).DenySession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionService/DenySession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).DenySession(ctx, req.(*DenySessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -185,10 +299,22 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSessions",
 			Handler:    _SessionService_ListSessions_Handler,
 		},
+		{
+			MethodName: "ListMySessions",
+			Handler:    _SessionService_ListMySessions_Handler,
+		},
 		{
 			MethodName: "CancelSession",
 			Handler:    _SessionService_CancelSession_Handler,
 		},
+		{
+			MethodName: "ApproveSession",
+			Handler:    _SessionService_ApproveSession_Handler,
+		},
+		{
+			MethodName: "DenySession",
+			Handler:    _SessionService_DenySession_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/session_service.proto",
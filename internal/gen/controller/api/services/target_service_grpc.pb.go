@@ -28,6 +28,10 @@ type TargetServiceClient interface {
 	// the Targets being retrieved. If the scope ID is missing, malformed, or
 	// reference a non existing scope, an error is returned.
 	ListTargets(ctx context.Context, in *ListTargetsRequest, opts ...grpc.CallOption) (*ListTargetsResponse, error)
+	// ListMyTargets returns the Targets, across all scopes, on which the
+	// authenticated user is allowed to authorize a session. Unlike ListTargets
+	// it doesn't require a grant for the list action.
+	ListMyTargets(ctx context.Context, in *ListMyTargetsRequest, opts ...grpc.CallOption) (*ListMyTargetsResponse, error)
 	// CreateTarget creates and stores a Target in boundary.  The provided
 	// request must include the scope id in which the Target will be created.
 	// If the scope id is missing, malformed or references a non existing
@@ -173,6 +177,15 @@ func (c *targetServiceClient) ListTargets(ctx context.Context, in *ListTargetsRe
 	return out, nil
 }
 
+func (c *targetServiceClient) ListMyTargets(ctx context.Context, in *ListMyTargetsRequest, opts ...grpc.CallOption) (*ListMyTargetsResponse, error) {
+	out := new(ListMyTargetsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/ListMyTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *targetServiceClient) CreateTarget(ctx context.Context, in *CreateTargetRequest, opts ...grpc.CallOption) (*CreateTargetResponse, error) {
 	out := new(CreateTargetResponse)
 	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/CreateTarget", in, out, opts...)
@@ -334,6 +347,10 @@ type TargetServiceServer interface {
 	// the Targets being retrieved. If the scope ID is missing, malformed, or
 	// reference a non existing scope, an error is returned.
 	ListTargets(context.Context, *ListTargetsRequest) (*ListTargetsResponse, error)
+	// ListMyTargets returns the Targets, across all scopes, on which the
+	// authenticated user is allowed to authorize a session. Unlike ListTargets
+	// it doesn't require a grant for the list action.
+	ListMyTargets(context.Context, *ListMyTargetsRequest) (*ListMyTargetsResponse, error)
 	// CreateTarget creates and stores a Target in boundary.  The provided
 	// request must include the scope id in which the Target will be created.
 	// If the scope id is missing, malformed or references a non existing
@@ -464,6 +481,9 @@ func (UnimplementedTargetServiceServer) GetTarget(context.Context, *GetTargetReq
 func (UnimplementedTargetServiceServer) ListTargets(context.Context, *ListTargetsRequest) (*ListTargetsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTargets not implemented")
 }
+func (UnimplementedTargetServiceServer) ListMyTargets(context.Context, *ListMyTargetsRequest) (*ListMyTargetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyTargets not implemented")
+}
 func (UnimplementedTargetServiceServer) CreateTarget(context.Context, *CreateTargetRequest) (*CreateTargetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateTarget not implemented")
 }
@@ -561,6 +581,24 @@ func _TargetService_ListTargets_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TargetService_ListMyTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).ListMyTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/ListMyTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).ListMyTargets(ctx, req.(*ListMyTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TargetService_CreateTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateTargetRequest)
 	if err := dec(in); err != nil {
@@ -864,6 +902,10 @@ var TargetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTargets",
 			Handler:    _TargetService_ListTargets_Handler,
 		},
+		{
+			MethodName: "ListMyTargets",
+			Handler:    _TargetService_ListMyTargets_Handler,
+		},
 		{
 			MethodName: "CreateTarget",
 			Handler:    _TargetService_CreateTarget_Handler,
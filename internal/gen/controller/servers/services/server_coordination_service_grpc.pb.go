@@ -22,6 +22,14 @@ type ServerCoordinationServiceClient interface {
 	// returns the status response which includes the changes the controller would like to make to
 	// jobs as well as provide a list of the controllers in the system.
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// RegisterWorker activates the worker which authenticated the connection
+	// with its activation token and returns the first credential of the worker.
+	// An activation token can only be used once.
+	RegisterWorker(ctx context.Context, in *RegisterWorkerRequest, opts ...grpc.CallOption) (*RegisterWorkerResponse, error)
+	// RotateWorkerCredential returns a new credential for the worker which
+	// authenticated the connection with one of its credentials. The previous
+	// credentials of the worker expire shortly after.
+	RotateWorkerCredential(ctx context.Context, in *RotateWorkerCredentialRequest, opts ...grpc.CallOption) (*RotateWorkerCredentialResponse, error)
 }
 
 type serverCoordinationServiceClient struct {
@@ -41,6 +49,24 @@ func (c *serverCoordinationServiceClient) Status(ctx context.Context, in *Status
 	return out, nil
 }
 
+func (c *serverCoordinationServiceClient) RegisterWorker(ctx context.Context, in *RegisterWorkerRequest, opts ...grpc.CallOption) (*RegisterWorkerResponse, error) {
+	out := new(RegisterWorkerResponse)
+	err := c.cc.Invoke(ctx, "/controller.servers.services.v1.ServerCoordinationService/RegisterWorker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverCoordinationServiceClient) RotateWorkerCredential(ctx context.Context, in *RotateWorkerCredentialRequest, opts ...grpc.CallOption) (*RotateWorkerCredentialResponse, error) {
+	out := new(RotateWorkerCredentialResponse)
+	err := c.cc.Invoke(ctx, "/controller.servers.services.v1.ServerCoordinationService/RotateWorkerCredential", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServerCoordinationServiceServer is the server API for ServerCoordinationService service.
 // All implementations must embed UnimplementedServerCoordinationServiceServer
 // for forward compatibility
@@ -49,6 +75,14 @@ type ServerCoordinationServiceServer interface {
 	// returns the status response which includes the changes the controller would like to make to
 	// jobs as well as provide a list of the controllers in the system.
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// RegisterWorker activates the worker which authenticated the connection
+	// with its activation token and returns the first credential of the worker.
+	// An activation token can only be used once.
+	RegisterWorker(context.Context, *RegisterWorkerRequest) (*RegisterWorkerResponse, error)
+	// RotateWorkerCredential returns a new credential for the worker which
+	// authenticated the connection with one of its credentials. The previous
+	// credentials of the worker expire shortly after.
+	RotateWorkerCredential(context.Context, *RotateWorkerCredentialRequest) (*RotateWorkerCredentialResponse, error)
 	mustEmbedUnimplementedServerCoordinationServiceServer()
 }
 
@@ -59,6 +93,12 @@ type UnimplementedServerCoordinationServiceServer struct {
 func (UnimplementedServerCoordinationServiceServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedServerCoordinationServiceServer) RegisterWorker(context.Context, *RegisterWorkerRequest) (*RegisterWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWorker not implemented")
+}
+func (UnimplementedServerCoordinationServiceServer) RotateWorkerCredential(context.Context, *RotateWorkerCredentialRequest) (*RotateWorkerCredentialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateWorkerCredential not implemented")
+}
 func (UnimplementedServerCoordinationServiceServer) mustEmbedUnimplementedServerCoordinationServiceServer() {
 }
 
@@ -91,6 +131,42 @@ func _ServerCoordinationService_Status_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerCoordinationService_RegisterWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerCoordinationServiceServer).RegisterWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.servers.services.v1.ServerCoordinationService/RegisterWorker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerCoordinationServiceServer).RegisterWorker(ctx, req.(*RegisterWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerCoordinationService_RotateWorkerCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateWorkerCredentialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerCoordinationServiceServer).RotateWorkerCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.servers.services.v1.ServerCoordinationService/RotateWorkerCredential",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerCoordinationServiceServer).RotateWorkerCredential(ctx, req.(*RotateWorkerCredentialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServerCoordinationService_ServiceDesc is the grpc.ServiceDesc for ServerCoordinationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -102,6 +178,14 @@ var ServerCoordinationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _ServerCoordinationService_Status_Handler,
 		},
+		{
+			MethodName: "RegisterWorker",
+			Handler:    _ServerCoordinationService_RegisterWorker_Handler,
+		},
+		{
+			MethodName: "RotateWorkerCredential",
+			Handler:    _ServerCoordinationService_RotateWorkerCredential_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/servers/services/v1/server_coordination_service.proto",
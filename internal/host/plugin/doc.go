@@ -0,0 +1,36 @@
+// Package plugin provides a host, a host catalog, and a host set suitable
+// for hosts which are discovered dynamically from an external provider.
+//
+// A host catalog is backed by a HostPlugin, selected by the catalog's
+// plugin name. The plugin lists the hosts of the provider which match the
+// attributes of each host set in the catalog. Hosts are owned by the host
+// catalog and are created, updated, and deleted only by synchronizing the
+// catalog with its plugin. A host set contains references to the hosts
+// which matched its attributes the last time it was synchronized.
+//
+// Synchronization
+//
+// The members of host sets are synchronized with the plugin by a job
+// registered with the scheduler by RegisterJobs. A host set is
+// synchronized when it is created and then periodically. When any host set
+// in a catalog needs to be synchronized, all host sets in the catalog are
+// synchronized together so hosts which no longer match any host set can
+// be deleted.
+//
+// Repository
+//
+// A repository provides methods for creating, retrieving, and deleting
+// host catalogs and host sets, and for retrieving hosts. A new repository
+// should be created for each transaction. For example:
+//
+//  var wrapper wrapping.Wrapper
+//  ... init wrapper...
+//
+//  // db implements both the reader and writer interfaces.
+//  db, _ := db.Open(db.Postgres, url)
+//
+//  var repo *plugin.Repository
+//
+//  repo, _ = plugin.NewRepository(db, db, kms, plugins)
+//  catalog, _ := repo.LookupCatalog(ctx, catalogId)
+package plugin
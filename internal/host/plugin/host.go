@@ -0,0 +1,68 @@
+package plugin
+
+import (
+	"github.com/hashicorp/boundary/internal/host/plugin/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// A Host is a host returned by the plugin of its catalog. Hosts are only
+// created, updated, and deleted by synchronizing the catalog with its
+// plugin.
+type Host struct {
+	*store.Host
+	tableName string `gorm:"-"`
+}
+
+// newHost creates a new in memory Host for the result returned by the
+// plugin of catalogId.
+func newHost(catalogId string, in *ListHostsResult) *Host {
+	return &Host{
+		Host: &store.Host{
+			CatalogId:   catalogId,
+			ExternalId:  in.ExternalId,
+			Name:        in.Name,
+			Description: in.Description,
+			Address:     in.Address,
+		},
+	}
+}
+
+// TableName returns the table name for the host.
+func (h *Host) TableName() string {
+	if h.tableName != "" {
+		return h.tableName
+	}
+	return "host_plugin_host"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (h *Host) SetTableName(n string) {
+	h.tableName = n
+}
+
+func allocHost() *Host {
+	return &Host{
+		Host: &store.Host{},
+	}
+}
+
+func (h *Host) clone() *Host {
+	cp := proto.Clone(h.Host)
+	return &Host{
+		Host: cp.(*store.Host),
+	}
+}
+
+func (h *Host) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{h.PublicId},
+		"resource-type":      []string{"plugin-host"},
+		"op-type":            []string{op.String()},
+	}
+	if h.CatalogId != "" {
+		metadata["catalog-id"] = []string{h.CatalogId}
+	}
+	return metadata
+}
@@ -0,0 +1,166 @@
+package plugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	setSyncJobName = "plugin_host_set_sync"
+
+	// setSyncJobRunInterval is the interval at which the set sync job
+	// checks for host sets which need to be synchronized.
+	setSyncJobRunInterval = time.Minute
+
+	// setSyncInterval is the maximum age of the members of a host set
+	// before the host set is synchronized with its catalog's plugin.
+	setSyncInterval = 5 * time.Minute
+)
+
+// RegisterJobs registers plugin host related jobs with the provided
+// scheduler. plugins are the host plugins which can back a catalog, keyed
+// by plugin name.
+func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, r db.Reader, w db.Writer, kms *kms.Kms, plugins map[string]HostPlugin) error {
+	const op = "plugin.RegisterJobs"
+	setSync, err := newSetSyncJob(ctx, r, w, kms, scheduler, plugins)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, setSync); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("set sync job"))
+	}
+	return nil
+}
+
+// SetSyncJob is the recurring job that synchronizes the hosts and the
+// members of the host sets of plugin host catalogs with the catalog's
+// plugin. The SetSyncJob is not thread safe, an attempt to Run the job
+// concurrently will result in an JobAlreadyRunning error.
+type SetSyncJob struct {
+	reader    db.Reader
+	writer    db.Writer
+	kms       *kms.Kms
+	scheduler *scheduler.Scheduler
+	plugins   map[string]HostPlugin
+
+	running      ua.Bool
+	numCatalogs  int
+	numProcessed int
+}
+
+// newSetSyncJob creates a new in-memory SetSyncJob.
+func newSetSyncJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms, scheduler *scheduler.Scheduler, plugins map[string]HostPlugin) (*SetSyncJob, error) {
+	const op = "plugin.newSetSyncJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	case scheduler == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scheduler")
+	}
+
+	return &SetSyncJob{
+		reader:    r,
+		writer:    w,
+		kms:       kms,
+		scheduler: scheduler,
+		plugins:   plugins,
+	}, nil
+}
+
+// Status returns the current status of the set sync job. Total is the
+// total number of catalogs that are to be synchronized. Completed is the
+// number of catalogs already synchronized.
+func (r *SetSyncJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: r.numProcessed,
+		Total:     r.numCatalogs,
+	}
+}
+
+// Run queries the plugin host repo for the catalogs with host sets which
+// need to be synchronized and synchronizes each catalog with its plugin.
+// An error synchronizing one catalog does not prevent the other catalogs
+// from being synchronized. Can not be run in parallel, if Run is invoked
+// while already running an error with code JobAlreadyRunning will be
+// returned.
+func (r *SetSyncJob) Run(ctx context.Context) error {
+	const op = "plugin.(SetSyncJob).Run"
+	if !r.running.CAS(r.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer r.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	catalogIds, err := r.catalogsToSync(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	// Set numProcessed and numCatalogs for status report
+	r.numProcessed, r.numCatalogs = 0, len(catalogIds)
+
+	repo, err := NewRepository(r.reader, r.writer, r.kms, r.scheduler, r.plugins)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, id := range catalogIds {
+		// Verify context is not done before syncing next catalog
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := repo.SyncCatalog(ctx, id); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error syncing host catalog", "catalog id", id))
+		}
+		r.numProcessed++
+	}
+	return nil
+}
+
+func (r *SetSyncJob) catalogsToSync(ctx context.Context) ([]string, error) {
+	const op = "plugin.(SetSyncJob).catalogsToSync"
+	rows, err := r.reader.Query(ctx, catalogsToSyncQuery, []interface{}{int(setSyncInterval.Seconds())})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// NextRunIn returns the default run frequency of the set sync job.
+func (r *SetSyncJob) NextRunIn() (time.Duration, error) {
+	return setSyncJobRunInterval, nil
+}
+
+// Name is the unique name of the job.
+func (r *SetSyncJob) Name() string {
+	return setSyncJobName
+}
+
+// Description is the human readable description of the job.
+func (r *SetSyncJob) Description() string {
+	return "Periodically synchronizes the hosts and host set members of plugin host catalogs with the catalog's plugin."
+}
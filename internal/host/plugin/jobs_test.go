@@ -0,0 +1,128 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSetSyncJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+
+	type args struct {
+		r         db.Reader
+		w         db.Writer
+		kms       *kms.Kms
+		scheduler *scheduler.Scheduler
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "nil reader",
+			wantErr: true,
+		},
+		{
+			name:    "nil writer",
+			args:    args{r: rw},
+			wantErr: true,
+		},
+		{
+			name:    "nil kms",
+			args:    args{r: rw, w: rw},
+			wantErr: true,
+		},
+		{
+			name:    "nil scheduler",
+			args:    args{r: rw, w: rw, kms: kmsCache},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			args: args{r: rw, w: rw, kms: kmsCache, scheduler: sche},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := newSetSyncJob(ctx, tt.args.r, tt.args.w, tt.args.kms, tt.args.scheduler, nil)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(got)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(setSyncJobName, got.Name())
+			assert.NotEmpty(got.Description())
+		})
+	}
+}
+
+func TestSetSyncJob_Run(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	plg := NewTestPlugin(
+		&TestPluginHost{ExternalId: "i-1", Address: "10.0.0.1", Tags: map[string]string{"env": "prod"}},
+	)
+	r, err := newSetSyncJob(ctx, rw, rw, kmsCache, sche, TestPlugins(plg))
+	require.NoError(err)
+	require.NoError(sche.RegisterJob(ctx, r))
+
+	// Nothing to sync
+	require.NoError(r.Run(ctx))
+	assert.Equal(0, r.Status().Total)
+
+	cat := TestCatalog(t, conn, prj.GetPublicId())
+	set := TestSet(t, conn, cat.GetPublicId(), map[string]string{"env": "prod"})
+
+	require.NoError(r.Run(ctx))
+	assert.Equal(1, r.Status().Total)
+	assert.Equal(1, r.Status().Completed)
+
+	repo, err := NewRepository(rw, rw, kmsCache, sche, TestPlugins(plg))
+	require.NoError(err)
+	_, hosts, err := repo.LookupSet(ctx, set.GetPublicId())
+	require.NoError(err)
+	assert.Equal([]string{"10.0.0.1"}, hostAddresses(hosts))
+
+	// The set was just synced so it is skipped until it is stale
+	require.NoError(r.Run(ctx))
+	assert.Equal(0, r.Status().Total)
+
+	// Make the set stale
+	_, err = rw.Exec(ctx, "update host_plugin_set set last_sync_time = wt_sub_seconds_from_now(?) where public_id = ?",
+		[]interface{}{int(setSyncInterval.Seconds()) + 1, set.GetPublicId()})
+	require.NoError(err)
+	plg.SetHosts(&TestPluginHost{ExternalId: "i-2", Address: "10.0.0.2", Tags: map[string]string{"env": "prod"}})
+	require.NoError(r.Run(ctx))
+	assert.Equal(1, r.Status().Total)
+	_, hosts, err = repo.LookupSet(ctx, set.GetPublicId())
+	require.NoError(err)
+	assert.Equal([]string{"10.0.0.2"}, hostAddresses(hosts))
+}
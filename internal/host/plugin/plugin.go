@@ -0,0 +1,31 @@
+package plugin
+
+import "context"
+
+// A HostPlugin provides the hosts for the host catalogs which reference
+// it by name.
+type HostPlugin interface {
+	// ListHosts returns the hosts of the provider which match the
+	// attributes of at least one of the sets. All of the sets belong to
+	// catalog. Each returned host must have a unique ExternalId and lists
+	// the ids of the sets it is a member of. A host which matches none of
+	// the sets must not be returned.
+	ListHosts(ctx context.Context, catalog *HostCatalog, sets []*HostSet) ([]*ListHostsResult, error)
+}
+
+// ListHostsResult is a host returned by a HostPlugin.
+type ListHostsResult struct {
+	// ExternalId is the id of the host in the provider. It is used to
+	// match the host with the host in the repository across syncs.
+	ExternalId string
+
+	// Name and Description are optional.
+	Name        string
+	Description string
+
+	// Address is the IP address or DNS name of the host.
+	Address string
+
+	// SetIds are the public ids of the sets the host is a member of.
+	SetIds []string
+}
@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/types/subtypes"
+)
+
+func init() {
+	if err := host.Register(Subtype, HostCatalogPrefix, HostSetPrefix, HostPrefix); err != nil {
+		panic(err)
+	}
+}
+
+// PublicId prefixes for the resources in the plugin package.
+const (
+	HostCatalogPrefix = "hcplg"
+	HostSetPrefix     = "hsplg"
+	HostPrefix        = "hplg"
+
+	Subtype = subtypes.Subtype("plugin")
+)
+
+func newHostCatalogId() (string, error) {
+	id, err := db.NewPublicId(HostCatalogPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "plugin.newHostCatalogId")
+	}
+	return id, nil
+}
+
+func newHostId() (string, error) {
+	id, err := db.NewPublicId(HostPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "plugin.newHostId")
+	}
+	return id, nil
+}
+
+func newHostSetId() (string, error) {
+	id, err := db.NewPublicId(HostSetPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "plugin.newHostSetId")
+	}
+	return id, nil
+}
@@ -0,0 +1,88 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// LookupHost will look up a host in the repository. If the host is not
+// found, it will return nil, nil. All options are ignored.
+func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Option) (*Host, error) {
+	const op = "plugin.(Repository).LookupHost"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	h := allocHost()
+	h.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, h); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", publicId)))
+	}
+	return h, nil
+}
+
+// ListHosts returns a slice of Hosts for the catalogId. WithLimit is the
+// only option supported.
+func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Option) ([]*Host, error) {
+	const op = "plugin.(Repository).ListHosts"
+	if catalogId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no catalog id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var hosts []*Host
+	err := r.reader.SearchWhere(ctx, &hosts, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return hosts, nil
+}
+
+const unlimited = -1
+
+func getHosts(ctx context.Context, reader db.Reader, setId string, limit int) ([]*Host, error) {
+	const whereNoLimit = `public_id in
+       ( select host_id
+           from host_plugin_set_member
+          where set_id = ?
+       )`
+
+	const whereLimit = `public_id in
+       ( select host_id
+           from host_plugin_set_member
+          where set_id = ?
+          limit ?
+       )`
+
+	params := []interface{}{setId}
+	var where string
+	switch limit {
+	case unlimited:
+		where = whereNoLimit
+	default:
+		where = whereLimit
+		params = append(params, limit)
+	}
+
+	var hosts []*Host
+	if err := reader.SearchWhere(ctx, &hosts,
+		where,
+		params,
+		db.WithLimit(limit),
+	); err != nil {
+		return nil, errors.Wrap(ctx, err, "plugin.getHosts")
+	}
+	if len(hosts) == 0 {
+		return nil, nil
+	}
+	return hosts, nil
+}
@@ -0,0 +1,345 @@
+package plugin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/plugin/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// SyncCatalog synchronizes the hosts of catalogId and the members of every
+// host set in the catalog with the hosts returned by the catalog's plugin.
+// Hosts returned by the plugin are created or updated, hosts no longer
+// returned by the plugin are deleted, and the members of each host set
+// are replaced with the hosts the plugin returned for the set, the way
+// static.(Repository).SetSetMembers replaces the members of a static host
+// set. Each synchronized host set is marked as synced.
+//
+// If the catalog does not exist, SyncCatalog returns nil. All options are
+// ignored.
+func (r *Repository) SyncCatalog(ctx context.Context, catalogId string, _ ...Option) error {
+	const op = "plugin.(Repository).SyncCatalog"
+	if catalogId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "no catalog id")
+	}
+	c, err := r.LookupCatalog(ctx, catalogId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if c == nil {
+		return nil
+	}
+	plg, ok := r.plugins[c.PluginName]
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown plugin %q for catalog %s", c.PluginName, catalogId))
+	}
+	sets, err := r.ListSets(ctx, catalogId, WithLimit(unlimited))
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	var results []*ListHostsResult
+	if len(sets) > 0 {
+		results, err = plg.ListHosts(ctx, c, sets)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("plugin %q failed to list hosts", c.PluginName)))
+		}
+	}
+	byExternalId, err := validateResults(ctx, sets, results)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	current, err := r.ListHosts(ctx, catalogId, WithLimit(unlimited))
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	// hostIds maps the external ids of the hosts returned by the plugin
+	// to their public ids.
+	hostIds := make(map[string]string, len(results))
+	var creates, deletes []interface{}
+	var updates []*hostUpdate
+	for _, h := range current {
+		res, ok := byExternalId[h.ExternalId]
+		if !ok {
+			deletes = append(deletes, h)
+			continue
+		}
+		hostIds[h.ExternalId] = h.PublicId
+		if u := newHostUpdate(h, res); u != nil {
+			updates = append(updates, u)
+		}
+	}
+	for _, res := range results {
+		if _, ok := hostIds[res.ExternalId]; ok {
+			continue
+		}
+		h := newHost(catalogId, res)
+		if h.PublicId, err = newHostId(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		hostIds[res.ExternalId] = h.PublicId
+		creates = append(creates, h)
+	}
+
+	// setMembers maps the public ids of the sets to the public ids of
+	// their members.
+	setMembers := make(map[string][]string, len(sets))
+	for _, res := range results {
+		for _, setId := range res.SetIds {
+			setMembers[setId] = append(setMembers[setId], hostIds[res.ExternalId])
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(reader db.Reader, w db.Writer) error {
+		var msgs []*oplog.Message
+		if len(creates) > 0 {
+			if err := w.CreateItems(ctx, creates, db.NewOplogMsgs(&msgs)); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create hosts"))
+			}
+		}
+		for _, u := range updates {
+			msg := new(oplog.Message)
+			rowsUpdated, err := w.Update(ctx, u.host.clone(), u.fieldMask, u.nullFields, db.NewOplogMsg(msg))
+			switch {
+			case err != nil:
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update host"))
+			case rowsUpdated > 1:
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			msgs = append(msgs, msg)
+		}
+		if len(deletes) > 0 {
+			rowsDeleted, err := w.DeleteItems(ctx, deletes, db.NewOplogMsgs(&msgs))
+			switch {
+			case err != nil:
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete hosts"))
+			case rowsDeleted != len(deletes):
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("hosts deleted %d did not match request for %d", rowsDeleted, len(deletes)))
+			}
+		}
+		if len(msgs) > 0 {
+			metadata := oplog.Metadata{
+				"resource-type": []string{"plugin-host"},
+				"op-type":       []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+				"catalog-id":    []string{c.PublicId},
+				"scope-id":      []string{c.ScopeId},
+			}
+			ticket, err := w.GetTicket(allocHost())
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+		}
+
+		for _, s := range sets {
+			if err := syncSetMembers(ctx, reader, w, oplogWrapper, s, setMembers[s.PublicId]); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in catalog %s", catalogId)))
+	}
+	return nil
+}
+
+// validateResults verifies the hosts returned by a plugin for sets and
+// returns them keyed by their external id.
+func validateResults(ctx context.Context, sets []*HostSet, results []*ListHostsResult) (map[string]*ListHostsResult, error) {
+	const op = "plugin.validateResults"
+	setIds := make(map[string]struct{}, len(sets))
+	for _, s := range sets {
+		setIds[s.PublicId] = struct{}{}
+	}
+	byExternalId := make(map[string]*ListHostsResult, len(results))
+	for _, res := range results {
+		switch {
+		case res == nil:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "plugin returned a nil host")
+		case res.ExternalId == "":
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "plugin returned a host with no external id")
+		case res.Address == "":
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("plugin returned host %q with no address", res.ExternalId))
+		case len(res.SetIds) == 0:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("plugin returned host %q with no set ids", res.ExternalId))
+		}
+		if _, ok := byExternalId[res.ExternalId]; ok {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("plugin returned host %q more than once", res.ExternalId))
+		}
+		for _, setId := range res.SetIds {
+			if _, ok := setIds[setId]; !ok {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("plugin returned host %q for unknown set %q", res.ExternalId, setId))
+			}
+		}
+		byExternalId[res.ExternalId] = res
+	}
+	return byExternalId, nil
+}
+
+// hostUpdate contains the changes to a host returned by a plugin.
+type hostUpdate struct {
+	host       *Host
+	fieldMask  []string
+	nullFields []string
+}
+
+// newHostUpdate returns the changes required to update h to match res or
+// nil if h already matches res.
+func newHostUpdate(h *Host, res *ListHostsResult) *hostUpdate {
+	u := &hostUpdate{
+		host: &Host{
+			Host: &store.Host{
+				PublicId:    h.PublicId,
+				Name:        res.Name,
+				Description: res.Description,
+				Address:     res.Address,
+			},
+		},
+	}
+	update := func(field, current, want string) {
+		switch {
+		case current == want:
+		case want == "":
+			u.nullFields = append(u.nullFields, field)
+		default:
+			u.fieldMask = append(u.fieldMask, field)
+		}
+	}
+	update("Name", h.Name, res.Name)
+	update("Description", h.Description, res.Description)
+	update("Address", h.Address, res.Address)
+	if len(u.fieldMask) == 0 && len(u.nullFields) == 0 {
+		return nil
+	}
+	return u
+}
+
+// syncSetMembers replaces the members of s with hostIds and marks s as
+// synced. It must be called within a transaction.
+func syncSetMembers(ctx context.Context, reader db.Reader, w db.Writer, wrapper wrapping.Wrapper, s *HostSet, hostIds []string) error {
+	const op = "plugin.syncSetMembers"
+	changes, err := setChanges(ctx, reader, s.PublicId, hostIds)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	var deletions, additions []interface{}
+	for _, c := range changes {
+		m, err := NewHostSetMember(s.PublicId, c.HostId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		switch c.Action {
+		case "delete":
+			deletions = append(deletions, m)
+		case "add":
+			additions = append(additions, m)
+		}
+	}
+
+	metadata := s.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	var msgs []*oplog.Message
+	if len(deletions) > 0 {
+		rowsDeleted, err := w.DeleteItems(ctx, deletions, db.NewOplogMsgs(&msgs))
+		switch {
+		case err != nil:
+			return errors.Wrap(ctx, err, op)
+		case rowsDeleted != len(deletions):
+			return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("set members deleted %d did not match request for %d", rowsDeleted, len(deletions)))
+		}
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	}
+	if len(additions) > 0 {
+		if err := w.CreateItems(ctx, additions, db.NewOplogMsgs(&msgs)); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	}
+
+	synced := &HostSet{
+		HostSet: &store.HostSet{
+			PublicId:     s.PublicId,
+			LastSyncTime: timestamp.Now(),
+			NeedSync:     false,
+		},
+	}
+	setMsg := new(oplog.Message)
+	version := s.Version
+	rowsUpdated, err := w.Update(ctx, synced, []string{"LastSyncTime", "NeedSync"}, nil, db.NewOplogMsg(setMsg), db.WithVersion(&version))
+	switch {
+	case err != nil:
+		return errors.Wrap(ctx, err, op)
+	case rowsUpdated == 0:
+		return errors.New(ctx, errors.VersionMismatch, op, fmt.Sprintf("host set %s was modified during sync", s.PublicId))
+	case rowsUpdated > 1:
+		return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+	}
+	msgs = append(msgs, setMsg)
+
+	ticket, err := w.GetTicket(s)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+	}
+	if err := w.WriteOplogEntryWith(ctx, wrapper, ticket, metadata, msgs); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+	}
+	return nil
+}
+
+type change struct {
+	Action string
+	HostId string
+}
+
+func setChanges(ctx context.Context, reader db.Reader, setId string, hostIds []string) ([]*change, error) {
+	const op = "plugin.setChanges"
+	var inClauseSpots []string
+	// starts at 2 because there is already a @1 in the query
+	for i := 2; i < len(hostIds)+2; i++ {
+		inClauseSpots = append(inClauseSpots, fmt.Sprintf("@%d", i))
+	}
+	inClause := strings.Join(inClauseSpots, ",")
+	if inClause == "" {
+		inClause = "''"
+	}
+	query := fmt.Sprintf(setChangesQuery, inClause)
+
+	var params []interface{}
+	params = append(params, sql.Named("1", setId))
+	for idx, v := range hostIds {
+		params = append(params, sql.Named(fmt.Sprintf("%d", idx+2), v))
+	}
+	rows, err := reader.Query(ctx, query, params)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("query failed"))
+	}
+	defer rows.Close()
+
+	var changes []*change
+	for rows.Next() {
+		var chg change
+		if err := reader.ScanRows(rows, &chg); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		changes = append(changes, &chg)
+	}
+	return changes, nil
+}
@@ -0,0 +1,220 @@
+package plugin
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hostAddresses(hosts []*Host) []string {
+	var addrs []string
+	for _, h := range hosts {
+		addrs = append(addrs, h.Address)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+func TestRepository_SyncCatalog(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	plg := NewTestPlugin(
+		&TestPluginHost{ExternalId: "i-1", Address: "10.0.0.1", Tags: map[string]string{"env": "prod", "role": "web"}},
+		&TestPluginHost{ExternalId: "i-2", Address: "10.0.0.2", Tags: map[string]string{"env": "prod", "role": "db"}},
+		&TestPluginHost{ExternalId: "i-3", Address: "10.0.0.3", Tags: map[string]string{"env": "dev", "role": "web"}},
+	)
+	repo, err := NewRepository(rw, rw, kmsCache, sche, TestPlugins(plg))
+	require.NoError(t, err)
+
+	cat := TestCatalog(t, conn, prj.GetPublicId())
+	prod := TestSet(t, conn, cat.GetPublicId(), map[string]string{"env": "prod"})
+	web := TestSet(t, conn, cat.GetPublicId(), map[string]string{"role": "web"})
+
+	assertMembers := func(t *testing.T, setId string, want ...string) {
+		t.Helper()
+		s, hosts, err := repo.LookupSet(ctx, setId)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+		assert.False(t, s.NeedSync)
+		assert.NotNil(t, s.LastSyncTime)
+		assert.Equal(t, want, hostAddresses(hosts))
+	}
+
+	t.Run("initial", func(t *testing.T) {
+		require.NoError(t, repo.SyncCatalog(ctx, cat.GetPublicId()))
+		assertMembers(t, prod.GetPublicId(), "10.0.0.1", "10.0.0.2")
+		assertMembers(t, web.GetPublicId(), "10.0.0.1", "10.0.0.3")
+
+		hosts, err := repo.ListHosts(ctx, cat.GetPublicId())
+		require.NoError(t, err)
+		assert.Len(t, hosts, 3)
+	})
+
+	t.Run("churn", func(t *testing.T) {
+		before, err := repo.ListHosts(ctx, cat.GetPublicId())
+		require.NoError(t, err)
+		ids := map[string]string{}
+		for _, h := range before {
+			ids[h.ExternalId] = h.PublicId
+		}
+
+		// i-1 moves, i-2 is removed, i-3 is promoted to prod and i-4 is new.
+		plg.SetHosts(
+			&TestPluginHost{ExternalId: "i-1", Address: "10.0.1.1", Tags: map[string]string{"env": "prod", "role": "web"}},
+			&TestPluginHost{ExternalId: "i-3", Name: "promoted", Address: "10.0.0.3", Tags: map[string]string{"env": "prod", "role": "web"}},
+			&TestPluginHost{ExternalId: "i-4", Address: "10.0.0.4", Tags: map[string]string{"env": "prod", "role": "db"}},
+		)
+		require.NoError(t, repo.SyncCatalog(ctx, cat.GetPublicId()))
+		assertMembers(t, prod.GetPublicId(), "10.0.0.3", "10.0.0.4", "10.0.1.1")
+		assertMembers(t, web.GetPublicId(), "10.0.0.3", "10.0.1.1")
+
+		after, err := repo.ListHosts(ctx, cat.GetPublicId())
+		require.NoError(t, err)
+		require.Len(t, after, 3)
+		for _, h := range after {
+			switch h.ExternalId {
+			case "i-1", "i-3":
+				// existing hosts keep their public ids
+				assert.Equal(t, ids[h.ExternalId], h.PublicId)
+			case "i-4":
+				assert.NotContains(t, ids, h.ExternalId)
+			default:
+				t.Errorf("unexpected host %q", h.ExternalId)
+			}
+			if h.ExternalId == "i-3" {
+				assert.Equal(t, "promoted", h.Name)
+			}
+		}
+		got, err := repo.LookupHost(ctx, ids["i-2"])
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("no-changes", func(t *testing.T) {
+		require.NoError(t, repo.SyncCatalog(ctx, cat.GetPublicId()))
+		assertMembers(t, prod.GetPublicId(), "10.0.0.3", "10.0.0.4", "10.0.1.1")
+	})
+
+	t.Run("plugin-error", func(t *testing.T) {
+		plg.SetError(stderrors.New("provider unavailable"))
+		defer plg.SetError(nil)
+		plg.SetHosts()
+		require.Error(t, repo.SyncCatalog(ctx, cat.GetPublicId()))
+		// members are unchanged when the plugin fails
+		assertMembers(t, prod.GetPublicId(), "10.0.0.3", "10.0.0.4", "10.0.1.1")
+	})
+
+	t.Run("missing-catalog", func(t *testing.T) {
+		assert.NoError(t, repo.SyncCatalog(ctx, "hcplg_doesnotexist"))
+	})
+
+	t.Run("no-catalog-id", func(t *testing.T) {
+		err := repo.SyncCatalog(ctx, "")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("unknown-plugin", func(t *testing.T) {
+		other, err := NewRepository(rw, rw, kmsCache, sche, nil)
+		require.NoError(t, err)
+		err = other.SyncCatalog(ctx, cat.GetPublicId())
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestValidateResults(t *testing.T) {
+	t.Parallel()
+	sets := []*HostSet{allocHostSet(), allocHostSet()}
+	sets[0].PublicId, sets[1].PublicId = "hsplg_1", "hsplg_2"
+
+	tests := []struct {
+		name    string
+		results []*ListHostsResult
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			results: []*ListHostsResult{
+				{ExternalId: "a", Address: "10.0.0.1", SetIds: []string{"hsplg_1"}},
+				{ExternalId: "b", Address: "10.0.0.2", SetIds: []string{"hsplg_1", "hsplg_2"}},
+			},
+		},
+		{
+			name:    "nil-result",
+			results: []*ListHostsResult{nil},
+			wantErr: true,
+		},
+		{
+			name:    "no-external-id",
+			results: []*ListHostsResult{{Address: "10.0.0.1", SetIds: []string{"hsplg_1"}}},
+			wantErr: true,
+		},
+		{
+			name:    "no-address",
+			results: []*ListHostsResult{{ExternalId: "a", SetIds: []string{"hsplg_1"}}},
+			wantErr: true,
+		},
+		{
+			name:    "no-set-ids",
+			results: []*ListHostsResult{{ExternalId: "a", Address: "10.0.0.1"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-set",
+			results: []*ListHostsResult{{ExternalId: "a", Address: "10.0.0.1", SetIds: []string{"hsplg_3"}}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate-external-id",
+			results: []*ListHostsResult{
+				{ExternalId: "a", Address: "10.0.0.1", SetIds: []string{"hsplg_1"}},
+				{ExternalId: "a", Address: "10.0.0.2", SetIds: []string{"hsplg_2"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateResults(context.Background(), sets, tt.results)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got, len(tt.results))
+		})
+	}
+}
+
+func TestNewHostUpdate(t *testing.T) {
+	t.Parallel()
+	h := allocHost()
+	h.PublicId, h.Name, h.Address = "hplg_1", "name", "10.0.0.1"
+
+	assert.Nil(t, newHostUpdate(h, &ListHostsResult{Name: "name", Address: "10.0.0.1"}))
+
+	u := newHostUpdate(h, &ListHostsResult{Description: "desc", Address: "10.0.0.2"})
+	require.NotNil(t, u)
+	assert.Equal(t, "hplg_1", u.host.PublicId)
+	assert.ElementsMatch(t, []string{"Description", "Address"}, u.fieldMask)
+	assert.Equal(t, []string{"Name"}, u.nullFields)
+}
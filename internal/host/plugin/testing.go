@@ -0,0 +1,147 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPluginName is the name TestPlugin is registered with in the plugins
+// returned by TestPlugins.
+const TestPluginName = "test"
+
+// TestPluginHost is a host provided by a TestPlugin.
+type TestPluginHost struct {
+	ExternalId string
+	Name       string
+	Address    string
+	// Tags are matched with the attributes of the host sets.
+	Tags map[string]string
+}
+
+// TestPlugin is an in-process HostPlugin. The attributes of a host set
+// are a JSON encoded object of strings and a host is a member of every
+// set whose attributes are all present in the host's Tags. A set with no
+// attributes contains every host. The hosts can be changed with SetHosts
+// to simulate changes in the provider.
+type TestPlugin struct {
+	mu    sync.Mutex
+	hosts []*TestPluginHost
+	err   error
+}
+
+var _ HostPlugin = (*TestPlugin)(nil)
+
+// NewTestPlugin returns a TestPlugin providing hosts.
+func NewTestPlugin(hosts ...*TestPluginHost) *TestPlugin {
+	return &TestPlugin{hosts: hosts}
+}
+
+// SetHosts replaces the hosts provided by p.
+func (p *TestPlugin) SetHosts(hosts ...*TestPluginHost) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hosts = hosts
+}
+
+// SetError sets the error returned by ListHosts. A nil err makes ListHosts
+// succeed again.
+func (p *TestPlugin) SetError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// ListHosts returns the hosts of p matching the attributes of each set.
+func (p *TestPlugin) ListHosts(_ context.Context, _ *HostCatalog, sets []*HostSet) ([]*ListHostsResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	setAttrs := make(map[string]map[string]string, len(sets))
+	for _, s := range sets {
+		attrs := map[string]string{}
+		if len(s.Attributes) > 0 {
+			if err := json.Unmarshal(s.Attributes, &attrs); err != nil {
+				return nil, err
+			}
+		}
+		setAttrs[s.PublicId] = attrs
+	}
+
+	var results []*ListHostsResult
+	for _, h := range p.hosts {
+		res := &ListHostsResult{
+			ExternalId: h.ExternalId,
+			Name:       h.Name,
+			Address:    h.Address,
+		}
+		for _, s := range sets {
+			if matchTags(setAttrs[s.PublicId], h.Tags) {
+				res.SetIds = append(res.SetIds, s.PublicId)
+			}
+		}
+		if len(res.SetIds) > 0 {
+			results = append(results, res)
+		}
+	}
+	return results, nil
+}
+
+func matchTags(attrs, tags map[string]string) bool {
+	for k, v := range attrs {
+		if tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// TestPlugins returns the plugins map containing p registered as
+// TestPluginName.
+func TestPlugins(p HostPlugin) map[string]HostPlugin {
+	return map[string]HostPlugin{TestPluginName: p}
+}
+
+// TestCatalog creates a plugin host catalog for TestPluginName in the
+// provided DB with the provided scope id. If any errors are encountered
+// during the creation of the host catalog, the test will fail.
+func TestCatalog(t *testing.T, conn *db.DB, scopeId string, opt ...Option) *HostCatalog {
+	t.Helper()
+	require := require.New(t)
+	cat, err := NewHostCatalog(scopeId, TestPluginName, opt...)
+	require.NoError(err)
+	id, err := newHostCatalogId()
+	require.NoError(err)
+	cat.PublicId = id
+
+	w := db.New(conn)
+	require.NoError(w.Create(context.Background(), cat))
+	return cat
+}
+
+// TestSet creates a plugin host set in the provided DB with the provided
+// catalog id and the tags as the set's attributes. The catalog must have
+// been created previously. The test will fail if any errors are
+// encountered.
+func TestSet(t *testing.T, conn *db.DB, catalogId string, tags map[string]string) *HostSet {
+	t.Helper()
+	require := require.New(t)
+	attrs, err := json.Marshal(tags)
+	require.NoError(err)
+	set, err := NewHostSet(catalogId, WithAttributes(attrs))
+	require.NoError(err)
+	id, err := newHostSetId()
+	require.NoError(err)
+	set.PublicId = id
+
+	w := db.New(conn)
+	require.NoError(w.Create(context.Background(), set))
+	return set
+}
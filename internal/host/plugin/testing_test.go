@@ -0,0 +1,39 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestPlugin_ListHosts(t *testing.T) {
+	t.Parallel()
+	plg := NewTestPlugin(
+		&TestPluginHost{ExternalId: "i-1", Address: "10.0.0.1", Tags: map[string]string{"env": "prod"}},
+		&TestPluginHost{ExternalId: "i-2", Address: "10.0.0.2", Tags: map[string]string{"env": "dev"}},
+	)
+	all, prod, none := allocHostSet(), allocHostSet(), allocHostSet()
+	all.PublicId = "hsplg_all"
+	prod.PublicId, prod.Attributes = "hsplg_prod", []byte(`{"env":"prod"}`)
+	none.PublicId, none.Attributes = "hsplg_none", []byte(`{"env":"test"}`)
+
+	got, err := plg.ListHosts(context.Background(), nil, []*HostSet{all, prod, none})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "i-1", got[0].ExternalId)
+	assert.Equal(t, []string{"hsplg_all", "hsplg_prod"}, got[0].SetIds)
+	assert.Equal(t, "i-2", got[1].ExternalId)
+	assert.Equal(t, []string{"hsplg_all"}, got[1].SetIds)
+
+	// hosts matching no set are not returned
+	got, err = plg.ListHosts(context.Background(), nil, []*HostSet{none})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	bad := allocHostSet()
+	bad.Attributes = []byte(`not json`)
+	_, err = plg.ListHosts(context.Background(), nil, []*HostSet{bad})
+	assert.Error(t, err)
+}
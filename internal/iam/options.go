@@ -20,6 +20,7 @@ type options struct {
 	withName                    string
 	withDescription             string
 	withLimit                   int
+	withStartPageAfterItem      *string
 	withGrantScopeId            string
 	withSkipVetForWrite         bool
 	withDisassociate            bool
@@ -72,6 +73,15 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
 // WithGrantScopeId provides an option to specify the scope ID for grants in
 // roles.
 func WithGrantScopeId(id string) Option {
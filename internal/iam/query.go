@@ -1,5 +1,7 @@
 package iam
 
+import "github.com/hashicorp/boundary/internal/types/resource"
+
 // query.go contains "raw sql" for the iam package that goes directly against
 // the db via sql.DB vs the standard pattern of using the internal/db package to
 // interact with the db.
@@ -109,3 +111,149 @@ const (
 	order by action, member_id;
 	`
 )
+
+// resourceInfoQueries contains, for each resource type, a query returning the
+// scope_id, name and pin of the resource @public_id as they are used to
+// authorize actions on the resource.
+var resourceInfoQueries = map[resource.Type]string{
+	resource.Scope: `
+select coalesce(parent_id, public_id) as scope_id, coalesce(name, '') as name, '' as pin
+  from iam_scope
+ where public_id = @public_id;
+`,
+	resource.User: `
+select scope_id, coalesce(name, '') as name, '' as pin
+  from iam_user
+ where public_id = @public_id;
+`,
+	resource.Group: `
+select scope_id, coalesce(name, '') as name, '' as pin
+  from iam_group
+ where public_id = @public_id;
+`,
+	resource.Role: `
+select scope_id, coalesce(name, '') as name, '' as pin
+  from iam_role
+ where public_id = @public_id;
+`,
+	resource.AuthToken: `
+select a.scope_id, '' as name, '' as pin
+  from auth_token t
+  join auth_account a
+    on a.public_id = t.auth_account_id
+ where t.public_id = @public_id;
+`,
+	resource.AuthMethod: `
+select am.scope_id, coalesce(pw.name, oidc.name, ldap.name, '') as name, '' as pin
+  from auth_method am
+  left join auth_password_method pw
+    on pw.public_id = am.public_id
+  left join auth_oidc_method oidc
+    on oidc.public_id = am.public_id
+  left join auth_ldap_method ldap
+    on ldap.public_id = am.public_id
+ where am.public_id = @public_id;
+`,
+	resource.Account: `
+select a.scope_id, coalesce(pw.name, oidc.name, ldap.name, '') as name, a.auth_method_id as pin
+  from auth_account a
+  left join auth_password_account pw
+    on pw.public_id = a.public_id
+  left join auth_oidc_account oidc
+    on oidc.public_id = a.public_id
+  left join auth_ldap_account ldap
+    on ldap.public_id = a.public_id
+ where a.public_id = @public_id;
+`,
+	resource.ManagedGroup: `
+select am.scope_id, coalesce(oidc.name, ldap.name, '') as name, mg.auth_method_id as pin
+  from auth_managed_group mg
+  join auth_method am
+    on am.public_id = mg.auth_method_id
+  left join auth_oidc_managed_group oidc
+    on oidc.public_id = mg.public_id
+  left join auth_ldap_managed_group ldap
+    on ldap.public_id = mg.public_id
+ where mg.public_id = @public_id;
+`,
+	resource.HostCatalog: `
+select hc.scope_id, coalesce(s.name, p.name, '') as name, '' as pin
+  from host_catalog hc
+  left join static_host_catalog s
+    on s.public_id = hc.public_id
+  left join host_plugin_catalog p
+    on p.public_id = hc.public_id
+ where hc.public_id = @public_id;
+`,
+	resource.HostSet: `
+select hc.scope_id, coalesce(s.name, p.name, '') as name, hs.catalog_id as pin
+  from host_set hs
+  join host_catalog hc
+    on hc.public_id = hs.catalog_id
+  left join static_host_set s
+    on s.public_id = hs.public_id
+  left join host_plugin_set p
+    on p.public_id = hs.public_id
+ where hs.public_id = @public_id;
+`,
+	resource.Host: `
+select hc.scope_id, coalesce(s.name, p.name, '') as name, h.catalog_id as pin
+  from host h
+  join host_catalog hc
+    on hc.public_id = h.catalog_id
+  left join static_host s
+    on s.public_id = h.public_id
+  left join host_plugin_host p
+    on p.public_id = h.public_id
+ where h.public_id = @public_id;
+`,
+	resource.Target: `
+select scope_id, coalesce(name, '') as name, '' as pin
+  from target_all_subtypes
+ where public_id = @public_id;
+`,
+	resource.Session: `
+select scope_id, '' as name, '' as pin
+  from session
+ where public_id = @public_id;
+`,
+	resource.SessionPolicy: `
+select scope_id, coalesce(name, '') as name, '' as pin
+  from session_policy
+ where public_id = @public_id;
+`,
+	resource.CredentialStore: `
+select cs.scope_id, coalesce(v.name, s.name, '') as name, '' as pin
+  from credential_store cs
+  left join credential_vault_store v
+    on v.public_id = cs.public_id
+  left join credential_static_store s
+    on s.public_id = cs.public_id
+ where cs.public_id = @public_id;
+`,
+	resource.CredentialLibrary: `
+select cs.scope_id, coalesce(v.name, '') as name, cl.store_id as pin
+  from credential_library cl
+  join credential_store cs
+    on cs.public_id = cl.store_id
+  left join credential_vault_library v
+    on v.public_id = cl.public_id
+ where cl.public_id = @public_id;
+`,
+	resource.Credential: `
+select cs.scope_id, coalesce(up.name, pk.name, '') as name, c.store_id as pin
+  from credential_static c
+  join credential_store cs
+    on cs.public_id = c.store_id
+  left join credential_static_username_password_credential up
+    on up.public_id = c.public_id
+  left join credential_static_ssh_private_key_credential pk
+    on pk.public_id = c.public_id
+ where c.public_id = @public_id;
+`,
+	resource.Worker: `
+select scope_id, coalesce(name, '') as name, '' as pin
+  from server_worker
+ where public_id = @public_id;
+`,
+}
@@ -170,7 +170,8 @@ func (r *Repository) DeleteGroup(ctx context.Context, withPublicId string, _ ...
 	return rowsDeleted, nil
 }
 
-// ListGroups lists groups in the given scopes and supports WithLimit option.
+// ListGroups lists groups in the given scopes and supports WithLimit and
+// WithStartPageAfterItem options.
 func (r *Repository) ListGroups(ctx context.Context, withScopeIds []string, opt ...Option) ([]*Group, error) {
 	const op = "iam.(Repository).ListGroups"
 	if len(withScopeIds) == 0 {
@@ -0,0 +1,58 @@
+package iam
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// ResourceInfo contains the attributes of a resource which are used to
+// authorize an action on it.
+type ResourceInfo struct {
+	// ScopeId is the scope whose grants apply to the resource. For a scope,
+	// it is the parent scope, or the global scope for the global scope.
+	ScopeId string
+	// Name of the resource, empty if the resource has no name.
+	Name string
+	// Pin is the ID of the resource containing the resource, such as the host
+	// catalog of a host, empty if the resource is not contained in another
+	// resource.
+	Pin string
+}
+
+// LookupResourceInfo returns the scope, name and pin of the resource of type
+// resourceType with the public id. Returns nil if the resource does not
+// exist, and an error with code InvalidParameter if the type is not
+// supported.
+func (r *Repository) LookupResourceInfo(ctx context.Context, resourceType resource.Type, id string, _ ...Option) (*ResourceInfo, error) {
+	const op = "iam.(Repository).LookupResourceInfo"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	query, ok := resourceInfoQueries[resourceType]
+	if !ok {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported resource type %q", resourceType.String()))
+	}
+	rows, err := r.reader.Query(ctx, query, []interface{}{sql.Named("public_id", id)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var info *ResourceInfo
+	for rows.Next() {
+		if info != nil {
+			return nil, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("more than one resource found for %s", id))
+		}
+		info = &ResourceInfo{}
+		if err := r.reader.ScanRows(rows, info); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return info, nil
+}
@@ -0,0 +1,91 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_LookupResourceInfo(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId, WithName("alice"))
+	role := TestRole(t, conn, proj.PublicId, WithName("admins"))
+
+	tests := []struct {
+		name         string
+		resourceType resource.Type
+		id           string
+		want         *ResourceInfo
+		wantErrCode  errors.Code
+	}{
+		{
+			name:         "global",
+			resourceType: resource.Scope,
+			id:           scope.Global.String(),
+			want:         &ResourceInfo{ScopeId: scope.Global.String(), Name: "global"},
+		},
+		{
+			name:         "project",
+			resourceType: resource.Scope,
+			id:           proj.PublicId,
+			want:         &ResourceInfo{ScopeId: org.PublicId, Name: proj.Name},
+		},
+		{
+			name:         "user",
+			resourceType: resource.User,
+			id:           user.PublicId,
+			want:         &ResourceInfo{ScopeId: org.PublicId, Name: "alice"},
+		},
+		{
+			name:         "role",
+			resourceType: resource.Role,
+			id:           role.PublicId,
+			want:         &ResourceInfo{ScopeId: proj.PublicId, Name: "admins"},
+		},
+		{
+			name:         "not-found",
+			resourceType: resource.User,
+			id:           "u_1234567890",
+		},
+		{
+			name:         "wrong-type",
+			resourceType: resource.Group,
+			id:           user.PublicId,
+		},
+		{
+			name:         "unsupported-type",
+			resourceType: resource.Controller,
+			id:           "c_1234567890",
+			wantErrCode:  errors.InvalidParameter,
+		},
+		{
+			name:         "missing-id",
+			resourceType: resource.User,
+			wantErrCode:  errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.LookupResourceInfo(ctx, tt.resourceType, tt.id)
+			if tt.wantErrCode != errors.Unknown {
+				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "want err: %q got: %q", tt.wantErrCode, err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
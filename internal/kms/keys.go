@@ -0,0 +1,358 @@
+package kms
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// Key is the root key or a DEK of a scope and its versions.
+type Key struct {
+	Id      string
+	ScopeId string
+	// Purpose is "root" for the root key and the key purpose of a DEK
+	// otherwise.
+	Purpose    string
+	CreateTime *timestamp.Timestamp
+	// Versions are ordered from the newest to the oldest version.
+	Versions []*KeyVersion
+}
+
+// KeyVersion is a version of a Key.
+type KeyVersion struct {
+	Id         string
+	Version    uint32
+	CreateTime *timestamp.Timestamp
+}
+
+// DataKeyVersionDestructionJob contains the progress of destroying a
+// database key version. The key version is destroyed once every value it
+// encrypts has been rewrapped with the current database key version.
+type DataKeyVersionDestructionJob struct {
+	KeyId          string
+	ScopeId        string
+	CompletedCount int64
+	TotalCount     int64
+	CreateTime     *timestamp.Timestamp
+}
+
+// TableName returns the name of the view containing the progress of the
+// jobs.
+func (*DataKeyVersionDestructionJob) TableName() string {
+	return "kms_data_key_version_destruction_job_progress"
+}
+
+// Status returns "pending" until a value has been rewrapped and "running"
+// afterwards.
+func (j *DataKeyVersionDestructionJob) Status() string {
+	if j.CompletedCount == 0 {
+		return "pending"
+	}
+	return "running"
+}
+
+// ListKeys returns the root key and the DEKs of scopeId with their versions.
+func (k *Kms) ListKeys(ctx context.Context, scopeId string) ([]*Key, error) {
+	const op = "kms.(Kms).ListKeys"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	rows, err := k.repo.reader.Query(ctx, listKeysQuery, []interface{}{sql.Named("scope_id", scopeId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var keys []*Key
+	for rows.Next() {
+		var row struct {
+			Purpose           string
+			KeyId             string
+			KeyCreateTime     *timestamp.Timestamp
+			VersionId         string
+			Version           uint32
+			VersionCreateTime *timestamp.Timestamp
+		}
+		if err := k.repo.reader.ScanRows(rows, &row); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if len(keys) == 0 || keys[len(keys)-1].Id != row.KeyId {
+			keys = append(keys, &Key{
+				Id:         row.KeyId,
+				ScopeId:    scopeId,
+				Purpose:    row.Purpose,
+				CreateTime: row.KeyCreateTime,
+			})
+		}
+		key := keys[len(keys)-1]
+		key.Versions = append(key.Versions, &KeyVersion{
+			Id:         row.VersionId,
+			Version:    row.Version,
+			CreateTime: row.VersionCreateTime,
+		})
+	}
+	return keys, nil
+}
+
+// OplogWrapper returns the wrapper of the oplog key version keyVersionId,
+// whichever scope it belongs to. It's used to decrypt oplog entries, which
+// only record the id of the key version that encrypted them.
+func (k *Kms) OplogWrapper(ctx context.Context, keyVersionId string) (wrapping.Wrapper, error) {
+	const op = "kms.(Kms).OplogWrapper"
+	if keyVersionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing key version id")
+	}
+	rows, err := k.repo.reader.Query(ctx, oplogKeyVersionScopeQuery, []interface{}{sql.Named("key_version_id", keyVersionId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("oplog key version %s not found", keyVersionId))
+	}
+	var scopeId string
+	if err := rows.Scan(&scopeId); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	wrapper, err := k.GetWrapper(ctx, scopeId, KeyPurposeOplog, WithKeyId(keyVersionId))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return wrapper, nil
+}
+
+// DestroyKeyVersion destroys the root or database key version keyVersionId
+// of scopeId. The current version of a key cannot be destroyed, the keys of
+// the scope must be rotated first.
+//
+// A root key version is destroyed immediately after the DEK versions it
+// encrypts are rewrapped with the current root key version and true is
+// returned. A database key version is destroyed by a destruction job once
+// every value it encrypts has been rewrapped with the current database key
+// version and false is returned. Versions of the other DEKs cannot be
+// destroyed.
+func (k *Kms) DestroyKeyVersion(ctx context.Context, scopeId, keyVersionId string) (bool, error) {
+	const op = "kms.(Kms).DestroyKeyVersion"
+	switch {
+	case scopeId == "":
+		return false, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case keyVersionId == "":
+		return false, errors.New(ctx, errors.InvalidParameter, op, "missing key version id")
+	}
+	isRoot := strings.HasPrefix(keyVersionId, RootKeyVersionPrefix+"_")
+	if !isRoot && !strings.HasPrefix(keyVersionId, DatabaseKeyVersionPrefix+"_") {
+		return false, errors.New(ctx, errors.InvalidParameter, op, "only root and database key versions can be destroyed")
+	}
+
+	_, err := k.repo.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(r db.Reader, w db.Writer) error {
+			keyScopeId, isCurrent, err := lookupKeyVersionScope(ctx, r, keyVersionId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			switch {
+			case keyScopeId != scopeId:
+				return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("key version %s not found in scope %s", keyVersionId, scopeId))
+			case isCurrent:
+				return errors.New(ctx, errors.InvalidParameter, op, "the current key version cannot be destroyed, the keys of the scope must be rotated first")
+			}
+			if isRoot {
+				return k.destroyRootKeyVersion(ctx, r, w, scopeId, keyVersionId)
+			}
+			return createDataKeyVersionDestructionJob(ctx, r, w, keyVersionId)
+		},
+	)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", keyVersionId)))
+	}
+	return isRoot, nil
+}
+
+// lookupKeyVersionScope returns the scope of a root or database key version
+// and whether it is the current version of its key.
+func lookupKeyVersionScope(ctx context.Context, r db.Reader, keyVersionId string) (string, bool, error) {
+	const op = "kms.lookupKeyVersionScope"
+	rows, err := r.Query(ctx, keyVersionScopeQuery, []interface{}{sql.Named("key_version_id", keyVersionId)})
+	if err != nil {
+		return "", false, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return "", false, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("key version %s not found", keyVersionId))
+	}
+	var scopeId string
+	var isCurrent bool
+	if err := rows.Scan(&scopeId, &isCurrent); err != nil {
+		return "", false, errors.Wrap(ctx, err, op)
+	}
+	return scopeId, isCurrent, nil
+}
+
+// destroyRootKeyVersion rewraps the DEK versions encrypted by the root key
+// version rootKeyVersionId with the current root key version of scopeId and
+// deletes rootKeyVersionId.
+func (k *Kms) destroyRootKeyVersion(ctx context.Context, r db.Reader, w db.Writer, scopeId, rootKeyVersionId string) error {
+	const op = "kms.(Kms).destroyRootKeyVersion"
+	repo, err := NewRepository(r, w)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	// The multiwrapper decrypts with every root key version and encrypts with
+	// the current one.
+	rootWrapper, _, err := k.loadRoot(ctx, scopeId, WithRepository(repo))
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, t := range dekTables {
+		var dvs []*dekVersion
+		if err := queryDekVersions(ctx, r, &dvs, fmt.Sprintf(rootKeyVersionDekVersionsQuery, t.versionTable), []interface{}{
+			sql.Named("root_key_version_id", rootKeyVersionId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := rewrapDekVersions(ctx, w, t, dvs, rootWrapper, rootWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	// no oplog entries for root key versions
+	rowsDeleted, err := w.Exec(ctx, deleteRootKeyVersionQuery, []interface{}{sql.Named("key_id", rootKeyVersionId)})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if rowsDeleted != 1 {
+		return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("%d root key versions would have been deleted", rowsDeleted))
+	}
+	return nil
+}
+
+// createDataKeyVersionDestructionJob creates a destruction job for the
+// database key version keyId with a run for every registered table. It does
+// nothing if the job already exists.
+func createDataKeyVersionDestructionJob(ctx context.Context, r db.Reader, w db.Writer, keyId string) error {
+	const op = "kms.createDataKeyVersionDestructionJob"
+	rowsInserted, err := w.Exec(ctx, insertDestructionJobQuery, []interface{}{sql.Named("key_id", keyId)})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if rowsInserted == 0 {
+		return nil
+	}
+	for _, table := range rewrapTables() {
+		count, err := countKeyId(ctx, r, table, keyId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := w.Exec(ctx, insertDestructionJobRunQuery, []interface{}{
+			sql.Named("key_id", keyId),
+			sql.Named("table_name", table),
+			sql.Named("total_count", count),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to create run for table %s", table)))
+		}
+	}
+	return nil
+}
+
+// countKeyId returns the number of rows of table encrypted by keyId.
+func countKeyId(ctx context.Context, r db.Reader, table, keyId string) (int64, error) {
+	const op = "kms.countKeyId"
+	rows, err := r.Query(ctx, fmt.Sprintf(countKeyIdQuery, table), []interface{}{sql.Named("key_id", keyId)})
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to count rows of table %s", table)))
+	}
+	defer rows.Close()
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, errors.Wrap(ctx, err, op)
+		}
+	}
+	return count, nil
+}
+
+// ListDataKeyVersionDestructionJobs returns the destruction jobs of the
+// database key versions of scopeId, or of every scope if scopeId is empty.
+func (k *Kms) ListDataKeyVersionDestructionJobs(ctx context.Context, scopeId string) ([]*DataKeyVersionDestructionJob, error) {
+	const op = "kms.(Kms).ListDataKeyVersionDestructionJobs"
+	where, args := "true", []interface{}(nil)
+	if scopeId != "" {
+		where, args = "scope_id = ?", []interface{}{scopeId}
+	}
+	var jobs []*DataKeyVersionDestructionJob
+	if err := k.repo.reader.SearchWhere(ctx, &jobs, where, args, db.WithLimit(-1), db.WithOrder("create_time asc")); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return jobs, nil
+}
+
+// RunDataKeyVersionDestructionJob rewraps the values encrypted by the
+// database key version of the destruction job keyId with the current database
+// key version and updates the progress of the job. The key version is
+// destroyed once nothing references it and the job is older than the cache
+// TTL, so no controller encrypts with the key version anymore. Returns true if
+// the key version was destroyed.
+func (k *Kms) RunDataKeyVersionDestructionJob(ctx context.Context, keyId string) (bool, error) {
+	const op = "kms.(Kms).RunDataKeyVersionDestructionJob"
+	if keyId == "" {
+		return false, errors.New(ctx, errors.InvalidParameter, op, "missing key id")
+	}
+	job := &DataKeyVersionDestructionJob{}
+	if err := k.repo.reader.LookupWhere(ctx, job, "key_id = ?", keyId); err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+
+	// Reload the wrappers so values are rewrapped with the current database
+	// key version even if the keys were rotated by another controller.
+	k.clearCache(job.ScopeId)
+	wrapper, err := k.GetWrapper(ctx, job.ScopeId, KeyPurposeDatabase)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	if wrapper.KeyID() == keyId {
+		return false, errors.New(ctx, errors.Internal, op, "the key version being destroyed is the current database key version")
+	}
+
+	var remaining int64
+	for _, table := range rewrapTables() {
+		fn, _ := tableRewrapFn(table)
+		if err := fn(ctx, keyId, job.ScopeId, k.repo.reader, k.repo.writer, k); err != nil {
+			return false, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to rewrap table %s", table)))
+		}
+		count, err := countKeyId(ctx, k.repo.reader, table, keyId)
+		if err != nil {
+			return false, errors.Wrap(ctx, err, op)
+		}
+		if _, err := k.repo.writer.Exec(ctx, updateDestructionJobRunQuery, []interface{}{
+			sql.Named("remaining_count", count),
+			sql.Named("key_id", keyId),
+			sql.Named("table_name", table),
+		}); err != nil {
+			return false, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update run for table %s", table)))
+		}
+		remaining += count
+	}
+	if remaining > 0 || time.Since(job.CreateTime.AsTime()) < k.cacheTtl {
+		return false, nil
+	}
+
+	// Deleting the key version deletes its destruction job.
+	rowsDeleted, err := k.repo.writer.Exec(ctx, deleteDatabaseKeyVersionQuery, []interface{}{sql.Named("key_id", keyId)})
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	if rowsDeleted != 1 {
+		return false, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("%d database key versions would have been deleted", rowsDeleted))
+	}
+	k.clearCache(job.ScopeId)
+	return true, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -19,10 +20,11 @@ import (
 // ExternalWrappers holds wrappers defined outside of Boundary, e.g. in its
 // configuration file.
 type ExternalWrappers struct {
-	m          sync.RWMutex
-	root       wrapping.Wrapper
-	workerAuth wrapping.Wrapper
-	recovery   wrapping.Wrapper
+	m            sync.RWMutex
+	root         wrapping.Wrapper
+	previousRoot wrapping.Wrapper
+	workerAuth   wrapping.Wrapper
+	recovery     wrapping.Wrapper
 }
 
 // Root returns the wrapper for root keys
@@ -32,6 +34,26 @@ func (e *ExternalWrappers) Root() wrapping.Wrapper {
 	return e.root
 }
 
+// PreviousRoot returns the wrapper of the root kms replaced by the current
+// root wrapper, which may be nil
+func (e *ExternalWrappers) PreviousRoot() wrapping.Wrapper {
+	e.m.RLock()
+	defer e.m.RUnlock()
+	return e.previousRoot
+}
+
+// rootDecryptor returns a wrapper which decrypts root key versions encrypted
+// by either the root or the previous root wrapper. The caller must hold the
+// lock.
+func (e *ExternalWrappers) rootDecryptor() wrapping.Wrapper {
+	if e.previousRoot == nil {
+		return e.root
+	}
+	multi := multiwrapper.NewMultiWrapper(e.root)
+	multi.AddWrapper(e.previousRoot)
+	return multi
+}
+
 // WorkerAuth returns the wrapper for worker authentication
 func (e *ExternalWrappers) WorkerAuth() wrapping.Wrapper {
 	e.m.RLock()
@@ -46,14 +68,22 @@ func (e *ExternalWrappers) Recovery() wrapping.Wrapper {
 	return e.recovery
 }
 
-// Kms is a way to access wrappers for a given scope and purpose. Since keys can
-// never change, only be added or (eventually) removed, it opportunistically
-// caches, going to the database as needed.
+// DefaultCacheTtl is the default duration a scope's wrappers are cached
+// before they are reloaded from the database.
+const DefaultCacheTtl = 10 * time.Minute
+
+// Kms is a way to access wrappers for a given scope and purpose. Since keys are
+// only changed by adding new versions when they are rotated and old versions
+// are only removed once nothing uses them, it opportunistically caches, going
+// to the database as needed and when a cached wrapper is older than the cache
+// TTL, so that every controller encrypts with a new key version soon after a
+// rotation.
 type Kms struct {
 
-	// scopePurposeCache holds a per-scope-purpose multiwrapper containing the
+	// scopePurposeCache holds a per-scope-purpose *cachedWrapper containing the
 	// current encrypting key and all previous key versions, for decryption
 	scopePurposeCache sync.Map
+	cacheTtl          time.Duration
 
 	externalScopeCache      map[string]*ExternalWrappers
 	externalScopeCacheMutex sync.RWMutex
@@ -63,15 +93,23 @@ type Kms struct {
 	repo *Repository
 }
 
-// NewKms takes in a repo and returns a Kms.
+// cachedWrapper is a multiwrapper in the scope purpose cache.
+type cachedWrapper struct {
+	wrapper *multiwrapper.MultiWrapper
+	expires time.Time
+}
+
+// NewKms takes in a repo and returns a Kms. Supports the WithCacheTtl option.
 func NewKms(repo *Repository, opt ...Option) (*Kms, error) {
 	const op = "kms.NewKms"
 	if repo == nil {
 		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing underlying repo")
 	}
 
+	opts := getOpts(opt...)
 	return &Kms{
 		externalScopeCache: make(map[string]*ExternalWrappers),
+		cacheTtl:           opts.withCacheTtl,
 		repo:               repo,
 	}, nil
 }
@@ -111,6 +149,17 @@ func (k *Kms) AddExternalWrappers(opt ...Option) error {
 			return errors.NewDeprecated(errors.InvalidParameter, op, "root wrapper has no key ID")
 		}
 	}
+	if opts.withPreviousRootWrapper != nil {
+		ext.previousRoot = opts.withPreviousRootWrapper
+		switch {
+		case ext.previousRoot.KeyID() == "":
+			return errors.NewDeprecated(errors.InvalidParameter, op, "previous root wrapper has no key ID")
+		case ext.root != nil && ext.previousRoot.KeyID() == ext.root.KeyID():
+			// The multiwrapper decrypting root key versions selects a
+			// wrapper by key ID.
+			return errors.NewDeprecated(errors.InvalidParameter, op, "previous root wrapper has the same key ID as the root wrapper")
+		}
+	}
 	if opts.withWorkerAuthWrapper != nil {
 		ext.workerAuth = opts.withWorkerAuthWrapper
 		if ext.workerAuth.KeyID() == "" {
@@ -137,9 +186,10 @@ func (k *Kms) GetExternalWrappers() *ExternalWrappers {
 	defer ext.m.RUnlock()
 
 	ret := &ExternalWrappers{
-		root:       ext.root,
-		workerAuth: ext.workerAuth,
-		recovery:   ext.recovery,
+		root:         ext.root,
+		previousRoot: ext.previousRoot,
+		workerAuth:   ext.workerAuth,
+		recovery:     ext.recovery,
 	}
 	return ret
 }
@@ -163,12 +213,12 @@ func (k *Kms) GetWrapper(ctx context.Context, scopeId string, purpose KeyPurpose
 	}
 
 	opts := getOpts(opt...)
-	// Fast-path: we have a valid key at the scope/purpose. Verify the key with
-	// that ID is in the multiwrapper; if not, fall through to reload from the
-	// DB.
+	// Fast-path: we have a valid key at the scope/purpose which has not
+	// expired. Verify the key with that ID is in the multiwrapper; if not, fall
+	// through to reload from the DB.
 	val, ok := k.scopePurposeCache.Load(scopeId + purpose.String())
-	if ok {
-		wrapper := val.(*multiwrapper.MultiWrapper)
+	if ok && time.Now().Before(val.(*cachedWrapper).expires) {
+		wrapper := val.(*cachedWrapper).wrapper
 		if opts.withKeyId == "" {
 			return wrapper, nil
 		}
@@ -194,7 +244,10 @@ func (k *Kms) GetWrapper(ctx context.Context, scopeId string, purpose KeyPurpose
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("error loading %s for scope %s", purpose.String(), scopeId)))
 	}
-	k.scopePurposeCache.Store(scopeId+purpose.String(), wrapper)
+	k.scopePurposeCache.Store(scopeId+purpose.String(), &cachedWrapper{
+		wrapper: wrapper,
+		expires: time.Now().Add(k.cacheTtl),
+	})
 
 	if opts.withKeyId != "" {
 		if keyIdWrapper := wrapper.WrapperForKeyID(opts.withKeyId); keyIdWrapper != nil {
@@ -243,7 +296,7 @@ func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*mul
 	if externalWrappers.root == nil {
 		return nil, "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("root key wrapper for scope %s is nil", scopeId))
 	}
-	rootKeyVersions, err := repo.ListRootKeyVersions(ctx, externalWrappers.root, rootKeyId, WithOrderByVersion(db.DescendingOrderBy))
+	rootKeyVersions, err := repo.ListRootKeyVersions(ctx, externalWrappers.rootDecryptor(), rootKeyId, WithOrderByVersion(db.DescendingOrderBy))
 	if err != nil {
 		return nil, "", errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("error looking up root key versions for scope %s with key ID %s", scopeId, externalWrappers.root.KeyID())))
 	}
@@ -272,6 +325,14 @@ func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*mul
 	return multi, rootKeyId, nil
 }
 
+// clearCache removes the cached wrappers of every purpose for scopeId, so
+// they are reloaded from the database the next time they are used.
+func (k *Kms) clearCache(scopeId string) {
+	for _, purpose := range []KeyPurpose{KeyPurposeOplog, KeyPurposeDatabase, KeyPurposeTokens, KeyPurposeSessions, KeyPurposeOidc} {
+		k.scopePurposeCache.Delete(scopeId + purpose.String())
+	}
+}
+
 // Dek is an interface wrapping dek types to allow a lot less switching in loadDek
 type Dek interface {
 	GetRootKeyId() string
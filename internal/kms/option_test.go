@@ -1,7 +1,10 @@
 package kms
 
 import (
+	"crypto/rand"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/stretchr/testify/assert"
@@ -35,4 +38,32 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withOrderByVersion = db.DescendingOrderBy
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithRandomReader", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Equal(rand.Reader, opts.withRandomReader)
+
+		reader := strings.NewReader("notrandom")
+		opts = getOpts(WithRandomReader(reader))
+		testOpts := getDefaultOptions()
+		testOpts.withRandomReader = reader
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRewrap", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithRewrap(true))
+		testOpts := getDefaultOptions()
+		testOpts.withRewrap = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithCacheTtl", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Equal(DefaultCacheTtl, opts.withCacheTtl)
+
+		opts = getOpts(WithCacheTtl(time.Minute))
+		testOpts := getDefaultOptions()
+		testOpts.withCacheTtl = time.Minute
+		assert.Equal(opts, testOpts)
+	})
 }
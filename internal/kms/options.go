@@ -1,6 +1,10 @@
 package kms
 
 import (
+	"crypto/rand"
+	"io"
+	"time"
+
 	"github.com/hashicorp/boundary/internal/db"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 )
@@ -19,17 +23,24 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withLimit             int
-	withRootWrapper       wrapping.Wrapper
-	withWorkerAuthWrapper wrapping.Wrapper
-	withRecoveryWrapper   wrapping.Wrapper
-	withRepository        *Repository
-	withOrderByVersion    db.OrderBy
-	withKeyId             string
+	withLimit               int
+	withRootWrapper         wrapping.Wrapper
+	withWorkerAuthWrapper   wrapping.Wrapper
+	withRecoveryWrapper     wrapping.Wrapper
+	withRepository          *Repository
+	withOrderByVersion      db.OrderBy
+	withKeyId               string
+	withPreviousRootWrapper wrapping.Wrapper
+	withRandomReader        io.Reader
+	withRewrap              bool
+	withCacheTtl            time.Duration
 }
 
 func getDefaultOptions() options {
-	return options{}
+	return options{
+		withRandomReader: rand.Reader,
+		withCacheTtl:     DefaultCacheTtl,
+	}
 }
 
 // WithLimit provides an option to provide a limit. Intentionally allowing
@@ -87,3 +98,37 @@ func WithKeyId(keyId string) Option {
 		o.withKeyId = keyId
 	}
 }
+
+// WithPreviousRootWrapper sets the external wrapper of the root kms which was
+// replaced by the root wrapper. It is only used to decrypt root key versions
+// until they have been rewrapped with the root wrapper.
+func WithPreviousRootWrapper(w wrapping.Wrapper) Option {
+	return func(o *options) {
+		o.withPreviousRootWrapper = w
+	}
+}
+
+// WithRandomReader provides an option to specify the reader used to generate
+// new keys. The default is crypto/rand.Reader.
+func WithRandomReader(r io.Reader) Option {
+	return func(o *options) {
+		o.withRandomReader = r
+	}
+}
+
+// WithRewrap provides an option to rewrap the existing key versions of a
+// scope with the newest key versions when its keys are rotated.
+func WithRewrap(rewrap bool) Option {
+	return func(o *options) {
+		o.withRewrap = rewrap
+	}
+}
+
+// WithCacheTtl provides an option to specify how long a scope's wrappers are
+// cached before they are reloaded from the database. The default is
+// DefaultCacheTtl.
+func WithCacheTtl(ttl time.Duration) Option {
+	return func(o *options) {
+		o.withCacheTtl = ttl
+	}
+}
@@ -0,0 +1,167 @@
+package kms
+
+const (
+	// dekIdQuery selects the DEK of a purpose for a root key. The table is
+	// formatted into the query.
+	dekIdQuery = `
+select private_id
+  from %s
+ where root_key_id = @root_key_id;
+`
+
+	// insertDekVersionQuery inserts a DEK version. The table and the column
+	// referencing the DEK are formatted into the query.
+	insertDekVersionQuery = `
+insert into %s
+  (private_id, %s, root_key_version_id, key)
+values
+  (@private_id, @dek_id, @root_key_version_id, @key);
+`
+
+	// dekVersionsQuery selects the DEK versions of a purpose for a root key
+	// which are not encrypted by excludedRootKeyVersionId. The tables and the
+	// column referencing the DEK are formatted into the query.
+	dekVersionsQuery = `
+select dkv.private_id,
+       dkv.root_key_version_id,
+       dkv.key as ct_key
+  from %s dkv
+  join %s dk on dk.private_id = dkv.%s
+ where dk.root_key_id = @root_key_id
+   and dkv.root_key_version_id != @excluded_root_key_version_id;
+`
+
+	// rootKeyVersionDekVersionsQuery selects the DEK versions of a purpose
+	// which are encrypted by a root key version. The table is formatted into
+	// the query.
+	rootKeyVersionDekVersionsQuery = `
+select private_id,
+       root_key_version_id,
+       key as ct_key
+  from %s
+ where root_key_version_id = @root_key_version_id;
+`
+
+	// rewrapKeyVersionQuery updates the key of a key version and the root key
+	// version which encrypts it. The table is formatted into the query.
+	rewrapKeyVersionQuery = `
+update %s
+   set key = @key,
+       root_key_version_id = @root_key_version_id
+ where private_id = @private_id;
+`
+
+	rewrapRootKeyVersionQuery = `
+update kms_root_key_version
+   set key = @key
+ where private_id = @private_id;
+`
+
+	// keyVersionScopeQuery selects the scope of a root or database key
+	// version and whether it is the newest version of its key.
+	keyVersionScopeQuery = `
+select rk.scope_id,
+       rkv.version = (select max(version) from kms_root_key_version where root_key_id = rk.private_id) as is_current
+  from kms_root_key_version rkv
+  join kms_root_key rk on rk.private_id = rkv.root_key_id
+ where rkv.private_id = @key_version_id
+union all
+select rk.scope_id,
+       dkv.version = (select max(version) from kms_database_key_version where database_key_id = dk.private_id) as is_current
+  from kms_database_key_version dkv
+  join kms_database_key dk on dk.private_id = dkv.database_key_id
+  join kms_root_key rk     on rk.private_id = dk.root_key_id
+ where dkv.private_id = @key_version_id;
+`
+
+	listKeysQuery = `
+select 'root' as purpose,
+       rk.private_id as key_id,
+       rk.create_time as key_create_time,
+       rkv.private_id as version_id,
+       rkv.version,
+       rkv.create_time as version_create_time
+  from kms_root_key rk
+  join kms_root_key_version rkv on rkv.root_key_id = rk.private_id
+ where rk.scope_id = @scope_id
+union all
+select 'database', dk.private_id, dk.create_time, dkv.private_id, dkv.version, dkv.create_time
+  from kms_database_key dk
+  join kms_root_key rk on rk.private_id = dk.root_key_id
+  join kms_database_key_version dkv on dkv.database_key_id = dk.private_id
+ where rk.scope_id = @scope_id
+union all
+select 'oplog', ok.private_id, ok.create_time, okv.private_id, okv.version, okv.create_time
+  from kms_oplog_key ok
+  join kms_root_key rk on rk.private_id = ok.root_key_id
+  join kms_oplog_key_version okv on okv.oplog_key_id = ok.private_id
+ where rk.scope_id = @scope_id
+union all
+select 'tokens', tk.private_id, tk.create_time, tkv.private_id, tkv.version, tkv.create_time
+  from kms_token_key tk
+  join kms_root_key rk on rk.private_id = tk.root_key_id
+  join kms_token_key_version tkv on tkv.token_key_id = tk.private_id
+ where rk.scope_id = @scope_id
+union all
+select 'sessions', sk.private_id, sk.create_time, skv.private_id, skv.version, skv.create_time
+  from kms_session_key sk
+  join kms_root_key rk on rk.private_id = sk.root_key_id
+  join kms_session_key_version skv on skv.session_key_id = sk.private_id
+ where rk.scope_id = @scope_id
+union all
+select 'oidc', oidck.private_id, oidck.create_time, oidckv.private_id, oidckv.version, oidckv.create_time
+  from kms_oidc_key oidck
+  join kms_root_key rk on rk.private_id = oidck.root_key_id
+  join kms_oidc_key_version oidckv on oidckv.oidc_key_id = oidck.private_id
+ where rk.scope_id = @scope_id
+order by purpose, version desc;
+`
+
+	// countKeyIdQuery counts the rows of a table encrypted by a key version.
+	// The table is formatted into the query.
+	countKeyIdQuery = `
+select count(*) as count
+  from %s
+ where key_id = @key_id;
+`
+
+	insertDestructionJobQuery = `
+insert into kms_data_key_version_destruction_job
+  (key_id)
+values
+  (@key_id)
+on conflict do nothing;
+`
+
+	insertDestructionJobRunQuery = `
+insert into kms_data_key_version_destruction_job_run
+  (key_id, table_name, total_count)
+values
+  (@key_id, @table_name, @total_count);
+`
+
+	updateDestructionJobRunQuery = `
+update kms_data_key_version_destruction_job_run
+   set completed_count = greatest(total_count - @remaining_count, 0)
+ where key_id = @key_id
+   and table_name = @table_name;
+`
+
+	deleteDatabaseKeyVersionQuery = `
+delete from kms_database_key_version
+ where private_id = @key_id;
+`
+
+	deleteRootKeyVersionQuery = `
+delete from kms_root_key_version
+ where private_id = @key_id;
+`
+
+	oplogKeyVersionScopeQuery = `
+select rk.scope_id
+  from kms_oplog_key_version okv
+  join kms_oplog_key ok on ok.private_id = okv.oplog_key_id
+  join kms_root_key rk  on rk.private_id = ok.root_key_id
+ where okv.private_id = @key_version_id;
+`
+)
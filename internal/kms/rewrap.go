@@ -0,0 +1,63 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// A RewrapFn decrypts the values of a table which are encrypted by the
+// database key version dataKeyVersionId and encrypts them with the current
+// database key version of scopeId.
+type RewrapFn func(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kms *Kms) error
+
+var (
+	tableRewrapFns   = make(map[string]RewrapFn)
+	tableRewrapFnsMu sync.RWMutex
+)
+
+// RegisterTableRewrapFn registers fn to rewrap the values of tableName before
+// a database key version is destroyed. The table must have a key_id column
+// containing the id of the database key version which encrypts each row.
+// Every table containing values encrypted by a database key version must be
+// registered, usually from the init function of the package which owns the
+// table. Returns an error if tableName has already been registered.
+func RegisterTableRewrapFn(tableName string, fn RewrapFn) error {
+	const op = "kms.RegisterTableRewrapFn"
+	switch {
+	case tableName == "":
+		return errors.NewDeprecated(errors.InvalidParameter, op, "missing table name")
+	case fn == nil:
+		return errors.NewDeprecated(errors.InvalidParameter, op, "missing rewrap function")
+	}
+	tableRewrapFnsMu.Lock()
+	defer tableRewrapFnsMu.Unlock()
+	if _, ok := tableRewrapFns[tableName]; ok {
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("table %q already registered", tableName))
+	}
+	tableRewrapFns[tableName] = fn
+	return nil
+}
+
+// rewrapTables returns the names of the registered tables in sorted order.
+func rewrapTables() []string {
+	tableRewrapFnsMu.RLock()
+	defer tableRewrapFnsMu.RUnlock()
+	tables := make([]string, 0, len(tableRewrapFns))
+	for table := range tableRewrapFns {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+func tableRewrapFn(tableName string) (RewrapFn, bool) {
+	tableRewrapFnsMu.RLock()
+	defer tableRewrapFnsMu.RUnlock()
+	fn, ok := tableRewrapFns[tableName]
+	return fn, ok
+}
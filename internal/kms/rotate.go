@@ -0,0 +1,294 @@
+package kms
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+)
+
+// dekTable describes the tables of the DEKs of a purpose and their versions.
+type dekTable struct {
+	purpose      KeyPurpose
+	keyTable     string
+	versionTable string
+	keyIdColumn  string
+	newVersionId func() (string, error)
+}
+
+var dekTables = []dekTable{
+	{KeyPurposeDatabase, "kms_database_key", "kms_database_key_version", "database_key_id", newDatabaseKeyVersionId},
+	{KeyPurposeOplog, "kms_oplog_key", "kms_oplog_key_version", "oplog_key_id", newOplogKeyVersionId},
+	{KeyPurposeTokens, "kms_token_key", "kms_token_key_version", "token_key_id", newTokenKeyVersionId},
+	{KeyPurposeSessions, "kms_session_key", "kms_session_key_version", "session_key_id", newSessionKeyVersionId},
+	{KeyPurposeOidc, "kms_oidc_key", "kms_oidc_key_version", "oidc_key_id", newOidcKeyVersionId},
+}
+
+// dekVersion is used to encrypt and decrypt the key of a DEK version
+// regardless of its purpose.
+type dekVersion struct {
+	PrivateId        string
+	RootKeyVersionId string
+	CtKey            []byte `wrapping:"ct,key"`
+	Key              []byte `wrapping:"pt,key"`
+}
+
+// RotateKeys rotates the keys of scopeId by creating a new version of its root
+// key and of each of its DEKs. The new versions are used to encrypt from then
+// on; existing values remain encrypted by the old versions.
+//
+// If WithRewrap is true, the versions of the root key are rewrapped with the
+// current root kms and the old versions of the DEKs are rewrapped with the new
+// root key version. Rewrapping the root key versions allows the previous root
+// kms to be removed from the configuration and rewrapping the DEK versions
+// allows the old root key versions to be destroyed.
+//
+// Supports the WithRewrap and WithRandomReader options.
+func (k *Kms) RotateKeys(ctx context.Context, scopeId string, opt ...Option) error {
+	const op = "kms.(Kms).RotateKeys"
+	if scopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	if opts.withRandomReader == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing random reader")
+	}
+
+	root, rootDecryptor, err := k.rootWrappers(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	_, err = k.repo.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(r db.Reader, w db.Writer) error {
+			rk := AllocRootKey()
+			if err := r.LookupWhere(ctx, &rk, "scope_id = ?", scopeId); err != nil {
+				if errors.IsNotFoundError(err) {
+					return errors.New(ctx, errors.KeyNotFound, op, fmt.Sprintf("missing root key for scope %s", scopeId))
+				}
+				return errors.Wrap(ctx, err, op)
+			}
+
+			rootKey, err := generateKey(ctx, opts.withRandomReader)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			rkv := AllocRootKeyVersion()
+			if rkv.PrivateId, err = newRootKeyVersionId(); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			rkv.RootKeyId = rk.PrivateId
+			rkv.Key = rootKey
+			if err := rkv.Encrypt(ctx, root); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			// no oplog entries for root key versions
+			if err := w.Create(ctx, &rkv); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("root key version"))
+			}
+			rkvWrapper, err := newAeadWrapper(ctx, rkv.PrivateId, rootKey)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
+			for _, t := range dekTables {
+				dekId, err := lookupDekId(ctx, r, t, rk.PrivateId)
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				key, err := generateKey(ctx, opts.withRandomReader)
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				dv := &dekVersion{
+					RootKeyVersionId: rkv.PrivateId,
+					Key:              key,
+				}
+				if dv.PrivateId, err = t.newVersionId(); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if err := structwrapping.WrapStruct(ctx, rkvWrapper, dv, nil); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+				}
+				// no oplog entries for key versions
+				if _, err := w.Exec(ctx, fmt.Sprintf(insertDekVersionQuery, t.versionTable, t.keyIdColumn), []interface{}{
+					sql.Named("private_id", dv.PrivateId),
+					sql.Named("dek_id", dekId),
+					sql.Named("root_key_version_id", dv.RootKeyVersionId),
+					sql.Named("key", dv.CtKey),
+				}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to create %s key version", t.purpose.String())))
+				}
+			}
+
+			if !opts.withRewrap {
+				return nil
+			}
+			if err := rewrapRootKeyVersions(ctx, r, w, rootDecryptor, root, rk.PrivateId); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			repo, err := NewRepository(r, w)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			rootWrapper, _, err := k.loadRoot(ctx, scopeId, WithRepository(repo))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			for _, t := range dekTables {
+				var dvs []*dekVersion
+				if err := queryDekVersions(ctx, r, &dvs, fmt.Sprintf(dekVersionsQuery, t.versionTable, t.keyTable, t.keyIdColumn), []interface{}{
+					sql.Named("root_key_id", rk.PrivateId),
+					sql.Named("excluded_root_key_version_id", rkv.PrivateId),
+				}); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if err := rewrapDekVersions(ctx, w, t, dvs, rootWrapper, rkvWrapper); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for scope %s", scopeId)))
+	}
+	k.clearCache(scopeId)
+	return nil
+}
+
+// rootWrappers returns the external root wrapper and a wrapper which decrypts
+// root key versions encrypted by either the root or the previous root
+// wrapper.
+func (k *Kms) rootWrappers(ctx context.Context) (wrapping.Wrapper, wrapping.Wrapper, error) {
+	const op = "kms.(Kms).rootWrappers"
+	k.externalScopeCacheMutex.RLock()
+	externalWrappers := k.externalScopeCache[scope.Global.String()]
+	k.externalScopeCacheMutex.RUnlock()
+	if externalWrappers == nil {
+		return nil, nil, errors.New(ctx, errors.KeyNotFound, op, "could not find kms information at global scope")
+	}
+	externalWrappers.m.RLock()
+	defer externalWrappers.m.RUnlock()
+	if externalWrappers.root == nil {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "root key wrapper is nil")
+	}
+	return externalWrappers.root, externalWrappers.rootDecryptor(), nil
+}
+
+// newAeadWrapper returns a wrapper for the key of a root key or DEK version.
+func newAeadWrapper(ctx context.Context, keyId string, key []byte) (wrapping.Wrapper, error) {
+	const op = "kms.newAeadWrapper"
+	wrapper := aead.NewWrapper(nil)
+	if _, err := wrapper.SetConfig(map[string]string{
+		"key_id": keyId,
+	}); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error setting config on aead wrapper"))
+	}
+	if err := wrapper.SetAESGCMKeyBytes(key); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error setting key bytes on aead wrapper"))
+	}
+	return wrapper, nil
+}
+
+func lookupDekId(ctx context.Context, r db.Reader, t dekTable, rootKeyId string) (string, error) {
+	const op = "kms.lookupDekId"
+	rows, err := r.Query(ctx, fmt.Sprintf(dekIdQuery, t.keyTable), []interface{}{sql.Named("root_key_id", rootKeyId)})
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var dekId string
+	for rows.Next() {
+		if err := rows.Scan(&dekId); err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+	}
+	if dekId == "" {
+		return "", errors.New(ctx, errors.KeyNotFound, op, fmt.Sprintf("missing %s key for root key %s", t.purpose.String(), rootKeyId))
+	}
+	return dekId, nil
+}
+
+func queryDekVersions(ctx context.Context, r db.Reader, dvs *[]*dekVersion, query string, values []interface{}) error {
+	const op = "kms.queryDekVersions"
+	rows, err := r.Query(ctx, query, values)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var dv dekVersion
+		if err := r.ScanRows(rows, &dv); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		*dvs = append(*dvs, &dv)
+	}
+	return nil
+}
+
+// rewrapDekVersions decrypts the keys of dvs with decryptor and encrypts them
+// with rkvWrapper, the wrapper of a root key version.
+func rewrapDekVersions(ctx context.Context, w db.Writer, t dekTable, dvs []*dekVersion, decryptor, rkvWrapper wrapping.Wrapper) error {
+	const op = "kms.rewrapDekVersions"
+	for _, dv := range dvs {
+		if err := structwrapping.UnwrapStruct(ctx, decryptor, dv, nil); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg(fmt.Sprintf("unable to decrypt %s key version %s", t.purpose.String(), dv.PrivateId)))
+		}
+		if err := structwrapping.WrapStruct(ctx, rkvWrapper, dv, nil); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg(fmt.Sprintf("unable to encrypt %s key version %s", t.purpose.String(), dv.PrivateId)))
+		}
+		// no oplog entries for key versions
+		rowsUpdated, err := w.Exec(ctx, fmt.Sprintf(rewrapKeyVersionQuery, t.versionTable), []interface{}{
+			sql.Named("key", dv.CtKey),
+			sql.Named("root_key_version_id", rkvWrapper.KeyID()),
+			sql.Named("private_id", dv.PrivateId),
+		})
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to rewrap %s key version %s", t.purpose.String(), dv.PrivateId)))
+		}
+		if rowsUpdated != 1 {
+			return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("%d %s key versions would have been updated", rowsUpdated, t.purpose.String()))
+		}
+	}
+	return nil
+}
+
+// rewrapRootKeyVersions decrypts the versions of rootKeyId with decryptor and
+// encrypts them with rootWrapper.
+func rewrapRootKeyVersions(ctx context.Context, r db.Reader, w db.Writer, decryptor, rootWrapper wrapping.Wrapper, rootKeyId string) error {
+	const op = "kms.rewrapRootKeyVersions"
+	var rkvs []*RootKeyVersion
+	if err := r.SearchWhere(ctx, &rkvs, "root_key_id = ?", []interface{}{rootKeyId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, rkv := range rkvs {
+		if err := rkv.Decrypt(ctx, decryptor); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to decrypt root key version %s", rkv.PrivateId)))
+		}
+		if err := rkv.Encrypt(ctx, rootWrapper); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to encrypt root key version %s", rkv.PrivateId)))
+		}
+		// no oplog entries for root key versions
+		rowsUpdated, err := w.Exec(ctx, rewrapRootKeyVersionQuery, []interface{}{
+			sql.Named("key", rkv.CtKey),
+			sql.Named("private_id", rkv.PrivateId),
+		})
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to rewrap root key version %s", rkv.PrivateId)))
+		}
+		if rowsUpdated != 1 {
+			return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("%d root key versions would have been updated", rowsUpdated))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKeyVersions(t *testing.T, kmsCache *Kms, scopeId, purpose string) []*KeyVersion {
+	t.Helper()
+	keys, err := kmsCache.ListKeys(context.Background(), scopeId)
+	require.NoError(t, err)
+	for _, k := range keys {
+		if k.Purpose == purpose {
+			return k.Versions
+		}
+	}
+	require.FailNow(t, "key not found", purpose)
+	return nil
+}
+
+func TestKms_RotateKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	oldRoot := db.TestWrapper(t)
+	newRoot := db.TestWrapper(t)
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	_, err = CreateKeysTx(ctx, rw, rw, oldRoot, rand.Reader, scope.Global.String())
+	require.NoError(t, err)
+
+	kmsCache, err := NewKms(repo)
+	require.NoError(t, err)
+	require.NoError(t, kmsCache.AddExternalWrappers(WithRootWrapper(oldRoot)))
+
+	err = kmsCache.RotateKeys(ctx, "", WithRandomReader(rand.Reader))
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+
+	before, err := kmsCache.GetWrapper(ctx, scope.Global.String(), KeyPurposeDatabase)
+	require.NoError(t, err)
+	require.NoError(t, kmsCache.RotateKeys(ctx, scope.Global.String(), WithRandomReader(rand.Reader)))
+
+	assert.Len(t, testKeyVersions(t, kmsCache, scope.Global.String(), "root"), 2)
+	dkvs := testKeyVersions(t, kmsCache, scope.Global.String(), KeyPurposeDatabase.String())
+	require.Len(t, dkvs, 2)
+	assert.Equal(t, before.KeyID(), dkvs[1].Id)
+
+	kmsCache.clearCache(scope.Global.String())
+	after, err := kmsCache.GetWrapper(ctx, scope.Global.String(), KeyPurposeDatabase)
+	require.NoError(t, err)
+	assert.Equal(t, dkvs[0].Id, after.KeyID())
+
+	// Rewrapping with a new root kms allows the previous one to be removed.
+	rewrapKms, err := NewKms(repo)
+	require.NoError(t, err)
+	require.NoError(t, rewrapKms.AddExternalWrappers(WithRootWrapper(newRoot), WithPreviousRootWrapper(oldRoot)))
+	require.NoError(t, rewrapKms.RotateKeys(ctx, scope.Global.String(), WithRewrap(true), WithRandomReader(rand.Reader)))
+
+	newKms, err := NewKms(repo)
+	require.NoError(t, err)
+	require.NoError(t, newKms.AddExternalWrappers(WithRootWrapper(newRoot)))
+	_, err = newKms.GetWrapper(ctx, scope.Global.String(), KeyPurposeDatabase, WithKeyId(before.KeyID()))
+	require.NoError(t, err)
+}
+
+func TestKms_DestroyKeyVersion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	extWrapper := db.TestWrapper(t)
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	_, err = CreateKeysTx(ctx, rw, rw, extWrapper, rand.Reader, scope.Global.String())
+	require.NoError(t, err)
+
+	kmsCache, err := NewKms(repo, WithCacheTtl(0))
+	require.NoError(t, err)
+	require.NoError(t, kmsCache.AddExternalWrappers(WithRootWrapper(extWrapper)))
+
+	rkvs := testKeyVersions(t, kmsCache, scope.Global.String(), "root")
+	require.Len(t, rkvs, 1)
+	_, err = kmsCache.DestroyKeyVersion(ctx, scope.Global.String(), rkvs[0].Id)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "current version: unexpected error: %v", err)
+
+	_, err = kmsCache.DestroyKeyVersion(ctx, scope.Global.String(), "kopkv_1234567890")
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "oplog version: unexpected error: %v", err)
+
+	_, err = kmsCache.DestroyKeyVersion(ctx, scope.Global.String(), "krkv_1234567890")
+	assert.Truef(t, errors.Match(errors.T(errors.RecordNotFound), err), "unknown version: unexpected error: %v", err)
+
+	require.NoError(t, kmsCache.RotateKeys(ctx, scope.Global.String(), WithRandomReader(rand.Reader)))
+
+	// A root key version is destroyed immediately.
+	destroyed, err := kmsCache.DestroyKeyVersion(ctx, scope.Global.String(), rkvs[0].Id)
+	require.NoError(t, err)
+	assert.True(t, destroyed)
+	assert.Len(t, testKeyVersions(t, kmsCache, scope.Global.String(), "root"), 1)
+
+	// A database key version is destroyed by its destruction job.
+	dkvs := testKeyVersions(t, kmsCache, scope.Global.String(), KeyPurposeDatabase.String())
+	require.Len(t, dkvs, 2)
+	destroyed, err = kmsCache.DestroyKeyVersion(ctx, scope.Global.String(), dkvs[1].Id)
+	require.NoError(t, err)
+	assert.False(t, destroyed)
+
+	jobs, err := kmsCache.ListDataKeyVersionDestructionJobs(ctx, scope.Global.String())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, dkvs[1].Id, jobs[0].KeyId)
+	assert.Equal(t, "pending", jobs[0].Status())
+
+	destroyed, err = kmsCache.RunDataKeyVersionDestructionJob(ctx, dkvs[1].Id)
+	require.NoError(t, err)
+	assert.True(t, destroyed)
+
+	jobs, err = kmsCache.ListDataKeyVersionDestructionJobs(ctx, scope.Global.String())
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+	assert.Len(t, testKeyVersions(t, kmsCache, scope.Global.String(), KeyPurposeDatabase.String()), 1)
+}
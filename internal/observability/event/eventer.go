@@ -235,6 +235,33 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 				return nil, fmt.Errorf("%s: %w", op, err)
 			}
 			sinkId = eventlogger.NodeID(id)
+		case HttpSink:
+			var enforced []Type
+			if c.AuditDelivery == Enforced {
+				enforced = append(enforced, AuditType)
+			}
+			hs, err := newHttpSink(log, s.HttpConfig, enforced...)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkNode = hs
+			e.flushableNodes = append(e.flushableNodes, hs)
+			id, err := NewId("http")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkId = eventlogger.NodeID(id)
+		case SyslogSink:
+			ss, err := newSyslogSink(s.Format, s.SyslogConfig)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkNode = ss
+			id, err := NewId("syslog")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkId = eventlogger.NodeID(id)
 		default:
 			return nil, fmt.Errorf("%s: unknown sink type %s", op, s.Type)
 		}
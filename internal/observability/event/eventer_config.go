@@ -6,16 +6,20 @@ import (
 
 // EventerConfig supplies all the configuration needed to create/config an Eventer.
 type EventerConfig struct {
-	AuditEnabled        bool          `hcl:"audit_enabled"`        // AuditEnabled specifies if audit events should be emitted.
-	ObservationsEnabled bool          `hcl:"observations_enabled"` // ObservationsEnabled specifies if observation events should be emitted.
-	SysEventsEnabled    bool          `hcl:"sysevents_enabled"`    // SysEventsEnabled specifies if sysevents should be emitted.
-	Sinks               []*SinkConfig `hcl:"-"`                    // Sinks are all the configured sinks
+	AuditEnabled        bool              `hcl:"audit_enabled"`        // AuditEnabled specifies if audit events should be emitted.
+	ObservationsEnabled bool              `hcl:"observations_enabled"` // ObservationsEnabled specifies if observation events should be emitted.
+	SysEventsEnabled    bool              `hcl:"sysevents_enabled"`    // SysEventsEnabled specifies if sysevents should be emitted.
+	AuditDelivery       DeliveryGuarantee `hcl:"audit_delivery"`       // AuditDelivery specifies the delivery guarantee for audit events (best-effort or enforced).
+	Sinks               []*SinkConfig     `hcl:"-"`                    // Sinks are all the configured sinks
 }
 
 // Validate will Validate the config. A config isn't required to have any
 // sinks to be valid.
 func (c *EventerConfig) Validate() error {
 	const op = "event.(EventerConfig).Validate"
+	if err := c.AuditDelivery.validate(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 	for i, s := range c.Sinks {
 		if err := s.Validate(); err != nil {
 			return fmt.Errorf("%s: sink %d is invalid: %w", op, i, err)
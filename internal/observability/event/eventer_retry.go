@@ -2,12 +2,14 @@ package event
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
 	"time"
 
 	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -38,7 +40,19 @@ type sendHandler func() (eventlogger.Status, error)
 // retrySend will attempt sendHandler (which is intended to be a closure that
 // sends an event) the specified number of retries using the specified backoff.
 func (e *Eventer) retrySend(ctx context.Context, retries uint, backOff backoff, handler sendHandler) error {
-	const op = "event.(Eventer).retrySend"
+	return retrySend(ctx, e.logger, retries, backOff, handler)
+}
+
+// retrySend will attempt sendHandler the specified number of retries using the
+// specified backoff.  Any warnings returned by the handler are logged to the
+// logger.  The send isn't retried when a warning shows that a node already
+// retried the event itself, since sending it again would duplicate the event
+// in the nodes which succeeded.
+func retrySend(ctx context.Context, logger hclog.Logger, retries uint, backOff backoff, handler sendHandler) error {
+	const op = "event.retrySend"
+	if logger == nil {
+		return fmt.Errorf("%s: missing logger: %w", op, ErrInvalidParameter)
+	}
 	if backOff == nil {
 		return fmt.Errorf("%s: missing backoff: %w", op, ErrInvalidParameter)
 	}
@@ -61,10 +75,13 @@ func (e *Eventer) retrySend(ctx context.Context, retries uint, backOff backoff,
 			for _, w := range attemptStatus.Warnings {
 				retryWarnings = multierror.Append(retryWarnings, w)
 			}
-			e.logger.Error("unable to send event", "operation", op, "warning", retryWarnings)
+			logger.Error("unable to send event", "operation", op, "warning", retryWarnings)
 		}
 		if err != nil {
 			retryErrors = multierror.Append(retryErrors, fmt.Errorf("%s: %w", op, err))
+			if retriedByNode(attemptStatus) {
+				return multierror.Append(retryErrors, attemptStatus.Warnings...)
+			}
 			d := backOff.duration(attempts)
 			info.retries++
 			info.backoff = info.backoff + d
@@ -79,3 +96,14 @@ func (e *Eventer) retrySend(ctx context.Context, retries uint, backOff backoff,
 	}
 	return nil
 }
+
+// retriedByNode reports whether a node of the pipeline already reached its
+// max retries sending the event.
+func retriedByNode(s eventlogger.Status) bool {
+	for _, w := range s.Warnings {
+		if errors.Is(w, ErrMaxRetries) {
+			return true
+		}
+	}
+	return false
+}
@@ -119,3 +119,22 @@ func TestEventer_retrySend(t *testing.T) {
 		})
 	}
 }
+
+func Test_retrySend_retriedByNode(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	testLogger := hclog.New(&hclog.LoggerOptions{Name: "test"})
+
+	attempts := 0
+	err := retrySend(ctx, testLogger, 3, expBackoff{}, func() (eventlogger.Status, error) {
+		attempts++
+		return eventlogger.Status{
+				Warnings: []error{fmt.Errorf("%s: gave up: %w", "Test_retrySend_retriedByNode", ErrMaxRetries)},
+			},
+			fmt.Errorf("%s: not enough sinks: %w", "Test_retrySend_retriedByNode", ErrIo)
+	})
+	require.Error(err)
+	assert.ErrorIs(err, ErrIo)
+	assert.Equal(1, attempts)
+}
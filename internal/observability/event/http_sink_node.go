@@ -0,0 +1,270 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	defaultHttpBatchSize     = 100              // defaultHttpBatchSize is the default max number of events in a request
+	defaultHttpFlushInterval = 5 * time.Second  // defaultHttpFlushInterval is the default max time an event is buffered
+	defaultHttpTimeout       = 10 * time.Second // defaultHttpTimeout is the default time limit for a request
+
+	// httpSinkContentType is the content type of a batch of events using the
+	// cloudevents JSON batch format.
+	httpSinkContentType = "application/cloudevents-batch+json"
+)
+
+// httpSink is an eventlogger sink node which POSTs batches of cloudevents-json
+// formatted events to a URL.
+//
+// Events are buffered until either the batch size is reached or the flush
+// interval has passed, at which point the batch is sent in the background
+// using retrySend. Events whose type has an enforced delivery guarantee are
+// not buffered: they are sent immediately, retrying within the node so that
+// the Eventer doesn't resend the event to the other sinks, and an error is
+// returned if they still can't be sent so the failure is reported to the
+// caller.
+type httpSink struct {
+	url           string
+	headers       map[string]string
+	batchSize     int
+	flushInterval time.Duration
+	client        *http.Client
+	logger        hclog.Logger
+	enforced      map[eventlogger.EventType]bool
+	retries       uint
+	backoff       backoff
+
+	l        sync.Mutex
+	pending  [][]byte
+	timer    *time.Timer
+	inFlight sync.WaitGroup
+}
+
+var (
+	_ eventlogger.Node = &httpSink{}
+	_ flushable        = &httpSink{}
+)
+
+// newHttpSink creates a new httpSink from the config.  The enforced event types
+// are sent immediately instead of being batched.
+func newHttpSink(logger hclog.Logger, c *HttpSinkTypeConfig, enforced ...Type) (*httpSink, error) {
+	const op = "event.newHttpSink"
+	if logger == nil {
+		return nil, fmt.Errorf("%s: missing logger: %w", op, ErrInvalidParameter)
+	}
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing http sink config: %w", op, ErrInvalidParameter)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s := &httpSink{
+		url:           c.Url,
+		headers:       c.Headers,
+		batchSize:     c.BatchSize,
+		flushInterval: c.FlushInterval,
+		logger:        logger,
+		enforced:      make(map[eventlogger.EventType]bool, len(enforced)),
+		retries:       stdRetryCount,
+		backoff:       expBackoff{},
+	}
+	if s.batchSize == 0 {
+		s.batchSize = defaultHttpBatchSize
+	}
+	if s.flushInterval == 0 {
+		s.flushInterval = defaultHttpFlushInterval
+	}
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultHttpTimeout
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	s.client = &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+	for _, t := range enforced {
+		s.enforced[eventlogger.EventType(t)] = true
+	}
+	return s, nil
+}
+
+// tlsConfig returns the tls.Config for the http sink's client.
+func (c *HttpSinkTypeConfig) tlsConfig() (*tls.Config, error) {
+	const op = "event.(HttpSinkTypeConfig).tlsConfig"
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.TlsServerName,
+		InsecureSkipVerify: c.TlsSkipVerify,
+	}
+	if c.TlsCaCertFile != "" {
+		pem, err := ioutil.ReadFile(c.TlsCaCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to read tls ca cert file: %w", op, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no certs found in tls ca cert file: %w", op, ErrInvalidParameter)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.TlsClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.TlsClientCertFile, c.TlsClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to load tls client cert: %w", op, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Type describes the type of the node as a Sink.
+func (s *httpSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Reopen does nothing for this type of Sink.
+func (s *httpSink) Reopen() error { return nil }
+
+// Process will buffer the event, or send it immediately if its type has an
+// enforced delivery guarantee.  An enforced event which can't be sent after
+// retrying returns an error wrapping ErrMaxRetries.
+func (s *httpSink) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(httpSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	val, ok := e.Format(string(JSONSinkFormat))
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not formatted as %s: %w", op, JSONSinkFormat, ErrInvalidParameter)
+	}
+	val = bytes.TrimSpace(val)
+
+	if s.enforced[e.Type] {
+		err := retrySend(ctx, s.logger, s.retries, s.backoff, func() (eventlogger.Status, error) {
+			return eventlogger.Status{}, s.send(ctx, [][]byte{val})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return nil, nil
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.pending = append(s.pending, val)
+	switch {
+	case len(s.pending) >= s.batchSize:
+		s.sendPendingLocked()
+	case len(s.pending) == 1:
+		s.timer = time.AfterFunc(s.flushInterval, func() {
+			s.l.Lock()
+			defer s.l.Unlock()
+			s.sendPendingLocked()
+		})
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
+
+// FlushAll sends all of the buffered events and waits for all the batches in
+// flight to be sent.
+func (s *httpSink) FlushAll(ctx context.Context) error {
+	const op = "event.(httpSink).FlushAll"
+	s.l.Lock()
+	batch := s.takePendingLocked()
+	s.l.Unlock()
+	s.inFlight.Wait()
+	if len(batch) == 0 {
+		return nil
+	}
+	err := retrySend(ctx, s.logger, s.retries, s.backoff, func() (eventlogger.Status, error) {
+		return eventlogger.Status{}, s.send(ctx, batch)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// takePendingLocked returns and clears the buffered events.  The lock must be
+// held by the caller.
+func (s *httpSink) takePendingLocked() [][]byte {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	batch := s.pending
+	s.pending = nil
+	return batch
+}
+
+// sendPendingLocked sends the buffered events in the background.  Since the
+// events have a best-effort delivery guarantee, a batch which can't be sent
+// after retrying is logged and dropped.  The lock must be held by the caller.
+func (s *httpSink) sendPendingLocked() {
+	const op = "event.(httpSink).sendPendingLocked"
+	batch := s.takePendingLocked()
+	if len(batch) == 0 {
+		return
+	}
+	s.inFlight.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+		ctx := context.Background()
+		err := retrySend(ctx, s.logger, s.retries, s.backoff, func() (eventlogger.Status, error) {
+			return eventlogger.Status{}, s.send(ctx, batch)
+		})
+		if err != nil {
+			s.logger.Error("unable to send batch of events", "operation", op, "url", s.url, "events", len(batch), "error", err.Error())
+		}
+	}()
+}
+
+// send POSTs the batch of events as a JSON array.
+func (s *httpSink) send(ctx context.Context, batch [][]byte) error {
+	const op = "event.(httpSink).send"
+	body := make([]byte, 0, len(batch)*256)
+	body = append(body, '[')
+	body = append(body, bytes.Join(batch, []byte{','})...)
+	body = append(body, ']')
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: unable to create request: %w", op, err)
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", httpSinkContentType)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: unexpected response status %d from %s", op, resp.StatusCode, s.url)
+	}
+	return nil
+}
@@ -0,0 +1,394 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHttpCollector is a test http server which collects the batches of events
+// sent by an http sink.
+type testHttpCollector struct {
+	*httptest.Server
+
+	l        sync.Mutex
+	batches  [][]map[string]interface{}
+	headers  []http.Header
+	status   int
+	failures int
+	requests int
+}
+
+func newTestHttpCollector(t *testing.T) *testHttpCollector {
+	t.Helper()
+	c := &testHttpCollector{status: http.StatusOK}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.l.Lock()
+		defer c.l.Unlock()
+		c.requests++
+		if c.failures > 0 {
+			c.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if c.status != http.StatusOK {
+			w.WriteHeader(c.status)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		var batch []map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &batch))
+		c.batches = append(c.batches, batch)
+		c.headers = append(c.headers, r.Header.Clone())
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *testHttpCollector) setStatus(status int) {
+	c.l.Lock()
+	defer c.l.Unlock()
+	c.status = status
+}
+
+// failNext makes the next n requests fail.
+func (c *testHttpCollector) failNext(n int) {
+	c.l.Lock()
+	defer c.l.Unlock()
+	c.failures = n
+}
+
+func (c *testHttpCollector) requestCount() int {
+	c.l.Lock()
+	defer c.l.Unlock()
+	return c.requests
+}
+
+func (c *testHttpCollector) received() ([][]map[string]interface{}, []http.Header) {
+	c.l.Lock()
+	defer c.l.Unlock()
+	return c.batches, c.headers
+}
+
+type testZeroBackoff struct{}
+
+func (testZeroBackoff) duration(uint) time.Duration { return 0 }
+
+func testHttpEvent(t *testing.T, typ Type, id string) *eventlogger.Event {
+	t.Helper()
+	e := &eventlogger.Event{
+		Type:      eventlogger.EventType(typ),
+		CreatedAt: time.Now(),
+	}
+	e.FormattedAs(string(JSONSinkFormat), []byte(fmt.Sprintf(`{"id":%q,"type":%q}`+"\n", id, typ)))
+	return e
+}
+
+func Test_newHttpSink(t *testing.T) {
+	t.Parallel()
+	testLogger := hclog.New(&hclog.LoggerOptions{Name: "test"})
+	tests := []struct {
+		name            string
+		logger          hclog.Logger
+		config          *HttpSinkTypeConfig
+		enforced        []Type
+		wantBatchSize   int
+		wantInterval    time.Duration
+		wantTimeout     time.Duration
+		wantEnforced    map[eventlogger.EventType]bool
+		wantErrIs       error
+		wantErrContains string
+	}{
+		{
+			name:            "missing-logger",
+			config:          &HttpSinkTypeConfig{Url: "http://localhost"},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing logger",
+		},
+		{
+			name:            "missing-config",
+			logger:          testLogger,
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing http sink config",
+		},
+		{
+			name:            "invalid-config",
+			logger:          testLogger,
+			config:          &HttpSinkTypeConfig{},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing url",
+		},
+		{
+			name:   "missing-ca-cert-file",
+			logger: testLogger,
+			config: &HttpSinkTypeConfig{
+				Url:           "https://localhost",
+				TlsCaCertFile: "/does/not/exist.pem",
+			},
+			wantErrContains: "unable to read tls ca cert file",
+		},
+		{
+			name:          "defaults",
+			logger:        testLogger,
+			config:        &HttpSinkTypeConfig{Url: "http://localhost"},
+			wantBatchSize: defaultHttpBatchSize,
+			wantInterval:  defaultHttpFlushInterval,
+			wantTimeout:   defaultHttpTimeout,
+			wantEnforced:  map[eventlogger.EventType]bool{},
+		},
+		{
+			name:   "configured",
+			logger: testLogger,
+			config: &HttpSinkTypeConfig{
+				Url:           "http://localhost",
+				BatchSize:     10,
+				FlushInterval: time.Second,
+				Timeout:       2 * time.Second,
+			},
+			enforced:      []Type{AuditType},
+			wantBatchSize: 10,
+			wantInterval:  time.Second,
+			wantTimeout:   2 * time.Second,
+			wantEnforced:  map[eventlogger.EventType]bool{eventlogger.EventType(AuditType): true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := newHttpSink(tt.logger, tt.config, tt.enforced...)
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.Nil(got)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(err, tt.wantErrIs)
+				}
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantBatchSize, got.batchSize)
+			assert.Equal(tt.wantInterval, got.flushInterval)
+			assert.Equal(tt.wantTimeout, got.client.Timeout)
+			assert.Equal(tt.wantEnforced, got.enforced)
+		})
+	}
+}
+
+func Test_httpSink_Process(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testLogger := hclog.New(&hclog.LoggerOptions{Name: "test"})
+
+	newSink := func(t *testing.T, c *testHttpCollector, batchSize int, interval time.Duration, enforced ...Type) *httpSink {
+		t.Helper()
+		s, err := newHttpSink(testLogger, &HttpSinkTypeConfig{
+			Url:           c.URL,
+			Headers:       map[string]string{"Authorization": "Bearer test-token"},
+			BatchSize:     batchSize,
+			FlushInterval: interval,
+		}, enforced...)
+		require.NoError(t, err)
+		s.backoff = testZeroBackoff{}
+		return s
+	}
+
+	t.Run("missing-event", func(t *testing.T) {
+		s := newSink(t, newTestHttpCollector(t), 1, time.Hour)
+		_, err := s.Process(ctx, nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+	t.Run("not-formatted", func(t *testing.T) {
+		s := newSink(t, newTestHttpCollector(t), 1, time.Hour)
+		_, err := s.Process(ctx, &eventlogger.Event{Type: eventlogger.EventType(ErrorType)})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+	t.Run("batch-size", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := newTestHttpCollector(t)
+		s := newSink(t, c, 2, time.Hour)
+		for i := 0; i < 3; i++ {
+			_, err := s.Process(ctx, testHttpEvent(t, ErrorType, fmt.Sprintf("e_%d", i)))
+			require.NoError(err)
+		}
+		// wait for the full batch to be sent in the background, but leave
+		// the last event buffered.
+		s.inFlight.Wait()
+		batches, headers := c.received()
+		require.Len(batches, 1)
+		assert.Len(batches[0], 2)
+		assert.Equal("e_0", batches[0][0]["id"])
+		assert.Equal("e_1", batches[0][1]["id"])
+		assert.Equal("Bearer test-token", headers[0].Get("Authorization"))
+		assert.Equal(httpSinkContentType, headers[0].Get("Content-Type"))
+
+		require.NoError(s.FlushAll(ctx))
+		batches, _ = c.received()
+		require.Len(batches, 2)
+		require.Len(batches[1], 1)
+		assert.Equal("e_2", batches[1][0]["id"])
+	})
+	t.Run("flush-interval", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := newTestHttpCollector(t)
+		s := newSink(t, c, 100, 10*time.Millisecond)
+		_, err := s.Process(ctx, testHttpEvent(t, ObservationType, "o_1"))
+		require.NoError(err)
+		assert.Eventually(func() bool {
+			batches, _ := c.received()
+			return len(batches) == 1
+		}, time.Second, 5*time.Millisecond)
+	})
+	t.Run("enforced", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := newTestHttpCollector(t)
+		s := newSink(t, c, 100, time.Hour, AuditType)
+
+		_, err := s.Process(ctx, testHttpEvent(t, ObservationType, "o_1"))
+		require.NoError(err)
+		_, err = s.Process(ctx, testHttpEvent(t, AuditType, "a_1"))
+		require.NoError(err)
+		// the enforced audit event is sent on its own, without waiting for
+		// the buffered observation.
+		batches, _ := c.received()
+		require.Len(batches, 1)
+		require.Len(batches[0], 1)
+		assert.Equal("a_1", batches[0][0]["id"])
+
+		// a failed send is retried by the sink
+		c.failNext(1)
+		_, err = s.Process(ctx, testHttpEvent(t, AuditType, "a_2"))
+		require.NoError(err)
+		batches, _ = c.received()
+		require.Len(batches, 2)
+		assert.Equal("a_2", batches[1][0]["id"])
+		assert.Equal(3, c.requestCount())
+
+		c.setStatus(http.StatusInternalServerError)
+		_, err = s.Process(ctx, testHttpEvent(t, AuditType, "a_3"))
+		require.Error(err)
+		assert.ErrorIs(err, ErrMaxRetries)
+		assert.Contains(err.Error(), "unexpected response status 500")
+		assert.Equal(3+int(s.retries)+1, c.requestCount())
+	})
+	t.Run("flush-all-error", func(t *testing.T) {
+		c := newTestHttpCollector(t)
+		c.setStatus(http.StatusServiceUnavailable)
+		s := newSink(t, c, 100, time.Hour)
+		_, err := s.Process(ctx, testHttpEvent(t, ErrorType, "e_1"))
+		require.NoError(t, err)
+		err = s.FlushAll(ctx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrMaxRetries)
+	})
+}
+
+func TestEventer_httpSink(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	testLock := &sync.Mutex{}
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: testLock,
+		Name:  "test",
+	})
+	c := newTestHttpCollector(t)
+
+	e, err := NewEventer(testLogger, testLock, "TestEventer_httpSink", EventerConfig{
+		Sinks: []*SinkConfig{
+			{
+				Name:       "http-sink",
+				EventTypes: []Type{ErrorType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url:           c.URL,
+					FlushInterval: time.Hour,
+				},
+			},
+		},
+	})
+	require.NoError(err)
+
+	testErr, err := newError("TestEventer_httpSink", fmt.Errorf("%s: test error", ErrInvalidParameter), WithId("test-error"))
+	require.NoError(err)
+	require.NoError(e.writeError(ctx, testErr))
+
+	batches, _ := c.received()
+	assert.Empty(batches)
+	require.NoError(e.FlushNodes(ctx))
+	batches, _ = c.received()
+	require.Len(batches, 1)
+	require.Len(batches[0], 1)
+	assert.Equal(string(ErrorType), batches[0][0]["type"])
+}
+
+func TestEventer_httpSink_enforced(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	testLock := &sync.Mutex{}
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: testLock,
+		Name:  "test",
+	})
+	c := newTestHttpCollector(t)
+	c.setStatus(http.StatusInternalServerError)
+	dir := t.TempDir()
+
+	e, err := NewEventer(testLogger, testLock, "TestEventer_httpSink_enforced", EventerConfig{
+		AuditEnabled:  true,
+		AuditDelivery: Enforced,
+		Sinks: []*SinkConfig{
+			{
+				Name:       "file-sink",
+				EventTypes: []Type{AuditType},
+				Type:       FileSink,
+				Format:     JSONSinkFormat,
+				FileConfig: &FileSinkTypeConfig{
+					Path:     dir,
+					FileName: "audit.log",
+				},
+			},
+			{
+				Name:       "http-sink",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url: c.URL,
+				},
+			},
+		},
+	})
+	require.NoError(err)
+
+	testAudit, err := newAudit("TestEventer_httpSink_enforced", WithId("test-audit"), WithFlush())
+	require.NoError(err)
+	err = e.writeAudit(ctx, testAudit)
+	require.Error(err)
+	assert.ErrorIs(err, ErrMaxRetries)
+
+	// the http sink retried the event itself, so the eventer did not send it
+	// to the file sink again.
+	assert.Equal(stdRetryCount+1, c.requestCount())
+	b, err := ioutil.ReadFile(filepath.Join(dir, "audit.log"))
+	require.NoError(err)
+	assert.Len(bytes.Split(bytes.TrimSpace(b), []byte("\n")), 1)
+}
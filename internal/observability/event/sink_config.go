@@ -2,6 +2,7 @@ package event
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -14,9 +15,11 @@ type SinkConfig struct {
 	AllowFilters   []string              `hcl:"allow_filters"`    // AllowFilters define a set predicates for including an event in the sink. If any filter matches, the event will be included. The filter should be in a format supported by hashicorp/go-bexpr.
 	DenyFilters    []string              `hcl:"deny_filters"`     // DenyFilters define a set predicates for excluding an event in the sink. If any filter matches, the event will be excluded. The filter should be in a format supported by hashicorp/go-bexpr.
 	Format         SinkFormat            `hcl:"format"`           // Format defines the format for the sink (JSONSinkFormat or TextSinkFormat).
-	Type           SinkType              `hcl:"type"`             // Type defines the type of sink (StderrSink or FileSink).
+	Type           SinkType              `hcl:"type"`             // Type defines the type of sink (StderrSink, FileSink, HttpSink or SyslogSink).
 	StderrConfig   *StderrSinkTypeConfig `hcl:"stderr"`           // StderrConfig defines parameters for a stderr output.
 	FileConfig     *FileSinkTypeConfig   `hcl:"file"`             // FileConfig defines parameters for a file output.
+	HttpConfig     *HttpSinkTypeConfig   `hcl:"http"`             // HttpConfig defines parameters for a http output.
+	SyslogConfig   *SyslogSinkTypeConfig `hcl:"syslog"`           // SyslogConfig defines parameters for a syslog output.
 	AuditConfig    *AuditConfig          `hcl:"audit_config"`     // AuditConfig defines optional parameters for audit events (if EventTypes contains audit)
 }
 
@@ -36,6 +39,12 @@ func (sc *SinkConfig) Validate() error {
 	if sc.FileConfig != nil {
 		foundSinkTypeConfigs++
 	}
+	if sc.HttpConfig != nil {
+		foundSinkTypeConfigs++
+	}
+	if sc.SyslogConfig != nil {
+		foundSinkTypeConfigs++
+	}
 	if foundSinkTypeConfigs > 1 {
 		return fmt.Errorf("%s: too many sink type config blocks: %w", op, ErrInvalidParameter)
 	}
@@ -56,6 +65,23 @@ func (sc *SinkConfig) Validate() error {
 		if sc.FileConfig.FileName == "" {
 			return fmt.Errorf("%s: missing file name: %w", op, ErrInvalidParameter)
 		}
+	case HttpSink:
+		if sc.HttpConfig == nil {
+			return fmt.Errorf(`%s: missing "http" block: %w`, op, ErrInvalidParameter)
+		}
+		if sc.Format != JSONSinkFormat {
+			return fmt.Errorf("%s: http sinks only support the %s format: %w", op, JSONSinkFormat, ErrInvalidParameter)
+		}
+		if err := sc.HttpConfig.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case SyslogSink:
+		if sc.SyslogConfig == nil {
+			return fmt.Errorf(`%s: missing "syslog" block: %w`, op, ErrInvalidParameter)
+		}
+		if err := sc.SyslogConfig.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
 	}
 	if sc.Name == "" {
 		return fmt.Errorf("%s: missing sink name: %w", op, ErrInvalidParameter)
@@ -95,6 +121,89 @@ type FileSinkTypeConfig struct {
 	RotateMaxFiles    int           `hcl:"rotate_max_files" mapstructure:"rotate_max_files"` // RotateMaxFiles defines how may historical rotated files should be kept for a FileSink
 }
 
+// HttpSinkTypeConfig contains configuration structures for http sink types.
+// Events are sent in batches as a JSON array of cloudevents.
+type HttpSinkTypeConfig struct {
+	Url               string            `hcl:"url"                  mapstructure:"url"`                  // Url defines the URL the batches of events are POSTed to
+	Headers           map[string]string `hcl:"headers"              mapstructure:"headers"`              // Headers defines additional headers sent with every request
+	BatchSize         int               `hcl:"batch_size"           mapstructure:"batch_size"`           // BatchSize defines the max number of events sent in a single request
+	FlushInterval     time.Duration     `hcl:"-"                    mapstructure:"flush_interval"`       // FlushInterval defines the max time an event is buffered before it's sent
+	FlushIntervalHCL  string            `hcl:"flush_interval"       json:"-"`                            // FlushIntervalHCL defines hcl string version of FlushInterval
+	Timeout           time.Duration     `hcl:"-"                    mapstructure:"timeout"`              // Timeout defines the time limit for a single request
+	TimeoutHCL        string            `hcl:"timeout"              json:"-"`                            // TimeoutHCL defines hcl string version of Timeout
+	TlsCaCertFile     string            `hcl:"tls_ca_cert_file"     mapstructure:"tls_ca_cert_file"`     // TlsCaCertFile defines a PEM-encoded CA cert file used to verify the server
+	TlsClientCertFile string            `hcl:"tls_client_cert_file" mapstructure:"tls_client_cert_file"` // TlsClientCertFile defines a PEM-encoded client cert file used for TLS client auth
+	TlsClientKeyFile  string            `hcl:"tls_client_key_file"  mapstructure:"tls_client_key_file"`  // TlsClientKeyFile defines a PEM-encoded client key file used for TLS client auth
+	TlsServerName     string            `hcl:"tls_server_name"      mapstructure:"tls_server_name"`      // TlsServerName defines the server name used to verify the server cert
+	TlsSkipVerify     bool              `hcl:"tls_skip_verify"      mapstructure:"tls_skip_verify"`      // TlsSkipVerify disables the verification of the server cert
+}
+
+// Validate a HttpSinkTypeConfig
+func (c *HttpSinkTypeConfig) Validate() error {
+	const op = "event.(HttpSinkTypeConfig).Validate"
+	if c.Url == "" {
+		return fmt.Errorf("%s: missing url: %w", op, ErrInvalidParameter)
+	}
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return fmt.Errorf("%s: invalid url: %w", op, ErrInvalidParameter)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return fmt.Errorf("%s: url scheme must be http or https: %w", op, ErrInvalidParameter)
+	}
+	if c.BatchSize < 0 {
+		return fmt.Errorf("%s: batch size must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if c.FlushInterval < 0 {
+		return fmt.Errorf("%s: flush interval must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("%s: timeout must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if (c.TlsClientCertFile == "") != (c.TlsClientKeyFile == "") {
+		return fmt.Errorf("%s: tls client cert and key files must both be set: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// SyslogSinkTypeConfig contains configuration structures for syslog sink types
+type SyslogSinkTypeConfig struct {
+	Network  string `hcl:"network"  mapstructure:"network"`  // Network defines the network used to connect to the syslog server (udp, tcp or unix)
+	Address  string `hcl:"address"  mapstructure:"address"`  // Address defines the address of the syslog server (host:port or a unix socket path)
+	Facility string `hcl:"facility" mapstructure:"facility"` // Facility defines the syslog facility of the events (defaults to local0)
+	AppName  string `hcl:"app_name" mapstructure:"app_name"` // AppName defines the syslog app-name of the events (defaults to boundary)
+}
+
+// Validate a SyslogSinkTypeConfig
+func (c *SyslogSinkTypeConfig) Validate() error {
+	const op = "event.(SyslogSinkTypeConfig).Validate"
+	switch c.Network {
+	case "udp", "tcp", "unix":
+	default:
+		return fmt.Errorf("%s: network must be udp, tcp or unix: %w", op, ErrInvalidParameter)
+	}
+	if c.Address == "" {
+		return fmt.Errorf("%s: missing address: %w", op, ErrInvalidParameter)
+	}
+	if c.Facility != "" {
+		if _, ok := syslogFacilities[c.Facility]; !ok {
+			return fmt.Errorf("%s: '%s' is not a valid syslog facility: %w", op, c.Facility, ErrInvalidParameter)
+		}
+	}
+	// RFC 5424 limits the app-name to 48 printable US-ASCII characters
+	if len(c.AppName) > 48 {
+		return fmt.Errorf("%s: app name must not be longer than 48 characters: %w", op, ErrInvalidParameter)
+	}
+	for _, r := range c.AppName {
+		if r < 33 || r > 126 {
+			return fmt.Errorf("%s: app name must only contain printable US-ASCII characters: %w", op, ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
 // FilterType defines a type for filters (allow or deny)
 type FilterType string
 
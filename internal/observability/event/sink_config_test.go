@@ -204,6 +204,177 @@ func TestSinkConfig_Validate(t *testing.T) {
 			wantErrIs:       ErrInvalidParameter,
 			wantErrContains: `too many sink type config blocks`,
 		},
+		{
+			name: "type mismatch http type file config",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				FileConfig: &FileSinkTypeConfig{},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: `missing "http" block`,
+		},
+		{
+			name: "http-sink-with-text-format",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       HttpSink,
+				Format:     TextSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url: "https://localhost/events",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "http sinks only support the cloudevents-json format",
+		},
+		{
+			name: "http-sink-with-no-url",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing url",
+		},
+		{
+			name: "http-sink-with-invalid-url-scheme",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url: "ftp://localhost/events",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "url scheme must be http or https",
+		},
+		{
+			name: "http-sink-with-negative-batch-size",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url:       "https://localhost/events",
+					BatchSize: -1,
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "batch size must not be negative",
+		},
+		{
+			name: "http-sink-with-client-cert-and-no-key",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url:               "https://localhost/events",
+					TlsClientCertFile: "client.pem",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "tls client cert and key files must both be set",
+		},
+		{
+			name: "type mismatch syslog type http config",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: `missing "syslog" block`,
+		},
+		{
+			name: "syslog-sink-with-invalid-network",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Network: "ip",
+					Address: "localhost:514",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "network must be udp, tcp or unix",
+		},
+		{
+			name: "syslog-sink-with-no-address",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Network: "udp",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing address",
+		},
+		{
+			name: "syslog-sink-with-invalid-facility",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Network:  "udp",
+					Address:  "localhost:514",
+					Facility: "local8",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "'local8' is not a valid syslog facility",
+		},
+		{
+			name: "syslog-sink-with-invalid-app-name",
+			sc: SinkConfig{
+				EventTypes: []Type{EveryType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Network: "udp",
+					Address: "localhost:514",
+					AppName: "my app",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "app name must only contain printable US-ASCII characters",
+		},
+		{
+			name: "valid-syslog",
+			sc: SinkConfig{
+				Name:       "valid",
+				EventTypes: []Type{EveryType},
+				Type:       SyslogSink,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Network:  "unix",
+					Address:  "/dev/log",
+					Facility: "local3",
+					AppName:  "boundary",
+				},
+				Format: TextHclogSinkFormat,
+			},
+		},
+		{
+			name: "valid-http",
+			sc: SinkConfig{
+				Name:       "valid",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url: "https://localhost/events",
+				},
+				Format: JSONSinkFormat,
+			},
+		},
 		{
 			name: "valid",
 			sc: SinkConfig{
@@ -7,14 +7,16 @@ import (
 const (
 	StderrSink SinkType = "stderr" // StderrSink is written to stderr
 	FileSink   SinkType = "file"   // FileSink is written to a file
+	HttpSink   SinkType = "http"   // HttpSink is POSTed to a URL
+	SyslogSink SinkType = "syslog" // SyslogSink is written to a syslog server
 )
 
-type SinkType string // SinkType defines the type of sink in a config stanza (file, stderr)
+type SinkType string // SinkType defines the type of sink in a config stanza (file, stderr, http, syslog)
 
 func (t SinkType) Validate() error {
 	const op = "event.(SinkType).validate"
 	switch t {
-	case StderrSink, FileSink:
+	case StderrSink, FileSink, HttpSink, SyslogSink:
 		return nil
 	default:
 		return fmt.Errorf("%s: '%s' is not a valid sink type: %w", op, t, ErrInvalidParameter)
@@ -0,0 +1,241 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+)
+
+const (
+	defaultSyslogFacility = "local0"   // defaultSyslogFacility is the default facility of syslog events
+	defaultSyslogAppName  = "boundary" // defaultSyslogAppName is the default app-name of syslog events
+
+	// syslogTimeout is the time limit for connecting to and writing to the
+	// syslog server
+	syslogTimeout = 5 * time.Second
+
+	// syslogTimestampFormat is the RFC 5424 timestamp format, which is
+	// limited to microsecond precision.
+	syslogTimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	syslogNilValue = "-" // syslogNilValue is the RFC 5424 NILVALUE
+)
+
+// syslogFacilities maps the facility names to their RFC 5424 numerical codes
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// syslog severities used for events, see RFC 5424 section 6.2.1
+const (
+	syslogSeverityError  = 3
+	syslogSeverityNotice = 5
+	syslogSeverityInfo   = 6
+)
+
+// syslogSeverity returns the syslog severity for an event type.
+func syslogSeverity(t eventlogger.EventType) int {
+	switch Type(t) {
+	case ErrorType:
+		return syslogSeverityError
+	case AuditType:
+		return syslogSeverityNotice
+	default:
+		return syslogSeverityInfo
+	}
+}
+
+// syslogSink is an eventlogger sink node which writes events to a syslog
+// server using RFC 5424 messages.  Messages sent over a stream (tcp or a unix
+// stream socket) are framed using octet counting (RFC 6587), while messages
+// sent over a datagram socket (udp or a unix datagram socket) are sent as a
+// single datagram.
+type syslogSink struct {
+	format   string
+	network  string
+	address  string
+	facility int
+	appName  string
+	hostname string
+	procId   string
+
+	l      sync.Mutex
+	conn   net.Conn
+	stream bool
+}
+
+var _ eventlogger.Node = &syslogSink{}
+
+// newSyslogSink creates a new syslogSink for events with the format.  The
+// connection to the syslog server is established when the first event is
+// written.
+func newSyslogSink(format SinkFormat, c *SyslogSinkTypeConfig) (*syslogSink, error) {
+	const op = "event.newSyslogSink"
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing syslog sink config: %w", op, ErrInvalidParameter)
+	}
+	if err := format.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s := &syslogSink{
+		format:   string(format),
+		network:  c.Network,
+		address:  c.Address,
+		facility: syslogFacilities[defaultSyslogFacility],
+		appName:  defaultSyslogAppName,
+		hostname: syslogNilValue,
+		procId:   strconv.Itoa(os.Getpid()),
+	}
+	if c.Facility != "" {
+		s.facility = syslogFacilities[c.Facility]
+	}
+	if c.AppName != "" {
+		s.appName = c.AppName
+	}
+	if h, err := os.Hostname(); err == nil && h != "" {
+		s.hostname = h
+	}
+	return s, nil
+}
+
+// Type describes the type of the node as a Sink.
+func (s *syslogSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Reopen will close the connection to the syslog server, which is
+// re-established when the next event is written.
+func (s *syslogSink) Reopen() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.closeLocked()
+}
+
+// Process will write the event to the syslog server.  If the write fails, the
+// connection is closed and re-established when the event is retried.
+func (s *syslogSink) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(syslogSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	val, ok := e.Format(s.format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not formatted as %s: %w", op, s.format, ErrInvalidParameter)
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.conn == nil {
+		if err := s.dialLocked(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	msg := s.message(e, bytes.TrimSpace(val))
+	if s.stream {
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout)); err != nil {
+		_ = s.closeLocked()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.conn.Write(msg); err != nil {
+		_ = s.closeLocked()
+		return nil, fmt.Errorf("%s: unable to write to syslog server: %w", op, err)
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
+
+// message returns the RFC 5424 message for the event, which is made of the
+// PRI, VERSION, TIMESTAMP, HOSTNAME, APP-NAME, PROCID, MSGID, STRUCTURED-DATA
+// and MSG parts.  The event type is used as the MSGID.
+func (s *syslogSink) message(e *eventlogger.Event, val []byte) []byte {
+	createdAt := e.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	msgId := string(e.Type)
+	if msgId == "" {
+		msgId = syslogNilValue
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s %s %s %s ",
+		s.facility*8+syslogSeverity(e.Type),
+		createdAt.UTC().Format(syslogTimestampFormat),
+		s.hostname,
+		s.appName,
+		s.procId,
+		msgId,
+		syslogNilValue,
+	)
+	return append([]byte(header), val...)
+}
+
+// dialLocked connects to the syslog server.  A unix socket is first dialed as
+// a datagram socket, and then as a stream socket, since syslog servers commonly
+// listen on a unix datagram socket.  The lock must be held by the caller.
+func (s *syslogSink) dialLocked() error {
+	const op = "event.(syslogSink).dialLocked"
+	var err error
+	switch s.network {
+	case "unix":
+		if s.conn, err = net.DialTimeout("unixgram", s.address, syslogTimeout); err == nil {
+			s.stream = false
+			return nil
+		}
+		s.conn, err = net.DialTimeout("unix", s.address, syslogTimeout)
+		s.stream = true
+	default:
+		s.conn, err = net.DialTimeout(s.network, s.address, syslogTimeout)
+		s.stream = s.network == "tcp"
+	}
+	if err != nil {
+		s.conn = nil
+		return fmt.Errorf("%s: unable to connect to syslog server: %w", op, err)
+	}
+	return nil
+}
+
+// closeLocked closes the connection to the syslog server.  The lock must be
+// held by the caller.
+func (s *syslogSink) closeLocked() error {
+	const op = "event.(syslogSink).closeLocked"
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newSyslogSink(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		format          SinkFormat
+		config          *SyslogSinkTypeConfig
+		wantFacility    int
+		wantAppName     string
+		wantErrIs       error
+		wantErrContains string
+	}{
+		{
+			name:            "missing-config",
+			format:          JSONSinkFormat,
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing syslog sink config",
+		},
+		{
+			name:            "invalid-format",
+			format:          "invalid",
+			config:          &SyslogSinkTypeConfig{Network: "udp", Address: "localhost:514"},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "not a valid sink format",
+		},
+		{
+			name:            "invalid-config",
+			format:          JSONSinkFormat,
+			config:          &SyslogSinkTypeConfig{Network: "udp"},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing address",
+		},
+		{
+			name:         "defaults",
+			format:       TextHclogSinkFormat,
+			config:       &SyslogSinkTypeConfig{Network: "udp", Address: "localhost:514"},
+			wantFacility: 16,
+			wantAppName:  "boundary",
+		},
+		{
+			name:   "configured",
+			format: JSONSinkFormat,
+			config: &SyslogSinkTypeConfig{
+				Network:  "tcp",
+				Address:  "localhost:514",
+				Facility: "auth",
+				AppName:  "boundary-controller",
+			},
+			wantFacility: 4,
+			wantAppName:  "boundary-controller",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := newSyslogSink(tt.format, tt.config)
+			if tt.wantErrIs != nil {
+				require.Error(err)
+				assert.Nil(got)
+				assert.ErrorIs(err, tt.wantErrIs)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(string(tt.format), got.format)
+			assert.Equal(tt.wantFacility, got.facility)
+			assert.Equal(tt.wantAppName, got.appName)
+			assert.Equal(strconv.Itoa(os.Getpid()), got.procId)
+		})
+	}
+}
+
+func Test_syslogSink_Process(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	createdAt := time.Date(2021, 9, 1, 12, 30, 15, 123456789, time.UTC)
+	testEvent := func(typ Type, format SinkFormat, msg string) *eventlogger.Event {
+		e := &eventlogger.Event{
+			Type:      eventlogger.EventType(typ),
+			CreatedAt: createdAt,
+		}
+		e.FormattedAs(string(format), []byte(msg+"\n"))
+		return e
+	}
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	wantHeader := func(pri int, typ Type) string {
+		return fmt.Sprintf("<%d>1 2021-09-01T12:30:15.123456Z %s boundary %d %s - ", pri, hostname, os.Getpid(), typ)
+	}
+
+	t.Run("missing-event", func(t *testing.T) {
+		s, err := newSyslogSink(JSONSinkFormat, &SyslogSinkTypeConfig{Network: "udp", Address: "localhost:514"})
+		require.NoError(t, err)
+		_, err = s.Process(ctx, nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+	t.Run("not-formatted", func(t *testing.T) {
+		s, err := newSyslogSink(JSONSinkFormat, &SyslogSinkTypeConfig{Network: "udp", Address: "localhost:514"})
+		require.NoError(t, err)
+		_, err = s.Process(ctx, testEvent(ErrorType, TextHclogSinkFormat, "msg"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+	t.Run("udp", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(err)
+		defer pc.Close()
+
+		s, err := newSyslogSink(JSONSinkFormat, &SyslogSinkTypeConfig{Network: "udp", Address: pc.LocalAddr().String()})
+		require.NoError(err)
+		defer s.Reopen()
+
+		tests := []struct {
+			typ     Type
+			wantPri int
+		}{
+			{typ: ErrorType, wantPri: 16*8 + 3},
+			{typ: AuditType, wantPri: 16*8 + 5},
+			{typ: SystemType, wantPri: 16*8 + 6},
+			{typ: ObservationType, wantPri: 16*8 + 6},
+		}
+		for _, tt := range tests {
+			_, err = s.Process(ctx, testEvent(tt.typ, JSONSinkFormat, `{"id":"test"}`))
+			require.NoError(err)
+
+			buf := make([]byte, 1024)
+			require.NoError(pc.SetReadDeadline(time.Now().Add(5 * time.Second)))
+			n, _, err := pc.ReadFrom(buf)
+			require.NoError(err)
+			assert.Equal(wantHeader(tt.wantPri, tt.typ)+`{"id":"test"}`, string(buf[:n]))
+		}
+	})
+	t.Run("tcp", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(err)
+		defer l.Close()
+
+		s, err := newSyslogSink(TextHclogSinkFormat, &SyslogSinkTypeConfig{
+			Network:  "tcp",
+			Address:  l.Addr().String(),
+			Facility: "user",
+		})
+		require.NoError(err)
+		defer s.Reopen()
+
+		_, err = s.Process(ctx, testEvent(ErrorType, TextHclogSinkFormat, "[ERROR] first"))
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent(SystemType, TextHclogSinkFormat, "[INFO] second"))
+		require.NoError(err)
+
+		conn, err := l.Accept()
+		require.NoError(err)
+		defer conn.Close()
+		require.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		r := bufio.NewReader(conn)
+		readFrame := func() string {
+			l, err := r.ReadString(' ')
+			require.NoError(err)
+			n, err := strconv.Atoi(strings.TrimSpace(l))
+			require.NoError(err)
+			frame := make([]byte, n)
+			_, err = io.ReadFull(r, frame)
+			require.NoError(err)
+			return string(frame)
+		}
+		assert.Equal(wantHeader(1*8+3, ErrorType)+"[ERROR] first", readFrame())
+		assert.Equal(wantHeader(1*8+6, SystemType)+"[INFO] second", readFrame())
+	})
+	t.Run("unix", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		addr := filepath.Join(t.TempDir(), "syslog.sock")
+		pc, err := net.ListenPacket("unixgram", addr)
+		require.NoError(err)
+		defer pc.Close()
+
+		s, err := newSyslogSink(JSONSinkFormat, &SyslogSinkTypeConfig{Network: "unix", Address: addr, AppName: "test-app"})
+		require.NoError(err)
+		defer s.Reopen()
+
+		_, err = s.Process(ctx, testEvent(AuditType, JSONSinkFormat, `{"id":"test"}`))
+		require.NoError(err)
+		assert.False(s.stream)
+
+		buf := make([]byte, 1024)
+		require.NoError(pc.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		n, _, err := pc.ReadFrom(buf)
+		require.NoError(err)
+		want := fmt.Sprintf("<%d>1 2021-09-01T12:30:15.123456Z %s test-app %d audit - {\"id\":\"test\"}", 16*8+5, hostname, os.Getpid())
+		assert.Equal(want, string(buf[:n]))
+	})
+	t.Run("unable-to-connect", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := newSyslogSink(JSONSinkFormat, &SyslogSinkTypeConfig{Network: "unix", Address: filepath.Join(t.TempDir(), "missing.sock")})
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent(ErrorType, JSONSinkFormat, "msg"))
+		require.Error(err)
+		assert.Contains(err.Error(), "unable to connect to syslog server")
+		assert.Nil(s.conn)
+	})
+}
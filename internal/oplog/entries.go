@@ -0,0 +1,232 @@
+package oplog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+const (
+	verifyTicketsQuery = `
+select t.name, t.version, count(e.id) as entry_count
+  from oplog_ticket t
+  left join oplog_entry e on e.aggregate_name = t.name
+ group by t.name, t.version
+union all
+select e.aggregate_name, 0, count(*)
+  from oplog_entry e
+ where not exists (select from oplog_ticket t where t.name = e.aggregate_name)
+ group by e.aggregate_name
+ order by name;
+`
+
+	tableColumnsQuery = `
+select column_name
+  from information_schema.columns
+ where table_schema = current_schema()
+   and table_name = ?
+ order by ordinal_position;
+`
+)
+
+// ListEntries returns the entries with their metadata ordered by id. The
+// data of the entries is not decrypted. Supports the WithStartTime,
+// WithEndTime and WithAggregateName options.
+func ListEntries(ctx context.Context, tx *gorm.DB, opt ...Option) ([]*Entry, error) {
+	const op = "oplog.ListEntries"
+	if tx == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil tx")
+	}
+	opts := GetOpts(opt...)
+	q := tx.WithContext(ctx).Order("id asc")
+	if startTime := opts[optionWithStartTime].(time.Time); !startTime.IsZero() {
+		q = q.Where("create_time >= ?", startTime)
+	}
+	if endTime := opts[optionWithEndTime].(time.Time); !endTime.IsZero() {
+		q = q.Where("create_time < ?", endTime)
+	}
+	if aggregateName := opts[optionWithAggregateName].(string); aggregateName != "" {
+		q = q.Where("aggregate_name = ?", aggregateName)
+	}
+	var storeEntries []*store.Entry
+	if err := q.Find(&storeEntries).Error; err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error reading entries"))
+	}
+	if len(storeEntries) == 0 {
+		return nil, nil
+	}
+
+	entries := make([]*Entry, 0, len(storeEntries))
+	byId := make(map[uint32]*Entry, len(storeEntries))
+	ids := make([]uint32, 0, len(storeEntries))
+	for _, se := range storeEntries {
+		e := &Entry{Entry: se}
+		entries = append(entries, e)
+		byId[se.Id] = e
+		ids = append(ids, se.Id)
+	}
+	var md []*store.Metadata
+	if err := tx.WithContext(ctx).Where("entry_id in (?)", ids).Order("id asc").Find(&md).Error; err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error reading entry metadata"))
+	}
+	for _, m := range md {
+		if e, ok := byId[m.EntryId]; ok {
+			e.Metadata = append(e.Metadata, m)
+		}
+	}
+	return entries, nil
+}
+
+// KeyId returns the id of the key which encrypted the data of the entry.
+func (e *Entry) KeyId(ctx context.Context) (string, error) {
+	const op = "oplog.(Entry).KeyId"
+	if e.Entry == nil {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "nil entry")
+	}
+	if len(e.CtData) == 0 {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing encrypted data")
+	}
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(e.CtData, blobInfo); err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+	}
+	if blobInfo.GetKeyInfo().GetKeyID() == "" {
+		return "", errors.New(ctx, errors.Decode, op, "missing key id")
+	}
+	return blobInfo.GetKeyInfo().GetKeyID(), nil
+}
+
+// TicketCheck compares the version of an oplog ticket with the number of
+// entries of its aggregate. Every entry redeems the ticket of its aggregate,
+// which starts at version 1, so a ticket version that doesn't match the number
+// of entries means entries were removed or written without redeeming the
+// ticket.
+type TicketCheck struct {
+	Name string
+	// Version is 0 if entries were written for an aggregate without a ticket.
+	Version    int64
+	EntryCount int64
+}
+
+// Ok returns true if the ticket version matches the number of entries.
+func (c *TicketCheck) Ok() bool {
+	return c.Version > 0 && c.Version-1 == c.EntryCount
+}
+
+// VerifyTickets returns a TicketCheck for every ticket and for every
+// aggregate of the entries without a ticket, ordered by name.
+func VerifyTickets(ctx context.Context, tx *gorm.DB) ([]*TicketCheck, error) {
+	const op = "oplog.VerifyTickets"
+	if tx == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil tx")
+	}
+	var checks []*TicketCheck
+	if err := tx.WithContext(ctx).Raw(verifyTicketsQuery).Scan(&checks).Error; err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error reading tickets"))
+	}
+	return checks, nil
+}
+
+// ReplayEntries replays the decrypted entries, in order, into tables named
+// like the tables of their messages with tableSuffix appended. The replay
+// tables are dropped first if they exist. Returns the names of the tables of
+// the replayed messages.
+func ReplayEntries(ctx context.Context, tx *gorm.DB, types *TypeCatalog, tableSuffix string, entries ...*Entry) ([]string, error) {
+	const op = "oplog.ReplayEntries"
+	switch {
+	case tx == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil tx")
+	case types == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil type catalog")
+	case tableSuffix == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing table suffix")
+	}
+	w := &GormWriter{Tx: tx.WithContext(ctx)}
+
+	found := map[string]bool{}
+	var tables []string
+	for _, e := range entries {
+		msgs, err := e.UnmarshalData(types)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("entry %d", e.Id)))
+		}
+		for _, m := range msgs {
+			rm, ok := m.Message.(ReplayableMessage)
+			if !ok {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%T is not a replayable message", m.Message))
+			}
+			if !found[rm.TableName()] {
+				found[rm.TableName()] = true
+				tables = append(tables, rm.TableName())
+			}
+		}
+	}
+	sort.Strings(tables)
+	for _, t := range tables {
+		if err := w.dropTableIfExists(t + tableSuffix); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	for _, e := range entries {
+		if err := e.Replay(ctx, w, types, tableSuffix); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("entry %d", e.Id)))
+		}
+	}
+	return tables, nil
+}
+
+// CompareReplayTable compares the replay table of table with table. It
+// returns the number of rows of the replay table and how many of them don't
+// match a row of table. The columns in ignoreColumns, for example columns
+// set by triggers, are not compared.
+func CompareReplayTable(ctx context.Context, tx *gorm.DB, table, tableSuffix string, ignoreColumns ...string) (int64, int64, error) {
+	const op = "oplog.CompareReplayTable"
+	switch {
+	case tx == nil:
+		return 0, 0, errors.New(ctx, errors.InvalidParameter, op, "nil tx")
+	case table == "":
+		return 0, 0, errors.New(ctx, errors.InvalidParameter, op, "missing table")
+	case tableSuffix == "":
+		return 0, 0, errors.New(ctx, errors.InvalidParameter, op, "missing table suffix")
+	}
+	tx = tx.WithContext(ctx)
+
+	var columns []string
+	if err := tx.Raw(tableColumnsQuery, table).Scan(&columns).Error; err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg("error reading columns"))
+	}
+	ignored := make(map[string]bool, len(ignoreColumns))
+	for _, c := range ignoreColumns {
+		ignored[c] = true
+	}
+	var compared []string
+	for _, c := range columns {
+		if !ignored[c] {
+			compared = append(compared, tx.Statement.Quote(c))
+		}
+	}
+	if len(compared) == 0 {
+		return 0, 0, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("no columns to compare in %s", table))
+	}
+
+	cols := strings.Join(compared, ", ")
+	replayTable := tx.Statement.Quote(table + tableSuffix)
+	var rows, mismatched int64
+	if err := tx.Raw(fmt.Sprintf("select count(*) from %s", replayTable)).Scan(&rows).Error; err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg("error counting replayed rows"))
+	}
+	query := fmt.Sprintf("select count(*) from (select %s from %s except select %s from %s) as mismatched",
+		cols, replayTable, cols, tx.Statement.Quote(table))
+	if err := tx.Raw(query).Scan(&mismatched).Error; err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg("error comparing rows"))
+	}
+	return rows, mismatched, nil
+}
@@ -0,0 +1,167 @@
+package oplog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/oplog/oplog_test"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// testEntry writes an entry for the "default" ticket creating a user.
+func testEntry(t *testing.T, db *gorm.DB, cipherer wrapping.Wrapper, metadata Metadata) (*Entry, *oplog_test.TestUser) {
+	t.Helper()
+	require := require.New(t)
+	ticketer, err := NewGormTicketer(db, WithAggregateNames(true))
+	require.NoError(err)
+	ticket, err := ticketer.GetTicket("default")
+	require.NoError(err)
+
+	e, err := NewEntry("default", metadata, cipherer, ticketer)
+	require.NoError(err)
+	u := testUser(t, db, "foo-"+testId(t), "", "")
+	require.NoError(e.WriteEntryWith(context.Background(), &GormWriter{db}, ticket,
+		&Message{Message: u, TypeName: "user", OpType: OpType_OP_TYPE_CREATE},
+	))
+	return e, u
+}
+
+func Test_ListEntries(t *testing.T) {
+	cleanup, db := setup(t)
+	defer testCleanup(t, cleanup, db)
+	ctx := context.Background()
+
+	cipherer := testWrapper(t)
+	before := time.Now().Add(-time.Minute)
+	e1, _ := testEntry(t, db, cipherer, Metadata{"project": []string{"a", "b"}})
+	e2, _ := testEntry(t, db, cipherer, Metadata{"key-only": nil})
+
+	t.Run("all", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		entries, err := ListEntries(ctx, db)
+		require.NoError(err)
+		require.Len(entries, 2)
+		assert.Equal(e1.Id, entries[0].Id)
+		assert.Equal(e2.Id, entries[1].Id)
+		assert.Len(entries[0].Metadata, 2)
+		assert.Len(entries[1].Metadata, 1)
+		assert.Empty(entries[0].Data)
+
+		keyId, err := entries[0].KeyId(ctx)
+		require.NoError(err)
+		assert.Equal(cipherer.KeyID(), keyId)
+
+		entries[0].Cipherer = cipherer
+		require.NoError(entries[0].DecryptData(ctx))
+		types, err := NewTypeCatalog(Type{new(oplog_test.TestUser), "user"})
+		require.NoError(err)
+		msgs, err := entries[0].UnmarshalData(types)
+		require.NoError(err)
+		assert.Len(msgs, 1)
+	})
+	t.Run("time range", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		entries, err := ListEntries(ctx, db, WithStartTime(before))
+		require.NoError(err)
+		assert.Len(entries, 2)
+		entries, err = ListEntries(ctx, db, WithEndTime(before))
+		require.NoError(err)
+		assert.Empty(entries)
+	})
+	t.Run("aggregate", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		entries, err := ListEntries(ctx, db, WithAggregateName("default"))
+		require.NoError(err)
+		assert.Len(entries, 2)
+		entries, err = ListEntries(ctx, db, WithAggregateName("iam_scope"))
+		require.NoError(err)
+		assert.Empty(entries)
+	})
+	t.Run("nil tx", func(t *testing.T) {
+		_, err := ListEntries(ctx, nil)
+		assert.Error(t, err)
+	})
+}
+
+func Test_VerifyTickets(t *testing.T) {
+	cleanup, db := setup(t)
+	defer testCleanup(t, cleanup, db)
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	cipherer := testWrapper(t)
+	testEntry(t, db, cipherer, Metadata{"key-only": nil})
+	testEntry(t, db, cipherer, Metadata{"key-only": nil})
+
+	checks, err := VerifyTickets(ctx, db)
+	require.NoError(err)
+	require.NotEmpty(checks)
+	for _, c := range checks {
+		assert.Truef(c.Ok(), "ticket %s: version %d, %d entries", c.Name, c.Version, c.EntryCount)
+	}
+
+	// An entry written without redeeming a ticket fails the check.
+	require.NoError(db.Exec("insert into oplog_entry (version, aggregate_name, data) values ('v1', 'default', 'x')").Error)
+	checks, err = VerifyTickets(ctx, db)
+	require.NoError(err)
+	var found bool
+	for _, c := range checks {
+		if c.Name == "default" {
+			found = true
+			assert.False(c.Ok())
+			assert.Equal(int64(3), c.Version)
+			assert.Equal(int64(3), c.EntryCount)
+		}
+	}
+	assert.True(found)
+}
+
+func Test_ReplayEntries(t *testing.T) {
+	cleanup, db := setup(t)
+	defer testCleanup(t, cleanup, db)
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	cipherer := testWrapper(t)
+	_, u1 := testEntry(t, db, cipherer, Metadata{"key-only": nil})
+	_, u2 := testEntry(t, db, cipherer, Metadata{"key-only": nil})
+	types, err := NewTypeCatalog(Type{new(oplog_test.TestUser), "user"})
+	require.NoError(err)
+
+	entries, err := ListEntries(ctx, db)
+	require.NoError(err)
+	for _, e := range entries {
+		e.Cipherer = cipherer
+		require.NoError(e.DecryptData(ctx))
+	}
+
+	tableSuffix := "_" + testId(t)
+	defer func() {
+		require.NoError((&GormWriter{db}).dropTableIfExists(u1.TableName() + tableSuffix))
+	}()
+	_, err = ReplayEntries(ctx, db, types, "", entries...)
+	require.Error(err)
+
+	tables, err := ReplayEntries(ctx, db, types, tableSuffix, entries...)
+	require.NoError(err)
+	assert.Equal([]string{u1.TableName()}, tables)
+
+	rows, mismatched, err := CompareReplayTable(ctx, db, u1.TableName(), tableSuffix, "create_time", "update_time")
+	require.NoError(err)
+	assert.Equal(int64(2), rows)
+	assert.Equal(int64(0), mismatched)
+
+	// Replaying again drops the replay tables first.
+	_, err = ReplayEntries(ctx, db, types, tableSuffix, entries...)
+	require.NoError(err)
+
+	require.NoError(db.Model(u2).Update("name", "changed").Error)
+	rows, mismatched, err = CompareReplayTable(ctx, db, u1.TableName(), tableSuffix, "create_time", "update_time")
+	require.NoError(err)
+	assert.Equal(int64(2), rows)
+	assert.Equal(int64(1), mismatched)
+}
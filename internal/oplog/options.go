@@ -1,5 +1,7 @@
 package oplog
 
+import "time"
+
 // GetOpts - iterate the inbound Options and return a struct
 func GetOpts(opt ...Option) Options {
 	opts := getDefaultOptions()
@@ -20,6 +22,9 @@ func getDefaultOptions() Options {
 		optionWithFieldMaskPaths: []string{},
 		optionWithSetToNullPaths: []string{},
 		optionWithAggregateNames: false,
+		optionWithStartTime:      time.Time{},
+		optionWithEndTime:        time.Time{},
+		optionWithAggregateName:  "",
 	}
 }
 
@@ -51,3 +56,33 @@ func WithAggregateNames(enabled bool) Option {
 		o[optionWithAggregateNames] = enabled
 	}
 }
+
+const optionWithStartTime = "optionWithStartTime"
+
+// WithStartTime restricts the entries listed to the ones created at or after
+// startTime.
+func WithStartTime(startTime time.Time) Option {
+	return func(o Options) {
+		o[optionWithStartTime] = startTime
+	}
+}
+
+const optionWithEndTime = "optionWithEndTime"
+
+// WithEndTime restricts the entries listed to the ones created before
+// endTime.
+func WithEndTime(endTime time.Time) Option {
+	return func(o Options) {
+		o[optionWithEndTime] = endTime
+	}
+}
+
+const optionWithAggregateName = "optionWithAggregateName"
+
+// WithAggregateName restricts the entries listed to the ones of the
+// aggregate aggregateName.
+func WithAggregateName(aggregateName string) Option {
+	return func(o Options) {
+		o[optionWithAggregateName] = aggregateName
+	}
+}
@@ -2,6 +2,7 @@ package oplog
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -28,4 +29,24 @@ func Test_GetOpts(t *testing.T) {
 		testOpts[optionWithAggregateNames] = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartTime", func(t *testing.T) {
+		now := time.Now()
+		opts := GetOpts(WithStartTime(now))
+		testOpts := getDefaultOptions()
+		testOpts[optionWithStartTime] = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndTime", func(t *testing.T) {
+		now := time.Now()
+		opts := GetOpts(WithEndTime(now))
+		testOpts := getDefaultOptions()
+		testOpts[optionWithEndTime] = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAggregateName", func(t *testing.T) {
+		opts := GetOpts(WithAggregateName("iam_scope"))
+		testOpts := getDefaultOptions()
+		testOpts[optionWithAggregateName] = "iam_scope"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -76,7 +76,29 @@ func (t TypeCatalog) Get(typeName string) (interface{}, error) {
 		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing type name")
 	}
 	if typ, ok := t[typeName]; ok {
-		return reflect.New(typ.Elem()).Elem().Addr().Interface(), nil
+		v := reflect.New(typ.Elem())
+		allocEmbedded(v.Elem())
+		return v.Interface(), nil
 	}
 	return nil, errors.NewDeprecated(errors.KeyNotFound, op, "type name not found")
 }
+
+// allocEmbedded allocates the embedded struct pointers of v, so types which
+// wrap their storage message (for example: iam.Scope embeds *store.Scope) can
+// be unmarshaled.
+func allocEmbedded(v reflect.Value) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.Anonymous || f.Type.Kind() != reflect.Ptr || f.Type.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		fv := v.Field(i)
+		if !fv.CanSet() || !fv.IsNil() {
+			continue
+		}
+		fv.Set(reflect.New(f.Type.Elem()))
+	}
+}
@@ -147,6 +147,22 @@ func Test_Get(t *testing.T) {
 		require.NoError(err)
 		assert.Equal(reflect.TypeOf(u), reflect.TypeOf(new(oplog_test.TestUser)))
 	})
+	t.Run("embedded", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		type wrappedUser struct {
+			*oplog_test.TestUser
+		}
+		types, err := NewTypeCatalog(
+			Type{new(wrappedUser), "user"},
+		)
+		require.NoError(err)
+
+		u, err := types.Get("user")
+		require.NoError(err)
+		require.IsType(new(wrappedUser), u)
+		assert.NotNil(u.(*wrappedUser).TestUser)
+	})
 	t.Run("bad name", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
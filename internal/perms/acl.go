@@ -55,6 +55,9 @@ type Resource struct {
 	// Pin if defined would constrain the resource within the collection of the
 	// pin id.
 	Pin string `json:"pin,omitempty"`
+
+	// Name of the resource, if known. It is only used by grant conditions.
+	Name string `json:"name,omitempty"`
 }
 
 // NewACL creates an ACL from the grants provided.
@@ -71,18 +74,93 @@ func NewACL(grants ...Grant) ACL {
 }
 
 // Allowed determines if the grants for an ACL allow an action for a resource.
-func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
+// A deny grant which matches the action and resource always takes precedence
+// over the allow grants, in which case the action is not authorized and no
+// output fields are returned.
+//
+// Grants with a condition only apply when their condition is met for the
+// resource and the request, which can be provided using WithRequest.
+func (a ACL) Allowed(r Resource, aType action.Type, opt ...Option) (results ACLResults) {
+	return a.allowed(r, aType, getOpts(opt...), nil)
+}
+
+// Explanation describes how an ACL arrived at the results for an action on a
+// resource.
+type Explanation struct {
+	ACLResults
+
+	// Grants are the grants which matched the action and resource. If a deny
+	// grant matched, it is the only grant included.
+	Grants []Grant
+
+	// ScopeIds are the IDs of the scopes whose grants were evaluated, which
+	// is only the resource's scope.
+	ScopeIds []string
+}
+
+// Denied reports whether the results are due to a deny grant.
+func (e Explanation) Denied() bool {
+	return len(e.Grants) == 1 && e.Grants[0].deny
+}
+
+// Explain returns the same results as Allowed along with the grants which
+// matched the action and resource.
+func (a ACL) Explain(r Resource, aType action.Type, opt ...Option) Explanation {
+	var ret Explanation
+	ret.ACLResults = a.allowed(r, aType, getOpts(opt...), func(g Grant) {
+		ret.Grants = append(ret.Grants, g)
+	})
+	ret.ScopeIds = []string{r.ScopeId}
+	return ret
+}
+
+// allowed implements Allowed. If matched is not nil it is called with each
+// grant which matched the action and resource, and evaluation doesn't stop
+// early so that all of the matching grants are found.
+func (a ACL) allowed(r Resource, aType action.Type, opts options, matched func(Grant)) (results ACLResults) {
 	// First, get the grants within the specified scope
 	grants := a.scopeMap[r.ScopeId]
 	results.scopeMap = a.scopeMap
+	var condData *conditionData
+	// conditionMet lazily builds the data for conditions, as most grants do
+	// not have one
+	conditionMet := func(g Grant, onError bool) bool {
+		if g.conditionEval == nil {
+			return true
+		}
+		if condData == nil {
+			d := newConditionData(r, aType, opts.withRequest)
+			condData = &d
+		}
+		return g.conditionMet(*condData, onError)
+	}
 
 	var parentAction action.Type
 	split := strings.Split(aType.String(), ":")
 	if len(split) == 2 {
 		parentAction = action.Map[split[0]]
 	}
+
+	// Deny grants are checked first, as any matching deny grant wins over the
+	// allow grants. A condition which can't be evaluated is treated as met so
+	// that we fail closed.
+	for _, grant := range grants {
+		if !grant.deny || !grant.hasAction(aType, parentAction) {
+			continue
+		}
+		if grant.matchesResource(r, aType) && conditionMet(grant, true) {
+			if matched != nil {
+				matched(grant)
+			}
+			return
+		}
+	}
+
 	// Now, go through and check the cases indicated above
 	for _, grant := range grants {
+		if grant.deny {
+			continue
+		}
 		var outputFieldsOnly bool
 		switch {
 		case len(grant.actions) == 0:
@@ -94,14 +172,7 @@ func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 			} else {
 				continue
 			}
-		case grant.actions[aType]:
-			// We have this action
-		case grant.actions[parentAction]:
-			// We don't have this action, but it's a subaction and we have the
-			// parent action. As an example, if we are looking for "read:self"
-			// and have "read", this is sufficient.
-		case grant.actions[action.All]:
-			// All actions are allowed
+		case grant.hasAction(aType, parentAction):
 		default:
 			// No actions in the grant match what we're looking for, so continue
 			// with the next grant
@@ -114,61 +185,14 @@ func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 		// If the action was not found above but we did find output fields in
 		// patterns that match, we do not authorize the request, but we do build
 		// up the output fields patterns.
-		var found bool
-		switch {
-		// id=<resource.id>;actions=<action> where ID cannot be a wildcard; or
-		// id=<resource.id>;output_fields=<fields> where fields cannot be a
-		// wildcard.
-		case grant.id == r.Id &&
-			grant.id != "" &&
-			grant.id != "*" &&
-			grant.typ == resource.Unknown &&
-			aType != action.List &&
-			aType != action.Create:
-
-			found = true
-
-		// type=<resource.type>;actions=<action> when action is list or create.
-		// Must be a top level collection, otherwise must be one of the two
-		// formats specified below. Or,
-		// type=resource.type;output_fields=<fields> and no action.
-		case grant.id == "" &&
-			r.Id == "" &&
-			grant.typ == r.Type &&
-			grant.typ != resource.Unknown &&
-			topLevelType(r.Type) &&
-			(aType == action.List ||
-				aType == action.Create):
-
-			found = true
-
-		// id=*;type=<resource.type>;actions=<action> where type cannot be
-		// unknown but can be a wildcard to allow any resource at all; or
-		// id=*;type=<resource.type>;output_fields=<fields> with no action.
-		case grant.id == "*" &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type ||
-				grant.typ == resource.All):
-
-			found = true
-
-		// id=<pin>;type=<resource.type>;actions=<action> where type can be a
-		// wildcard and this this is operating on a non-top-level type. Same for
-		// output fields only.
-		case grant.id != "" &&
-			grant.id == r.Pin &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type || grant.typ == resource.All) &&
-			!topLevelType(r.Type):
-
-			found = true
-		}
-
-		if found {
+		if grant.matchesResource(r, aType) && conditionMet(grant, false) {
+			if matched != nil {
+				matched(grant)
+			}
 			if !outputFieldsOnly {
 				results.Authorized = true
 			}
-			if results.OutputFields = results.OutputFields.AddFields(grant.OutputFields.Fields()); results.OutputFields.HasAll() && results.Authorized {
+			if results.OutputFields = results.OutputFields.AddFields(grant.OutputFields.Fields()); results.OutputFields.HasAll() && results.Authorized && matched == nil {
 				return
 			}
 		}
@@ -176,6 +200,77 @@ func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 	return
 }
 
+// hasAction reports whether the grant contains the action.
+func (g Grant) hasAction(aType, parentAction action.Type) bool {
+	switch {
+	case g.actions[aType]:
+		// We have this action
+	case g.actions[parentAction]:
+		// We don't have this action, but it's a subaction and we have the
+		// parent action. As an example, if we are looking for "read:self"
+		// and have "read", this is sufficient.
+	case g.actions[action.All]:
+		// All actions are allowed
+	default:
+		return false
+	}
+	return true
+}
+
+// matchesResource reports whether the grant applies to the resource for the
+// action, using the patterns indicated above.
+func (g Grant) matchesResource(r Resource, aType action.Type) bool {
+	switch {
+	// id=<resource.id>;actions=<action> where ID cannot be a wildcard; or
+	// id=<resource.id>;output_fields=<fields> where fields cannot be a
+	// wildcard.
+	case g.id == r.Id &&
+		g.id != "" &&
+		g.id != "*" &&
+		g.typ == resource.Unknown &&
+		aType != action.List &&
+		aType != action.Create:
+
+		return true
+
+	// type=<resource.type>;actions=<action> when action is list or create.
+	// Must be a top level collection, otherwise must be one of the two
+	// formats specified below. Or,
+	// type=resource.type;output_fields=<fields> and no action.
+	case g.id == "" &&
+		r.Id == "" &&
+		g.typ == r.Type &&
+		g.typ != resource.Unknown &&
+		topLevelType(r.Type) &&
+		(aType == action.List ||
+			aType == action.Create):
+
+		return true
+
+	// id=*;type=<resource.type>;actions=<action> where type cannot be
+	// unknown but can be a wildcard to allow any resource at all; or
+	// id=*;type=<resource.type>;output_fields=<fields> with no action.
+	case g.id == "*" &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type ||
+			g.typ == resource.All):
+
+		return true
+
+	// id=<pin>;type=<resource.type>;actions=<action> where type can be a
+	// wildcard and this this is operating on a non-top-level type. Same for
+	// output fields only.
+	case g.id != "" &&
+		g.id == r.Pin &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type || g.typ == resource.All) &&
+		!topLevelType(r.Type):
+
+		return true
+	}
+	return false
+}
+
 func topLevelType(typ resource.Type) bool {
 	switch typ {
 	case resource.AuthMethod,
@@ -186,8 +281,10 @@ func topLevelType(typ resource.Type) bool {
 		resource.Role,
 		resource.Scope,
 		resource.Session,
+		resource.SessionPolicy,
 		resource.Target,
-		resource.User:
+		resource.User,
+		resource.Worker:
 		return true
 	}
 	return false
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/intglobals"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -69,6 +70,17 @@ func Test_ACLAllowed(t *testing.T) {
 				"id=*;type=account;actions=update;output_fields=id,version",
 			},
 		},
+		{
+			scope: "o_e",
+			grants: []string{
+				"id=*;type=*;actions=*;output_fields=id",
+				"effect=deny;id=*;type=target;actions=delete",
+				"effect=deny;type=target;actions=create",
+				"effect=deny;id=ttcp_locked;actions=*",
+				"effect=deny;id=hsc_pin;type=host;actions=read",
+				"effect=deny;id=*;type=account;actions=read",
+			},
+		},
 	}
 
 	// See acl.go for expected allowed formats. The goal here is to basically
@@ -306,6 +318,62 @@ func Test_ACLAllowed(t *testing.T) {
 				{action: action.ReadSelf, authorized: true},
 			},
 		},
+		{
+			name:        "deny wins over wildcard allow",
+			resource:    Resource{ScopeId: "o_e", Id: "ttcp_1234567890", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true, outputFields: []string{"id"}},
+				{action: action.Update, authorized: true, outputFields: []string{"id"}},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:        "deny collection action",
+			resource:    Resource{ScopeId: "o_e", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.List, authorized: true, outputFields: []string{"id"}},
+				{action: action.Create},
+			},
+		},
+		{
+			name:        "deny all actions on id",
+			resource:    Resource{ScopeId: "o_e", Id: "ttcp_locked", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.Update},
+				{action: action.AuthorizeSession},
+			},
+		},
+		{
+			name:        "deny with pin",
+			resource:    Resource{ScopeId: "o_e", Id: "hst_1234567890", Type: resource.Host, Pin: "hsc_pin"},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.Update, authorized: true, outputFields: []string{"id"}},
+			},
+		},
+		{
+			name:        "deny with other pin",
+			resource:    Resource{ScopeId: "o_e", Id: "hst_1234567890", Type: resource.Host, Pin: "hsc_other"},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true, outputFields: []string{"id"}},
+			},
+		},
+		{
+			name:        "deny parent action denies subaction",
+			resource:    Resource{ScopeId: "o_e", Id: "acctpw_1234567890", Type: resource.Account},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.ReadSelf},
+				{action: action.ChangePassword, authorized: true, outputFields: []string{"id"}},
+			},
+		},
 		{
 			name:        "read self only",
 			resource:    Resource{ScopeId: "o_a", Id: "a_baz"},
@@ -337,6 +405,213 @@ func Test_ACLAllowed(t *testing.T) {
 	}
 }
 
+func Test_ACLAllowed_Conditions(t *testing.T) {
+	t.Parallel()
+
+	grantStrings := []string{
+		// contractors can only connect during business hours from the VPN range
+		`id=*;type=target;actions=authorize-session;condition="/request/time/hour" matches "^(09|1[0-6])$" and "/request/time/weekday" not matches "^(Saturday|Sunday)$" and "/request/client_ip" matches "^10\\.8\\."`,
+		`id=*;type=target;actions=read;condition="/resource/name" matches "^contractor-"`,
+		`id=*;type=target;actions=read;output_fields=id,name;condition="/resource/name" matches "^contractor-"`,
+		`effect=deny;id=*;type=target;actions=read;condition="/resource/name" == "contractor-restricted"`,
+		`id=*;type=target;actions=cancel;condition="172.16.0.0/12" in "/request/client_networks"`,
+	}
+	var grants []Grant
+	for _, g := range grantStrings {
+		grant, err := Parse("p_1", g)
+		require.NoError(t, err)
+		grants = append(grants, grant)
+	}
+	acl := NewACL(grants...)
+
+	// Wednesday
+	businessHours := time.Date(2021, 9, 1, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		resource         Resource
+		action           action.Type
+		request          Request
+		wantAuthorized   bool
+		wantOutputFields []string
+	}{
+		{
+			name:           "business-hours-vpn",
+			resource:       Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:         action.AuthorizeSession,
+			request:        Request{ClientIp: "10.8.1.2", Time: businessHours},
+			wantAuthorized: true,
+		},
+		{
+			name:     "business-hours-non-utc",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:   action.AuthorizeSession,
+			request: Request{
+				ClientIp: "10.8.1.2",
+				Time:     businessHours.In(time.FixedZone("test", 8*60*60)),
+			},
+			wantAuthorized: true,
+		},
+		{
+			name:     "after-hours",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:   action.AuthorizeSession,
+			request:  Request{ClientIp: "10.8.1.2", Time: businessHours.Add(7 * time.Hour)},
+		},
+		{
+			name:     "weekend",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:   action.AuthorizeSession,
+			request:  Request{ClientIp: "10.8.1.2", Time: businessHours.AddDate(0, 0, 3)},
+		},
+		{
+			name:     "outside-vpn",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:   action.AuthorizeSession,
+			request:  Request{ClientIp: "192.168.1.2", Time: businessHours},
+		},
+		{
+			name:     "no-client-ip",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:   action.AuthorizeSession,
+			request:  Request{Time: businessHours},
+		},
+		{
+			name:           "client-network-in-range",
+			resource:       Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:         action.Cancel,
+			request:        Request{ClientIp: "172.31.255.1"},
+			wantAuthorized: true,
+		},
+		{
+			name:           "client-network-ipv4-mapped",
+			resource:       Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:         action.Cancel,
+			request:        Request{ClientIp: "::ffff:172.16.0.1"},
+			wantAuthorized: true,
+		},
+		{
+			name:     "client-network-out-of-range",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:   action.Cancel,
+			request:  Request{ClientIp: "172.32.0.1"},
+		},
+		{
+			name:     "client-network-invalid-ip",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:   action.Cancel,
+			request:  Request{ClientIp: "not-an-ip"},
+		},
+		{
+			name:             "name-prefix",
+			resource:         Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target, Name: "contractor-db"},
+			action:           action.Read,
+			wantAuthorized:   true,
+			wantOutputFields: []string{"id", "name"},
+		},
+		{
+			name:     "name-prefix-not-matched",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target, Name: "prod-db"},
+			action:   action.Read,
+		},
+		{
+			name:     "denied-by-condition",
+			resource: Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target, Name: "contractor-restricted"},
+			action:   action.Read,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			result := acl.Allowed(tt.resource, tt.action, WithRequest(tt.request))
+			assert.Equal(tt.wantAuthorized, result.Authorized)
+			assert.ElementsMatch(tt.wantOutputFields, result.OutputFields.Fields())
+		})
+	}
+}
+
+func Test_ACLExplain(t *testing.T) {
+	t.Parallel()
+
+	type roleGrant struct {
+		roleId  string
+		scopeId string
+		grant   string
+	}
+	roleGrants := []roleGrant{
+		{roleId: "r_1", scopeId: "p_1", grant: "id=*;type=*;actions=read;output_fields=id"},
+		{roleId: "r_2", scopeId: "p_1", grant: "id=*;type=target;actions=*"},
+		{roleId: "r_2", scopeId: "p_1", grant: "id=*;type=target;output_fields=name"},
+		{roleId: "r_3", scopeId: "p_1", grant: "effect=deny;id=ttcp_locked;actions=*"},
+		{roleId: "r_4", scopeId: "o_1", grant: "id=*;type=*;actions=*"},
+	}
+	var grants []Grant
+	for _, rg := range roleGrants {
+		grant, err := Parse(rg.scopeId, rg.grant, WithRoleId(rg.roleId))
+		require.NoError(t, err)
+		assert.Equal(t, rg.roleId, grant.RoleId())
+		assert.Equal(t, rg.scopeId, grant.ScopeId())
+		grants = append(grants, grant)
+	}
+	acl := NewACL(grants...)
+
+	tests := []struct {
+		name             string
+		resource         Resource
+		action           action.Type
+		wantAuthorized   bool
+		wantDenied       bool
+		wantGrants       []string
+		wantOutputFields []string
+	}{
+		{
+			name:             "allowed",
+			resource:         Resource{ScopeId: "p_1", Id: "ttcp_1", Type: resource.Target},
+			action:           action.Read,
+			wantAuthorized:   true,
+			wantGrants:       []string{"r_1: id=*;type=*;actions=read;output_fields=id", "r_2: id=*;type=target;actions=*", "r_2: id=*;type=target;output_fields=name"},
+			wantOutputFields: []string{"id", "name"},
+		},
+		{
+			name:     "no-match",
+			resource: Resource{ScopeId: "p_1", Id: "hsst_1", Type: resource.HostSet, Pin: "hcst_1"},
+			action:   action.Update,
+		},
+		{
+			name:       "denied",
+			resource:   Resource{ScopeId: "p_1", Id: "ttcp_locked", Type: resource.Target},
+			action:     action.Read,
+			wantDenied: true,
+			wantGrants: []string{"r_3: effect=deny;id=ttcp_locked;actions=*"},
+		},
+		{
+			name:     "other-scope",
+			resource: Resource{ScopeId: "p_2", Id: "ttcp_1", Type: resource.Target},
+			action:   action.Read,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got := acl.Explain(tt.resource, tt.action)
+			assert.Equal(tt.wantAuthorized, got.Authorized)
+			assert.Equal(tt.wantDenied, got.Denied())
+			assert.ElementsMatch(tt.wantOutputFields, got.OutputFields.Fields())
+			var gotGrants []string
+			for _, g := range got.Grants {
+				gotGrants = append(gotGrants, fmt.Sprintf("%s: %s", g.RoleId(), g.CanonicalString()))
+			}
+			assert.Equal(tt.wantGrants, gotGrants)
+			assert.Equal([]string{tt.resource.ScopeId}, got.ScopeIds)
+
+			allowed := acl.Allowed(tt.resource, tt.action)
+			assert.Equal(allowed.Authorized, got.Authorized)
+			assert.ElementsMatch(allowed.OutputFields.Fields(), got.OutputFields.Fields())
+		})
+	}
+}
+
 func TestJsonMarshal(t *testing.T) {
 	res := &Resource{
 		ScopeId: "scope",
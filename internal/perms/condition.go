@@ -0,0 +1,145 @@
+package perms
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/filter"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/go-bexpr"
+)
+
+// Request contains the information about a request which can be referenced
+// by grant conditions, in addition to the resource the request is for.
+type Request struct {
+	// ClientIp is the IP address of the client making the request.
+	ClientIp string
+
+	// Time is the time of the request. If not set, the current time is used
+	// when evaluating grant conditions.
+	Time time.Time
+}
+
+// conditionData is the data a grant condition is evaluated against. The
+// selectors of a condition use the json tags, e.g. "/resource/name" or
+// "/request/time/hour".
+type conditionData struct {
+	Resource conditionResource `json:"resource"`
+	Request  conditionRequest  `json:"request"`
+}
+
+type conditionResource struct {
+	Id      string `json:"id"`
+	Type    string `json:"type"`
+	ScopeId string `json:"scope_id"`
+	Pin     string `json:"pin"`
+	Name    string `json:"name"`
+}
+
+type conditionRequest struct {
+	Action   string `json:"action"`
+	ClientIp string `json:"client_ip"`
+	// ClientNetworks are the networks containing the client IP, one for each
+	// prefix length, so that a range can be matched with the "in" operator,
+	// e.g. "10.8.0.0/16" in "/request/client_networks".
+	ClientNetworks []string      `json:"client_networks"`
+	Time           conditionTime `json:"time"`
+}
+
+// conditionTime contains the parts of the request time, in UTC. The hour and
+// minute are zero padded strings so that they can be used with the "matches"
+// operator, e.g. "/request/time/hour" matches "^(09|1[0-6])$".
+type conditionTime struct {
+	Hour    string `json:"hour"`
+	Minute  string `json:"minute"`
+	Weekday string `json:"weekday"`
+}
+
+func newConditionData(r Resource, aType action.Type, req Request) conditionData {
+	t := req.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	t = t.UTC()
+	return conditionData{
+		Resource: conditionResource{
+			Id:      r.Id,
+			Type:    r.Type.String(),
+			ScopeId: r.ScopeId,
+			Pin:     r.Pin,
+			Name:    r.Name,
+		},
+		Request: conditionRequest{
+			Action:         aType.String(),
+			ClientIp:       req.ClientIp,
+			ClientNetworks: clientNetworks(req.ClientIp),
+			Time: conditionTime{
+				Hour:    fmt.Sprintf("%02d", t.Hour()),
+				Minute:  fmt.Sprintf("%02d", t.Minute()),
+				Weekday: t.Weekday().String(),
+			},
+		},
+	}
+}
+
+// clientNetworks returns the networks containing ip in CIDR notation, from
+// the address itself to the whole address space. IPv4 addresses, including
+// IPv4-mapped IPv6 addresses, use IPv4 networks. It returns nil if ip is not
+// a valid IP address.
+func clientNetworks(ip string) []string {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil
+	}
+	bits := 8 * net.IPv6len
+	if v4 := addr.To4(); v4 != nil {
+		addr, bits = v4, 8*net.IPv4len
+	}
+	networks := make([]string, 0, bits+1)
+	for ones := bits; ones >= 0; ones-- {
+		n := net.IPNet{IP: addr.Mask(net.CIDRMask(ones, bits)), Mask: net.CIDRMask(ones, bits)}
+		networks = append(networks, n.String())
+	}
+	return networks
+}
+
+// parseCondition builds the evaluator for the grant's condition, using the
+// same bexpr options as the filters of list requests. The condition is
+// evaluated once against empty data to catch selectors which don't exist.
+func (g *Grant) parseCondition() error {
+	const op = "perms.(Grant).parseCondition"
+	if g.condition == "" {
+		return nil
+	}
+	// Segments of the text format are separated by semicolons, so a condition
+	// containing one could not be represented in the canonical string
+	if strings.Contains(g.condition, ";") {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "condition cannot contain a semicolon")
+	}
+	eval, err := bexpr.CreateEvaluator(g.condition, bexpr.WithTagName("json"), bexpr.WithHookFn(filter.WellKnownTypeFilterHook))
+	if err != nil {
+		return errors.WrapDeprecated(err, op, errors.WithMsg("unable to parse condition"), errors.WithCode(errors.InvalidParameter))
+	}
+	if _, err := eval.Evaluate(conditionData{}); err != nil {
+		return errors.WrapDeprecated(err, op, errors.WithMsg("invalid condition"), errors.WithCode(errors.InvalidParameter))
+	}
+	g.conditionEval = eval
+	return nil
+}
+
+// conditionMet reports whether the grant's condition is met for the data. A
+// grant without a condition always meets it. If the condition can't be
+// evaluated, onError is returned so that callers can fail closed.
+func (g Grant) conditionMet(data conditionData, onError bool) bool {
+	if g.conditionEval == nil {
+		return true
+	}
+	m, err := g.conditionEval.Evaluate(data)
+	if err != nil {
+		return onError
+	}
+	return m
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-bexpr"
 )
 
 // GrantTuple is simply a struct that can be reference from other code to return
@@ -30,11 +31,31 @@ type Scope struct {
 	Type scope.Type
 }
 
+// Effect is the effect of a grant, which either allows or denies the actions
+// of the grant.
+type Effect string
+
+const (
+	// AllowEffect is the effect of grants which allow actions. It's the
+	// default effect of a grant.
+	AllowEffect Effect = "allow"
+
+	// DenyEffect is the effect of grants which deny actions. A deny grant
+	// always takes precedence over the allow grants of an ACL.
+	DenyEffect Effect = "deny"
+)
+
 // Grant is a Go representation of a parsed grant
 type Grant struct {
 	// The scope ID, which will be a project ID or an org ID
 	scope Scope
 
+	// The ID of the role the grant is from, if provided when parsing
+	roleId string
+
+	// Whether the grant denies instead of allows its actions
+	deny bool
+
 	// The ID in the grant, if provided.
 	id string
 
@@ -47,6 +68,12 @@ type Grant struct {
 	// The set of output fields granted
 	OutputFields OutputFieldsMap
 
+	// The condition, if provided, which must be met for the grant to apply
+	condition string
+
+	// The evaluator of the condition
+	conditionEval *bexpr.Evaluator
+
 	// This is used as a temporary staging area before validating permissions to
 	// allow the same validation code across grant string formats
 	actionsBeingParsed []string
@@ -60,6 +87,29 @@ func (g Grant) Type() resource.Type {
 	return g.typ
 }
 
+// RoleId returns the ID of the role the grant is from, if known
+func (g Grant) RoleId() string {
+	return g.roleId
+}
+
+// ScopeId returns the ID of the scope the grant applies to
+func (g Grant) ScopeId() string {
+	return g.scope.Id
+}
+
+// Condition returns the condition of the grant, if any
+func (g Grant) Condition() string {
+	return g.condition
+}
+
+// Effect returns whether the grant allows or denies its actions
+func (g Grant) Effect() Effect {
+	if g.deny {
+		return DenyEffect
+	}
+	return AllowEffect
+}
+
 func (g Grant) Actions() (typs []action.Type, strs []string) {
 	typs = make([]action.Type, 0, len(g.actions))
 	strs = make([]string, 0, len(g.actions))
@@ -76,9 +126,13 @@ func (g Grant) Actions() (typs []action.Type, strs []string) {
 
 func (g Grant) clone() *Grant {
 	ret := &Grant{
-		scope: g.scope,
-		id:    g.id,
-		typ:   g.typ,
+		scope:         g.scope,
+		roleId:        g.roleId,
+		deny:          g.deny,
+		id:            g.id,
+		typ:           g.typ,
+		condition:     g.condition,
+		conditionEval: g.conditionEval,
 	}
 	if g.actionsBeingParsed != nil {
 		ret.actionsBeingParsed = append(ret.actionsBeingParsed, g.actionsBeingParsed...)
@@ -102,6 +156,12 @@ func (g Grant) clone() *Grant {
 func (g Grant) CanonicalString() string {
 	var builder []string
 
+	// The allow effect is the default so it's left out to keep the canonical
+	// form of existing grants the same
+	if g.deny {
+		builder = append(builder, fmt.Sprintf("effect=%s", DenyEffect))
+	}
+
 	if g.id != "" {
 		builder = append(builder, fmt.Sprintf("id=%s", g.id))
 	}
@@ -123,13 +183,20 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("output_fields=%s", strings.Join(g.OutputFields.Fields(), ",")))
 	}
 
+	if g.condition != "" {
+		builder = append(builder, fmt.Sprintf("condition=%s", g.condition))
+	}
+
 	return strings.Join(builder, ";")
 }
 
 // MarshalJSON provides a custom marshaller for grants
 func (g Grant) MarshalJSON() ([]byte, error) {
 	const op = "perms.(Grant).MarshalJSON"
-	res := make(map[string]interface{}, 4)
+	res := make(map[string]interface{}, 5)
+	if g.deny {
+		res["effect"] = string(DenyEffect)
+	}
 	if g.id != "" {
 		res["id"] = g.id
 	}
@@ -147,6 +214,9 @@ func (g Grant) MarshalJSON() ([]byte, error) {
 	if len(g.OutputFields) > 0 {
 		res["output_fields"] = g.OutputFields.Fields()
 	}
+	if g.condition != "" {
+		res["condition"] = g.condition
+	}
 	b, err := json.Marshal(res)
 	if err != nil {
 		return nil, errors.WrapDeprecated(err, op, errors.WithCode(errors.Encode))
@@ -159,10 +229,19 @@ func (g Grant) MarshalJSON() ([]byte, error) {
 // when JSON is detected.
 func (g *Grant) unmarshalJSON(data []byte) error {
 	const op = "perms.(Grant).unmarshalJSON"
-	raw := make(map[string]interface{}, 4)
+	raw := make(map[string]interface{}, 5)
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return errors.WrapDeprecated(err, op, errors.WithCode(errors.Decode))
 	}
+	if rawEffect, ok := raw["effect"]; ok {
+		effect, ok := rawEffect.(string)
+		if !ok {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as string", "effect"))
+		}
+		if err := g.setEffect(effect); err != nil {
+			return errors.WrapDeprecated(err, op)
+		}
+	}
 	if rawId, ok := raw["id"]; ok {
 		id, ok := rawId.(string)
 		if !ok {
@@ -220,6 +299,26 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 			}
 		}
 	}
+	if rawCondition, ok := raw["condition"]; ok {
+		condition, ok := rawCondition.(string)
+		if !ok {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as string", "condition"))
+		}
+		g.condition = condition
+	}
+	return nil
+}
+
+func (g *Grant) setEffect(effect string) error {
+	const op = "perms.(Grant).setEffect"
+	switch Effect(strings.ToLower(effect)) {
+	case AllowEffect:
+		g.deny = false
+	case DenyEffect:
+		g.deny = true
+	default:
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown effect %q", effect))
+	}
 	return nil
 }
 
@@ -228,6 +327,11 @@ func (g *Grant) unmarshalText(grantString string) error {
 	segments := strings.Split(grantString, ";")
 	for _, segment := range segments {
 		kv := strings.Split(segment, "=")
+		// Conditions are expressions which can contain equal signs, so
+		// everything after the first one is the value
+		if kv[0] == "condition" && len(kv) > 2 {
+			kv = []string{kv[0], strings.Join(kv[1:], "=")}
+		}
 
 		// Ensure we don't accept "foo=bar=baz", "=foo", or "foo="
 		switch {
@@ -240,6 +344,11 @@ func (g *Grant) unmarshalText(grantString string) error {
 		}
 
 		switch kv[0] {
+		case "effect":
+			if err := g.setEffect(kv[1]); err != nil {
+				return errors.WrapDeprecated(err, op)
+			}
+
 		case "id":
 			g.id = kv[1]
 
@@ -264,6 +373,9 @@ func (g *Grant) unmarshalText(grantString string) error {
 
 		case "output_fields":
 			g.OutputFields = g.OutputFields.AddFields(strings.Split(kv[1], ","))
+
+		case "condition":
+			g.condition = kv[1]
 		}
 	}
 
@@ -313,6 +425,7 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 	}
 
 	opts := getOpts(opt...)
+	grant.roleId = opts.withRoleId
 
 	// Check for templated values ID, and substitute in with the authenticated values
 	// if so
@@ -341,6 +454,10 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		return Grant{}, errors.WrapDeprecated(err, op)
 	}
 
+	if err := grant.parseCondition(); err != nil {
+		return Grant{}, errors.WrapDeprecated(err, op)
+	}
+
 	if !opts.withSkipFinalValidation {
 		// Filter out some forms that don't make sense
 
@@ -393,11 +510,20 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		if grant.OutputFields != nil && len(grant.OutputFields) == 0 {
 			return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, "parsed grant string has output_fields set but empty")
 		}
+		// Deny grants only deny actions; output fields can only be granted
+		if grant.deny && grant.OutputFields != nil {
+			return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, "parsed grant string has output_fields set on a deny grant")
+		}
 		// This might be zero if output fields is populated
 		if len(grant.actions) > 0 {
 			// Create a dummy resource and pass it through Allowed and ensure that
-			// we get allowed.
-			acl := NewACL(grant)
+			// we get allowed. A deny grant is checked using its allow form, as
+			// it denies exactly what its allow form would allow, and the
+			// condition is left out as it depends on the request.
+			checkGrant := grant
+			checkGrant.deny = false
+			checkGrant.conditionEval = nil
+			acl := NewACL(checkGrant)
 			r := Resource{
 				ScopeId: scopeId,
 				Id:      grant.id,
@@ -414,6 +540,9 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 				}
 			}
 			if !allowed {
+				if grant.deny {
+					return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, "parsed grant string would not result in any action being denied")
+				}
 				return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, "parsed grant string would not result in any action being authorized")
 			}
 		}
@@ -429,7 +558,7 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 func (g Grant) validateType() error {
 	const op = "perms.(Grant).validateType"
 	switch g.typ {
-	case resource.Controller, resource.Worker:
+	case resource.Controller:
 		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown type specifier %q", g.typ))
 	}
 	return nil
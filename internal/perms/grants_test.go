@@ -110,7 +110,7 @@ func Test_ValidateType(t *testing.T) {
 	var g Grant
 	for i := resource.Unknown; i <= resource.CredentialLibrary; i++ {
 		g.typ = i
-		if i == resource.Controller || i == resource.Worker {
+		if i == resource.Controller {
 			assert.Error(t, g.validateType())
 		} else {
 			assert.NoError(t, g.validateType())
@@ -190,6 +190,22 @@ func Test_MarshalingAndCloning(t *testing.T) {
 			jsonOutput:      `{"actions":["create","read"],"id":"baz","output_fields":["id","name","version"],"type":"group"}`,
 			canonicalString: `id=baz;type=group;actions=create,read;output_fields=id,name,version`,
 		},
+		{
+			name: "deny",
+			input: Grant{
+				deny: true,
+				id:   "*",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				typ: resource.Target,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+			},
+			jsonOutput:      `{"actions":["delete"],"effect":"deny","id":"*","type":"target"}`,
+			canonicalString: `effect=deny;id=*;type=target;actions=delete`,
+		},
 	}
 
 	for _, test := range tests {
@@ -311,6 +327,42 @@ func Test_Unmarshaling(t *testing.T) {
 			jsonInput: `{"actions":[1, true]}`,
 			jsonErr:   `perms.(Grant).unmarshalJSON: unable to interpret 1 in actions array as string: parameter violation: error #100`,
 		},
+		{
+			name: "good deny effect",
+			expected: Grant{
+				deny: true,
+			},
+			jsonInput: `{"effect":"deny"}`,
+			textInput: `effect=DENY`,
+		},
+		{
+			name:      "good allow effect",
+			expected:  Grant{},
+			jsonInput: `{"effect":"allow"}`,
+			textInput: `effect=allow`,
+		},
+		{
+			name:      "bad effect",
+			jsonInput: `{"effect":true}`,
+			jsonErr:   `perms.(Grant).unmarshalJSON: unable to interpret "effect" as string: parameter violation: error #100`,
+			textInput: `effect=maybe`,
+			textErr:   `perms.(Grant).unmarshalText: perms.(Grant).setEffect: unknown effect "maybe": parameter violation: error #100`,
+		},
+		{
+			name: "good condition",
+			expected: Grant{
+				condition: `"/resource/name" == "foo=bar"`,
+			},
+			jsonInput: `{"condition":"\"/resource/name\" == \"foo=bar\""}`,
+			textInput: `condition="/resource/name" == "foo=bar"`,
+		},
+		{
+			name:      "bad condition",
+			jsonInput: `{"condition":["foo"]}`,
+			jsonErr:   `perms.(Grant).unmarshalJSON: unable to interpret "condition" as string: parameter violation: error #100`,
+			textInput: `condition=`,
+			textErr:   `perms.(Grant).unmarshalText: segment "condition=" not formatted correctly, missing value: parameter violation: error #100`,
+		},
 	}
 
 	for _, test := range tests {
@@ -431,6 +483,52 @@ func Test_Parse(t *testing.T) {
 			input: `{"id": "*", "type": "*", "actions": ["read", "list"], "output_fields": []}`,
 			err:   "perms.Parse: parsed grant string has output_fields set but empty: parameter violation: error #100",
 		},
+		{
+			name:  "deny with output fields",
+			input: "effect=deny;id=*;type=target;output_fields=id",
+			err:   "perms.Parse: parsed grant string has output_fields set on a deny grant: parameter violation: error #100",
+		},
+		{
+			name:  "deny with actions and output fields",
+			input: `{"effect": "deny", "id": "*", "type": "target", "actions": ["delete"], "output_fields": ["id"]}`,
+			err:   "perms.Parse: parsed grant string has output_fields set on a deny grant: parameter violation: error #100",
+		},
+		{
+			name:  "deny wildcard id and actions without collection",
+			input: "effect=deny;id=*;actions=read",
+			err:   `perms.Parse: parsed grant string would not result in any action being denied: parameter violation: error #100`,
+		},
+		{
+			name:  "deny",
+			input: "effect=deny;id=*;type=target;actions=delete",
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				deny: true,
+				id:   "*",
+				typ:  resource.Target,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+			},
+		},
+		{
+			name:  "deny json",
+			input: `{"effect": "deny", "type": "target", "actions": ["create"]}`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				deny: true,
+				typ:  resource.Target,
+				actions: map[action.Type]bool{
+					action.Create: true,
+				},
+			},
+		},
 		{
 			name:  "wildcard id and type and actions with list",
 			input: "id=*;type=*;actions=read,list",
@@ -701,6 +799,82 @@ func Test_Parse(t *testing.T) {
 			} else {
 				require.NoError(err)
 				assert.Equal(test.expected, grant)
+
+				// The canonical string must parse into the same grant
+				reparsed, err := Parse(scope, grant.CanonicalString())
+				require.NoError(err)
+				assert.Equal(grant, reparsed)
+			}
+		})
+	}
+}
+
+func Test_ParseCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		input         string
+		wantCondition string
+		wantCanonical string
+		wantJson      string
+		err           string
+	}{
+		{
+			name:          "text",
+			input:         `id=*;type=target;actions=authorize-session;condition="/request/client_ip" matches "^10\\.8\\."`,
+			wantCondition: `"/request/client_ip" matches "^10\\.8\\."`,
+			wantCanonical: `id=*;type=target;actions=authorize-session;condition="/request/client_ip" matches "^10\\.8\\."`,
+			wantJson:      `{"actions":["authorize-session"],"condition":"\"/request/client_ip\" matches \"^10\\\\.8\\\\.\"","id":"*","type":"target"}`,
+		},
+		{
+			name:          "json",
+			input:         `{"effect":"deny","id":"*","type":"target","actions":["read"],"condition":"\"/resource/name\" == \"prod\" or \"/request/time/weekday\" == \"Sunday\""}`,
+			wantCondition: `"/resource/name" == "prod" or "/request/time/weekday" == "Sunday"`,
+			wantCanonical: `effect=deny;id=*;type=target;actions=read;condition="/resource/name" == "prod" or "/request/time/weekday" == "Sunday"`,
+			wantJson:      `{"actions":["read"],"condition":"\"/resource/name\" == \"prod\" or \"/request/time/weekday\" == \"Sunday\"","effect":"deny","id":"*","type":"target"}`,
+		},
+		{
+			name:  "invalid expression",
+			input: `id=*;type=target;actions=read;condition="/resource/name" ==`,
+			err:   `perms.Parse: perms.(Grant).parseCondition: unable to parse condition`,
+		},
+		{
+			name:  "unknown selector",
+			input: `id=*;type=target;actions=read;condition="/resource/foo" == "bar"`,
+			err:   `perms.Parse: perms.(Grant).parseCondition: invalid condition`,
+		},
+		{
+			name:  "semicolon",
+			input: `{"id":"*","type":"target","actions":["read"],"condition":"\"/resource/name\" == \"a;b\""}`,
+			err:   `perms.Parse: perms.(Grant).parseCondition: condition cannot contain a semicolon: parameter violation: error #100`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			grant, err := Parse("p_scope", tt.input)
+			if tt.err != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.err)
+				return
+			}
+			require.NoError(err)
+			assert.NotNil(grant.conditionEval)
+			assert.Equal(tt.wantCondition, grant.Condition())
+			assert.Equal(tt.wantCanonical, grant.CanonicalString())
+			out, err := grant.MarshalJSON()
+			require.NoError(err)
+			assert.Equal(tt.wantJson, string(out))
+
+			// Both the canonical string and the JSON must parse into the same
+			// grant
+			for _, in := range []string{grant.CanonicalString(), string(out)} {
+				reparsed, err := Parse("p_scope", in)
+				require.NoError(err)
+				assert.Equal(grant.CanonicalString(), reparsed.CanonicalString())
+				assert.Equal(grant.Condition(), reparsed.Condition())
 			}
 		})
 	}
@@ -17,6 +17,8 @@ type options struct {
 	withUserId              string
 	withAccountId           string
 	withSkipFinalValidation bool
+	withRequest             Request
+	withRoleId              string
 }
 
 func getDefaultOptions() options {
@@ -45,3 +47,19 @@ func WithSkipFinalValidation(skipFinalValidation bool) Option {
 		o.withSkipFinalValidation = skipFinalValidation
 	}
 }
+
+// WithRequest provides the information about the request which is used when
+// evaluating grant conditions
+func WithRequest(r Request) Option {
+	return func(o *options) {
+		o.withRequest = r
+	}
+}
+
+// WithRoleId provides the ID of the role a grant is from, which is included
+// when explaining the results of an ACL
+func WithRoleId(roleId string) Option {
+	return func(o *options) {
+		o.withRoleId = roleId
+	}
+}
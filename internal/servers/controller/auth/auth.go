@@ -60,6 +60,10 @@ type RequestInfo struct {
 	Token          string
 	TokenFormat    TokenFormat
 
+	// ClientIp is the IP address of the client, which can be referenced by
+	// grant conditions
+	ClientIp string
+
 	// The following are useful for tests
 	scopeIdOverride      string
 	userIdOverride       string
@@ -228,6 +232,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		Id:      opts.withId,
 		Pin:     opts.withPin,
 		Type:    opts.withType,
+		Name:    opts.withName,
 	}
 	// Global scope has no parent ID; account for this
 	if opts.withId == scope.Global.String() && opts.withType == resource.Scope {
@@ -245,6 +250,13 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error performing authn/authz check"))
 		return
 	}
+	if opts.withRecoveryTokenNotAllowed && v.requestInfo.TokenFormat == AuthTokenTypeRecoveryKms {
+		return
+	}
+	if opts.withAnonymousUserNotAllowed && ret.UserId == AnonymousUserId {
+		ret.Error = handlers.UnauthenticatedError()
+		return
+	}
 
 	ret.AuthTokenId = v.requestInfo.PublicId
 	ret.AuthenticationFinished = authResults.AuthenticationFinished
@@ -536,7 +548,7 @@ func (v verifier) performAuthCheck(ctx context.Context) (aclResults perms.ACLRes
 	}
 
 	retAcl = perms.NewACL(parsedGrants...)
-	aclResults = retAcl.Allowed(*v.res, v.act)
+	aclResults = retAcl.Allowed(*v.res, v.act, perms.WithRequest(v.permsRequest()))
 	// We don't set authenticated above because setting this but not authorized
 	// is used for further permissions checks, such as during recursive listing.
 	// So we want to make sure any code relying on that has the full set of
@@ -586,7 +598,7 @@ func (r *VerifyResults) fetchActions(id string, typ resource.Type, availableActi
 
 	ret := make(action.ActionSet, 0, len(availableActions))
 	for _, act := range availableActions {
-		if r.v.acl.Allowed(*res, act).Authorized {
+		if r.v.acl.Allowed(*res, act, perms.WithRequest(r.v.permsRequest())).Authorized {
 			ret = append(ret, act)
 		}
 	}
@@ -604,7 +616,30 @@ func (r *VerifyResults) FetchOutputFields(res perms.Resource, act action.Type) p
 		return nil
 	}
 
-	return r.v.acl.Allowed(res, act).OutputFields
+	return r.v.acl.Allowed(res, act, perms.WithRequest(r.v.permsRequest())).OutputFields
+}
+
+// permsRequest returns the information about the request used when evaluating
+// grant conditions.
+func (v *verifier) permsRequest() perms.Request {
+	return perms.Request{
+		ClientIp: v.requestInfo.ClientIp,
+		Time:     time.Now(),
+	}
+}
+
+// ListTokenWrapper returns the wrapper used to encrypt and decrypt the list
+// tokens handed out for paginated list requests.
+func (r *VerifyResults) ListTokenWrapper(ctx context.Context) (wrapping.Wrapper, error) {
+	const op = "auth.(VerifyResults).ListTokenWrapper"
+	if r.v.kms == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no kms available to verifier")
+	}
+	wrapper, err := r.v.kms.GetWrapper(ctx, scope.Global.String(), kms.KeyPurposeTokens)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get tokens wrapper"))
+	}
+	return wrapper, nil
 }
 
 // GetTokenFromRequest pulls the token from either the Authorization header or
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateAuthorizedCollectionActions(t *testing.T) {
+	ctx := context.Background()
+	const scopeId = "p_1234567890"
+	collectionActions := map[resource.Type]action.ActionSet{
+		resource.Target:      {action.Create, action.List},
+		resource.HostCatalog: {action.Create, action.List},
+	}
+
+	tests := []struct {
+		name   string
+		grants []string
+		want   map[string][]interface{}
+	}{
+		{
+			name: "no-grants",
+		},
+		{
+			name:   "allow",
+			grants: []string{"id=*;type=*;actions=*"},
+			want: map[string][]interface{}{
+				"targets":       {"create", "list"},
+				"host-catalogs": {"create", "list"},
+			},
+		},
+		{
+			name: "deny-action",
+			grants: []string{
+				"id=*;type=*;actions=*",
+				"effect=deny;id=*;type=target;actions=create",
+			},
+			want: map[string][]interface{}{
+				"targets":       {"list"},
+				"host-catalogs": {"create", "list"},
+			},
+		},
+		{
+			name: "deny-type",
+			grants: []string{
+				"id=*;type=*;actions=*",
+				"effect=deny;id=*;type=host-catalog;actions=*",
+			},
+			want: map[string][]interface{}{
+				"targets": {"create", "list"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var grants []perms.Grant
+			for _, g := range tt.grants {
+				grant, err := perms.Parse(scopeId, g)
+				require.NoError(err)
+				grants = append(grants, grant)
+			}
+			res := VerifyResults{v: &verifier{acl: perms.NewACL(grants...)}}
+			got, err := CalculateAuthorizedCollectionActions(ctx, res, collectionActions, scopeId, "")
+			require.NoError(err)
+			if tt.want == nil {
+				assert.Nil(got)
+				return
+			}
+			gotActions := make(map[string][]interface{}, len(got))
+			for k, v := range got {
+				gotActions[k] = v.AsSlice()
+			}
+			assert.Equal(tt.want, gotActions)
+		})
+	}
+}
@@ -30,6 +30,7 @@ type options struct {
 	withRecoveryTokenNotAllowed bool
 	withAnonymousUserNotAllowed bool
 	withResource                *perms.Resource
+	withName                    string
 }
 
 func getDefaultOptions() options {
@@ -78,12 +79,16 @@ func WithKms(kms *kms.Kms) Option {
 	}
 }
 
+// WithRecoveryTokenNotAllowed fails the verification of requests authenticated
+// with the recovery KMS
 func WithRecoveryTokenNotAllowed(notAllowed bool) Option {
 	return func(o *options) {
 		o.withRecoveryTokenNotAllowed = notAllowed
 	}
 }
 
+// WithAnonymousUserNotAllowed fails the verification of requests made by the
+// anonymous user, even if the anonymous user is granted the action
 func WithAnonymousUserNotAllowed(notAllowed bool) Option {
 	return func(o *options) {
 		o.withAnonymousUserNotAllowed = notAllowed
@@ -96,3 +101,11 @@ func WithResource(resource *perms.Resource) Option {
 		o.withResource = resource
 	}
 }
+
+// WithName specifies the name of the resource, which can be referenced by grant
+// conditions
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
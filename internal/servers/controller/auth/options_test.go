@@ -28,6 +28,7 @@ func Test_GetOpts(t *testing.T) {
 		WithRecoveryTokenNotAllowed(true),
 		WithAnonymousUserNotAllowed(true),
 		WithResource(res),
+		WithName("name"),
 	)
 	exp := options{
 		withScopeId:                 "foo",
@@ -40,6 +41,7 @@ func Test_GetOpts(t *testing.T) {
 		withRecoveryTokenNotAllowed: true,
 		withAnonymousUserNotAllowed: true,
 		withResource:                res,
+		withName:                    "name",
 	}
 	assert.Equal(t, exp, opts)
 }
@@ -1,9 +1,12 @@
 package common
 
 import (
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/credential/vault"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/servers"
@@ -12,13 +15,16 @@ import (
 )
 
 type (
-	AuthTokenRepoFactory       = oidc.AuthTokenRepoFactory
-	VaultCredentialRepoFactory = func() (*vault.Repository, error)
-	IamRepoFactory             func() (*iam.Repository, error)
-	OidcAuthRepoFactory        = oidc.OidcRepoFactory
-	PasswordAuthRepoFactory    func() (*password.Repository, error)
-	ServersRepoFactory         func() (*servers.Repository, error)
-	StaticRepoFactory          func() (*static.Repository, error)
-	SessionRepoFactory         func() (*session.Repository, error)
-	TargetRepoFactory          func() (*target.Repository, error)
+	AuthTokenRepoFactory        = oidc.AuthTokenRepoFactory
+	VaultCredentialRepoFactory  = func() (*vault.Repository, error)
+	IamRepoFactory              func() (*iam.Repository, error)
+	LdapAuthRepoFactory         = ldap.LdapRepoFactory
+	OidcAuthRepoFactory         = oidc.OidcRepoFactory
+	PasswordAuthRepoFactory     func() (*password.Repository, error)
+	PluginHostRepoFactory       func() (*plugin.Repository, error)
+	ServersRepoFactory          func() (*servers.Repository, error)
+	StaticRepoFactory           func() (*static.Repository, error)
+	StaticCredentialRepoFactory = func() (*credstatic.Repository, error)
+	SessionRepoFactory          func() (*session.Repository, error)
+	TargetRepoFactory           func() (*target.Repository, error)
 )
@@ -177,3 +177,34 @@ func GetListingScopeIds(
 
 	return scopeIds, scopeInfoMap, nil
 }
+
+// GetAllScopeIds returns the IDs of all scopes and a map of the scopes to their
+// info. It is used by listings whose items are authorized individually rather
+// than through the list permissions of their scopes.
+func GetAllScopeIds(ctx context.Context, repoFn common.IamRepoFactory) ([]string, map[string]*scopes.ScopeInfo, error) {
+	const op = "GetAllScopeIds"
+	if repoFn == nil {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "nil iam repo")
+	}
+	repo, err := repoFn()
+	if err != nil {
+		return nil, nil, err
+	}
+	scps, err := repo.ListScopesRecursively(ctx, scope.Global.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	scopeIds := make([]string, 0, len(scps))
+	scopeInfoMap := make(map[string]*scopes.ScopeInfo, len(scps))
+	for _, scp := range scps {
+		scopeIds = append(scopeIds, scp.GetPublicId())
+		scopeInfoMap[scp.GetPublicId()] = &scopes.ScopeInfo{
+			Id:            scp.GetPublicId(),
+			Type:          scp.GetType(),
+			Name:          scp.GetName(),
+			Description:   scp.GetDescription(),
+			ParentScopeId: scp.GetParentId(),
+		}
+	}
+	return scopeIds, scopeInfoMap, nil
+}
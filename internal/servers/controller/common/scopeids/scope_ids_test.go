@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
@@ -312,3 +313,22 @@ func TestListingScopeIds(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllScopeIds(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	scopeIds, scopeInfoMap, err := scopeids.GetAllScopeIds(context.Background(), iamRepoFn)
+	require.NoError(t, err)
+	require.Subset(t, scopeIds, []string{scope.Global.String(), org.GetPublicId(), proj.GetPublicId()})
+	require.Len(t, scopeInfoMap, len(scopeIds))
+	require.Equal(t, org.GetPublicId(), scopeInfoMap[proj.GetPublicId()].GetParentScopeId())
+
+	_, _, err = scopeids.GetAllScopeIds(context.Background(), nil)
+	require.Error(t, err)
+}
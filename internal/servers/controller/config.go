@@ -3,6 +3,7 @@ package controller
 import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 )
 
 type Config struct {
@@ -14,4 +15,7 @@ type Config struct {
 	RawConfig *config.Config
 	// If set, authorization checking occurrs but failures are ignored
 	DisableAuthorizationFailures bool
+	// HostPlugins are the plugins available to plugin host catalogs, keyed
+	// by plugin name
+	HostPlugins map[string]plugin.HostPlugin
 }
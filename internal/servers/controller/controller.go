@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -45,15 +48,18 @@ type Controller struct {
 	workerStatusUpdateTimes *sync.Map
 
 	// Repo factory methods
-	AuthTokenRepoFn       common.AuthTokenRepoFactory
-	VaultCredentialRepoFn common.VaultCredentialRepoFactory
-	IamRepoFn             common.IamRepoFactory
-	OidcRepoFn            common.OidcAuthRepoFactory
-	PasswordAuthRepoFn    common.PasswordAuthRepoFactory
-	ServersRepoFn         common.ServersRepoFactory
-	SessionRepoFn         common.SessionRepoFactory
-	StaticHostRepoFn      common.StaticRepoFactory
-	TargetRepoFn          common.TargetRepoFactory
+	AuthTokenRepoFn        common.AuthTokenRepoFactory
+	VaultCredentialRepoFn  common.VaultCredentialRepoFactory
+	IamRepoFn              common.IamRepoFactory
+	LdapRepoFn             common.LdapAuthRepoFactory
+	OidcRepoFn             common.OidcAuthRepoFactory
+	PasswordAuthRepoFn     common.PasswordAuthRepoFactory
+	PluginHostRepoFn       common.PluginHostRepoFactory
+	ServersRepoFn          common.ServersRepoFactory
+	SessionRepoFn          common.SessionRepoFactory
+	StaticHostRepoFn       common.StaticRepoFactory
+	StaticCredentialRepoFn common.StaticCredentialRepoFactory
+	TargetRepoFn           common.TargetRepoFactory
 
 	scheduler *scheduler.Scheduler
 
@@ -111,6 +117,7 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	}
 	if err := c.kms.AddExternalWrappers(
 		kms.WithRootWrapper(c.conf.RootKms),
+		kms.WithPreviousRootWrapper(c.conf.PreviousRootKms),
 		kms.WithWorkerAuthWrapper(c.conf.WorkerAuthKms),
 		kms.WithRecoveryWrapper(c.conf.RecoveryKms),
 	); err != nil {
@@ -131,6 +138,9 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	c.StaticHostRepoFn = func() (*static.Repository, error) {
 		return static.NewRepository(dbase, dbase, c.kms)
 	}
+	c.PluginHostRepoFn = func() (*plugin.Repository, error) {
+		return plugin.NewRepository(dbase, dbase, c.kms, c.scheduler, c.conf.HostPlugins)
+	}
 	c.AuthTokenRepoFn = func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(dbase, dbase, c.kms,
 			authtoken.WithTokenTimeToLiveDuration(c.conf.RawConfig.Controller.AuthTokenTimeToLiveDuration),
@@ -139,12 +149,18 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	c.VaultCredentialRepoFn = func() (*vault.Repository, error) {
 		return vault.NewRepository(dbase, dbase, c.kms, c.scheduler)
 	}
+	c.StaticCredentialRepoFn = func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(dbase, dbase, c.kms)
+	}
 	c.ServersRepoFn = func() (*servers.Repository, error) {
 		return servers.NewRepository(dbase, dbase, c.kms)
 	}
 	c.OidcRepoFn = func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, dbase, dbase, c.kms)
 	}
+	c.LdapRepoFn = func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, dbase, dbase, c.kms)
+	}
 	c.PasswordAuthRepoFn = func() (*password.Repository, error) {
 		return password.NewRepository(dbase, dbase, c.kms)
 	}
@@ -206,10 +222,19 @@ func (c *Controller) registerJobs() error {
 	if err := vault.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms); err != nil {
 		return err
 	}
+	if err := plugin.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms, c.conf.HostPlugins); err != nil {
+		return err
+	}
 
 	if err := c.registerSessionCleanupJob(); err != nil {
 		return err
 	}
+	if err := c.registerDataKeyVersionDestructionMonitorJob(); err != nil {
+		return err
+	}
+	if err := c.registerSessionApprovalExpirationJob(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -228,6 +253,34 @@ func (c *Controller) registerSessionCleanupJob() error {
 	return nil
 }
 
+// registerDataKeyVersionDestructionMonitorJob is a helper method to abstract
+// registering the database key version destruction monitor job.
+func (c *Controller) registerDataKeyVersionDestructionMonitorJob() error {
+	destructionJob, err := newDataKeyVersionDestructionMonitorJob(c.kms)
+	if err != nil {
+		return fmt.Errorf("error creating data key version destruction monitor job: %w", err)
+	}
+	if err = c.scheduler.RegisterJob(c.baseContext, destructionJob); err != nil {
+		return fmt.Errorf("error registering data key version destruction monitor job: %w", err)
+	}
+
+	return nil
+}
+
+// registerSessionApprovalExpirationJob is a helper method to abstract
+// registering the session approval expiration job.
+func (c *Controller) registerSessionApprovalExpirationJob() error {
+	approvalJob, err := newSessionApprovalExpirationJob(c.SessionRepoFn)
+	if err != nil {
+		return fmt.Errorf("error creating session approval expiration job: %w", err)
+	}
+	if err = c.scheduler.RegisterJob(c.baseContext, approvalJob); err != nil {
+		return fmt.Errorf("error registering session approval expiration job: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) Shutdown(serversOnly bool) error {
 	const op = "controller.(Controller).Shutdown"
 	if !c.started.Load() {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/textproto"
 	"os"
@@ -24,9 +25,11 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/accounts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentiallibraries"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentials"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentialstores"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_sets"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/managed_groups"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/session_policies"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/go-cleanhttp"
@@ -42,6 +45,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/workers"
 )
 
 type HandlerProperties struct {
@@ -106,14 +110,14 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterHostServiceHandlerServer(ctx, mux, hs); err != nil {
 		return nil, fmt.Errorf("failed to register host service handler: %w", err)
 	}
-	accts, err := accounts.NewService(c.PasswordAuthRepoFn, c.OidcRepoFn)
+	accts, err := accounts.NewService(c.PasswordAuthRepoFn, c.OidcRepoFn, c.LdapRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create account handler service: %w", err)
 	}
 	if err := services.RegisterAccountServiceHandlerServer(ctx, mux, accts); err != nil {
 		return nil, fmt.Errorf("failed to register account service handler: %w", err)
 	}
-	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
+	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcRepoFn, c.LdapRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth method handler service: %w", err)
 	}
@@ -127,7 +131,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterAuthTokenServiceHandlerServer(ctx, mux, authtoks); err != nil {
 		return nil, fmt.Errorf("failed to register auth token service handler: %w", err)
 	}
-	os, err := scopes.NewService(c.IamRepoFn)
+	os, err := scopes.NewService(c.IamRepoFn, c.kms)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scope handler service: %w", err)
 	}
@@ -148,7 +152,9 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		c.ServersRepoFn,
 		c.SessionRepoFn,
 		c.StaticHostRepoFn,
-		c.VaultCredentialRepoFn)
+		c.PluginHostRepoFn,
+		c.VaultCredentialRepoFn,
+		c.StaticCredentialRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create target handler service: %w", err)
 	}
@@ -176,14 +182,21 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterSessionServiceHandlerServer(ctx, mux, ss); err != nil {
 		return nil, fmt.Errorf("failed to register session service handler: %w", err)
 	}
-	mgs, err := managed_groups.NewService(c.OidcRepoFn)
+	sps, err := session_policies.NewService(c.IamRepoFn, c.SessionRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session policy handler service: %w", err)
+	}
+	if err := services.RegisterSessionPolicyServiceHandlerServer(ctx, mux, sps); err != nil {
+		return nil, fmt.Errorf("failed to register session policy service handler: %w", err)
+	}
+	mgs, err := managed_groups.NewService(c.OidcRepoFn, c.LdapRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create managed groups handler service: %w", err)
 	}
 	if err := services.RegisterManagedGroupServiceHandlerServer(ctx, mux, mgs); err != nil {
 		return nil, fmt.Errorf("failed to register managed groups service handler: %w", err)
 	}
-	cs, err := credentialstores.NewService(c.VaultCredentialRepoFn, c.IamRepoFn)
+	cs, err := credentialstores.NewService(c.VaultCredentialRepoFn, c.StaticCredentialRepoFn, c.IamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create credential store handler service: %w", err)
 	}
@@ -197,6 +210,20 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterCredentialLibraryServiceHandlerServer(ctx, mux, cl); err != nil {
 		return nil, fmt.Errorf("failed to register credential library service handler: %w", err)
 	}
+	cred, err := credentials.NewService(c.StaticCredentialRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential handler service: %w", err)
+	}
+	if err := services.RegisterCredentialServiceHandlerServer(ctx, mux, cred); err != nil {
+		return nil, fmt.Errorf("failed to register credential service handler: %w", err)
+	}
+	ws, err := workers.NewService(c.IamRepoFn, c.ServersRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker handler service: %w", err)
+	}
+	if err := services.RegisterWorkerServiceHandlerServer(ctx, mux, ws); err != nil {
+		return nil, fmt.Errorf("failed to register worker service handler: %w", err)
+	}
 
 	return mux, nil
 }
@@ -242,6 +269,11 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 			DisableAuthzFailures: disableAuthzFailures,
 		}
 
+		// The remote address has already been set from the X-Forwarded-For
+		// header if that's configured for the listener
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			requestInfo.ClientIp = host
+		}
 		requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = auth.GetTokenFromRequest(ctx, c.kms, r)
 		ctx = auth.NewVerifierContext(ctx, c.IamRepoFn, c.AuthTokenRepoFn, c.ServersRepoFn, c.kms, requestInfo)
 
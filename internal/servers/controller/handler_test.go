@@ -108,6 +108,8 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/auth-tokens/someid",
 			"v1/credential-stores",
 			"v1/credential-stores/someid",
+			"v1/credentials",
+			"v1/credentials/someid",
 			"v1/groups",
 			"v1/groups/someid",
 			"v1/host-catalogs",
@@ -121,30 +123,44 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"400_v1/sc\u200Bopes",
 			"200_v1/scopes",
 			"v1/scopes/someid",
+			"v1/scopes/someid:list-keys",
+			"v1/scopes/someid:list-key-version-destruction-jobs",
+			"v1/session-policies",
+			"v1/session-policies/someid",
 			"v1/sessions",
 			"v1/sessions/someid",
+			"v1/sessions:list-mine",
 			"v1/targets",
 			"v1/targets/someid",
+			"v1/targets:list-mine",
 			"v1/users",
 			"v1/users/someid",
+			"v1/workers",
+			"v1/workers/someid",
 		},
 		"POST": {
 			// Creation end points
 			"v1/accounts",
 			"v1/auth-methods",
 			"v1/credential-stores",
+			"v1/credentials",
 			"v1/groups",
 			"v1/host-catalogs",
 			"v1/host-sets",
 			"v1/hosts",
 			"v1/roles",
 			"v1/scopes",
+			"v1/session-policies",
 			"v1/targets",
 			"v1/users",
+			"v1/workers",
 
 			// custom methods
 			"v1/accounts/someid:set-password",
 			"v1/accounts/someid:change-password",
+			"v1/accounts/someid:enroll-totp",
+			"v1/accounts/someid:confirm-totp",
+			"v1/accounts/someid:remove-totp",
 			"v1/auth-methods/someid:authenticate",
 			"v1/groups/someid:add-members",
 			"v1/groups/someid:set-members",
@@ -158,7 +174,11 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/roles/someid:add-principals",
 			"v1/roles/someid:set-principals",
 			"v1/roles/someid:remove-principals",
+			"v1/scopes/someid:rotate-keys",
+			"v1/scopes/someid:destroy-key-version",
 			"v1/sessions/someid:cancel",
+			"v1/sessions/someid:approve",
+			"v1/sessions/someid:deny",
 			"v1/targets/someid:authorize-session",
 			"v1/targets/someid:add-host-sets",
 			"v1/targets/someid:set-host-sets",
@@ -166,31 +186,39 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/users/someid:add-accounts",
 			"v1/users/someid:set-accounts",
 			"v1/users/someid:remove-accounts",
+			"v1/workers/someid:add-worker-tags",
+			"v1/workers/someid:set-worker-tags",
+			"v1/workers/someid:remove-worker-tags",
 		},
 		"DELETE": {
 			"v1/accounts/someid",
 			"v1/auth-methods/someid",
 			"v1/auth-tokens/someid",
 			"v1/credential-stores/someid",
+			"v1/credentials/someid",
 			"v1/groups/someid",
 			"v1/host-catalogs/someid",
 			"v1/host-sets/someid",
 			"v1/hosts/someid",
 			"v1/roles/someid",
 			"v1/scopes/someid",
+			"v1/session-policies/someid",
 			"v1/targets/someid",
 			"v1/users/someid",
+			"v1/workers/someid",
 		},
 		"PATCH": {
 			"v1/accounts/someid",
 			"v1/auth-methods/someid",
 			"v1/credential-stores/someid",
+			"v1/credentials/someid",
 			"v1/groups/someid",
 			"v1/host-catalogs/someid",
 			"v1/host-sets/someid",
 			"v1/hosts/someid",
 			"v1/roles/someid",
 			"v1/scopes/someid",
+			"v1/session-policies/someid",
 			"v1/targets/someid",
 			"v1/users/someid",
 		},
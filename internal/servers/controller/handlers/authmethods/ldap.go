@@ -0,0 +1,323 @@
+package authmethods
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	ldapstore "github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
+	pba "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// ldap field names
+	urlsField             = "attributes.urls"
+	certificatesField     = "attributes.certificates"
+	userFilterField       = "attributes.user_filter"
+	groupFilterField      = "attributes.group_filter"
+	bindDnField           = "attributes.bind_dn"
+	bindPasswordHmacField = "attributes.bind_password_hmac"
+)
+
+var ldapMaskManager handlers.MaskManager
+
+func init() {
+	var err error
+	if ldapMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&ldapstore.AuthMethod{}}, handlers.MaskSource{&pb.AuthMethod{}, &pb.LdapAuthMethodAttributes{}}); err != nil {
+		panic(err)
+	}
+
+	IdActions[ldap.Subtype] = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.Authenticate,
+	}
+}
+
+// createLdapInRepo creates an ldap auth method in a repo and returns the result.
+// This method should never return a nil AuthMethod without returning an error.
+func (s Service) createLdapInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (*ldap.AuthMethod, error) {
+	u, err := toStorageLdapAuthMethod(ctx, scopeId, item)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateAuthMethod(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create auth method: %w", err)
+	}
+	return out, nil
+}
+
+func (s Service) updateLdapInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod) (*ldap.AuthMethod, error) {
+	u, err := toStorageLdapAuthMethod(ctx, scopeId, item)
+	if err != nil {
+		return nil, err
+	}
+
+	version := item.GetVersion()
+	u.PublicId = id
+
+	dbMask := ldapMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateAuthMethod(ctx, u, version, dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update auth method: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) authenticateLdap(ctx context.Context, req *pbs.AuthenticateRequest, authResults *auth.VerifyResults) (*pbs.AuthenticateResponse, error) {
+	reqAttrs := req.GetAttributes().GetFields()
+	tok, err := s.authenticateWithLdapRepo(ctx, req.GetAuthMethodId(), reqAttrs[loginNameField].GetStringValue(), reqAttrs[passwordField].GetStringValue())
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
+}
+
+func (s Service) authenticateWithLdapRepo(ctx context.Context, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ldapRepo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	acct, err := ldapRepo.Authenticate(ctx, authMethodId, loginName, pw)
+	if err != nil {
+		return nil, err
+	}
+	if acct == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+
+	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ConvertInternalAuthTokenToApiAuthToken(
+		ctx,
+		tok,
+	)
+}
+
+func validateAuthenticateLdapRequest(req *pbs.AuthenticateRequest) error {
+	badFields := make(map[string]string)
+
+	if req.GetAttributes() == nil || req.GetAttributes().GetFields() == nil {
+		badFields["attributes"] = "This is a required field."
+		// Return early because we need non-nil values in the rest of the check.
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+
+	attrs := req.GetAttributes().GetFields()
+	if _, ok := attrs[loginNameField]; !ok {
+		badFields["attributes.login_name"] = "This is a required field."
+	}
+	if _, ok := attrs[passwordField]; !ok {
+		badFields["attributes.password"] = "This is a required field."
+	}
+	if req.GetCommand() != loginCommand {
+		badFields[commandField] = "Invalid command for this auth method type."
+	}
+	tType := strings.ToLower(strings.TrimSpace(req.GetTokenType()))
+	if tType != "" && tType != "token" && tType != "cookie" {
+		badFields[tokenTypeField] = `The only accepted types are "token" and "cookie".`
+	}
+
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+// validateLdapAttributes checks the ldap attributes provided when creating
+// or updating an auth method and adds any problems found to badFields.
+func validateLdapAttributes(ctx context.Context, attrs *pb.LdapAuthMethodAttributes, badFields map[string]string) {
+	for _, u := range attrs.GetUrls() {
+		parsed, err := url.Parse(u)
+		if err != nil || parsed.Host == "" {
+			badFields[urlsField] = fmt.Sprintf("%q cannot be parsed as a url.", u)
+			break
+		}
+		if parsed.Scheme != "ldap" && parsed.Scheme != "ldaps" {
+			badFields[urlsField] = fmt.Sprintf("%q must have schema %q or %q specified.", u, "ldap", "ldaps")
+			break
+		}
+	}
+	if len(attrs.GetCertificates()) > 0 {
+		if _, err := ldap.ParseCertificates(ctx, attrs.GetCertificates()...); err != nil {
+			badFields[certificatesField] = fmt.Sprintf("Cannot parse certificates. %v", err.Error())
+		}
+	}
+	for field, filter := range map[string]string{
+		userFilterField:  attrs.GetUserFilter().GetValue(),
+		groupFilterField: attrs.GetGroupFilter().GetValue(),
+	} {
+		if filter == "" {
+			continue
+		}
+		if _, err := template.New(field).Parse(filter); err != nil {
+			badFields[field] = fmt.Sprintf("Cannot be parsed as a template. %v", err)
+		}
+	}
+	if attrs.GetBindPasswordHmac() != "" {
+		badFields[bindPasswordHmacField] = "Field is read only."
+	}
+}
+
+func toStorageLdapAuthMethod(ctx context.Context, scopeId string, in *pb.AuthMethod) (*ldap.AuthMethod, error) {
+	const op = "authmethod_service.toStorageLdapAuthMethod"
+	if in == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil auth method.")
+	}
+	attrs := &pb.LdapAuthMethodAttributes{}
+	if err := handlers.StructToProto(in.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{attributesField: "Attribute fields do not match the expected format."})
+	}
+
+	var opts []ldap.Option
+	if in.GetName() != nil {
+		opts = append(opts, ldap.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, ldap.WithDescription(in.GetDescription().GetValue()))
+	}
+	if len(attrs.GetUrls()) > 0 {
+		urls := make([]*url.URL, 0, len(attrs.GetUrls()))
+		for _, u := range attrs.GetUrls() {
+			parsed, err := url.Parse(strings.TrimSpace(u))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("cannot parse url"), errors.WithCode(errors.InvalidParameter))
+			}
+			urls = append(urls, parsed)
+		}
+		opts = append(opts, ldap.WithUrls(urls...))
+	}
+	if len(attrs.GetCertificates()) > 0 {
+		certs, err := ldap.ParseCertificates(ctx, attrs.GetCertificates()...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, ldap.WithCertificates(certs...))
+	}
+	if attrs.GetStartTls() {
+		opts = append(opts, ldap.WithStartTls())
+	}
+	if attrs.GetInsecureTls() {
+		opts = append(opts, ldap.WithInsecureTls())
+	}
+	if attrs.GetDiscoverDn() {
+		opts = append(opts, ldap.WithDiscoverDn())
+	}
+	if attrs.GetAnonGroupSearch() {
+		opts = append(opts, ldap.WithAnonGroupSearch())
+	}
+	if attrs.GetUpnDomain() != nil {
+		opts = append(opts, ldap.WithUpnDomain(attrs.GetUpnDomain().GetValue()))
+	}
+	if attrs.GetUserDn() != nil {
+		opts = append(opts, ldap.WithUserDn(attrs.GetUserDn().GetValue()))
+	}
+	if attrs.GetUserAttr() != nil {
+		opts = append(opts, ldap.WithUserAttr(attrs.GetUserAttr().GetValue()))
+	}
+	if attrs.GetUserFilter() != nil {
+		opts = append(opts, ldap.WithUserFilter(attrs.GetUserFilter().GetValue()))
+	}
+	if attrs.GetGroupDn() != nil {
+		opts = append(opts, ldap.WithGroupDn(attrs.GetGroupDn().GetValue()))
+	}
+	if attrs.GetGroupAttr() != nil {
+		opts = append(opts, ldap.WithGroupAttr(attrs.GetGroupAttr().GetValue()))
+	}
+	if attrs.GetGroupFilter() != nil {
+		opts = append(opts, ldap.WithGroupFilter(attrs.GetGroupFilter().GetValue()))
+	}
+
+	u, err := ldap.NewAuthMethod(ctx, scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method: %v.", err)
+	}
+	// The bind credential is set directly since an update may only include
+	// one of its fields; the repository validates the resulting pair.
+	u.BindDn = attrs.GetBindDn().GetValue()
+	u.BindPassword = attrs.GetBindPassword().GetValue()
+	return u, nil
+}
+
+func toLdapAuthMethodAttributes(in *ldap.AuthMethod) *pb.LdapAuthMethodAttributes {
+	attrs := &pb.LdapAuthMethodAttributes{
+		Urls:             in.GetUrls(),
+		StartTls:         in.GetStartTls(),
+		InsecureTls:      in.GetInsecureTls(),
+		DiscoverDn:       in.GetDiscoverDn(),
+		AnonGroupSearch:  in.GetAnonGroupSearch(),
+		Certificates:     in.GetCertificates(),
+		BindPasswordHmac: in.GetBindPasswordHmac(),
+	}
+	if in.GetUpnDomain() != "" {
+		attrs.UpnDomain = wrapperspb.String(in.GetUpnDomain())
+	}
+	if in.GetUserDn() != "" {
+		attrs.UserDn = wrapperspb.String(in.GetUserDn())
+	}
+	if in.GetUserAttr() != "" {
+		attrs.UserAttr = wrapperspb.String(in.GetUserAttr())
+	}
+	if in.GetUserFilter() != "" {
+		attrs.UserFilter = wrapperspb.String(in.GetUserFilter())
+	}
+	if in.GetGroupDn() != "" {
+		attrs.GroupDn = wrapperspb.String(in.GetGroupDn())
+	}
+	if in.GetGroupAttr() != "" {
+		attrs.GroupAttr = wrapperspb.String(in.GetGroupAttr())
+	}
+	if in.GetGroupFilter() != "" {
+		attrs.GroupFilter = wrapperspb.String(in.GetGroupFilter())
+	}
+	if in.GetBindDn() != "" {
+		attrs.BindDn = wrapperspb.String(in.GetBindDn())
+	}
+	return attrs
+}
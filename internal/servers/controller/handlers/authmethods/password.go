@@ -15,13 +15,24 @@ import (
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
 	pba "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 const (
 	// password field names
-	loginNameField = "login_name"
-	passwordField  = "password"
-	loginCommand   = "login"
+	loginNameField            = "login_name"
+	passwordField             = "password"
+	newPasswordField          = "new_password"
+	totpCodeField             = "totp_code"
+	passwordHistoryCountField = "attributes.password_history_count"
+	loginCommand              = "login"
+	enrollTotpCommand         = "enroll-totp"
+	totpUrlField              = "url"
+	totpSecretField           = "secret"
+
+	// maxPasswordHistoryCount is the largest password history count
+	// supported by the database.
+	maxPasswordHistoryCount = 24
 )
 
 var pwMaskManager handlers.MaskManager
@@ -89,14 +100,17 @@ func (s Service) updatePwInRepo(ctx context.Context, scopeId, id string, mask []
 
 func (s Service) authenticatePassword(ctx context.Context, req *pbs.AuthenticateRequest, authResults *auth.VerifyResults) (*pbs.AuthenticateResponse, error) {
 	reqAttrs := req.GetAttributes().GetFields()
-	tok, err := s.authenticateWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs[loginNameField].GetStringValue(), reqAttrs[passwordField].GetStringValue())
+	if req.GetCommand() == enrollTotpCommand {
+		return s.enrollTotpWithPassword(ctx, req, authResults.Scope.GetId(), reqAttrs[loginNameField].GetStringValue(), reqAttrs[passwordField].GetStringValue())
+	}
+	tok, err := s.authenticateWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs[loginNameField].GetStringValue(), reqAttrs[passwordField].GetStringValue(), reqAttrs[newPasswordField].GetStringValue(), reqAttrs[totpCodeField].GetStringValue())
 	if err != nil {
 		return nil, err
 	}
 	return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
 }
 
-func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
+func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethodId, loginName, pw, newPw, totpCode string) (*pba.AuthToken, error) {
 	iamRepo, err := s.iamRepoFn()
 	if err != nil {
 		return nil, err
@@ -110,8 +124,30 @@ func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethod
 		return nil, err
 	}
 
-	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
+	var pwOpts []password.Option
+	if newPw != "" {
+		pwOpts = append(pwOpts, password.WithNewPassword(newPw))
+	}
+	if totpCode != "" {
+		pwOpts = append(pwOpts, password.WithTotpCode(totpCode))
+	}
+	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw, pwOpts...)
 	if err != nil {
+		switch {
+		case errors.Match(errors.T(errors.TotpRequired), err):
+			return nil, handlers.InvalidArgumentErrorf("A TOTP code is required.",
+				map[string]string{fmt.Sprintf("%s.%s", attributesField, totpCodeField): "This is a required field."})
+		case errors.Match(errors.T(errors.TotpNotEnrolled), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The auth method requires TOTP and the account has not enrolled a TOTP secret; enroll one with the %q command.", enrollTotpCommand)
+		case errors.Match(errors.T(errors.PasswordChangeRequired), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "A password change is required; provide a new password in the %q attribute.", newPasswordField)
+		case errors.Match(errors.T(errors.PasswordTooShort), err),
+			errors.Match(errors.T(errors.PasswordTooWeak), err),
+			errors.Match(errors.T(errors.PasswordReused), err),
+			errors.Match(errors.T(errors.PasswordsEqual), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{fmt.Sprintf("%s.%s", attributesField, newPasswordField): fmt.Sprintf("New password does not meet the password policy: %s", err.Error())})
+		}
 		return nil, err
 	}
 	if acct == nil {
@@ -133,6 +169,36 @@ func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethod
 	)
 }
 
+// enrollTotpWithPassword enrolls a TOTP secret for the account of loginName,
+// authenticating it with its password since the account may not be able to
+// get an auth token until it has enrolled. No auth token is returned; the
+// secret is confirmed by the first login with a code for it.
+func (s Service) enrollTotpWithPassword(ctx context.Context, req *pbs.AuthenticateRequest, scopeId, loginName, pw string) (*pbs.AuthenticateResponse, error) {
+	const op = "authmethods.(Service).enrollTotpWithPassword"
+	pwRepo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	url, secret, err := pwRepo.EnrollTotpWithPassword(ctx, scopeId, req.GetAuthMethodId(), loginName, pw)
+	if err != nil {
+		if errors.Match(errors.T(errors.TotpAlreadyEnrolled), err) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The account already has a confirmed TOTP secret.")
+		}
+		return nil, err
+	}
+	if url == "" {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+	attrs, err := structpb.NewStruct(map[string]interface{}{
+		totpUrlField:    url,
+		totpSecretField: secret,
+	})
+	if err != nil {
+		return nil, errors.New(ctx, errors.Internal, op, "Error generating response attributes.", errors.WithWrap(err))
+	}
+	return &pbs.AuthenticateResponse{Command: req.GetCommand(), Attributes: attrs}, nil
+}
+
 func validateAuthenticatePasswordRequest(req *pbs.AuthenticateRequest) error {
 	badFields := make(map[string]string)
 
@@ -153,7 +219,7 @@ func validateAuthenticatePasswordRequest(req *pbs.AuthenticateRequest) error {
 		// TODO: Eventually, require a command. For now, fall back to "login" for backwards compat.
 		req.Command = loginCommand
 	}
-	if req.Command != loginCommand {
+	if req.Command != loginCommand && req.Command != enrollTotpCommand {
 		badFields[commandField] = "Invalid command for this auth method type."
 	}
 	tType := strings.ToLower(strings.TrimSpace(req.GetTokenType()))
@@ -195,5 +261,15 @@ func toStoragePwAuthMethod(scopeId string, item *pb.AuthMethod) (*password.AuthM
 	if pwAttrs.GetMinPasswordLength() != 0 {
 		u.MinPasswordLength = pwAttrs.GetMinPasswordLength()
 	}
+	u.MinUppercase = pwAttrs.GetMinUppercase()
+	u.MinLowercase = pwAttrs.GetMinLowercase()
+	u.MinDigits = pwAttrs.GetMinDigits()
+	u.MinSymbols = pwAttrs.GetMinSymbols()
+	u.PasswordHistoryCount = pwAttrs.GetPasswordHistoryCount()
+	u.MaxFailedAttempts = pwAttrs.GetMaxFailedAttempts()
+	if pwAttrs.GetLockoutDurationSeconds() != 0 {
+		u.LockoutDuration = pwAttrs.GetLockoutDurationSeconds()
+	}
+	u.TotpRequired = pwAttrs.GetTotpRequired()
 	return u, nil
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -43,6 +44,9 @@ func TestUpdate_Password(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
@@ -52,7 +56,7 @@ func TestUpdate_Password(t *testing.T) {
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
-	tested, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+	tested, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType(), ParentScopeId: scope.Global.String()}
@@ -101,8 +105,9 @@ func TestUpdate_Password(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "desc"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":      structpb.NewNumberValue(8),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+						"min_login_name_length":    structpb.NewNumberValue(3),
 					}},
 					Scope:                       defaultScopeInfo,
 					AuthorizedActions:           pwAuthorizedActions,
@@ -129,8 +134,9 @@ func TestUpdate_Password(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "desc"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":      structpb.NewNumberValue(8),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+						"min_login_name_length":    structpb.NewNumberValue(3),
 					}},
 					Scope:                       defaultScopeInfo,
 					AuthorizedActions:           pwAuthorizedActions,
@@ -207,8 +213,9 @@ func TestUpdate_Password(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "default"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":      structpb.NewNumberValue(8),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+						"min_login_name_length":    structpb.NewNumberValue(3),
 					}},
 					Scope:                       defaultScopeInfo,
 					AuthorizedActions:           pwAuthorizedActions,
@@ -234,8 +241,9 @@ func TestUpdate_Password(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "default"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":      structpb.NewNumberValue(8),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+						"min_login_name_length":    structpb.NewNumberValue(3),
 					}},
 					Scope:                       defaultScopeInfo,
 					AuthorizedActions:           pwAuthorizedActions,
@@ -261,8 +269,9 @@ func TestUpdate_Password(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "notignored"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":      structpb.NewNumberValue(8),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+						"min_login_name_length":    structpb.NewNumberValue(3),
 					}},
 					Scope:                       defaultScopeInfo,
 					AuthorizedActions:           pwAuthorizedActions,
@@ -360,8 +369,9 @@ func TestUpdate_Password(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "default"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(42),
+						"min_password_length":      structpb.NewNumberValue(8),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+						"min_login_name_length":    structpb.NewNumberValue(42),
 					}},
 					Scope:                       defaultScopeInfo,
 					AuthorizedActions:           pwAuthorizedActions,
@@ -391,8 +401,9 @@ func TestUpdate_Password(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "default"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(42),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":      structpb.NewNumberValue(42),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+						"min_login_name_length":    structpb.NewNumberValue(3),
 					}},
 					Scope:                       defaultScopeInfo,
 					AuthorizedActions:           pwAuthorizedActions,
@@ -461,6 +472,9 @@ func TestAuthenticate_Password(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
@@ -592,7 +606,7 @@ func TestAuthenticate_Password(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err)
 
 			resp, err := s.Authenticate(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), tc.request)
@@ -632,6 +646,9 @@ func TestAuthenticate_AuthAccountConnectedToIamUser_Password(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
@@ -655,7 +672,7 @@ func TestAuthenticate_AuthAccountConnectedToIamUser_Password(t *testing.T) {
 	iamUser, err := iamRepo.LookupUserWithLogin(context.Background(), acct.GetPublicId())
 	require.NoError(err)
 
-	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(err)
 	resp, err := s.Authenticate(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.AuthenticateRequest{
 		AuthMethodId: am.GetPublicId(),
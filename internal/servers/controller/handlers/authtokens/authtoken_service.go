@@ -3,6 +3,7 @@ package authtokens
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -90,55 +91,69 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 		return &pbs.ListAuthTokensResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds)
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.AuthToken, req.GetPageSize(), req.GetListToken(),
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(ul) == 0 {
-		return &pbs.ListAuthTokensResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.AuthToken, 0, len(ul))
+	var finalItems []*pb.AuthToken
 	res := perms.Resource{
 		Type: resource.AuthToken,
 	}
-	for _, at := range ul {
-		res.Id = at.GetPublicId()
-		res.ScopeId = at.GetScopeId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions, auth.WithResource(&res))
-		if len(authorizedActions) == 0 {
-			continue
-		}
-
-		if authorizedActions.OnlySelf() && at.GetIamUserId() != authResults.UserId {
-			continue
-		}
-
-		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
-		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
-		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[at.GetScopeId()]))
-		}
-		if outputFields.Has(globals.AuthorizedActionsField) {
-			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
-		}
-
-		item, err := toProto(ctx, at, outputOpts...)
+	for {
+		ul, err := s.listFromRepo(ctx, scopeIds, pg)
 		if err != nil {
 			return nil, err
 		}
-
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		for _, at := range ul {
+			if !pg.Admit(at.GetPublicId()) {
+				break
+			}
+			res.Id = at.GetPublicId()
+			res.ScopeId = at.GetScopeId()
+			authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions, auth.WithResource(&res))
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			if authorizedActions.OnlySelf() && at.GetIamUserId() != authResults.UserId {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[at.GetScopeId()]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+			}
+
+			item, err := toProto(ctx, at, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
+		}
+		if !pg.Next(len(ul)) {
+			break
 		}
 	}
 
-	return &pbs.ListAuthTokensResponse{Items: finalItems}, nil
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListAuthTokensResponse{Items: finalItems, ListToken: listToken}, nil
 }
 
 // GetAuthToken implements the interface pbs.AuthTokenServiceServer.
@@ -258,13 +273,17 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*authtoken.AuthToken, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, pg *handlers.Paginator) ([]*authtoken.AuthToken, error) {
 	repo, err := s.repoFn()
 	_ = repo
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthTokens(ctx, scopeIds)
+	var opts []authtoken.Option
+	if pg.Enabled() {
+		opts = append(opts, authtoken.WithStartPageAfterItem(pg.StartAfter()), authtoken.WithLimit(pg.BatchSize()))
+	}
+	ul, err := repo.ListAuthTokens(ctx, scopeIds, opts...)
 	if err != nil {
 		return nil, err
 	}
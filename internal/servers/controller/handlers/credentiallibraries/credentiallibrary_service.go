@@ -21,6 +21,7 @@ import (
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -28,8 +29,26 @@ const (
 	vaultPathField       = "attributes.path"
 	httpMethodField      = "attributes.http_method"
 	httpRequestBodyField = "attributes.http_request_body"
+
+	usernameAttribute    = "username_attribute"
+	passwordAttribute    = "password_attribute"
+	privateKeyAttribute  = "private_key_attribute"
+	certificateAttribute = "certificate_attribute"
 )
 
+// mappingOverrides maps each key of the credential mapping overrides to the
+// credential types which have that part and to the storage field which
+// holds it.
+var mappingOverrides = map[string]struct {
+	types []credential.Type
+	field string
+}{
+	usernameAttribute:    {[]credential.Type{credential.UsernamePasswordType, credential.SshPrivateKeyType}, "UsernameAttribute"},
+	passwordAttribute:    {[]credential.Type{credential.UsernamePasswordType}, "PasswordAttribute"},
+	privateKeyAttribute:  {[]credential.Type{credential.SshPrivateKeyType, credential.CertificateType}, "PrivateKeyAttribute"},
+	certificateAttribute: {[]credential.Type{credential.CertificateType}, "CertificateAttribute"},
+}
+
 var (
 	maskManager handlers.MaskManager
 
@@ -90,51 +109,66 @@ func (s Service) ListCredentialLibraries(ctx context.Context, req *pbs.ListCrede
 		return nil, authResults.Error
 	}
 
-	csl, err := s.listFromRepo(ctx, req.GetCredentialStoreId())
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.CredentialLibrary, req.GetPageSize(), req.GetListToken(),
+		req.GetCredentialStoreId(), req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(csl) == 0 {
-		return &pbs.ListCredentialLibrariesResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.CredentialLibrary, 0, len(csl))
+	var finalItems []*pb.CredentialLibrary
 	res := perms.Resource{
 		ScopeId: authResults.Scope.Id,
 		Type:    resource.CredentialLibrary,
 		Pin:     req.GetCredentialStoreId(),
 	}
-	for _, item := range csl {
-		res.Id = item.GetPublicId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
-		if len(authorizedActions) == 0 {
-			continue
-		}
-
-		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
-		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
-		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
-		}
-		if outputFields.Has(globals.AuthorizedActionsField) {
-			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
-		}
-
-		item, err := toProto(item, outputOpts...)
+	for {
+		csl, err := s.listFromRepo(ctx, req.GetCredentialStoreId(), pg)
 		if err != nil {
 			return nil, err
 		}
+		for _, item := range csl {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.Name = item.GetName()
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+			}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+			item, err := toProto(item, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
 		}
+		if !pg.Next(len(csl)) {
+			break
+		}
+	}
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListCredentialLibrariesResponse{Items: finalItems}, nil
+	return &pbs.ListCredentialLibrariesResponse{Items: finalItems, ListToken: listToken}, nil
 }
 
 // GetCredentialLibrary implements the interface pbs.CredentialLibraryServiceServer.
@@ -270,13 +304,17 @@ func (s Service) DeleteCredentialLibrary(ctx context.Context, req *pbs.DeleteCre
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, storeId string) ([]*vault.CredentialLibrary, error) {
+func (s Service) listFromRepo(ctx context.Context, storeId string, pg *handlers.Paginator) ([]*vault.CredentialLibrary, error) {
 	const op = "credentiallibraries.(Service).listFromRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	csl, err := repo.ListCredentialLibraries(ctx, storeId)
+	var opts []vault.Option
+	if pg.Enabled() {
+		opts = append(opts, vault.WithStartPageAfterItem(pg.StartAfter()), vault.WithLimit(pg.BatchSize()))
+	}
+	csl, err := repo.ListCredentialLibraries(ctx, storeId, opts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -328,6 +366,8 @@ func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []str
 	cl.PublicId = id
 
 	dbMask := maskManager.Translate(mask)
+	overrideMask := translateMappingOverridesMask(mask)
+	dbMask = append(dbMask, overrideMask...)
 	if len(dbMask) == 0 {
 		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
 	}
@@ -335,6 +375,25 @@ func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []str
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
+	methodChanged := handlers.MaskContains(mask, httpMethodField)
+	if len(overrideMask) > 0 || methodChanged {
+		// Which mapping overrides and http methods are valid depends on the
+		// credential type of the existing library.
+		cur, err := repo.LookupCredentialLibrary(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if cur == nil {
+			return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist.", id)
+		}
+		badFields := validateMappingOverrides(cur.CredentialType(), item.GetCredentialMappingOverrides())
+		if methodChanged && cur.CredentialType() == credential.SshCertificateType && vault.Method(cl.GetHttpMethod()) != vault.MethodPost {
+			badFields[httpMethodField] = fmt.Sprintf("Must be 'POST' for credential type %q.", credential.SshCertificateType)
+		}
+		if len(badFields) > 0 {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+		}
+	}
 	out, rowsUpdated, err := repo.UpdateCredentialLibrary(ctx, projId, cl, item.GetVersion(), dbMask)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential library"))
@@ -390,6 +449,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 				return res
 			}
 			parentId = cl.GetStoreId()
+			opts = append(opts, auth.WithName(cl.GetName()))
 		default:
 			res.Error = errors.New(ctx, errors.InvalidParameter, op, "unrecognized credential library subtype from id")
 			return res
@@ -463,6 +523,30 @@ func toProto(in credential.Library, opt ...handlers.Option) (*pb.CredentialLibra
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.CredentialTypeField) {
+		out.CredentialType = string(in.CredentialType())
+	}
+	if outputFields.Has(globals.CredentialMappingOverridesField) {
+		if vaultIn, ok := in.(*vault.CredentialLibrary); ok && vaultIn.MappingOverride() != nil {
+			m := vaultIn.MappingOverride()
+			overrides := map[string]interface{}{}
+			for k, v := range map[string]string{
+				usernameAttribute:    m.UsernameAttribute,
+				passwordAttribute:    m.PasswordAttribute,
+				privateKeyAttribute:  m.PrivateKeyAttribute,
+				certificateAttribute: m.CertificateAttribute,
+			} {
+				if v != "" {
+					overrides[k] = v
+				}
+			}
+			var err error
+			out.CredentialMappingOverrides, err = structpb.NewStruct(overrides)
+			if err != nil {
+				return nil, errors.WrapDeprecated(err, op, errors.WithMsg("failed to convert resource from storage to api"))
+			}
+		}
+	}
 	if outputFields.Has(globals.AttributesField) {
 		switch credential.SubtypeFromId(in.GetPublicId()) {
 		case vault.Subtype:
@@ -510,6 +594,17 @@ func toStorageVaultLibrary(storeId string, in *pb.CredentialLibrary) (out *vault
 	if attrs.GetHttpRequestBody() != nil {
 		opts = append(opts, vault.WithRequestBody([]byte(attrs.GetHttpRequestBody().GetValue())))
 	}
+	if in.GetCredentialType() != "" {
+		opts = append(opts, vault.WithCredentialType(credential.Type(in.GetCredentialType())))
+	}
+	if overrides := in.GetCredentialMappingOverrides().GetFields(); len(overrides) > 0 {
+		opts = append(opts, vault.WithMappingOverride(&vault.MappingOverride{
+			UsernameAttribute:    overrides[usernameAttribute].GetStringValue(),
+			PasswordAttribute:    overrides[passwordAttribute].GetStringValue(),
+			PrivateKeyAttribute:  overrides[privateKeyAttribute].GetStringValue(),
+			CertificateAttribute: overrides[certificateAttribute].GetStringValue(),
+		}))
+	}
 
 	cs, err := vault.NewCredentialLibrary(storeId, attrs.GetPath().GetValue(), opts...)
 	if err != nil {
@@ -549,6 +644,20 @@ func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
 			if b := attrs.GetHttpRequestBody(); b != nil && strings.ToUpper(attrs.GetHttpMethod().GetValue()) != "POST" {
 				badFields[httpRequestBodyField] = fmt.Sprintf("Field can only be set if %q is set to the value 'POST'.", httpMethodField)
 			}
+			ct := credential.Type(req.GetItem().GetCredentialType())
+			if ct == "" {
+				ct = credential.UnspecifiedType
+			}
+			if !credential.ValidType(ct) {
+				badFields[globals.CredentialTypeField] = fmt.Sprintf("Unknown credential type %q.", ct)
+				break
+			}
+			if ct == credential.SshCertificateType && strings.ToUpper(attrs.GetHttpMethod().GetValue()) != "POST" {
+				badFields[httpMethodField] = fmt.Sprintf("Must be 'POST' for credential type %q.", ct)
+			}
+			for k, v := range validateMappingOverrides(ct, req.GetItem().GetCredentialMappingOverrides()) {
+				badFields[k] = v
+			}
 		default:
 			badFields[globals.CredentialStoreIdField] = "This field must be a valid credential store id."
 		}
@@ -578,11 +687,74 @@ func validateUpdateRequest(req *pbs.UpdateCredentialLibraryRequest) error {
 			if b := attrs.GetHttpRequestBody(); b != nil && strings.ToUpper(attrs.GetHttpMethod().GetValue()) == "GET" {
 				badFields[httpRequestBodyField] = fmt.Sprintf("Field can only be set if %q is set to the value 'POST'.", httpMethodField)
 			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.CredentialTypeField) || req.GetItem().GetCredentialType() != "" {
+				badFields[globals.CredentialTypeField] = "This is a read only field."
+			}
 		}
 		return badFields
 	}, vault.CredentialLibraryPrefix)
 }
 
+// validateMappingOverrides returns the bad fields of overrides for a
+// library providing credentials of type ct. A null value clears the
+// override.
+func validateMappingOverrides(ct credential.Type, overrides *structpb.Struct) map[string]string {
+	badFields := map[string]string{}
+	for k, v := range overrides.GetFields() {
+		field := fmt.Sprintf("%s.%s", globals.CredentialMappingOverridesField, k)
+		mo, ok := mappingOverrides[k]
+		switch {
+		case !ok:
+			badFields[field] = "Unknown credential mapping override."
+		case !credentialTypeIn(ct, mo.types):
+			badFields[field] = fmt.Sprintf("Cannot be set for credential type %q.", ct)
+		default:
+			switch v.GetKind().(type) {
+			case *structpb.Value_NullValue:
+			case *structpb.Value_StringValue:
+				if strings.TrimSpace(v.GetStringValue()) == "" {
+					badFields[field] = "If set, value cannot be empty."
+				}
+			default:
+				badFields[field] = "Value must be a string."
+			}
+		}
+	}
+	return badFields
+}
+
+func credentialTypeIn(ct credential.Type, types []credential.Type) bool {
+	for _, t := range types {
+		if t == ct {
+			return true
+		}
+	}
+	return false
+}
+
+// translateMappingOverridesMask returns the storage fields for the
+// credential mapping overrides in the update mask paths. The path of the
+// credential mapping overrides themselves covers all of the overrides.
+func translateMappingOverridesMask(paths []string) []string {
+	var result []string
+	for _, p := range paths {
+		for _, p := range strings.Split(p, ",") {
+			p = strings.TrimSpace(p)
+			switch {
+			case p == globals.CredentialMappingOverridesField:
+				for _, k := range []string{usernameAttribute, passwordAttribute, privateKeyAttribute, certificateAttribute} {
+					result = append(result, mappingOverrides[k].field)
+				}
+			case strings.HasPrefix(p, globals.CredentialMappingOverridesField+"."):
+				if mo, ok := mappingOverrides[strings.TrimPrefix(p, globals.CredentialMappingOverridesField+".")]; ok {
+					result = append(result, mo.field)
+				}
+			}
+		}
+	}
+	return result
+}
+
 func validateDeleteRequest(req *pbs.DeleteCredentialLibraryRequest) error {
 	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, vault.CredentialLibraryPrefix)
 }
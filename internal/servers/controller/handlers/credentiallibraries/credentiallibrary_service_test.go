@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -63,6 +65,7 @@ func TestList(t *testing.T) {
 			UpdatedTime:       l.GetUpdateTime().GetTimestamp(),
 			Version:           l.GetVersion(),
 			Type:              vault.Subtype.String(),
+			CredentialType:    string(credential.UnspecifiedType),
 			AuthorizedActions: testAuthorizedActions,
 			Attributes: func() *structpb.Struct {
 				attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
@@ -351,6 +354,7 @@ func TestCreate(t *testing.T) {
 					Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: prj.GetType(), ParentScopeId: prj.GetParentId()},
 					Version:           1,
 					Type:              vault.Subtype.String(),
+					CredentialType:    string(credential.UnspecifiedType),
 					Attributes: func() *structpb.Struct {
 						attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
 							Path:            wrapperspb.String("something"),
@@ -364,6 +368,102 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Unknown credential type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				CredentialType:    "unknown",
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("something"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Mapping override not valid for credential type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				CredentialType:    string(credential.UsernamePasswordType),
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					privateKeyAttribute: structpb.NewStringValue("key"),
+				}},
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("something"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unknown mapping override",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				CredentialType:    string(credential.UsernamePasswordType),
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"unknown_attribute": structpb.NewStringValue("unknown"),
+				}},
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("something"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create a typed vault CredentialLibrary with mapping overrides",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				CredentialType:    string(credential.UsernamePasswordType),
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					usernameAttribute: structpb.NewStringValue("user"),
+					passwordAttribute: structpb.NewStringValue("pass"),
+				}},
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("kv/data/db"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+			}},
+			idPrefix: vault.CredentialLibraryPrefix + "_",
+			res: &pbs.CreateCredentialLibraryResponse{
+				Uri: fmt.Sprintf("credential-libraries/%s_", vault.CredentialLibraryPrefix),
+				Item: &pb.CredentialLibrary{
+					Id:                store.GetPublicId(),
+					CredentialStoreId: store.GetPublicId(),
+					CreatedTime:       store.GetCreateTime().GetTimestamp(),
+					UpdatedTime:       store.GetUpdateTime().GetTimestamp(),
+					Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: prj.GetType(), ParentScopeId: prj.GetParentId()},
+					Version:           1,
+					Type:              vault.Subtype.String(),
+					CredentialType:    string(credential.UsernamePasswordType),
+					CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+						usernameAttribute: structpb.NewStringValue("user"),
+						passwordAttribute: structpb.NewStringValue("pass"),
+					}},
+					Attributes: func() *structpb.Struct {
+						attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+							Path:       wrapperspb.String("kv/data/db"),
+							HttpMethod: wrapperspb.String("GET"),
+						})
+						require.NoError(t, err)
+						return attrs
+					}(),
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
 		{
 			name: "Create a valid vault CredentialLibrary",
 			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
@@ -391,6 +491,7 @@ func TestCreate(t *testing.T) {
 					Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: prj.GetType(), ParentScopeId: prj.GetParentId()},
 					Version:           1,
 					Type:              vault.Subtype.String(),
+					CredentialType:    string(credential.UnspecifiedType),
 					Attributes: func() *structpb.Struct {
 						attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
 							Path:       wrapperspb.String("something"),
@@ -480,6 +581,7 @@ func TestGet(t *testing.T) {
 					CredentialStoreId: vl.GetStoreId(),
 					Scope:             &scopepb.ScopeInfo{Id: store.GetScopeId(), Type: scope.Project.String(), ParentScopeId: prj.GetParentId()},
 					Type:              vault.Subtype.String(),
+					CredentialType:    string(credential.UnspecifiedType),
 					AuthorizedActions: testAuthorizedActions,
 					CreatedTime:       vl.CreateTime.GetTimestamp(),
 					UpdatedTime:       vl.UpdateTime.GetTimestamp(),
@@ -776,6 +878,11 @@ func TestUpdate(t *testing.T) {
 			path: "created_time",
 			item: &pb.CredentialLibrary{UpdatedTime: timestamppb.Now()},
 		},
+		{
+			name: "read only credential type",
+			path: "credential_type",
+			item: &pb.CredentialLibrary{CredentialType: string(credential.SshPrivateKeyType)},
+		},
 		{
 			name: "read only authorized actions",
 			path: "authorized actions",
@@ -877,4 +984,77 @@ func TestUpdate(t *testing.T) {
 		assert.Equal(t, "GET", cl.GetItem().GetAttributes().GetFields()["http_method"].GetStringValue())
 		assert.Nil(t, cl.GetItem().GetAttributes().GetFields()["http_request_body"])
 	})
+
+	t.Run("credential mapping overrides", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		created, err := s.CreateCredentialLibrary(ctx, &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+			CredentialStoreId: store.GetPublicId(),
+			CredentialType:    string(credential.SshPrivateKeyType),
+			Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"path": structpb.NewStringValue("kv/data/ssh"),
+			}},
+		}})
+		require.NoError(err)
+		defer func() {
+			_, err := s.DeleteCredentialLibrary(ctx, &pbs.DeleteCredentialLibraryRequest{Id: created.GetItem().GetId()})
+			require.NoError(err)
+		}()
+		assert.Nil(created.GetItem().GetCredentialMappingOverrides())
+
+		// Overrides not valid for the credential type are rejected
+		_, err = s.UpdateCredentialLibrary(ctx, &pbs.UpdateCredentialLibraryRequest{
+			Id:         created.GetItem().GetId(),
+			UpdateMask: fieldmask(globals.CredentialMappingOverridesField + "." + passwordAttribute),
+			Item: &pb.CredentialLibrary{
+				Version: created.GetItem().GetVersion(),
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					passwordAttribute: structpb.NewStringValue("pass"),
+				}},
+			},
+		})
+		assert.Truef(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", err)
+
+		// A single override can be set
+		cl, err := s.UpdateCredentialLibrary(ctx, &pbs.UpdateCredentialLibraryRequest{
+			Id:         created.GetItem().GetId(),
+			UpdateMask: fieldmask(globals.CredentialMappingOverridesField + "." + privateKeyAttribute),
+			Item: &pb.CredentialLibrary{
+				Version: created.GetItem().GetVersion(),
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					privateKeyAttribute: structpb.NewStringValue("key"),
+				}},
+			},
+		})
+		require.NoError(err)
+		assert.Equal(map[string]interface{}{privateKeyAttribute: "key"}, cl.GetItem().GetCredentialMappingOverrides().AsMap())
+
+		// The mapping overrides path replaces all of the overrides
+		cl, err = s.UpdateCredentialLibrary(ctx, &pbs.UpdateCredentialLibraryRequest{
+			Id:         created.GetItem().GetId(),
+			UpdateMask: fieldmask(globals.CredentialMappingOverridesField),
+			Item: &pb.CredentialLibrary{
+				Version: cl.GetItem().GetVersion(),
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					usernameAttribute: structpb.NewStringValue("user"),
+				}},
+			},
+		})
+		require.NoError(err)
+		assert.Equal(map[string]interface{}{usernameAttribute: "user"}, cl.GetItem().GetCredentialMappingOverrides().AsMap())
+		assert.Equal(string(credential.SshPrivateKeyType), cl.GetItem().GetCredentialType())
+
+		// A null value clears an override
+		cl, err = s.UpdateCredentialLibrary(ctx, &pbs.UpdateCredentialLibraryRequest{
+			Id:         created.GetItem().GetId(),
+			UpdateMask: fieldmask(globals.CredentialMappingOverridesField + "." + usernameAttribute),
+			Item: &pb.CredentialLibrary{
+				Version: cl.GetItem().GetVersion(),
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					usernameAttribute: structpb.NewNullValue(),
+				}},
+			},
+		})
+		require.NoError(err)
+		assert.Nil(cl.GetItem().GetCredentialMappingOverrides())
+	})
 }
@@ -0,0 +1,717 @@
+package credentials
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentials"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	usernameField       = "attributes.username"
+	passwordField       = "attributes.password"
+	passwordHmacField   = "attributes.password_hmac"
+	privateKeyField     = "attributes.private_key"
+	privateKeyHmacField = "attributes.private_key_hmac"
+)
+
+var (
+	usernamePasswordMaskManager handlers.MaskManager
+	sshPrivateKeyMaskManager    handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+func init() {
+	var err error
+	if usernamePasswordMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.UsernamePasswordCredential{}},
+		handlers.MaskSource{&pb.Credential{}, &pb.UsernamePasswordAttributes{}}); err != nil {
+		panic(err)
+	}
+	if sshPrivateKeyMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.SshPrivateKeyCredential{}},
+		handlers.MaskSource{&pb.Credential{}, &pb.SshPrivateKeyAttributes{}}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.CredentialServiceServer interface.
+type Service struct {
+	pbs.UnimplementedCredentialServiceServer
+
+	iamRepoFn common.IamRepoFactory
+	repoFn    common.StaticCredentialRepoFactory
+}
+
+// NewService returns a credential service which handles credential related requests to boundary.
+func NewService(repo common.StaticCredentialRepoFactory, iamRepo common.IamRepoFactory) (Service, error) {
+	const op = "credentials.NewService"
+	if iamRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	if repo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing static credential repository")
+	}
+	return Service{iamRepoFn: iamRepo, repoFn: repo}, nil
+}
+
+var _ pbs.CredentialServiceServer = Service{}
+
+// ListCredentials implements the interface pbs.CredentialServiceServer
+func (s Service) ListCredentials(ctx context.Context, req *pbs.ListCredentialsRequest) (*pbs.ListCredentialsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetCredentialStoreId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.Credential, req.GetPageSize(), req.GetListToken(),
+		req.GetCredentialStoreId(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	var finalItems []*pb.Credential
+	res := perms.Resource{
+		ScopeId: authResults.Scope.Id,
+		Type:    resource.Credential,
+		Pin:     req.GetCredentialStoreId(),
+	}
+	for {
+		cl, err := s.listFromRepo(ctx, req.GetCredentialStoreId(), pg)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range cl {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.Name = item.GetName()
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+			}
+
+			item, err := toProto(item, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
+		}
+		if !pg.Next(len(cl)) {
+			break
+		}
+	}
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListCredentialsResponse{Items: finalItems, ListToken: listToken}, nil
+}
+
+// GetCredential implements the interface pbs.CredentialServiceServer.
+func (s Service) GetCredential(ctx context.Context, req *pbs.GetCredentialRequest) (*pbs.GetCredentialResponse, error) {
+	const op = "credentials.(Service).GetCredential"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	c, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, c.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(c, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetCredentialResponse{Item: item}, nil
+}
+
+// CreateCredential implements the interface pbs.CredentialServiceServer.
+func (s Service) CreateCredential(ctx context.Context, req *pbs.CreateCredentialRequest) (*pbs.CreateCredentialResponse, error) {
+	const op = "credentials.(Service).CreateCredential"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetCredentialStoreId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	c, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, c.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(c, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateCredentialResponse{
+		Item: item,
+		Uri:  fmt.Sprintf("credentials/%s", item.GetId()),
+	}, nil
+}
+
+// UpdateCredential implements the interface pbs.CredentialServiceServer.
+func (s Service) UpdateCredential(ctx context.Context, req *pbs.UpdateCredentialRequest) (*pbs.UpdateCredentialResponse, error) {
+	const op = "credentials.(Service).UpdateCredential"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	c, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, c.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(c, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateCredentialResponse{Item: item}, nil
+}
+
+// DeleteCredential implements the interface pbs.CredentialServiceServer.
+func (s Service) DeleteCredential(ctx context.Context, req *pbs.DeleteCredentialRequest) (*pbs.DeleteCredentialResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, storeId string, pg *handlers.Paginator) ([]static.Credential, error) {
+	const op = "credentials.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var opts []static.Option
+	if pg.Enabled() {
+		opts = append(opts, static.WithStartPageAfterItem(pg.StartAfter()), static.WithLimit(pg.BatchSize()))
+	}
+	cl, err := repo.ListCredentials(ctx, storeId, opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return cl, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (static.Credential, error) {
+	const op = "credentials.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c, err := repo.LookupCredential(ctx, id)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if c == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("credential %q not found", id))
+	}
+	return c, err
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Credential) (static.Credential, error) {
+	const op = "credentials.(Service).createInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var out static.Credential
+	switch credential.Type(item.GetType()) {
+	case credential.UsernamePasswordType:
+		c, err := toStorageUsernamePasswordCredential(item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		upc, err := repo.CreateUsernamePasswordCredential(ctx, scopeId, c)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential"))
+		}
+		if upc != nil {
+			out = upc
+		}
+	case credential.SshPrivateKeyType:
+		c, err := toStorageSshPrivateKeyCredential(item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		spkc, err := repo.CreateSshPrivateKeyCredential(ctx, scopeId, c)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential"))
+		}
+		if spkc != nil {
+			out = spkc
+		}
+	default:
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{globals.TypeField: "Unknown credential type."})
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Credential) (static.Credential, error) {
+	const op = "credentials.(Service).updateInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var out static.Credential
+	var rowsUpdated int
+	switch static.CredentialTypeFromId(id) {
+	case credential.UsernamePasswordType:
+		c, err := toStorageUsernamePasswordCredential(item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		c.PublicId = id
+		dbMask := usernamePasswordMaskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+		}
+		upc, n, err := repo.UpdateUsernamePasswordCredential(ctx, scopeId, c, item.GetVersion(), dbMask)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential"))
+		}
+		out, rowsUpdated = upc, n
+	case credential.SshPrivateKeyType:
+		c, err := toStorageSshPrivateKeyCredential(item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		c.PublicId = id
+		dbMask := sshPrivateKeyMaskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+		}
+		spkc, n, err := repo.UpdateSshPrivateKeyCredential(ctx, scopeId, c, item.GetVersion(), dbMask)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential"))
+		}
+		out, rowsUpdated = spkc, n
+	default:
+		return nil, handlers.NotFoundErrorf("Credential %q doesn't exist.", id)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Credential %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
+	const op = "credentials.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteCredential(ctx, scopeId, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete credential"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	const op = "credentials.(Service).authResult"
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Credential), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+	default:
+		opts = append(opts, auth.WithId(id))
+
+		switch credential.SubtypeFromId(id) {
+		case static.Subtype:
+			c, err := repo.LookupCredential(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if c == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			parentId = c.GetStoreId()
+			opts = append(opts, auth.WithName(c.GetName()))
+		default:
+			res.Error = errors.New(ctx, errors.InvalidParameter, op, "unrecognized credential subtype from id")
+			return res
+		}
+	}
+
+	if parentId == "" {
+		res.Error = errors.New(ctx, errors.RecordNotFound, op, "unable to find credential store for provided credential")
+		return res
+	}
+
+	opts = append(opts, auth.WithPin(parentId))
+
+	switch credential.SubtypeFromId(parentId) {
+	case static.Subtype:
+		cs, err := repo.LookupCredentialStore(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if cs == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		opts = append(opts, auth.WithScopeId(cs.GetScopeId()))
+	default:
+		res.Error = errors.New(ctx, errors.InvalidParameter, op, "unrecognized credential store subtype from id")
+		return res
+	}
+
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(in static.Credential, opt ...handlers.Option) (*pb.Credential, error) {
+	const op = "credentials.toProto"
+
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building credential proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Credential{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.CredentialStoreIdField) {
+		out.CredentialStoreId = in.GetStoreId()
+	}
+	if outputFields.Has(globals.TypeField) {
+		out.Type = string(in.CredentialType())
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has(globals.AttributesField) {
+		var attrs proto.Message
+		switch c := in.(type) {
+		case *static.UsernamePasswordCredential:
+			attrs = &pb.UsernamePasswordAttributes{
+				Username:     wrapperspb.String(c.GetUsername()),
+				PasswordHmac: base64.RawURLEncoding.EncodeToString(c.GetPasswordHmac()),
+			}
+		case *static.SshPrivateKeyCredential:
+			attrs = &pb.SshPrivateKeyAttributes{
+				Username:       wrapperspb.String(c.GetUsername()),
+				PrivateKeyHmac: base64.RawURLEncoding.EncodeToString(c.GetPrivateKeyHmac()),
+			}
+		default:
+			return nil, errors.NewDeprecated(errors.Internal, op, fmt.Sprintf("unsupported static credential %T", in))
+		}
+		var err error
+		out.Attributes, err = handlers.ProtoToStruct(attrs)
+		if err != nil {
+			return nil, errors.WrapDeprecated(err, op, errors.WithMsg("failed to convert resource from storage to api"))
+		}
+	}
+	return &out, nil
+}
+
+func toStorageUsernamePasswordCredential(storeId string, in *pb.Credential) (out *static.UsernamePasswordCredential, err error) {
+	const op = "credentials.toStorageUsernamePasswordCredential"
+	var opts []static.Option
+	if in.GetName() != nil {
+		opts = append(opts, static.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	attrs := &pb.UsernamePasswordAttributes{}
+	if err := handlers.StructToProto(in.GetAttributes(), attrs); err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to parse the attributes"))
+	}
+
+	c, err := static.NewUsernamePasswordCredential(storeId, attrs.GetUsername().GetValue(), credential.Password(attrs.GetPassword().GetValue()), opts...)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to build credential"))
+	}
+	return c, nil
+}
+
+func toStorageSshPrivateKeyCredential(storeId string, in *pb.Credential) (out *static.SshPrivateKeyCredential, err error) {
+	const op = "credentials.toStorageSshPrivateKeyCredential"
+	var opts []static.Option
+	if in.GetName() != nil {
+		opts = append(opts, static.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	attrs := &pb.SshPrivateKeyAttributes{}
+	if err := handlers.StructToProto(in.GetAttributes(), attrs); err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to parse the attributes"))
+	}
+
+	c, err := static.NewSshPrivateKeyCredential(storeId, attrs.GetUsername().GetValue(), credential.PrivateKey(attrs.GetPrivateKey().GetValue()), opts...)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to build credential"))
+	}
+	return c, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetCredentialRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, static.UsernamePasswordCredentialPrefix, static.SshPrivateKeyCredentialPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateCredentialRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(handlers.Id(req.GetItem().GetCredentialStoreId()), static.CredentialStorePrefix) {
+			badFields[globals.CredentialStoreIdField] = "This field must be a valid static credential store id."
+		}
+		switch credential.Type(req.GetItem().GetType()) {
+		case credential.UsernamePasswordType:
+			attrs := &pb.UsernamePasswordAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+				break
+			}
+			if attrs.GetUsername().GetValue() == "" {
+				badFields[usernameField] = "Field required for creating a username_password credential."
+			}
+			if attrs.GetPassword().GetValue() == "" {
+				badFields[passwordField] = "Field required for creating a username_password credential."
+			}
+			if attrs.GetPasswordHmac() != "" {
+				badFields[passwordHmacField] = "This is a read only field."
+			}
+		case credential.SshPrivateKeyType:
+			attrs := &pb.SshPrivateKeyAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+				break
+			}
+			if attrs.GetUsername().GetValue() == "" {
+				badFields[usernameField] = "Field required for creating a ssh_private_key credential."
+			}
+			if attrs.GetPrivateKey().GetValue() == "" {
+				badFields[privateKeyField] = "Field required for creating a ssh_private_key credential."
+			}
+			if attrs.GetPrivateKeyHmac() != "" {
+				badFields[privateKeyHmacField] = "This is a read only field."
+			}
+		default:
+			badFields[globals.TypeField] = fmt.Sprintf("This is a required field and must be %q or %q.", credential.UsernamePasswordType, credential.SshPrivateKeyType)
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateCredentialRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		ct := static.CredentialTypeFromId(req.GetId())
+		if t := req.GetItem().GetType(); t != "" && credential.Type(t) != ct {
+			badFields[globals.TypeField] = "Cannot modify resource type."
+		}
+		switch ct {
+		case credential.UsernamePasswordType:
+			attrs := &pb.UsernamePasswordAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+				break
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), usernameField) && attrs.GetUsername().GetValue() == "" {
+				badFields[usernameField] = "This is a required field and cannot be unset."
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), passwordField) && attrs.GetPassword().GetValue() == "" {
+				badFields[passwordField] = "This is a required field and cannot be unset."
+			}
+			if attrs.GetPasswordHmac() != "" {
+				badFields[passwordHmacField] = "This is a read only field."
+			}
+		case credential.SshPrivateKeyType:
+			attrs := &pb.SshPrivateKeyAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+				break
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), usernameField) && attrs.GetUsername().GetValue() == "" {
+				badFields[usernameField] = "This is a required field and cannot be unset."
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), privateKeyField) && attrs.GetPrivateKey().GetValue() == "" {
+				badFields[privateKeyField] = "This is a required field and cannot be unset."
+			}
+			if attrs.GetPrivateKeyHmac() != "" {
+				badFields[privateKeyHmacField] = "This is a read only field."
+			}
+		}
+		return badFields
+	}, static.UsernamePasswordCredentialPrefix, static.SshPrivateKeyCredentialPrefix)
+}
+
+func validateDeleteRequest(req *pbs.DeleteCredentialRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, static.UsernamePasswordCredentialPrefix, static.SshPrivateKeyCredentialPrefix)
+}
+
+func validateListRequest(req *pbs.ListCredentialsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetCredentialStoreId()), static.CredentialStorePrefix) {
+		badFields[globals.CredentialStoreIdField] = "This field must be a valid static credential store id."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+package credentials
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/vault"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentials"
+	scopepb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+
+func TestGet(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+
+	store := static.TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	upCred := static.TestUsernamePasswordCredential(t, conn, wrapper, prj.GetPublicId(), store.GetPublicId(), "user", "pass")
+	spkCred := static.TestSshPrivateKeyCredential(t, conn, wrapper, prj.GetPublicId(), store.GetPublicId(), "user", "private key")
+	s, err := NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		id   string
+		res  *pbs.GetCredentialResponse
+		err  error
+	}{
+		{
+			name: "success username password",
+			id:   upCred.GetPublicId(),
+			res: &pbs.GetCredentialResponse{
+				Item: &pb.Credential{
+					Id:                upCred.GetPublicId(),
+					CredentialStoreId: upCred.GetStoreId(),
+					Scope:             &scopepb.ScopeInfo{Id: store.GetScopeId(), Type: scope.Project.String(), ParentScopeId: prj.GetParentId()},
+					Type:              string(credential.UsernamePasswordType),
+					AuthorizedActions: testAuthorizedActions,
+					CreatedTime:       upCred.CreateTime.GetTimestamp(),
+					UpdatedTime:       upCred.UpdateTime.GetTimestamp(),
+					Version:           1,
+					Attributes: func() *structpb.Struct {
+						attrs, err := handlers.ProtoToStruct(&pb.UsernamePasswordAttributes{
+							Username:     wrapperspb.String("user"),
+							PasswordHmac: base64.RawURLEncoding.EncodeToString(upCred.GetPasswordHmac()),
+						})
+						require.NoError(t, err)
+						return attrs
+					}(),
+				},
+			},
+		},
+		{
+			name: "success ssh private key",
+			id:   spkCred.GetPublicId(),
+			res: &pbs.GetCredentialResponse{
+				Item: &pb.Credential{
+					Id:                spkCred.GetPublicId(),
+					CredentialStoreId: spkCred.GetStoreId(),
+					Scope:             &scopepb.ScopeInfo{Id: store.GetScopeId(), Type: scope.Project.String(), ParentScopeId: prj.GetParentId()},
+					Type:              string(credential.SshPrivateKeyType),
+					AuthorizedActions: testAuthorizedActions,
+					CreatedTime:       spkCred.CreateTime.GetTimestamp(),
+					UpdatedTime:       spkCred.UpdateTime.GetTimestamp(),
+					Version:           1,
+					Attributes: func() *structpb.Struct {
+						attrs, err := handlers.ProtoToStruct(&pb.SshPrivateKeyAttributes{
+							Username:       wrapperspb.String("user"),
+							PrivateKeyHmac: base64.RawURLEncoding.EncodeToString(spkCred.GetPrivateKeyHmac()),
+						})
+						require.NoError(t, err)
+						return attrs
+					}(),
+				},
+			},
+		},
+		{
+			name: "not found error",
+			id:   fmt.Sprintf("%s_1234567890", static.UsernamePasswordCredentialPrefix),
+			err:  handlers.NotFoundError(),
+		},
+		{
+			name: "bad prefix",
+			id:   fmt.Sprintf("%s_1234567890", vault.CredentialLibraryPrefix),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := s.GetCredential(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.GetCredentialRequest{Id: tc.id})
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err))
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Empty(t, cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	store := static.TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	s, err := NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	upAttrs := func(t *testing.T, a *pb.UsernamePasswordAttributes) *structpb.Struct {
+		attrs, err := handlers.ProtoToStruct(a)
+		require.NoError(t, err)
+		return attrs
+	}
+	spkAttrs := func(t *testing.T, a *pb.SshPrivateKeyAttributes) *structpb.Struct {
+		attrs, err := handlers.ProtoToStruct(a)
+		require.NoError(t, err)
+		return attrs
+	}
+
+	cases := []struct {
+		name     string
+		req      *pbs.CreateCredentialRequest
+		idPrefix string
+		err      error
+	}{
+		{
+			name: "username password",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              string(credential.UsernamePasswordType),
+				Name:              wrapperspb.String("name"),
+				Attributes: upAttrs(t, &pb.UsernamePasswordAttributes{
+					Username: wrapperspb.String("user"),
+					Password: wrapperspb.String("pass"),
+				}),
+			}},
+			idPrefix: static.UsernamePasswordCredentialPrefix + "_",
+		},
+		{
+			name: "ssh private key",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              string(credential.SshPrivateKeyType),
+				Attributes: spkAttrs(t, &pb.SshPrivateKeyAttributes{
+					Username:   wrapperspb.String("user"),
+					PrivateKey: wrapperspb.String("private key"),
+				}),
+			}},
+			idPrefix: static.SshPrivateKeyCredentialPrefix + "_",
+		},
+		{
+			name: "missing type",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Attributes: upAttrs(t, &pb.UsernamePasswordAttributes{
+					Username: wrapperspb.String("user"),
+					Password: wrapperspb.String("pass"),
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "missing password",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              string(credential.UsernamePasswordType),
+				Attributes: upAttrs(t, &pb.UsernamePasswordAttributes{
+					Username: wrapperspb.String("user"),
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "password hmac is read only",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              string(credential.UsernamePasswordType),
+				Attributes: upAttrs(t, &pb.UsernamePasswordAttributes{
+					Username:     wrapperspb.String("user"),
+					Password:     wrapperspb.String("pass"),
+					PasswordHmac: "hmac",
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "store must be static",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: fmt.Sprintf("%s_1234567890", vault.CredentialStorePrefix),
+				Type:              string(credential.UsernamePasswordType),
+				Attributes: upAttrs(t, &pb.UsernamePasswordAttributes{
+					Username: wrapperspb.String("user"),
+					Password: wrapperspb.String("pass"),
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.CreateCredential(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err))
+				return
+			}
+			require.NoError(gErr)
+			require.NotNil(got)
+			assert.Contains(got.GetUri(), got.GetItem().GetId())
+			assert.True(len(got.GetItem().GetId()) > len(tc.idPrefix))
+			assert.Equal(tc.idPrefix, got.GetItem().GetId()[:len(tc.idPrefix)])
+			assert.Equal(store.GetPublicId(), got.GetItem().GetCredentialStoreId())
+			assert.Equal(tc.req.GetItem().GetType(), got.GetItem().GetType())
+
+			// The secrets must never be returned.
+			fields := got.GetItem().GetAttributes().GetFields()
+			assert.NotContains(fields, "password")
+			assert.NotContains(fields, "private_key")
+			assert.Equal("user", fields["username"].GetStringValue())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+
+	store := static.TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	cred := static.TestUsernamePasswordCredential(t, conn, wrapper, prj.GetPublicId(), store.GetPublicId(), "user", "pass")
+	s, err := NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		id   string
+		err  error
+	}{
+		{
+			name: "success",
+			id:   cred.GetPublicId(),
+		},
+		{
+			name: "not found error",
+			id:   fmt.Sprintf("%s_1234567890", static.UsernamePasswordCredentialPrefix),
+			err:  handlers.NotFoundError(),
+		},
+		{
+			name: "bad prefix",
+			id:   fmt.Sprintf("%s_1234567890", vault.CredentialLibraryPrefix),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := s.DeleteCredential(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.DeleteCredentialRequest{Id: tc.id})
+			assert.Nil(t, got)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err))
+				return
+			}
+			require.NoError(t, gErr)
+			g, err := s.GetCredential(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.GetCredentialRequest{Id: tc.id})
+			assert.Nil(t, g)
+			assert.True(t, errors.Is(err, handlers.NotFoundError()))
+		})
+	}
+}
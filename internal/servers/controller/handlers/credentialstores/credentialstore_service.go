@@ -5,9 +5,12 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/credential/vault/store"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -19,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentiallibraries"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentials"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -55,9 +59,13 @@ var (
 		action.List,
 	}
 
-	collectionTypeMap = map[resource.Type]action.ActionSet{
+	vaultCollectionTypeMap = map[resource.Type]action.ActionSet{
 		resource.CredentialLibrary: credentiallibraries.CollectionActions,
 	}
+
+	staticCollectionTypeMap = map[resource.Type]action.ActionSet{
+		resource.Credential: credentials.CollectionActions,
+	}
 )
 
 func init() {
@@ -72,12 +80,13 @@ func init() {
 type Service struct {
 	pbs.UnimplementedCredentialStoreServiceServer
 
-	iamRepoFn common.IamRepoFactory
-	repoFn    common.VaultCredentialRepoFactory
+	iamRepoFn    common.IamRepoFactory
+	repoFn       common.VaultCredentialRepoFactory
+	staticRepoFn common.StaticCredentialRepoFactory
 }
 
 // NewService returns a credential store service which handles credential store related requests to boundary.
-func NewService(repo common.VaultCredentialRepoFactory, iamRepo common.IamRepoFactory) (Service, error) {
+func NewService(repo common.VaultCredentialRepoFactory, staticRepo common.StaticCredentialRepoFactory, iamRepo common.IamRepoFactory) (Service, error) {
 	const op = "credentialstores.NewService"
 	if iamRepo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
@@ -85,7 +94,10 @@ func NewService(repo common.VaultCredentialRepoFactory, iamRepo common.IamRepoFa
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing vault credential repository")
 	}
-	return Service{iamRepoFn: iamRepo, repoFn: repo}, nil
+	if staticRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing static credential repository")
+	}
+	return Service{iamRepoFn: iamRepo, repoFn: repo, staticRepoFn: staticRepo}, nil
 }
 
 var _ pbs.CredentialStoreServiceServer = Service{}
@@ -119,57 +131,72 @@ func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredenti
 		return &pbs.ListCredentialStoresResponse{}, nil
 	}
 
-	csl, err := s.listFromRepo(ctx, scopeIds)
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.CredentialStore, req.GetPageSize(), req.GetListToken(),
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(csl) == 0 {
-		return &pbs.ListCredentialStoresResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.CredentialStore, 0, len(csl))
+	var finalItems []*pb.CredentialStore
 	res := perms.Resource{
 		Type: resource.CredentialStore,
 	}
-	for _, item := range csl {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetScopeId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
-		if len(authorizedActions) == 0 {
-			continue
+	for {
+		csl, err := s.listFromRepo(ctx, scopeIds, pg)
+		if err != nil {
+			return nil, err
 		}
+		for _, item := range csl {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.ScopeId = item.GetScopeId()
+			res.Name = item.GetName()
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+			if len(authorizedActions) == 0 {
+				continue
+			}
 
-		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
-		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
-		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
-		}
-		if outputFields.Has(globals.AuthorizedActionsField) {
-			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
-		}
-		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
-			collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, item.GetPublicId())
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+			}
+			if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+				collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap(item.GetPublicId()), authResults.Scope.Id, item.GetPublicId())
+				if err != nil {
+					return nil, err
+				}
+				outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
+			}
+
+			item, err := toProto(item, outputOpts...)
 			if err != nil {
 				return nil, err
 			}
-			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
-		}
 
-		item, err := toProto(item, outputOpts...)
-		if err != nil {
-			return nil, err
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
 		}
-
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !pg.Next(len(csl)) {
+			break
 		}
 	}
-	return &pbs.ListCredentialStoresResponse{Items: finalItems}, nil
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListCredentialStoresResponse{Items: finalItems, ListToken: listToken}, nil
 }
 
 // GetCredentialStore implements the interface pbs.CredentialStoreServiceServer.
@@ -202,7 +229,7 @@ func (s Service) GetCredentialStore(ctx context.Context, req *pbs.GetCredentialS
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions).Strings()))
 	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
-		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, cs.GetPublicId())
+		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap(cs.GetPublicId()), authResults.Scope.Id, cs.GetPublicId())
 		if err != nil {
 			return nil, err
 		}
@@ -247,7 +274,7 @@ func (s Service) CreateCredentialStore(ctx context.Context, req *pbs.CreateCrede
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions).Strings()))
 	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
-		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, cs.GetPublicId())
+		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap(cs.GetPublicId()), authResults.Scope.Id, cs.GetPublicId())
 		if err != nil {
 			return nil, err
 		}
@@ -295,7 +322,7 @@ func (s Service) UpdateCredentialStore(ctx context.Context, req *pbs.UpdateCrede
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions).Strings()))
 	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
-		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, cs.GetPublicId())
+		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap(cs.GetPublicId()), authResults.Scope.Id, cs.GetPublicId())
 		if err != nil {
 			return nil, err
 		}
@@ -326,26 +353,57 @@ func (s Service) DeleteCredentialStore(ctx context.Context, req *pbs.DeleteCrede
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*vault.CredentialStore, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, pg *handlers.Paginator) ([]credential.Store, error) {
 	const op = "credentialstores.(Service).listFromRepo"
+	var outCsl []credential.Store
+
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	csl, err := repo.ListCredentialStores(ctx, scopeIds)
+	var opts []vault.Option
+	if pg.Enabled() {
+		opts = append(opts, vault.WithStartPageAfterItem(pg.StartAfter()), vault.WithLimit(pg.BatchSize()))
+	}
+	csl, err := repo.ListCredentialStores(ctx, scopeIds, opts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	return csl, nil
-}
+	for _, item := range csl {
+		outCsl = append(outCsl, item)
+	}
 
-func (s Service) getFromRepo(ctx context.Context, id string) (credential.Store, error) {
-	const op = "credentialstores.(Service).getFromRepo"
-	repo, err := s.repoFn()
+	staticRepo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	cs, err := repo.LookupCredentialStore(ctx, id)
+	var staticOpts []static.Option
+	if pg.Enabled() {
+		staticOpts = append(staticOpts, static.WithStartPageAfterItem(pg.StartAfter()), static.WithLimit(pg.BatchSize()))
+	}
+	scsl, err := staticRepo.ListCredentialStores(ctx, scopeIds, staticOpts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, item := range scsl {
+		outCsl = append(outCsl, item)
+	}
+	if pg.Enabled() {
+		// Each repository returns a batch in public id order, the batch of
+		// the page is made up of the first items of all batches combined.
+		sort.Slice(outCsl, func(i, j int) bool {
+			return outCsl[i].GetPublicId() < outCsl[j].GetPublicId()
+		})
+		if len(outCsl) > pg.BatchSize() {
+			outCsl = outCsl[:pg.BatchSize()]
+		}
+	}
+	return outCsl, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (credential.Store, error) {
+	const op = "credentialstores.(Service).getFromRepo"
+	cs, err := s.lookupInRepo(ctx, id)
 	if err != nil && !errors.IsNotFoundError(err) {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -355,19 +413,71 @@ func (s Service) getFromRepo(ctx context.Context, id string) (credential.Store,
 	return cs, err
 }
 
+// lookupInRepo looks up the credential store with the id in the repository
+// of its subtype. It returns nil if the store is not found.
+func (s Service) lookupInRepo(ctx context.Context, id string) (credential.Store, error) {
+	const op = "credentialstores.(Service).lookupInRepo"
+	switch credential.SubtypeFromId(id) {
+	case vault.Subtype:
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		cs, err := repo.LookupCredentialStore(ctx, id)
+		if err != nil || cs == nil {
+			return nil, err
+		}
+		return cs, nil
+	case static.Subtype:
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		cs, err := repo.LookupCredentialStore(ctx, id)
+		if err != nil || cs == nil {
+			return nil, err
+		}
+		return cs, nil
+	}
+	return nil, nil
+}
+
 func (s Service) createInRepo(ctx context.Context, projId string, item *pb.CredentialStore) (credential.Store, error) {
 	const op = "credentialstores.(Service).createInRepo"
-	cs, err := toStorageVaultStore(projId, item)
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
-	}
-	repo, err := s.repoFn()
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
-	}
-	out, err := repo.CreateCredentialStore(ctx, cs)
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential store"))
+	var out credential.Store
+	switch credential.SubtypeFromType(item.GetType()) {
+	case vault.Subtype:
+		cs, err := toStorageVaultStore(projId, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		vcs, err := repo.CreateCredentialStore(ctx, cs)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential store"))
+		}
+		if vcs != nil {
+			out = vcs
+		}
+	case static.Subtype:
+		cs, err := toStorageStaticStore(projId, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		scs, err := repo.CreateCredentialStore(ctx, cs)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential store"))
+		}
+		if scs != nil {
+			out = scs
+		}
 	}
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential store but no error returned from repository.")
@@ -377,23 +487,44 @@ func (s Service) createInRepo(ctx context.Context, projId string, item *pb.Crede
 
 func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []string, item *pb.CredentialStore) (credential.Store, error) {
 	const op = "credentialstores.(Service).updateInRepo"
-	cs, err := toStorageVaultStore(projId, item)
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
-	}
-	cs.PublicId = id
-
 	dbMask := maskManager.Translate(mask)
 	if len(dbMask) == 0 {
 		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
 	}
-	repo, err := s.repoFn()
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
-	}
-	out, rowsUpdated, err := repo.UpdateCredentialStore(ctx, cs, item.GetVersion(), dbMask)
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential store"))
+
+	var out credential.Store
+	var rowsUpdated int
+	switch credential.SubtypeFromId(id) {
+	case vault.Subtype:
+		cs, err := toStorageVaultStore(projId, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		cs.PublicId = id
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		vcs, n, err := repo.UpdateCredentialStore(ctx, cs, item.GetVersion(), dbMask)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential store"))
+		}
+		out, rowsUpdated = vcs, n
+	case static.Subtype:
+		cs, err := toStorageStaticStore(projId, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		cs.PublicId = id
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		scs, n, err := repo.UpdateCredentialStore(ctx, cs, item.GetVersion(), dbMask)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential store"))
+		}
+		out, rowsUpdated = scs, n
 	}
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Credential Store %q doesn't exist or incorrect version provided.", id)
@@ -403,11 +534,22 @@ func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []str
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	const op = "credentialstores.(Service).deleteFromRepo"
-	repo, err := s.repoFn()
-	if err != nil {
-		return false, err
+	var rows int
+	var err error
+	switch credential.SubtypeFromId(id) {
+	case vault.Subtype:
+		repo, rErr := s.repoFn()
+		if rErr != nil {
+			return false, rErr
+		}
+		rows, err = repo.DeleteCredentialStore(ctx, id)
+	case static.Subtype:
+		repo, rErr := s.staticRepoFn()
+		if rErr != nil {
+			return false, rErr
+		}
+		rows, err = repo.DeleteCredentialStore(ctx, id)
 	}
-	rows, err := repo.DeleteCredentialStore(ctx, id)
 	if err != nil {
 		if errors.IsNotFoundError(err) {
 			return false, nil
@@ -424,11 +566,6 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 		res.Error = err
 		return res
 	}
-	repo, err := s.repoFn()
-	if err != nil {
-		res.Error = err
-		return res
-	}
 
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.CredentialStore), auth.WithAction(a)}
@@ -445,7 +582,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 	default:
-		cs, err := repo.LookupCredentialStore(ctx, id)
+		cs, err := s.lookupInRepo(ctx, id)
 		if err != nil {
 			res.Error = err
 			return res
@@ -455,7 +592,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 		parentId = cs.GetScopeId()
-		opts = append(opts, auth.WithId(id))
+		opts = append(opts, auth.WithId(id), auth.WithName(cs.GetName()))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	return auth.Verify(ctx, opts...)
@@ -601,13 +738,40 @@ func toStorageVaultStore(scopeId string, in *pb.CredentialStore) (out *vault.Cre
 	return cs, err
 }
 
+func toStorageStaticStore(scopeId string, in *pb.CredentialStore) (out *static.CredentialStore, err error) {
+	const op = "credentialstores.toStorageStaticStore"
+	var opts []static.Option
+	if in.GetName() != nil {
+		opts = append(opts, static.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	cs, err := static.NewCredentialStore(scopeId, opts...)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to build credential store for creation"))
+	}
+	return cs, err
+}
+
+// collectionTypeMap returns the collections which are part of the
+// credential store with the id: credential libraries for vault stores and
+// credentials for static stores.
+func collectionTypeMap(id string) map[resource.Type]action.ActionSet {
+	if credential.SubtypeFromId(id) == static.Subtype {
+		return staticCollectionTypeMap
+	}
+	return vaultCollectionTypeMap
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
 //  * All required parameters are set
 //  * There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetCredentialStoreRequest) error {
-	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, vault.CredentialStorePrefix)
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, vault.CredentialStorePrefix, static.CredentialStorePrefix)
 }
 
 func validateCreateRequest(req *pbs.CreateCredentialStoreRequest) error {
@@ -649,6 +813,10 @@ func validateCreateRequest(req *pbs.CreateCredentialStoreRequest) error {
 			if len(cs) > 0 && pk == nil {
 				badFields[clientCertField] = "Cannot set a client certificate without a private key."
 			}
+		case static.Subtype:
+			if len(req.GetItem().GetAttributes().GetFields()) > 0 {
+				badFields[globals.AttributesField] = "Static credential stores have no attributes."
+			}
 		default:
 			badFields[globals.TypeField] = "This is a required field and must be a known credential store type."
 		}
@@ -691,13 +859,20 @@ func validateUpdateRequest(req *pbs.UpdateCredentialStoreRequest) error {
 			if err != nil {
 				badFields[clientCertField] = fmt.Sprintf("Invalid values: %q", err.Error())
 			}
+		case static.Subtype:
+			if req.GetItem().GetType() != "" && credential.SubtypeFromType(req.GetItem().GetType()) != static.Subtype {
+				badFields["type"] = "Cannot modify resource type."
+			}
+			if len(req.GetItem().GetAttributes().GetFields()) > 0 {
+				badFields[globals.AttributesField] = "Static credential stores have no attributes."
+			}
 		}
 		return badFields
-	}, vault.CredentialStorePrefix)
+	}, vault.CredentialStorePrefix, static.CredentialStorePrefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteCredentialStoreRequest) error {
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, vault.CredentialStorePrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, vault.CredentialStorePrefix, static.CredentialStorePrefix)
 }
 
 func validateListRequest(req *pbs.ListCredentialStoresRequest) error {
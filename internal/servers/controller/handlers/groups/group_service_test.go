@@ -8,9 +8,13 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/groups"
@@ -208,6 +212,56 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetNameCondition(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	allowed := iam.TestGroup(t, conn, o.GetPublicId(), iam.WithName("allowed"))
+	restricted := iam.TestGroup(t, conn, o.GetPublicId(), iam.WithName("restricted"))
+
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	r := iam.TestRole(t, conn, o.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=group;actions=read,list")
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), `effect=deny;id=*;type=group;actions=read;condition="/resource/name" == "restricted"`)
+
+	s, err := groups.NewService(repoFn)
+	require.NoError(t, err, "Couldn't create new group service.")
+	ctx := auth.NewVerifierContext(requests.NewRequestContext(context.Background()), repoFn, atRepoFn, serversRepoFn, kms, auth.RequestInfo{
+		TokenFormat: auth.AuthTokenTypeBearer,
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	})
+
+	got, err := s.GetGroup(ctx, &pbs.GetGroupRequest{Id: allowed.GetPublicId()})
+	require.NoError(t, err)
+	assert.Equal(t, allowed.GetPublicId(), got.GetItem().GetId())
+
+	_, err = s.GetGroup(ctx, &pbs.GetGroupRequest{Id: restricted.GetPublicId()})
+	assert.True(t, errors.Is(err, handlers.ForbiddenError()), "got error %v, wanted forbidden", err)
+
+	list, err := s.ListGroups(ctx, &pbs.ListGroupsRequest{ScopeId: o.GetPublicId()})
+	require.NoError(t, err)
+	var gotIds []string
+	for _, item := range list.GetItems() {
+		gotIds = append(gotIds, item.GetId())
+	}
+	assert.ElementsMatch(t, []string{allowed.GetPublicId()}, gotIds)
+}
+
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
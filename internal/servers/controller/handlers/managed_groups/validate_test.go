@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
@@ -73,6 +74,25 @@ func TestValidateCreateRequest(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "missing ldap group names",
+			item: &pb.ManagedGroup{
+				Type:         ldap.Subtype.String(),
+				AuthMethodId: ldap.AuthMethodPrefix + "_1234567890",
+				Attributes:   &structpb.Struct{Fields: map[string]*structpb.Value{}},
+			},
+			errContains: fieldError(attrGroupNamesField, "This field is required."),
+		},
+		{
+			name: "no ldap errors",
+			item: &pb.ManagedGroup{
+				Type:         ldap.Subtype.String(),
+				AuthMethodId: ldap.AuthMethodPrefix + "_1234567890",
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"group_names": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("admins")}}),
+				}},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
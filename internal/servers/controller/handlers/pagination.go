@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/gen/controller/tokens"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mr-tron/base58"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxPageSize is the largest number of items returned in a single page of a
+// paginated list request. Larger page sizes are lowered to MaxPageSize.
+const MaxPageSize = 1000
+
+// ListTokenWrapperFn returns the wrapper used to encrypt and decrypt the list
+// tokens of paginated list requests.
+type ListTokenWrapperFn func(context.Context) (wrapping.Wrapper, error)
+
+// Paginator builds a page of a list request out of the items returned by one
+// or more listings from a repository. Items must be listed ordered by their
+// public id, starting after StartAfter, in batches of BatchSize items.
+//
+// For every listed item Admit must be called before deciding whether the item
+// is part of the page, and Include must be called for every item which is
+// added to the page. Once a batch has been handled Next reports whether
+// another batch must be listed. The list token for the next page is returned
+// by ListToken.
+//
+// A list request without a page size and list token is not paginated, in
+// which case every item is admitted and only a single listing is done.
+type Paginator struct {
+	wrapperFn    ListTokenWrapperFn
+	resourceType resource.Type
+	requestHash  []byte
+	pageSize     int
+	startAfter   string
+	included     int
+	more         bool
+}
+
+// NewPaginator returns a Paginator for the page size and list token of a list
+// request for resources of type typ. The reqParams must contain every request
+// parameter which influences the items being listed, e.g. the scope id and
+// the filter. A list token is only accepted for a request with the same
+// resource type and request parameters as the request it was handed out for.
+func NewPaginator(ctx context.Context, wrapperFn ListTokenWrapperFn, typ resource.Type, pageSize uint32, listToken string, reqParams ...string) (*Paginator, error) {
+	const op = "handlers.NewPaginator"
+	if wrapperFn == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing list token wrapper function")
+	}
+	p := &Paginator{
+		wrapperFn:    wrapperFn,
+		resourceType: typ,
+		requestHash:  hashRequestParams(reqParams...),
+		pageSize:     int(pageSize),
+	}
+	if listToken != "" {
+		info, err := p.decodeListToken(ctx, listToken)
+		if err != nil {
+			return nil, err
+		}
+		p.startAfter = info.GetLastItemId()
+		if p.pageSize == 0 {
+			p.pageSize = int(info.GetPageSize())
+		}
+	}
+	if p.pageSize > MaxPageSize {
+		p.pageSize = MaxPageSize
+	}
+	return p, nil
+}
+
+// Enabled reports whether the list request is paginated. The repository
+// listings of a paginated request must be ordered and limited using
+// StartAfter and BatchSize.
+func (p *Paginator) Enabled() bool {
+	return p.pageSize > 0
+}
+
+// StartAfter returns the public id of the item after which the next batch of
+// items must start. An empty string is returned for the first batch of the
+// first page.
+func (p *Paginator) StartAfter() string {
+	return p.startAfter
+}
+
+// BatchSize returns the number of items which must be listed in a batch.
+func (p *Paginator) BatchSize() int {
+	return p.pageSize
+}
+
+// Admit records that the item with publicId is being considered for the page.
+// It returns false if the page is already full, in which case neither the item
+// nor the remaining items of the batch may be considered.
+func (p *Paginator) Admit(publicId string) bool {
+	if p.Enabled() && p.included >= p.pageSize {
+		p.more = true
+		return false
+	}
+	p.startAfter = publicId
+	return true
+}
+
+// Include records that the last admitted item has been added to the page.
+func (p *Paginator) Include() {
+	p.included++
+}
+
+// Next reports whether another batch of items must be listed to fill the
+// page, given the number of items which were returned for the last batch.
+func (p *Paginator) Next(listed int) bool {
+	return p.Enabled() && !p.more && listed >= p.pageSize
+}
+
+// ListToken returns the list token for the next page. An empty string is
+// returned if there are no more pages.
+func (p *Paginator) ListToken(ctx context.Context) (string, error) {
+	const op = "handlers.(Paginator).ListToken"
+	if !p.more {
+		return "", nil
+	}
+	marshaledInfo, err := proto.Marshal(&tokens.ListTokenInfo{
+		ResourceType: p.resourceType.String(),
+		RequestHash:  p.requestHash,
+		LastItemId:   p.startAfter,
+		PageSize:     uint32(p.pageSize),
+	})
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	wrapper, err := p.wrapperFn(ctx)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	blobInfo, err := wrapper.Encrypt(ctx, marshaledInfo, []byte(p.resourceType.String()))
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	marshaledBlob, err := proto.Marshal(blobInfo)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	return base58.FastBase58Encoding(marshaledBlob), nil
+}
+
+func (p *Paginator) decodeListToken(ctx context.Context, listToken string) (*tokens.ListTokenInfo, error) {
+	const op = "handlers.(Paginator).decodeListToken"
+	invalidToken := InvalidArgumentErrorf("Invalid list token.", map[string]string{"list_token": "The list token is malformed or was not issued for this request."})
+	marshaledBlob, err := base58.FastBase58Decoding(listToken)
+	if err != nil {
+		return nil, invalidToken
+	}
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(marshaledBlob, blobInfo); err != nil {
+		return nil, invalidToken
+	}
+	wrapper, err := p.wrapperFn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	marshaledInfo, err := wrapper.Decrypt(ctx, blobInfo, []byte(p.resourceType.String()))
+	if err != nil {
+		return nil, invalidToken
+	}
+	info := new(tokens.ListTokenInfo)
+	if err := proto.Unmarshal(marshaledInfo, info); err != nil {
+		return nil, invalidToken
+	}
+	if info.GetResourceType() != p.resourceType.String() || !bytes.Equal(info.GetRequestHash(), p.requestHash) {
+		return nil, invalidToken
+	}
+	return info, nil
+}
+
+func hashRequestParams(reqParams ...string) []byte {
+	h := sha256.New()
+	for _, p := range reqParams {
+		_, _ = h.Write([]byte(p))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginator(t *testing.T) {
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	wrapperFn := func(context.Context) (wrapping.Wrapper, error) { return wrapper, nil }
+
+	// listPage simulates a handler filling a single page from a sorted list of
+	// ids where only the ids accepted by match are added to the page.
+	listPage := func(t *testing.T, pg *Paginator, ids []string, match func(string) bool) []string {
+		t.Helper()
+		var page []string
+		for {
+			var batch []string
+			for _, id := range ids {
+				if pg.Enabled() && id <= pg.StartAfter() {
+					continue
+				}
+				batch = append(batch, id)
+				if pg.Enabled() && len(batch) == pg.BatchSize() {
+					break
+				}
+			}
+			for _, id := range batch {
+				if !pg.Admit(id) {
+					break
+				}
+				if match(id) {
+					page = append(page, id)
+					pg.Include()
+				}
+			}
+			if !pg.Next(len(batch)) {
+				break
+			}
+		}
+		return page
+	}
+	ids := []string{"id_1", "id_2", "id_3", "id_4", "id_5", "id_6", "id_7"}
+	all := func(string) bool { return true }
+
+	t.Run("not-paginated", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pg, err := NewPaginator(ctx, wrapperFn, resource.Target, 0, "", "p_1")
+		require.NoError(err)
+		assert.False(pg.Enabled())
+		assert.Equal(ids, listPage(t, pg, ids, all))
+		token, err := pg.ListToken(ctx)
+		require.NoError(err)
+		assert.Empty(token)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pg, err := NewPaginator(ctx, wrapperFn, resource.Target, 3, "", "p_1")
+		require.NoError(err)
+		assert.Equal([]string{"id_1", "id_2", "id_3"}, listPage(t, pg, ids, all))
+		token, err := pg.ListToken(ctx)
+		require.NoError(err)
+		require.NotEmpty(token)
+
+		// The page size is taken from the list token when not provided.
+		pg, err = NewPaginator(ctx, wrapperFn, resource.Target, 0, token, "p_1")
+		require.NoError(err)
+		assert.Equal([]string{"id_4", "id_5", "id_6"}, listPage(t, pg, ids, all))
+		token, err = pg.ListToken(ctx)
+		require.NoError(err)
+		require.NotEmpty(token)
+
+		pg, err = NewPaginator(ctx, wrapperFn, resource.Target, 0, token, "p_1")
+		require.NoError(err)
+		assert.Equal([]string{"id_7"}, listPage(t, pg, ids, all))
+		token, err = pg.ListToken(ctx)
+		require.NoError(err)
+		assert.Empty(token)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		odd := func(id string) bool { return (id[len(id)-1]-'0')%2 == 1 }
+		pg, err := NewPaginator(ctx, wrapperFn, resource.Target, 2, "", "p_1")
+		require.NoError(err)
+		assert.Equal([]string{"id_1", "id_3"}, listPage(t, pg, ids, odd))
+		token, err := pg.ListToken(ctx)
+		require.NoError(err)
+		require.NotEmpty(token)
+
+		pg, err = NewPaginator(ctx, wrapperFn, resource.Target, 2, token, "p_1")
+		require.NoError(err)
+		assert.Equal([]string{"id_5", "id_7"}, listPage(t, pg, ids, odd))
+	})
+
+	t.Run("max-page-size", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pg, err := NewPaginator(ctx, wrapperFn, resource.Target, MaxPageSize+1, "", "p_1")
+		require.NoError(err)
+		assert.Equal(MaxPageSize, pg.BatchSize())
+	})
+
+	t.Run("invalid-token", func(t *testing.T) {
+		pg, err := NewPaginator(ctx, wrapperFn, resource.Target, 1, "", "p_1")
+		require.NoError(t, err)
+		listPage(t, pg, ids, all)
+		token, err := pg.ListToken(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+
+		tests := []struct {
+			name      string
+			typ       resource.Type
+			token     string
+			reqParams []string
+		}{
+			{name: "malformed", typ: resource.Target, token: "not-a-token", reqParams: []string{"p_1"}},
+			{name: "other-resource-type", typ: resource.Session, token: token, reqParams: []string{"p_1"}},
+			{name: "other-request", typ: resource.Target, token: token, reqParams: []string{"p_2"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := NewPaginator(ctx, wrapperFn, tt.typ, 0, tt.token, tt.reqParams...)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "Invalid list token.")
+			})
+		}
+	})
+
+	t.Run("missing-wrapper-fn", func(t *testing.T) {
+		_, err := NewPaginator(ctx, nil, resource.Target, 1, "")
+		require.Error(t, err)
+	})
+}
@@ -3,19 +3,34 @@ package roles
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/intglobals"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -48,6 +63,7 @@ var (
 	CollectionActions = action.ActionSet{
 		action.Create,
 		action.List,
+		action.Explain,
 	}
 )
 
@@ -105,50 +121,65 @@ func (s Service) ListRoles(ctx context.Context, req *pbs.ListRolesRequest) (*pbs
 		return &pbs.ListRolesResponse{}, nil
 	}
 
-	items, err := s.listFromRepo(ctx, scopeIds)
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.Role, req.GetPageSize(), req.GetListToken(),
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(items) == 0 {
-		return &pbs.ListRolesResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Role, 0, len(items))
+	var finalItems []*pb.Role
 	res := perms.Resource{
 		Type: resource.Role,
 	}
-	for _, item := range items {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetScopeId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
-		if len(authorizedActions) == 0 {
-			continue
-		}
-
-		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
-		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
-		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
-		}
-		if outputFields.Has(globals.AuthorizedActionsField) {
-			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
-		}
-
-		item, err := toProto(ctx, item, nil, nil, outputOpts...)
+	for {
+		items, err := s.listFromRepo(ctx, scopeIds, pg)
 		if err != nil {
 			return nil, err
 		}
+		for _, item := range items {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.ScopeId = item.GetScopeId()
+			res.Name = item.GetName()
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+			}
+
+			item, err := toProto(ctx, item, nil, nil, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
+		}
+		if !pg.Next(len(items)) {
+			break
 		}
 	}
-	return &pbs.ListRolesResponse{Items: finalItems}, nil
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListRolesResponse{Items: finalItems, ListToken: listToken}, nil
 }
 
 // GetRoles implements the interface pbs.RoleServiceServer.
@@ -509,6 +540,22 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	return &pbs.RemoveRoleGrantsResponse{Item: item}, nil
 }
 
+// ExplainRoles implements the interface pbs.RoleServiceServer.
+func (s Service) ExplainRoles(ctx context.Context, req *pbs.ExplainRolesRequest) (*pbs.ExplainRolesResponse, error) {
+	if err := validateExplainRolesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.Explain)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	item, err := s.explainFromRepo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ExplainRolesResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -610,12 +657,16 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Role, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, pg *handlers.Paginator) ([]*iam.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	rl, err := repo.ListRoles(ctx, scopeIds)
+	var opts []iam.Option
+	if pg.Enabled() {
+		opts = append(opts, iam.WithStartPageAfterItem(pg.StartAfter()), iam.WithLimit(pg.BatchSize()))
+	}
+	rl, err := repo.ListRoles(ctx, scopeIds, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -752,6 +803,124 @@ func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants [
 	return out, pr, roleGrants, nil
 }
 
+// explainFromRepo builds the ACL of the user from the grants of their roles, the
+// same way it is built when authorizing a request, and explains the action on
+// the resource using it.
+func (s Service) explainFromRepo(ctx context.Context, req *pbs.ExplainRolesRequest) (*pb.Explanation, error) {
+	const op = "roles.(Service).explainFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	u, _, err := repo.LookupUser(ctx, req.GetUserId())
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, err
+	}
+	if u == nil {
+		return nil, handlers.NotFoundErrorf("User %q doesn't exist.", req.GetUserId())
+	}
+	grantTuples, err := repo.GrantsForUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]perms.Grant, 0, len(grantTuples))
+	for _, pair := range grantTuples {
+		parsed, err := perms.Parse(
+			pair.ScopeId,
+			pair.Grant,
+			perms.WithUserId(req.GetUserId()),
+			perms.WithRoleId(pair.RoleId),
+			perms.WithSkipFinalValidation(true))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", pair.Grant)))
+		}
+		grants = append(grants, parsed)
+	}
+
+	res, err := explainedResource(ctx, repo, req)
+	if err != nil {
+		return nil, err
+	}
+	act := action.Map[req.GetAction()]
+	exp := perms.NewACL(grants...).Explain(res, act, perms.WithRequest(perms.Request{ClientIp: req.GetClientIp()}))
+
+	out := &pb.Explanation{
+		UserId:            req.GetUserId(),
+		ScopeId:           res.ScopeId,
+		ResourceId:        res.Id,
+		ResourceType:      res.Type.String(),
+		Pin:               res.Pin,
+		Action:            act.String(),
+		Authorized:        exp.Authorized,
+		Denied:            exp.Denied(),
+		ConsultedScopeIds: exp.ScopeIds,
+	}
+	if exp.Authorized {
+		out.OutputFields = exp.OutputFields.SelfOrDefaults(req.GetUserId()).Fields()
+	}
+	roleIds := make(map[string]bool, len(exp.Grants))
+	for _, g := range exp.Grants {
+		out.Grants = append(out.Grants, &pb.ExplainedGrant{
+			RoleId:    g.RoleId(),
+			ScopeId:   g.ScopeId(),
+			Canonical: g.CanonicalString(),
+			Effect:    string(g.Effect()),
+		})
+		if !roleIds[g.RoleId()] {
+			roleIds[g.RoleId()] = true
+			out.RoleIds = append(out.RoleIds, g.RoleId())
+		}
+	}
+	sort.Strings(out.RoleIds)
+	return out, nil
+}
+
+// explainedResource returns the resource to explain with the scope, name and
+// pin it has in the repository rather than the values of the request, so the
+// explanation matches the authorization of an actual request for it. The
+// resource, or the resource containing the collection, must be in the scope
+// of the request, since the caller is only authorized to explain the grants
+// of that scope.
+func explainedResource(ctx context.Context, repo *iam.Repository, req *pbs.ExplainRolesRequest) (perms.Resource, error) {
+	res := perms.Resource{
+		ScopeId: req.GetScopeId(),
+		Id:      req.GetResourceId(),
+		Type:    resource.Map[req.GetResourceType()],
+	}
+	if res.Type == resource.Unknown {
+		res.Type = resourceTypeFromId(req.GetResourceId())
+	}
+
+	lookup := func(typ resource.Type, id string) (*iam.ResourceInfo, error) {
+		info, err := repo.LookupResourceInfo(ctx, typ, id)
+		switch {
+		case errors.Match(errors.T(errors.InvalidParameter), err):
+			return nil, handlers.InvalidArgumentErrorf("Errors in provided fields.",
+				map[string]string{"resource_type": fmt.Sprintf("Resources of type %q can't be explained.", typ.String())})
+		case err != nil:
+			return nil, err
+		case info == nil || info.ScopeId != req.GetScopeId():
+			return nil, handlers.NotFoundErrorf("Resource %q doesn't exist in scope %q.", id, req.GetScopeId())
+		}
+		return info, nil
+	}
+	switch {
+	case res.Id != "":
+		info, err := lookup(res.Type, res.Id)
+		if err != nil {
+			return perms.Resource{}, err
+		}
+		res.Name = info.Name
+		res.Pin = info.Pin
+	case req.GetPin() != "":
+		if _, err := lookup(resourceTypeFromId(req.GetPin()), req.GetPin()); err != nil {
+			return perms.Resource{}, err
+		}
+		res.Pin = req.GetPin()
+	}
+	return res, nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 	repo, err := s.repoFn()
@@ -763,7 +932,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Role), auth.WithAction(a)}
 	switch a {
-	case action.List, action.Create:
+	case action.List, action.Create, action.Explain:
 		parentId = id
 		scp, err := repo.LookupScope(ctx, parentId)
 		if err != nil {
@@ -785,7 +954,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 		parentId = r.GetScopeId()
-		opts = append(opts, auth.WithId(id))
+		opts = append(opts, auth.WithId(id), auth.WithName(r.GetName()))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	return auth.Verify(ctx, opts...)
@@ -866,9 +1035,11 @@ func toProto(ctx context.Context, in *iam.Role, principals []iam.PrincipalRole,
 					Raw:       g.GetRawGrant(),
 					Canonical: g.GetCanonicalGrant(),
 					Json: &pb.GrantJson{
-						Id:      parsed.Id(),
-						Type:    parsed.Type().String(),
-						Actions: actions,
+						Id:        parsed.Id(),
+						Type:      parsed.Type().String(),
+						Actions:   actions,
+						Effect:    string(parsed.Effect()),
+						Condition: parsed.Condition(),
 					},
 				})
 			}
@@ -880,9 +1051,9 @@ func toProto(ctx context.Context, in *iam.Role, principals []iam.PrincipalRole,
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetRoleRequest) error {
 	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.RolePrefix)
 }
@@ -971,7 +1142,8 @@ func validateAddRolePrincipalsRequest(req *pbs.AddRolePrincipalsRequest) error {
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.LdapManagedGroupPrefix) {
 			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
 			break
 		}
@@ -997,7 +1169,8 @@ func validateSetRolePrincipalsRequest(req *pbs.SetRolePrincipalsRequest) error {
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.LdapManagedGroupPrefix) {
 			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
 			break
 		}
@@ -1026,7 +1199,8 @@ func validateRemoveRolePrincipalsRequest(req *pbs.RemoveRolePrincipalsRequest) e
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.LdapManagedGroupPrefix) {
 			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
 			break
 		}
@@ -1114,3 +1288,89 @@ func validateRemoveRoleGrantsRequest(req *pbs.RemoveRoleGrantsRequest) error {
 	}
 	return nil
 }
+
+func validateExplainRolesRequest(req *pbs.ExplainRolesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		!handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Improperly formatted field."
+	}
+	if !handlers.ValidId(handlers.Id(req.GetUserId()), iam.UserPrefix) {
+		badFields["user_id"] = "Improperly formatted identifier."
+	}
+	switch act := action.Map[req.GetAction()]; act {
+	case action.Unknown, action.All:
+		badFields["action"] = fmt.Sprintf("Unknown action %q.", req.GetAction())
+	}
+	switch {
+	case req.GetResourceType() != "":
+		switch resource.Map[req.GetResourceType()] {
+		case resource.Unknown, resource.All:
+			badFields["resource_type"] = fmt.Sprintf("Unknown resource type %q.", req.GetResourceType())
+		}
+	case req.GetResourceId() == "":
+		badFields["resource_type"] = "This field is required if resource_id is not set."
+	case resourceTypeFromId(req.GetResourceId()) == resource.Unknown:
+		badFields["resource_type"] = "The resource type can't be determined from resource_id and must be set."
+	}
+	if req.GetPin() != "" && resourceTypeFromId(req.GetPin()) == resource.Unknown {
+		badFields["pin"] = "Improperly formatted identifier."
+	}
+	if req.GetClientIp() != "" && net.ParseIP(req.GetClientIp()) == nil {
+		badFields["client_ip"] = "Improperly formatted IP address."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+// idPrefixTypes maps the prefixes of resource IDs to the resource type.
+var idPrefixTypes = map[string]resource.Type{
+	scope.Org.Prefix():                          resource.Scope,
+	scope.Project.Prefix():                      resource.Scope,
+	iam.UserPrefix:                              resource.User,
+	iam.GroupPrefix:                             resource.Group,
+	iam.RolePrefix:                              resource.Role,
+	authtoken.AuthTokenPrefix:                   resource.AuthToken,
+	password.AuthMethodPrefix:                   resource.AuthMethod,
+	oidc.AuthMethodPrefix:                       resource.AuthMethod,
+	ldap.AuthMethodPrefix:                       resource.AuthMethod,
+	intglobals.OldPasswordAccountPrefix:         resource.Account,
+	intglobals.NewPasswordAccountPrefix:         resource.Account,
+	oidc.AccountPrefix:                          resource.Account,
+	ldap.AccountPrefix:                          resource.Account,
+	intglobals.OidcManagedGroupPrefix:           resource.ManagedGroup,
+	intglobals.LdapManagedGroupPrefix:           resource.ManagedGroup,
+	static.HostCatalogPrefix:                    resource.HostCatalog,
+	static.HostSetPrefix:                        resource.HostSet,
+	static.HostPrefix:                           resource.Host,
+	plugin.HostCatalogPrefix:                    resource.HostCatalog,
+	plugin.HostSetPrefix:                        resource.HostSet,
+	plugin.HostPrefix:                           resource.Host,
+	target.TcpTargetPrefix:                      resource.Target,
+	target.SshTargetPrefix:                      resource.Target,
+	target.UdpTargetPrefix:                      resource.Target,
+	session.SessionPrefix:                       resource.Session,
+	session.PolicyPrefix:                        resource.SessionPolicy,
+	vault.CredentialStorePrefix:                 resource.CredentialStore,
+	vault.CredentialLibraryPrefix:               resource.CredentialLibrary,
+	credstatic.CredentialStorePrefix:            resource.CredentialStore,
+	credstatic.UsernamePasswordCredentialPrefix: resource.Credential,
+	credstatic.SshPrivateKeyCredentialPrefix:    resource.Credential,
+	servers.WorkerPrefix:                        resource.Worker,
+}
+
+// resourceTypeFromId returns the type of the resource with the ID, based on the
+// prefix of the ID, or resource.Unknown if it can't be determined.
+func resourceTypeFromId(id string) resource.Type {
+	if id == scope.Global.String() {
+		return resource.Scope
+	}
+	i := strings.Index(id, "_")
+	if i < 1 {
+		return resource.Unknown
+	}
+	return idPrefixTypes[id[:i]]
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -2030,3 +2031,178 @@ func TestRemoveGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestExplain(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	o, p := iam.TestScopes(t, iamRepo)
+	tar := target.TestTcpTarget(t, conn, p.GetPublicId(), "restricted")
+	u := iam.TestUser(t, iamRepo, o.GetPublicId())
+	allowRole := iam.TestRole(t, conn, p.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, allowRole.GetPublicId(), "id=*;type=target;actions=read,authorize-session")
+	_ = iam.TestUserRole(t, conn, allowRole.GetPublicId(), u.GetPublicId())
+	denyRole := iam.TestRole(t, conn, p.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, denyRole.GetPublicId(), fmt.Sprintf("effect=deny;id=%s;actions=authorize-session", tar.GetPublicId()))
+	_ = iam.TestUserRole(t, conn, denyRole.GetPublicId(), u.GetPublicId())
+	// The name of the target is looked up to evaluate the condition.
+	nameRole := iam.TestRole(t, conn, p.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, nameRole.GetPublicId(), `effect=deny;id=*;type=target;actions=update;condition="/resource/name" == "restricted"`)
+	_ = iam.TestUserRole(t, conn, nameRole.GetPublicId(), u.GetPublicId())
+
+	cases := []struct {
+		name           string
+		req            *pbs.ExplainRolesRequest
+		wantAuthorized bool
+		wantDenied     bool
+		wantRoleIds    []string
+		err            error
+	}{
+		{
+			name: "Allowed",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "read",
+			},
+			wantAuthorized: true,
+			wantRoleIds:    []string{allowRole.GetPublicId()},
+		},
+		{
+			name: "Denied",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "authorize-session",
+			},
+			wantDenied:  true,
+			wantRoleIds: []string{denyRole.GetPublicId()},
+		},
+		{
+			name: "No matching grant",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "delete",
+			},
+		},
+		{
+			name: "Name condition",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "update",
+			},
+			wantDenied:  true,
+			wantRoleIds: []string{nameRole.GetPublicId()},
+		},
+		{
+			name: "Other scope",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    o.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Unknown resource",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: "ttcp_1234567890",
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Unknown user",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     "u_1234567890",
+				ResourceId: tar.GetPublicId(),
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad scope id",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    "bad id",
+				UserId:     u.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing user id",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unknown action",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "unknown",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unknown resource type",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: "x_1234567890",
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad client ip",
+			req: &pbs.ExplainRolesRequest{
+				ScopeId:    p.GetPublicId(),
+				UserId:     u.GetPublicId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "read",
+				ClientIp:   "not an ip",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ExplainRoles(auth.DisabledAuthTestContext(repoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ExplainRoles(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			item := got.GetItem()
+			assert.Equal("target", item.GetResourceType())
+			assert.Equal(tc.wantAuthorized, item.GetAuthorized())
+			assert.Equal(tc.wantDenied, item.GetDenied())
+			assert.Equal(tc.wantRoleIds, item.GetRoleIds())
+			assert.Equal([]string{p.GetPublicId()}, item.GetConsultedScopeIds())
+		})
+	}
+}
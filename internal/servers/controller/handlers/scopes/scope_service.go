@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/boundary/globals"
@@ -13,6 +14,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
@@ -25,9 +27,11 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_catalogs"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/session_policies"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/workers"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -46,6 +50,10 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.RotateKeys,
+		action.ListKeys,
+		action.DestroyKeyVersion,
+		action.ListKeyVersionDestructionJobs,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -63,15 +71,17 @@ var (
 			resource.Role:       roles.CollectionActions,
 			resource.Scope:      CollectionActions,
 			resource.User:       users.CollectionActions,
+			resource.Worker:     workers.CollectionActions,
 		},
 
 		scope.Org.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.User:       users.CollectionActions,
+			resource.AuthMethod:    authmethods.CollectionActions,
+			resource.AuthToken:     authtokens.CollectionActions,
+			resource.Group:         groups.CollectionActions,
+			resource.Role:          roles.CollectionActions,
+			resource.Scope:         CollectionActions,
+			resource.SessionPolicy: session_policies.CollectionActions,
+			resource.User:          users.CollectionActions,
 		},
 
 		scope.Project.String(): {
@@ -80,6 +90,7 @@ var (
 			resource.HostCatalog:     host_catalogs.CollectionActions,
 			resource.Role:            roles.CollectionActions,
 			resource.Session:         sessions.CollectionActions,
+			resource.SessionPolicy:   session_policies.CollectionActions,
 			resource.Target:          targets.CollectionActions,
 		},
 	}
@@ -96,16 +107,20 @@ func init() {
 type Service struct {
 	pbs.UnimplementedScopeServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn   common.IamRepoFactory
+	kmsCache *kms.Kms
 }
 
 // NewService returns a project service which handles project related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+func NewService(repo common.IamRepoFactory, kmsCache *kms.Kms) (Service, error) {
 	const op = "scopes.(Service).NewService"
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	if kmsCache == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing kms")
+	}
+	return Service{repoFn: repo, kmsCache: kmsCache}, nil
 }
 
 var _ pbs.ScopeServiceServer = Service{}
@@ -142,59 +157,74 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		return &pbs.ListScopesResponse{}, nil
 	}
 
-	pl, err := s.listFromRepo(ctx, scopeIds)
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.Scope, req.GetPageSize(), req.GetListToken(),
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(pl) == 0 {
-		return &pbs.ListScopesResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Scope, 0, len(pl))
+	var finalItems []*pb.Scope
 	res := perms.Resource{
 		Type: resource.Scope,
 	}
-	for _, item := range pl {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetParentId()
-
-		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
-		if len(authorizedActions) == 0 {
-			continue
+	for {
+		pl, err := s.listFromRepo(ctx, scopeIds, pg)
+		if err != nil {
+			return nil, err
 		}
+		for _, item := range pl {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.ScopeId = item.GetParentId()
+			res.Name = item.GetName()
 
-		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
-		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
-		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetParentId()]))
-		}
-		if outputFields.Has(globals.AuthorizedActionsField) {
-			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
-		}
-		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
-			collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[item.Type], item.GetPublicId(), "")
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetParentId()]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+			}
+			if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+				collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[item.Type], item.GetPublicId(), "")
+				if err != nil {
+					return nil, err
+				}
+				outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
+			}
+
+			item, err := ToProto(ctx, item, outputOpts...)
 			if err != nil {
 				return nil, err
 			}
-			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
-		}
 
-		item, err := ToProto(ctx, item, outputOpts...)
-		if err != nil {
-			return nil, err
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
 		}
-
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !pg.Next(len(pl)) {
+			break
 		}
 	}
 	SortScopes(finalItems)
-	return &pbs.ListScopesResponse{Items: finalItems}, nil
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListScopesResponse{Items: finalItems, ListToken: listToken}, nil
 }
 
 // GetScopes implements the interface pbs.ScopeServiceServer.
@@ -226,7 +256,7 @@ func (s Service) GetScope(ctx context.Context, req *pbs.GetScopeRequest) (*pbs.G
 	act := IdActions
 	// Can't delete global so elide it
 	if p.GetPublicId() == "global" {
-		act = act[0:3]
+		act = append(act[0:3:3], act[4:]...)
 	}
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), act).Strings()))
@@ -353,6 +383,129 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return nil, nil
 }
 
+// RotateKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) RotateKeys(ctx context.Context, req *pbs.RotateKeysRequest) (*pbs.RotateKeysResponse, error) {
+	if err := validateKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RotateKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if err := s.kmsCache.RotateKeys(ctx, req.GetId(), kms.WithRewrap(req.GetRewrap())); err != nil {
+		return nil, err
+	}
+	return &pbs.RotateKeysResponse{}, nil
+}
+
+// ListKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) ListKeys(ctx context.Context, req *pbs.ListKeysRequest) (*pbs.ListKeysResponse, error) {
+	if err := validateKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	scopeInfo, err := s.scopeInfo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.kmsCache.ListKeys(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*pb.Key, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, toKeyProto(k, scopeInfo))
+	}
+	return &pbs.ListKeysResponse{Items: items}, nil
+}
+
+// DestroyKeyVersion implements the interface pbs.ScopeServiceServer.
+func (s Service) DestroyKeyVersion(ctx context.Context, req *pbs.DestroyKeyVersionRequest) (*pbs.DestroyKeyVersionResponse, error) {
+	if err := validateDestroyKeyVersionRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.DestroyKeyVersion)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	destroyed, err := s.kmsCache.DestroyKeyVersion(ctx, req.GetId(), req.GetKeyVersionId())
+	if err != nil {
+		return nil, err
+	}
+	state := "pending"
+	if destroyed {
+		state = "destroyed"
+	}
+	return &pbs.DestroyKeyVersionResponse{State: state}, nil
+}
+
+// ListKeyVersionDestructionJobs implements the interface pbs.ScopeServiceServer.
+func (s Service) ListKeyVersionDestructionJobs(ctx context.Context, req *pbs.ListKeyVersionDestructionJobsRequest) (*pbs.ListKeyVersionDestructionJobsResponse, error) {
+	if err := validateKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListKeyVersionDestructionJobs)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	scopeInfo, err := s.scopeInfo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.kmsCache.ListDataKeyVersionDestructionJobs(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*pb.KeyVersionDestructionJob, 0, len(jobs))
+	for _, j := range jobs {
+		items = append(items, &pb.KeyVersionDestructionJob{
+			KeyVersionId:   j.KeyId,
+			Scope:          scopeInfo,
+			Status:         j.Status(),
+			CreatedTime:    j.CreateTime.GetTimestamp(),
+			CompletedCount: j.CompletedCount,
+			TotalCount:     j.TotalCount,
+		})
+	}
+	return &pbs.ListKeyVersionDestructionJobsResponse{Items: items}, nil
+}
+
+// scopeInfo returns the scope information of the scope id, used as the
+// scope of its keys.
+func (s Service) scopeInfo(ctx context.Context, id string) (*pb.ScopeInfo, error) {
+	scp, err := s.getFromRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ScopeInfo{
+		Id:            scp.GetPublicId(),
+		Type:          scp.GetType(),
+		Name:          scp.GetName(),
+		Description:   scp.GetDescription(),
+		ParentScopeId: scp.GetParentId(),
+	}, nil
+}
+
+func toKeyProto(in *kms.Key, scopeInfo *pb.ScopeInfo) *pb.Key {
+	out := &pb.Key{
+		Id:          in.Id,
+		Scope:       scopeInfo,
+		Purpose:     in.Purpose,
+		CreatedTime: in.CreateTime.GetTimestamp(),
+	}
+	for _, v := range in.Versions {
+		out.Versions = append(out.Versions, &pb.KeyVersion{
+			Id:          v.Id,
+			Version:     v.Version,
+			CreatedTime: v.CreateTime.GetTimestamp(),
+		})
+	}
+	return out
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -488,12 +641,16 @@ func SortScopes(scps []*pb.Scope) {
 	})
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Scope, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, pg *handlers.Paginator) ([]*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	scps, err := repo.ListScopes(ctx, scopeIds)
+	var opts []iam.Option
+	if pg.Enabled() {
+		opts = append(opts, iam.WithStartPageAfterItem(pg.StartAfter()), iam.WithLimit(pg.BatchSize()))
+	}
+	scps, err := repo.ListScopes(ctx, scopeIds, opts...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to list scopes: %v", err)
 	}
@@ -533,7 +690,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 		parentId = s.GetParentId()
-		opts = append(opts, auth.WithId(id))
+		opts = append(opts, auth.WithId(id), auth.WithName(s.GetName()))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	return auth.Verify(ctx, opts...)
@@ -589,9 +746,9 @@ func ToProto(ctx context.Context, in *iam.Scope, opt ...handlers.Option) (*pb.Sc
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetScopeRequest) error {
 	badFields := map[string]string{}
 	id := req.GetId()
@@ -730,6 +887,45 @@ func validateDeleteRequest(req *pbs.DeleteScopeRequest) error {
 	return nil
 }
 
+func validateKeysRequest(req interface{ GetId() string }) error {
+	badFields := map[string]string{}
+	validateScopeId(req.GetId(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateDestroyKeyVersionRequest(req *pbs.DestroyKeyVersionRequest) error {
+	badFields := map[string]string{}
+	validateScopeId(req.GetId(), badFields)
+	if req.GetKeyVersionId() == "" {
+		badFields["key_version_id"] = "This field is required."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+// validateScopeId adds an error to badFields if id is not the global scope or
+// a validly formatted org or project scope id.
+func validateScopeId(id string, badFields map[string]string) {
+	switch {
+	case id == scope.Global.String():
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		if !handlers.ValidId(handlers.Id(id), scope.Org.Prefix()) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	case strings.HasPrefix(id, scope.Project.Prefix()):
+		if !handlers.ValidId(handlers.Id(id), scope.Project.Prefix()) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	default:
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+}
+
 func validateListRequest(req *pbs.ListScopesRequest) error {
 	badFields := map[string]string{}
 	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) {
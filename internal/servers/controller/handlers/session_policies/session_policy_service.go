@@ -0,0 +1,553 @@
+package session_policies
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/session/store"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessionpolicies"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.Policy{}}, handlers.MaskSource{&pb.SessionPolicy{}}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.SessionPolicyServiceServer interface.
+type Service struct {
+	pbs.UnimplementedSessionPolicyServiceServer
+
+	iamRepoFn     common.IamRepoFactory
+	sessionRepoFn common.SessionRepoFactory
+}
+
+// NewService returns a session policy service which handles session policy related requests to boundary.
+func NewService(iamRepo common.IamRepoFactory, sessionRepo common.SessionRepoFactory) (Service, error) {
+	const op = "session_policies.NewService"
+	if iamRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	if sessionRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing session repository")
+	}
+	return Service{iamRepoFn: iamRepo, sessionRepoFn: sessionRepo}, nil
+}
+
+var _ pbs.SessionPolicyServiceServer = Service{}
+
+// ListSessionPolicies implements the interface pbs.SessionPolicyServiceServer.
+func (s Service) ListSessionPolicies(ctx context.Context, req *pbs.ListSessionPoliciesRequest) (*pbs.ListSessionPoliciesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.SessionPolicy, req.GetRecursive(), false)
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListSessionPoliciesResponse{}, nil
+	}
+
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.SessionPolicy, req.GetPageSize(), req.GetListToken(),
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	var finalItems []*pb.SessionPolicy
+	res := perms.Resource{
+		Type: resource.SessionPolicy,
+	}
+	for {
+		pl, err := s.listFromRepo(ctx, scopeIds, pg)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range pl {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.ScopeId = item.GetScopeId()
+			res.Name = item.GetName()
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+			}
+
+			item, err := toProto(ctx, item, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
+		}
+		if !pg.Next(len(pl)) {
+			break
+		}
+	}
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListSessionPoliciesResponse{Items: finalItems, ListToken: listToken}, nil
+}
+
+// GetSessionPolicy implements the interface pbs.SessionPolicyServiceServer.
+func (s Service) GetSessionPolicy(ctx context.Context, req *pbs.GetSessionPolicyRequest) (*pbs.GetSessionPolicyResponse, error) {
+	const op = "session_policies.(Service).GetSessionPolicy"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, p, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetSessionPolicyResponse{Item: item}, nil
+}
+
+// CreateSessionPolicy implements the interface pbs.SessionPolicyServiceServer.
+func (s Service) CreateSessionPolicy(ctx context.Context, req *pbs.CreateSessionPolicyRequest) (*pbs.CreateSessionPolicyResponse, error) {
+	const op = "session_policies.(Service).CreateSessionPolicy"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, p, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateSessionPolicyResponse{Item: item, Uri: fmt.Sprintf("session-policies/%s", item.GetId())}, nil
+}
+
+// UpdateSessionPolicy implements the interface pbs.SessionPolicyServiceServer.
+func (s Service) UpdateSessionPolicy(ctx context.Context, req *pbs.UpdateSessionPolicyRequest) (*pbs.UpdateSessionPolicyResponse, error) {
+	const op = "session_policies.(Service).UpdateSessionPolicy"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, p, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateSessionPolicyResponse{Item: item}, nil
+}
+
+// DeleteSessionPolicy implements the interface pbs.SessionPolicyServiceServer.
+func (s Service) DeleteSessionPolicy(ctx context.Context, req *pbs.DeleteSessionPolicyRequest) (*pbs.DeleteSessionPolicyResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*session.Policy, error) {
+	const op = "session_policies.(Service).getFromRepo"
+	repo, err := s.sessionRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	p, err := repo.LookupPolicy(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if p == nil {
+		return nil, handlers.NotFoundErrorf("Session policy %q doesn't exist.", id)
+	}
+	return p, nil
+}
+
+func toStoragePolicy(scopeId string, item *pb.SessionPolicy) (*session.Policy, error) {
+	var opts []session.Option
+	if item.GetName() != nil {
+		opts = append(opts, session.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, session.WithDescription(item.GetDescription().GetValue()))
+	}
+	if item.GetMaxActiveSessionsPerUser() != nil {
+		opts = append(opts, session.WithMaxActiveSessionsPerUser(item.GetMaxActiveSessionsPerUser().GetValue()))
+	}
+	if item.GetMaxDailySessionSecondsPerUser() != nil {
+		opts = append(opts, session.WithMaxDailySessionSecondsPerUser(item.GetMaxDailySessionSecondsPerUser().GetValue()))
+	}
+	return session.NewPolicy(scopeId, opts...)
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.SessionPolicy) (*session.Policy, error) {
+	const op = "session_policies.(Service).createInRepo"
+	p, err := toStoragePolicy(scopeId, item)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build session policy for creation: %v.", err)
+	}
+	repo, err := s.sessionRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreatePolicy(ctx, p)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create session policy but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.SessionPolicy) (*session.Policy, error) {
+	const op = "session_policies.(Service).updateInRepo"
+	p, err := toStoragePolicy(scopeId, item)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build session policy for update: %v.", err)
+	}
+	version := item.GetVersion()
+	p.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.sessionRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdatePolicy(ctx, p, version, dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Session policy %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "session_policies.(Service).deleteFromRepo"
+	repo, err := s.sessionRepoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeletePolicy(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete session policy"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, pg *handlers.Paginator) ([]*session.Policy, error) {
+	const op = "session_policies.(Service).listFromRepo"
+	repo, err := s.sessionRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var opts []session.Option
+	if pg.Enabled() {
+		opts = append(opts, session.WithStartPageAfterItem(pg.StartAfter()), session.WithLimit(pg.BatchSize()))
+	}
+	pl, err := repo.ListPolicies(ctx, scopeIds, opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return pl, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.SessionPolicy), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.sessionRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		p, err := repo.LookupPolicy(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if p == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = p.GetScopeId()
+		opts = append(opts, auth.WithId(id), auth.WithName(p.GetName()))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *session.Policy, opt ...handlers.Option) (*pb.SessionPolicy, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building session policy proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.SessionPolicy{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.MaxActiveSessionsPerUserField) && in.GetMaxActiveSessionsPerUser() != 0 {
+		out.MaxActiveSessionsPerUser = wrapperspb.UInt32(in.GetMaxActiveSessionsPerUser())
+	}
+	if outputFields.Has(globals.MaxDailySessionSecondsPerUserField) && in.GetMaxDailySessionSecondsPerUser() != 0 {
+		out.MaxDailySessionSecondsPerUser = wrapperspb.UInt32(in.GetMaxDailySessionSecondsPerUser())
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetSessionPolicyRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, session.PolicyPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateSessionPolicyRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := validateLimits(req.GetItem())
+		if !handlers.ValidId(handlers.Id(req.GetItem().GetScopeId()), scope.Org.Prefix()) &&
+			!handlers.ValidId(handlers.Id(req.GetItem().GetScopeId()), scope.Project.Prefix()) {
+			badFields[globals.ScopeIdField] = "This field is required to have a properly formatted org or project scope id."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateSessionPolicyRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		return validateLimits(req.GetItem())
+	}, session.PolicyPrefix)
+}
+
+// validateLimits checks the limits which are set in item. A limit is removed
+// by leaving it unset, so a set limit must be greater than zero.
+func validateLimits(item *pb.SessionPolicy) map[string]string {
+	badFields := map[string]string{}
+	if item.GetMaxActiveSessionsPerUser() != nil && item.GetMaxActiveSessionsPerUser().GetValue() == 0 {
+		badFields[globals.MaxActiveSessionsPerUserField] = "This must be greater than zero."
+	}
+	if item.GetMaxDailySessionSecondsPerUser() != nil && item.GetMaxDailySessionSecondsPerUser().GetValue() == 0 {
+		badFields[globals.MaxDailySessionSecondsPerUserField] = "This must be greater than zero."
+	}
+	return badFields
+}
+
+func validateDeleteRequest(req *pbs.DeleteSessionPolicyRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, session.PolicyPrefix)
+}
+
+func validateListRequest(req *pbs.ListSessionPoliciesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		!handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "Incorrectly formatted identifier."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields[globals.FilterField] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
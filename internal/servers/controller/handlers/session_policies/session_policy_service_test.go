@@ -0,0 +1,359 @@
+package session_policies_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/session_policies"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessionpolicies"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+
+type testEnv struct {
+	conn          *db.DB
+	org, proj     *iam.Scope
+	iamRepoFn     func() (*iam.Repository, error)
+	sessionRepoFn func() (*session.Repository, error)
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	kms := kms.TestKms(t, conn, wrap)
+	sessionRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	o, p := iam.TestScopes(t, iamRepo)
+	return &testEnv{
+		conn: conn,
+		org:  o,
+		proj: p,
+		iamRepoFn: func() (*iam.Repository, error) {
+			return iamRepo, nil
+		},
+		sessionRepoFn: func() (*session.Repository, error) {
+			return sessionRepo, nil
+		},
+	}
+}
+
+func (e *testEnv) service(t *testing.T) session_policies.Service {
+	t.Helper()
+	s, err := session_policies.NewService(e.iamRepoFn, e.sessionRepoFn)
+	require.NoError(t, err, "Couldn't create new session policy service.")
+	return s
+}
+
+func TestNewService(t *testing.T) {
+	iamRepoFn := func() (*iam.Repository, error) { return nil, nil }
+	sessionRepoFn := func() (*session.Repository, error) { return nil, nil }
+
+	_, err := session_policies.NewService(nil, sessionRepoFn)
+	assert.Error(t, err)
+	_, err = session_policies.NewService(iamRepoFn, nil)
+	assert.Error(t, err)
+	_, err = session_policies.NewService(iamRepoFn, sessionRepoFn)
+	assert.NoError(t, err)
+}
+
+func TestGet(t *testing.T) {
+	env := newTestEnv(t)
+	sp := session.TestPolicy(t, env.conn, env.org.GetPublicId(), session.WithName("default"), session.WithMaxActiveSessionsPerUser(3))
+
+	want := &pb.SessionPolicy{
+		Id:                       sp.GetPublicId(),
+		ScopeId:                  sp.GetScopeId(),
+		Scope:                    &scopes.ScopeInfo{Id: env.org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+		Name:                     wrapperspb.String("default"),
+		CreatedTime:              sp.GetCreateTime().GetTimestamp(),
+		UpdatedTime:              sp.GetUpdateTime().GetTimestamp(),
+		Version:                  1,
+		MaxActiveSessionsPerUser: wrapperspb.UInt32(3),
+		AuthorizedActions:        testAuthorizedActions,
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetSessionPolicyRequest
+		res  *pbs.GetSessionPolicyResponse
+		err  error
+	}{
+		{
+			name: "Get an existing policy",
+			req:  &pbs.GetSessionPolicyRequest{Id: sp.GetPublicId()},
+			res:  &pbs.GetSessionPolicyResponse{Item: want},
+		},
+		{
+			name: "Get a non existing policy",
+			req:  &pbs.GetSessionPolicyRequest{Id: session.PolicyPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetSessionPolicyRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := env.service(t).GetSessionPolicy(auth.DisabledAuthTestContext(env.iamRepoFn, env.org.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetSessionPolicy(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetSessionPolicy(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	env := newTestEnv(t)
+	s := env.service(t)
+
+	orgPolicy := session.TestPolicy(t, env.conn, env.org.GetPublicId())
+	projPolicy := session.TestPolicy(t, env.conn, env.proj.GetPublicId())
+
+	got, err := s.ListSessionPolicies(auth.DisabledAuthTestContext(env.iamRepoFn, env.proj.GetPublicId()), &pbs.ListSessionPoliciesRequest{ScopeId: env.proj.GetPublicId()})
+	require.NoError(err)
+	require.Len(got.GetItems(), 1)
+	assert.Equal(projPolicy.GetPublicId(), got.GetItems()[0].GetId())
+
+	got, err = s.ListSessionPolicies(auth.DisabledAuthTestContext(env.iamRepoFn, scope.Global.String()), &pbs.ListSessionPoliciesRequest{ScopeId: scope.Global.String(), Recursive: true})
+	require.NoError(err)
+	var ids []string
+	for _, item := range got.GetItems() {
+		ids = append(ids, item.GetId())
+	}
+	assert.ElementsMatch([]string{orgPolicy.GetPublicId(), projPolicy.GetPublicId()}, ids)
+
+	got, err = s.ListSessionPolicies(auth.DisabledAuthTestContext(env.iamRepoFn, scope.Global.String()), &pbs.ListSessionPoliciesRequest{
+		ScopeId:   scope.Global.String(),
+		Recursive: true,
+		Filter:    fmt.Sprintf(`"/item/scope_id"==%q`, env.org.GetPublicId()),
+	})
+	require.NoError(err)
+	require.Len(got.GetItems(), 1)
+	assert.Equal(orgPolicy.GetPublicId(), got.GetItems()[0].GetId())
+
+	_, err = s.ListSessionPolicies(auth.DisabledAuthTestContext(env.iamRepoFn, scope.Global.String()), &pbs.ListSessionPoliciesRequest{ScopeId: "j_1234567890"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+}
+
+func TestCreate(t *testing.T) {
+	env := newTestEnv(t)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateSessionPolicyRequest
+		res  *pbs.CreateSessionPolicyResponse
+		err  error
+	}{
+		{
+			name: "Create a valid org policy",
+			req: &pbs.CreateSessionPolicyRequest{Item: &pb.SessionPolicy{
+				ScopeId:                       env.org.GetPublicId(),
+				Name:                          wrapperspb.String("name"),
+				Description:                   wrapperspb.String("desc"),
+				MaxActiveSessionsPerUser:      wrapperspb.UInt32(2),
+				MaxDailySessionSecondsPerUser: wrapperspb.UInt32(3600),
+			}},
+			res: &pbs.CreateSessionPolicyResponse{
+				Uri: fmt.Sprintf("session-policies/%s_", session.PolicyPrefix),
+				Item: &pb.SessionPolicy{
+					ScopeId:                       env.org.GetPublicId(),
+					Scope:                         &scopes.ScopeInfo{Id: env.org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:                          wrapperspb.String("name"),
+					Description:                   wrapperspb.String("desc"),
+					Version:                       1,
+					MaxActiveSessionsPerUser:      wrapperspb.UInt32(2),
+					MaxDailySessionSecondsPerUser: wrapperspb.UInt32(3600),
+					AuthorizedActions:             testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Create a valid project policy",
+			req: &pbs.CreateSessionPolicyRequest{Item: &pb.SessionPolicy{
+				ScopeId:                  env.proj.GetPublicId(),
+				MaxActiveSessionsPerUser: wrapperspb.UInt32(1),
+			}},
+			res: &pbs.CreateSessionPolicyResponse{
+				Uri: fmt.Sprintf("session-policies/%s_", session.PolicyPrefix),
+				Item: &pb.SessionPolicy{
+					ScopeId:                  env.proj.GetPublicId(),
+					Scope:                    &scopes.ScopeInfo{Id: env.proj.GetPublicId(), Type: scope.Project.String(), ParentScopeId: env.org.GetPublicId()},
+					Version:                  1,
+					MaxActiveSessionsPerUser: wrapperspb.UInt32(1),
+					AuthorizedActions:        testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Can't create a global policy",
+			req: &pbs.CreateSessionPolicyRequest{Item: &pb.SessionPolicy{
+				ScopeId: scope.Global.String(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Zero limit",
+			req: &pbs.CreateSessionPolicyRequest{Item: &pb.SessionPolicy{
+				ScopeId:                  env.org.GetPublicId(),
+				MaxActiveSessionsPerUser: wrapperspb.UInt32(0),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Id",
+			req: &pbs.CreateSessionPolicyRequest{Item: &pb.SessionPolicy{
+				ScopeId: env.org.GetPublicId(),
+				Id:      session.PolicyPrefix + "_notallowed",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Created Time",
+			req: &pbs.CreateSessionPolicyRequest{Item: &pb.SessionPolicy{
+				ScopeId:     env.org.GetPublicId(),
+				CreatedTime: timestamppb.Now(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := env.service(t).CreateSessionPolicy(auth.DisabledAuthTestContext(env.iamRepoFn, tc.req.GetItem().GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateSessionPolicy(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			if got != nil {
+				assert.Contains(got.GetUri(), tc.res.Uri)
+				assert.True(strings.HasPrefix(got.GetItem().GetId(), session.PolicyPrefix+"_"))
+
+				// Clear all values which are hard to compare against.
+				got.Uri, tc.res.Uri = "", ""
+				got.Item.Id, tc.res.Item.Id = "", ""
+				got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "CreateSessionPolicy(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := auth.DisabledAuthTestContext(env.iamRepoFn, env.proj.GetPublicId())
+
+	cases := []struct {
+		name string
+		item *pb.SessionPolicy
+		mask []string
+		want *pb.SessionPolicy
+		err  error
+	}{
+		{
+			name: "Update name and limits",
+			item: &pb.SessionPolicy{
+				Name:                          wrapperspb.String("updated"),
+				MaxActiveSessionsPerUser:      wrapperspb.UInt32(5),
+				MaxDailySessionSecondsPerUser: wrapperspb.UInt32(7200),
+			},
+			mask: []string{"name", "max_active_sessions_per_user", "max_daily_session_seconds_per_user"},
+			want: &pb.SessionPolicy{
+				Name:                          wrapperspb.String("updated"),
+				MaxActiveSessionsPerUser:      wrapperspb.UInt32(5),
+				MaxDailySessionSecondsPerUser: wrapperspb.UInt32(7200),
+			},
+		},
+		{
+			name: "Remove a limit",
+			item: &pb.SessionPolicy{},
+			mask: []string{"max_active_sessions_per_user"},
+			want: &pb.SessionPolicy{
+				Name:                          wrapperspb.String("default"),
+				MaxDailySessionSecondsPerUser: wrapperspb.UInt32(60),
+			},
+		},
+		{
+			name: "Zero limit",
+			item: &pb.SessionPolicy{MaxActiveSessionsPerUser: wrapperspb.UInt32(0)},
+			mask: []string{"max_active_sessions_per_user"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No valid fields in mask",
+			item: &pb.SessionPolicy{},
+			mask: []string{"unknown_field"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			sp := session.TestPolicy(t, env.conn, env.proj.GetPublicId(), session.WithName("default"),
+				session.WithMaxActiveSessionsPerUser(1), session.WithMaxDailySessionSecondsPerUser(60))
+			tc.item.Version = sp.GetVersion()
+			// The policy is deleted when the case ends so the next case can reuse the name.
+			defer func() {
+				_, err := env.service(t).DeleteSessionPolicy(ctx, &pbs.DeleteSessionPolicyRequest{Id: sp.GetPublicId()})
+				require.NoError(err)
+			}()
+			got, gErr := env.service(t).UpdateSessionPolicy(ctx, &pbs.UpdateSessionPolicyRequest{
+				Id:         sp.GetPublicId(),
+				Item:       tc.item,
+				UpdateMask: &field_mask.FieldMask{Paths: tc.mask},
+			})
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "UpdateSessionPolicy got error %v, wanted %v", gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			item := got.GetItem()
+			assert.Equal(sp.GetVersion()+1, item.GetVersion())
+			assert.Empty(cmp.Diff(tc.want.GetMaxActiveSessionsPerUser(), item.GetMaxActiveSessionsPerUser(), protocmp.Transform()))
+			assert.Empty(cmp.Diff(tc.want.GetMaxDailySessionSecondsPerUser(), item.GetMaxDailySessionSecondsPerUser(), protocmp.Transform()))
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	env := newTestEnv(t)
+	s := env.service(t)
+	sp := session.TestPolicy(t, env.conn, env.org.GetPublicId())
+	ctx := auth.DisabledAuthTestContext(env.iamRepoFn, env.org.GetPublicId())
+
+	_, err := s.DeleteSessionPolicy(ctx, &pbs.DeleteSessionPolicyRequest{Id: sp.GetPublicId()})
+	require.NoError(err, "First attempt")
+	_, err = s.DeleteSessionPolicy(ctx, &pbs.DeleteSessionPolicyRequest{Id: sp.GetPublicId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "Expected not found for the second delete.")
+	_, err = s.DeleteSessionPolicy(ctx, &pbs.DeleteSessionPolicyRequest{Id: "bad_format"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
@@ -32,6 +35,8 @@ var (
 		action.ReadSelf,
 		action.Cancel,
 		action.CancelSelf,
+		action.Approve,
+		action.Deny,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -147,55 +152,168 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 		return &pbs.ListSessionsResponse{}, nil
 	}
 
-	sesList, err := s.listFromRepo(ctx, scopeIds)
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.Session, req.GetPageSize(), req.GetListToken(),
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(sesList) == 0 {
-		return &pbs.ListSessionsResponse{}, nil
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
 	}
+	var finalItems []*pb.Session
+	res := perms.Resource{
+		Type: resource.Session,
+	}
+	for {
+		sesList, err := s.listFromRepo(ctx, scopeIds, pg)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range sesList {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.ScopeId = item.ScopeId
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res))
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			if authorizedActions.OnlySelf() && item.UserId != authResults.UserId {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.ScopeId]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+			}
+
+			item, err := toProto(ctx, item, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
 
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
+		}
+		if !pg.Next(len(sesList)) {
+			break
+		}
+	}
+
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListSessionsResponse{Items: finalItems, ListToken: listToken}, nil
+}
+
+// ListMySessions implements the interface pbs.SessionServiceServer.
+func (s Service) ListMySessions(ctx context.Context, req *pbs.ListMySessionsRequest) (*pbs.ListMySessionsResponse, error) {
+	if err := validateListMineRequest(req); err != nil {
+		return nil, err
+	}
+
+	authResults := s.mineAuthResult(ctx)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	_, scopeInfoMap, err := scopeids.GetAllScopeIds(ctx, s.iamRepoFn)
+	if err != nil {
+		return nil, err
+	}
+
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.Session, req.GetPageSize(), req.GetListToken(),
+		authResults.UserId, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Session, 0, len(sesList))
+	var finalItems []*pb.Session
+	// The sessions returned with their connections, which are looked up once
+	// every session is listed.
+	withConnections := map[string]*pb.Session{}
 	res := perms.Resource{
 		Type: resource.Session,
 	}
-	for _, item := range sesList {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.ScopeId
-		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res))
-		if len(authorizedActions) == 0 {
-			continue
+	for {
+		sesList, err := s.listMineFromRepo(ctx, authResults.UserId, pg)
+		if err != nil {
+			return nil, err
 		}
+		for _, ses := range sesList {
+			if !pg.Admit(ses.GetPublicId()) {
+				break
+			}
+			res.Id = ses.GetPublicId()
+			res.ScopeId = ses.ScopeId
+			authorizedActions := authResults.FetchActionSetForId(ctx, ses.GetPublicId(), IdActions, auth.WithResource(&res))
+
+			outputFields := authResults.FetchOutputFields(res, action.ReadSelf).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[ses.ScopeId]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+			}
 
-		if authorizedActions.OnlySelf() && item.UserId != authResults.UserId {
-			continue
-		}
+			item, err := toProto(ctx, ses, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
 
-		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
-		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
-		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.ScopeId]))
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				if outputFields.Has(globals.ConnectionsField) {
+					withConnections[ses.GetPublicId()] = item
+				}
+				pg.Include()
+			}
 		}
-		if outputFields.Has(globals.AuthorizedActionsField) {
-			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+		if !pg.Next(len(sesList)) {
+			break
 		}
+	}
 
-		item, err := toProto(ctx, item, outputOpts...)
+	if len(withConnections) > 0 {
+		repo, err := s.repoFn()
 		if err != nil {
 			return nil, err
 		}
-
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		sessionIds := make([]string, 0, len(withConnections))
+		for id := range withConnections {
+			sessionIds = append(sessionIds, id)
+		}
+		conns, statuses, err := repo.ListConnectionsBySessionIds(ctx, sessionIds, session.WithLimit(-1), session.WithOrderByCreateTime(db.AscendingOrderBy))
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range conns {
+			item := withConnections[c.SessionId]
+			item.Connections = append(item.Connections, connectionToProto(c, statuses[c.PublicId]))
 		}
 	}
 
-	return &pbs.ListSessionsResponse{Items: finalItems}, nil
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListMySessionsResponse{Items: finalItems, ListToken: listToken}, nil
 }
 
 // CancelSession implements the interface pbs.SessionServiceServer.
@@ -269,6 +387,84 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 	return &pbs.CancelSessionResponse{Item: item}, nil
 }
 
+// ApproveSession implements the interface pbs.SessionServiceServer.
+func (s Service) ApproveSession(ctx context.Context, req *pbs.ApproveSessionRequest) (*pbs.ApproveSessionResponse, error) {
+	const op = "sessions.(Service).ApproveSession"
+
+	if err := validateApproveRequest(req); err != nil {
+		return nil, err
+	}
+	item, err := s.decide(ctx, req.GetId(), req.GetVersion(), action.Approve)
+	if err != nil {
+		return nil, err
+	}
+	event.WriteSysEvent(ctx, op, "session approved", "session_id", item.GetId(), "approver_id", item.GetApproverId())
+	return &pbs.ApproveSessionResponse{Item: item}, nil
+}
+
+// DenySession implements the interface pbs.SessionServiceServer.
+func (s Service) DenySession(ctx context.Context, req *pbs.DenySessionRequest) (*pbs.DenySessionResponse, error) {
+	const op = "sessions.(Service).DenySession"
+
+	if err := validateDenyRequest(req); err != nil {
+		return nil, err
+	}
+	item, err := s.decide(ctx, req.GetId(), req.GetVersion(), action.Deny)
+	if err != nil {
+		return nil, err
+	}
+	event.WriteSysEvent(ctx, op, "session denied", "session_id", item.GetId(), "approver_id", item.GetApproverId())
+	return &pbs.DenySessionResponse{Item: item}, nil
+}
+
+// decide approves or denies, depending on the action a, the session id on
+// behalf of the requester. The user of a session cannot decide on it.
+func (s Service) decide(ctx context.Context, id string, version uint32, a action.Type) (*pb.Session, error) {
+	authResults := s.authResult(ctx, id, a)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	var ses *session.Session
+	switch a {
+	case action.Approve:
+		ses, err = repo.ApproveSession(ctx, id, version, authResults.UserId)
+	default:
+		ses, err = repo.DenySession(ctx, id, version, authResults.UserId)
+	}
+	if err != nil {
+		switch {
+		case errors.Match(errors.T(errors.SessionSelfApproval), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "A session cannot be approved or denied by its own user.")
+		case errors.Match(errors.T(errors.InvalidSessionState), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The session is not pending approval or its approval has expired.")
+		case errors.Match(errors.T(errors.VersionMismatch), err):
+			return nil, handlers.InvalidArgumentErrorf("Errors in provided fields.", map[string]string{globals.VersionField: "The version does not match the current version of the session."})
+		}
+		return nil, err
+	}
+
+	outputFields := authResults.FetchOutputFields(perms.Resource{
+		Id:      ses.GetPublicId(),
+		ScopeId: ses.ScopeId,
+		Type:    resource.Session,
+	}, a).SelfOrDefaults(authResults.UserId)
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, ses.GetPublicId(), IdActions).Strings()))
+	}
+	return toProto(ctx, ses, outputOpts...)
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*session.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -287,12 +483,36 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*session.Session,
 	return sess, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*session.Session, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, pg *handlers.Paginator) ([]*session.Session, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	opts := []session.Option{session.WithScopeIds(scopeIds)}
+	if pg.Enabled() {
+		opts = append(opts, session.WithStartPageAfterItem(pg.StartAfter()), session.WithLimit(pg.BatchSize()))
+	}
+	sesList, err := repo.ListSessions(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return sesList, nil
+}
+
+// listMineFromRepo lists the sessions of the user which are not terminated.
+func (s Service) listMineFromRepo(ctx context.Context, userId string, pg *handlers.Paginator) ([]*session.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	sesList, err := repo.ListSessions(ctx, session.WithScopeIds(scopeIds))
+	opts := []session.Option{
+		session.WithUserId(userId),
+		session.WithStatuses(session.StatusPendingApproval, session.StatusPending, session.StatusActive, session.StatusCanceling),
+	}
+	if pg.Enabled() {
+		opts = append(opts, session.WithStartPageAfterItem(pg.StartAfter()), session.WithLimit(pg.BatchSize()))
+	}
+	sesList, err := repo.ListSessions(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -334,7 +554,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			res.Error = handlers.NotFoundError()
 			return res
 		}
-	case action.Read, action.ReadSelf, action.Cancel, action.CancelSelf:
+	case action.Read, action.ReadSelf, action.Cancel, action.CancelSelf, action.Approve, action.Deny:
 		repo, err := s.repoFn()
 		if err != nil {
 			res.Error = err
@@ -359,6 +579,22 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
+// mineAuthResult authenticates the requester of a listing of its own sessions.
+// The requester doesn't need to be granted the list action since only its own
+// sessions are returned, but it must be authenticated as a user.
+func (s Service) mineAuthResult(ctx context.Context) auth.VerifyResults {
+	res := auth.Verify(ctx,
+		auth.WithScopeId(scope.Global.String()),
+		auth.WithType(resource.Session),
+		auth.WithAction(action.List),
+		auth.WithRecoveryTokenNotAllowed(true),
+		auth.WithAnonymousUserNotAllowed(true))
+	if res.Error == handlers.ForbiddenError() && res.AuthenticationFinished {
+		res.Error = nil
+	}
+	return res
+}
+
 func toProto(ctx context.Context, in *session.Session, opt ...handlers.Option) (*pb.Session, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
@@ -415,6 +651,20 @@ func toProto(ctx context.Context, in *session.Session, opt ...handlers.Option) (
 	if outputFields.Has(globals.TerminationReasonField) {
 		out.TerminationReason = in.TerminationReason
 	}
+	if outputFields.Has(globals.ApprovalExpirationTimeField) && in.ApprovalExpirationTime != nil {
+		out.ApprovalExpirationTime = in.ApprovalExpirationTime.GetTimestamp()
+	}
+	if outputFields.Has(globals.ApproverIdField) {
+		out.ApproverId = in.ApproverId
+	}
+	if outputFields.Has(globals.RecordingField) && in.RecordingEnabled {
+		// The recording only has a location once a worker has activated the
+		// session.
+		out.Recording = &pb.SessionRecording{
+			WorkerId:    in.ServerId,
+			StoragePath: in.RecordingPath,
+		}
+	}
 	if outputFields.Has(globals.ScopeField) {
 		out.Scope = opts.WithScope
 	}
@@ -444,6 +694,24 @@ func toProto(ctx context.Context, in *session.Session, opt ...handlers.Option) (
 	return &out, nil
 }
 
+func connectionToProto(in *session.Connection, status session.ConnectionStatus) *pb.Connection {
+	out := &pb.Connection{
+		Id:                 in.PublicId,
+		Status:             status.String(),
+		ClientTcpAddress:   in.ClientTcpAddress,
+		ClientTcpPort:      in.ClientTcpPort,
+		EndpointTcpAddress: in.EndpointTcpAddress,
+		EndpointTcpPort:    in.EndpointTcpPort,
+		BytesUp:            in.BytesUp,
+		BytesDown:          in.BytesDown,
+		ClosedReason:       in.ClosedReason,
+	}
+	if in.CreateTime != nil {
+		out.CreatedTime = in.CreateTime.GetTimestamp()
+	}
+	return out
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -468,6 +736,17 @@ func validateListRequest(req *pbs.ListSessionsRequest) error {
 	return nil
 }
 
+func validateListMineRequest(req *pbs.ListMySessionsRequest) error {
+	badFields := map[string]string{}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
 func validateCancelRequest(req *pbs.CancelSessionRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetId()), session.SessionPrefix) {
@@ -481,3 +760,31 @@ func validateCancelRequest(req *pbs.CancelSessionRequest) error {
 	}
 	return nil
 }
+
+func validateApproveRequest(req *pbs.ApproveSessionRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), session.SessionPrefix) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateDenyRequest(req *pbs.DenySessionRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), session.SessionPrefix) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
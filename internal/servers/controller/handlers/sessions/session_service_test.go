@@ -30,7 +30,7 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "read:self", "cancel", "cancel:self"}
+var testAuthorizedActions = []string{"no-op", "read", "read:self", "cancel", "cancel:self", "approve", "deny"}
 
 func TestGetSession(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
@@ -226,6 +226,117 @@ func TestList_Self(t *testing.T) {
 	}
 }
 
+func TestListMine(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	o, pWithSessions := iam.TestScopes(t, iamRepo)
+
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	uId := at.GetIamUserId()
+	otherAt := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+
+	hc := static.TestCatalogs(t, conn, pWithSessions.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := target.TestTcpTarget(t, conn, pWithSessions.GetPublicId(), "test", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	composedOf := session.ComposedOf{
+		UserId:      uId,
+		HostId:      h.GetPublicId(),
+		TargetId:    tar.GetPublicId(),
+		HostSetId:   hs.GetPublicId(),
+		AuthTokenId: at.GetPublicId(),
+		ScopeId:     pWithSessions.GetPublicId(),
+		Endpoint:    "tcp://127.0.0.1:22",
+	}
+	active := session.TestSession(t, conn, wrap, composedOf)
+	_ = session.TestState(t, conn, active.PublicId, session.StatusActive)
+	c := session.TestConnection(t, conn, active.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+	terminated := session.TestSession(t, conn, wrap, composedOf)
+	_ = session.TestState(t, conn, terminated.PublicId, session.StatusTerminated)
+
+	s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new session service.")
+
+	cases := []struct {
+		name        string
+		tokenFormat auth.TokenFormat
+		requester   *authtoken.AuthToken
+		wantIds     []string
+		wantErr     error
+	}{
+		{
+			name:        "List My Sessions",
+			tokenFormat: auth.AuthTokenTypeBearer,
+			requester:   at,
+			wantIds:     []string{active.PublicId},
+		},
+		{
+			name:        "No Sessions Of Others",
+			tokenFormat: auth.AuthTokenTypeBearer,
+			requester:   otherAt,
+		},
+		{
+			name:        "Anonymous",
+			tokenFormat: auth.AuthTokenTypeUnknown,
+			wantErr:     handlers.UnauthenticatedError(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			req := httptest.NewRequest("GET", "http://127.0.0.1/v1/sessions:list-mine", nil)
+			requestInfo := auth.RequestInfo{
+				Path:        req.URL.Path,
+				Method:      req.Method,
+				TokenFormat: tc.tokenFormat,
+			}
+			if tc.requester != nil {
+				requestInfo.PublicId = tc.requester.GetPublicId()
+				requestInfo.Token = tc.requester.GetToken()
+			}
+
+			ctx := auth.NewVerifierContext(context.Background(), iamRepoFn, tokenRepoFn, serversRepoFn, kms, requestInfo)
+			got, err := s.ListMySessions(ctx, &pbs.ListMySessionsRequest{})
+			if tc.wantErr != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tc.wantErr), "got error %v, wanted %v", err, tc.wantErr)
+				return
+			}
+			require.NoError(err)
+			var gotIds []string
+			for _, item := range got.GetItems() {
+				gotIds = append(gotIds, item.GetId())
+			}
+			assert.Equal(tc.wantIds, gotIds)
+			if len(tc.wantIds) > 0 {
+				require.Len(got.GetItems()[0].GetConnections(), 1)
+				assert.Equal(c.PublicId, got.GetItems()[0].GetConnections()[0].GetId())
+				assert.Equal(session.StatusConnected.String(), got.GetItems()[0].GetConnections()[0].GetStatus())
+			}
+		})
+	}
+}
+
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -0,0 +1,202 @@
+package targets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/boundary"
+	"github.com/hashicorp/boundary/internal/credential"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/errors"
+	workerpbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/session"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// typedCredential returns the fields of c returned to the user as the
+// typed credential of a session credential. It returns nil if c is not a
+// typed credential.
+func typedCredential(c credential.Credential) (*structpb.Struct, error) {
+	var fields map[string]interface{}
+	switch tc := c.(type) {
+	case credential.SshCertificate:
+		fields = map[string]interface{}{
+			"username":    tc.Username(),
+			"private_key": string(tc.Private()),
+			"certificate": string(tc.Certificate()),
+		}
+	case credential.UserPassword:
+		fields = map[string]interface{}{
+			"username": tc.Username(),
+			"password": string(tc.Password()),
+		}
+	case credential.KeyPair:
+		fields = map[string]interface{}{
+			"username":    tc.Username(),
+			"private_key": string(tc.Private()),
+		}
+	case credential.Certificate:
+		fields = map[string]interface{}{
+			"certificate": string(tc.Certificate()),
+			"private_key": string(tc.Private()),
+		}
+	default:
+		return nil, nil
+	}
+	return structpb.NewStruct(fields)
+}
+
+// staticSessionCredential returns the session credential returned to the
+// user for the static credential c. The secret of a static credential is
+// made up of the fields of its typed credential.
+func staticSessionCredential(c credential.Static) (*pb.SessionCredential, error) {
+	const op = "targets.staticSessionCredential"
+	typed, err := typedCredential(c)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("creating proto struct for credential"))
+	}
+	if typed == nil {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unsupported static credential %s", c.GetPublicId()))
+	}
+	jSecret, err := json.Marshal(typed.AsMap())
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("marshalling secret to json"))
+	}
+	source := &pb.CredentialSource{
+		Id:                c.GetPublicId(),
+		CredentialStoreId: c.GetStoreId(),
+		Type:              credstatic.Subtype.String(),
+		CredentialType:    string(credstatic.CredentialTypeFromId(c.GetPublicId())),
+	}
+	if r, ok := c.(boundary.Resource); ok {
+		source.Name = r.GetName()
+		source.Description = r.GetDescription()
+	}
+	return &pb.SessionCredential{
+		// The deprecated credential library is filled in for clients which
+		// do not know about credential sources.
+		CredentialLibrary: &pb.CredentialLibrary{
+			Id:                source.GetId(),
+			Name:              source.GetName(),
+			Description:       source.GetDescription(),
+			CredentialStoreId: source.GetCredentialStoreId(),
+			Type:              source.GetType(),
+		},
+		CredentialSource: source,
+		Secret: &pb.SessionSecret{
+			Raw:     base64.StdEncoding.EncodeToString(jSecret),
+			Decoded: typed,
+		},
+		Credential: typed,
+	}, nil
+}
+
+// egressCredential converts an egress credential into the marshaled form
+// stored with the session and handed to the worker. Username and password,
+// SSH private key and SSH certificate credentials are supported. For dynamic credentials
+// from libraries which do not declare a credential type, secrets containing
+// a username and either a password or a private_key are supported. Secrets
+// from a Vault KV version 2 engine, which nest the values under a "data"
+// key, are also supported.
+func egressCredential(ctx context.Context, c credential.Credential) (session.Credential, error) {
+	const op = "targets.egressCredential"
+	var pc *workerpbs.Credential
+	switch tc := c.(type) {
+	case credential.SshCertificate:
+		pc = &workerpbs.Credential{
+			Credential: &workerpbs.Credential_SshPrivateKey{
+				SshPrivateKey: &workerpbs.SshPrivateKey{
+					Username:    tc.Username(),
+					PrivateKey:  tc.Private(),
+					Certificate: tc.Certificate(),
+				},
+			},
+		}
+	case credential.UserPassword:
+		pc = &workerpbs.Credential{
+			Credential: &workerpbs.Credential_UsernamePassword{
+				UsernamePassword: &workerpbs.UsernamePassword{
+					Username: tc.Username(),
+					Password: string(tc.Password()),
+				},
+			},
+		}
+	case credential.KeyPair:
+		pc = &workerpbs.Credential{
+			Credential: &workerpbs.Credential_SshPrivateKey{
+				SshPrivateKey: &workerpbs.SshPrivateKey{
+					Username:   tc.Username(),
+					PrivateKey: tc.Private(),
+				},
+			},
+		}
+	default:
+		dc, ok := c.(credential.Dynamic)
+		if !ok {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported egress credential %s", c.GetPublicId()))
+		}
+		var err error
+		if pc, err = untypedEgressCredential(ctx, dc); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := proto.Marshal(pc)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("marshaling egress credential"))
+	}
+	return data, nil
+}
+
+// untypedEgressCredential guesses the type of the egress credential c from
+// the fields of its secret.
+func untypedEgressCredential(ctx context.Context, c credential.Dynamic) (*workerpbs.Credential, error) {
+	const op = "targets.untypedEgressCredential"
+	secret, ok := c.Secret().(map[string]interface{})
+	if !ok {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported secret type %T for egress credential from %s", c.Secret(), c.Library().GetPublicId()))
+	}
+	if data, ok := secret["data"].(map[string]interface{}); ok {
+		if _, ok := secret["metadata"]; ok {
+			secret = data
+		}
+	}
+
+	getString := func(key string) string {
+		v, _ := secret[key].(string)
+		return v
+	}
+	username := getString("username")
+	if username == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("egress credential from %s is missing a username", c.Library().GetPublicId()))
+	}
+
+	var pc *workerpbs.Credential
+	switch {
+	case getString("private_key") != "":
+		pc = &workerpbs.Credential{
+			Credential: &workerpbs.Credential_SshPrivateKey{
+				SshPrivateKey: &workerpbs.SshPrivateKey{
+					Username:   username,
+					PrivateKey: []byte(getString("private_key")),
+				},
+			},
+		}
+	case getString("password") != "":
+		pc = &workerpbs.Credential{
+			Credential: &workerpbs.Credential_UsernamePassword{
+				UsernamePassword: &workerpbs.UsernamePassword{
+					Username: username,
+					Password: getString("password"),
+				},
+			},
+		}
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("egress credential from %s has neither a password nor a private_key", c.Library().GetPublicId()))
+	}
+	return pc, nil
+}
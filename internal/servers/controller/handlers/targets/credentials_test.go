@@ -0,0 +1,278 @@
+package targets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	workerpbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeLibrary struct {
+	credential.Library
+}
+
+func (fakeLibrary) GetPublicId() string { return "clvlt_1234567890" }
+
+type fakeDynamic struct {
+	credential.Dynamic
+	secret credential.SecretData
+}
+
+func (f fakeDynamic) Secret() credential.SecretData { return f.secret }
+func (fakeDynamic) Library() credential.Library     { return fakeLibrary{} }
+
+type fakeUserPassword struct {
+	fakeDynamic
+	username, password string
+}
+
+func (f fakeUserPassword) Username() string              { return f.username }
+func (f fakeUserPassword) Password() credential.Password { return credential.Password(f.password) }
+
+type fakeKeyPair struct {
+	fakeDynamic
+	username, privateKey string
+}
+
+func (f fakeKeyPair) Username() string               { return f.username }
+func (f fakeKeyPair) Private() credential.PrivateKey { return credential.PrivateKey(f.privateKey) }
+
+type fakeSshCertificate struct {
+	fakeKeyPair
+	certificate string
+}
+
+func (f fakeSshCertificate) Certificate() []byte { return []byte(f.certificate) }
+
+type fakeStatic struct {
+	credential.Static
+	id string
+}
+
+func (f fakeStatic) GetPublicId() string { return f.id }
+func (fakeStatic) GetStoreId() string    { return "csst_1234567890" }
+
+type fakeStaticUserPassword struct {
+	fakeStatic
+	username, password string
+}
+
+func (f fakeStaticUserPassword) Username() string { return f.username }
+func (f fakeStaticUserPassword) Password() credential.Password {
+	return credential.Password(f.password)
+}
+
+func TestEgressCredential(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  credential.SecretData
+		cred    credential.Credential
+		want    *workerpbs.Credential
+		wantErr bool
+	}{
+		{
+			name:   "username-password",
+			secret: map[string]interface{}{"username": "user", "password": "pass"},
+			want: &workerpbs.Credential{
+				Credential: &workerpbs.Credential_UsernamePassword{
+					UsernamePassword: &workerpbs.UsernamePassword{Username: "user", Password: "pass"},
+				},
+			},
+		},
+		{
+			name:   "private-key",
+			secret: map[string]interface{}{"username": "user", "private_key": "key"},
+			want: &workerpbs.Credential{
+				Credential: &workerpbs.Credential_SshPrivateKey{
+					SshPrivateKey: &workerpbs.SshPrivateKey{Username: "user", PrivateKey: []byte("key")},
+				},
+			},
+		},
+		{
+			name: "kv2-nested",
+			secret: map[string]interface{}{
+				"metadata": map[string]interface{}{"version": 1},
+				"data":     map[string]interface{}{"username": "user", "password": "pass"},
+			},
+			want: &workerpbs.Credential{
+				Credential: &workerpbs.Credential_UsernamePassword{
+					UsernamePassword: &workerpbs.UsernamePassword{Username: "user", Password: "pass"},
+				},
+			},
+		},
+		{
+			name: "typed-username-password",
+			cred: fakeUserPassword{
+				fakeDynamic: fakeDynamic{secret: map[string]interface{}{"user": "user", "pass": "pass"}},
+				username:    "user",
+				password:    "pass",
+			},
+			want: &workerpbs.Credential{
+				Credential: &workerpbs.Credential_UsernamePassword{
+					UsernamePassword: &workerpbs.UsernamePassword{Username: "user", Password: "pass"},
+				},
+			},
+		},
+		{
+			name: "typed-private-key",
+			cred: fakeKeyPair{
+				fakeDynamic: fakeDynamic{secret: map[string]interface{}{"user": "user", "key": "key"}},
+				username:    "user",
+				privateKey:  "key",
+			},
+			want: &workerpbs.Credential{
+				Credential: &workerpbs.Credential_SshPrivateKey{
+					SshPrivateKey: &workerpbs.SshPrivateKey{Username: "user", PrivateKey: []byte("key")},
+				},
+			},
+		},
+		{
+			name: "typed-ssh-certificate",
+			cred: fakeSshCertificate{
+				fakeKeyPair: fakeKeyPair{
+					fakeDynamic: fakeDynamic{secret: map[string]interface{}{"signed_key": "cert"}},
+					username:    "user",
+					privateKey:  "key",
+				},
+				certificate: "cert",
+			},
+			want: &workerpbs.Credential{
+				Credential: &workerpbs.Credential_SshPrivateKey{
+					SshPrivateKey: &workerpbs.SshPrivateKey{Username: "user", PrivateKey: []byte("key"), Certificate: []byte("cert")},
+				},
+			},
+		},
+		{
+			name: "static-username-password",
+			cred: fakeStaticUserPassword{
+				fakeStatic: fakeStatic{id: "credup_1234567890"},
+				username:   "user",
+				password:   "pass",
+			},
+			want: &workerpbs.Credential{
+				Credential: &workerpbs.Credential_UsernamePassword{
+					UsernamePassword: &workerpbs.UsernamePassword{Username: "user", Password: "pass"},
+				},
+			},
+		},
+		{
+			name:    "static-untyped",
+			cred:    fakeStatic{id: "credup_1234567890"},
+			wantErr: true,
+		},
+		{
+			name:    "missing-username",
+			secret:  map[string]interface{}{"password": "pass"},
+			wantErr: true,
+		},
+		{
+			name:    "missing-password-and-key",
+			secret:  map[string]interface{}{"username": "user"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported-secret",
+			secret:  "secret",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			c := tt.cred
+			if c == nil {
+				c = fakeDynamic{secret: tt.secret}
+			}
+			got, err := egressCredential(context.Background(), c)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			var pc workerpbs.Credential
+			require.NoError(proto.Unmarshal(got, &pc))
+			assert.True(proto.Equal(tt.want, &pc))
+		})
+	}
+}
+
+func TestStaticSessionCredential(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	c := fakeStaticUserPassword{
+		fakeStatic: fakeStatic{id: "credup_1234567890"},
+		username:   "user",
+		password:   "pass",
+	}
+	got, err := staticSessionCredential(c)
+	require.NoError(err)
+	want := map[string]interface{}{"username": "user", "password": "pass"}
+	assert.Equal(want, got.GetCredential().AsMap())
+	assert.Equal(want, got.GetSecret().GetDecoded().AsMap())
+
+	raw, err := base64.StdEncoding.DecodeString(got.GetSecret().GetRaw())
+	require.NoError(err)
+	var rawSecret map[string]interface{}
+	require.NoError(json.Unmarshal(raw, &rawSecret))
+	assert.Equal(want, rawSecret)
+
+	source := got.GetCredentialSource()
+	assert.Equal("credup_1234567890", source.GetId())
+	assert.Equal("csst_1234567890", source.GetCredentialStoreId())
+	assert.Equal("static", source.GetType())
+	assert.Equal(string(credential.UsernamePasswordType), source.GetCredentialType())
+	assert.Equal("credup_1234567890", got.GetCredentialLibrary().GetId())
+	assert.Equal("static", got.GetCredentialLibrary().GetType())
+
+	_, err = staticSessionCredential(fakeStatic{id: "credup_1234567890"})
+	assert.Error(err)
+}
+
+func TestTypedCredential(t *testing.T) {
+	tests := []struct {
+		name string
+		cred credential.Credential
+		want map[string]interface{}
+	}{
+		{
+			name: "username-password",
+			cred: fakeUserPassword{username: "user", password: "pass"},
+			want: map[string]interface{}{"username": "user", "password": "pass"},
+		},
+		{
+			name: "ssh-private-key",
+			cred: fakeKeyPair{username: "user", privateKey: "key"},
+			want: map[string]interface{}{"username": "user", "private_key": "key"},
+		},
+		{
+			name: "ssh-certificate",
+			cred: fakeSshCertificate{
+				fakeKeyPair: fakeKeyPair{username: "user", privateKey: "key"},
+				certificate: "cert",
+			},
+			want: map[string]interface{}{"username": "user", "private_key": "key", "certificate": "cert"},
+		},
+		{
+			name: "untyped",
+			cred: fakeDynamic{secret: map[string]interface{}{"username": "user"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := typedCredential(tt.cred)
+			require.NoError(err)
+			if tt.want == nil {
+				assert.Nil(got)
+				return
+			}
+			assert.Equal(tt.want, got.AsMap())
+		})
+	}
+}
@@ -8,18 +8,23 @@ import (
 	"fmt"
 	"math/rand"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/servers"
@@ -38,6 +43,7 @@ import (
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/mitchellh/pointerstructure"
 	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/ssh"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -46,7 +52,8 @@ import (
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager    handlers.MaskManager
+	sshMaskManager handlers.MaskManager
 
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -77,9 +84,14 @@ var (
 
 func init() {
 	var err error
+	// Udp targets share the tcp target storage fields and attributes, so the
+	// tcp mappings are used to translate their update masks as well.
 	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.TcpTarget{}}, handlers.MaskSource{&pb.Target{}, &pb.TcpTargetAttributes{}}); err != nil {
 		panic(err)
 	}
+	if sshMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.SshTarget{}}, handlers.MaskSource{&pb.Target{}, &pb.SshTargetAttributes{}}); err != nil {
+		panic(err)
+	}
 }
 
 // Service handles request as described by the pbs.TargetServiceServer interface.
@@ -91,7 +103,9 @@ type Service struct {
 	serversRepoFn    common.ServersRepoFactory
 	sessionRepoFn    common.SessionRepoFactory
 	staticHostRepoFn common.StaticRepoFactory
+	pluginHostRepoFn common.PluginHostRepoFactory
 	vaultCredRepoFn  common.VaultCredentialRepoFactory
+	staticCredRepoFn common.StaticCredentialRepoFactory
 	kmsCache         *kms.Kms
 }
 
@@ -103,7 +117,9 @@ func NewService(
 	serversRepoFn common.ServersRepoFactory,
 	sessionRepoFn common.SessionRepoFactory,
 	staticHostRepoFn common.StaticRepoFactory,
-	vaultCredRepoFn common.VaultCredentialRepoFactory) (Service, error) {
+	pluginHostRepoFn common.PluginHostRepoFactory,
+	vaultCredRepoFn common.VaultCredentialRepoFactory,
+	staticCredRepoFn common.StaticCredentialRepoFactory) (Service, error) {
 	const op = "targets.NewService"
 	if repoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing target repository")
@@ -120,16 +136,24 @@ func NewService(
 	if staticHostRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing static host repository")
 	}
+	if pluginHostRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing plugin host repository")
+	}
 	if vaultCredRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing vault credential repository")
 	}
+	if staticCredRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing static credential repository")
+	}
 	return Service{
 		repoFn:           repoFn,
 		iamRepoFn:        iamRepoFn,
 		serversRepoFn:    serversRepoFn,
 		sessionRepoFn:    sessionRepoFn,
 		staticHostRepoFn: staticHostRepoFn,
+		pluginHostRepoFn: pluginHostRepoFn,
 		vaultCredRepoFn:  vaultCredRepoFn,
+		staticCredRepoFn: staticCredRepoFn,
 		kmsCache:         kmsCache,
 	}, nil
 }
@@ -165,50 +189,143 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 		return &pbs.ListTargetsResponse{}, nil
 	}
 
-	tl, err := s.listFromRepo(ctx, scopeIds)
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.Target, req.GetPageSize(), req.GetListToken(),
+		req.GetScopeId(), strconv.FormatBool(req.GetRecursive()), req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(tl) == 0 {
-		return &pbs.ListTargetsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Target, 0, len(tl))
+	var finalItems []*pb.Target
 	res := perms.Resource{
 		Type: resource.Target,
 	}
-	for _, item := range tl {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetScopeId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
-		if len(authorizedActions) == 0 {
-			continue
+	for {
+		tl, err := s.listFromRepo(ctx, scopeIds, pg)
+		if err != nil {
+			return nil, err
 		}
+		for _, item := range tl {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.ScopeId = item.GetScopeId()
+			res.Name = item.GetName()
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+			}
 
-		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
-		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
-		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+			item, err := toProto(ctx, item, nil, nil, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
 		}
-		if outputFields.Has(globals.AuthorizedActionsField) {
-			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		if !pg.Next(len(tl)) {
+			break
 		}
+	}
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListTargetsResponse{Items: finalItems, ListToken: listToken}, nil
+}
+
+// ListMyTargets implements the interface pbs.TargetServiceServer.
+func (s Service) ListMyTargets(ctx context.Context, req *pbs.ListMyTargetsRequest) (*pbs.ListMyTargetsResponse, error) {
+	if err := validateListMineRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.mineAuthResult(ctx)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetAllScopeIds(ctx, s.iamRepoFn)
+	if err != nil {
+		return nil, err
+	}
 
-		item, err := toProto(ctx, item, nil, nil, outputOpts...)
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.Target, req.GetPageSize(), req.GetListToken(),
+		authResults.UserId, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	var finalItems []*pb.Target
+	res := perms.Resource{
+		Type: resource.Target,
+	}
+	for {
+		tl, err := s.listFromRepo(ctx, scopeIds, pg)
 		if err != nil {
 			return nil, err
 		}
+		for _, item := range tl {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.ScopeId = item.GetScopeId()
+			res.Name = item.GetName()
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res))
+			if !authorizedActions.HasAction(action.AuthorizeSession) {
+				continue
+			}
+
+			// The requester may only be granted authorize-session, so the
+			// output fields are the ones of that action.
+			outputFields := authResults.FetchOutputFields(res, action.AuthorizeSession).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+			}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+			item, err := toProto(ctx, item, nil, nil, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
+		}
+		if !pg.Next(len(tl)) {
+			break
 		}
 	}
-	return &pbs.ListTargetsResponse{Items: finalItems}, nil
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListMyTargetsResponse{Items: finalItems, ListToken: listToken}, nil
 }
 
 // GetTargets implements the interface pbs.TargetServiceServer.
@@ -580,7 +697,7 @@ func (s Service) AddTargetCredentialLibraries(ctx context.Context, req *pbs.AddT
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	t, ts, cl, err := s.addCredentialSourcesInRepo(ctx, req.GetId(), req.GetApplicationCredentialLibraryIds(), req.GetVersion())
+	t, ts, cl, err := s.addCredentialSourcesInRepo(ctx, req.GetId(), target.CredentialSources{ApplicationCredentialIds: req.GetApplicationCredentialLibraryIds()}, req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -618,7 +735,7 @@ func (s Service) SetTargetCredentialLibraries(ctx context.Context, req *pbs.SetT
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	t, ts, cl, err := s.setCredentialSourcesInRepo(ctx, req.GetId(), req.GetApplicationCredentialLibraryIds(), req.GetVersion())
+	t, ts, cl, err := s.setCredentialSourcesInRepo(ctx, req.GetId(), target.CredentialSources{ApplicationCredentialIds: req.GetApplicationCredentialLibraryIds()}, req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -656,7 +773,7 @@ func (s Service) RemoveTargetCredentialLibraries(ctx context.Context, req *pbs.R
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	t, ts, cl, err := s.removeCredentialSourcesInRepo(ctx, req.GetId(), req.GetApplicationCredentialLibraryIds(), req.GetVersion())
+	t, ts, cl, err := s.removeCredentialSourcesInRepo(ctx, req.GetId(), target.CredentialSources{ApplicationCredentialIds: req.GetApplicationCredentialLibraryIds()}, req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -694,7 +811,10 @@ func (s Service) AddTargetCredentialSources(ctx context.Context, req *pbs.AddTar
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	t, ts, cl, err := s.addCredentialSourcesInRepo(ctx, req.GetId(), req.GetApplicationCredentialSourceIds(), req.GetVersion())
+	t, ts, cl, err := s.addCredentialSourcesInRepo(ctx, req.GetId(), target.CredentialSources{
+		ApplicationCredentialIds: req.GetApplicationCredentialSourceIds(),
+		EgressCredentialIds:      req.GetEgressCredentialSourceIds(),
+	}, req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -732,7 +852,10 @@ func (s Service) SetTargetCredentialSources(ctx context.Context, req *pbs.SetTar
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	t, ts, cl, err := s.setCredentialSourcesInRepo(ctx, req.GetId(), req.GetApplicationCredentialSourceIds(), req.GetVersion())
+	t, ts, cl, err := s.setCredentialSourcesInRepo(ctx, req.GetId(), target.CredentialSources{
+		ApplicationCredentialIds: req.GetApplicationCredentialSourceIds(),
+		EgressCredentialIds:      req.GetEgressCredentialSourceIds(),
+	}, req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -770,7 +893,10 @@ func (s Service) RemoveTargetCredentialSources(ctx context.Context, req *pbs.Rem
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	t, ts, cl, err := s.removeCredentialSourcesInRepo(ctx, req.GetId(), req.GetApplicationCredentialSourceIds(), req.GetVersion())
+	t, ts, cl, err := s.removeCredentialSourcesInRepo(ctx, req.GetId(), target.CredentialSources{
+		ApplicationCredentialIds: req.GetApplicationCredentialSourceIds(),
+		EgressCredentialIds:      req.GetEgressCredentialSourceIds(),
+	}, req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -866,77 +992,24 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		return nil, err
 	}
 
-	// First ensure we can actually service a request, that is, we have workers
-	// available (after any filtering). WorkerInfo only contains the address;
-	// worker IDs below is used to contain their IDs in the same order. This is
-	// used to fetch tags for filtering. But we avoid allocation unless we
-	// actually need it.
-	var workers []*pb.WorkerInfo
-	var workerIds []string
-	hasWorkerFilter := len(t.GetWorkerFilter()) > 0
-	servers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
-	if err != nil {
-		return nil, err
+	// The user of an approved session retrieves its authorization rather than
+	// creating a new session.
+	if req.GetSessionId() != "" {
+		return s.authorizeApprovedSession(ctx, req.GetSessionId(), t, libs, authResults, sessionRepo, serversRepo)
 	}
-	for _, v := range servers {
-		if hasWorkerFilter {
-			workerIds = append(workerIds, v.GetPrivateId())
-		}
-		workers = append(workers, &pb.WorkerInfo{Address: v.Address})
-	}
-
-	if hasWorkerFilter && len(workerIds) > 0 {
-		finalWorkers := make([]*pb.WorkerInfo, 0, len(workers))
-		// Fetch the tags for the given worker IDs
-		tags, err := serversRepo.ListTagsForServers(ctx, workerIds)
-		if err != nil {
-			return nil, err
-		}
-		// Build the map for filtering. This is similar to the filter map we
-		// built from the worker config, but with one extra level: a map of the
-		// worker's ID to its filter map.
-		tagMap := make(map[string]map[string][]string)
-		for _, tag := range tags {
-			currWorkerMap := tagMap[tag.ServerId]
-			if currWorkerMap == nil {
-				currWorkerMap = make(map[string][]string)
-				tagMap[tag.ServerId] = currWorkerMap
-			}
-			currWorkerMap[tag.Key] = append(currWorkerMap[tag.Key], tag.Value)
-			// We don't need to reinsert after the fact because maps are
-			// reference types, so we don't need to re-insert into tagMap
-		}
-
-		// Create the evaluator
-		eval, err := bexpr.CreateEvaluator(t.GetWorkerFilter())
-		if err != nil {
-			return nil, err
-		}
 
-		// Iterate through the known worker IDs, and evaluate. If evaluation
-		// returns true, add to the final worker slice, which is assigned back
-		// to workers after this.
-		for i, worker := range workerIds {
-			filterInput := map[string]interface{}{
-				"name": worker,
-				"tags": tagMap[worker],
-			}
-			ok, err := eval.Evaluate(filterInput)
-			if err != nil && !stderrors.Is(err, pointerstructure.ErrNotFound) {
-				return nil, handlers.ApiErrorWithCodeAndMessage(
-					codes.FailedPrecondition,
-					fmt.Sprintf("Worker filter expression evaluation resulted in error: %s", err))
-			}
-			if ok {
-				finalWorkers = append(finalWorkers, workers[i])
-			}
-		}
-		workers = finalWorkers
+	// Check the session policies of the project and its org. The remaining
+	// daily session time, if limited, caps the expiration of the session.
+	remainingTime, err := sessionRepo.CheckPolicies(ctx, authResults.UserId, t.GetScopeId())
+	if err != nil {
+		return nil, sessionPolicyError(err)
 	}
-	if len(workers) == 0 {
-		return nil, handlers.ApiErrorWithCodeAndMessage(
-			codes.FailedPrecondition,
-			"No workers are available to handle this session, or all have been filtered.")
+
+	// First ensure we can actually service a request, that is, we have workers
+	// available (after any filtering).
+	workers, err := authorizedWorkers(ctx, serversRepo, t)
+	if err != nil {
+		return nil, err
 	}
 
 	// First, fetch all available hosts. Unless one was chosen in the request,
@@ -952,6 +1025,10 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if err != nil {
 		return nil, err
 	}
+	pluginHostRepo, err := s.pluginHostRepoFn()
+	if err != nil {
+		return nil, err
+	}
 
 	hostIds := make([]compoundHost, 0, len(hostSources)*10)
 
@@ -972,6 +1049,19 @@ HostSourceIterationLoop:
 					break HostSourceIterationLoop
 				}
 			}
+		case plugin.Subtype:
+			_, hosts, err := pluginHostRepo.LookupSet(ctx, hsId)
+			if err != nil {
+				return nil, err
+			}
+			for _, host := range hosts {
+				compoundId := compoundHost{hostSetId: hsId, hostId: host.PublicId}
+				hostIds = append(hostIds, compoundId)
+				if host.PublicId == requestedId {
+					chosenId = &compoundId
+					break HostSourceIterationLoop
+				}
+			}
 		}
 	}
 	if requestedId != "" && chosenId == nil {
@@ -1006,6 +1096,15 @@ HostSourceIterationLoop:
 		if endpointHost == "" {
 			return nil, stderrors.New("host had empty address")
 		}
+	case plugin.Subtype:
+		h, err := pluginHostRepo.LookupHost(ctx, chosenId.hostId)
+		if err != nil || h == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "errors looking up host")
+		}
+		endpointHost = h.Address
+		if endpointHost == "" {
+			return nil, stderrors.New("host had empty address")
+		}
 	}
 	if defaultPort != 0 {
 		endpointUrl.Host = fmt.Sprintf("%s:%d", endpointHost, defaultPort)
@@ -1013,18 +1112,21 @@ HostSourceIterationLoop:
 		endpointUrl.Host = endpointHost
 	}
 
-	var reqs []credential.Request
 	var dynCreds []*session.DynamicCredential
 	for _, l := range libs {
-		reqs = append(reqs, credential.Request{
-			SourceId: l.Id(),
-			Purpose:  l.CredentialPurpose(),
-		})
+		if credential.SubtypeFromId(l.Id()) == credstatic.Subtype {
+			// Static credentials are not issued for the session.
+			continue
+		}
 		dynCreds = append(dynCreds, session.NewDynamicCredential(l.Id(), l.CredentialPurpose()))
 	}
 
+	expSeconds := int64(t.GetSessionMaxSeconds())
+	if remaining := int64(remainingTime.Seconds()); remainingTime > 0 && remaining < expSeconds {
+		expSeconds = remaining
+	}
 	expTime := timestamppb.Now()
-	expTime.Seconds += int64(t.GetSessionMaxSeconds())
+	expTime.Seconds += expSeconds
 	sessionComposition := session.ComposedOf{
 		UserId:             authResults.UserId,
 		HostId:             chosenId.hostId,
@@ -1038,6 +1140,26 @@ HostSourceIterationLoop:
 		WorkerFilter:       t.GetWorkerFilter(),
 		DynamicCredentials: dynCreds,
 	}
+	if tcpTarget, ok := t.(*target.TcpTarget); ok {
+		sessionComposition.RecordingEnabled = tcpTarget.GetSessionRecordingEnabled()
+		sessionComposition.UploadRateLimit = tcpTarget.GetUploadRateLimit()
+		sessionComposition.DownloadRateLimit = tcpTarget.GetDownloadRateLimit()
+		if tcpTarget.GetSessionApprovalRequired() {
+			// The session must be approved, and its authorization retrieved,
+			// within the approval window, which ends no later than the
+			// expiration of the session.
+			approvalSeconds := expSeconds
+			if window := int64(tcpTarget.GetSessionApprovalWindowSeconds()); window > 0 && window < approvalSeconds {
+				approvalSeconds = window
+			}
+			approvalExpTime := proto.Clone(expTime).(*timestamppb.Timestamp)
+			approvalExpTime.Seconds -= expSeconds - approvalSeconds
+			sessionComposition.ApprovalExpirationTime = &timestamp.Timestamp{Timestamp: approvalExpTime}
+		}
+	}
+	if sshTarget, ok := t.(*target.SshTarget); ok {
+		sessionComposition.EndpointHostKey = sshTarget.GetHostKey()
+	}
 
 	sess, err := session.New(sessionComposition)
 	if err != nil {
@@ -1047,25 +1169,176 @@ HostSourceIterationLoop:
 	if err != nil {
 		return nil, err
 	}
+	// The policies are checked again when the session is created, since
+	// other sessions of the user may have been created since the check above.
 	sess, privKey, err := sessionRepo.CreateSession(ctx, wrapper, sess)
+	if err != nil {
+		return nil, sessionPolicyError(err)
+	}
+
+	if sess.ApprovalExpirationTime != nil {
+		// No credentials are issued until the session is approved. The user
+		// retrieves the authorization of the session once it is approved.
+		event.WriteSysEvent(ctx, op, "session approval requested", "session_id", sess.PublicId, "target_id", t.GetPublicId(), "user_id", authResults.UserId)
+		ret := &pb.SessionAuthorization{
+			SessionId:   sess.PublicId,
+			TargetId:    t.GetPublicId(),
+			Scope:       authResults.Scope,
+			CreatedTime: sess.CreateTime.GetTimestamp(),
+			Type:        t.GetType(),
+			UserId:      authResults.UserId,
+			HostId:      chosenId.hostId,
+			HostSetId:   chosenId.hostSetId,
+			Endpoint:    endpointUrl.String(),
+			Status:      session.StatusPendingApproval.String(),
+		}
+		return &pbs.AuthorizeSessionResponse{Item: ret}, nil
+	}
+
+	ret, err := s.sessionAuthorization(ctx, sessionRepo, t, libs, sess, privKey, workers, authResults)
 	if err != nil {
 		return nil, err
 	}
+	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
+}
+
+// authorizeApprovedSession returns the authorization of the approved session
+// sessionId of the target t to the user of the session. The authorization of
+// a session can only be retrieved once.
+func (s Service) authorizeApprovedSession(ctx context.Context, sessionId string, t target.Target, libs []target.CredentialSource, authResults auth.VerifyResults, sessionRepo *session.Repository, serversRepo *servers.Repository) (*pbs.AuthorizeSessionResponse, error) {
+	const op = "targets.(Service).authorizeApprovedSession"
+	sess, _, err := sessionRepo.LookupSession(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	if sess == nil || sess.TargetId != t.GetPublicId() || sess.UserId != authResults.UserId {
+		return nil, handlers.NotFoundErrorf("Session %q not found.", sessionId)
+	}
+
+	workers, err := authorizedWorkers(ctx, serversRepo, t)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err = sessionRepo.ClaimApprovedSession(ctx, sessionId, authResults.UserId)
+	if err != nil {
+		if errors.Match(errors.T(errors.InvalidSessionState), err) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(
+				codes.FailedPrecondition,
+				fmt.Sprintf("Session %q is not approved, has expired, or its authorization was already retrieved.", sessionId))
+		}
+		return nil, err
+	}
+
+	// The private key of the session is derived from the key version which
+	// was used to create its certificate.
+	wrapper, err := s.kmsCache.GetWrapper(ctx, sess.ScopeId, kms.KeyPurposeSessions, kms.WithKeyId(sess.KeyId))
+	if err != nil {
+		return nil, err
+	}
+	_, privKey, err := session.DeriveED25519Key(wrapper, sess.UserId, sess.PublicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	ret, err := s.sessionAuthorization(ctx, sessionRepo, t, libs, sess, privKey, workers, authResults)
+	if err != nil {
+		return nil, err
+	}
+	event.WriteSysEvent(ctx, op, "approved session authorized", "session_id", sess.PublicId, "user_id", sess.UserId, "approver_id", sess.ApproverId)
+	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
+}
+
+// credentialSessionData returns the data about the session sess to target t
+// which credential libraries can use when issuing credentials. The account
+// data is from the user's account in the primary auth method of the user's
+// scope.
+func (s Service) credentialSessionData(ctx context.Context, t target.Target, sess *session.Session) (*credential.SessionData, error) {
+	const op = "targets.(Service).credentialSessionData"
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	u, _, err := iamRepo.LookupUser(ctx, sess.UserId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sd := &credential.SessionData{
+		UserId:     sess.UserId,
+		TargetId:   t.GetPublicId(),
+		TargetName: t.GetName(),
+		HostId:     sess.HostId,
+	}
+	if u != nil {
+		sd.UserName = u.GetName()
+		sd.AccountId = u.GetPrimaryAccountId()
+		sd.LoginName = u.GetLoginName()
+		sd.FullName = u.GetFullName()
+		sd.Email = u.GetEmail()
+	}
+	if sess.Endpoint != "" {
+		endpoint, err := url.Parse(sess.Endpoint)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("parsing session endpoint"))
+		}
+		sd.HostAddress = endpoint.Hostname()
+	}
+	if exp := sess.ExpirationTime.GetTimestamp(); exp != nil {
+		sd.Ttl = time.Until(exp.AsTime()).Round(time.Second)
+	}
+	return sd, nil
+}
+
+// sessionAuthorization issues the credentials of the session sess and returns
+// its authorization.
+func (s Service) sessionAuthorization(ctx context.Context, sessionRepo *session.Repository, t target.Target, libs []target.CredentialSource, sess *session.Session, privKey []byte, workers []*pb.WorkerInfo, authResults auth.VerifyResults) (*pb.SessionAuthorization, error) {
+	const op = "targets.(Service).sessionAuthorization"
+	var reqs []credential.Request
+	var staticIds []string
+	staticSeen := make(map[string]bool)
+	for _, l := range libs {
+		if credential.SubtypeFromId(l.Id()) == credstatic.Subtype {
+			if !staticSeen[l.Id()] {
+				staticSeen[l.Id()] = true
+				staticIds = append(staticIds, l.Id())
+			}
+			continue
+		}
+		reqs = append(reqs, credential.Request{
+			SourceId: l.Id(),
+			Purpose:  l.CredentialPurpose(),
+		})
+	}
 
 	var cs []credential.Dynamic
 	if len(reqs) > 0 {
+		sd, err := s.credentialSessionData(ctx, t, sess)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
 		credRepo, err := s.vaultCredRepoFn()
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
-		cs, err = credRepo.Issue(ctx, sess.GetPublicId(), reqs)
+		cs, err = credRepo.Issue(ctx, sess.GetPublicId(), reqs, credential.WithSessionData(sd))
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
 	}
 
 	var creds []*pb.SessionCredential
+	var egressCreds []session.Credential
 	for _, c := range cs {
+		if c.Purpose() == credential.EgressPurpose {
+			// Egress credentials are handed to the worker through the
+			// session and are never returned to the user.
+			ec, err := egressCredential(ctx, c)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			egressCreds = append(egressCreds, ec)
+			continue
+		}
 		l := c.Library()
 		secret := c.Secret()
 		// TODO: Access the json directly from the vault response instead of re-marshalling it.
@@ -1091,6 +1364,10 @@ HostSourceIterationLoop:
 				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for secret"))
 			}
 		}
+		typed, err := typedCredential(c)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for credential"))
+		}
 		creds = append(creds, &pb.SessionCredential{
 			CredentialLibrary: &pb.CredentialLibrary{
 				Id:                l.GetPublicId(),
@@ -1105,14 +1382,56 @@ HostSourceIterationLoop:
 				Description:       l.GetDescription(),
 				CredentialStoreId: l.GetStoreId(),
 				Type:              credential.SubtypeFromId(l.GetPublicId()).String(),
+				CredentialType:    string(l.CredentialType()),
 			},
 			Secret: &pb.SessionSecret{
 				Raw:     base64.StdEncoding.EncodeToString(jSecret),
 				Decoded: sSecret,
 			},
+			Credential: typed,
 		})
 	}
 
+	if len(staticIds) > 0 {
+		staticRepo, err := s.staticCredRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		scs, err := staticRepo.Retrieve(ctx, staticIds)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		staticCreds := make(map[string]credential.Static, len(scs))
+		for _, c := range scs {
+			staticCreds[c.GetPublicId()] = c
+		}
+		for _, l := range libs {
+			c, ok := staticCreds[l.Id()]
+			if !ok {
+				continue
+			}
+			if l.CredentialPurpose() == credential.EgressPurpose {
+				ec, err := egressCredential(ctx, c)
+				if err != nil {
+					return nil, errors.Wrap(ctx, err, op)
+				}
+				egressCreds = append(egressCreds, ec)
+				continue
+			}
+			sc, err := staticSessionCredential(c)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			creds = append(creds, sc)
+		}
+	}
+
+	if len(egressCreds) > 0 {
+		if err := sessionRepo.AddSessionCredentials(ctx, sess.ScopeId, sess.GetPublicId(), egressCreds); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
 	sad := &pb.SessionAuthorizationData{
 		SessionId:       sess.PublicId,
 		TargetId:        t.GetPublicId(),
@@ -1121,8 +1440,8 @@ HostSourceIterationLoop:
 		Type:            t.GetType(),
 		Certificate:     sess.Certificate,
 		PrivateKey:      privKey,
-		HostId:          chosenId.hostId,
-		Endpoint:        endpointUrl.String(),
+		HostId:          sess.HostId,
+		Endpoint:        sess.Endpoint,
 		WorkerInfo:      workers,
 		ConnectionLimit: t.GetSessionConnectionLimit(),
 	}
@@ -1140,12 +1459,102 @@ HostSourceIterationLoop:
 		Type:               t.GetType(),
 		AuthorizationToken: string(encodedMarshaledSad),
 		UserId:             authResults.UserId,
-		HostId:             chosenId.hostId,
-		HostSetId:          chosenId.hostSetId,
-		Endpoint:           endpointUrl.String(),
+		HostId:             sess.HostId,
+		HostSetId:          sess.HostSetId,
+		Endpoint:           sess.Endpoint,
 		Credentials:        creds,
 	}
-	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
+	return ret, nil
+}
+
+// sessionPolicyError converts the errors returned when a session policy limit
+// has been reached into API errors. Other errors are returned unchanged.
+func sessionPolicyError(err error) error {
+	switch {
+	case errors.Match(errors.T(errors.SessionLimitExceeded), err):
+		return handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "The maximum number of active sessions for this user has been reached.")
+	case errors.Match(errors.T(errors.SessionTimeLimitExceeded), err):
+		return handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "The maximum daily session time for this user has been reached.")
+	}
+	return err
+}
+
+// authorizedWorkers returns the workers which can handle a session of the
+// target t, after any filtering. It returns a FailedPrecondition error if
+// there are none.
+func authorizedWorkers(ctx context.Context, serversRepo *servers.Repository, t target.Target) ([]*pb.WorkerInfo, error) {
+	// WorkerInfo only contains the address; worker IDs below is used to
+	// contain their IDs in the same order. This is used to fetch tags for
+	// filtering. But we avoid allocation unless we actually need it.
+	var workers []*pb.WorkerInfo
+	var workerIds []string
+	hasWorkerFilter := len(t.GetWorkerFilter()) > 0
+	servers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range servers {
+		if hasWorkerFilter {
+			workerIds = append(workerIds, v.GetPrivateId())
+		}
+		workers = append(workers, &pb.WorkerInfo{Address: v.Address})
+	}
+
+	if hasWorkerFilter && len(workerIds) > 0 {
+		finalWorkers := make([]*pb.WorkerInfo, 0, len(workers))
+		// Fetch the tags for the given worker IDs
+		tags, err := serversRepo.ListTagsForServers(ctx, workerIds)
+		if err != nil {
+			return nil, err
+		}
+		// Build the map for filtering. This is similar to the filter map we
+		// built from the worker config, but with one extra level: a map of the
+		// worker's ID to its filter map.
+		tagMap := make(map[string]map[string][]string)
+		for _, tag := range tags {
+			currWorkerMap := tagMap[tag.ServerId]
+			if currWorkerMap == nil {
+				currWorkerMap = make(map[string][]string)
+				tagMap[tag.ServerId] = currWorkerMap
+			}
+			currWorkerMap[tag.Key] = append(currWorkerMap[tag.Key], tag.Value)
+			// We don't need to reinsert after the fact because maps are
+			// reference types, so we don't need to re-insert into tagMap
+		}
+
+		// Create the evaluator
+		eval, err := bexpr.CreateEvaluator(t.GetWorkerFilter())
+		if err != nil {
+			return nil, err
+		}
+
+		// Iterate through the known worker IDs, and evaluate. If evaluation
+		// returns true, add to the final worker slice, which is assigned back
+		// to workers after this.
+		for i, worker := range workerIds {
+			filterInput := map[string]interface{}{
+				"name": worker,
+				"tags": tagMap[worker],
+			}
+			ok, err := eval.Evaluate(filterInput)
+			if err != nil && !stderrors.Is(err, pointerstructure.ErrNotFound) {
+				return nil, handlers.ApiErrorWithCodeAndMessage(
+					codes.FailedPrecondition,
+					fmt.Sprintf("Worker filter expression evaluation resulted in error: %s", err))
+			}
+			if ok {
+				finalWorkers = append(finalWorkers, workers[i])
+			}
+		}
+		workers = finalWorkers
+	}
+	if len(workers) == 0 {
+		return nil, handlers.ApiErrorWithCodeAndMessage(
+			codes.FailedPrecondition,
+			"No workers are available to handle this session, or all have been filtered.")
+	}
+
+	return workers, nil
 }
 
 func (s Service) getFromRepo(ctx context.Context, id string) (target.Target, []target.HostSource, []target.CredentialSource, error) {
@@ -1181,22 +1590,72 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 	if item.GetWorkerFilter() != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
-	tcpAttrs := &pb.TcpTargetAttributes{}
-	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
-	}
-	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
-		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
-	}
-	u, err := target.NewTcpTarget(item.GetScopeId(), opts...)
-	if err != nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
-	}
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	out, hs, cl, err := repo.CreateTcpTarget(ctx, u)
+	var out target.Target
+	var hs []target.HostSource
+	var cl []target.CredentialSource
+	switch target.SubtypeFromType(item.GetType()) {
+	case target.SshSubtype:
+		sshAttrs := &pb.SshTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), sshAttrs); err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if sshAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(sshAttrs.GetDefaultPort().GetValue()))
+		}
+		if sshAttrs.GetHostKey() != nil {
+			opts = append(opts, target.WithHostKey(sshAttrs.GetHostKey().GetValue()))
+		}
+		u, err := target.NewSshTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, hs, cl, err = repo.CreateSshTarget(ctx, u)
+	case target.UdpSubtype:
+		udpAttrs := &pb.UdpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), udpAttrs); err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if udpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(udpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewUdpTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, hs, cl, err = repo.CreateUdpTarget(ctx, u)
+	default:
+		tcpAttrs := &pb.TcpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if tcpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+		}
+		if tcpAttrs.GetSessionRecordingEnabled().GetValue() {
+			opts = append(opts, target.WithSessionRecordingEnabled(true))
+		}
+		if tcpAttrs.GetUploadRateLimit() != nil {
+			opts = append(opts, target.WithUploadRateLimit(tcpAttrs.GetUploadRateLimit().GetValue()))
+		}
+		if tcpAttrs.GetDownloadRateLimit() != nil {
+			opts = append(opts, target.WithDownloadRateLimit(tcpAttrs.GetDownloadRateLimit().GetValue()))
+		}
+		if tcpAttrs.GetSessionApprovalRequired().GetValue() {
+			opts = append(opts, target.WithSessionApprovalRequired(true))
+		}
+		if tcpAttrs.GetSessionApprovalWindowSeconds() != nil {
+			opts = append(opts, target.WithSessionApprovalWindowSeconds(tcpAttrs.GetSessionApprovalWindowSeconds().GetValue()))
+		}
+		u, err := target.NewTcpTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, hs, cl, err = repo.CreateTcpTarget(ctx, u)
+	}
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create target"))
 	}
@@ -1224,20 +1683,11 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if filter := item.GetWorkerFilter(); filter != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
-	tcpAttrs := &pb.TcpTargetAttributes{}
-	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
-	}
-	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
-		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
-	}
 	version := item.GetVersion()
-	u, err := target.NewTcpTarget(scopeId, opts...)
-	if err != nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
-	}
-	u.PublicId = id
 	dbMask := maskManager.Translate(mask)
+	if target.SubtypeFromId(id) == target.SshSubtype {
+		dbMask = sshMaskManager.Translate(mask)
+	}
 	if len(dbMask) == 0 {
 		return nil, nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
 	}
@@ -1245,7 +1695,72 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	out, hs, cl, rowsUpdated, err := repo.UpdateTcpTarget(ctx, u, version, dbMask)
+	var out target.Target
+	var hs []target.HostSource
+	var cl []target.CredentialSource
+	var rowsUpdated int
+	switch target.SubtypeFromId(id) {
+	case target.SshSubtype:
+		sshAttrs := &pb.SshTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), sshAttrs); err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if sshAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(sshAttrs.GetDefaultPort().GetValue()))
+		}
+		if sshAttrs.GetHostKey() != nil {
+			opts = append(opts, target.WithHostKey(sshAttrs.GetHostKey().GetValue()))
+		}
+		u, err := target.NewSshTarget(scopeId, opts...)
+		if err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		out, hs, cl, rowsUpdated, err = repo.UpdateSshTarget(ctx, u, version, dbMask)
+	case target.UdpSubtype:
+		udpAttrs := &pb.UdpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), udpAttrs); err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if udpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(udpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewUdpTarget(scopeId, opts...)
+		if err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		out, hs, cl, rowsUpdated, err = repo.UpdateUdpTarget(ctx, u, version, dbMask)
+	default:
+		tcpAttrs := &pb.TcpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if tcpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+		}
+		if tcpAttrs.GetSessionRecordingEnabled().GetValue() {
+			opts = append(opts, target.WithSessionRecordingEnabled(true))
+		}
+		if tcpAttrs.GetUploadRateLimit() != nil {
+			opts = append(opts, target.WithUploadRateLimit(tcpAttrs.GetUploadRateLimit().GetValue()))
+		}
+		if tcpAttrs.GetDownloadRateLimit() != nil {
+			opts = append(opts, target.WithDownloadRateLimit(tcpAttrs.GetDownloadRateLimit().GetValue()))
+		}
+		if tcpAttrs.GetSessionApprovalRequired().GetValue() {
+			opts = append(opts, target.WithSessionApprovalRequired(true))
+		}
+		if tcpAttrs.GetSessionApprovalWindowSeconds() != nil {
+			opts = append(opts, target.WithSessionApprovalWindowSeconds(tcpAttrs.GetSessionApprovalWindowSeconds().GetValue()))
+		}
+		u, err := target.NewTcpTarget(scopeId, opts...)
+		if err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		out, hs, cl, rowsUpdated, err = repo.UpdateTcpTarget(ctx, u, version, dbMask)
+	}
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update target"))
 	}
@@ -1255,6 +1770,14 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	return out, hs, cl, nil
 }
 
+// dedupCredentialSources removes duplicate ids within each purpose of cs.
+func dedupCredentialSources(cs target.CredentialSources) target.CredentialSources {
+	return target.CredentialSources{
+		ApplicationCredentialIds: strutil.RemoveDuplicates(cs.ApplicationCredentialIds, false),
+		EgressCredentialIds:      strutil.RemoveDuplicates(cs.EgressCredentialIds, false),
+	}
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	const op = "targets.(Service).deleteFromRepo"
 	repo, err := s.repoFn()
@@ -1271,12 +1794,16 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]target.Target, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, pg *handlers.Paginator) ([]target.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListTargets(ctx, target.WithScopeIds(scopeIds))
+	opts := []target.Option{target.WithScopeIds(scopeIds)}
+	if pg.Enabled() {
+		opts = append(opts, target.WithStartPageAfterItem(pg.StartAfter()), target.WithLimit(pg.BatchSize()))
+	}
+	ul, err := repo.ListTargets(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1342,12 +1869,12 @@ func (s Service) removeHostSourcesInRepo(ctx context.Context, targetId string, h
 	return out, hs, cl, nil
 }
 
-func (s Service) addCredentialSourcesInRepo(ctx context.Context, targetId string, ids []string, version uint32) (target.Target, []target.HostSource, []target.CredentialSource, error) {
+func (s Service) addCredentialSourcesInRepo(ctx context.Context, targetId string, ids target.CredentialSources, version uint32) (target.Target, []target.HostSource, []target.CredentialSource, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	out, hs, credSources, err := repo.AddTargetCredentialSources(ctx, targetId, version, strutil.RemoveDuplicates(ids, false))
+	out, hs, credSources, err := repo.AddTargetCredentialSources(ctx, targetId, version, dedupCredentialSources(ids))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add credential sources to target: %v.", err)
@@ -1358,13 +1885,13 @@ func (s Service) addCredentialSourcesInRepo(ctx context.Context, targetId string
 	return out, hs, credSources, nil
 }
 
-func (s Service) setCredentialSourcesInRepo(ctx context.Context, targetId string, ids []string, version uint32) (target.Target, []target.HostSource, []target.CredentialSource, error) {
+func (s Service) setCredentialSourcesInRepo(ctx context.Context, targetId string, ids target.CredentialSources, version uint32) (target.Target, []target.HostSource, []target.CredentialSource, error) {
 	const op = "targets.(Service).setCredentialSourcesInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	_, _, _, err = repo.SetTargetCredentialSources(ctx, targetId, version, strutil.RemoveDuplicates(ids, false))
+	_, _, _, err = repo.SetTargetCredentialSources(ctx, targetId, version, dedupCredentialSources(ids))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set credential sources in target: %v.", err)
@@ -1380,13 +1907,13 @@ func (s Service) setCredentialSourcesInRepo(ctx context.Context, targetId string
 	return out, hs, credSources, nil
 }
 
-func (s Service) removeCredentialSourcesInRepo(ctx context.Context, targetId string, ids []string, version uint32) (target.Target, []target.HostSource, []target.CredentialSource, error) {
+func (s Service) removeCredentialSourcesInRepo(ctx context.Context, targetId string, ids target.CredentialSources, version uint32) (target.Target, []target.HostSource, []target.CredentialSource, error) {
 	const op = "targets.(Service).removeCredentialSourcesInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	_, err = repo.DeleteTargetCredentialSources(ctx, targetId, version, strutil.RemoveDuplicates(ids, false))
+	_, err = repo.DeleteTargetCredentialSources(ctx, targetId, version, dedupCredentialSources(ids))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove credential sources from target: %v.", err)
@@ -1446,7 +1973,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, looku
 		}
 		id = t.GetPublicId()
 		parentId = t.GetScopeId()
-		opts = append(opts, auth.WithId(id))
+		opts = append(opts, auth.WithId(id), auth.WithName(t.GetName()))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	ret := auth.Verify(ctx, opts...)
@@ -1454,6 +1981,23 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, looku
 	return ret
 }
 
+// mineAuthResult authenticates the requester of a listing of the targets it
+// can connect to. The requester doesn't need to be granted the list action
+// since every target is authorized individually, but it must be authenticated
+// as a user.
+func (s Service) mineAuthResult(ctx context.Context) auth.VerifyResults {
+	res := auth.Verify(ctx,
+		auth.WithScopeId(scope.Global.String()),
+		auth.WithType(resource.Target),
+		auth.WithAction(action.List),
+		auth.WithRecoveryTokenNotAllowed(true),
+		auth.WithAnonymousUserNotAllowed(true))
+	if res.Error == handlers.ForbiddenError() && res.AuthenticationFinished {
+		res.Error = nil
+	}
+	return res
+}
+
 func toProto(ctx context.Context, in target.Target, hostSources []target.HostSource, credSources []target.CredentialSource, opt ...handlers.Option) (*pb.Target, error) {
 	const op = "target_service.toProto"
 	opts := handlers.GetOpts(opt...)
@@ -1470,7 +2014,7 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 		out.ScopeId = in.GetScopeId()
 	}
 	if outputFields.Has(globals.TypeField) {
-		out.Type = target.TcpTargetType.String()
+		out.Type = target.SubtypeFromId(in.GetPublicId()).String()
 	}
 	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
 		out.Description = wrapperspb.String(in.GetDescription())
@@ -1530,12 +2074,23 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 	}
 	if outputFields.Has(globals.ApplicationCredentialLibraryIdsField) {
 		for _, cs := range credSources {
-			out.ApplicationCredentialLibraryIds = append(out.ApplicationCredentialLibraryIds, cs.Id())
+			if cs.CredentialPurpose() == credential.ApplicationPurpose {
+				out.ApplicationCredentialLibraryIds = append(out.ApplicationCredentialLibraryIds, cs.Id())
+			}
 		}
 	}
 	if outputFields.Has(globals.ApplicationCredentialSourceIdsField) {
 		for _, cs := range credSources {
-			out.ApplicationCredentialSourceIds = append(out.ApplicationCredentialSourceIds, cs.Id())
+			if cs.CredentialPurpose() == credential.ApplicationPurpose {
+				out.ApplicationCredentialSourceIds = append(out.ApplicationCredentialSourceIds, cs.Id())
+			}
+		}
+	}
+	if outputFields.Has(globals.EgressCredentialSourceIdsField) {
+		for _, cs := range credSources {
+			if cs.CredentialPurpose() == credential.EgressPurpose {
+				out.EgressCredentialSourceIds = append(out.EgressCredentialSourceIds, cs.Id())
+			}
 		}
 	}
 	if outputFields.Has(globals.ApplicationCredentialLibrariesField) {
@@ -1568,10 +2123,61 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 			}
 		}
 	}
+	if outputFields.Has(globals.EgressCredentialSourcesField) {
+		for _, cs := range credSources {
+			switch credential.Purpose(cs.CredentialPurpose()) {
+			case credential.EgressPurpose:
+				out.EgressCredentialSources = append(out.EgressCredentialSources, &pb.CredentialSource{
+					Id:                cs.Id(),
+					CredentialStoreId: cs.CredentialStoreId(),
+				})
+			case credential.ApplicationPurpose, credential.IngressPurpose:
+			default:
+				return nil, errors.New(ctx, errors.Internal, op, fmt.Sprintf("unrecognized purpose %q for credential source on target", cs.CredentialPurpose()))
+			}
+		}
+	}
 	if outputFields.Has(globals.AttributesField) {
-		attrs := &pb.TcpTargetAttributes{}
-		if in.GetDefaultPort() > 0 {
-			attrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+		var attrs proto.Message
+		switch target.SubtypeFromId(in.GetPublicId()) {
+		case target.SshSubtype:
+			sshAttrs := &pb.SshTargetAttributes{}
+			if in.GetDefaultPort() > 0 {
+				sshAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+			}
+			if sshTarget, ok := in.(*target.SshTarget); ok && sshTarget.GetHostKey() != "" {
+				sshAttrs.HostKey = &wrappers.StringValue{Value: sshTarget.GetHostKey()}
+			}
+			attrs = sshAttrs
+		case target.UdpSubtype:
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if in.GetDefaultPort() > 0 {
+				udpAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+			}
+			attrs = udpAttrs
+		default:
+			tcpAttrs := &pb.TcpTargetAttributes{}
+			if in.GetDefaultPort() > 0 {
+				tcpAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+			}
+			if tcpTarget, ok := in.(*target.TcpTarget); ok {
+				if tcpTarget.GetSessionRecordingEnabled() {
+					tcpAttrs.SessionRecordingEnabled = &wrappers.BoolValue{Value: true}
+				}
+				if tcpTarget.GetUploadRateLimit() > 0 {
+					tcpAttrs.UploadRateLimit = &wrappers.UInt32Value{Value: tcpTarget.GetUploadRateLimit()}
+				}
+				if tcpTarget.GetDownloadRateLimit() > 0 {
+					tcpAttrs.DownloadRateLimit = &wrappers.UInt32Value{Value: tcpTarget.GetDownloadRateLimit()}
+				}
+				if tcpTarget.GetSessionApprovalRequired() {
+					tcpAttrs.SessionApprovalRequired = &wrappers.BoolValue{Value: true}
+				}
+				if tcpTarget.GetSessionApprovalWindowSeconds() > 0 {
+					tcpAttrs.SessionApprovalWindowSeconds = &wrappers.UInt32Value{Value: tcpTarget.GetSessionApprovalWindowSeconds()}
+				}
+			}
+			attrs = tcpAttrs
 		}
 		st, err := handlers.ProtoToStruct(attrs)
 		if err != nil {
@@ -1588,7 +2194,7 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 //  * All required parameters are set
 //  * There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetTargetRequest) error {
-	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, target.TcpTargetPrefix)
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix)
 }
 
 func validateCreateRequest(req *pbs.CreateTargetRequest) error {
@@ -1621,9 +2227,30 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+		case target.SshSubtype:
+			sshAttrs := &pb.SshTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), sshAttrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+			}
+			if sshAttrs.GetDefaultPort() != nil && sshAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
+			if sshAttrs.GetHostKey() != nil {
+				if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(sshAttrs.GetHostKey().GetValue())); err != nil {
+					badFields["attributes.host_key"] = "Unable to parse the public key in the authorized_keys format."
+				}
+			}
+		case target.UdpSubtype:
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), udpAttrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+			}
+			if udpAttrs.GetDefaultPort() != nil && udpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
 		}
 		switch req.GetItem().GetType() {
-		case target.TcpTargetType.String():
+		case target.TcpTargetType.String(), target.SshTargetType.String(), target.UdpTargetType.String():
 		case "":
 			badFields[globals.TypeField] = "This is a required field."
 		default:
@@ -1656,7 +2283,7 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 		if req.GetItem().GetSessionMaxSeconds() != nil && req.GetItem().GetSessionMaxSeconds().GetValue() == 0 {
 			badFields[globals.SessionMaxSecondsField] = "This must be greater than zero."
 		}
-		switch target.SubtypeFromId(req.GetItem().GetType()) {
+		switch target.SubtypeFromId(req.GetId()) {
 		case target.TcpSubtype:
 			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.TcpSubtype {
 				badFields[globals.TypeField] = "Cannot modify the resource type."
@@ -1668,6 +2295,33 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+		case target.SshSubtype:
+			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.SshSubtype {
+				badFields[globals.TypeField] = "Cannot modify the resource type."
+			}
+			sshAttrs := &pb.SshTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), sshAttrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+			}
+			if sshAttrs.GetDefaultPort() != nil && sshAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
+			if sshAttrs.GetHostKey() != nil {
+				if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(sshAttrs.GetHostKey().GetValue())); err != nil {
+					badFields["attributes.host_key"] = "Unable to parse the public key in the authorized_keys format."
+				}
+			}
+		case target.UdpSubtype:
+			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.UdpSubtype {
+				badFields[globals.TypeField] = "Cannot modify the resource type."
+			}
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), udpAttrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+			}
+			if udpAttrs.GetDefaultPort() != nil && udpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
 		}
 		if filter := req.GetItem().GetWorkerFilter(); filter != nil {
 			if _, err := bexpr.CreateEvaluator(filter.GetValue()); err != nil {
@@ -1675,11 +2329,11 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 			}
 		}
 		return badFields
-	}, target.TcpTargetPrefix)
+	}, target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteTargetRequest) error {
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, target.TcpTargetPrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix)
 }
 
 func validateListRequest(req *pbs.ListTargetsRequest) error {
@@ -1697,9 +2351,20 @@ func validateListRequest(req *pbs.ListTargetsRequest) error {
 	return nil
 }
 
+func validateListMineRequest(req *pbs.ListMyTargetsRequest) error {
+	badFields := map[string]string{}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
 func validateAddSetsRequest(req *pbs.AddTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1709,7 +2374,7 @@ func validateAddSetsRequest(req *pbs.AddTargetHostSetsRequest) error {
 		badFields[globals.HostSetIdsField] = "Must be non-empty."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix) {
+		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix, plugin.HostSetPrefix) {
 			badFields[globals.HostSetIdsField] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -1722,14 +2387,14 @@ func validateAddSetsRequest(req *pbs.AddTargetHostSetsRequest) error {
 
 func validateSetSetsRequest(req *pbs.SetTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
 		badFields[globals.VersionField] = "Required field."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix) {
+		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix, plugin.HostSetPrefix) {
 			badFields[globals.HostSetIdsField] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -1742,7 +2407,7 @@ func validateSetSetsRequest(req *pbs.SetTargetHostSetsRequest) error {
 
 func validateRemoveSetsRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1752,7 +2417,7 @@ func validateRemoveSetsRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 		badFields[globals.HostSetIdsField] = "Must be non-empty."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix) {
+		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix, plugin.HostSetPrefix) {
 			badFields[globals.HostSetIdsField] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -1765,7 +2430,7 @@ func validateRemoveSetsRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 
 func validateAddHostSourcesRequest(req *pbs.AddTargetHostSourcesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1775,7 +2440,7 @@ func validateAddHostSourcesRequest(req *pbs.AddTargetHostSourcesRequest) error {
 		badFields[globals.HostSourceIdsField] = "Must be non-empty."
 	}
 	for _, id := range req.GetHostSourceIds() {
-		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix) {
+		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix, plugin.HostSetPrefix) {
 			badFields[globals.HostSourceIdsField] = fmt.Sprintf("Incorrectly formatted host source identifier %q.", id)
 			break
 		}
@@ -1788,14 +2453,14 @@ func validateAddHostSourcesRequest(req *pbs.AddTargetHostSourcesRequest) error {
 
 func validateSetHostSourcesRequest(req *pbs.SetTargetHostSourcesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
 		badFields[globals.VersionField] = "Required field."
 	}
 	for _, id := range req.GetHostSourceIds() {
-		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix) {
+		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix, plugin.HostSetPrefix) {
 			badFields[globals.HostSourceIdsField] = fmt.Sprintf("Incorrectly formatted host source identifier %q.", id)
 			break
 		}
@@ -1808,7 +2473,7 @@ func validateSetHostSourcesRequest(req *pbs.SetTargetHostSourcesRequest) error {
 
 func validateRemoveHostSourcesRequest(req *pbs.RemoveTargetHostSourcesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1818,7 +2483,7 @@ func validateRemoveHostSourcesRequest(req *pbs.RemoveTargetHostSourcesRequest) e
 		badFields[globals.HostSourceIdsField] = "Must be non-empty."
 	}
 	for _, id := range req.GetHostSourceIds() {
-		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix) {
+		if !handlers.ValidId(handlers.Id(id), static.HostSetPrefix, plugin.HostSetPrefix) {
 			badFields[globals.HostSourceIdsField] = fmt.Sprintf("Incorrectly formatted host source identifier %q.", id)
 			break
 		}
@@ -1831,7 +2496,7 @@ func validateRemoveHostSourcesRequest(req *pbs.RemoveTargetHostSourcesRequest) e
 
 func validateAddLibrariesRequest(req *pbs.AddTargetCredentialLibrariesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1854,7 +2519,7 @@ func validateAddLibrariesRequest(req *pbs.AddTargetCredentialLibrariesRequest) e
 
 func validateSetLibrariesRequest(req *pbs.SetTargetCredentialLibrariesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1874,7 +2539,7 @@ func validateSetLibrariesRequest(req *pbs.SetTargetCredentialLibrariesRequest) e
 
 func validateRemoveLibrariesRequest(req *pbs.RemoveTargetCredentialLibrariesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1897,21 +2562,30 @@ func validateRemoveLibrariesRequest(req *pbs.RemoveTargetCredentialLibrariesRequ
 
 func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
 		badFields[globals.VersionField] = "Required field."
 	}
-	if len(req.GetApplicationCredentialSourceIds()) == 0 {
+	if len(req.GetApplicationCredentialSourceIds()) == 0 && len(req.GetEgressCredentialSourceIds()) == 0 {
 		badFields[globals.ApplicationCredentialSourceIdsField] = "Must be non-empty."
 	}
 	for _, cl := range req.GetApplicationCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !validCredentialSourceId(cl) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
 	}
+	for _, cl := range req.GetEgressCredentialSourceIds() {
+		if !validCredentialSourceId(cl) {
+			badFields[globals.EgressCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
+			break
+		}
+	}
+	if len(req.GetEgressCredentialSourceIds()) > 0 && target.SubtypeFromId(req.GetId()) != target.SshSubtype {
+		badFields[globals.EgressCredentialSourceIdsField] = "Egress credential sources are only supported by ssh targets."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
 	}
@@ -1920,18 +2594,27 @@ func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequ
 
 func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
 		badFields[globals.VersionField] = "Required field."
 	}
 	for _, cl := range req.GetApplicationCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !validCredentialSourceId(cl) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
 	}
+	for _, cl := range req.GetEgressCredentialSourceIds() {
+		if !validCredentialSourceId(cl) {
+			badFields[globals.EgressCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
+			break
+		}
+	}
+	if len(req.GetEgressCredentialSourceIds()) > 0 && target.SubtypeFromId(req.GetId()) != target.SshSubtype {
+		badFields[globals.EgressCredentialSourceIdsField] = "Egress credential sources are only supported by ssh targets."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
 	}
@@ -1940,34 +2623,49 @@ func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequ
 
 func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourcesRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 		badFields[globals.IdField] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
 		badFields[globals.VersionField] = "Required field."
 	}
-	if len(req.GetApplicationCredentialSourceIds()) == 0 {
+	if len(req.GetApplicationCredentialSourceIds()) == 0 && len(req.GetEgressCredentialSourceIds()) == 0 {
 		badFields[globals.ApplicationCredentialLibraryIdsField] = "Must be non-empty."
 	}
 	for _, cl := range req.GetApplicationCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !validCredentialSourceId(cl) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
 	}
+	for _, cl := range req.GetEgressCredentialSourceIds() {
+		if !validCredentialSourceId(cl) {
+			badFields[globals.EgressCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
+			break
+		}
+	}
+	if len(req.GetEgressCredentialSourceIds()) > 0 && target.SubtypeFromId(req.GetId()) != target.SshSubtype {
+		badFields[globals.EgressCredentialSourceIdsField] = "Egress credential sources are only supported by ssh targets."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
 	}
 	return nil
 }
 
+// validCredentialSourceId reports whether id is the id of a credential
+// library or of a static credential.
+func validCredentialSourceId(id string) bool {
+	return handlers.ValidId(handlers.Id(id), vault.CredentialLibraryPrefix, credstatic.UsernamePasswordCredentialPrefix, credstatic.SshPrivateKeyCredentialPrefix)
+}
+
 func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	badFields := map[string]string{}
 	nameEmpty := req.GetName() == ""
 	scopeIdEmpty := req.GetScopeId() == ""
 	scopeNameEmpty := req.GetScopeName() == ""
 	if nameEmpty {
-		if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+		if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.SshTargetPrefix, target.UdpTargetPrefix) {
 			badFields[globals.IdField] = "Incorrectly formatted identifier."
 		}
 		if !scopeIdEmpty {
@@ -1991,11 +2689,19 @@ func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	}
 	if req.GetHostId() != "" {
 		switch host.SubtypeFromId(req.GetHostId()) {
-		case static.Subtype:
+		case static.Subtype, plugin.Subtype:
 		default:
 			badFields[globals.HostIdField] = "Incorrectly formatted identifier."
 		}
 	}
+	if req.GetSessionId() != "" {
+		if !handlers.ValidId(handlers.Id(req.GetSessionId()), session.SessionPrefix) {
+			badFields[globals.SessionIdField] = "Incorrectly formatted identifier."
+		}
+		if req.GetHostId() != "" {
+			badFields[globals.HostIdField] = "Cannot be provided with a session id; the host of the session is used."
+		}
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
 	}
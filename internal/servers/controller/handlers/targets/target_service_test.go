@@ -2,6 +2,8 @@ package targets_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -14,10 +16,13 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/credential"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	spbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -37,6 +42,7 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mr-tron/base58"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/protobuf/field_mask"
@@ -84,10 +90,16 @@ func testService(t *testing.T, conn *db.DB, kms *kms.Kms, wrapper wrapping.Wrapp
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, nil)
+	}
 	credentialRepoFn := func() (*vault.Repository, error) {
 		return vault.NewRepository(rw, rw, kms, sche)
 	}
-	return targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, credentialRepoFn)
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(rw, rw, kms)
+	}
+	return targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, pluginHostRepoFn, credentialRepoFn, staticCredRepoFn)
 }
 
 func TestGet(t *testing.T) {
@@ -304,6 +316,134 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestListMine(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	repoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, nil)
+	}
+	credentialRepoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	s, err := targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, pluginHostRepoFn, credentialRepoFn, staticCredRepoFn)
+	require.NoError(t, err)
+
+	org1, proj1 := iam.TestScopes(t, iamRepo)
+	_, proj2 := iam.TestScopes(t, iamRepo)
+	tar1 := target.TestTcpTarget(t, conn, proj1.GetPublicId(), "test")
+	_ = target.TestTcpTarget(t, conn, proj1.GetPublicId(), "test other")
+	tar3 := target.TestTcpTarget(t, conn, proj2.GetPublicId(), "test")
+	_ = target.TestTcpTarget(t, conn, proj2.GetPublicId(), "test other")
+
+	at := authtoken.TestAuthToken(t, conn, kms, org1.GetPublicId())
+	r1 := iam.TestRole(t, conn, proj1.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r1.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r1.GetPublicId(), fmt.Sprintf("id=%s;actions=authorize-session", tar1.GetPublicId()))
+	r2 := iam.TestRole(t, conn, proj2.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r2.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r2.GetPublicId(), fmt.Sprintf("id=%s;actions=read,authorize-session", tar3.GetPublicId()))
+	_ = iam.TestRoleGrant(t, conn, r2.GetPublicId(), "id=*;type=target;actions=read")
+
+	otherAt := authtoken.TestAuthToken(t, conn, kms, org1.GetPublicId())
+
+	cases := []struct {
+		name        string
+		tokenFormat auth.TokenFormat
+		requester   *authtoken.AuthToken
+		req         *pbs.ListMyTargetsRequest
+		wantIds     []string
+		wantErr     error
+	}{
+		{
+			name:        "Targets Of All Scopes",
+			tokenFormat: auth.AuthTokenTypeBearer,
+			requester:   at,
+			req:         &pbs.ListMyTargetsRequest{},
+			wantIds:     []string{tar1.GetPublicId(), tar3.GetPublicId()},
+		},
+		{
+			name:        "Filtered",
+			tokenFormat: auth.AuthTokenTypeBearer,
+			requester:   at,
+			req:         &pbs.ListMyTargetsRequest{Filter: fmt.Sprintf(`"/item/scope_id"==%q`, proj2.GetPublicId())},
+			wantIds:     []string{tar3.GetPublicId()},
+		},
+		{
+			name:        "No Grants",
+			tokenFormat: auth.AuthTokenTypeBearer,
+			requester:   otherAt,
+			req:         &pbs.ListMyTargetsRequest{},
+		},
+		{
+			name:        "Anonymous",
+			tokenFormat: auth.AuthTokenTypeUnknown,
+			req:         &pbs.ListMyTargetsRequest{},
+			wantErr:     handlers.UnauthenticatedError(),
+		},
+		{
+			name:        "Bad Filter",
+			tokenFormat: auth.AuthTokenTypeBearer,
+			requester:   at,
+			req:         &pbs.ListMyTargetsRequest{Filter: `"/item/id" has`},
+			wantErr:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			requestInfo := auth.RequestInfo{
+				TokenFormat: tc.tokenFormat,
+			}
+			if tc.requester != nil {
+				requestInfo.PublicId = tc.requester.GetPublicId()
+				requestInfo.Token = tc.requester.GetToken()
+			}
+			ctx := auth.NewVerifierContext(requests.NewRequestContext(context.Background()),
+				iamRepoFn, atRepoFn, serversRepoFn, kms, requestInfo)
+
+			got, err := s.ListMyTargets(ctx, tc.req)
+			if tc.wantErr != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tc.wantErr), "got error %v, wanted %v", err, tc.wantErr)
+				return
+			}
+			require.NoError(err)
+			var gotIds []string
+			for _, item := range got.GetItems() {
+				gotIds = append(gotIds, item.GetId())
+			}
+			assert.ElementsMatch(tc.wantIds, gotIds)
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -455,6 +595,18 @@ func TestCreate(t *testing.T) {
 			}},
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Create ssh target with invalid host key",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				Name:        wrapperspb.String("name"),
+				Description: wrapperspb.String("desc"),
+				Type:        target.SshTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"host_key": structpb.NewStringValue("not a key"),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "Create with unknown type",
 			req: &pbs.CreateTargetRequest{Item: &pb.Target{
@@ -2485,9 +2637,15 @@ func TestAuthorizeSession(t *testing.T) {
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, nil)
+	}
 	credentialRepoFn := func() (*vault.Repository, error) {
 		return vault.NewRepository(rw, rw, kms, sche)
 	}
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -2509,7 +2667,7 @@ func TestAuthorizeSession(t *testing.T) {
 	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
 	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
 
-	s, err := targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, credentialRepoFn)
+	s, err := targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, pluginHostRepoFn, credentialRepoFn, staticCredRepoFn)
 	require.NoError(t, err)
 
 	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test")
@@ -2541,6 +2699,7 @@ func TestAuthorizeSession(t *testing.T) {
 			"http_method":       structpb.NewStringValue("POST"),
 			"http_request_body": structpb.NewStringValue(`{"common_name":"boundary.com"}`),
 		}},
+		CredentialType: string(credential.CertificateType),
 	}})
 	require.NoError(t, err)
 
@@ -2601,6 +2760,7 @@ func TestAuthorizeSession(t *testing.T) {
 				Description:       clsResp.GetItem().GetDescription().GetValue(),
 				CredentialStoreId: store.GetPublicId(),
 				Type:              vault.Subtype.String(),
+				CredentialType:    string(credential.CertificateType),
 			},
 		}},
 		// TODO: validate the contents of the authorization token is what is expected
@@ -2628,10 +2788,281 @@ func TestAuthorizeSession(t *testing.T) {
 	}
 	gotCred.Secret = nil
 
+	require.NotNil(t, gotCred.Credential)
+	typed := gotCred.Credential.AsMap()
+	assert.Len(t, typed, 2)
+	assert.Equal(t, dSec["certificate"], typed["certificate"])
+	assert.Equal(t, dSec["private_key"], typed["private_key"])
+	gotCred.Credential = nil
+
 	got.AuthorizationToken, got.SessionId, got.CreatedTime = "", "", nil
 	assert.Empty(t, cmp.Diff(got, want, protocmp.Transform()))
 }
 
+func TestAuthorizeSession_Policies(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	repoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, nil)
+	}
+	credentialRepoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	ctx := auth.NewVerifierContext(requests.NewRequestContext(context.Background()),
+		iamRepoFn,
+		atRepoFn,
+		serversRepoFn,
+		kms,
+		auth.RequestInfo{
+			Token:       at.GetToken(),
+			TokenFormat: auth.AuthTokenTypeBearer,
+			PublicId:    at.GetPublicId(),
+		})
+
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+
+	s, err := targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, pluginHostRepoFn, credentialRepoFn, staticCredRepoFn)
+	require.NoError(t, err)
+
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test", target.WithSessionMaxSeconds(3600))
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	_ = static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	_, err = s.AddTargetHostSets(ctx, &pbs.AddTargetHostSetsRequest{
+		Id:         tar.GetPublicId(),
+		Version:    tar.GetVersion(),
+		HostSetIds: []string{hs.GetPublicId()},
+	})
+	require.NoError(t, err)
+
+	// Tell our DB that there is a worker ready to serve the data
+	workerService := workers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, &sync.Map{}, kms)
+	_, err = workerService.Status(ctx, &spbs.StatusRequest{
+		Worker: &spb.Server{
+			PrivateId: "testworker",
+			Address:   "localhost:8457",
+		},
+	})
+	require.NoError(t, err)
+
+	// The org policy limits the active sessions and the project policy limits
+	// the daily session time to less than twice the session max seconds of the
+	// target.
+	_ = session.TestPolicy(t, conn, org.GetPublicId(), session.WithMaxActiveSessionsPerUser(3))
+	projPolicy := session.TestPolicy(t, conn, proj.GetPublicId(), session.WithMaxDailySessionSecondsPerUser(5400))
+
+	sessionRepo, err := sessionRepoFn()
+	require.NoError(t, err)
+	authorize := func(t *testing.T) (*session.Session, error) {
+		t.Helper()
+		res, err := s.AuthorizeSession(ctx, &pbs.AuthorizeSessionRequest{Id: tar.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		sess, _, err := sessionRepo.LookupSession(ctx, res.GetItem().GetSessionId())
+		require.NoError(t, err)
+		return sess, nil
+	}
+	duration := func(sess *session.Session) float64 {
+		return sess.ExpirationTime.Timestamp.AsTime().Sub(sess.CreateTime.Timestamp.AsTime()).Seconds()
+	}
+
+	sess, err := authorize(t)
+	require.NoError(t, err)
+	assert.InDelta(t, 3600, duration(sess), 60)
+
+	// Only half an hour of session time is left for the day.
+	sess, err = authorize(t)
+	require.NoError(t, err)
+	assert.InDelta(t, 1800, duration(sess), 60)
+
+	_, err = authorize(t)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.ResourceExhausted)), "got error %v, wanted resource exhausted", err)
+	assert.Contains(t, err.Error(), "daily session time")
+
+	// Without the daily limit only the active sessions are limited.
+	projPolicy.MaxDailySessionSecondsPerUser = 0
+	_, _, err = sessionRepo.UpdatePolicy(ctx, projPolicy, projPolicy.Version, []string{"MaxDailySessionSecondsPerUser"})
+	require.NoError(t, err)
+	_, err = authorize(t)
+	require.NoError(t, err)
+	_, err = authorize(t)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.ResourceExhausted)), "got error %v, wanted resource exhausted", err)
+	assert.Contains(t, err.Error(), "active sessions")
+
+	// Terminated sessions are no longer active.
+	_, err = sessionRepo.TerminateSession(ctx, sess.PublicId, sess.Version, session.ClosedByUser)
+	require.NoError(t, err)
+	_, err = authorize(t)
+	assert.NoError(t, err)
+}
+
+func TestAuthorizeSession_Approval(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	repoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, nil)
+	}
+	credentialRepoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	ctx := auth.NewVerifierContext(requests.NewRequestContext(context.Background()),
+		iamRepoFn,
+		atRepoFn,
+		serversRepoFn,
+		kms,
+		auth.RequestInfo{
+			Token:       at.GetToken(),
+			TokenFormat: auth.AuthTokenTypeBearer,
+			PublicId:    at.GetPublicId(),
+		})
+
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+
+	s, err := targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, pluginHostRepoFn, credentialRepoFn, staticCredRepoFn)
+	require.NoError(t, err)
+
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test",
+		target.WithSessionMaxSeconds(3600),
+		target.WithSessionApprovalRequired(true),
+		target.WithSessionApprovalWindowSeconds(600))
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	_ = static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	_, err = s.AddTargetHostSets(ctx, &pbs.AddTargetHostSetsRequest{
+		Id:         tar.GetPublicId(),
+		Version:    tar.GetVersion(),
+		HostSetIds: []string{hs.GetPublicId()},
+	})
+	require.NoError(t, err)
+
+	// Tell our DB that there is a worker ready to serve the data
+	workerService := workers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, &sync.Map{}, kms)
+	_, err = workerService.Status(ctx, &spbs.StatusRequest{
+		Worker: &spb.Server{
+			PrivateId: "testworker",
+			Address:   "localhost:8457",
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := s.AuthorizeSession(ctx, &pbs.AuthorizeSessionRequest{Id: tar.GetPublicId()})
+	require.NoError(t, err)
+	pending := res.GetItem()
+	assert.Equal(t, session.StatusPendingApproval.String(), pending.GetStatus())
+	assert.Empty(t, pending.GetAuthorizationToken())
+
+	sessionRepo, err := sessionRepoFn()
+	require.NoError(t, err)
+	sess, _, err := sessionRepo.LookupSession(ctx, pending.GetSessionId())
+	require.NoError(t, err)
+	assert.InDelta(t, 600, sess.ApprovalExpirationTime.Timestamp.AsTime().Sub(sess.CreateTime.Timestamp.AsTime()).Seconds(), 60)
+
+	claim := func() (*pb.SessionAuthorization, error) {
+		res, err := s.AuthorizeSession(ctx, &pbs.AuthorizeSessionRequest{
+			Id:        tar.GetPublicId(),
+			SessionId: pending.GetSessionId(),
+		})
+		return res.GetItem(), err
+	}
+
+	// The authorization cannot be retrieved before the session is approved.
+	_, err = claim()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "got error %v, wanted failed precondition", err)
+
+	approver := iam.TestUser(t, iamRepo, org.GetPublicId())
+	_, err = sessionRepo.ApproveSession(ctx, sess.PublicId, sess.Version, approver.GetPublicId())
+	require.NoError(t, err)
+
+	got, err := claim()
+	require.NoError(t, err)
+	assert.Empty(t, got.GetStatus())
+	assert.Equal(t, pending.GetSessionId(), got.GetSessionId())
+	assert.Equal(t, h.GetPublicId(), got.GetHostId())
+	require.NotEmpty(t, got.GetAuthorizationToken())
+
+	// The private key must match the certificate of the session.
+	marshaledSad, err := base58.FastBase58Decoding(got.GetAuthorizationToken())
+	require.NoError(t, err)
+	sad := &pb.SessionAuthorizationData{}
+	require.NoError(t, proto.Unmarshal(marshaledSad, sad))
+	cert, err := x509.ParseCertificate(sad.GetCertificate())
+	require.NoError(t, err)
+	assert.Equal(t, cert.PublicKey, ed25519.PrivateKey(sad.GetPrivateKey()).Public())
+
+	// The authorization can only be retrieved once.
+	_, err = claim()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "got error %v, wanted failed precondition", err)
+}
+
 func TestAuthorizeSession_Errors(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -2655,15 +3086,21 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, nil)
+	}
 	credentialRepoFn := func() (*vault.Repository, error) {
 		return vault.NewRepository(rw, rw, kms, sche)
 	}
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
 	org, proj := iam.TestScopes(t, iamRepo)
 
-	s, err := targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, credentialRepoFn)
+	s, err := targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, pluginHostRepoFn, credentialRepoFn, staticCredRepoFn)
 	require.NoError(t, err)
 
 	// Authorized user gets full permissions
@@ -0,0 +1,782 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/workers"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// maxTagLength is the maximum length of the keys and values of worker tags.
+const maxTagLength = 512
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Delete,
+		action.AddWorkerTags,
+		action.SetWorkerTags,
+		action.RemoveWorkerTags,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+// Service handles request as described by the pbs.WorkerServiceServer interface.
+type Service struct {
+	pbs.UnimplementedWorkerServiceServer
+
+	iamRepoFn     common.IamRepoFactory
+	serversRepoFn common.ServersRepoFactory
+}
+
+// NewService returns a worker service which handles worker related requests to boundary.
+func NewService(iamRepo common.IamRepoFactory, serversRepo common.ServersRepoFactory) (Service, error) {
+	const op = "workers.NewService"
+	if iamRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	if serversRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing servers repository")
+	}
+	return Service{iamRepoFn: iamRepo, serversRepoFn: serversRepo}, nil
+}
+
+var _ pbs.WorkerServiceServer = Service{}
+
+// ListWorkers implements the interface pbs.WorkerServiceServer.
+func (s Service) ListWorkers(ctx context.Context, req *pbs.ListWorkersRequest) (*pbs.ListWorkersResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	pg, err := handlers.NewPaginator(ctx, authResults.ListTokenWrapper, resource.Worker, req.GetPageSize(), req.GetListToken(),
+		req.GetScopeId(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	var finalItems []*pb.Worker
+	res := perms.Resource{
+		Type:    resource.Worker,
+		ScopeId: scope.Global.String(),
+	}
+	for {
+		wl, err := s.listFromRepo(ctx, pg)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range wl {
+			if !pg.Admit(item.GetPublicId()) {
+				break
+			}
+			res.Id = item.GetPublicId()
+			res.Name = item.GetName()
+			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+			if len(authorizedActions) == 0 {
+				continue
+			}
+
+			outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+			outputOpts := make([]handlers.Option, 0, 3)
+			outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+			if outputFields.Has(globals.ScopeField) {
+				outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+			}
+			if outputFields.Has(globals.AuthorizedActionsField) {
+				outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+			}
+
+			item, err := toProto(ctx, item, outputOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if filter.Match(item) {
+				finalItems = append(finalItems, item)
+				pg.Include()
+			}
+		}
+		if !pg.Next(len(wl)) {
+			break
+		}
+	}
+	listToken, err := pg.ListToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListWorkersResponse{Items: finalItems, ListToken: listToken}, nil
+}
+
+// GetWorker implements the interface pbs.WorkerServiceServer.
+func (s Service) GetWorker(ctx context.Context, req *pbs.GetWorkerRequest) (*pbs.GetWorkerResponse, error) {
+	const op = "workers.(Service).GetWorker"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, w.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, w, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetWorkerResponse{Item: item}, nil
+}
+
+// CreateWorker implements the interface pbs.WorkerServiceServer. The
+// activation token of the worker is only returned by this method.
+func (s Service) CreateWorker(ctx context.Context, req *pbs.CreateWorkerRequest) (*pbs.CreateWorkerResponse, error) {
+	const op = "workers.(Service).CreateWorker"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.createInRepo(ctx, req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, w.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, w, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateWorkerResponse{Item: item, Uri: fmt.Sprintf("workers/%s", item.GetId())}, nil
+}
+
+// DeleteWorker implements the interface pbs.WorkerServiceServer. Deleting a
+// worker revokes it: its credentials are deleted and the controllers deny its
+// requests.
+func (s Service) DeleteWorker(ctx context.Context, req *pbs.DeleteWorkerRequest) (*pbs.DeleteWorkerResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// AddWorkerTags implements the interface pbs.WorkerServiceServer.
+func (s Service) AddWorkerTags(ctx context.Context, req *pbs.AddWorkerTagsRequest) (*pbs.AddWorkerTagsResponse, error) {
+	const op = "workers.(Service).AddWorkerTags"
+
+	if err := validateAddWorkerTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddWorkerTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.addTagsInRepo(ctx, req.GetId(), req.GetVersion(), req.GetApiTags())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, w.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, w, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddWorkerTagsResponse{Item: item}, nil
+}
+
+// SetWorkerTags implements the interface pbs.WorkerServiceServer.
+func (s Service) SetWorkerTags(ctx context.Context, req *pbs.SetWorkerTagsRequest) (*pbs.SetWorkerTagsResponse, error) {
+	const op = "workers.(Service).SetWorkerTags"
+
+	if err := validateSetWorkerTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetWorkerTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.setTagsInRepo(ctx, req.GetId(), req.GetVersion(), req.GetApiTags())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, w.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, w, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetWorkerTagsResponse{Item: item}, nil
+}
+
+// RemoveWorkerTags implements the interface pbs.WorkerServiceServer.
+func (s Service) RemoveWorkerTags(ctx context.Context, req *pbs.RemoveWorkerTagsRequest) (*pbs.RemoveWorkerTagsResponse, error) {
+	const op = "workers.(Service).RemoveWorkerTags"
+
+	if err := validateRemoveWorkerTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveWorkerTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.removeTagsInRepo(ctx, req.GetId(), req.GetVersion(), req.GetApiTags())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, w.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, w, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveWorkerTagsResponse{Item: item}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*servers.Worker, error) {
+	const op = "workers.(Service).getFromRepo"
+	repo, err := s.serversRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	w, err := repo.LookupWorker(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if w == nil {
+		return nil, handlers.NotFoundErrorf("Worker %q doesn't exist.", id)
+	}
+	return w, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, item *pb.Worker) (*servers.Worker, error) {
+	const op = "workers.(Service).createInRepo"
+	var opts []servers.Option
+	if item.GetDescription() != nil {
+		opts = append(opts, servers.WithDescription(item.GetDescription().GetValue()))
+	}
+	w, err := servers.NewWorker(item.GetName().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build worker for creation: %v.", err)
+	}
+	repo, err := s.serversRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateWorker(ctx, w)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create worker but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "workers.(Service).deleteFromRepo"
+	repo, err := s.serversRepoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteWorker(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete worker"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) addTagsInRepo(ctx context.Context, workerId string, version uint32, tags map[string]*structpb.ListValue) (*servers.Worker, error) {
+	const op = "workers.(Service).addTagsInRepo"
+	repo, err := s.serversRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	_, err = repo.AddWorkerTags(ctx, workerId, version, tagsFromProto(tags))
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add tags to worker: %v.", err)
+	}
+	out, err := repo.LookupWorker(ctx, workerId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up worker after adding tags"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup worker after adding tags to it.")
+	}
+	return out, nil
+}
+
+func (s Service) setTagsInRepo(ctx context.Context, workerId string, version uint32, tags map[string]*structpb.ListValue) (*servers.Worker, error) {
+	const op = "workers.(Service).setTagsInRepo"
+	repo, err := s.serversRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	_, err = repo.SetWorkerTags(ctx, workerId, version, tagsFromProto(tags))
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set tags on worker: %v.", err)
+	}
+	out, err := repo.LookupWorker(ctx, workerId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up worker after setting tags"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup worker after setting tags on it.")
+	}
+	return out, nil
+}
+
+func (s Service) removeTagsInRepo(ctx context.Context, workerId string, version uint32, tags map[string]*structpb.ListValue) (*servers.Worker, error) {
+	const op = "workers.(Service).removeTagsInRepo"
+	repo, err := s.serversRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	_, err = repo.DeleteWorkerTags(ctx, workerId, version, tagsFromProto(tags))
+	if err != nil {
+		if errors.Match(errors.T(errors.RecordNotFound), err) {
+			return nil, handlers.InvalidArgumentErrorf("Errors in provided fields.", map[string]string{globals.ApiTagsField: "Only tags set on the worker through the API can be removed."})
+		}
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove tags from worker: %v.", err)
+	}
+	out, err := repo.LookupWorker(ctx, workerId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up worker after removing tags"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup worker after removing tags from it.")
+	}
+	return out, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, pg *handlers.Paginator) ([]*servers.Worker, error) {
+	const op = "workers.(Service).listFromRepo"
+	repo, err := s.serversRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var opts []servers.Option
+	if pg.Enabled() {
+		opts = append(opts, servers.WithStartPageAfterItem(pg.StartAfter()), servers.WithLimit(pg.BatchSize()))
+	}
+	wl, err := repo.ListWorkers(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return wl, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	opts := []auth.Option{auth.WithType(resource.Worker), auth.WithAction(a), auth.WithScopeId(scope.Global.String())}
+	switch a {
+	case action.List, action.Create:
+		if id != scope.Global.String() {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.serversRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		w, err := repo.LookupWorker(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if w == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		opts = append(opts, auth.WithId(id), auth.WithName(w.GetName()))
+	}
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *servers.Worker, opt ...handlers.Option) (*pb.Worker, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building worker proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Worker{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ActivationTokenField) {
+		out.ActivationToken = in.GetActivationToken()
+	}
+	if outputFields.Has(globals.ActivationTokenExpirationTimeField) && in.GetActivationTokenExpirationTime() != nil {
+		out.ActivationTokenExpirationTime = in.GetActivationTokenExpirationTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.ActivationTimeField) && in.GetActivationTime() != nil {
+		out.ActivationTime = in.GetActivationTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.AddressField) {
+		out.Address = in.Address
+	}
+	if outputFields.Has(globals.LastStatusTimeField) && in.LastStatusTime != nil {
+		out.LastStatusTime = in.LastStatusTime.GetTimestamp()
+	}
+	if outputFields.Has(globals.RecordingPublicKeyField) && len(in.RecordingPublicKey) > 0 {
+		out.RecordingPublicKey = string(in.RecordingPublicKey)
+	}
+	if outputFields.Has(globals.ConfigTagsField) && len(in.ConfigTags) > 0 {
+		out.ConfigTags = tagsToProto(in.ConfigTags)
+	}
+	if outputFields.Has(globals.ApiTagsField) && len(in.ApiTags) > 0 {
+		out.ApiTags = tagsToProto(in.ApiTags)
+	}
+	if outputFields.Has(globals.CanonicalTagsField) {
+		if tags := in.CanonicalTags(); len(tags) > 0 {
+			out.CanonicalTags = tagsToProto(tags)
+		}
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+func tagsToProto(tags map[string][]string) map[string]*structpb.ListValue {
+	ret := make(map[string]*structpb.ListValue, len(tags))
+	for k, vals := range tags {
+		sorted := make([]string, len(vals))
+		copy(sorted, vals)
+		sort.Strings(sorted)
+		lv := &structpb.ListValue{Values: make([]*structpb.Value, 0, len(sorted))}
+		for _, v := range sorted {
+			lv.Values = append(lv.Values, structpb.NewStringValue(v))
+		}
+		ret[k] = lv
+	}
+	return ret
+}
+
+// tagsFromProto returns the tags in tags, which must have been validated by
+// validateApiTags.
+func tagsFromProto(tags map[string]*structpb.ListValue) map[string][]string {
+	ret := make(map[string][]string, len(tags))
+	for k, lv := range tags {
+		for _, v := range lv.GetValues() {
+			ret[k] = append(ret[k], v.GetStringValue())
+		}
+	}
+	return ret
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetWorkerRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, servers.WorkerPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateWorkerRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if item.GetScopeId() != scope.Global.String() {
+			badFields[globals.ScopeIdField] = "Workers can only be created in the global scope."
+		}
+		name := item.GetName().GetValue()
+		switch {
+		case name == "":
+			badFields[globals.NameField] = "This field is required. It must match the name the worker is configured with."
+		case name != strings.ToLower(name):
+			badFields[globals.NameField] = "This field must be all lower-case."
+		case !strutil.Printable(name):
+			badFields[globals.NameField] = "This field contains non-printable characters."
+		}
+		if item.GetActivationToken() != "" {
+			badFields[globals.ActivationTokenField] = "This is a read only field."
+		}
+		if item.GetActivationTokenExpirationTime() != nil {
+			badFields[globals.ActivationTokenExpirationTimeField] = "This is a read only field."
+		}
+		if item.GetActivationTime() != nil {
+			badFields[globals.ActivationTimeField] = "This is a read only field."
+		}
+		if item.GetAddress() != "" {
+			badFields[globals.AddressField] = "This is a read only field."
+		}
+		if item.GetLastStatusTime() != nil {
+			badFields[globals.LastStatusTimeField] = "This is a read only field."
+		}
+		if item.GetRecordingPublicKey() != "" {
+			badFields[globals.RecordingPublicKeyField] = "This is a read only field."
+		}
+		if item.GetConfigTags() != nil {
+			badFields[globals.ConfigTagsField] = "This is a read only field."
+		}
+		if item.GetApiTags() != nil {
+			badFields[globals.ApiTagsField] = "This field can only be set through the add, set and remove worker tags actions."
+		}
+		if item.GetCanonicalTags() != nil {
+			badFields[globals.CanonicalTagsField] = "This is a read only field."
+		}
+		return badFields
+	})
+}
+
+func validateDeleteRequest(req *pbs.DeleteWorkerRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, servers.WorkerPrefix)
+}
+
+func validateListRequest(req *pbs.ListWorkersRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "Workers can only be listed in the global scope."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields[globals.FilterField] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateAddWorkerTagsRequest(req *pbs.AddWorkerTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), servers.WorkerPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	if len(req.GetApiTags()) == 0 {
+		badFields[globals.ApiTagsField] = "Must be non-empty."
+	}
+	validateApiTags(req.GetApiTags(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetWorkerTagsRequest(req *pbs.SetWorkerTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), servers.WorkerPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	validateApiTags(req.GetApiTags(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveWorkerTagsRequest(req *pbs.RemoveWorkerTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), servers.WorkerPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	if len(req.GetApiTags()) == 0 {
+		badFields[globals.ApiTagsField] = "Must be non-empty."
+	}
+	validateApiTags(req.GetApiTags(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+// validateApiTags checks that the keys and values of tags follow the same
+// rules as the tags in the configuration of a worker.
+func validateApiTags(tags map[string]*structpb.ListValue, badFields map[string]string) {
+	for k, lv := range tags {
+		switch {
+		case k == "", k != strings.TrimSpace(k):
+			badFields[globals.ApiTagsField] = fmt.Sprintf("Tag key %q must be non-empty and must not have leading or trailing spaces.", k)
+			return
+		case len(k) > maxTagLength:
+			badFields[globals.ApiTagsField] = fmt.Sprintf("Tag key %q is longer than %d characters.", k, maxTagLength)
+			return
+		case k != strings.ToLower(k):
+			badFields[globals.ApiTagsField] = fmt.Sprintf("Tag key %q is not all lower-case letters.", k)
+			return
+		case !strutil.Printable(k):
+			badFields[globals.ApiTagsField] = fmt.Sprintf("Tag key %q contains non-printable characters.", k)
+			return
+		case len(lv.GetValues()) == 0:
+			badFields[globals.ApiTagsField] = fmt.Sprintf("Tag key %q has no values.", k)
+			return
+		}
+		for _, v := range lv.GetValues() {
+			sv, ok := v.GetKind().(*structpb.Value_StringValue)
+			if !ok {
+				badFields[globals.ApiTagsField] = fmt.Sprintf("Tag values for tag key %q must be strings.", k)
+				return
+			}
+			val := sv.StringValue
+			switch {
+			case val == "", val != strings.TrimSpace(val):
+				badFields[globals.ApiTagsField] = fmt.Sprintf("Tag value for tag key %q must be non-empty and must not have leading or trailing spaces.", k)
+				return
+			case len(val) > maxTagLength:
+				badFields[globals.ApiTagsField] = fmt.Sprintf("Tag value %q for tag key %q is longer than %d characters.", val, k, maxTagLength)
+				return
+			case val != strings.ToLower(val):
+				badFields[globals.ApiTagsField] = fmt.Sprintf("Tag value %q for tag key %q is not all lower-case letters.", val, k)
+				return
+			case !strutil.Printable(val):
+				badFields[globals.ApiTagsField] = fmt.Sprintf("Tag value %q for tag key %q contains non-printable characters.", val, k)
+				return
+			}
+		}
+	}
+}
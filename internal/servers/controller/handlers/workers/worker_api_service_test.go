@@ -0,0 +1,454 @@
+package workers_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	spbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/workers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/workers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "delete", "add-worker-tags", "set-worker-tags", "remove-worker-tags"}
+
+type testEnv struct {
+	kms           *kms.Kms
+	iamRepoFn     func() (*iam.Repository, error)
+	serversRepoFn func() (*servers.Repository, error)
+	sessionRepoFn func() (*session.Repository, error)
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	kms := kms.TestKms(t, conn, wrap)
+	serversRepo, err := servers.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	sessionRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	return &testEnv{
+		kms: kms,
+		iamRepoFn: func() (*iam.Repository, error) {
+			return iamRepo, nil
+		},
+		serversRepoFn: func() (*servers.Repository, error) {
+			return serversRepo, nil
+		},
+		sessionRepoFn: func() (*session.Repository, error) {
+			return sessionRepo, nil
+		},
+	}
+}
+
+func (e *testEnv) service(t *testing.T) workers.Service {
+	t.Helper()
+	s, err := workers.NewService(e.iamRepoFn, e.serversRepoFn)
+	require.NoError(t, err, "Couldn't create new worker service.")
+	return s
+}
+
+func (e *testEnv) createWorker(t *testing.T, name string) *servers.Worker {
+	t.Helper()
+	repo, err := e.serversRepoFn()
+	require.NoError(t, err)
+	in, err := servers.NewWorker(name)
+	require.NoError(t, err)
+	w, err := repo.CreateWorker(context.Background(), in)
+	require.NoError(t, err)
+	return w
+}
+
+// authContext returns a context for a request from a worker which
+// authenticated with its activation token or one of its credentials.
+func authContext(addr *workers.AuthAddr) context.Context {
+	addr.Addr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 9201}
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+}
+
+func TestNewService(t *testing.T) {
+	iamRepoFn := func() (*iam.Repository, error) { return nil, nil }
+	serversRepoFn := func() (*servers.Repository, error) { return nil, nil }
+
+	_, err := workers.NewService(nil, serversRepoFn)
+	assert.Error(t, err)
+	_, err = workers.NewService(iamRepoFn, nil)
+	assert.Error(t, err)
+	_, err = workers.NewService(iamRepoFn, serversRepoFn)
+	assert.NoError(t, err)
+}
+
+func TestGet(t *testing.T) {
+	env := newTestEnv(t)
+	w := env.createWorker(t, "get-worker")
+
+	want := &pb.Worker{
+		Id:                            w.GetPublicId(),
+		ScopeId:                       scope.Global.String(),
+		Scope:                         &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+		Name:                          wrapperspb.String("get-worker"),
+		CreatedTime:                   w.GetCreateTime().GetTimestamp(),
+		UpdatedTime:                   w.GetUpdateTime().GetTimestamp(),
+		Version:                       1,
+		ActivationTokenExpirationTime: w.GetActivationTokenExpirationTime().GetTimestamp(),
+		AuthorizedActions:             testAuthorizedActions,
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetWorkerRequest
+		res  *pbs.GetWorkerResponse
+		err  error
+	}{
+		{
+			name: "Get an existing worker",
+			req:  &pbs.GetWorkerRequest{Id: w.GetPublicId()},
+			res:  &pbs.GetWorkerResponse{Item: want},
+		},
+		{
+			name: "Get a non existing worker",
+			req:  &pbs.GetWorkerRequest{Id: servers.WorkerPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetWorkerRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := env.service(t).GetWorker(auth.DisabledAuthTestContext(env.iamRepoFn, scope.Global.String()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetWorker(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetWorker(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	env := newTestEnv(t)
+	s := env.service(t)
+	ctx := auth.DisabledAuthTestContext(env.iamRepoFn, scope.Global.String())
+
+	w1 := env.createWorker(t, "list-worker-1")
+	w2 := env.createWorker(t, "list-worker-2")
+
+	got, err := s.ListWorkers(ctx, &pbs.ListWorkersRequest{ScopeId: scope.Global.String()})
+	require.NoError(err)
+	var ids []string
+	for _, item := range got.GetItems() {
+		ids = append(ids, item.GetId())
+		assert.Empty(item.GetActivationToken())
+	}
+	assert.ElementsMatch([]string{w1.GetPublicId(), w2.GetPublicId()}, ids)
+
+	got, err = s.ListWorkers(ctx, &pbs.ListWorkersRequest{
+		ScopeId: scope.Global.String(),
+		Filter:  `"/item/name"=="list-worker-2"`,
+	})
+	require.NoError(err)
+	require.Len(got.GetItems(), 1)
+	assert.Equal(w2.GetPublicId(), got.GetItems()[0].GetId())
+
+	_, err = s.ListWorkers(ctx, &pbs.ListWorkersRequest{ScopeId: "o_1234567890"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+}
+
+func TestCreate(t *testing.T) {
+	env := newTestEnv(t)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateWorkerRequest
+		res  *pbs.CreateWorkerResponse
+		err  error
+	}{
+		{
+			name: "Create a valid worker",
+			req: &pbs.CreateWorkerRequest{Item: &pb.Worker{
+				ScopeId:     scope.Global.String(),
+				Name:        wrapperspb.String("create-worker"),
+				Description: wrapperspb.String("desc"),
+			}},
+			res: &pbs.CreateWorkerResponse{
+				Uri: "workers/" + servers.WorkerPrefix + "_",
+				Item: &pb.Worker{
+					ScopeId:           scope.Global.String(),
+					Scope:             &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+					Name:              wrapperspb.String("create-worker"),
+					Description:       wrapperspb.String("desc"),
+					Version:           1,
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Can't create outside of the global scope",
+			req: &pbs.CreateWorkerRequest{Item: &pb.Worker{
+				ScopeId: "o_1234567890",
+				Name:    wrapperspb.String("org-worker"),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Name is required",
+			req: &pbs.CreateWorkerRequest{Item: &pb.Worker{
+				ScopeId: scope.Global.String(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Name must be lower case",
+			req: &pbs.CreateWorkerRequest{Item: &pb.Worker{
+				ScopeId: scope.Global.String(),
+				Name:    wrapperspb.String("Worker"),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify the activation token",
+			req: &pbs.CreateWorkerRequest{Item: &pb.Worker{
+				ScopeId:         scope.Global.String(),
+				Name:            wrapperspb.String("token-worker"),
+				ActivationToken: "w_1234567890_secret",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Created Time",
+			req: &pbs.CreateWorkerRequest{Item: &pb.Worker{
+				ScopeId:     scope.Global.String(),
+				Name:        wrapperspb.String("time-worker"),
+				CreatedTime: timestamppb.Now(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := env.service(t).CreateWorker(auth.DisabledAuthTestContext(env.iamRepoFn, scope.Global.String()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateWorker(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			if got != nil {
+				assert.Contains(got.GetUri(), tc.res.Uri)
+				assert.True(strings.HasPrefix(got.GetItem().GetId(), servers.WorkerPrefix+"_"))
+				assert.True(strings.HasPrefix(got.GetItem().GetActivationToken(), got.GetItem().GetId()+"_"))
+				assert.NotNil(got.GetItem().GetActivationTokenExpirationTime())
+
+				// Clear all values which are hard to compare against.
+				got.Uri, tc.res.Uri = "", ""
+				got.Item.Id, tc.res.Item.Id = "", ""
+				got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+				got.Item.ActivationToken, got.Item.ActivationTokenExpirationTime = "", nil
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "CreateWorker(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	env := newTestEnv(t)
+	s := env.service(t)
+	w := env.createWorker(t, "delete-worker")
+	ctx := auth.DisabledAuthTestContext(env.iamRepoFn, scope.Global.String())
+
+	_, err := s.DeleteWorker(ctx, &pbs.DeleteWorkerRequest{Id: w.GetPublicId()})
+	require.NoError(err, "First attempt")
+	_, err = s.DeleteWorker(ctx, &pbs.DeleteWorkerRequest{Id: w.GetPublicId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "Expected not found for the second delete.")
+	_, err = s.DeleteWorker(ctx, &pbs.DeleteWorkerRequest{Id: "bad_format"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+}
+
+func tagsValue(t *testing.T, tags map[string][]interface{}) map[string]*structpb.ListValue {
+	t.Helper()
+	ret := make(map[string]*structpb.ListValue, len(tags))
+	for k, vals := range tags {
+		lv, err := structpb.NewList(vals)
+		require.NoError(t, err)
+		ret[k] = lv
+	}
+	return ret
+}
+
+func TestWorkerTags(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	env := newTestEnv(t)
+	s := env.service(t)
+	w := env.createWorker(t, "tags-worker")
+	ctx := auth.DisabledAuthTestContext(env.iamRepoFn, scope.Global.String())
+
+	added, err := s.AddWorkerTags(ctx, &pbs.AddWorkerTagsRequest{
+		Id:      w.GetPublicId(),
+		Version: w.GetVersion(),
+		ApiTags: tagsValue(t, map[string][]interface{}{"type": {"web", "prod"}, "region": {"us-east-1"}}),
+	})
+	require.NoError(err)
+	assert.Equal(w.GetVersion()+1, added.GetItem().GetVersion())
+	want := tagsValue(t, map[string][]interface{}{"type": {"prod", "web"}, "region": {"us-east-1"}})
+	assert.Empty(cmp.Diff(want, added.GetItem().GetApiTags(), protocmp.Transform()))
+	assert.Empty(cmp.Diff(want, added.GetItem().GetCanonicalTags(), protocmp.Transform()))
+	assert.Empty(added.GetItem().GetConfigTags())
+
+	set, err := s.SetWorkerTags(ctx, &pbs.SetWorkerTagsRequest{
+		Id:      w.GetPublicId(),
+		Version: added.GetItem().GetVersion(),
+		ApiTags: tagsValue(t, map[string][]interface{}{"type": {"db"}}),
+	})
+	require.NoError(err)
+	assert.Empty(cmp.Diff(tagsValue(t, map[string][]interface{}{"type": {"db"}}), set.GetItem().GetApiTags(), protocmp.Transform()))
+
+	_, err = s.RemoveWorkerTags(ctx, &pbs.RemoveWorkerTagsRequest{
+		Id:      w.GetPublicId(),
+		Version: set.GetItem().GetVersion(),
+		ApiTags: tagsValue(t, map[string][]interface{}{"type": {"web"}}),
+	})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for a tag not set on the worker, got %v.", err)
+
+	removed, err := s.RemoveWorkerTags(ctx, &pbs.RemoveWorkerTagsRequest{
+		Id:      w.GetPublicId(),
+		Version: set.GetItem().GetVersion(),
+		ApiTags: tagsValue(t, map[string][]interface{}{"type": {"db"}}),
+	})
+	require.NoError(err)
+	assert.Empty(removed.GetItem().GetApiTags())
+
+	_, err = s.AddWorkerTags(ctx, &pbs.AddWorkerTagsRequest{
+		Id:      w.GetPublicId(),
+		Version: w.GetVersion(),
+		ApiTags: tagsValue(t, map[string][]interface{}{"type": {"web"}}),
+	})
+	assert.Error(err, "Expected an error for an outdated version.")
+
+	invalid := []struct {
+		name string
+		req  *pbs.AddWorkerTagsRequest
+	}{
+		{name: "Wrong id prefix", req: &pbs.AddWorkerTagsRequest{Id: "j_1234567890", Version: 1, ApiTags: tagsValue(t, map[string][]interface{}{"a": {"b"}})}},
+		{name: "Missing version", req: &pbs.AddWorkerTagsRequest{Id: w.GetPublicId(), ApiTags: tagsValue(t, map[string][]interface{}{"a": {"b"}})}},
+		{name: "Missing tags", req: &pbs.AddWorkerTagsRequest{Id: w.GetPublicId(), Version: 1}},
+		{name: "No values", req: &pbs.AddWorkerTagsRequest{Id: w.GetPublicId(), Version: 1, ApiTags: tagsValue(t, map[string][]interface{}{"a": {}})}},
+		{name: "Upper case key", req: &pbs.AddWorkerTagsRequest{Id: w.GetPublicId(), Version: 1, ApiTags: tagsValue(t, map[string][]interface{}{"A": {"b"}})}},
+		{name: "Upper case value", req: &pbs.AddWorkerTagsRequest{Id: w.GetPublicId(), Version: 1, ApiTags: tagsValue(t, map[string][]interface{}{"a": {"B"}})}},
+		{name: "Empty value", req: &pbs.AddWorkerTagsRequest{Id: w.GetPublicId(), Version: 1, ApiTags: tagsValue(t, map[string][]interface{}{"a": {""}})}},
+		{name: "Non string value", req: &pbs.AddWorkerTagsRequest{Id: w.GetPublicId(), Version: 1, ApiTags: tagsValue(t, map[string][]interface{}{"a": {1}})}},
+	}
+	for _, tc := range invalid {
+		_, err := s.AddWorkerTags(ctx, tc.req)
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "%s: AddWorkerTags(%+v) got error %v, wanted invalid argument", tc.name, tc.req, err)
+	}
+}
+
+func TestRegisterAndRotate(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	env := newTestEnv(t)
+	ws := workers.NewWorkerServiceServer(env.serversRepoFn, env.sessionRepoFn, new(sync.Map), env.kms)
+	w := env.createWorker(t, "register-worker")
+
+	// A worker which did not authenticate with its activation token can't
+	// register
+	_, err := ws.RegisterWorker(context.Background(), &spbs.RegisterWorkerRequest{})
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// The name must match the name of the worker
+	_, err = ws.RegisterWorker(authContext(&workers.AuthAddr{WorkerId: w.GetPublicId(), Name: "another-name"}), &spbs.RegisterWorkerRequest{})
+	assert.Equal(codes.PermissionDenied, status.Code(err))
+
+	regResp, err := ws.RegisterWorker(authContext(&workers.AuthAddr{WorkerId: w.GetPublicId(), Name: "register-worker"}), &spbs.RegisterWorkerRequest{})
+	require.NoError(err)
+	assert.Equal(w.GetPublicId(), regResp.GetWorkerId())
+	cred := regResp.GetCredential()
+	assert.True(strings.HasPrefix(cred.GetId(), servers.WorkerCredentialPrefix+"_"))
+	assert.Len(cred.GetKey(), 32)
+	assert.True(cred.GetRotationTime().AsTime().Before(cred.GetExpirationTime().AsTime()))
+
+	// The activation token is one-time
+	_, err = ws.RegisterWorker(authContext(&workers.AuthAddr{WorkerId: w.GetPublicId(), Name: "register-worker"}), &spbs.RegisterWorkerRequest{})
+	assert.Equal(codes.PermissionDenied, status.Code(err))
+
+	credCtx := authContext(&workers.AuthAddr{WorkerId: w.GetPublicId(), CredentialId: cred.GetId(), Name: "register-worker"})
+	rotResp, err := ws.RotateWorkerCredential(credCtx, &spbs.RotateWorkerCredentialRequest{})
+	require.NoError(err)
+	assert.NotEqual(cred.GetId(), rotResp.GetCredential().GetId())
+
+	// Only a worker which authenticated with a credential can rotate it
+	_, err = ws.RotateWorkerCredential(context.Background(), &spbs.RotateWorkerCredentialRequest{})
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	env := newTestEnv(t)
+	interceptor := workers.AuthInterceptor(env.serversRepoFn)
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+	statusInfo := &grpc.UnaryServerInfo{FullMethod: "/controller.servers.services.v1.ServerCoordinationService/Status"}
+	registerInfo := &grpc.UnaryServerInfo{FullMethod: "/controller.servers.services.v1.ServerCoordinationService/RegisterWorker"}
+
+	w := env.createWorker(t, "interceptor-worker")
+	tokenCtx := authContext(&workers.AuthAddr{WorkerId: w.GetPublicId(), Name: "interceptor-worker"})
+	credCtx := authContext(&workers.AuthAddr{WorkerId: w.GetPublicId(), CredentialId: "wcr_1234567890", Name: "interceptor-worker"})
+	otherNameCtx := authContext(&workers.AuthAddr{WorkerId: w.GetPublicId(), CredentialId: "wcr_1234567890", Name: "other-worker"})
+
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		info     *grpc.UnaryServerInfo
+		wantCode codes.Code
+	}{
+		{name: "shared kms", ctx: context.Background(), info: statusInfo, wantCode: codes.OK},
+		{name: "activation token register", ctx: tokenCtx, info: registerInfo, wantCode: codes.OK},
+		{name: "activation token other request", ctx: tokenCtx, info: statusInfo, wantCode: codes.PermissionDenied},
+		{name: "credential", ctx: credCtx, info: statusInfo, wantCode: codes.OK},
+		{name: "credential other name", ctx: otherNameCtx, info: statusInfo, wantCode: codes.PermissionDenied},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := interceptor(tc.ctx, nil, tc.info, handler)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+
+	t.Run("revoked", func(t *testing.T) {
+		repo, err := env.serversRepoFn()
+		require.NoError(t, err)
+		_, err = repo.DeleteWorker(context.Background(), w.GetPublicId())
+		require.NoError(t, err)
+		_, err = interceptor(credCtx, nil, statusInfo, handler)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
@@ -0,0 +1,144 @@
+package workers
+
+import (
+	"context"
+	"net"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const registerWorkerMethod = "/controller.servers.services.v1.ServerCoordinationService/RegisterWorker"
+
+// AuthAddr is the remote address of a connection from a worker which
+// authenticated with its activation token or one of its credentials instead of
+// the shared worker-auth KMS. The controller sets it as the remote address of
+// the connection so the handlers know how the worker authenticated.
+type AuthAddr struct {
+	net.Addr
+
+	// WorkerId is the id of the worker.
+	WorkerId string
+	// CredentialId is the id of the credential the worker authenticated with.
+	// It is empty if the worker authenticated with its activation token.
+	CredentialId string
+	// Name is the name the worker authenticated with.
+	Name string
+}
+
+// authAddrFromContext returns the AuthAddr of the connection of the request
+// if the worker did not authenticate with the shared worker-auth KMS.
+func authAddrFromContext(ctx context.Context) (*AuthAddr, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	addr, ok := p.Addr.(*AuthAddr)
+	return addr, ok
+}
+
+// AuthInterceptor returns a grpc interceptor which authorizes the requests of
+// the workers which authenticated with their activation token or one of their
+// credentials. A worker which authenticated with its activation token can only
+// register. A worker which authenticated with a credential is denied once it
+// has been deleted, which revokes it, or if it authenticated with a name other
+// than the one it was registered with. Requests of workers which authenticated
+// with the shared worker-auth KMS are passed through.
+func AuthInterceptor(serversRepoFn common.ServersRepoFactory) grpc.UnaryServerInterceptor {
+	const op = "workers.AuthInterceptor"
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		addr, ok := authAddrFromContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+		if addr.CredentialId == "" {
+			if info.FullMethod != registerWorkerMethod {
+				return nil, status.Error(codes.PermissionDenied, "Worker must register before making any other request.")
+			}
+			return handler(ctx, req)
+		}
+		serversRepo, err := serversRepoFn()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Error acquiring servers repo: %v", err)
+		}
+		w, err := serversRepo.LookupWorker(ctx, addr.WorkerId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Error looking up worker: %v", err)
+		}
+		if w == nil {
+			event.WriteSysEvent(ctx, op, "denied request from revoked worker", "worker_id", addr.WorkerId, "name", addr.Name)
+			return nil, status.Error(codes.PermissionDenied, "Worker has been revoked.")
+		}
+		if w.GetName() != addr.Name {
+			event.WriteSysEvent(ctx, op, "denied request from worker using another name", "worker_id", addr.WorkerId, "name", addr.Name, "registered_name", w.GetName())
+			return nil, status.Error(codes.PermissionDenied, "Worker name does not match the name the worker was registered with.")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (ws *workerServiceServer) RegisterWorker(ctx context.Context, req *pbs.RegisterWorkerRequest) (*pbs.RegisterWorkerResponse, error) {
+	const op = "workers.(workerServiceServer).RegisterWorker"
+	addr, ok := authAddrFromContext(ctx)
+	if !ok || addr.CredentialId != "" {
+		return nil, status.Error(codes.FailedPrecondition, "Worker must authenticate with its activation token to register.")
+	}
+	serversRepo, err := ws.serversRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error acquiring servers repo: %v", err)
+	}
+	cred, err := serversRepo.ActivateWorker(ctx, addr.WorkerId, addr.Name)
+	switch {
+	case err == nil:
+	case errors.Match(errors.T(errors.RecordNotFound), err), errors.Match(errors.T(errors.InvalidParameter), err):
+		return nil, status.Errorf(codes.PermissionDenied, "Unable to register worker: %v", err)
+	default:
+		return nil, status.Errorf(codes.Internal, "Error registering worker: %v", err)
+	}
+	event.WriteSysEvent(ctx, op, "worker registered", "worker_id", addr.WorkerId, "name", addr.Name)
+	return &pbs.RegisterWorkerResponse{
+		WorkerId:   addr.WorkerId,
+		Credential: toWorkerCredentialProto(cred),
+	}, nil
+}
+
+func (ws *workerServiceServer) RotateWorkerCredential(ctx context.Context, req *pbs.RotateWorkerCredentialRequest) (*pbs.RotateWorkerCredentialResponse, error) {
+	const op = "workers.(workerServiceServer).RotateWorkerCredential"
+	addr, ok := authAddrFromContext(ctx)
+	if !ok || addr.CredentialId == "" {
+		return nil, status.Error(codes.FailedPrecondition, "Worker must authenticate with a credential to rotate it.")
+	}
+	serversRepo, err := ws.serversRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error acquiring servers repo: %v", err)
+	}
+	cred, err := serversRepo.RotateWorkerCredential(ctx, addr.CredentialId)
+	switch {
+	case err == nil:
+	case errors.Match(errors.T(errors.RecordNotFound), err):
+		return nil, status.Errorf(codes.PermissionDenied, "Unable to rotate worker credential: %v", err)
+	default:
+		return nil, status.Errorf(codes.Internal, "Error rotating worker credential: %v", err)
+	}
+	event.WriteSysEvent(ctx, op, "worker credential rotated", "worker_id", addr.WorkerId, "credential_id", cred.GetPublicId())
+	return &pbs.RotateWorkerCredentialResponse{
+		Credential: toWorkerCredentialProto(cred),
+	}, nil
+}
+
+func toWorkerCredentialProto(c *servers.WorkerCredential) *pbs.WorkerCredential {
+	return &pbs.WorkerCredential{
+		Id:             c.GetPublicId(),
+		Key:            c.GetKey(),
+		ExpirationTime: c.GetExpirationTime().GetTimestamp(),
+		RotationTime:   timestamppb.New(c.RotationTime()),
+	}
+}
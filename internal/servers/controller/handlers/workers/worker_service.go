@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/go-bexpr"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type workerServiceServer struct {
@@ -51,6 +52,11 @@ var (
 
 func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusRequest) (*pbs.StatusResponse, error) {
 	const op = "workers.(workerServiceServer).Status"
+	// A worker which authenticated with one of its credentials can only report
+	// the status under the name it was registered with.
+	if addr, ok := authAddrFromContext(ctx); ok && req.GetWorker().GetPrivateId() != addr.Name {
+		return &pbs.StatusResponse{}, status.Error(codes.PermissionDenied, "Worker name does not match the name the worker was registered with.")
+	}
 	// TODO: on the worker, if we get errors back from this repeatedly, do we
 	// terminate all sessions since we can't know if they were canceled?
 	ws.updateTimes.Store(req.Worker.PrivateId, time.Now())
@@ -82,6 +88,9 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		// requests for these because canceling the session terminates the
 		// connections.
 		requestedSessionCancelIds []string
+		// For tracking the running byte counts of the reported open
+		// connections.
+		reportedConnBytes []session.BytesWith
 	)
 
 	// This is a map of all sessions and their statuses. We keep track of
@@ -112,6 +121,11 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 					// Note that unspecified is the default state for the enum
 					// but it's not ever explicitly set by us.
 					reportedOpenConns = append(reportedOpenConns, conn.GetConnectionId())
+					reportedConnBytes = append(reportedConnBytes, session.BytesWith{
+						ConnectionId: conn.GetConnectionId(),
+						BytesUp:      conn.GetBytesUp(),
+						BytesDown:    conn.GetBytesDown(),
+					})
 				}
 			}
 
@@ -164,6 +178,15 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		}
 	}
 
+	// Record the running byte counts of the open connections. Failing to
+	// record them shouldn't prevent the normalization of sessions and
+	// connections below, so the error is only logged.
+	if len(reportedConnBytes) > 0 {
+		if _, err := sessRepo.UpdateConnectionBytes(ctx, reportedConnBytes); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error updating connection byte counts", "server_id", req.Worker.PrivateId))
+		}
+	}
+
 	// Normalize the current state of connections on the worker side
 	// with the data from the controller. In other words, if one of our
 	// found connections isn't supposed to be alive still, kill it.
@@ -282,17 +305,21 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 			SessionId:   sessionInfo.GetPublicId(),
 			Certificate: sessionInfo.Certificate,
 		},
-		Status:          sessionInfo.States[0].Status.ProtoVal(),
-		Version:         sessionInfo.Version,
-		TofuToken:       string(sessionInfo.TofuToken),
-		Endpoint:        sessionInfo.Endpoint,
-		Expiration:      sessionInfo.ExpirationTime.Timestamp,
-		ConnectionLimit: sessionInfo.ConnectionLimit,
-		ConnectionsLeft: authzSummary.ConnectionLimit,
-		HostId:          sessionInfo.HostId,
-		HostSetId:       sessionInfo.HostSetId,
-		TargetId:        sessionInfo.TargetId,
-		UserId:          sessionInfo.UserId,
+		Status:            sessionInfo.States[0].Status.ProtoVal(),
+		Version:           sessionInfo.Version,
+		TofuToken:         string(sessionInfo.TofuToken),
+		Endpoint:          sessionInfo.Endpoint,
+		Expiration:        sessionInfo.ExpirationTime.Timestamp,
+		ConnectionLimit:   sessionInfo.ConnectionLimit,
+		ConnectionsLeft:   authzSummary.ConnectionLimit,
+		HostId:            sessionInfo.HostId,
+		HostSetId:         sessionInfo.HostSetId,
+		TargetId:          sessionInfo.TargetId,
+		UserId:            sessionInfo.UserId,
+		RecordingEnabled:  sessionInfo.RecordingEnabled,
+		UploadRateLimit:   sessionInfo.UploadRateLimit,
+		DownloadRateLimit: sessionInfo.DownloadRateLimit,
+		EndpointHostKey:   sessionInfo.EndpointHostKey,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
@@ -310,6 +337,18 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		return nil, status.Errorf(codes.Internal, "Error deriving session key: %v", err)
 	}
 
+	creds, err := sessRepo.ListSessionCredentials(ctx, sessionInfo.ScopeId, sessionInfo.GetPublicId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error retrieving session credentials: %v", err)
+	}
+	for _, c := range creds {
+		m := &pbs.Credential{}
+		if err := proto.Unmarshal(c, m); err != nil {
+			return nil, status.Errorf(codes.Internal, "Error unmarshaling session credential: %v", err)
+		}
+		resp.Credentials = append(resp.Credentials, m)
+	}
+
 	return resp, nil
 }
 
@@ -350,7 +389,8 @@ func (ws *workerServiceServer) ActivateSession(ctx context.Context, req *pbs.Act
 		req.GetVersion(),
 		req.GetWorkerId(),
 		resource.Worker.String(),
-		[]byte(req.GetTofuToken()))
+		[]byte(req.GetTofuToken()),
+		session.WithRecordingPath(req.GetRecordingPath()))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error looking up session: %v", err)
 	}
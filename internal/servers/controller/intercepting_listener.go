@@ -7,6 +7,7 @@ import (
 	"net"
 
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/workers"
 )
 
 // interceptingListener allows us to validate the nonce from a connection before
@@ -80,9 +81,34 @@ func (m *interceptingListener) Accept() (net.Conn, error) {
 	workerInfo := workerInfoRaw.(*workerAuthEntry)
 	workerInfo.conn = conn
 	event.WriteSysEvent(ctx, op, "worker successfully authed", "name", workerInfo.Name)
+	if workerInfo.workerId != "" {
+		// Let the handlers know how the worker authenticated
+		return &workerAuthConn{
+			Conn: conn,
+			addr: &workers.AuthAddr{
+				Addr:         conn.RemoteAddr(),
+				WorkerId:     workerInfo.workerId,
+				CredentialId: workerInfo.credentialId,
+				Name:         workerInfo.Name,
+			},
+		}, nil
+	}
 	return conn, nil
 }
 
+// workerAuthConn is the connection of a worker which authenticated with its
+// activation token or one of its credentials. Its remote address is a
+// workers.AuthAddr, which gRPC makes available to the handlers as the address
+// of the peer.
+type workerAuthConn struct {
+	net.Conn
+	addr *workers.AuthAddr
+}
+
+func (c *workerAuthConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
 func (m *interceptingListener) Close() error {
 	return m.baseLn.Close()
 }
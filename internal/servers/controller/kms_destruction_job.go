@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// dataKeyVersionDestructionMonitorJob defines a periodic job that runs the
+// pending database key version destruction jobs.
+//
+// Each run rewraps the values still encrypted by the database key versions
+// being destroyed with the current database key version of their scope. A
+// key version is deleted once nothing references it anymore.
+type dataKeyVersionDestructionMonitorJob struct {
+	kms *kms.Kms
+
+	// The number of destruction jobs which were run and which completed in
+	// the last run.
+	total     int
+	completed int
+}
+
+// newDataKeyVersionDestructionMonitorJob instantiates the database key
+// version destruction monitor job.
+func newDataKeyVersionDestructionMonitorJob(kmsCache *kms.Kms) (*dataKeyVersionDestructionMonitorJob, error) {
+	const op = "controller.newDataKeyVersionDestructionMonitorJob"
+	if kmsCache == nil {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing kms")
+	}
+	return &dataKeyVersionDestructionMonitorJob{
+		kms: kmsCache,
+	}, nil
+}
+
+// Name returns a short, unique name for the job.
+func (j *dataKeyVersionDestructionMonitorJob) Name() string {
+	return "data_key_version_destruction_monitor"
+}
+
+// Description returns the description for the job.
+func (j *dataKeyVersionDestructionMonitorJob) Description() string {
+	return "Rewrap the values encrypted by database key versions being destroyed and destroy the key versions"
+}
+
+// NextRunIn returns the next run time after a job is completed.
+func (j *dataKeyVersionDestructionMonitorJob) NextRunIn() (time.Duration, error) {
+	return time.Minute, nil
+}
+
+// Status returns the status of the running job.
+func (j *dataKeyVersionDestructionMonitorJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.completed,
+		Total:     j.total,
+	}
+}
+
+// Run executes the job.
+func (j *dataKeyVersionDestructionMonitorJob) Run(ctx context.Context) error {
+	const op = "controller.(dataKeyVersionDestructionMonitorJob).Run"
+	j.total, j.completed = 0, 0
+
+	jobs, err := j.kms.ListDataKeyVersionDestructionJobs(ctx, "")
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	j.total = len(jobs)
+	for _, job := range jobs {
+		destroyed, err := j.kms.RunDataKeyVersionDestructionJob(ctx, job.KeyId)
+		if err != nil {
+			// Keep going, one failing key version should not block the
+			// destruction of the others.
+			event.WriteError(ctx, op, err, event.WithInfo("key_id", job.KeyId, "scope_id", job.ScopeId))
+			continue
+		}
+		if destroyed {
+			j.completed++
+		}
+	}
+	return nil
+}
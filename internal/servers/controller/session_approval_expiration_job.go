@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+)
+
+// sessionApprovalExpirationJob defines a periodic job that terminates the
+// sessions requiring approval which were not approved, or whose authorization
+// was not retrieved by their user, before their approval expiration time.
+type sessionApprovalExpirationJob struct {
+	sessionRepoFn common.SessionRepoFactory
+
+	// The number of sessions terminated in the last run.
+	totalExpired int
+}
+
+// newSessionApprovalExpirationJob instantiates the session approval
+// expiration job.
+func newSessionApprovalExpirationJob(sessionRepoFn common.SessionRepoFactory) (*sessionApprovalExpirationJob, error) {
+	const op = "controller.newSessionApprovalExpirationJob"
+	if sessionRepoFn == nil {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing sessionRepoFn")
+	}
+	return &sessionApprovalExpirationJob{
+		sessionRepoFn: sessionRepoFn,
+	}, nil
+}
+
+// Name returns a short, unique name for the job.
+func (j *sessionApprovalExpirationJob) Name() string { return "session_approval_expiration" }
+
+// Description returns the description for the job.
+func (j *sessionApprovalExpirationJob) Description() string {
+	return "Terminate sessions whose approval expired"
+}
+
+// NextRunIn returns the next run time after a job is completed.
+func (j *sessionApprovalExpirationJob) NextRunIn() (time.Duration, error) {
+	return 10 * time.Second, nil
+}
+
+// Status returns the status of the running job.
+func (j *sessionApprovalExpirationJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.totalExpired,
+		Total:     j.totalExpired,
+	}
+}
+
+// Run executes the job.
+func (j *sessionApprovalExpirationJob) Run(ctx context.Context) error {
+	const op = "controller.(sessionApprovalExpirationJob).Run"
+	j.totalExpired = 0
+
+	sessionRepo, err := j.sessionRepoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("error getting session repo"))
+	}
+	ids, err := sessionRepo.ExpireSessionApprovals(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, id := range ids {
+		event.WriteSysEvent(ctx, op, "session approval expired", "session_id", id)
+	}
+	j.totalExpired = len(ids)
+	return nil
+}
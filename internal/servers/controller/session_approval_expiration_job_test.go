@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// assert the interface
+var _ = scheduler.Job(new(sessionApprovalExpirationJob))
+
+func TestSessionApprovalExpirationJob(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	sessionRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	composedOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	composedOf.ApprovalExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(time.Second))}
+	expired := session.TestSession(t, conn, wrapper, composedOf)
+	notExpired := session.TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	job, err := newSessionApprovalExpirationJob(func() (*session.Repository, error) { return sessionRepo, nil })
+	require.NoError(err)
+
+	time.Sleep(2 * time.Second)
+	require.NoError(job.Run(ctx))
+	assert.Equal(1, job.Status().Completed)
+
+	s, _, err := sessionRepo.LookupSession(ctx, expired.PublicId)
+	require.NoError(err)
+	assert.Equal(session.ApprovalExpired.String(), s.TerminationReason)
+	s, _, err = sessionRepo.LookupSession(ctx, notExpired.PublicId)
+	require.NoError(err)
+	assert.Empty(s.TerminationReason)
+}
+
+func TestSessionApprovalExpirationJobNewJobErr(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	const op = "controller.newSessionApprovalExpirationJob"
+	require := require.New(t)
+
+	job, err := newSessionApprovalExpirationJob(nil)
+	require.Equal(err, errors.E(
+		ctx,
+		errors.WithCode(errors.InvalidParameter),
+		errors.WithOp(op),
+		errors.WithMsg("missing sessionRepoFn"),
+	))
+	require.Nil(job)
+}
@@ -7,10 +7,12 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/servers"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 	"google.golang.org/protobuf/proto"
 )
@@ -18,6 +20,12 @@ import (
 type workerAuthEntry struct {
 	*base.WorkerAuthInfo
 	conn net.Conn
+
+	// workerId and credentialId are set if the worker authenticated with its
+	// activation token or one of its credentials instead of the shared
+	// worker-auth KMS. credentialId is empty for an activation token.
+	workerId     string
+	credentialId string
 }
 
 func (c Controller) validateWorkerTls(hello *tls.ClientHelloInfo) (*tls.Config, error) {
@@ -27,9 +35,7 @@ func (c Controller) validateWorkerTls(hello *tls.ClientHelloInfo) (*tls.Config,
 			tlsConf, workerInfo, err := c.v1WorkerAuthConfig(hello.SupportedProtos)
 			if err == nil {
 				// Set the info we need to prevent replays
-				c.workerAuthCache.Set(workerInfo.ConnectionNonce, &workerAuthEntry{
-					WorkerAuthInfo: workerInfo,
-				}, 0)
+				c.workerAuthCache.Set(workerInfo.ConnectionNonce, workerInfo, 0)
 			}
 			return tlsConf, err
 		}
@@ -37,7 +43,7 @@ func (c Controller) validateWorkerTls(hello *tls.ClientHelloInfo) (*tls.Config,
 	return nil, nil
 }
 
-func (c Controller) v1WorkerAuthConfig(protos []string) (*tls.Config, *base.WorkerAuthInfo, error) {
+func (c Controller) v1WorkerAuthConfig(protos []string) (*tls.Config, *workerAuthEntry, error) {
 	var firstMatchProto string
 	var encString string
 	for _, p := range protos {
@@ -60,7 +66,12 @@ func (c Controller) v1WorkerAuthConfig(protos []string) (*tls.Config, *base.Work
 	if err := proto.Unmarshal(marshaledEncInfo, encInfo); err != nil {
 		return nil, nil, err
 	}
-	marshaledInfo, err := c.conf.WorkerAuthKms.Decrypt(context.Background(), encInfo, nil)
+	entry := new(workerAuthEntry)
+	wrapper, err := c.workerAuthWrapper(context.Background(), encInfo.GetKeyInfo().GetKeyID(), entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	marshaledInfo, err := wrapper.Decrypt(context.Background(), encInfo, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -68,14 +79,20 @@ func (c Controller) v1WorkerAuthConfig(protos []string) (*tls.Config, *base.Work
 	if err := json.Unmarshal(marshaledInfo, info); err != nil {
 		return nil, nil, err
 	}
+	entry.WorkerAuthInfo = info
+	if entry.credentialId != "" {
+		if err := c.verifyWorkerName(context.Background(), entry.workerId, info.Name); err != nil {
+			return nil, entry, err
+		}
+	}
 
 	rootCAs := x509.NewCertPool()
 	if ok := rootCAs.AppendCertsFromPEM(info.CertPEM); !ok {
-		return nil, info, errors.New("unable to add ca cert to cert pool")
+		return nil, entry, errors.New("unable to add ca cert to cert pool")
 	}
 	tlsCert, err := tls.X509KeyPair(info.CertPEM, info.KeyPEM)
 	if err != nil {
-		return nil, info, err
+		return nil, entry, err
 	}
 	tlsConfig := &tls.Config{
 		Certificates: []tls.Certificate{tlsCert},
@@ -85,5 +102,70 @@ func (c Controller) v1WorkerAuthConfig(protos []string) (*tls.Config, *base.Work
 		MinVersion:   tls.VersionTLS13,
 	}
 
-	return tlsConfig, info, nil
+	return tlsConfig, entry, nil
+}
+
+// workerAuthWrapper returns the wrapper to decrypt the worker auth info
+// encrypted by the key keyId with. A worker which authenticates with one of
+// its credentials uses the credential id as key id, and a worker which
+// authenticates with its activation token uses its worker id. Any other key id
+// is expected to belong to the shared worker-auth KMS. The worker and
+// credential ids are set in entry.
+func (c *Controller) workerAuthWrapper(ctx context.Context, keyId string, entry *workerAuthEntry) (wrapping.Wrapper, error) {
+	switch {
+	case strings.HasPrefix(keyId, servers.WorkerCredentialPrefix+"_"):
+		repo, err := c.ServersRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		cred, err := repo.LookupWorkerCredential(ctx, keyId)
+		if err != nil {
+			return nil, err
+		}
+		if cred == nil {
+			return nil, fmt.Errorf("unknown or expired worker credential %q", keyId)
+		}
+		entry.workerId, entry.credentialId = cred.GetWorkerId(), cred.GetPublicId()
+		return servers.NewWorkerCredentialWrapper(cred.GetPublicId(), cred.GetKey())
+
+	case strings.HasPrefix(keyId, servers.WorkerPrefix+"_"):
+		repo, err := c.ServersRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		token, err := repo.LookupActivationToken(ctx, keyId)
+		if err != nil {
+			return nil, err
+		}
+		entry.workerId = keyId
+		return servers.NewActivationTokenWrapper(token)
+
+	case c.conf.WorkerAuthKms != nil:
+		return c.conf.WorkerAuthKms, nil
+
+	default:
+		return nil, errors.New("worker did not authenticate with an activation token or credential and no worker-auth kms is configured")
+	}
+}
+
+// verifyWorkerName returns an error if name is not the name the worker with
+// workerId was registered with. The name in the worker auth info is chosen
+// by the worker, so a worker authenticating with its credential could
+// otherwise claim the name, and with it the tags, of another worker.
+func (c *Controller) verifyWorkerName(ctx context.Context, workerId, name string) error {
+	repo, err := c.ServersRepoFn()
+	if err != nil {
+		return err
+	}
+	w, err := repo.LookupWorker(ctx, workerId)
+	if err != nil {
+		return err
+	}
+	if w == nil {
+		return fmt.Errorf("unknown worker %q", workerId)
+	}
+	if w.GetName() != name {
+		return fmt.Errorf("worker %s authenticated with name %q but is registered as %q", workerId, name, w.GetName())
+	}
+	return nil
 }
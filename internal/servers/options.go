@@ -16,9 +16,11 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withLimit      int
-	withLiveness   time.Duration
-	withUpdateTags bool
+	withLimit              int
+	withLiveness           time.Duration
+	withUpdateTags         bool
+	withDescription        string
+	withStartPageAfterItem *string
 }
 
 func getDefaultOptions() options {
@@ -54,3 +56,19 @@ func WithUpdateTags(updateTags bool) Option {
 		o.withUpdateTags = updateTags
 	}
 }
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
@@ -24,4 +24,18 @@ func Test_GetOpts(t *testing.T) {
 		withUpdateTags: true,
 	}
 	assert.Equal(t, exp, opts)
+
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, testOpts, opts)
+	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterItem("w_1234567890"))
+		testOpts := getDefaultOptions()
+		id := "w_1234567890"
+		testOpts.withStartPageAfterItem = &id
+		assert.Equal(t, testOpts, opts)
+	})
 }
@@ -3,16 +3,52 @@ package servers
 const (
 	serverUpsertQuery = `
 		insert into server
-			(private_id, type, description, address, update_time)
+			(private_id, type, description, address, recording_public_key, update_time)
 		values
-			(@private_id, @type, @description, @address, now())
+			(@private_id, @type, @description, @address, @recording_public_key, now())
 		on conflict on constraint server_pkey
 		do update set
 			type = @type,
 			description = @description,
 			address = @address,
+			recording_public_key = @recording_public_key,
 			update_time = now();
 	`
+	// insertWorkerForServerQuery adds a worker which reports its status under
+	// the name @name, unless it is already known. Names which are not valid
+	// worker names are skipped rather than failing the status report.
+	insertWorkerForServerQuery = `
+		insert into server_worker
+			(public_id, name)
+		select @public_id, @name
+		where @name = lower(trim(@name))
+			and length(@name) between 1 and 127
+		on conflict on constraint server_worker_name_uq
+		do nothing;
+	`
+	activateWorkerQuery = `
+		update server_worker
+			set activation_time = now(),
+				ct_activation_token = null,
+				key_id = null,
+				activation_token_expiration_time = null
+		where public_id = @public_id
+			and activation_time is null
+			and activation_token_expiration_time > now();
+	`
+	// expireWorkerCredentialsQuery shortens the expiration of the credentials
+	// of a worker, other than public_id, to expiration_time.
+	expireWorkerCredentialsQuery = `
+		update server_worker_credential
+			set expiration_time = least(expiration_time, @expiration_time)
+		where worker_id = @worker_id
+			and public_id != @public_id;
+	`
+	deleteExpiredWorkerCredentialsQuery = `
+		delete from server_worker_credential
+		where worker_id = @worker_id
+			and expiration_time <= now();
+	`
 	deleteWhereCreateTimeSql = `create_time < ?`
 	deleteTagsSql            = `server_id = ?`
 )
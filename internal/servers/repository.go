@@ -113,18 +113,28 @@ func (ServerTag) TableName() string {
 }
 
 // ListTagsForServers pulls out tag tuples into ServerTag structs for the
-// given server ID values.
+// given server ID values. The tags of a worker are the tags in its
+// configuration merged with the tags set on it through the API.
 func (r *Repository) ListTagsForServers(ctx context.Context, serverIds []string, opt ...Option) ([]*ServerTag, error) {
-	var serverTags []*ServerTag
+	var tags []*canonicalTag
 	if err := r.reader.SearchWhere(
 		ctx,
-		&serverTags,
+		&tags,
 		"server_id in (?)",
 		[]interface{}{serverIds},
 		db.WithLimit(-1),
+		db.WithOrder("server_id, key, value"),
 	); err != nil {
 		return nil, errors.Wrap(ctx, err, "servers.ListTagsForServers", errors.WithMsg(fmt.Sprintf("server IDs %v", serverIds)))
 	}
+	serverTags := make([]*ServerTag, 0, len(tags))
+	for _, t := range tags {
+		serverTags = append(serverTags, &ServerTag{
+			ServerId: t.ServerId,
+			Key:      t.Key,
+			Value:    t.Value,
+		})
+	}
 	return serverTags, nil
 }
 
@@ -153,13 +163,28 @@ func (r *Repository) UpsertServer(ctx context.Context, server *Server, opt ...Op
 					sql.Named("type", server.Type),
 					sql.Named("description", server.Description),
 					sql.Named("address", server.Address),
+					sql.Named("recording_public_key", server.RecordingPublicKey),
 				})
 			if err != nil {
 				return errors.Wrap(ctx, err, op+":Upsert")
 			}
 
-			// If it's a worker, fetch the current controllers to feed to them
+			// If it's a worker, make sure it's known as a worker resource,
+			// and fetch the current controllers to feed to them
 			if server.Type == resource.Worker.String() {
+				id, err := db.NewPublicId(WorkerPrefix)
+				if err != nil {
+					return errors.Wrap(ctx, err, op+":NewWorkerId")
+				}
+				if _, err := w.Exec(ctx,
+					insertWorkerForServerQuery,
+					[]interface{}{
+						sql.Named("public_id", id),
+						sql.Named("name", server.PrivateId),
+					}); err != nil {
+					return errors.Wrap(ctx, err, op+":InsertWorker")
+				}
+
 				// Fetch current controllers to feed to the workers
 				controllers, err = r.listServersWithReader(ctx, read, ServerTypeController)
 				if err != nil {
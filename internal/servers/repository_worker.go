@@ -0,0 +1,378 @@
+package servers
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+)
+
+// CreateWorker inserts w into the repository and returns a new Worker
+// containing the worker's PublicId and its one-time activation token. w is not
+// changed. w must contain a Name and must not contain a PublicId. The name
+// must be unique and match the name the worker is configured with. The
+// activation token expires after ActivationTokenLifetime.
+func (r *Repository) CreateWorker(ctx context.Context, w *Worker, _ ...Option) (*Worker, error) {
+	const op = "servers.(Repository).CreateWorker"
+	if w == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Worker")
+	}
+	if w.Worker == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Worker")
+	}
+	if w.Name == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing name")
+	}
+	if w.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	w = w.clone()
+	w.ScopeId = scope.Global.String()
+
+	id, err := db.NewPublicId(WorkerPrefix)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	secret, err := base62.Random(32)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to generate activation token"))
+	}
+	w.PublicId = id
+	w.ActivationToken = fmt.Sprintf("%s_%s", id, secret)
+	w.ActivationTokenExpirationTime = timestamp.New(time.Now().Add(ActivationTokenLifetime))
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := w.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+	// The plaintext token must not end up in the oplog.
+	token := w.ActivationToken
+	w.ActivationToken = ""
+
+	metadata := newWorkerMetadata(w, oplog.OpType_OP_TYPE_CREATE)
+
+	var newWorker *Worker
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, wr db.Writer) error {
+			newWorker = w.clone()
+			if err := wr.Create(ctx, newWorker, db.WithOplog(oplogWrapper, metadata)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("name %s already exists", w.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newWorker.CtActivationToken = nil
+	newWorker.ActivationToken = token
+	return newWorker, nil
+}
+
+// LookupWorker returns the Worker for id, including the address and the time
+// of its last status report and its configuration and API tags. Returns nil,
+// nil if no Worker is found for id. The activation token of the worker is not
+// returned.
+func (r *Repository) LookupWorker(ctx context.Context, id string, _ ...Option) (*Worker, error) {
+	const op = "servers.(Repository).LookupWorker"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	var aggs []*workerAggregate
+	if err := r.reader.SearchWhere(ctx, &aggs, "public_id = ?", []interface{}{id}, db.WithLimit(1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+	if len(aggs) == 0 {
+		return nil, nil
+	}
+	w := aggs[0].toWorker()
+	if err := r.listWorkerTags(ctx, []*Worker{w}); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return w, nil
+}
+
+// ListWorkers returns the Workers, including the address and the time of
+// their last status report and their configuration and API tags. Supports the
+// WithLimit and WithStartPageAfterItem options. The activation tokens of the
+// workers are not returned.
+func (r *Repository) ListWorkers(ctx context.Context, opt ...Option) ([]*Worker, error) {
+	const op = "servers.(Repository).ListWorkers"
+	opts := getOpts(opt...)
+	var dbOpts []db.Option
+	if opts.withLimit != 0 {
+		dbOpts = append(dbOpts, db.WithLimit(opts.withLimit))
+	}
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
+	var aggs []*workerAggregate
+	if err := r.reader.SearchWhere(ctx, &aggs, "true", nil, dbOpts...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	workers := make([]*Worker, 0, len(aggs))
+	for _, agg := range aggs {
+		workers = append(workers, agg.toWorker())
+	}
+	if err := r.listWorkerTags(ctx, workers); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return workers, nil
+}
+
+// DeleteWorker deletes the Worker for id and the credentials issued to it,
+// returning a count of the number of records deleted. Once deleted, the
+// worker can no longer authenticate to the controllers with a credential. A
+// worker which authenticates with the worker-auth KMS is added again on its
+// next status report.
+func (r *Repository) DeleteWorker(ctx context.Context, id string, _ ...Option) (int, error) {
+	const op = "servers.(Repository).DeleteWorker"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	w := allocWorker()
+	w.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, w); err != nil {
+		if errors.IsNotFoundError(err) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newWorkerMetadata(w, oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, wr db.Writer) error {
+			dw := w.clone()
+			rowsDeleted, err = wr.Delete(ctx, dw, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", w.PublicId)))
+	}
+	return rowsDeleted, nil
+}
+
+// LookupActivationToken returns the activation token of the worker
+// workerId. Returns a RecordNotFound error if the worker does not exist, has
+// already been activated or if its activation token has expired.
+func (r *Repository) LookupActivationToken(ctx context.Context, workerId string) (string, error) {
+	const op = "servers.(Repository).LookupActivationToken"
+	if workerId == "" {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	}
+	var workers []*Worker
+	if err := r.reader.SearchWhere(ctx, &workers,
+		"public_id = ? and activation_time is null and activation_token_expiration_time > now()",
+		[]interface{}{workerId}, db.WithLimit(1)); err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	if len(workers) == 0 {
+		return "", errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("no activation token for worker %s", workerId))
+	}
+	w := workers[0]
+	databaseWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(w.KeyId))
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := w.decrypt(ctx, databaseWrapper); err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return w.ActivationToken, nil
+}
+
+// ActivateWorker activates the worker workerId, which presented its
+// activation token under the name name, and returns its first credential. The
+// activation token can no longer be used afterwards. Returns a RecordNotFound
+// error if the worker does not exist, has already been activated or if its
+// activation token has expired, and an InvalidParameter error if name is not
+// the name of the worker.
+func (r *Repository) ActivateWorker(ctx context.Context, workerId, name string) (*WorkerCredential, error) {
+	const op = "servers.(Repository).ActivateWorker"
+	if workerId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	}
+	if name == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing name")
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scope.Global.String(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+
+	var cred *WorkerCredential
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			wk := allocWorker()
+			wk.PublicId = workerId
+			if err := reader.LookupByPublicId(ctx, wk); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", workerId)))
+			}
+			if wk.Name != name {
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("name %q does not match the name of worker %s", name, workerId))
+			}
+			rowsUpdated, err := w.Exec(ctx, activateWorkerQuery, []interface{}{
+				sql.Named("public_id", workerId),
+			})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("worker %s has already been activated or its activation token has expired", workerId))
+			}
+			cred, err = r.createWorkerCredential(ctx, w, workerId, databaseWrapper)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("worker %s not found or its activation token can no longer be used", workerId))
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return cred, nil
+}
+
+// LookupWorkerCredential returns the WorkerCredential for id, including its
+// key. Returns nil, nil if no credential is found for id or if it has
+// expired.
+func (r *Repository) LookupWorkerCredential(ctx context.Context, id string) (*WorkerCredential, error) {
+	const op = "servers.(Repository).LookupWorkerCredential"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	var creds []*WorkerCredential
+	if err := r.reader.SearchWhere(ctx, &creds, "public_id = ? and expiration_time > now()", []interface{}{id}, db.WithLimit(1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(creds) == 0 {
+		return nil, nil
+	}
+	c := creds[0]
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scope.Global.String(), kms.KeyPurposeDatabase, kms.WithKeyId(c.KeyId))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := c.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.CtKey = nil
+	return c, nil
+}
+
+// RotateWorkerCredential issues a new credential to the worker the credential
+// id was issued to and returns it. The other credentials of the worker expire
+// shortly after, leaving the worker time to switch to the new credential, and
+// the expired credentials of the worker are deleted. Returns a RecordNotFound
+// error if the credential id does not exist or has expired.
+func (r *Repository) RotateWorkerCredential(ctx context.Context, id string) (*WorkerCredential, error) {
+	const op = "servers.(Repository).RotateWorkerCredential"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scope.Global.String(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+
+	var cred *WorkerCredential
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var creds []*WorkerCredential
+			if err := reader.SearchWhere(ctx, &creds, "public_id = ? and expiration_time > now()", []interface{}{id}, db.WithLimit(1)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if len(creds) == 0 {
+				return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("credential %s not found or expired", id))
+			}
+			workerId := creds[0].WorkerId
+			cred, err = r.createWorkerCredential(ctx, w, workerId, databaseWrapper)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if _, err := w.Exec(ctx, expireWorkerCredentialsQuery, []interface{}{
+				sql.Named("expiration_time", timestamp.New(time.Now().Add(workerCredentialRotationGrace))),
+				sql.Named("worker_id", workerId),
+				sql.Named("public_id", cred.PublicId),
+			}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to expire previous credentials"))
+			}
+			if _, err := w.Exec(ctx, deleteExpiredWorkerCredentialsQuery, []interface{}{
+				sql.Named("worker_id", workerId),
+			}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete expired credentials"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return cred, nil
+}
+
+// createWorkerCredential creates a new credential for the worker workerId
+// using w and returns it with its plaintext key.
+func (r *Repository) createWorkerCredential(ctx context.Context, w db.Writer, workerId string, databaseWrapper wrapping.Wrapper) (*WorkerCredential, error) {
+	const op = "servers.(Repository).createWorkerCredential"
+	c, err := newWorkerCredential(ctx, workerId, rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.ExpirationTime = timestamp.New(time.Now().Add(WorkerCredentialLifetime))
+	if err := c.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := w.Create(ctx, c); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.CtKey = nil
+	return c, nil
+}
@@ -0,0 +1,274 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// AddWorkerTags adds the API tags to the worker workerId and returns all the
+// API tags of the worker. workerVersion must match the current version of the
+// worker.
+func (r *Repository) AddWorkerTags(ctx context.Context, workerId string, workerVersion uint32, tags map[string][]string, _ ...Option) ([]*WorkerTag, error) {
+	const op = "servers.(Repository).AddWorkerTags"
+	switch {
+	case workerId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	case workerVersion == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	case len(tags) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing tags")
+	}
+	newTags := workerTagsFromMap(workerId, tags)
+	if len(newTags) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing tag values")
+	}
+
+	var currentTags []*WorkerTag
+	err := r.updateWorkerTags(ctx, workerId, workerVersion, oplog.OpType_OP_TYPE_CREATE,
+		func(_ db.Reader, w db.Writer) ([]*oplog.Message, error) {
+			msgs := make([]*oplog.Message, 0, len(newTags))
+			if err := w.CreateItems(ctx, newTags, db.NewOplogMsgs(&msgs)); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to add worker tags"))
+			}
+			return msgs, nil
+		},
+		&currentTags)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return currentTags, nil
+}
+
+// SetWorkerTags sets the API tags of the worker workerId to tags, adding and
+// removing tags as needed, and returns all the API tags of the worker. If tags
+// is empty, all the API tags of the worker are removed. workerVersion must
+// match the current version of the worker.
+func (r *Repository) SetWorkerTags(ctx context.Context, workerId string, workerVersion uint32, tags map[string][]string, _ ...Option) ([]*WorkerTag, error) {
+	const op = "servers.(Repository).SetWorkerTags"
+	switch {
+	case workerId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	case workerVersion == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	wanted := make(map[workerTagKey]bool)
+	for _, t := range workerTagsFromMap(workerId, tags) {
+		wanted[t.(*WorkerTag).key()] = true
+	}
+
+	var currentTags []*WorkerTag
+	err := r.updateWorkerTags(ctx, workerId, workerVersion, oplog.OpType_OP_TYPE_UPDATE,
+		func(reader db.Reader, w db.Writer) ([]*oplog.Message, error) {
+			var existing []*WorkerTag
+			if err := reader.SearchWhere(ctx, &existing, "worker_id = ?", []interface{}{workerId}, db.WithLimit(-1)); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up current worker tags"))
+			}
+			var deleteTags []interface{}
+			for _, t := range existing {
+				if wanted[t.key()] {
+					delete(wanted, t.key())
+					continue
+				}
+				deleteTags = append(deleteTags, t)
+			}
+			var addTags []interface{}
+			for k := range wanted {
+				addTags = append(addTags, newWorkerTag(workerId, k.key, k.value))
+			}
+
+			var msgs []*oplog.Message
+			if len(deleteTags) > 0 {
+				deleteMsgs := make([]*oplog.Message, 0, len(deleteTags))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteTags, db.NewOplogMsgs(&deleteMsgs))
+				if err != nil {
+					return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to remove worker tags"))
+				}
+				if rowsDeleted != len(deleteTags) {
+					return nil, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("worker tags removed %d did not match request for %d", rowsDeleted, len(deleteTags)))
+				}
+				msgs = append(msgs, deleteMsgs...)
+			}
+			if len(addTags) > 0 {
+				addMsgs := make([]*oplog.Message, 0, len(addTags))
+				if err := w.CreateItems(ctx, addTags, db.NewOplogMsgs(&addMsgs)); err != nil {
+					return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to add worker tags"))
+				}
+				msgs = append(msgs, addMsgs...)
+			}
+			return msgs, nil
+		},
+		&currentTags)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return currentTags, nil
+}
+
+// DeleteWorkerTags removes the API tags from the worker workerId and returns
+// the number of tags removed. Every tag must be set on the worker.
+// workerVersion must match the current version of the worker.
+func (r *Repository) DeleteWorkerTags(ctx context.Context, workerId string, workerVersion uint32, tags map[string][]string, _ ...Option) (int, error) {
+	const op = "servers.(Repository).DeleteWorkerTags"
+	switch {
+	case workerId == "":
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	case workerVersion == 0:
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	case len(tags) == 0:
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing tags")
+	}
+	deleteTags := workerTagsFromMap(workerId, tags)
+	if len(deleteTags) == 0 {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing tag values")
+	}
+
+	var totalRowsDeleted int
+	err := r.updateWorkerTags(ctx, workerId, workerVersion, oplog.OpType_OP_TYPE_DELETE,
+		func(_ db.Reader, w db.Writer) ([]*oplog.Message, error) {
+			msgs := make([]*oplog.Message, 0, len(deleteTags))
+			rowsDeleted, err := w.DeleteItems(ctx, deleteTags, db.NewOplogMsgs(&msgs))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to remove worker tags"))
+			}
+			if rowsDeleted != len(deleteTags) {
+				return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("worker tags removed %d did not match request for %d", rowsDeleted, len(deleteTags)))
+			}
+			totalRowsDeleted = rowsDeleted
+			return msgs, nil
+		},
+		nil)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return totalRowsDeleted, nil
+}
+
+// updateWorkerTags increments the version of the worker workerId, which must
+// be workerVersion, and applies the tag changes of fn in the same
+// transaction, writing a single oplog entry for both. If currentTags is not
+// nil, it is set to the API tags of the worker after the changes.
+func (r *Repository) updateWorkerTags(ctx context.Context, workerId string, workerVersion uint32, opType oplog.OpType,
+	fn func(db.Reader, db.Writer) ([]*oplog.Message, error), currentTags *[]*WorkerTag) error {
+	const op = "servers.(Repository).updateWorkerTags"
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.Global.String(), kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			worker := allocWorker()
+			worker.PublicId = workerId
+			worker.ScopeId = scope.Global.String()
+			ticket, err := w.GetTicket(worker)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			updatedWorker := allocWorker()
+			updatedWorker.PublicId = workerId
+			updatedWorker.Version = workerVersion + 1
+			var workerOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, updatedWorker, []string{"Version"}, nil, db.NewOplogMsg(&workerOplogMsg), db.WithVersion(&workerVersion))
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update worker version"))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.VersionMismatch, op, fmt.Sprintf("worker %s not found or version %d is not its current version", workerId, workerVersion))
+			}
+			msgs := []*oplog.Message{&workerOplogMsg}
+			tagMsgs, err := fn(reader, w)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, tagMsgs...)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, newWorkerMetadata(worker, opType), msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			if currentTags != nil {
+				if err := reader.SearchWhere(ctx, currentTags, "worker_id = ?", []interface{}{workerId}, db.WithLimit(-1)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current worker tags"))
+				}
+			}
+			return nil
+		},
+	)
+	return err
+}
+
+// listWorkerTags sets the configuration and API tags of the workers.
+func (r *Repository) listWorkerTags(ctx context.Context, workers []*Worker) error {
+	const op = "servers.(Repository).listWorkerTags"
+	if len(workers) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(workers))
+	names := make([]string, 0, len(workers))
+	byId := make(map[string]*Worker, len(workers))
+	byName := make(map[string]*Worker, len(workers))
+	for _, w := range workers {
+		ids = append(ids, w.GetPublicId())
+		names = append(names, w.GetName())
+		byId[w.GetPublicId()] = w
+		byName[w.GetName()] = w
+	}
+
+	var configTags []*ServerTag
+	if err := r.reader.SearchWhere(ctx, &configTags, "server_id in (?)", []interface{}{names}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to list configuration tags"))
+	}
+	for _, t := range configTags {
+		w := byName[t.ServerId]
+		if w.ConfigTags == nil {
+			w.ConfigTags = make(map[string][]string)
+		}
+		w.ConfigTags[t.Key] = append(w.ConfigTags[t.Key], t.Value)
+	}
+
+	var apiTags []*WorkerTag
+	if err := r.reader.SearchWhere(ctx, &apiTags, "worker_id in (?)", []interface{}{ids}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to list api tags"))
+	}
+	for _, t := range apiTags {
+		w := byId[t.WorkerId]
+		if w.ApiTags == nil {
+			w.ApiTags = make(map[string][]string)
+		}
+		w.ApiTags[t.Key] = append(w.ApiTags[t.Key], t.Value)
+	}
+	return nil
+}
+
+type workerTagKey struct {
+	key, value string
+}
+
+func (t *WorkerTag) key() workerTagKey {
+	return workerTagKey{key: t.GetKey(), value: t.GetValue()}
+}
+
+// workerTagsFromMap returns the tags of the worker workerId in tags, without
+// duplicates.
+func workerTagsFromMap(workerId string, tags map[string][]string) []interface{} {
+	seen := make(map[workerTagKey]bool)
+	var ret []interface{}
+	for k, vals := range tags {
+		for _, v := range vals {
+			tk := workerTagKey{key: k, value: v}
+			if seen[tk] {
+				continue
+			}
+			seen[tk] = true
+			ret = append(ret, newWorkerTag(workerId, k, v))
+		}
+	}
+	return ret
+}
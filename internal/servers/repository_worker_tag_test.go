@@ -0,0 +1,198 @@
+package servers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_WorkerTags(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	newWorker := func(t *testing.T, name string) *Worker {
+		t.Helper()
+		in, err := NewWorker(name)
+		require.NoError(t, err)
+		w, err := repo.CreateWorker(ctx, in)
+		require.NoError(t, err)
+		return w
+	}
+	lookup := func(t *testing.T, id string) *Worker {
+		t.Helper()
+		w, err := repo.LookupWorker(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, w)
+		return w
+	}
+
+	t.Run("add", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := newWorker(t, "tags-add")
+		tags, err := repo.AddWorkerTags(ctx, w.GetPublicId(), w.GetVersion(), map[string][]string{
+			"region": {"us-east-1"},
+			"type":   {"prod", "web", "prod"},
+		})
+		require.NoError(err)
+		assert.Len(tags, 3)
+
+		got := lookup(t, w.GetPublicId())
+		assert.Equal(w.GetVersion()+1, got.GetVersion())
+		assert.Equal([]string{"us-east-1"}, got.ApiTags["region"])
+		assert.ElementsMatch([]string{"prod", "web"}, got.ApiTags["type"])
+
+		_, err = repo.AddWorkerTags(ctx, w.GetPublicId(), w.GetVersion(), map[string][]string{"type": {"db"}})
+		assert.Truef(errors.Match(errors.T(errors.VersionMismatch), err), "unexpected error: %v", err)
+
+		_, err = repo.AddWorkerTags(ctx, w.GetPublicId(), got.GetVersion(), map[string][]string{"type": {"prod"}})
+		assert.True(errors.IsUniqueError(err), "unexpected error: %v", err)
+	})
+	t.Run("set", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := newWorker(t, "tags-set")
+		_, err := repo.AddWorkerTags(ctx, w.GetPublicId(), w.GetVersion(), map[string][]string{
+			"region": {"us-east-1"},
+			"type":   {"prod"},
+		})
+		require.NoError(err)
+
+		tags, err := repo.SetWorkerTags(ctx, w.GetPublicId(), w.GetVersion()+1, map[string][]string{
+			"type": {"prod", "db"},
+		})
+		require.NoError(err)
+		assert.Len(tags, 2)
+		got := lookup(t, w.GetPublicId())
+		assert.Equal(w.GetVersion()+2, got.GetVersion())
+		assert.Len(got.ApiTags, 1)
+		assert.ElementsMatch([]string{"prod", "db"}, got.ApiTags["type"])
+
+		tags, err = repo.SetWorkerTags(ctx, w.GetPublicId(), got.GetVersion(), nil)
+		require.NoError(err)
+		assert.Empty(tags)
+		got = lookup(t, w.GetPublicId())
+		assert.Empty(got.ApiTags)
+	})
+	t.Run("remove", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := newWorker(t, "tags-remove")
+		_, err := repo.AddWorkerTags(ctx, w.GetPublicId(), w.GetVersion(), map[string][]string{
+			"type": {"prod", "web"},
+		})
+		require.NoError(err)
+
+		_, err = repo.DeleteWorkerTags(ctx, w.GetPublicId(), w.GetVersion()+1, map[string][]string{
+			"type": {"db"},
+		})
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+
+		rows, err := repo.DeleteWorkerTags(ctx, w.GetPublicId(), w.GetVersion()+1, map[string][]string{
+			"type": {"web"},
+		})
+		require.NoError(err)
+		assert.Equal(1, rows)
+		got := lookup(t, w.GetPublicId())
+		assert.Equal(map[string][]string{"type": {"prod"}}, got.ApiTags)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.AddWorkerTags(ctx, "", 1, map[string][]string{"a": {"b"}})
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		_, err = repo.AddWorkerTags(ctx, "w_1234567890", 0, map[string][]string{"a": {"b"}})
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		_, err = repo.AddWorkerTags(ctx, "w_1234567890", 1, nil)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		_, err = repo.DeleteWorkerTags(ctx, "w_1234567890", 1, map[string][]string{"a": nil})
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		_, err = repo.SetWorkerTags(ctx, "w_1234567890", 1, map[string][]string{"a": {"b"}})
+		assert.Truef(errors.Match(errors.T(errors.VersionMismatch), err), "unexpected error: %v", err)
+	})
+}
+
+func TestRepository_WorkerStatusAndCanonicalTags(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	srv := &Server{
+		PrivateId:          "kms-worker",
+		Type:               ServerTypeWorker.String(),
+		Address:            "127.0.0.1:9202",
+		RecordingPublicKey: []byte("-----BEGIN PUBLIC KEY-----"),
+		Tags: map[string]*TagValues{
+			"type": {Values: []string{"prod"}},
+		},
+	}
+	_, _, err = repo.UpsertServer(ctx, srv, WithUpdateTags(true))
+	require.NoError(err)
+	// A second status report does not add the worker again.
+	_, _, err = repo.UpsertServer(ctx, srv)
+	require.NoError(err)
+	// Workers reporting names which are not valid worker names are not
+	// added.
+	_, _, err = repo.UpsertServer(ctx, &Server{PrivateId: "Invalid Name", Type: ServerTypeWorker.String()})
+	require.NoError(err)
+
+	workers, err := repo.ListWorkers(ctx)
+	require.NoError(err)
+	require.Len(workers, 1)
+	w := workers[0]
+	assert.Equal("kms-worker", w.GetName())
+	assert.Equal("127.0.0.1:9202", w.Address)
+	assert.NotNil(w.LastStatusTime)
+	assert.Equal([]byte("-----BEGIN PUBLIC KEY-----"), w.RecordingPublicKey)
+	assert.Nil(w.GetActivationTokenExpirationTime())
+	assert.Equal(map[string][]string{"type": {"prod"}}, w.ConfigTags)
+
+	_, err = repo.AddWorkerTags(ctx, w.GetPublicId(), w.GetVersion(), map[string][]string{
+		"type":   {"prod", "canary"},
+		"region": {"us-east-1"},
+	})
+	require.NoError(err)
+
+	got, err := repo.LookupWorker(ctx, w.GetPublicId())
+	require.NoError(err)
+	assert.Equal(map[string][]string{"type": {"prod"}}, got.ConfigTags)
+	canonical := got.CanonicalTags()
+	assert.Equal([]string{"us-east-1"}, canonical["region"])
+	assert.ElementsMatch([]string{"prod", "canary"}, canonical["type"])
+
+	tags, err := repo.ListTagsForServers(ctx, []string{"kms-worker"})
+	require.NoError(err)
+	assert.Equal([]*ServerTag{
+		{ServerId: "kms-worker", Key: "region", Value: "us-east-1"},
+		{ServerId: "kms-worker", Key: "type", Value: "canary"},
+		{ServerId: "kms-worker", Key: "type", Value: "prod"},
+	}, tags)
+
+	// Deleting a worker which authenticates with the worker-auth KMS only
+	// removes it until its next status report.
+	_, err = repo.DeleteWorker(ctx, w.GetPublicId())
+	require.NoError(err)
+	tags, err = repo.ListTagsForServers(ctx, []string{"kms-worker"})
+	require.NoError(err)
+	assert.Equal([]*ServerTag{{ServerId: "kms-worker", Key: "type", Value: "prod"}}, tags)
+	_, _, err = repo.UpsertServer(ctx, srv)
+	require.NoError(err)
+	workers, err = repo.ListWorkers(ctx)
+	require.NoError(err)
+	require.Len(workers, 1)
+	assert.NotEqual(w.GetPublicId(), workers[0].GetPublicId())
+	assert.Empty(workers[0].ApiTags)
+}
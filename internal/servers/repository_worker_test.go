@@ -0,0 +1,252 @@
+package servers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateWorker(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in, err := NewWorker("create-valid", WithDescription("a worker"))
+		require.NoError(err)
+		got, err := repo.CreateWorker(ctx, in)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.True(len(got.GetPublicId()) > len(WorkerPrefix))
+		assert.Empty(in.GetPublicId(), "the input should not be changed")
+		assert.Equal("global", got.GetScopeId())
+		assert.Equal("create-valid", got.GetName())
+		assert.Equal("a worker", got.GetDescription())
+		assert.Nil(got.GetCtActivationToken())
+		assert.NotNil(got.GetActivationTokenExpirationTime())
+		assert.Nil(got.GetActivationTime())
+
+		workerId, err := ParseActivationToken(got.GetActivationToken())
+		require.NoError(err)
+		assert.Equal(got.GetPublicId(), workerId)
+		token, err := repo.LookupActivationToken(ctx, got.GetPublicId())
+		require.NoError(err)
+		assert.Equal(got.GetActivationToken(), token)
+
+		assert.NoError(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+	})
+	t.Run("duplicate-name", func(t *testing.T) {
+		require := require.New(t)
+		in, err := NewWorker("create-duplicate")
+		require.NoError(err)
+		_, err = repo.CreateWorker(ctx, in)
+		require.NoError(err)
+		_, err = repo.CreateWorker(ctx, in)
+		require.Error(err)
+		assert.True(t, errors.IsUniqueError(err))
+	})
+	t.Run("public-id-set", func(t *testing.T) {
+		in, err := NewWorker("create-public-id")
+		require.NoError(t, err)
+		in.PublicId = "w_1234567890"
+		_, err = repo.CreateWorker(ctx, in)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+	t.Run("nil", func(t *testing.T) {
+		_, err := repo.CreateWorker(ctx, nil)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+}
+
+func TestRepository_LookupListDeleteWorker(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	var ids []string
+	for _, name := range []string{"worker-a", "worker-b", "worker-c"} {
+		in, err := NewWorker(name)
+		require.NoError(err)
+		w, err := repo.CreateWorker(ctx, in)
+		require.NoError(err)
+		ids = append(ids, w.GetPublicId())
+	}
+
+	got, err := repo.LookupWorker(ctx, ids[0])
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal("worker-a", got.GetName())
+	assert.Empty(got.GetActivationToken())
+	assert.Nil(got.GetCtActivationToken())
+
+	got, err = repo.LookupWorker(ctx, "w_1234567890")
+	require.NoError(err)
+	assert.Nil(got)
+
+	workers, err := repo.ListWorkers(ctx)
+	require.NoError(err)
+	assert.Len(workers, 3)
+	for _, w := range workers {
+		assert.Nil(w.GetCtActivationToken())
+	}
+
+	workers, err = repo.ListWorkers(ctx, WithLimit(2))
+	require.NoError(err)
+	assert.Len(workers, 2)
+
+	rows, err := repo.DeleteWorker(ctx, ids[0])
+	require.NoError(err)
+	assert.Equal(1, rows)
+	got, err = repo.LookupWorker(ctx, ids[0])
+	require.NoError(err)
+	assert.Nil(got)
+	assert.NoError(db.TestVerifyOplog(t, rw, ids[0], db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second)))
+
+	rows, err = repo.DeleteWorker(ctx, ids[0])
+	require.NoError(err)
+	assert.Equal(0, rows)
+}
+
+func TestRepository_ActivateWorker(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	newWorker := func(t *testing.T, name string) *Worker {
+		t.Helper()
+		in, err := NewWorker(name)
+		require.NoError(t, err)
+		w, err := repo.CreateWorker(ctx, in)
+		require.NoError(t, err)
+		return w
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := newWorker(t, "activate-valid")
+		cred, err := repo.ActivateWorker(ctx, w.GetPublicId(), "activate-valid")
+		require.NoError(err)
+		require.NotNil(cred)
+		assert.Equal(w.GetPublicId(), cred.GetWorkerId())
+		assert.Len(cred.GetKey(), workerAuthKeyLength)
+		assert.Nil(cred.GetCtKey())
+		assert.True(cred.RotationTime().Before(cred.GetExpirationTime().AsTime()))
+
+		got, err := repo.LookupWorker(ctx, w.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(got.GetActivationTime())
+		assert.Nil(got.GetActivationTokenExpirationTime())
+
+		found, err := repo.LookupWorkerCredential(ctx, cred.GetPublicId())
+		require.NoError(err)
+		require.NotNil(found)
+		assert.Equal(cred.GetKey(), found.GetKey())
+
+		// The activation token is one-time
+		_, err = repo.LookupActivationToken(ctx, w.GetPublicId())
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+		_, err = repo.ActivateWorker(ctx, w.GetPublicId(), "activate-valid")
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+	})
+	t.Run("wrong-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := newWorker(t, "activate-wrong-name")
+		_, err := repo.ActivateWorker(ctx, w.GetPublicId(), "another-name")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+
+		got, err := repo.LookupWorker(ctx, w.GetPublicId())
+		require.NoError(err)
+		assert.Nil(got.GetActivationTime())
+	})
+	t.Run("expired-token", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := newWorker(t, "activate-expired")
+		_, err := rw.Exec(ctx, "update server_worker set activation_token_expiration_time = now() - interval '1 second' where public_id = ?", []interface{}{w.GetPublicId()})
+		require.NoError(err)
+		_, err = repo.ActivateWorker(ctx, w.GetPublicId(), "activate-expired")
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+		_, err = repo.LookupActivationToken(ctx, w.GetPublicId())
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+	})
+	t.Run("unknown-worker", func(t *testing.T) {
+		_, err := repo.ActivateWorker(ctx, "w_1234567890", "unknown")
+		assert.Truef(t, errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+	})
+}
+
+func TestRepository_RotateWorkerCredential(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	in, err := NewWorker("rotate")
+	require.NoError(err)
+	w, err := repo.CreateWorker(ctx, in)
+	require.NoError(err)
+	first, err := repo.ActivateWorker(ctx, w.GetPublicId(), "rotate")
+	require.NoError(err)
+
+	second, err := repo.RotateWorkerCredential(ctx, first.GetPublicId())
+	require.NoError(err)
+	assert.NotEqual(first.GetPublicId(), second.GetPublicId())
+	assert.NotEqual(first.GetKey(), second.GetKey())
+
+	// The previous credential remains valid for a short time only
+	prev, err := repo.LookupWorkerCredential(ctx, first.GetPublicId())
+	require.NoError(err)
+	require.NotNil(prev)
+	assert.True(prev.GetExpirationTime().AsTime().Before(time.Now().Add(workerCredentialRotationGrace + time.Minute)))
+
+	// Once expired, it can no longer be used and is deleted on the next
+	// rotation
+	_, err = rw.Exec(ctx, "update server_worker_credential set expiration_time = now() - interval '1 second' where public_id = ?", []interface{}{first.GetPublicId()})
+	require.NoError(err)
+	prev, err = repo.LookupWorkerCredential(ctx, first.GetPublicId())
+	require.NoError(err)
+	assert.Nil(prev)
+	_, err = repo.RotateWorkerCredential(ctx, first.GetPublicId())
+	assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+
+	_, err = repo.RotateWorkerCredential(ctx, second.GetPublicId())
+	require.NoError(err)
+	var creds []*WorkerCredential
+	require.NoError(rw.SearchWhere(ctx, &creds, "worker_id = ?", []interface{}{w.GetPublicId()}))
+	assert.Len(creds, 2)
+
+	// Deleting the worker revokes its credentials
+	_, err = repo.DeleteWorker(ctx, w.GetPublicId())
+	require.NoError(err)
+	got, err := repo.LookupWorkerCredential(ctx, second.GetPublicId())
+	require.NoError(err)
+	assert.Nil(got)
+}
@@ -0,0 +1,110 @@
+package servers
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+const (
+	rewrapWorkerActivationTokenQuery = `
+update server_worker
+   set ct_activation_token = @ct_activation_token,
+       key_id = @key_id
+ where public_id = @public_id;
+`
+	rewrapWorkerCredentialQuery = `
+update server_worker_credential
+   set ct_key = @ct_key,
+       key_id = @key_id
+ where public_id = @public_id;
+`
+)
+
+func init() {
+	if err := kms.RegisterTableRewrapFn(defaultWorkerTableName, rewrapWorkerActivationTokens); err != nil {
+		panic(err)
+	}
+	if err := kms.RegisterTableRewrapFn(defaultWorkerCredentialTableName, rewrapWorkerCredentials); err != nil {
+		panic(err)
+	}
+}
+
+// rewrapWorkerActivationTokens encrypts the activation tokens encrypted by the
+// database key version dataKeyVersionId with the current database key version
+// of scopeId.
+func rewrapWorkerActivationTokens(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "servers.rewrapWorkerActivationTokens"
+	var workers []*Worker
+	if err := reader.SearchWhere(ctx, &workers, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(workers) == 0 {
+		return nil
+	}
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for key version"))
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	for _, w := range workers {
+		if err := w.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := w.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapWorkerActivationTokenQuery, []interface{}{
+			sql.Named("ct_activation_token", w.CtActivationToken),
+			sql.Named("key_id", w.KeyId),
+			sql.Named("public_id", w.PublicId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update worker"))
+		}
+	}
+	return nil
+}
+
+// rewrapWorkerCredentials encrypts the worker credentials encrypted by the
+// database key version dataKeyVersionId with the current database key version
+// of scopeId.
+func rewrapWorkerCredentials(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "servers.rewrapWorkerCredentials"
+	var creds []*WorkerCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(creds) == 0 {
+		return nil
+	}
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for key version"))
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	for _, c := range creds {
+		if err := c.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapWorkerCredentialQuery, []interface{}{
+			sql.Named("ct_key", c.CtKey),
+			sql.Named("key_id", c.KeyId),
+			sql.Named("public_id", c.PublicId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update worker credential"))
+		}
+	}
+	return nil
+}
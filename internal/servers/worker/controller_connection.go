@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
 	"github.com/hashicorp/go-secure-stdlib/base62"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/resolver"
@@ -49,6 +50,10 @@ func (w *Worker) startControllerConnections() error {
 		return errors.New("no initial controller addresses found")
 	}
 
+	if err := w.startWorkerAuth(initialAddrs); err != nil {
+		return fmt.Errorf("error setting up worker auth: %w", err)
+	}
+
 	w.Resolver().InitialState(resolver.State{
 		Addresses: initialAddrs,
 	})
@@ -59,10 +64,12 @@ func (w *Worker) startControllerConnections() error {
 	return nil
 }
 
-func (w *Worker) controllerDialerFunc() func(context.Context, string) (net.Conn, error) {
+// controllerDialerFunc returns a dialer which authenticates to the controller
+// with the wrapper returned by authWrapper at the time of each dial.
+func (w *Worker) controllerDialerFunc(authWrapper func() wrapping.Wrapper) func(context.Context, string) (net.Conn, error) {
 	const op = "worker.(Worker).controllerDialerFunc"
 	return func(ctx context.Context, addr string) (net.Conn, error) {
-		tlsConf, authInfo, err := w.workerAuthTLSConfig()
+		tlsConf, authInfo, err := w.workerAuthTLSConfig(authWrapper())
 		if err != nil {
 			return nil, fmt.Errorf("error creating tls config for worker auth: %w", err)
 		}
@@ -116,7 +123,7 @@ func (w *Worker) createClientConn(addr string) error {
 		grpc.WithResolvers(res),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32)),
 		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(math.MaxInt32)),
-		grpc.WithContextDialer(w.controllerDialerFunc()),
+		grpc.WithContextDialer(w.controllerDialerFunc(w.authWrapper)),
 		grpc.WithInsecure(),
 		grpc.WithDefaultServiceConfig(defServiceConfig),
 		// Don't have the resolver reach out for a service config from the
@@ -134,7 +141,7 @@ func (w *Worker) createClientConn(addr string) error {
 	return nil
 }
 
-func (w *Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error) {
+func (w *Worker) workerAuthTLSConfig(authWrapper wrapping.Wrapper) (*tls.Config, *base.WorkerAuthInfo, error) {
 	var err error
 	info := &base.WorkerAuthInfo{
 		Name:        w.conf.RawConfig.Worker.Name,
@@ -192,7 +199,7 @@ func (w *Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error
 	if err != nil {
 		return nil, nil, err
 	}
-	encInfo, err := w.conf.WorkerAuthKms.Encrypt(context.Background(), marshaledInfo, nil)
+	encInfo, err := authWrapper.Encrypt(context.Background(), marshaledInfo, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+)
+
+var (
+	_ credential.UserPassword   = (*usernamePassword)(nil)
+	_ credential.KeyPair        = (*sshPrivateKey)(nil)
+	_ credential.SshCertificate = (*sshCertificate)(nil)
+)
+
+// usernamePassword is an egress credential containing a username and a
+// password, received from the controller when looking up a session.
+type usernamePassword struct {
+	username string
+	password credential.Password
+}
+
+func (c *usernamePassword) GetPublicId() string           { return "" }
+func (c *usernamePassword) Secret() credential.SecretData { return nil }
+func (c *usernamePassword) Username() string              { return c.username }
+func (c *usernamePassword) Password() credential.Password { return c.password }
+
+// sshPrivateKey is an egress credential containing a username and an ssh
+// private key, received from the controller when looking up a session.
+type sshPrivateKey struct {
+	username   string
+	privateKey credential.PrivateKey
+}
+
+func (c *sshPrivateKey) GetPublicId() string            { return "" }
+func (c *sshPrivateKey) Secret() credential.SecretData  { return nil }
+func (c *sshPrivateKey) Username() string               { return c.username }
+func (c *sshPrivateKey) Private() credential.PrivateKey { return c.privateKey }
+
+// sshCertificate is an egress credential containing a username, an ssh
+// private key and an ssh certificate for the key, received from the
+// controller when looking up a session.
+type sshCertificate struct {
+	sshPrivateKey
+	certificate []byte
+}
+
+func (c *sshCertificate) Certificate() []byte { return c.certificate }
+
+// egressCredentials converts the credentials returned by the controller for a
+// session into credentials usable by the proxy handlers.
+func egressCredentials(in []*pbs.Credential) ([]credential.Credential, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := make([]credential.Credential, 0, len(in))
+	for _, c := range in {
+		switch v := c.GetCredential().(type) {
+		case *pbs.Credential_UsernamePassword:
+			out = append(out, &usernamePassword{
+				username: v.UsernamePassword.GetUsername(),
+				password: credential.Password(v.UsernamePassword.GetPassword()),
+			})
+		case *pbs.Credential_SshPrivateKey:
+			key := sshPrivateKey{
+				username:   v.SshPrivateKey.GetUsername(),
+				privateKey: v.SshPrivateKey.GetPrivateKey(),
+			}
+			if cert := v.SshPrivateKey.GetCertificate(); len(cert) > 0 {
+				out = append(out, &sshCertificate{sshPrivateKey: key, certificate: cert})
+				continue
+			}
+			out = append(out, &key)
+		default:
+			return nil, fmt.Errorf("unsupported credential type %T", v)
+		}
+	}
+	return out, nil
+}
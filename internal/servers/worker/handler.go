@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/proxy"
 	proxyHandlers "github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
 	"github.com/hashicorp/boundary/internal/servers/worker/session"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
 	"nhooyr.io/websocket"
@@ -77,6 +79,7 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		tofuToken := si.LookupSessionResponse.GetTofuToken()
 		version := si.LookupSessionResponse.GetVersion()
 		endpoint := si.LookupSessionResponse.GetEndpoint()
+		recordingEnabled := si.LookupSessionResponse.GetRecordingEnabled()
 		sessStatus := si.Status
 		si.RUnlock()
 
@@ -105,6 +108,21 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		}
 		workerId := w.conf.RawConfig.Worker.Name
 
+		// A session which requires recording is only proxied by a worker which
+		// is able to record it.
+		var recordingPath string
+		if recordingEnabled {
+			storagePath := w.conf.RawConfig.Worker.RecordingStoragePath
+			if storagePath == "" {
+				event.WriteError(ctx, op, errors.New("session requires recording but no recording storage path is configured"), event.WithInfo("session_id", sessionId))
+				if err = conn.Close(websocket.StatusPolicyViolation, "session recording not supported by worker"); err != nil {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+				}
+				return
+			}
+			recordingPath = filepath.Join(storagePath, sessionId)
+		}
+
 		var handshake proxy.ClientHandshake
 		if err := wspb.Read(connCtx, conn, &handshake); err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error reading handshake from client"))
@@ -138,7 +156,7 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 				return
 			}
 			if handshake.Command == proxy.HANDSHAKECOMMAND_HANDSHAKECOMMAND_UNSPECIFIED {
-				sessStatus, err = session.Activate(ctx, sessClient, workerId, sessionId, handshake.GetTofuToken(), version)
+				sessStatus, err = session.Activate(ctx, sessClient, workerId, sessionId, handshake.GetTofuToken(), version, recordingPath)
 				if err != nil {
 					event.WriteError(ctx, op, err, event.WithInfoMsg("unable to validate session"))
 					if err = conn.Close(websocket.StatusInternalError, "unable to activate session"); err != nil {
@@ -232,7 +250,53 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 			return
 		}
 
-		if err = handleProxyFn(connCtx, conf); err != nil {
+		var proxyOpts []proxyHandlers.Option
+		si.RLock()
+		creds, err := egressCredentials(si.LookupSessionResponse.GetCredentials())
+		si.RUnlock()
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error reading session credentials"))
+			if err = conn.Close(websocket.StatusInternalError, "unable to read session credentials"); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+			}
+			return
+		}
+		if len(creds) > 0 {
+			proxyOpts = append(proxyOpts, proxyHandlers.WithEgressCredentials(creds))
+		}
+		si.RLock()
+		uploadRateLimit := si.LookupSessionResponse.GetUploadRateLimit()
+		downloadRateLimit := si.LookupSessionResponse.GetDownloadRateLimit()
+		endpointHostKey := si.LookupSessionResponse.GetEndpointHostKey()
+		si.RUnlock()
+		if uploadRateLimit > 0 {
+			proxyOpts = append(proxyOpts, proxyHandlers.WithUploadRateLimit(uploadRateLimit))
+		}
+		if downloadRateLimit > 0 {
+			proxyOpts = append(proxyOpts, proxyHandlers.WithDownloadRateLimit(downloadRateLimit))
+		}
+		if endpointHostKey != "" {
+			proxyOpts = append(proxyOpts, proxyHandlers.WithEndpointHostKey(endpointHostKey))
+		}
+
+		if recordingEnabled {
+			recorder, err := recording.NewRecorder(recordingPath, sessionId, ci.Id, w.recordingKey)
+			if err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error creating session recorder", "session_id", sessionId))
+				if err = conn.Close(websocket.StatusInternalError, "unable to record session"); err != nil {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+				}
+				return
+			}
+			defer func() {
+				if err := recorder.Close(); err != nil {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error closing session recorder", "session_id", sessionId, "connection_id", ci.Id))
+				}
+			}()
+			proxyOpts = append(proxyOpts, proxyHandlers.WithRecorder(recorder))
+		}
+
+		if err = handleProxyFn(connCtx, conf, proxyOpts...); err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error handling proxy", "session_id", sessionId, "endpoint", endpoint))
 			if err = conn.Close(websocket.StatusInternalError, "unable to establish proxy"); err != nil {
 				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
@@ -1,5 +1,7 @@
 package worker
 
 import (
+	_ "github.com/hashicorp/boundary/internal/servers/worker/proxy/ssh"
 	_ "github.com/hashicorp/boundary/internal/servers/worker/proxy/tcp"
+	_ "github.com/hashicorp/boundary/internal/servers/worker/proxy/udp"
 )
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
 )
 
 // Option - how Options are passed as arguments.
@@ -19,11 +20,19 @@ func GetOpts(opt ...Option) Options {
 // Options = how options are represented
 type Options struct {
 	WithEgressCredentials []credential.Credential
+	WithRecorder          *recording.Recorder
+	WithUploadRateLimit   uint32
+	WithDownloadRateLimit uint32
+	WithEndpointHostKey   string
 }
 
 func getDefaultOptions() Options {
 	return Options{
 		WithEgressCredentials: nil,
+		WithRecorder:          nil,
+		WithUploadRateLimit:   0,
+		WithDownloadRateLimit: 0,
+		WithEndpointHostKey:   "",
 	}
 }
 
@@ -33,3 +42,35 @@ func WithEgressCredentials(creds []credential.Credential) Option {
 		o.WithEgressCredentials = creds
 	}
 }
+
+// WithRecorder provides an optional recorder to record the data proxied in
+// both directions of the connection
+func WithRecorder(r *recording.Recorder) Option {
+	return func(o *Options) {
+		o.WithRecorder = r
+	}
+}
+
+// WithUploadRateLimit provides an optional max rate, in bytes per second, at
+// which data is sent from the client to the endpoint. 0 means unlimited.
+func WithUploadRateLimit(bytesPerSecond uint32) Option {
+	return func(o *Options) {
+		o.WithUploadRateLimit = bytesPerSecond
+	}
+}
+
+// WithDownloadRateLimit provides an optional max rate, in bytes per second, at
+// which data is sent from the endpoint to the client. 0 means unlimited.
+func WithDownloadRateLimit(bytesPerSecond uint32) Option {
+	return func(o *Options) {
+		o.WithDownloadRateLimit = bytesPerSecond
+	}
+}
+
+// WithEndpointHostKey provides an optional public key, in the authorized_keys
+// format, which the endpoint must present as its ssh host key.
+func WithEndpointHostKey(key string) Option {
+	return func(o *Options) {
+		o.WithEndpointHostKey = key
+	}
+}
@@ -1,10 +1,14 @@
 package proxy
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type cred struct {
@@ -27,4 +31,40 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.WithEgressCredentials = []credential.Credential{c}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithRecorder", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(err)
+		r, err := recording.NewRecorder(t.TempDir(), "s_1234567890", "sc_1234567890", key)
+		require.NoError(err)
+		opts := GetOpts(WithRecorder(r))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.WithRecorder = r
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUploadRateLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithUploadRateLimit(1024))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.WithUploadRateLimit = 1024
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDownloadRateLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithDownloadRateLimit(1024))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.WithDownloadRateLimit = 1024
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndpointHostKey", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithEndpointHostKey("ssh-ed25519 AAAA"))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.WithEndpointHostKey = "ssh-ed25519 AAAA"
+		assert.Equal(opts, testOpts)
+	})
 }
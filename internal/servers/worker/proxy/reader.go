@@ -0,0 +1,140 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	ua "go.uber.org/atomic"
+)
+
+// NewCountingReader returns a reader which adds the number of bytes read from
+// r to counter.
+func NewCountingReader(r io.Reader, counter *ua.Uint64) io.Reader {
+	return &countingReader{r: r, counter: counter}
+}
+
+type countingReader struct {
+	r       io.Reader
+	counter *ua.Uint64
+}
+
+// Read reads from the underlying reader and counts the bytes read.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.counter.Add(uint64(n))
+	}
+	return n, err
+}
+
+// NewRateLimitedReader returns a reader which reads from r at no more than
+// bytesPerSecond on average. Up to one second's worth of bytes can be read at
+// once, after which reads block until the rate allows more bytes to be read or
+// ctx is done. A rate of 0 means unlimited, in which case r is returned.
+func NewRateLimitedReader(ctx context.Context, r io.Reader, bytesPerSecond uint32) io.Reader {
+	return NewRateLimiter(bytesPerSecond).Reader(ctx, r)
+}
+
+// RateLimiter limits the combined rate of the reads of all the readers
+// returned by its Reader method, so that a single rate can be enforced across
+// several streams, such as the channels of an ssh connection. It is safe for
+// concurrent use.
+type RateLimiter struct {
+	l      sync.Mutex
+	rate   float64
+	burst  uint32
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter which allows no more than
+// bytesPerSecond to be read on average. Up to one second's worth of bytes can
+// be read at once. A rate of 0 means unlimited, in which case nil is returned,
+// whose readers are not limited.
+func NewRateLimiter(bytesPerSecond uint32) *RateLimiter {
+	if bytesPerSecond == 0 {
+		return nil
+	}
+	return &RateLimiter{
+		rate:   float64(bytesPerSecond),
+		burst:  bytesPerSecond,
+		tokens: float64(bytesPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// Reader returns a reader which reads from r within the rate of the limiter,
+// blocking until the rate allows more bytes to be read or ctx is done. If the
+// limiter is nil, r is returned.
+func (l *RateLimiter) Reader(ctx context.Context, r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+	return &rateLimitedReader{
+		ctx:     ctx,
+		r:       r,
+		limiter: l,
+	}
+}
+
+// reserve takes n bytes from the token bucket, which holds up to burst bytes
+// and is refilled at rate bytes per second, and returns how long the caller
+// must wait for the bucket to no longer be in deficit.
+func (l *RateLimiter) reserve(n int) time.Duration {
+	l.l.Lock()
+	defer l.l.Unlock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// rateLimitedReader limits the rate of reads from r using the token bucket of
+// its limiter. It is not safe for concurrent use, but several readers can
+// share a limiter.
+type rateLimitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *RateLimiter
+}
+
+// Read reads at most burst bytes from the underlying reader, then waits until
+// the bytes read are allowed by the rate.
+func (l *rateLimitedReader) Read(p []byte) (int, error) {
+	if uint64(len(p)) > uint64(l.limiter.burst) {
+		p = p[:l.limiter.burst]
+	}
+	n, err := l.r.Read(p)
+	if n > 0 {
+		if waitErr := l.wait(n); waitErr != nil && err == nil {
+			err = waitErr
+		}
+	}
+	return n, err
+}
+
+// wait takes n bytes from the limiter and blocks until the limiter is no
+// longer in deficit or the context is done.
+func (l *rateLimitedReader) wait(n int) error {
+	d := l.limiter.reserve(n)
+	if d == 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-l.ctx.Done():
+		return l.ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
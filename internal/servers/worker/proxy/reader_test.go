@@ -0,0 +1,93 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ua "go.uber.org/atomic"
+)
+
+func TestCountingReader(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	counter := ua.NewUint64(5)
+	data := []byte("hello world")
+	got, err := io.ReadAll(NewCountingReader(bytes.NewReader(data), counter))
+	require.NoError(err)
+	assert.Equal(data, got)
+	assert.Equal(uint64(5+len(data)), counter.Load())
+}
+
+func TestRateLimitedReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unlimited", func(t *testing.T) {
+		r := bytes.NewReader([]byte("hello"))
+		assert.Equal(t, r, NewRateLimitedReader(context.Background(), r, 0))
+	})
+	t.Run("limited", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		const rate = 10000
+		data := make([]byte, rate*3/2)
+		start := time.Now()
+		got, err := io.ReadAll(NewRateLimitedReader(context.Background(), bytes.NewReader(data), rate))
+		require.NoError(err)
+		assert.Equal(data, got)
+		// The first second's worth of bytes is read at once and the remaining
+		// half is read at the rate.
+		elapsed := time.Since(start)
+		assert.GreaterOrEqual(int64(elapsed), int64(400*time.Millisecond))
+		assert.Less(int64(elapsed), int64(2*time.Second))
+	})
+	t.Run("canceled", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		r := NewRateLimitedReader(ctx, bytes.NewReader(make([]byte, 10)), 1)
+		p := make([]byte, 10)
+		n, err := r.Read(p)
+		require.NoError(err)
+		assert.Equal(1, n)
+		cancel()
+		n, err = r.Read(p)
+		assert.Equal(1, n)
+		assert.ErrorIs(err, context.Canceled)
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unlimited", func(t *testing.T) {
+		r := bytes.NewReader([]byte("hello"))
+		assert.Equal(t, r, NewRateLimiter(0).Reader(context.Background(), r))
+	})
+	t.Run("shared", func(t *testing.T) {
+		assert := assert.New(t)
+		const rate = 10000
+		l := NewRateLimiter(rate)
+		// Each reader reads a second's worth of bytes, which would be read at
+		// once with their own limiter, but they share the limiter so the
+		// second second's worth of bytes is read at the rate.
+		start := time.Now()
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got, err := io.ReadAll(l.Reader(context.Background(), bytes.NewReader(make([]byte, rate))))
+				assert.NoError(err)
+				assert.Len(got, rate)
+			}()
+		}
+		wg.Wait()
+		elapsed := time.Since(start)
+		assert.GreaterOrEqual(int64(elapsed), int64(900*time.Millisecond))
+		assert.Less(int64(elapsed), int64(3*time.Second))
+	})
+}
@@ -0,0 +1,315 @@
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	"golang.org/x/crypto/ssh"
+	"nhooyr.io/websocket"
+)
+
+func init() {
+	err := proxy.RegisterHandler("ssh", handleProxy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy terminates the ssh session of the incoming websocket conn on the
+// worker and creates a second ssh session with the remote endpoint, which is
+// authenticated using the egress credentials of the session. Channels and
+// requests are then bridged between the two ssh sessions. handleProxy sets the
+// connectionId as connected in the repository once the session with the remote
+// endpoint has been established.
+//
+// handleProxy blocks until either ssh session is closed.
+//
+// The WithEgressCredentials option is required; at least one of the
+// credentials must be a credential.UserPassword, credential.KeyPair or
+// credential.SshCertificate. The WithEndpointHostKey option is also required;
+// the endpoint must present it as its host key or no session is established.
+//
+// The data sent in both directions over the channels is counted in the
+// connection's info. If WithUploadRateLimit or WithDownloadRateLimit is
+// provided, the data sent in that direction over all of the channels of the
+// connection combined is limited to the rate. All other options are ignored.
+func handleProxy(ctx context.Context, conf proxy.Config, opt ...proxy.Option) error {
+	opts := proxy.GetOpts(opt...)
+	sessionUrl, err := url.Parse(conf.RemoteEndpoint)
+	if err != nil {
+		return fmt.Errorf("error parsing endpoint information: %w", err)
+	}
+	if sessionUrl.Scheme != "ssh" {
+		return fmt.Errorf("invalid scheme for ssh proxy: %v", sessionUrl.Scheme)
+	}
+	user, auths, err := authMethods(opts.WithEgressCredentials)
+	if err != nil {
+		return err
+	}
+	if opts.WithEndpointHostKey == "" {
+		return errors.New("missing endpoint host key")
+	}
+	endpointHostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(opts.WithEndpointHostKey))
+	if err != nil {
+		return fmt.Errorf("error parsing endpoint host key: %w", err)
+	}
+
+	conf.SessionInfo.RLock()
+	hostKey := conf.SessionInfo.LookupSessionResponse.GetAuthorization().GetPrivateKey()
+	conf.SessionInfo.RUnlock()
+	if len(hostKey) != ed25519.PrivateKeySize {
+		return errors.New("missing session private key")
+	}
+	signer, err := ssh.NewSignerFromKey(ed25519.PrivateKey(hostKey))
+	if err != nil {
+		return fmt.Errorf("error creating host key signer: %w", err)
+	}
+
+	remoteConn, err := net.Dial("tcp", sessionUrl.Host)
+	if err != nil {
+		return fmt.Errorf("error dialing endpoint: %w", err)
+	}
+	defer remoteConn.Close()
+
+	// Only the host key configured on the target is accepted, so the endpoint
+	// is asked for a key of that type.
+	clientConfig := &ssh.ClientConfig{
+		User:              user,
+		Auth:              auths,
+		HostKeyCallback:   ssh.FixedHostKey(endpointHostKey),
+		HostKeyAlgorithms: []string{endpointHostKey.Type()},
+	}
+	remoteSshConn, remoteChans, remoteReqs, err := ssh.NewClientConn(remoteConn, sessionUrl.Host, clientConfig)
+	if err != nil {
+		return fmt.Errorf("error establishing ssh session with endpoint: %w", err)
+	}
+	defer remoteSshConn.Close()
+
+	endpointAddr := remoteConn.RemoteAddr().(*net.TCPAddr)
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:       conf.ConnectionId,
+		ClientTcpAddress:   conf.ClientAddress.IP.String(),
+		ClientTcpPort:      uint32(conf.ClientAddress.Port),
+		EndpointTcpAddress: endpointAddr.IP.String(),
+		EndpointTcpPort:    uint32(endpointAddr.Port),
+		Type:               "ssh",
+	}
+
+	connStatus, err := session.ConnectConnection(ctx, conf.SessionClient, connectionInfo)
+	if err != nil {
+		return fmt.Errorf("error marking connection as connected: %w", err)
+	}
+
+	// Update connection info to set connection status
+	conf.SessionInfo.Lock()
+	connInfo := conf.SessionInfo.ConnInfoMap[conf.ConnectionId]
+	connInfo.Status = connStatus
+	conf.SessionInfo.Unlock()
+
+	// The limiters are shared by every channel of the connection, so that
+	// opening more channels or using stderr doesn't raise the rate.
+	uploadLimiter := proxy.NewRateLimiter(opts.WithUploadRateLimit)
+	downloadLimiter := proxy.NewRateLimiter(opts.WithDownloadRateLimit)
+	fromClient := func(r io.Reader) io.Reader {
+		return uploadLimiter.Reader(ctx, proxy.NewCountingReader(r, &connInfo.BytesUp))
+	}
+	fromEndpoint := func(r io.Reader) io.Reader {
+		return downloadLimiter.Reader(ctx, proxy.NewCountingReader(r, &connInfo.BytesDown))
+	}
+
+	// The user has already been authorized for the session when the
+	// connection was authorized, so no further authentication is required.
+	serverConfig := &ssh.ServerConfig{
+		NoClientAuth: true,
+	}
+	serverConfig.AddHostKey(signer)
+
+	netConn := websocket.NetConn(ctx, conf.ClientConn, websocket.MessageBinary)
+	defer netConn.Close()
+	clientSshConn, clientChans, clientReqs, err := ssh.NewServerConn(netConn, serverConfig)
+	if err != nil {
+		return fmt.Errorf("error establishing ssh session with client: %w", err)
+	}
+	defer clientSshConn.Close()
+
+	connWg := new(sync.WaitGroup)
+	connWg.Add(4)
+	go func() {
+		defer connWg.Done()
+		forwardChannels(clientChans, remoteSshConn, fromClient, fromEndpoint)
+		_ = remoteSshConn.Close()
+	}()
+	go func() {
+		defer connWg.Done()
+		forwardChannels(remoteChans, clientSshConn, fromEndpoint, fromClient)
+		_ = clientSshConn.Close()
+	}()
+	go func() {
+		defer connWg.Done()
+		forwardRequests(clientReqs, remoteSshConn)
+	}()
+	go func() {
+		defer connWg.Done()
+		forwardRequests(remoteReqs, clientSshConn)
+	}()
+	connWg.Wait()
+	return nil
+}
+
+// authMethods returns the user and the ssh auth methods built from the egress
+// credentials. All credentials must be for the same user.
+func authMethods(creds []credential.Credential) (string, []ssh.AuthMethod, error) {
+	var user string
+	var auths []ssh.AuthMethod
+	setUser := func(u string) error {
+		switch {
+		case u == "":
+			return errors.New("egress credential is missing a username")
+		case user != "" && user != u:
+			return errors.New("egress credentials are for different users")
+		}
+		user = u
+		return nil
+	}
+	for _, c := range creds {
+		switch v := c.(type) {
+		case credential.SshCertificate:
+			if err := setUser(v.Username()); err != nil {
+				return "", nil, err
+			}
+			signer, err := ssh.ParsePrivateKey(v.Private())
+			if err != nil {
+				return "", nil, fmt.Errorf("error parsing private key: %w", err)
+			}
+			pub, _, _, _, err := ssh.ParseAuthorizedKey(v.Certificate())
+			if err != nil {
+				return "", nil, fmt.Errorf("error parsing certificate: %w", err)
+			}
+			cert, ok := pub.(*ssh.Certificate)
+			if !ok {
+				return "", nil, fmt.Errorf("egress credential certificate is a %s key, not a certificate", pub.Type())
+			}
+			certSigner, err := ssh.NewCertSigner(cert, signer)
+			if err != nil {
+				return "", nil, fmt.Errorf("error creating certificate signer: %w", err)
+			}
+			auths = append(auths, ssh.PublicKeys(certSigner))
+		case credential.KeyPair:
+			if err := setUser(v.Username()); err != nil {
+				return "", nil, err
+			}
+			signer, err := ssh.ParsePrivateKey(v.Private())
+			if err != nil {
+				return "", nil, fmt.Errorf("error parsing private key: %w", err)
+			}
+			auths = append(auths, ssh.PublicKeys(signer))
+		case credential.UserPassword:
+			if err := setUser(v.Username()); err != nil {
+				return "", nil, err
+			}
+			auths = append(auths, ssh.Password(string(v.Password())))
+		}
+	}
+	if len(auths) == 0 {
+		return "", nil, errors.New("no egress credentials usable for ssh authentication")
+	}
+	return user, auths, nil
+}
+
+// forwardChannels opens a channel on dst for every new channel received in
+// chans and copies the data and requests between the two. The data read from
+// a new channel is read through wrapSrc, and the data read from the channel
+// opened on dst through wrapDst.
+func forwardChannels(chans <-chan ssh.NewChannel, dst ssh.Conn, wrapSrc, wrapDst func(io.Reader) io.Reader) {
+	for newCh := range chans {
+		dstCh, dstReqs, err := dst.OpenChannel(newCh.ChannelType(), newCh.ExtraData())
+		if err != nil {
+			var openErr *ssh.OpenChannelError
+			if errors.As(err, &openErr) {
+				_ = newCh.Reject(openErr.Reason, openErr.Message)
+			} else {
+				_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+			}
+			continue
+		}
+		srcCh, srcReqs, err := newCh.Accept()
+		if err != nil {
+			_ = dstCh.Close()
+			continue
+		}
+		go bridgeChannel(srcCh, srcReqs, wrapSrc, dstCh, dstReqs, wrapDst)
+	}
+}
+
+// bridgeChannel copies the data, stderr and requests between a and b, reading
+// the data of a through wrapA and of b through wrapB. Each channel is closed
+// once the other side has closed its channel and all of the data and requests
+// from that side have been forwarded.
+func bridgeChannel(a ssh.Channel, aReqs <-chan *ssh.Request, wrapA func(io.Reader) io.Reader, b ssh.Channel, bReqs <-chan *ssh.Request, wrapB func(io.Reader) io.Reader) {
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		forwardHalf(b, a, aReqs, wrapA)
+	}()
+	go func() {
+		defer wg.Done()
+		forwardHalf(a, b, bReqs, wrapB)
+	}()
+	wg.Wait()
+}
+
+// forwardHalf copies the data, stderr and requests from src to dst, reading
+// the data and stderr of src through wrap. The requests channel of src is
+// closed when the peer closes src, at which point dst is closed after the
+// remaining data has been written to it.
+func forwardHalf(dst, src ssh.Channel, srcReqs <-chan *ssh.Request, wrap func(io.Reader) io.Reader) {
+	copyWg := new(sync.WaitGroup)
+	copyWg.Add(2)
+	go func() {
+		defer copyWg.Done()
+		_, _ = io.Copy(dst, wrap(src))
+		_ = dst.CloseWrite()
+	}()
+	go func() {
+		defer copyWg.Done()
+		_, _ = io.Copy(dst.Stderr(), wrap(src.Stderr()))
+	}()
+	forwardChannelRequests(srcReqs, dst)
+	copyWg.Wait()
+	_ = dst.Close()
+}
+
+func forwardChannelRequests(reqs <-chan *ssh.Request, dst ssh.Channel) {
+	for req := range reqs {
+		ok, err := dst.SendRequest(req.Type, req.WantReply, req.Payload)
+		if err != nil {
+			ok = false
+		}
+		if req.WantReply {
+			_ = req.Reply(ok, nil)
+		}
+	}
+}
+
+func forwardRequests(reqs <-chan *ssh.Request, dst ssh.Conn) {
+	for req := range reqs {
+		ok, payload, err := dst.SendRequest(req.Type, req.WantReply, req.Payload)
+		if err != nil {
+			ok = false
+		}
+		if req.WantReply {
+			_ = req.Reply(ok, payload)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/hashicorp/boundary/sdk/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"nhooyr.io/websocket"
+)
+
+type testUserPassword struct {
+	credential.UserPassword
+	username, password string
+}
+
+func (c testUserPassword) Username() string              { return c.username }
+func (c testUserPassword) Password() credential.Password { return credential.Password(c.password) }
+
+type testSshCertificate struct {
+	credential.SshCertificate
+	username         string
+	privateKey, cert []byte
+}
+
+func (c testSshCertificate) Username() string               { return c.username }
+func (c testSshCertificate) Private() credential.PrivateKey { return c.privateKey }
+func (c testSshCertificate) Certificate() []byte            { return c.cert }
+
+// testEndpoint starts an ssh server which accepts the username and password
+// and replies to every exec request with the command that was executed, or
+// with n zero bytes for a "bytes:<n>" command. It returns the port the server
+// listens on and its host key in the authorized_keys format.
+func testEndpoint(t *testing.T, ctx context.Context, username, password string) (int, string) {
+	t.Helper()
+	require := require.New(t)
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(err)
+	conf := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == username && string(pass) == password {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("password rejected for %q", c.User())
+		},
+	}
+	conf.AddHostKey(signer)
+
+	port := testutil.TestFreePort(t)
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	require.NoError(err)
+	go func() {
+		<-ctx.Done()
+		_ = l.Close()
+	}()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_, chans, reqs, err := ssh.NewServerConn(c, conf)
+			if err != nil {
+				continue
+			}
+			go ssh.DiscardRequests(reqs)
+			go func() {
+				for newCh := range chans {
+					ch, chReqs, err := newCh.Accept()
+					if err != nil {
+						continue
+					}
+					go func() {
+						defer ch.Close()
+						for req := range chReqs {
+							if req.Type != "exec" {
+								_ = req.Reply(false, nil)
+								continue
+							}
+							_ = req.Reply(true, nil)
+							cmd := string(req.Payload[4:])
+							var n int
+							if _, err := fmt.Sscanf(cmd, "bytes:%d", &n); err == nil {
+								_, _ = ch.Write(make([]byte, n))
+							} else {
+								_, _ = ch.Write([]byte("ran: " + cmd))
+							}
+							status := make([]byte, 4)
+							binary.BigEndian.PutUint32(status, 0)
+							_, _ = ch.SendRequest("exit-status", false, status)
+							return
+						}
+					}()
+				}
+			}()
+		}
+	}()
+	return port, string(ssh.MarshalAuthorizedKey(signer.PublicKey()))
+}
+
+// testConfig returns the proxy config for the ssh endpoint listening on port,
+// the client side of its websocket conn and the public key of the session.
+func testConfig(t *testing.T, ctx context.Context, port int) (proxy.Config, *websocket.Conn, ed25519.PublicKey) {
+	t.Helper()
+	require := require.New(t)
+
+	clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+	require.NotNil(clientConn)
+	require.NotNil(proxyConn)
+
+	sessPub, sessKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	si := &session.Info{
+		Id: "one",
+		LookupSessionResponse: &pbs.LookupSessionResponse{
+			Authorization: &targets.SessionAuthorizationData{
+				SessionId:  "mock-session",
+				PrivateKey: sessKey,
+			},
+		},
+		ConnInfoMap: map[string]*session.ConnInfo{
+			"mock-connection": {},
+		},
+	}
+	conf := proxy.Config{
+		ClientAddress: &net.TCPAddr{
+			IP:   net.ParseIP("127.0.0.1"),
+			Port: 50000,
+		},
+		ClientConn:     proxyConn,
+		RemoteEndpoint: fmt.Sprintf("ssh://localhost:%d", port),
+		SessionClient:  pbs.NewMockSessionServiceClient(),
+		SessionInfo:    si,
+		ConnectionId:   "mock-connection",
+	}
+	return conf, clientConn, sessPub
+}
+
+// testProxy runs handleProxy for the ssh endpoint listening on port with the
+// options, and returns the client connected to it, the session info and the
+// channel receiving the error returned by handleProxy.
+func testProxy(t *testing.T, ctx context.Context, port int, opt ...proxy.Option) (*ssh.Client, *session.Info, <-chan error) {
+	t.Helper()
+	require := require.New(t)
+
+	conf, clientConn, sessPub := testConfig(t, ctx, port)
+	opt = append(opt, proxy.WithEgressCredentials([]credential.Credential{
+		testUserPassword{username: "alice", password: "secret"},
+	}))
+	proxyErr := make(chan error, 1)
+	go func() {
+		proxyErr <- handleProxy(ctx, conf, opt...)
+	}()
+
+	// The client does not provide any credentials and must be presented
+	// with the session's host key.
+	wantHostKey, err := ssh.NewPublicKey(sessPub)
+	require.NoError(err)
+	netConn := websocket.NetConn(ctx, clientConn, websocket.MessageBinary)
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, "mock-session", &ssh.ClientConfig{
+		User:            "anyone",
+		HostKeyCallback: ssh.FixedHostKey(wantHostKey),
+	})
+	require.NoError(err)
+	return ssh.NewClient(sshConn, chans, reqs), conf.SessionInfo, proxyErr
+}
+
+func TestHandleProxy(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	port, hostKey := testEndpoint(t, ctx, "alice", "secret")
+	client, si, proxyErr := testProxy(t, ctx, port, proxy.WithEndpointHostKey(hostKey))
+
+	sess, err := client.NewSession()
+	require.NoError(err)
+	out, err := sess.Output("whoami")
+	require.NoError(err)
+	assert.Equal("ran: whoami", string(out))
+	assert.Equal(pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED, si.ConnInfoMap["mock-connection"].Status)
+	// The command is sent in a request, so only its output is channel data.
+	assert.Equal(uint64(0), si.ConnInfoMap["mock-connection"].BytesUp.Load())
+	assert.Equal(uint64(len("ran: whoami")), si.ConnInfoMap["mock-connection"].BytesDown.Load())
+
+	require.NoError(client.Close())
+	require.NoError(<-proxyErr)
+}
+
+func TestHandleProxy_rateLimit(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	port, hostKey := testEndpoint(t, ctx, "alice", "secret")
+	const rate = 10000
+	client, si, proxyErr := testProxy(t, ctx, port, proxy.WithEndpointHostKey(hostKey), proxy.WithDownloadRateLimit(rate))
+
+	// Each channel downloads a second's worth of bytes, which would be
+	// forwarded at once if every channel had its own limit, but the channels
+	// share the limit of the connection.
+	start := time.Now()
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sess, err := client.NewSession()
+			if !assert.NoError(err) {
+				return
+			}
+			out, err := sess.Output(fmt.Sprintf("bytes:%d", rate))
+			assert.NoError(err)
+			assert.Len(out, rate)
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(int64(elapsed), int64(900*time.Millisecond))
+	assert.Equal(uint64(2*rate), si.ConnInfoMap["mock-connection"].BytesDown.Load())
+
+	require.NoError(client.Close())
+	require.NoError(<-proxyErr)
+}
+
+func TestHandleProxy_hostKey(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	port, _ := testEndpoint(t, ctx, "alice", "secret")
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ssh.NewPublicKey(otherPub)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		hostKey string
+		wantErr string
+	}{
+		{
+			name:    "missing",
+			wantErr: "missing endpoint host key",
+		},
+		{
+			name:    "invalid",
+			hostKey: "not a key",
+			wantErr: "error parsing endpoint host key",
+		},
+		{
+			name:    "mismatch",
+			hostKey: string(ssh.MarshalAuthorizedKey(otherKey)),
+			wantErr: "error establishing ssh session with endpoint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require, assert := require.New(t), assert.New(t)
+			conf, _, _ := testConfig(t, ctx, port)
+			err := handleProxy(ctx, conf,
+				proxy.WithEgressCredentials([]credential.Credential{testUserPassword{username: "alice", password: "secret"}}),
+				proxy.WithEndpointHostKey(tt.hostKey))
+			require.Error(err)
+			assert.Contains(err.Error(), tt.wantErr)
+			assert.Equal(pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_UNSPECIFIED, conf.SessionInfo.ConnInfoMap["mock-connection"].Status)
+		})
+	}
+}
+
+func TestAuthMethods(t *testing.T) {
+	t.Parallel()
+	_, _, err := authMethods(nil)
+	assert.Error(t, err)
+
+	_, _, err = authMethods([]credential.Credential{testUserPassword{password: "secret"}})
+	assert.Error(t, err)
+
+	_, _, err = authMethods([]credential.Credential{
+		testUserPassword{username: "alice", password: "secret"},
+		testUserPassword{username: "bob", password: "secret"},
+	})
+	assert.Error(t, err)
+
+	user, auths, err := authMethods([]credential.Credential{testUserPassword{username: "alice", password: "secret"}})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user)
+	assert.Len(t, auths, 1)
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ca, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+	cert := &ssh.Certificate{Key: sshPub, CertType: ssh.UserCert, ValidPrincipals: []string{"alice"}}
+	require.NoError(t, cert.SignCert(rand.Reader, ca))
+
+	user, auths, err = authMethods([]credential.Credential{testSshCertificate{
+		username:   "alice",
+		privateKey: privateKey,
+		cert:       ssh.MarshalAuthorizedKey(cert),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user)
+	assert.Len(t, auths, 1)
+
+	_, _, err = authMethods([]credential.Credential{testSshCertificate{
+		username:   "alice",
+		privateKey: privateKey,
+		cert:       ssh.MarshalAuthorizedKey(sshPub),
+	}})
+	assert.Error(t, err)
+}
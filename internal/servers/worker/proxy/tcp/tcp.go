@@ -10,6 +10,7 @@ import (
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
 	"github.com/hashicorp/boundary/internal/servers/worker/session"
 	"nhooyr.io/websocket"
 )
@@ -28,8 +29,13 @@ func init() {
 // handleProxy blocks until an error (EOF on happy path) is received on either
 // connection.
 //
-// All options are ignored.
-func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) error {
+// The bytes sent in both directions are counted in the connection's info. If
+// WithRecorder is provided, the data sent in both directions is recorded,
+// and a failure to record it ends the connection. If WithUploadRateLimit or
+// WithDownloadRateLimit is provided, the data sent in that direction is
+// limited to the rate. All other options are ignored.
+func handleProxy(ctx context.Context, conf proxy.Config, opt ...proxy.Option) error {
+	opts := proxy.GetOpts(opt...)
 	conn := conf.ClientConn
 	sessionUrl, err := url.Parse(conf.RemoteEndpoint)
 	if err != nil {
@@ -62,23 +68,32 @@ func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) erro
 
 	// Update connection info to set connection status
 	conf.SessionInfo.Lock()
-	conf.SessionInfo.ConnInfoMap[conf.ConnectionId].Status = connStatus
+	connInfo := conf.SessionInfo.ConnInfoMap[conf.ConnectionId]
+	connInfo.Status = connStatus
 	conf.SessionInfo.Unlock()
 
 	// Get a wrapped net.Conn so we can use io.Copy
 	netConn := websocket.NetConn(ctx, conn, websocket.MessageBinary)
 
+	var fromClient, fromEndpoint io.Reader = netConn, tcpRemoteConn
+	if opts.WithRecorder != nil {
+		fromClient = io.TeeReader(netConn, opts.WithRecorder.Writer(recording.ClientToEndpoint))
+		fromEndpoint = io.TeeReader(tcpRemoteConn, opts.WithRecorder.Writer(recording.EndpointToClient))
+	}
+	fromClient = proxy.NewRateLimitedReader(ctx, proxy.NewCountingReader(fromClient, &connInfo.BytesUp), opts.WithUploadRateLimit)
+	fromEndpoint = proxy.NewRateLimitedReader(ctx, proxy.NewCountingReader(fromEndpoint, &connInfo.BytesDown), opts.WithDownloadRateLimit)
+
 	connWg := new(sync.WaitGroup)
 	connWg.Add(2)
 	go func() {
 		defer connWg.Done()
-		_, _ = io.Copy(netConn, tcpRemoteConn)
+		_, _ = io.Copy(netConn, fromEndpoint)
 		_ = netConn.Close()
 		_ = tcpRemoteConn.Close()
 	}()
 	go func() {
 		defer connWg.Done()
-		_, _ = io.Copy(tcpRemoteConn, netConn)
+		_, _ = io.Copy(tcpRemoteConn, fromClient)
 		_ = tcpRemoteConn.Close()
 		_ = netConn.Close()
 	}()
@@ -2,12 +2,18 @@ package tcp
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"fmt"
+	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
 	"github.com/hashicorp/boundary/internal/servers/worker/session"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/boundary/sdk/testutil"
@@ -101,3 +107,111 @@ func TestHandleTcpProxyV1(t *testing.T) {
 
 	cancelCtx()
 }
+
+func TestHandleTcpProxyV1_Recording(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+	require.NotNil(clientConn)
+	require.NotNil(proxyConn)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer l.Close()
+
+	endpointConns := make(chan net.Conn)
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			close(endpointConns)
+			return
+		}
+		endpointConns <- c
+	}()
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	recorder, err := recording.NewRecorder(t.TempDir(), "mock-session", "mock-connection", key)
+	require.NoError(err)
+
+	conf := proxy.Config{
+		ClientAddress:  &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 50000},
+		ClientConn:     proxyConn,
+		RemoteEndpoint: fmt.Sprintf("tcp://%s", l.Addr().String()),
+		SessionClient:  pbs.NewMockSessionServiceClient(),
+		SessionInfo: &session.Info{
+			Id: "one",
+			LookupSessionResponse: &pbs.LookupSessionResponse{
+				Authorization: &targets.SessionAuthorizationData{
+					SessionId: "mock-session",
+				},
+				RecordingEnabled: true,
+			},
+			ConnInfoMap: map[string]*session.ConnInfo{
+				"mock-connection": {},
+			},
+		},
+		ConnectionId: "mock-connection",
+	}
+
+	proxyErr := make(chan error)
+	go func() {
+		proxyErr <- handleProxy(ctx, conf, proxy.WithRecorder(recorder))
+	}()
+
+	endpointConn, ok := <-endpointConns
+	require.True(ok)
+	netConn := websocket.NetConn(ctx, clientConn, websocket.MessageBinary)
+
+	_, err = netConn.Write([]byte("client write to endpoint via proxy"))
+	require.NoError(err)
+	b := make([]byte, len("client write to endpoint via proxy"))
+	_, err = io.ReadFull(endpointConn, b)
+	require.NoError(err)
+
+	_, err = endpointConn.Write([]byte("endpoint write to client via proxy"))
+	require.NoError(err)
+	b = make([]byte, len("endpoint write to client via proxy"))
+	_, err = io.ReadFull(netConn, b)
+	require.NoError(err)
+
+	// Closing the endpoint connection ends the proxy. The client reads until
+	// the proxy closes its connection so the close handshake completes.
+	go func() { _, _ = io.Copy(io.Discard, netConn) }()
+	require.NoError(endpointConn.Close())
+	require.NoError(<-proxyErr)
+	require.NoError(recorder.Close())
+
+	m, err := recording.Verify(recorder.Dir(), pub)
+	require.NoError(err)
+	assert.Equal(int64(len("client write to endpoint via proxy")), m.ClientToEndpointBytes)
+	assert.Equal(int64(len("endpoint write to client via proxy")), m.EndpointToClientBytes)
+	require.Len(m.Chunks, 1)
+
+	connInfo := conf.SessionInfo.ConnInfoMap["mock-connection"]
+	assert.Equal(uint64(len("client write to endpoint via proxy")), connInfo.BytesUp.Load())
+	assert.Equal(uint64(len("endpoint write to client via proxy")), connInfo.BytesDown.Load())
+
+	f, err := os.Open(filepath.Join(recorder.Dir(), m.Chunks[0].Name))
+	require.NoError(err)
+	defer f.Close()
+	fr, err := recording.NewFrameReader(f)
+	require.NoError(err)
+	var frames []*recording.Frame
+	for {
+		frame, err := fr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		frames = append(frames, frame)
+	}
+	require.Len(frames, 2)
+	assert.Equal(recording.ClientToEndpoint, frames[0].Direction)
+	assert.Equal("client write to endpoint via proxy", string(frames[0].Data))
+	assert.Equal(recording.EndpointToClient, frames[1].Direction)
+	assert.Equal("endpoint write to client via proxy", string(frames[1].Data))
+}
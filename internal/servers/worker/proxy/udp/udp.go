@@ -0,0 +1,169 @@
+package udp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	"nhooyr.io/websocket"
+)
+
+// IdleTimeout is how long a flow of datagrams may go without a datagram in
+// either direction before the proxy closes it.
+const IdleTimeout = 60 * time.Second
+
+// maxDatagramSize is the largest payload of a UDP datagram.
+const maxDatagramSize = 65535
+
+func init() {
+	err := proxy.RegisterHandler("udp", handleProxy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy creates a udp proxy between the incoming websocket conn and the
+// udp socket it creates with the remote endpoint. Each binary websocket
+// message is one datagram. handleProxy sets the connectionId as connected in
+// the repository.
+//
+// The websocket connection is one flow of datagrams from a single client
+// address, and so one connection of the session. handleProxy blocks until
+// either side returns an error or no datagram has been proxied in either
+// direction for the idle timeout, at which point the flow is closed.
+//
+// The bytes sent in both directions are counted in the connection's info.
+// Options are ignored.
+func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) error {
+	return handleProxyWithIdleTimeout(ctx, conf, IdleTimeout)
+}
+
+func handleProxyWithIdleTimeout(ctx context.Context, conf proxy.Config, idleTimeout time.Duration) error {
+	conn := conf.ClientConn
+	sessionUrl, err := url.Parse(conf.RemoteEndpoint)
+	if err != nil {
+		return fmt.Errorf("error parsing endpoint information: %w", err)
+	}
+	if sessionUrl.Scheme != "udp" {
+		return fmt.Errorf("invalid scheme for udp proxy: %v", sessionUrl.Scheme)
+	}
+	remoteConn, err := net.Dial("udp", sessionUrl.Host)
+	if err != nil {
+		return fmt.Errorf("error dialing endpoint: %w", err)
+	}
+	udpRemoteConn := remoteConn.(*net.UDPConn)
+
+	endpointAddr := udpRemoteConn.RemoteAddr().(*net.UDPAddr)
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:       conf.ConnectionId,
+		ClientTcpAddress:   conf.ClientAddress.IP.String(),
+		ClientTcpPort:      uint32(conf.ClientAddress.Port),
+		EndpointTcpAddress: endpointAddr.IP.String(),
+		EndpointTcpPort:    uint32(endpointAddr.Port),
+		Type:               "udp",
+	}
+
+	connStatus, err := session.ConnectConnection(ctx, conf.SessionClient, connectionInfo)
+	if err != nil {
+		_ = udpRemoteConn.Close()
+		return fmt.Errorf("error marking connection as connected: %w", err)
+	}
+
+	// Update connection info to set connection status
+	conf.SessionInfo.Lock()
+	connInfo := conf.SessionInfo.ConnInfoMap[conf.ConnectionId]
+	connInfo.Status = connStatus
+	conf.SessionInfo.Unlock()
+
+	// Each message is exactly one datagram, which can be larger than the
+	// default read limit of the websocket.
+	conn.SetReadLimit(maxDatagramSize)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// activity receives a value for every datagram proxied so the idle timer
+	// can be reset.
+	activity := make(chan struct{}, 1)
+	active := func() {
+		select {
+		case activity <- struct{}{}:
+		default:
+		}
+	}
+
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		defer cancel()
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, err := udpRemoteConn.Read(buf)
+			if err != nil {
+				// The endpoint refusing a datagram is reported on the next
+				// read of a connected udp socket; the flow stays open.
+				if ctx.Err() == nil && errors.Is(err, syscall.ECONNREFUSED) {
+					continue
+				}
+				return
+			}
+			if err := conn.Write(ctx, websocket.MessageBinary, buf[:n]); err != nil {
+				return
+			}
+			connInfo.BytesDown.Add(uint64(n))
+			active()
+		}
+	}()
+	go func() {
+		defer connWg.Done()
+		defer cancel()
+		for {
+			typ, b, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			if typ != websocket.MessageBinary {
+				continue
+			}
+			if _, err := udpRemoteConn.Write(b); err != nil && !errors.Is(err, syscall.ECONNREFUSED) {
+				return
+			}
+			connInfo.BytesUp.Add(uint64(len(b)))
+			active()
+		}
+	}()
+
+	idle := time.NewTimer(idleTimeout)
+	defer idle.Stop()
+	closeReason := "proxy closed"
+	for done := false; !done; {
+		select {
+		case <-activity:
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(idleTimeout)
+		case <-idle.C:
+			closeReason = "idle timeout"
+			done = true
+		case <-ctx.Done():
+			done = true
+		}
+	}
+	// The websocket is closed first since canceling a read of it closes it
+	// without the reason.
+	_ = conn.Close(websocket.StatusNormalClosure, closeReason)
+	_ = udpRemoteConn.Close()
+	cancel()
+	connWg.Wait()
+	return nil
+}
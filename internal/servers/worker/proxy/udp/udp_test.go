@@ -0,0 +1,193 @@
+package udp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+func testConfig(t *testing.T, proxyConn *websocket.Conn, endpoint string) proxy.Config {
+	t.Helper()
+	return proxy.Config{
+		ClientAddress:  &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 50000},
+		ClientConn:     proxyConn,
+		RemoteEndpoint: fmt.Sprintf("udp://%s", endpoint),
+		SessionClient:  pbs.NewMockSessionServiceClient(),
+		SessionInfo: &session.Info{
+			Id: "one",
+			LookupSessionResponse: &pbs.LookupSessionResponse{
+				Authorization: &targets.SessionAuthorizationData{
+					SessionId: "mock-session",
+				},
+			},
+			ConnInfoMap: map[string]*session.ConnInfo{
+				"mock-connection": {},
+			},
+		},
+		ConnectionId: "mock-connection",
+	}
+}
+
+func TestHandleUdpProxy(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+	require.NotNil(clientConn)
+	require.NotNil(proxyConn)
+
+	endpoint, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	require.NoError(err)
+	defer endpoint.Close()
+
+	conf := testConfig(t, proxyConn, endpoint.LocalAddr().String())
+	proxyErr := make(chan error)
+	go func() {
+		proxyErr <- handleProxy(ctx, conf)
+	}()
+
+	// Each message from the client is one datagram to the endpoint.
+	require.NoError(clientConn.Write(ctx, websocket.MessageBinary, []byte("first datagram")))
+	require.NoError(clientConn.Write(ctx, websocket.MessageBinary, []byte("second")))
+
+	b := make([]byte, maxDatagramSize)
+	n, proxyAddr, err := endpoint.ReadFromUDP(b)
+	require.NoError(err)
+	assert.Equal("first datagram", string(b[:n]))
+	n, _, err = endpoint.ReadFromUDP(b)
+	require.NoError(err)
+	assert.Equal("second", string(b[:n]))
+
+	// Each datagram from the endpoint is one message to the client.
+	_, err = endpoint.WriteToUDP([]byte("reply"), proxyAddr)
+	require.NoError(err)
+	typ, got, err := clientConn.Read(ctx)
+	require.NoError(err)
+	assert.Equal(websocket.MessageBinary, typ)
+	assert.Equal("reply", string(got))
+
+	connInfo := conf.SessionInfo.ConnInfoMap["mock-connection"]
+	assert.Equal(uint64(len("first datagram")+len("second")), connInfo.BytesUp.Load())
+	assert.Equal(uint64(len("reply")), connInfo.BytesDown.Load())
+
+	// Closing the client connection ends the flow.
+	require.NoError(clientConn.Close(websocket.StatusNormalClosure, ""))
+	select {
+	case err := <-proxyErr:
+		require.NoError(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("proxy did not return after the client closed")
+	}
+}
+
+func TestHandleUdpProxy_LargeDatagram(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+	require.NotNil(clientConn)
+	require.NotNil(proxyConn)
+	// Like the client of boundary connect, the test client reads messages
+	// as large as a datagram.
+	clientConn.SetReadLimit(maxDatagramSize)
+
+	endpoint, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	require.NoError(err)
+	defer endpoint.Close()
+
+	conf := testConfig(t, proxyConn, endpoint.LocalAddr().String())
+	proxyErr := make(chan error)
+	go func() {
+		proxyErr <- handleProxy(ctx, conf)
+	}()
+
+	// The datagrams are larger than the default websocket read limit of
+	// 32 KiB.
+	up := make([]byte, 60000)
+	for i := range up {
+		up[i] = byte(i)
+	}
+	require.NoError(clientConn.Write(ctx, websocket.MessageBinary, up))
+	require.NoError(endpoint.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	b := make([]byte, maxDatagramSize)
+	n, proxyAddr, err := endpoint.ReadFromUDP(b)
+	require.NoError(err)
+	assert.Equal(up, b[:n])
+
+	down := make([]byte, 60000)
+	for i := range down {
+		down[i] = byte(i % 7)
+	}
+	_, err = endpoint.WriteToUDP(down, proxyAddr)
+	require.NoError(err)
+	_, got, err := clientConn.Read(ctx)
+	require.NoError(err)
+	assert.Equal(down, got)
+
+	connInfo := conf.SessionInfo.ConnInfoMap["mock-connection"]
+	assert.Equal(uint64(len(up)), connInfo.BytesUp.Load())
+	assert.Equal(uint64(len(down)), connInfo.BytesDown.Load())
+
+	require.NoError(clientConn.Close(websocket.StatusNormalClosure, ""))
+	select {
+	case err := <-proxyErr:
+		require.NoError(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("proxy did not return after the client closed")
+	}
+}
+
+func TestHandleUdpProxy_IdleTimeout(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+	require.NotNil(clientConn)
+	require.NotNil(proxyConn)
+
+	endpoint, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	require.NoError(err)
+	defer endpoint.Close()
+
+	conf := testConfig(t, proxyConn, endpoint.LocalAddr().String())
+	proxyErr := make(chan error)
+	go func() {
+		proxyErr <- handleProxyWithIdleTimeout(ctx, conf, 500*time.Millisecond)
+	}()
+
+	// Datagrams reset the idle timer.
+	for i := 0; i < 3; i++ {
+		require.NoError(clientConn.Write(ctx, websocket.MessageBinary, []byte("ping")))
+		time.Sleep(300 * time.Millisecond)
+	}
+	select {
+	case err := <-proxyErr:
+		t.Fatalf("proxy returned while the flow was active: %v", err)
+	default:
+	}
+
+	// The proxy closes the idle flow and the client sees the reason.
+	_, _, err = clientConn.Read(ctx)
+	require.Error(err)
+	assert.Equal(websocket.StatusNormalClosure, websocket.CloseStatus(err))
+	var closeErr websocket.CloseError
+	require.ErrorAs(err, &closeErr)
+	assert.Equal("idle timeout", closeErr.Reason)
+	require.NoError(<-proxyErr)
+}
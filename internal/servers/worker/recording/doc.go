@@ -0,0 +1,22 @@
+// Package recording contains the recorder used by the worker to record the
+// data proxied for a connection of a session which has recording enabled.
+//
+// The recording of a connection is stored in its own directory, within the
+// directory of its session, and is made of a sequence of chunk files and a
+// manifest. Each chunk starts with a header made of the chunk magic and the
+// format version, followed by frames. Integers are big endian, and a frame is
+// made of:
+//
+//	time       8 bytes, unix nanoseconds at which the data was read
+//	direction  1 byte, the Direction the data was sent in
+//	length     4 bytes, the length of the data
+//	data       length bytes
+//
+// The manifest lists the chunks, along with their size and SHA-256 digest, and
+// is signed with the worker's recording signing key. Unlike the session's
+// private key, which the client of the session also receives, the signing key
+// never leaves the worker, and it is stored outside of the recording storage
+// path. The signature is stored next to the manifest and is verified using the
+// public key the worker reports to the controllers in its status, which is
+// exposed on the worker resource.
+package recording
@@ -0,0 +1,137 @@
+package recording
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SigningKeyFileName is the name of the file, in the auth storage path of the
+// worker, containing the PKCS #8 PEM encoded ed25519 key the worker signs the
+// manifests of its recordings with, unless another file is configured.
+const SigningKeyFileName = "recording_key"
+
+// LoadOrCreateSigningKey returns the signing key stored in keyFile. If keyFile
+// does not exist, a new key is generated and stored in it. The key is held by
+// the worker only, unlike the session's private key, which is also given to
+// the client of the session.
+//
+// keyFile must not be within storagePath, the directory the recordings are
+// stored in: the recordings are verified with the public key the worker
+// registers with the controllers, which must not be replaceable by whoever can
+// change the recordings.
+func LoadOrCreateSigningKey(keyFile, storagePath string) (ed25519.PrivateKey, error) {
+	if keyFile == "" {
+		return nil, errors.New("missing recording signing key file")
+	}
+	if storagePath != "" {
+		within, err := isWithin(keyFile, storagePath)
+		if err != nil {
+			return nil, err
+		}
+		if within {
+			return nil, errors.New("recording signing key file must not be within the recording storage path")
+		}
+	}
+	b, err := ioutil.ReadFile(keyFile)
+	switch {
+	case err == nil:
+		return parseSigningKey(b)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("error reading recording signing key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(keyFile), 0o700); err != nil {
+		return nil, fmt.Errorf("error creating recording signing key directory: %w", err)
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating recording signing key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling recording signing key: %w", err)
+	}
+	// The key is only written if it does not exist yet, so a key which has
+	// already signed recordings is never replaced.
+	f, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error creating recording signing key file: %w", err)
+	}
+	if _, err := f.Write(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("error writing recording signing key: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("error writing recording signing key: %w", err)
+	}
+	return key, nil
+}
+
+// MarshalPublicKey returns the PKIX PEM encoding of the public key of the
+// signing key, which the worker registers with the controllers.
+func MarshalPublicKey(key ed25519.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling recording public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// ParsePublicKey parses the PKIX PEM encoded public key registered by a worker
+// with the controllers, which verifies the recordings of the worker.
+func ParsePublicKey(b []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("recording public key is not a PEM encoded public key")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing recording public key: %w", err)
+	}
+	edPub, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("recording public key is not an ed25519 key")
+	}
+	return edPub, nil
+}
+
+func parseSigningKey(b []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("recording signing key is not a PEM encoded private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing recording signing key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("recording signing key is not an ed25519 key")
+	}
+	return edKey, nil
+}
+
+// isWithin reports whether path is dir or is within dir.
+func isWithin(path, dir string) (bool, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false, fmt.Errorf("error resolving recording signing key file: %w", err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false, fmt.Errorf("error resolving recording storage path: %w", err)
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil {
+		return false, nil
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))), nil
+}
@@ -0,0 +1,55 @@
+package recording
+
+import (
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreateSigningKey(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir := t.TempDir()
+	storagePath := filepath.Join(dir, "recordings")
+	keyFile := filepath.Join(dir, "auth", SigningKeyFileName)
+
+	key, err := LoadOrCreateSigningKey(keyFile, storagePath)
+	require.NoError(err)
+	require.Len(key, ed25519.PrivateKeySize)
+
+	info, err := os.Stat(keyFile)
+	require.NoError(err)
+	assert.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	pem, err := MarshalPublicKey(key)
+	require.NoError(err)
+	pub, err := ParsePublicKey(pem)
+	require.NoError(err)
+	assert.Equal(key.Public(), pub)
+
+	// The stored key is loaded instead of generating a new one.
+	again, err := LoadOrCreateSigningKey(keyFile, storagePath)
+	require.NoError(err)
+	assert.Equal(key, again)
+
+	require.NoError(os.WriteFile(keyFile, []byte("not a key"), 0o600))
+	_, err = LoadOrCreateSigningKey(keyFile, storagePath)
+	assert.Error(err)
+
+	_, err = LoadOrCreateSigningKey("", storagePath)
+	assert.Error(err)
+
+	// The key must not be stored with the recordings it signs.
+	_, err = LoadOrCreateSigningKey(filepath.Join(storagePath, SigningKeyFileName), storagePath)
+	assert.Error(err)
+	_, err = LoadOrCreateSigningKey(filepath.Join(storagePath, "keys", "..", SigningKeyFileName), storagePath)
+	assert.Error(err)
+	_, err = os.Stat(filepath.Join(storagePath, SigningKeyFileName))
+	assert.True(os.IsNotExist(err))
+
+	_, err = ParsePublicKey([]byte("not a key"))
+	assert.Error(err)
+}
@@ -0,0 +1,39 @@
+package recording
+
+// DefaultMaxChunkSize is the size, in bytes, after which a chunk is closed and
+// a new chunk is started.
+const DefaultMaxChunkSize = 64 * 1024 * 1024
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// options = how options are represented
+type options struct {
+	withMaxChunkSize int64
+}
+
+func getDefaultOptions() options {
+	return options{
+		withMaxChunkSize: DefaultMaxChunkSize,
+	}
+}
+
+// WithMaxChunkSize provides an optional max size, in bytes, of the chunks of a
+// recording. A frame is never split across chunks, so a chunk holding a single
+// frame may exceed it.
+func WithMaxChunkSize(size int64) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.withMaxChunkSize = size
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Frame is data recorded for a connection.
+type Frame struct {
+	Time      time.Time
+	Direction Direction
+	Data      []byte
+}
+
+// FrameReader reads the frames of a chunk.
+type FrameReader struct {
+	r *bufio.Reader
+}
+
+// NewFrameReader reads and validates the header of the chunk and returns a
+// reader for its frames.
+func NewFrameReader(r io.Reader) (*FrameReader, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(chunkMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("error reading chunk header: %w", err)
+	}
+	if !bytes.Equal(header[:len(chunkMagic)], chunkMagic) {
+		return nil, errors.New("not a recording chunk")
+	}
+	if v := header[len(chunkMagic)]; v != chunkVersion {
+		return nil, fmt.Errorf("unsupported chunk version %d", v)
+	}
+	return &FrameReader{r: br}, nil
+}
+
+// Next returns the next frame of the chunk, or io.EOF when there are no more
+// frames.
+func (fr *FrameReader) Next() (*Frame, error) {
+	header := make([]byte, frameHeaderSize)
+	if _, err := io.ReadFull(fr.r, header); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("error reading frame header: %w", err)
+	}
+	data := make([]byte, binary.BigEndian.Uint32(header[9:13]))
+	if _, err := io.ReadFull(fr.r, data); err != nil {
+		return nil, fmt.Errorf("error reading frame data: %w", err)
+	}
+	return &Frame{
+		Time:      time.Unix(0, int64(binary.BigEndian.Uint64(header[0:8]))).UTC(),
+		Direction: Direction(header[8]),
+		Data:      data,
+	}, nil
+}
+
+// Verify verifies the signature of the manifest of the recording in dir using
+// publicKey, and that the chunks of the recording match the manifest. The
+// publicKey must be the recording public key the worker which recorded the
+// connection registered with the controllers, never a key read from the
+// recording storage path. The verified manifest is returned.
+func Verify(dir string, publicKey ed25519.PublicKey) (*Manifest, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid public key")
+	}
+	m, err := ioutil.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("error reading recording manifest: %w", err)
+	}
+	sig, err := ioutil.ReadFile(filepath.Join(dir, SignatureFileName))
+	if err != nil {
+		return nil, fmt.Errorf("error reading recording manifest signature: %w", err)
+	}
+	if !ed25519.Verify(publicKey, m, sig) {
+		return nil, errors.New("invalid recording manifest signature")
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(m, manifest); err != nil {
+		return nil, fmt.Errorf("error decoding recording manifest: %w", err)
+	}
+	for _, c := range manifest.Chunks {
+		if err := verifyChunk(filepath.Join(dir, c.Name), c); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+func verifyChunk(path string, c *Chunk) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening recording chunk: %w", err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return fmt.Errorf("error reading recording chunk: %w", err)
+	}
+	if n != c.Size || hex.EncodeToString(h.Sum(nil)) != c.Sha256 {
+		return fmt.Errorf("recording chunk %q does not match the manifest", c.Name)
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package recording
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// ManifestFileName is the name of the manifest file of a recording.
+	ManifestFileName = "manifest.json"
+
+	// SignatureFileName is the name of the file containing the ed25519
+	// signature of the manifest.
+	SignatureFileName = ManifestFileName + ".sig"
+
+	// ManifestVersion is the version of the manifest format.
+	ManifestVersion = 1
+
+	// chunkVersion is the version of the chunk format.
+	chunkVersion byte = 1
+
+	// frameHeaderSize is the size of the timestamp, direction and length of
+	// a frame.
+	frameHeaderSize = 8 + 1 + 4
+)
+
+// chunkMagic starts every chunk file.
+var chunkMagic = []byte("BNDRYREC")
+
+// Direction is the direction data was sent in.
+type Direction byte
+
+const (
+	// ClientToEndpoint is data sent by the client to the endpoint.
+	ClientToEndpoint Direction = 1
+	// EndpointToClient is data sent by the endpoint to the client.
+	EndpointToClient Direction = 2
+)
+
+// String returns the name of the direction.
+func (d Direction) String() string {
+	switch d {
+	case ClientToEndpoint:
+		return "client-to-endpoint"
+	case EndpointToClient:
+		return "endpoint-to-client"
+	default:
+		return "unknown"
+	}
+}
+
+// Manifest describes the recording of a connection.
+type Manifest struct {
+	Version               int       `json:"version"`
+	SessionId             string    `json:"session_id"`
+	ConnectionId          string    `json:"connection_id"`
+	StartTime             time.Time `json:"start_time"`
+	EndTime               time.Time `json:"end_time"`
+	ClientToEndpointBytes int64     `json:"client_to_endpoint_bytes"`
+	EndpointToClientBytes int64     `json:"endpoint_to_client_bytes"`
+	Chunks                []*Chunk  `json:"chunks"`
+}
+
+// Chunk describes a chunk file of a recording.
+type Chunk struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	Sha256    string    `json:"sha256"`
+	Frames    int       `json:"frames"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// Recorder records the data sent in both directions of a connection. It is
+// safe for concurrent use.
+type Recorder struct {
+	dir          string
+	signingKey   ed25519.PrivateKey
+	maxChunkSize int64
+
+	l        sync.Mutex
+	manifest *Manifest
+	chunk    *Chunk
+	file     *os.File
+	hash     hash.Hash
+	closed   bool
+}
+
+// NewRecorder creates a recorder for the connection of the session. The
+// recording is stored in the connection's directory within sessionDir, which
+// is created if it doesn't exist. The manifest of the recording is signed
+// with signingKey when the recorder is closed.
+//
+// Supported options: WithMaxChunkSize
+func NewRecorder(sessionDir, sessionId, connectionId string, signingKey ed25519.PrivateKey, opt ...Option) (*Recorder, error) {
+	switch {
+	case sessionDir == "":
+		return nil, errors.New("missing session directory")
+	case sessionId == "":
+		return nil, errors.New("missing session id")
+	case connectionId == "":
+		return nil, errors.New("missing connection id")
+	case len(signingKey) != ed25519.PrivateKeySize:
+		return nil, errors.New("invalid signing key")
+	}
+	opts := getOpts(opt...)
+
+	dir := filepath.Join(sessionDir, connectionId)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating recording directory: %w", err)
+	}
+	return &Recorder{
+		dir:          dir,
+		signingKey:   signingKey,
+		maxChunkSize: opts.withMaxChunkSize,
+		manifest: &Manifest{
+			Version:      ManifestVersion,
+			SessionId:    sessionId,
+			ConnectionId: connectionId,
+			StartTime:    time.Now().UTC(),
+			Chunks:       []*Chunk{},
+		},
+	}, nil
+}
+
+// Dir returns the directory the recording is stored in.
+func (r *Recorder) Dir() string {
+	return r.dir
+}
+
+// Writer returns a writer which records the data written to it as sent in
+// the direction.
+func (r *Recorder) Writer(d Direction) io.Writer {
+	return &directionWriter{r: r, d: d}
+}
+
+type directionWriter struct {
+	r *Recorder
+	d Direction
+}
+
+// Write records p as a single frame.
+func (w *directionWriter) Write(p []byte) (int, error) {
+	if err := w.r.writeFrame(w.d, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (r *Recorder) writeFrame(d Direction, p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	r.l.Lock()
+	defer r.l.Unlock()
+	if r.closed {
+		return errors.New("recorder is closed")
+	}
+
+	frameSize := int64(frameHeaderSize + len(p))
+	if r.chunk != nil && r.chunk.Frames > 0 && r.chunk.Size+frameSize > r.maxChunkSize {
+		if err := r.closeChunkLocked(); err != nil {
+			return err
+		}
+	}
+	if r.chunk == nil {
+		if err := r.openChunkLocked(); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC()
+	header := make([]byte, frameHeaderSize)
+	binary.BigEndian.PutUint64(header[0:8], uint64(now.UnixNano()))
+	header[8] = byte(d)
+	binary.BigEndian.PutUint32(header[9:13], uint32(len(p)))
+	if err := r.writeLocked(header); err != nil {
+		return err
+	}
+	if err := r.writeLocked(p); err != nil {
+		return err
+	}
+
+	if r.chunk.Frames == 0 {
+		r.chunk.StartTime = now
+	}
+	r.chunk.EndTime = now
+	r.chunk.Frames++
+	switch d {
+	case ClientToEndpoint:
+		r.manifest.ClientToEndpointBytes += int64(len(p))
+	case EndpointToClient:
+		r.manifest.EndpointToClientBytes += int64(len(p))
+	}
+	return nil
+}
+
+// writeLocked writes b to the current chunk. The lock must be held by the
+// caller.
+func (r *Recorder) writeLocked(b []byte) error {
+	if _, err := r.file.Write(b); err != nil {
+		return fmt.Errorf("error writing recording chunk: %w", err)
+	}
+	r.hash.Write(b)
+	r.chunk.Size += int64(len(b))
+	return nil
+}
+
+// openChunkLocked creates the next chunk file and writes its header. The lock
+// must be held by the caller.
+func (r *Recorder) openChunkLocked() error {
+	name := fmt.Sprintf("chunk-%06d.rec", len(r.manifest.Chunks))
+	f, err := os.OpenFile(filepath.Join(r.dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("error creating recording chunk: %w", err)
+	}
+	r.file = f
+	r.hash = sha256.New()
+	r.chunk = &Chunk{Name: name}
+	return r.writeLocked(append(append([]byte{}, chunkMagic...), chunkVersion))
+}
+
+// closeChunkLocked closes the current chunk and adds it to the manifest. The
+// lock must be held by the caller.
+func (r *Recorder) closeChunkLocked() error {
+	if r.chunk == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.chunk.Sha256 = hex.EncodeToString(r.hash.Sum(nil))
+	r.manifest.Chunks = append(r.manifest.Chunks, r.chunk)
+	r.chunk, r.file, r.hash = nil, nil, nil
+	if err != nil {
+		return fmt.Errorf("error closing recording chunk: %w", err)
+	}
+	return nil
+}
+
+// Close closes the current chunk and writes the signed manifest of the
+// recording. Frames can't be recorded once the recorder is closed.
+func (r *Recorder) Close() error {
+	r.l.Lock()
+	defer r.l.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if err := r.closeChunkLocked(); err != nil {
+		return err
+	}
+	r.manifest.EndTime = time.Now().UTC()
+
+	m, err := json.MarshalIndent(r.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding recording manifest: %w", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(r.dir, ManifestFileName), m, 0o600); err != nil {
+		return fmt.Errorf("error writing recording manifest: %w", err)
+	}
+	sig := ed25519.Sign(r.signingKey, m)
+	if err := ioutil.WriteFile(filepath.Join(r.dir, SignatureFileName), sig, 0o600); err != nil {
+		return fmt.Errorf("error writing recording manifest signature: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package recording
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecorder(t *testing.T) {
+	t.Parallel()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	tests := []struct {
+		name            string
+		sessionDir      string
+		sessionId       string
+		connectionId    string
+		key             ed25519.PrivateKey
+		wantErrContains string
+	}{
+		{
+			name:            "missing-session-dir",
+			sessionId:       "s_1234567890",
+			connectionId:    "sc_1234567890",
+			key:             key,
+			wantErrContains: "missing session directory",
+		},
+		{
+			name:            "missing-session-id",
+			sessionDir:      dir,
+			connectionId:    "sc_1234567890",
+			key:             key,
+			wantErrContains: "missing session id",
+		},
+		{
+			name:            "missing-connection-id",
+			sessionDir:      dir,
+			sessionId:       "s_1234567890",
+			key:             key,
+			wantErrContains: "missing connection id",
+		},
+		{
+			name:            "invalid-key",
+			sessionDir:      dir,
+			sessionId:       "s_1234567890",
+			connectionId:    "sc_1234567890",
+			key:             key[:10],
+			wantErrContains: "invalid signing key",
+		},
+		{
+			name:         "valid",
+			sessionDir:   dir,
+			sessionId:    "s_1234567890",
+			connectionId: "sc_1234567890",
+			key:          key,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewRecorder(tt.sessionDir, tt.sessionId, tt.connectionId, tt.key)
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(filepath.Join(tt.sessionDir, tt.connectionId), got.Dir())
+			fi, err := os.Stat(got.Dir())
+			require.NoError(err)
+			assert.True(fi.IsDir())
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("record", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		r, err := NewRecorder(t.TempDir(), "s_1234567890", "sc_1234567890", key, WithMaxChunkSize(64))
+		require.NoError(err)
+
+		toEndpoint, toClient := r.Writer(ClientToEndpoint), r.Writer(EndpointToClient)
+		writes := []struct {
+			d    Direction
+			w    io.Writer
+			data string
+		}{
+			{d: ClientToEndpoint, w: toEndpoint, data: "GET / HTTP/1.1\r\n\r\n"},
+			{d: EndpointToClient, w: toClient, data: "HTTP/1.1 200 OK\r\n\r\n"},
+			{d: EndpointToClient, w: toClient, data: "hello"},
+			{d: ClientToEndpoint, w: toEndpoint, data: "this frame is larger than the max chunk size of the recording"},
+		}
+		for _, w := range writes {
+			n, err := w.w.Write([]byte(w.data))
+			require.NoError(err)
+			assert.Equal(len(w.data), n)
+		}
+		require.NoError(r.Close())
+		// Closing again is a noop
+		require.NoError(r.Close())
+		_, err = toEndpoint.Write([]byte("closed"))
+		require.Error(err)
+
+		m, err := Verify(r.Dir(), pub)
+		require.NoError(err)
+		assert.Equal(ManifestVersion, m.Version)
+		assert.Equal("s_1234567890", m.SessionId)
+		assert.Equal("sc_1234567890", m.ConnectionId)
+		assert.Equal(int64(len(writes[0].data)+len(writes[3].data)), m.ClientToEndpointBytes)
+		assert.Equal(int64(len(writes[1].data)+len(writes[2].data)), m.EndpointToClientBytes)
+		assert.False(m.EndTime.Before(m.StartTime))
+		require.Len(m.Chunks, 3)
+		assert.Equal([]int{1, 2, 1}, []int{m.Chunks[0].Frames, m.Chunks[1].Frames, m.Chunks[2].Frames})
+
+		var got []*Frame
+		for _, c := range m.Chunks {
+			f, err := os.Open(filepath.Join(r.Dir(), c.Name))
+			require.NoError(err)
+			fr, err := NewFrameReader(f)
+			require.NoError(err)
+			for {
+				frame, err := fr.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(err)
+				assert.False(frame.Time.Before(c.StartTime))
+				assert.False(frame.Time.After(c.EndTime))
+				got = append(got, frame)
+			}
+			require.NoError(f.Close())
+		}
+		require.Len(got, len(writes))
+		for i, w := range writes {
+			assert.Equal(w.d, got[i].Direction)
+			assert.Equal(w.data, string(got[i].Data))
+		}
+	})
+	t.Run("no-data", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		r, err := NewRecorder(t.TempDir(), "s_1234567890", "sc_1234567890", key)
+		require.NoError(err)
+		require.NoError(r.Close())
+		m, err := Verify(r.Dir(), pub)
+		require.NoError(err)
+		assert.Empty(m.Chunks)
+	})
+	t.Run("tampered-chunk", func(t *testing.T) {
+		require := require.New(t)
+		r, err := NewRecorder(t.TempDir(), "s_1234567890", "sc_1234567890", key)
+		require.NoError(err)
+		_, err = r.Writer(ClientToEndpoint).Write([]byte("secret"))
+		require.NoError(err)
+		require.NoError(r.Close())
+
+		path := filepath.Join(r.Dir(), "chunk-000000.rec")
+		b, err := ioutil.ReadFile(path)
+		require.NoError(err)
+		b[len(b)-1] = 'T'
+		require.NoError(ioutil.WriteFile(path, b, 0o600))
+		_, err = Verify(r.Dir(), pub)
+		require.Error(err)
+		assert.Contains(t, err.Error(), "does not match the manifest")
+	})
+	t.Run("wrong-key", func(t *testing.T) {
+		require := require.New(t)
+		r, err := NewRecorder(t.TempDir(), "s_1234567890", "sc_1234567890", key)
+		require.NoError(err)
+		require.NoError(r.Close())
+		otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(err)
+		_, err = Verify(r.Dir(), otherPub)
+		require.Error(err)
+		assert.Contains(t, err.Error(), "invalid recording manifest signature")
+	})
+}
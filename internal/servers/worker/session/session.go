@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/servers/worker/common"
 	"github.com/hashicorp/boundary/internal/session"
+	ua "go.uber.org/atomic"
 )
 
 // ValidateSessionTimeout is the duration of the timeout when the worker queries the
@@ -27,6 +28,12 @@ type ConnInfo struct {
 	ConnCancel context.CancelFunc
 	Status     pbs.CONNECTIONSTATUS
 	CloseTime  time.Time
+
+	// BytesUp and BytesDown are the running counts of the bytes proxied from
+	// the client to the endpoint and from the endpoint to the client. They
+	// are updated by the proxy handler without holding the session lock.
+	BytesUp   ua.Uint64
+	BytesDown ua.Uint64
 }
 
 // Info defines the information about a session
@@ -40,13 +47,15 @@ type Info struct {
 }
 
 // Activate is a helper worker function that sends session activation request to the
-// controller.
-func Activate(ctx context.Context, sessClient pbs.SessionServiceClient, workerId, sessionId, tofuToken string, version uint32) (pbs.SESSIONSTATUS, error) {
+// controller. The recordingPath must be provided if recording is enabled for the
+// session.
+func Activate(ctx context.Context, sessClient pbs.SessionServiceClient, workerId, sessionId, tofuToken string, version uint32, recordingPath string) (pbs.SESSIONSTATUS, error) {
 	resp, err := sessClient.ActivateSession(ctx, &pbs.ActivateSessionRequest{
-		SessionId: sessionId,
-		TofuToken: tofuToken,
-		Version:   version,
-		WorkerId:  workerId,
+		SessionId:     sessionId,
+		TofuToken:     tofuToken,
+		Version:       version,
+		WorkerId:      workerId,
+		RecordingPath: recordingPath,
 	})
 	if err != nil {
 		return pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED, fmt.Errorf("error activating session: %w", err)
@@ -139,7 +148,7 @@ func CloseConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 	// within an adequate period of time.
 	closeConnCtx, closeConnCancel := context.WithTimeout(ctx, common.StatusTimeout)
 	defer closeConnCancel()
-	response, err := closeConnection(closeConnCtx, sessClient, makeCloseConnectionRequest(closeInfo))
+	response, err := closeConnection(closeConnCtx, sessClient, makeCloseConnectionRequest(sessionInfo, closeInfo))
 	if err != nil {
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error marking connections closed",
 			"warning", "error contacting controller, connections will be closed only on worker",
@@ -172,16 +181,27 @@ func CloseConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 // use with closing connections.
 //
 // closeInfo is a map, indexed by connection ID, to the individual
-// sessions IDs that those connections belong to. The values are
-// ignored; the parameter is expected as such just for convenience of
-// its caller.
-func makeCloseConnectionRequest(closeInfo map[string]string) *pbs.CloseConnectionRequest {
+// sessions IDs that those connections belong to. The session IDs are
+// used to look up the final byte counts of the connections in
+// sessionInfo; connections which can't be found are closed with no
+// byte counts.
+func makeCloseConnectionRequest(sessionInfo *sync.Map, closeInfo map[string]string) *pbs.CloseConnectionRequest {
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeInfo))
-	for connId := range closeInfo {
-		closeData = append(closeData, &pbs.CloseConnectionRequestData{
+	for connId, sessionId := range closeInfo {
+		data := &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
 			Reason:       session.UnknownReason.String(),
-		})
+		}
+		if siRaw, ok := sessionInfo.Load(sessionId); ok {
+			si := siRaw.(*Info)
+			si.RLock()
+			if ci, ok := si.ConnInfoMap[connId]; ok {
+				data.BytesUp = ci.BytesUp.Load()
+				data.BytesDown = ci.BytesDown.Load()
+			}
+			si.RUnlock()
+		}
+		closeData = append(closeData, data)
 	}
 
 	return &pbs.CloseConnectionRequest{
@@ -20,7 +20,30 @@ func TestWorkerMakeCloseConnectionRequest(t *testing.T) {
 			{ConnectionId: "bar", Reason: session.UnknownReason.String()},
 		},
 	}
-	actual := makeCloseConnectionRequest(in)
+	actual := makeCloseConnectionRequest(new(sync.Map), in)
+	require.ElementsMatch(expected.GetCloseRequestData(), actual.GetCloseRequestData())
+}
+
+func TestWorkerMakeCloseConnectionRequestWithBytes(t *testing.T) {
+	require := require.New(t)
+	foo := &ConnInfo{Id: "foo"}
+	foo.BytesUp.Store(10)
+	foo.BytesDown.Store(20)
+	sessionInfo := new(sync.Map)
+	sessionInfo.Store("one", &Info{
+		Id: "one",
+		ConnInfoMap: map[string]*ConnInfo{
+			"foo": foo,
+		},
+	})
+	in := map[string]string{"foo": "one", "bar": "two"}
+	expected := &pbs.CloseConnectionRequest{
+		CloseRequestData: []*pbs.CloseConnectionRequestData{
+			{ConnectionId: "foo", BytesUp: 10, BytesDown: 20, Reason: session.UnknownReason.String()},
+			{ConnectionId: "bar", Reason: session.UnknownReason.String()},
+		},
+	}
+	actual := makeCloseConnectionRequest(sessionInfo, in)
 	require.ElementsMatch(expected.GetCloseRequestData(), actual.GetCloseRequestData())
 }
 
@@ -106,6 +106,8 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context) {
 			connections = append(connections, &pbs.Connection{
 				ConnectionId: k,
 				Status:       v.Status,
+				BytesUp:      v.BytesUp.Load(),
+				BytesDown:    v.BytesDown.Load(),
 			})
 		}
 		si.RUnlock()
@@ -138,11 +140,12 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context) {
 	result, err := client.Status(statusCtx, &pbs.StatusRequest{
 		Jobs: activeJobs,
 		Worker: &servers.Server{
-			PrivateId:   w.conf.RawConfig.Worker.Name,
-			Type:        resource.Worker.String(),
-			Description: w.conf.RawConfig.Worker.Description,
-			Address:     w.conf.RawConfig.Worker.PublicAddr,
-			Tags:        tags,
+			PrivateId:          w.conf.RawConfig.Worker.Name,
+			Type:               resource.Worker.String(),
+			Description:        w.conf.RawConfig.Worker.Description,
+			Address:            w.conf.RawConfig.Worker.PublicAddr,
+			Tags:               tags,
+			RecordingPublicKey: w.recordingPublicKey,
 		},
 		UpdateTags: w.updateTags.Load(),
 	})
@@ -182,6 +185,7 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context) {
 			w.Resolver().UpdateState(resolver.State{Addresses: addrs})
 		}
 		w.lastStatusSuccess.Store(&LastStatusInformation{StatusResponse: result, StatusTime: time.Now()})
+		w.rotateWorkerCredential(statusCtx, client)
 
 		for _, request := range result.GetJobsRequests() {
 			switch request.GetRequestType() {
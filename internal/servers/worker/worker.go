@@ -8,6 +8,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,6 +19,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
 	"github.com/hashicorp/boundary/internal/servers/worker/session"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-secure-stdlib/mlock"
@@ -45,6 +47,12 @@ type Worker struct {
 	controllerSessionConn *atomic.Value
 	sessionInfoMap        *sync.Map
 
+	// workerAuthWrapper is the wrapper the worker encrypts the information it
+	// authenticates to a controller with: either the shared worker-auth KMS
+	// or the wrapper of workerCredential, the credential issued to the worker.
+	workerAuthWrapper *atomic.Value
+	workerCredential  *atomic.Value
+
 	// We store the current set in an atomic value so that we can add
 	// reload-on-sighup behavior later
 	tags *atomic.Value
@@ -52,6 +60,13 @@ type Worker struct {
 	// request. It can be set via startup in New below, or (eventually) via
 	// SIGHUP.
 	updateTags ua.Bool
+
+	// recordingKey signs the manifests of the recordings made by the worker.
+	// It is nil if no recording storage path is configured.
+	recordingKey ed25519.PrivateKey
+	// recordingPublicKey is the PEM encoded public key of recordingKey, which
+	// is reported to the controllers in the status requests.
+	recordingPublicKey []byte
 }
 
 func New(conf *Config) (*Worker, error) {
@@ -64,11 +79,14 @@ func New(conf *Config) (*Worker, error) {
 		controllerResolver:    new(atomic.Value),
 		controllerSessionConn: new(atomic.Value),
 		sessionInfoMap:        new(sync.Map),
+		workerAuthWrapper:     new(atomic.Value),
+		workerCredential:      new(atomic.Value),
 		tags:                  new(atomic.Value),
 	}
 
 	w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
 	w.controllerResolver.Store((*manual.Resolver)(nil))
+	w.workerCredential.Store((*workerCredential)(nil))
 
 	if conf.RawConfig.Worker == nil {
 		conf.RawConfig.Worker = new(config.Worker)
@@ -85,6 +103,22 @@ func New(conf *Config) (*Worker, error) {
 		return nil, fmt.Errorf("error auto-generating worker name: %w", err)
 	}
 
+	if storagePath := conf.RawConfig.Worker.RecordingStoragePath; storagePath != "" {
+		keyPath := conf.RawConfig.Worker.RecordingKeyPath
+		if keyPath == "" && conf.RawConfig.Worker.AuthStoragePath != "" {
+			keyPath = filepath.Join(conf.RawConfig.Worker.AuthStoragePath, recording.SigningKeyFileName)
+		}
+		if keyPath == "" {
+			return nil, errors.New("recording_key_path or auth_storage_path is required when recording_storage_path is set")
+		}
+		if w.recordingKey, err = recording.LoadOrCreateSigningKey(keyPath, storagePath); err != nil {
+			return nil, fmt.Errorf("error loading recording signing key: %w", err)
+		}
+		if w.recordingPublicKey, err = recording.MarshalPublicKey(w.recordingKey); err != nil {
+			return nil, err
+		}
+	}
+
 	if !conf.RawConfig.DisableMlock {
 		// Ensure our memory usage is locked into physical RAM
 		if err := mlock.LockMemory(); err != nil {
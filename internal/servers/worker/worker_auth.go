@@ -0,0 +1,222 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/servers"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// workerCredentialFileName is the name of the file the worker stores its
+	// credential in, within the auth storage path.
+	workerCredentialFileName = "worker-credential.json"
+
+	// registerTimeout is the time the worker waits for a controller to accept
+	// its registration.
+	registerTimeout = 30 * time.Second
+)
+
+// workerCredential is the credential issued to the worker when it registered
+// or rotated its credential, as stored in the auth storage path.
+type workerCredential struct {
+	WorkerId       string    `json:"worker_id"`
+	CredentialId   string    `json:"credential_id"`
+	Key            []byte    `json:"key"`
+	ExpirationTime time.Time `json:"expiration_time"`
+	RotationTime   time.Time `json:"rotation_time"`
+}
+
+func newWorkerCredential(workerId string, c *pbs.WorkerCredential) (*workerCredential, error) {
+	if c == nil {
+		return nil, errors.New("no credential returned by the controller")
+	}
+	return &workerCredential{
+		WorkerId:       workerId,
+		CredentialId:   c.GetId(),
+		Key:            c.GetKey(),
+		ExpirationTime: c.GetExpirationTime().AsTime(),
+		RotationTime:   c.GetRotationTime().AsTime(),
+	}, nil
+}
+
+// loadWorkerCredential reads the credential stored in dir. Returns nil, nil if
+// no credential is stored in dir.
+func loadWorkerCredential(dir string) (*workerCredential, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, workerCredentialFileName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("error reading worker credential: %w", err)
+	}
+	c := new(workerCredential)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("error decoding worker credential: %w", err)
+	}
+	return c, nil
+}
+
+// storeWorkerCredential writes c to dir, creating dir if needed. The
+// credential is written to a temporary file which then replaces the previous
+// one, so a failed write never loses the previous credential.
+func storeWorkerCredential(dir string, c *workerCredential) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("error creating auth storage path: %w", err)
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("error encoding worker credential: %w", err)
+	}
+	tmp := filepath.Join(dir, workerCredentialFileName+".tmp")
+	if err := ioutil.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("error writing worker credential: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, workerCredentialFileName)); err != nil {
+		return fmt.Errorf("error writing worker credential: %w", err)
+	}
+	return nil
+}
+
+// authWrapper returns the wrapper the worker currently encrypts the
+// information it authenticates to a controller with.
+func (w *Worker) authWrapper() wrapping.Wrapper {
+	return w.workerAuthWrapper.Load().(wrapping.Wrapper)
+}
+
+// setWorkerCredential makes c the credential the worker authenticates to the
+// controllers with.
+func (w *Worker) setWorkerCredential(c *workerCredential) error {
+	wrapper, err := servers.NewWorkerCredentialWrapper(c.CredentialId, c.Key)
+	if err != nil {
+		return fmt.Errorf("error creating wrapper for worker credential: %w", err)
+	}
+	w.workerCredential.Store(c)
+	w.workerAuthWrapper.Store(wrapper)
+	return nil
+}
+
+// startWorkerAuth sets up how the worker authenticates to the controllers at
+// addrs. A worker with an auth storage path authenticates with the credential
+// stored there; if there is none yet, the worker registers with its activation
+// token to be issued one. Otherwise the worker authenticates with the shared
+// worker-auth KMS.
+func (w *Worker) startWorkerAuth(addrs []resolver.Address) error {
+	const op = "worker.(Worker).startWorkerAuth"
+	storagePath := w.conf.RawConfig.Worker.AuthStoragePath
+	if storagePath == "" {
+		if w.conf.WorkerAuthKms == nil {
+			return errors.New("worker has neither a worker-auth kms nor an auth storage path")
+		}
+		w.workerAuthWrapper.Store(w.conf.WorkerAuthKms)
+		return nil
+	}
+
+	c, err := loadWorkerCredential(storagePath)
+	if err != nil {
+		return err
+	}
+	switch {
+	case c != nil:
+		if time.Now().After(c.ExpirationTime) {
+			return fmt.Errorf("worker credential %s expired at %s; delete the worker and register it again with a new activation token", c.CredentialId, c.ExpirationTime)
+		}
+	case w.conf.RawConfig.Worker.ActivationToken != "":
+		if c, err = w.registerWorker(addrs); err != nil {
+			return fmt.Errorf("error registering worker: %w", err)
+		}
+		if err := storeWorkerCredential(storagePath, c); err != nil {
+			return err
+		}
+		event.WriteSysEvent(w.baseContext, op, "worker registered", "worker_id", c.WorkerId)
+	case w.conf.WorkerAuthKms != nil:
+		w.workerAuthWrapper.Store(w.conf.WorkerAuthKms)
+		return nil
+	default:
+		return fmt.Errorf("no worker credential found in %s and no activation token or worker-auth kms configured", storagePath)
+	}
+	return w.setWorkerCredential(c)
+}
+
+// registerWorker presents the activation token of the worker to the first of
+// the controllers at addrs which accepts it and returns the credential issued
+// to the worker. The connection authenticated with the activation token is
+// only used for the registration; it cannot be used for any other request.
+func (w *Worker) registerWorker(addrs []resolver.Address) (*workerCredential, error) {
+	const op = "worker.(Worker).registerWorker"
+	wrapper, err := servers.NewActivationTokenWrapper(w.conf.RawConfig.Worker.ActivationToken)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing activation token: %w", err)
+	}
+	var lastErr error
+	for _, addr := range addrs {
+		cc, err := grpc.DialContext(w.baseContext,
+			fmt.Sprintf("passthrough:///%s", addr.Addr),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32)),
+			grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(math.MaxInt32)),
+			grpc.WithContextDialer(w.controllerDialerFunc(func() wrapping.Wrapper { return wrapper })),
+			grpc.WithInsecure(),
+		)
+		if err != nil {
+			lastErr = fmt.Errorf("error dialing controller %s: %w", addr.Addr, err)
+			continue
+		}
+		ctx, cancel := context.WithTimeout(w.baseContext, registerTimeout)
+		resp, err := pbs.NewServerCoordinationServiceClient(cc).RegisterWorker(ctx, &pbs.RegisterWorkerRequest{})
+		cancel()
+		if err := cc.Close(); err != nil {
+			event.WriteError(w.baseContext, op, err, event.WithInfoMsg("error closing registration connection"))
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("error registering with controller %s: %w", addr.Addr, err)
+			continue
+		}
+		return newWorkerCredential(resp.GetWorkerId(), resp.GetCredential())
+	}
+	return nil, lastErr
+}
+
+// rotateWorkerCredential rotates the credential of the worker once its
+// rotation time has passed. The new credential is stored in the auth storage
+// path and used for the following connections to the controllers; the
+// previous one remains valid for a short time.
+func (w *Worker) rotateWorkerCredential(ctx context.Context, client pbs.ServerCoordinationServiceClient) {
+	const op = "worker.(Worker).rotateWorkerCredential"
+	c, ok := w.workerCredential.Load().(*workerCredential)
+	if !ok || c == nil || time.Now().Before(c.RotationTime) {
+		return
+	}
+	resp, err := client.RotateWorkerCredential(ctx, &pbs.RotateWorkerCredentialRequest{})
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error rotating worker credential"))
+		return
+	}
+	newCred, err := newWorkerCredential(c.WorkerId, resp.GetCredential())
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error rotating worker credential"))
+		return
+	}
+	// The previous credential expires shortly, so use the new one even if
+	// it cannot be stored.
+	if err := w.setWorkerCredential(newCred); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error rotating worker credential"))
+		return
+	}
+	if err := storeWorkerCredential(w.conf.RawConfig.Worker.AuthStoragePath, newCred); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error storing rotated worker credential; the worker will not be able to authenticate after a restart"))
+		return
+	}
+	event.WriteSysEvent(ctx, op, "worker credential rotated", "credential_id", newCred.CredentialId)
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestWorkerCredentialStorage(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir := filepath.Join(t.TempDir(), "auth")
+
+	got, err := loadWorkerCredential(dir)
+	require.NoError(err)
+	assert.Nil(got)
+
+	now := time.Now().Truncate(time.Second)
+	c, err := newWorkerCredential("w_1234567890", &pbs.WorkerCredential{
+		Id:             "wcr_1234567890",
+		Key:            []byte("01234567890123456789012345678901"),
+		ExpirationTime: timestamppb.New(now.Add(time.Hour)),
+		RotationTime:   timestamppb.New(now.Add(30 * time.Minute)),
+	})
+	require.NoError(err)
+	require.NoError(storeWorkerCredential(dir, c))
+
+	fi, err := os.Stat(filepath.Join(dir, workerCredentialFileName))
+	require.NoError(err)
+	assert.Equal(os.FileMode(0o600), fi.Mode().Perm())
+
+	got, err = loadWorkerCredential(dir)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal("w_1234567890", got.WorkerId)
+	assert.Equal("wcr_1234567890", got.CredentialId)
+	assert.Equal(c.Key, got.Key)
+	assert.True(c.ExpirationTime.Equal(got.ExpirationTime))
+	assert.True(c.RotationTime.Equal(got.RotationTime))
+
+	// Storing a rotated credential replaces the previous one
+	c.CredentialId = "wcr_0987654321"
+	require.NoError(storeWorkerCredential(dir, c))
+	got, err = loadWorkerCredential(dir)
+	require.NoError(err)
+	assert.Equal("wcr_0987654321", got.CredentialId)
+
+	_, err = newWorkerCredential("w_1234567890", nil)
+	assert.Error(err)
+}
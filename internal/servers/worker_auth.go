@@ -0,0 +1,283 @@
+package servers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/servers/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"golang.org/x/crypto/hkdf"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// WorkerPrefix for worker PK ids
+	WorkerPrefix = "w"
+
+	// WorkerCredentialPrefix for worker credential PK ids
+	WorkerCredentialPrefix = "wcr"
+
+	defaultWorkerTableName           = "server_worker"
+	defaultWorkerCredentialTableName = "server_worker_credential"
+
+	// ActivationTokenLifetime is the time in which a worker must present its
+	// activation token once it has been created.
+	ActivationTokenLifetime = 24 * time.Hour
+
+	// WorkerCredentialLifetime is the time for which a worker credential can
+	// be used. Workers rotate their credential once half of it has passed.
+	WorkerCredentialLifetime = 14 * 24 * time.Hour
+
+	// workerCredentialRotationGrace is the time for which the previous
+	// credentials of a worker can still be used once it has rotated its
+	// credential.
+	workerCredentialRotationGrace = 10 * time.Minute
+
+	// workerAuthKeyLength is the length of the AES-256 keys of worker
+	// credentials.
+	workerAuthKeyLength = 32
+
+	activationTokenKeyInfo = "boundary worker activation token"
+)
+
+// A Worker is a worker known to the controllers. A Worker created through the
+// API authenticates to the controllers with its own credentials instead of
+// the shared worker-auth KMS; a worker which authenticates with the shared
+// worker-auth KMS is added when it first reports its status. It is always in
+// the global scope.
+type Worker struct {
+	*store.Worker
+
+	// Address is the address the worker last reported to a controller.
+	Address string `gorm:"-"`
+	// LastStatusTime is the time the worker last reported its status to a
+	// controller. It is nil if the worker has not reported its status yet.
+	LastStatusTime *timestamp.Timestamp `gorm:"-"`
+	// RecordingPublicKey is the PEM encoded public key which verifies the
+	// recordings of the worker, as last reported to a controller.
+	RecordingPublicKey []byte `gorm:"-"`
+	// ConfigTags are the tags in the configuration of the worker.
+	ConfigTags map[string][]string `gorm:"-"`
+	// ApiTags are the tags set on the worker through the API.
+	ApiTags map[string][]string `gorm:"-"`
+
+	tableName string `gorm:"-"`
+}
+
+// NewWorker creates a new in memory Worker with name, which must match the
+// name the worker is configured with. Supports the WithDescription option.
+func NewWorker(name string, opt ...Option) (*Worker, error) {
+	const op = "servers.NewWorker"
+	if name == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing name")
+	}
+	opts := getOpts(opt...)
+	return &Worker{
+		Worker: &store.Worker{
+			ScopeId:     "global",
+			Name:        name,
+			Description: opts.withDescription,
+		},
+	}, nil
+}
+
+func allocWorker() *Worker {
+	return &Worker{
+		Worker: &store.Worker{},
+	}
+}
+
+func (w *Worker) clone() *Worker {
+	cp := proto.Clone(w.Worker)
+	return &Worker{
+		Worker: cp.(*store.Worker),
+	}
+}
+
+// CanonicalTags returns the tags the worker filters of targets are evaluated
+// against: the configuration tags of the worker merged with its API tags.
+func (w *Worker) CanonicalTags() map[string][]string {
+	tags := make(map[string][]string, len(w.ConfigTags)+len(w.ApiTags))
+	for _, src := range []map[string][]string{w.ConfigTags, w.ApiTags} {
+		for k, vals := range src {
+			for _, v := range vals {
+				if !strutil.StrListContains(tags[k], v) {
+					tags[k] = append(tags[k], v)
+				}
+			}
+		}
+	}
+	return tags
+}
+
+// TableName returns the table name of the worker.
+func (w *Worker) TableName() string {
+	if w.tableName != "" {
+		return w.tableName
+	}
+	return defaultWorkerTableName
+}
+
+// SetTableName sets the table name. If the caller attempts to set the name to
+// "" the name will be reset to the default name.
+func (w *Worker) SetTableName(n string) {
+	w.tableName = n
+}
+
+func (w *Worker) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "servers.(Worker).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, w.Worker, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	w.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (w *Worker) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "servers.(Worker).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, w.Worker, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func newWorkerMetadata(w *Worker, op oplog.OpType) oplog.Metadata {
+	return oplog.Metadata{
+		"resource-public-id": []string{w.GetPublicId()},
+		"resource-type":      []string{"worker"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{w.GetScopeId()},
+	}
+}
+
+// A WorkerCredential is a credential issued to a Worker. The worker encrypts
+// the information it authenticates to a controller with using the key of the
+// credential.
+type WorkerCredential struct {
+	*store.WorkerCredential
+	tableName string `gorm:"-"`
+}
+
+func newWorkerCredential(ctx context.Context, workerId string, r io.Reader) (*WorkerCredential, error) {
+	const op = "servers.newWorkerCredential"
+	id, err := db.NewPublicId(WorkerCredentialPrefix)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	key := make([]byte, workerAuthKeyLength)
+	if _, err := io.ReadFull(r, key); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to generate key"))
+	}
+	return &WorkerCredential{
+		WorkerCredential: &store.WorkerCredential{
+			PublicId: id,
+			WorkerId: workerId,
+			Key:      key,
+		},
+	}, nil
+}
+
+func allocWorkerCredential() *WorkerCredential {
+	return &WorkerCredential{
+		WorkerCredential: &store.WorkerCredential{},
+	}
+}
+
+// TableName returns the table name of the worker credential.
+func (c *WorkerCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return defaultWorkerCredentialTableName
+}
+
+// SetTableName sets the table name. If the caller attempts to set the name to
+// "" the name will be reset to the default name.
+func (c *WorkerCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+// RotationTime returns the time after which the worker should rotate the
+// credential, once half of its lifetime has passed.
+func (c *WorkerCredential) RotationTime() time.Time {
+	return c.GetExpirationTime().AsTime().Add(-WorkerCredentialLifetime / 2)
+}
+
+func (c *WorkerCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "servers.(WorkerCredential).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, c.WorkerCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	c.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (c *WorkerCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "servers.(WorkerCredential).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c.WorkerCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// ParseActivationToken returns the id of the worker an activation token was
+// created for. The token is the id of the worker followed by an underscore
+// and a random secret.
+func ParseActivationToken(token string) (string, error) {
+	const op = "servers.ParseActivationToken"
+	i := strings.LastIndex(token, "_")
+	if i < 0 || !strings.HasPrefix(token, WorkerPrefix+"_") || i == len(WorkerPrefix) || i == len(token)-1 {
+		return "", errors.NewDeprecated(errors.InvalidParameter, op, "malformed activation token")
+	}
+	return token[:i], nil
+}
+
+// NewActivationTokenWrapper returns the wrapper a worker encrypts the
+// information it authenticates to a controller with until it has been issued
+// a credential. Its key is derived from the activation token and its key id
+// is the id of the worker the token was created for.
+func NewActivationTokenWrapper(token string) (wrapping.Wrapper, error) {
+	const op = "servers.NewActivationTokenWrapper"
+	workerId, err := ParseActivationToken(token)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	key := make([]byte, workerAuthKeyLength)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(token), nil, []byte(activationTokenKeyInfo)), key); err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to derive key"))
+	}
+	return NewWorkerCredentialWrapper(workerId, key)
+}
+
+// NewWorkerCredentialWrapper returns the wrapper a worker encrypts the
+// information it authenticates to a controller with using key. keyId is sent
+// in the clear along with the encrypted information; it is the id of the
+// credential key belongs to, or the worker id for an activation token.
+func NewWorkerCredentialWrapper(keyId string, key []byte) (wrapping.Wrapper, error) {
+	const op = "servers.NewWorkerCredentialWrapper"
+	if keyId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing key id")
+	}
+	if len(key) != workerAuthKeyLength {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("key must be %d bytes", workerAuthKeyLength))
+	}
+	w := aead.NewWrapper(nil)
+	if _, err := w.SetConfig(map[string]string{"key_id": keyId}); err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	if err := w.SetAESGCMKeyBytes(key); err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	return w, nil
+}
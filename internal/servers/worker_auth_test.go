@@ -0,0 +1,107 @@
+package servers
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActivationToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{name: "valid", token: "w_1234567890_secret", want: "w_1234567890"},
+		{name: "empty", token: "", wantErr: true},
+		{name: "no-secret", token: "w_1234567890_", wantErr: true},
+		{name: "no-worker-id", token: "w_", wantErr: true},
+		{name: "wrong-prefix", token: "wcr_1234567890_secret", wantErr: true},
+		{name: "no-separator", token: "secret", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseActivationToken(tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewActivationTokenWrapper(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	const token = "w_1234567890_secret"
+
+	w1, err := NewActivationTokenWrapper(token)
+	require.NoError(err)
+	assert.Equal("w_1234567890", w1.KeyID())
+
+	// The controller derives the same key from the stored token
+	w2, err := NewActivationTokenWrapper(token)
+	require.NoError(err)
+	blob, err := w1.Encrypt(ctx, []byte("info"), nil)
+	require.NoError(err)
+	assert.Equal("w_1234567890", blob.GetKeyInfo().GetKeyID())
+	pt, err := w2.Decrypt(ctx, blob, nil)
+	require.NoError(err)
+	assert.Equal([]byte("info"), pt)
+
+	// A different token yields a different key
+	w3, err := NewActivationTokenWrapper("w_1234567890_other")
+	require.NoError(err)
+	_, err = w3.Decrypt(ctx, blob, nil)
+	assert.Error(err)
+
+	_, err = NewActivationTokenWrapper("malformed")
+	assert.Error(err)
+}
+
+func TestNewWorkerCredentialWrapper(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	c, err := newWorkerCredential(ctx, "w_1234567890", rand.Reader)
+	require.NoError(err)
+
+	w, err := NewWorkerCredentialWrapper(c.GetPublicId(), c.GetKey())
+	require.NoError(err)
+	assert.Equal(c.GetPublicId(), w.KeyID())
+	blob, err := w.Encrypt(ctx, []byte("info"), nil)
+	require.NoError(err)
+	assert.Equal(c.GetPublicId(), blob.GetKeyInfo().GetKeyID())
+	pt, err := w.Decrypt(ctx, blob, nil)
+	require.NoError(err)
+	assert.Equal([]byte("info"), pt)
+
+	_, err = NewWorkerCredentialWrapper("", c.GetKey())
+	assert.Error(err)
+	_, err = NewWorkerCredentialWrapper(c.GetPublicId(), []byte("short"))
+	assert.Error(err)
+}
+
+func TestWorker_CanonicalTags(t *testing.T) {
+	w := &Worker{
+		ConfigTags: map[string][]string{
+			"type":   {"prod"},
+			"region": {"us-east-1"},
+		},
+		ApiTags: map[string][]string{
+			"type": {"prod", "canary"},
+			"team": {"web"},
+		},
+	}
+	assert.Equal(t, map[string][]string{
+		"type":   {"prod", "canary"},
+		"region": {"us-east-1"},
+		"team":   {"web"},
+	}, w.CanonicalTags())
+	assert.Empty(t, (&Worker{}).CanonicalTags())
+}
@@ -0,0 +1,98 @@
+package servers
+
+import (
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/servers/store"
+)
+
+const (
+	defaultWorkerTagTableName       = "server_worker_tag"
+	defaultWorkerAggregateTableName = "server_worker_aggregate"
+	canonicalTagTableName           = "server_worker_canonical_tag"
+)
+
+// A WorkerTag is a tag set on a Worker through the API.
+type WorkerTag struct {
+	*store.WorkerTag
+	tableName string `gorm:"-"`
+}
+
+func newWorkerTag(workerId, key, value string) *WorkerTag {
+	return &WorkerTag{
+		WorkerTag: &store.WorkerTag{
+			WorkerId: workerId,
+			Key:      key,
+			Value:    value,
+		},
+	}
+}
+
+// TableName returns the table name of the worker tag.
+func (t *WorkerTag) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return defaultWorkerTagTableName
+}
+
+// SetTableName sets the table name. If the caller attempts to set the name to
+// "" the name will be reset to the default name.
+func (t *WorkerTag) SetTableName(n string) {
+	t.tableName = n
+}
+
+// workerAggregate is a worker as read from the server_worker_aggregate view,
+// which includes the address, the recording public key and the time of the
+// last status report of the worker.
+type workerAggregate struct {
+	PublicId                      string `gorm:"primary_key"`
+	ScopeId                       string
+	Name                          string
+	Description                   string
+	CreateTime                    *timestamp.Timestamp
+	UpdateTime                    *timestamp.Timestamp
+	Version                       uint32
+	ActivationTokenExpirationTime *timestamp.Timestamp
+	ActivationTime                *timestamp.Timestamp
+	Address                       string
+	LastStatusTime                *timestamp.Timestamp
+	RecordingPublicKey            []byte
+}
+
+// TableName returns the table name for gorm.
+func (agg *workerAggregate) TableName() string {
+	return defaultWorkerAggregateTableName
+}
+
+func (agg *workerAggregate) toWorker() *Worker {
+	return &Worker{
+		Worker: &store.Worker{
+			PublicId:                      agg.PublicId,
+			ScopeId:                       agg.ScopeId,
+			Name:                          agg.Name,
+			Description:                   agg.Description,
+			CreateTime:                    agg.CreateTime,
+			UpdateTime:                    agg.UpdateTime,
+			Version:                       agg.Version,
+			ActivationTokenExpirationTime: agg.ActivationTokenExpirationTime,
+			ActivationTime:                agg.ActivationTime,
+		},
+		Address:            agg.Address,
+		LastStatusTime:     agg.LastStatusTime,
+		RecordingPublicKey: agg.RecordingPublicKey,
+	}
+}
+
+// canonicalTag is a tag of a worker as read from the
+// server_worker_canonical_tag view, which contains both the configuration and
+// the API tags of the workers.
+type canonicalTag struct {
+	ServerId string
+	Key      string
+	Value    string
+}
+
+// TableName returns the table name for gorm.
+func (canonicalTag) TableName() string {
+	return canonicalTagTableName
+}
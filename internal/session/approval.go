@@ -0,0 +1,38 @@
+package session
+
+import (
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+const (
+	defaultApprovalTableName = "session_approval"
+)
+
+// approvalDecision is the decision on a session which requires approval.
+type approvalDecision string
+
+const (
+	approvalApproved approvalDecision = "approved"
+	approvalDenied   approvalDecision = "denied"
+)
+
+// approval records the decision on a session which requires approval. A
+// session has at most one approval.
+type approval struct {
+	// SessionId of the session the decision is on
+	SessionId string `gorm:"primary_key"`
+	// ApproverId is the user who approved or denied the session
+	ApproverId string `gorm:"default:null"`
+	// Decision is either approved or denied
+	Decision string
+	// AuthorizationTime is the time the user of an approved session
+	// retrieved its authorization. It is nil until retrieved.
+	AuthorizationTime *timestamp.Timestamp `gorm:"default:null"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+}
+
+// TableName returns the table name of the approval.
+func (a *approval) TableName() string {
+	return defaultApprovalTableName
+}
@@ -13,7 +13,10 @@ const (
 	defaultConnectionTableName = "session_connection"
 )
 
-// Connection contains information about session's connection to a target
+// Connection contains information about session's connection to a target.
+// For a udp target, a connection is the flow of datagrams from one client
+// address, which the worker closes once it is idle. Its tcp address and port
+// fields hold the udp addresses and ports of the flow.
 type Connection struct {
 	// PublicId is used to access the connection via an API
 	PublicId string `json:"public_id,omitempty" gorm:"primary_key"`
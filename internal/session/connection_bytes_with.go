@@ -0,0 +1,22 @@
+package session
+
+import (
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// BytesWith defines the running byte counts of an open connection between the
+// client and the endpoint which are reported by the worker.
+type BytesWith struct {
+	ConnectionId string
+	BytesUp      uint64
+	BytesDown    uint64
+}
+
+func (b BytesWith) validate() error {
+	const op = "session.(BytesWith).validate"
+	if b.ConnectionId == "" {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "missing connection id")
+	}
+	// 0 is valid for BytesUp and BytesDown
+	return nil
+}
@@ -0,0 +1,44 @@
+package session
+
+import (
+	"testing"
+)
+
+func TestBytesWith_validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		bw      BytesWith
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			bw: BytesWith{
+				ConnectionId: "sc_1234567890",
+				BytesUp:      1,
+				BytesDown:    2,
+			},
+		},
+		{
+			name: "valid-no-bytes",
+			bw: BytesWith{
+				ConnectionId: "sc_1234567890",
+			},
+		},
+		{
+			name: "missing-ConnectionId",
+			bw: BytesWith{
+				BytesUp:   1,
+				BytesDown: 2,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.bw.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("BytesWith.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
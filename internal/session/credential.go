@@ -0,0 +1,66 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+)
+
+// Credential represents the marshaled credential data that is handed to the
+// worker proxying a session. The worker uses it to authenticate to the
+// endpoint; it is never returned to the user.
+type Credential []byte
+
+// sessionCredential is the storage representation of a Credential for a
+// session.
+type sessionCredential struct {
+	SessionId        string `json:"session_id,omitempty" gorm:"primary_key"`
+	Credential       []byte `json:"credential,omitempty" gorm:"-" wrapping:"pt,credential"`
+	CtCredential     []byte `json:"ct_credential,omitempty" gorm:"column:credential;not_null" wrapping:"ct,credential"`
+	CredentialSha256 []byte `json:"credential_sha256,omitempty" gorm:"primary_key"`
+	KeyId            string `json:"key_id,omitempty" gorm:"not_null"`
+
+	tableName string `gorm:"-"`
+}
+
+func newSessionCredential(sessionId string, c Credential) *sessionCredential {
+	sum := sha256.Sum256(c)
+	return &sessionCredential{
+		SessionId:        sessionId,
+		Credential:       c,
+		CredentialSha256: sum[:],
+	}
+}
+
+// TableName returns the table name.
+func (c *sessionCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "session_credential"
+}
+
+// SetTableName sets the table name.
+func (c *sessionCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+func (c *sessionCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "session.(sessionCredential).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, c, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	c.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (c *sessionCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "session.(sessionCredential).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
@@ -17,6 +17,9 @@ const (
 
 	// ConnectionStatePrefix for connection state PK ids
 	ConnectionStatePrefix = "scs"
+
+	// PolicyPrefix for policy PK ids
+	PolicyPrefix = "sp"
 )
 
 func newId() (string, error) {
@@ -54,3 +57,12 @@ func newConnectionStateId() (string, error) {
 	}
 	return id, nil
 }
+
+func newPolicyId() (string, error) {
+	const op = "session.newPolicyId"
+	id, err := db.NewPublicId(PolicyPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, op)
+	}
+	return id, nil
+}
@@ -19,16 +19,25 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withLimit             int
-	withOrderByCreateTime db.OrderBy
-	withScopeIds          []string
-	withUserId            string
-	withExpirationTime    *timestamp.Timestamp
-	withTestTofu          []byte
-	withListingConvert    bool
-	withSessionIds        []string
-	withServerId          string
-	withDbOpts            []db.Option
+	withLimit              int
+	withStartPageAfterItem *string
+	withOrderByCreateTime  db.OrderBy
+	withScopeIds           []string
+	withUserId             string
+	withExpirationTime     *timestamp.Timestamp
+	withTestTofu           []byte
+	withListingConvert     bool
+	withSessionIds         []string
+	withServerId           string
+	withDbOpts             []db.Option
+	withRecordingPath      string
+	withStatuses           []Status
+	withName               string
+	withDescription        string
+	withPublicId           string
+
+	withMaxActiveSessionsPerUser      uint32
+	withMaxDailySessionSecondsPerUser uint32
 }
 
 func getDefaultOptions() options {
@@ -44,6 +53,15 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
 // WithOrderByCreateTime provides an option to specify ordering by the
 // CreateTime field.
 func WithOrderByCreateTime(orderBy db.OrderBy) Option {
@@ -102,6 +120,60 @@ func WithServerId(id string) Option {
 	}
 }
 
+// WithRecordingPath provides the path of the directory on the worker which
+// the connections of a session are recorded to.
+func WithRecordingPath(path string) Option {
+	return func(o *options) {
+		o.withRecordingPath = path
+	}
+}
+
+// WithStatuses allows specifying the current statuses of the sessions for the
+// function.
+func WithStatuses(statuses ...Status) Option {
+	return func(o *options) {
+		o.withStatuses = statuses
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithPublicId provides an optional public id.
+func WithPublicId(id string) Option {
+	return func(o *options) {
+		o.withPublicId = id
+	}
+}
+
+// WithMaxActiveSessionsPerUser provides the maximum number of sessions, which
+// are not terminated, a user can have under a policy.
+func WithMaxActiveSessionsPerUser(max uint32) Option {
+	return func(o *options) {
+		o.withMaxActiveSessionsPerUser = max
+	}
+}
+
+// WithMaxDailySessionSecondsPerUser provides the maximum total duration, in
+// seconds, of the sessions a user can have under a policy over the last 24
+// hours.
+func WithMaxDailySessionSecondsPerUser(max uint32) Option {
+	return func(o *options) {
+		o.withMaxDailySessionSecondsPerUser = max
+	}
+}
+
 // WithDbOpts passes through given DB options to the DB layer
 func WithDbOpts(opts ...db.Option) Option {
 	return func(o *options) {
@@ -30,6 +30,14 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 1
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterItem("s_1234567890"))
+		testOpts := getDefaultOptions()
+		id := "s_1234567890"
+		testOpts.withStartPageAfterItem = &id
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithScopeId", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithScopeIds([]string{"o_1234"}))
@@ -82,4 +90,46 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withServerId = "worker1"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithRecordingPath", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithRecordingPath("/var/lib/boundary/recordings/s_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withRecordingPath = "/var/lib/boundary/recordings/s_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithName", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithPublicId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithPublicId("sp_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "sp_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMaxActiveSessionsPerUser", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithMaxActiveSessionsPerUser(3))
+		testOpts := getDefaultOptions()
+		testOpts.withMaxActiveSessionsPerUser = 3
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMaxDailySessionSecondsPerUser", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithMaxDailySessionSecondsPerUser(3600))
+		testOpts := getDefaultOptions()
+		testOpts.withMaxDailySessionSecondsPerUser = 3600
+		assert.Equal(opts, testOpts)
+	})
 }
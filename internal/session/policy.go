@@ -0,0 +1,81 @@
+package session
+
+import (
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/session/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultPolicyTableName = "session_policy"
+)
+
+// A Policy limits the sessions users can have on the targets of an org or
+// project scope. The policies of an org apply to the sessions of all the
+// projects of the org, counted together. A zero limit means the policy does
+// not limit that aspect of the sessions.
+type Policy struct {
+	*store.Policy
+	tableName string `gorm:"-"`
+}
+
+// NewPolicy creates a new in memory Policy assigned to scopeId. Supports the
+// WithName, WithDescription, WithMaxActiveSessionsPerUser and
+// WithMaxDailySessionSecondsPerUser options.
+func NewPolicy(scopeId string, opt ...Option) (*Policy, error) {
+	if scopeId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, "session.NewPolicy", "no scope id")
+	}
+
+	opts := getOpts(opt...)
+	p := &Policy{
+		Policy: &store.Policy{
+			ScopeId:                       scopeId,
+			Name:                          opts.withName,
+			Description:                   opts.withDescription,
+			MaxActiveSessionsPerUser:      opts.withMaxActiveSessionsPerUser,
+			MaxDailySessionSecondsPerUser: opts.withMaxDailySessionSecondsPerUser,
+		},
+	}
+	return p, nil
+}
+
+func (p *Policy) clone() *Policy {
+	cp := proto.Clone(p.Policy)
+	return &Policy{
+		Policy: cp.(*store.Policy),
+	}
+}
+
+// TableName returns the table name for the policy.
+func (p *Policy) TableName() string {
+	if p.tableName != "" {
+		return p.tableName
+	}
+	return defaultPolicyTableName
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (p *Policy) SetTableName(n string) {
+	p.tableName = n
+}
+
+func allocPolicy() *Policy {
+	return &Policy{
+		Policy: &store.Policy{},
+	}
+}
+
+func newPolicyMetadata(p *Policy, op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{p.GetPublicId()},
+		"resource-type":      []string{"session policy"},
+		"op-type":            []string{op.String()},
+	}
+	if p.ScopeId != "" {
+		metadata["scope-id"] = []string{p.ScopeId}
+	}
+	return metadata
+}
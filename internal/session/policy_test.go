@@ -0,0 +1,72 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/session/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		scopeId string
+		opts    []Option
+		want    *Policy
+		wantErr bool
+	}{
+		{
+			name:    "no-scope",
+			wantErr: true,
+		},
+		{
+			name:    "no-options",
+			scopeId: "o_1234567890",
+			want:    &Policy{Policy: &store.Policy{ScopeId: "o_1234567890"}},
+		},
+		{
+			name:    "all-options",
+			scopeId: "p_1234567890",
+			opts: []Option{
+				WithName("name"),
+				WithDescription("description"),
+				WithMaxActiveSessionsPerUser(5),
+				WithMaxDailySessionSecondsPerUser(3600),
+			},
+			want: &Policy{Policy: &store.Policy{
+				ScopeId:                       "p_1234567890",
+				Name:                          "name",
+				Description:                   "description",
+				MaxActiveSessionsPerUser:      5,
+				MaxDailySessionSecondsPerUser: 3600,
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewPolicy(tt.scopeId, tt.opts...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestPolicy_TableName(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	p := allocPolicy()
+	assert.Equal(defaultPolicyTableName, p.TableName())
+	p.SetTableName("other")
+	assert.Equal("other", p.TableName())
+	p.SetTableName("")
+	assert.Equal(defaultPolicyTableName, p.TableName())
+}
@@ -6,6 +6,57 @@ import (
 )
 
 const (
+	// updateConnectionBytes updates the running byte counts of a connection
+	// which has not been closed. The counts of a closed connection are final,
+	// and the counts are never decreased, so a stale report is ignored.
+	updateConnectionBytes = `
+update session_connection
+   set bytes_up   = @bytes_up,
+       bytes_down = @bytes_down
+ where public_id = @public_id
+   and closed_reason is null
+   and coalesce(bytes_up, 0) <= @bytes_up
+   and coalesce(bytes_down, 0) <= @bytes_down;
+`
+
+	// claimApprovedSession records the retrieval of the authorization of an
+	// approved session by its user. It only updates an approval which is
+	// within the approval window of a session which is still pending.
+	claimApprovedSession = `
+update session_approval sa
+   set authorization_time = now()
+  from session s
+ where sa.session_id = @session_id
+   and s.public_id = sa.session_id
+   and s.user_id = @user_id
+   and s.approval_expiration_time > now()
+   and sa.decision = 'approved'
+   and sa.authorization_time is null
+   and s.public_id in (
+         select session_id
+           from session_state
+          where session_id = @session_id
+            and state = 'pending'
+            and end_time is null
+       );
+`
+
+	// expireSessionApprovals terminates the sessions which are past their
+	// approval expiration time and whose authorization was never retrieved.
+	// Denied sessions are already terminated.
+	expireSessionApprovals = `
+update session
+   set termination_reason = 'approval expired'
+ where termination_reason is null
+   and approval_expiration_time <= now()
+   and public_id not in (
+         select session_id
+           from session_approval
+          where authorization_time is not null
+       )
+returning public_id;
+`
+
 	activateStateCte = `
 insert into session_state
 with not_active as (
@@ -151,14 +202,23 @@ select expiration_time, connection_limit, current_connection_count
 from  
 	session_connection_limit, session_connection_count;	
 `
+
+	// sessionsWithCurrentStates is a where clause of sessionList selecting the
+	// sessions whose current state is one of the states in the in clause.
+	sessionsWithCurrentStates = `
+public_id in (
+	select session_id
+	from session_state
+	where end_time is null and state in (%s)
+)`
+
 	sessionList = `
 select * 
 from
-	(select public_id from session %s) s,
+	(select public_id from session %s %s) s,
 	session_with_state ss
 where 
 	s.public_id = ss.public_id 
-	%s
 %s
 `
 
@@ -363,6 +423,78 @@ values
 
 	sessionCredentialDynamicBatchInsertReturning = `
   returning session_id, library_id, credential_id, credential_purpose
+`
+
+	rewrapSessionQuery = `
+update session
+   set tofu_token = @tofu_token,
+       key_id = @key_id
+ where public_id = @public_id;
+`
+
+	rewrapSessionCredentialQuery = `
+update session_credential
+   set credential = @credential,
+       key_id = @key_id
+ where session_id = @session_id
+   and credential_sha256 = @credential_sha256;
+`
+
+	// lockUserSessionsQuery locks the row of @user_id until the end of the
+	// transaction, so the sessions of a user are created one at a time and
+	// each is checked against the sessions created before it.
+	lockUserSessionsQuery = `
+select public_id
+  from iam_user
+ where public_id = @user_id
+   for update;
+`
+
+	// sessionPolicyUsageQuery returns a row for each policy which applies to
+	// the project @scope_id, with the number of active sessions of @user_id
+	// and the session seconds @user_id used during the last 24 hours in the
+	// scope of the policy. A session uses time from its creation until its
+	// termination, or until its expiration if it has not been terminated.
+	sessionPolicyUsageQuery = `
+with
+policies as (
+  select public_id,
+         scope_id,
+         coalesce(max_active_sessions_per_user, 0)       as max_active_sessions_per_user,
+         coalesce(max_daily_session_seconds_per_user, 0) as max_daily_session_seconds_per_user
+    from session_policy
+   where scope_id = @scope_id
+      or scope_id = (select parent_id from iam_scope where public_id = @scope_id)
+),
+user_sessions as (
+  select s.public_id,
+         s.scope_id,
+         sc.parent_id as org_id,
+         s.create_time,
+         least(coalesce(t.start_time, s.expiration_time), s.expiration_time) as end_time,
+         t.start_time is null and s.expiration_time > now() as active
+    from session s
+    join iam_scope sc
+      on sc.public_id = s.scope_id
+    left join session_state t
+      on t.session_id = s.public_id
+     and t.state = 'terminated'
+   where s.user_id = @user_id
+)
+  select p.public_id as policy_id,
+         p.scope_id,
+         p.max_active_sessions_per_user,
+         p.max_daily_session_seconds_per_user,
+         count(us.public_id) filter (where us.active) as active_sessions,
+         coalesce(
+           sum(extract(epoch from us.end_time - greatest(us.create_time, now() - interval '24 hours')))
+             filter (where us.end_time > now() - interval '24 hours'),
+           0)::bigint as daily_session_seconds
+    from policies p
+    left join user_sessions us
+      on p.scope_id in (us.scope_id, us.org_id)
+group by p.public_id, p.scope_id, p.max_active_sessions_per_user, p.max_daily_session_seconds_per_user
+order by p.public_id;
 `
 )
 
@@ -94,26 +94,33 @@ func (r *Repository) convertToSessions(ctx context.Context, sessionsWithState []
 			}
 			prevSessionId = sv.PublicId
 			workingSession = &Session{
-				PublicId:          sv.PublicId,
-				UserId:            sv.UserId,
-				HostId:            sv.HostId,
-				ServerId:          sv.ServerId,
-				ServerType:        sv.ServerType,
-				TargetId:          sv.TargetId,
-				HostSetId:         sv.HostSetId,
-				AuthTokenId:       sv.AuthTokenId,
-				ScopeId:           sv.ScopeId,
-				Certificate:       sv.Certificate,
-				ExpirationTime:    sv.ExpirationTime,
-				CtTofuToken:       sv.CtTofuToken,
-				TofuToken:         sv.TofuToken, // will always be nil since it's not stored in the database.
-				TerminationReason: sv.TerminationReason,
-				CreateTime:        sv.CreateTime,
-				UpdateTime:        sv.UpdateTime,
-				Version:           sv.Version,
-				Endpoint:          sv.Endpoint,
-				ConnectionLimit:   sv.ConnectionLimit,
-				KeyId:             sv.KeyId,
+				PublicId:               sv.PublicId,
+				UserId:                 sv.UserId,
+				HostId:                 sv.HostId,
+				ServerId:               sv.ServerId,
+				ServerType:             sv.ServerType,
+				TargetId:               sv.TargetId,
+				HostSetId:              sv.HostSetId,
+				AuthTokenId:            sv.AuthTokenId,
+				ScopeId:                sv.ScopeId,
+				Certificate:            sv.Certificate,
+				ExpirationTime:         sv.ExpirationTime,
+				CtTofuToken:            sv.CtTofuToken,
+				TofuToken:              sv.TofuToken, // will always be nil since it's not stored in the database.
+				TerminationReason:      sv.TerminationReason,
+				CreateTime:             sv.CreateTime,
+				UpdateTime:             sv.UpdateTime,
+				Version:                sv.Version,
+				Endpoint:               sv.Endpoint,
+				ConnectionLimit:        sv.ConnectionLimit,
+				RecordingEnabled:       sv.RecordingEnabled,
+				RecordingPath:          sv.RecordingPath,
+				UploadRateLimit:        sv.UploadRateLimit,
+				DownloadRateLimit:      sv.DownloadRateLimit,
+				EndpointHostKey:        sv.EndpointHostKey,
+				KeyId:                  sv.KeyId,
+				ApprovalExpirationTime: sv.ApprovalExpirationTime,
+				ApproverId:             sv.ApproverId,
 			}
 			if opts.withListingConvert {
 				workingSession.CtTofuToken = nil // CtTofuToken should not returned in lists
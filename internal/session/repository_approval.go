@@ -0,0 +1,194 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ApproveSession approves a session which is pending approval on behalf of the
+// user approverId, who must not be the user of the session. The session moves
+// to the pending state. Its user must retrieve its authorization, see
+// ClaimApprovedSession, before the approval expiration time of the session.
+func (r *Repository) ApproveSession(ctx context.Context, sessionId string, sessionVersion uint32, approverId string) (*Session, error) {
+	const op = "session.(Repository).ApproveSession"
+	s, err := r.decideSession(ctx, sessionId, sessionVersion, approverId, approvalApproved)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return s, nil
+}
+
+// DenySession denies a session which is pending approval on behalf of the user
+// approverId, who must not be the user of the session. The session is
+// terminated with the ApprovalDenied reason.
+func (r *Repository) DenySession(ctx context.Context, sessionId string, sessionVersion uint32, approverId string) (*Session, error) {
+	const op = "session.(Repository).DenySession"
+	s, err := r.decideSession(ctx, sessionId, sessionVersion, approverId, approvalDenied)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return s, nil
+}
+
+func (r *Repository) decideSession(ctx context.Context, sessionId string, sessionVersion uint32, approverId string, decision approvalDecision) (*Session, error) {
+	const op = "session.(Repository).decideSession"
+	if sessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if sessionVersion == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session version")
+	}
+	if approverId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing approver id")
+	}
+
+	s := AllocSession()
+	s.PublicId = sessionId
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			if err := reader.LookupById(ctx, &s); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", sessionId)))
+			}
+			if s.Version != sessionVersion {
+				return errors.New(ctx, errors.VersionMismatch, op, fmt.Sprintf("session %s version %d does not match %d", sessionId, s.Version, sessionVersion))
+			}
+			if s.UserId == approverId {
+				return errors.New(ctx, errors.SessionSelfApproval, op, fmt.Sprintf("session %s cannot be %s by its own user", sessionId, decision))
+			}
+			states, err := fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if len(states) == 0 || states[0].Status != StatusPendingApproval {
+				return errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("session %s is not pending approval", sessionId))
+			}
+			if !s.ApprovalExpirationTime.GetTimestamp().AsTime().After(time.Now()) {
+				return errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("approval of session %s has expired", sessionId))
+			}
+
+			a := &approval{
+				SessionId:  sessionId,
+				ApproverId: approverId,
+				Decision:   string(decision),
+			}
+			if err := w.Create(ctx, a); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
+			// A denial terminates the session, so it is read again.
+			if err := reader.LookupById(ctx, &s); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", sessionId)))
+			}
+			s.ApproverId = approverId
+			s.States, err = fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("session %s not found", sessionId))
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	s.CtTofuToken = nil
+	return &s, nil
+}
+
+// ClaimApprovedSession records that the user userId retrieved the
+// authorization of the approved session sessionId and returns the session. The
+// authorization of a session can only be retrieved once, by the user of the
+// session, and before its approval expiration time.
+func (r *Repository) ClaimApprovedSession(ctx context.Context, sessionId, userId string) (*Session, error) {
+	const op = "session.(Repository).ClaimApprovedSession"
+	if sessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if userId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+
+	s := AllocSession()
+	s.PublicId = sessionId
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rowsUpdated, err := w.Exec(ctx, claimApprovedSession, []interface{}{
+				sql.Named("session_id", sessionId),
+				sql.Named("user_id", userId),
+			})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("session %s is not approved or its authorization was already retrieved", sessionId))
+			}
+			if err := reader.LookupById(ctx, &s); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", sessionId)))
+			}
+			s.States, err = fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			var approvals []*approval
+			if err := reader.SearchWhere(ctx, &approvals, "session_id = ?", []interface{}{sessionId}); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if len(approvals) > 0 {
+				s.ApproverId = approvals[0].ApproverId
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	s.CtTofuToken = nil
+	return &s, nil
+}
+
+// ExpireSessionApprovals terminates, with the ApprovalExpired reason, the
+// sessions which were not approved, or whose authorization was not retrieved,
+// before their approval expiration time. It returns the IDs of the terminated
+// sessions.
+func (r *Repository) ExpireSessionApprovals(ctx context.Context) ([]string, error) {
+	const op = "session.(Repository).ExpireSessionApprovals"
+	var ids []string
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			ids = nil
+			rows, err := w.Query(ctx, expireSessionApprovals, nil)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var id string
+				if err := rows.Scan(&id); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				ids = append(ids, id)
+			}
+			return rows.Err()
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
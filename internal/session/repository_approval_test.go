@@ -0,0 +1,229 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testApprovalSession(t *testing.T, conn *db.DB, iamRepo *iam.Repository, approvalWindow time.Duration) *Session {
+	t.Helper()
+	wrapper := db.TestWrapper(t)
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	composedOf.ApprovalExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(approvalWindow))}
+	return TestSession(t, conn, wrapper, composedOf)
+}
+
+func TestRepository_CreateSessionPendingApproval(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	s := testApprovalSession(t, conn, iamRepo, time.Minute)
+	require.Len(t, s.States, 1)
+	assert.Equal(t, StatusPendingApproval, s.States[0].Status)
+}
+
+func TestRepository_ApproveSession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	approver := iam.TestUser(t, iamRepo, scope.Global.String())
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testApprovalSession(t, conn, iamRepo, time.Minute)
+		got, err := repo.ApproveSession(ctx, s.PublicId, s.Version, approver.PublicId)
+		require.NoError(err)
+		assert.Equal(approver.PublicId, got.ApproverId)
+		require.Len(got.States, 2)
+		assert.Equal(StatusPending, got.States[0].Status)
+
+		found, _, err := repo.LookupSession(ctx, s.PublicId)
+		require.NoError(err)
+		assert.Equal(approver.PublicId, found.ApproverId)
+
+		_, err = repo.ApproveSession(ctx, s.PublicId, s.Version, approver.PublicId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidSessionState), err))
+	})
+	t.Run("own-session", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testApprovalSession(t, conn, iamRepo, time.Minute)
+		_, err := repo.ApproveSession(ctx, s.PublicId, s.Version, s.UserId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.SessionSelfApproval), err))
+	})
+	t.Run("not-requiring-approval", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		_, err := repo.ApproveSession(ctx, s.PublicId, s.Version, approver.PublicId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidSessionState), err))
+	})
+	t.Run("expired", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testApprovalSession(t, conn, iamRepo, time.Second)
+		time.Sleep(2 * time.Second)
+		_, err := repo.ApproveSession(ctx, s.PublicId, s.Version, approver.PublicId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidSessionState), err))
+	})
+	t.Run("not-found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		id, err := newId()
+		require.NoError(err)
+		_, err = repo.ApproveSession(ctx, id, 1, approver.PublicId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+	t.Run("version-mismatch", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testApprovalSession(t, conn, iamRepo, time.Minute)
+		_, err := repo.ApproveSession(ctx, s.PublicId, s.Version+1, approver.PublicId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.VersionMismatch), err))
+	})
+	t.Run("missing-approver", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testApprovalSession(t, conn, iamRepo, time.Minute)
+		_, err := repo.ApproveSession(ctx, s.PublicId, s.Version, "")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestRepository_DenySession(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	approver := iam.TestUser(t, iamRepo, scope.Global.String())
+
+	s := testApprovalSession(t, conn, iamRepo, time.Minute)
+	got, err := repo.DenySession(ctx, s.PublicId, s.Version, approver.PublicId)
+	require.NoError(err)
+	assert.Equal(ApprovalDenied.String(), got.TerminationReason)
+	assert.Equal(StatusTerminated, got.States[0].Status)
+
+	_, err = repo.ClaimApprovedSession(ctx, s.PublicId, s.UserId)
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidSessionState), err))
+}
+
+func TestRepository_ClaimApprovedSession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	approver := iam.TestUser(t, iamRepo, scope.Global.String())
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testApprovalSession(t, conn, iamRepo, time.Minute)
+		_, err := repo.ApproveSession(ctx, s.PublicId, s.Version, approver.PublicId)
+		require.NoError(err)
+
+		got, err := repo.ClaimApprovedSession(ctx, s.PublicId, s.UserId)
+		require.NoError(err)
+		assert.Equal(s.PublicId, got.PublicId)
+		assert.Equal(approver.PublicId, got.ApproverId)
+		assert.Equal(StatusPending, got.States[0].Status)
+
+		// The authorization can only be retrieved once.
+		_, err = repo.ClaimApprovedSession(ctx, s.PublicId, s.UserId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidSessionState), err))
+	})
+	t.Run("not-approved", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testApprovalSession(t, conn, iamRepo, time.Minute)
+		_, err := repo.ClaimApprovedSession(ctx, s.PublicId, s.UserId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidSessionState), err))
+	})
+	t.Run("other-user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testApprovalSession(t, conn, iamRepo, time.Minute)
+		_, err := repo.ApproveSession(ctx, s.PublicId, s.Version, approver.PublicId)
+		require.NoError(err)
+		_, err = repo.ClaimApprovedSession(ctx, s.PublicId, approver.PublicId)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidSessionState), err))
+	})
+}
+
+func TestRepository_ExpireSessionApprovals(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	approver := iam.TestUser(t, iamRepo, scope.Global.String())
+
+	pending := testApprovalSession(t, conn, iamRepo, time.Second)
+	approved := testApprovalSession(t, conn, iamRepo, 2*time.Second)
+	_, err = repo.ApproveSession(ctx, approved.PublicId, approved.Version, approver.PublicId)
+	require.NoError(err)
+	claimed := testApprovalSession(t, conn, iamRepo, 2*time.Second)
+	_, err = repo.ApproveSession(ctx, claimed.PublicId, claimed.Version, approver.PublicId)
+	require.NoError(err)
+	_, err = repo.ClaimApprovedSession(ctx, claimed.PublicId, claimed.UserId)
+	require.NoError(err)
+	notExpired := testApprovalSession(t, conn, iamRepo, time.Minute)
+	noApproval := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	time.Sleep(3 * time.Second)
+	ids, err := repo.ExpireSessionApprovals(ctx)
+	require.NoError(err)
+	assert.ElementsMatch([]string{pending.PublicId, approved.PublicId}, ids)
+
+	for _, id := range []string{pending.PublicId, approved.PublicId} {
+		s, _, err := repo.LookupSession(ctx, id)
+		require.NoError(err)
+		assert.Equal(ApprovalExpired.String(), s.TerminationReason)
+		assert.Equal(StatusTerminated, s.States[0].Status)
+	}
+	for _, id := range []string{claimed.PublicId, notExpired.PublicId, noApproval.PublicId} {
+		s, _, err := repo.LookupSession(ctx, id)
+		require.NoError(err)
+		assert.Empty(s.TerminationReason)
+	}
+
+	ids, err = repo.ExpireSessionApprovals(ctx)
+	require.NoError(err)
+	assert.Empty(ids)
+}
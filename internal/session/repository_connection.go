@@ -67,6 +67,36 @@ func (r *Repository) ListConnectionsBySessionId(ctx context.Context, sessionId s
 	return connections, nil
 }
 
+// ListConnectionsBySessionIds will list the connections of the sessions with
+// the current status of each connection, keyed by connection ID. Supports the
+// WithLimit and WithOrderByCreateTime options.
+func (r *Repository) ListConnectionsBySessionIds(ctx context.Context, sessionIds []string, opt ...Option) ([]*Connection, map[string]ConnectionStatus, error) {
+	const op = "session.(Repository).ListConnectionsBySessionIds"
+	if len(sessionIds) == 0 {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "no session IDs supplied")
+	}
+	var connections []*Connection
+	if err := r.list(ctx, &connections, "session_id in (?)", []interface{}{sessionIds}, opt...); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	statuses := make(map[string]ConnectionStatus, len(connections))
+	if len(connections) == 0 {
+		return connections, statuses, nil
+	}
+	connectionIds := make([]string, 0, len(connections))
+	for _, c := range connections {
+		connectionIds = append(connectionIds, c.PublicId)
+	}
+	var states []*ConnectionState
+	if err := r.reader.SearchWhere(ctx, &states, "connection_id in (?) and end_time is null", []interface{}{connectionIds}, db.WithLimit(-1)); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	for _, s := range states {
+		statuses[s.ConnectionId] = s.Status
+	}
+	return connections, statuses, nil
+}
+
 // DeleteConnection will delete a connection from the repository.
 func (r *Repository) DeleteConnection(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "session.(Repository).DeleteConnection"
@@ -154,6 +184,47 @@ func (r *Repository) CloseDeadConnectionsForWorker(ctx context.Context, serverId
 	return rowsAffected, nil
 }
 
+// UpdateConnectionBytes updates the running byte counts of the open
+// connections which are reported by a worker. The counts of closed
+// connections are not updated, since they are set when the connection is
+// closed. The number of connections updated is returned.
+func (r *Repository) UpdateConnectionBytes(ctx context.Context, bytesWith []BytesWith, _ ...Option) (int, error) {
+	const op = "session.(Repository).UpdateConnectionBytes"
+	if len(bytesWith) == 0 {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing connections")
+	}
+	for _, bw := range bytesWith {
+		if err := bw.validate(); err != nil {
+			return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+	}
+	var rowsUpdated int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rowsUpdated = 0
+			for _, bw := range bytesWith {
+				n, err := w.Exec(ctx, updateConnectionBytes, []interface{}{
+					sql.Named("public_id", bw.ConnectionId),
+					sql.Named("bytes_up", bw.BytesUp),
+					sql.Named("bytes_down", bw.BytesDown),
+				})
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update bytes of connection %s", bw.ConnectionId)))
+				}
+				rowsUpdated += n
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return rowsUpdated, nil
+}
+
 type CloseConnectionsForDeadWorkersResult struct {
 	ServerId                string
 	LastUpdateTime          time.Time
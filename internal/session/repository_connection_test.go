@@ -129,6 +129,53 @@ func TestRepository_ListConnection(t *testing.T) {
 	})
 }
 
+func TestRepository_ListConnectionsBySessionIds(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	rw := db.New(conn)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	s1 := TestDefaultSession(t, conn, wrapper, iamRepo)
+	s2 := TestDefaultSession(t, conn, wrapper, iamRepo)
+	s3 := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	c1 := TestConnection(t, conn, s1.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+	c2 := TestConnection(t, conn, s1.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+	_ = TestConnectionState(t, conn, c2.PublicId, StatusClosed)
+	c3 := TestConnection(t, conn, s2.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+	_ = TestConnection(t, conn, s3.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, statuses, err := repo.ListConnectionsBySessionIds(context.Background(), []string{s1.PublicId, s2.PublicId}, WithOrderByCreateTime(db.AscendingOrderBy))
+		require.NoError(err)
+		require.Equal(3, len(got))
+		assert.Equal(c1.PublicId, got[0].PublicId)
+		assert.Equal(c2.PublicId, got[1].PublicId)
+		assert.Equal(c3.PublicId, got[2].PublicId)
+		assert.Equal(map[string]ConnectionStatus{
+			c1.PublicId: StatusConnected,
+			c2.PublicId: StatusClosed,
+			c3.PublicId: StatusConnected,
+		}, statuses)
+	})
+	t.Run("no-connections", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, statuses, err := repo.ListConnectionsBySessionIds(context.Background(), []string{"s_thisIsNotValid"})
+		require.NoError(err)
+		assert.Empty(got)
+		assert.Empty(statuses)
+	})
+	t.Run("missing-session-ids", func(t *testing.T) {
+		_, _, err := repo.ListConnectionsBySessionIds(context.Background(), nil)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
 func TestRepository_DeleteConnection(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -769,3 +816,91 @@ func testIsConnectionClosed(ctx context.Context, t *testing.T, repo *Repository,
 	// start time, descending.
 	return states[0].Status == StatusClosed
 }
+
+func TestRepository_UpdateConnectionBytes(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	session := TestDefaultSession(t, conn, wrapper, iamRepo)
+	open := TestConnection(t, conn, session.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+	closed := TestConnection(t, conn, session.PublicId, "127.0.0.1", 22, "127.0.0.1", 2223)
+	_, err = repo.CloseConnections(ctx, []CloseWith{{
+		ConnectionId: closed.PublicId,
+		BytesUp:      10,
+		BytesDown:    20,
+		ClosedReason: ConnectionClosedByUser,
+	}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		bytesWith     []BytesWith
+		wantUpdated   int
+		wantBytesUp   uint64
+		wantBytesDown uint64
+		wantErrMsg    string
+	}{
+		{
+			name:       "missing-connections",
+			wantErrMsg: "session.(Repository).UpdateConnectionBytes: missing connections: parameter violation: error #100",
+		},
+		{
+			name:       "missing-connection-id",
+			bytesWith:  []BytesWith{{BytesUp: 1}},
+			wantErrMsg: "session.(BytesWith).validate: missing connection id: parameter violation: error #100",
+		},
+		{
+			name:          "valid",
+			bytesWith:     []BytesWith{{ConnectionId: open.PublicId, BytesUp: 100, BytesDown: 200}},
+			wantUpdated:   1,
+			wantBytesUp:   100,
+			wantBytesDown: 200,
+		},
+		{
+			name:          "stale-counts-ignored",
+			bytesWith:     []BytesWith{{ConnectionId: open.PublicId, BytesUp: 50, BytesDown: 60}},
+			wantBytesUp:   100,
+			wantBytesDown: 200,
+		},
+		{
+			name: "closed-connection-ignored",
+			bytesWith: []BytesWith{
+				{ConnectionId: open.PublicId, BytesUp: 150, BytesDown: 250},
+				{ConnectionId: closed.PublicId, BytesUp: 1000, BytesDown: 2000},
+			},
+			wantUpdated:   1,
+			wantBytesUp:   150,
+			wantBytesDown: 250,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			updated, err := repo.UpdateConnectionBytes(ctx, tt.bytesWith)
+			if tt.wantErrMsg != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErrMsg)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantUpdated, updated)
+
+			found, _, err := repo.LookupConnection(ctx, open.PublicId)
+			require.NoError(err)
+			assert.Equal(tt.wantBytesUp, found.BytesUp)
+			assert.Equal(tt.wantBytesDown, found.BytesDown)
+
+			found, _, err = repo.LookupConnection(ctx, closed.PublicId)
+			require.NoError(err)
+			assert.Equal(uint64(10), found.BytesUp)
+			assert.Equal(uint64(20), found.BytesDown)
+		})
+	}
+}
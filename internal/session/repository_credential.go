@@ -0,0 +1,92 @@
+package session
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// AddSessionCredentials encrypts the credData and adds a credential
+// representation to the repository for the session. The credentials are
+// removed from the repository when the session is canceled or terminated.
+func (r *Repository) AddSessionCredentials(ctx context.Context, sessScopeId, sessionId string, credData []Credential, _ ...Option) error {
+	const op = "session.(Repository).AddSessionCredentials"
+	if sessionId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if sessScopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing session scope id")
+	}
+	if len(credData) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing credentials")
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, sessScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+
+	addCreds := make([]interface{}, 0, len(credData))
+	for _, cred := range credData {
+		if len(cred) == 0 {
+			return errors.New(ctx, errors.InvalidParameter, op, "missing credential")
+		}
+		c := newSessionCredential(sessionId, cred)
+		if err := c.encrypt(ctx, databaseWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		addCreds = append(addCreds, c)
+	}
+
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if err := w.CreateItems(ctx, addCreds); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// ListSessionCredentials returns all Credential data for the sessionId, in
+// decrypted form. An empty slice is returned when the session has no
+// credentials.
+func (r *Repository) ListSessionCredentials(ctx context.Context, sessScopeId, sessionId string, _ ...Option) ([]Credential, error) {
+	const op = "session.(Repository).ListSessionCredentials"
+	if sessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if sessScopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session scope id")
+	}
+
+	var creds []*sessionCredential
+	if err := r.reader.SearchWhere(ctx, &creds, "session_id = ?", []interface{}{sessionId}); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(creds) == 0 {
+		return nil, nil
+	}
+
+	ret := make([]Credential, 0, len(creds))
+	for _, c := range creds {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, sessScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(c.KeyId))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := c.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ret = append(ret, c.Credential)
+	}
+	return ret, nil
+}
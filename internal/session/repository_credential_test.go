@@ -0,0 +1,77 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SessionCredentials(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("invalid-parameters", func(t *testing.T) {
+		assert := assert.New(t)
+		err := repo.AddSessionCredentials(ctx, "", "s_1234567890", []Credential{[]byte("cred")})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.AddSessionCredentials(ctx, "p_1234567890", "", []Credential{[]byte("cred")})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.AddSessionCredentials(ctx, "p_1234567890", "s_1234567890", nil)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.AddSessionCredentials(ctx, "p_1234567890", "s_1234567890", []Credential{nil})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+		_, err = repo.ListSessionCredentials(ctx, "", "s_1234567890")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.ListSessionCredentials(ctx, "p_1234567890", "")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("add-list-cancel", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		session := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+		got, err := repo.ListSessionCredentials(ctx, session.ScopeId, session.PublicId)
+		require.NoError(err)
+		assert.Empty(got)
+
+		want := []Credential{[]byte("first credential"), []byte("second credential")}
+		require.NoError(repo.AddSessionCredentials(ctx, session.ScopeId, session.PublicId, want))
+
+		var stored []*sessionCredential
+		require.NoError(rw.SearchWhere(ctx, &stored, "session_id = ?", []interface{}{session.PublicId}))
+		require.Len(stored, 2)
+		for _, c := range stored {
+			assert.NotContains(want, Credential(c.CtCredential))
+		}
+
+		got, err = repo.ListSessionCredentials(ctx, session.ScopeId, session.PublicId)
+		require.NoError(err)
+		assert.ElementsMatch(want, got)
+
+		// Adding the same credential again should fail
+		err = repo.AddSessionCredentials(ctx, session.ScopeId, session.PublicId, want[:1])
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+
+		// Canceling the session removes its credentials
+		_, err = repo.CancelSession(ctx, session.PublicId, session.Version)
+		require.NoError(err)
+		got, err = repo.ListSessionCredentials(ctx, session.ScopeId, session.PublicId)
+		require.NoError(err)
+		assert.Empty(got)
+	})
+}
@@ -0,0 +1,358 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreatePolicy inserts p into the repository and returns a new Policy
+// containing the policy's PublicId. p is not changed. p must contain a valid
+// ScopeId of an org or a project. p must not contain a PublicId. The PublicId
+// is generated and assigned by this method. WithPublicId is the only option
+// supported.
+//
+// Both p.Name and p.Description are optional. If p.Name is set, it must be
+// unique within p.ScopeId.
+func (r *Repository) CreatePolicy(ctx context.Context, p *Policy, opt ...Option) (*Policy, error) {
+	const op = "session.(Repository).CreatePolicy"
+	if p == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Policy")
+	}
+	if p.Policy == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Policy")
+	}
+	if p.ScopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	if p.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	p = p.clone()
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, PolicyPrefix+"_") {
+			return nil, errors.New(ctx,
+				errors.InvalidPublicId,
+				op,
+				fmt.Sprintf("passed-in public ID %q has wrong prefix, should be %q", opts.withPublicId, PolicyPrefix),
+			)
+		}
+		p.PublicId = opts.withPublicId
+	} else {
+		id, err := newPolicyId()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		p.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, p.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newPolicyMetadata(p, oplog.OpType_OP_TYPE_CREATE)
+
+	var newPolicy *Policy
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newPolicy = p.clone()
+			if err := w.Create(ctx, newPolicy, db.WithOplog(oplogWrapper, metadata)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in scope: %s: name %s already exists", p.ScopeId, p.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in scope: %s", p.ScopeId)))
+	}
+	return newPolicy, nil
+}
+
+// UpdatePolicy updates the repository entry for p.PublicId with the values in
+// p for the fields listed in fieldMask. It returns a new Policy containing the
+// updated values and a count of the number of records updated. p is not
+// changed.
+//
+// p must contain a valid PublicId. Only p.Name, p.Description,
+// p.MaxActiveSessionsPerUser and p.MaxDailySessionSecondsPerUser can be
+// updated. If p.Name is set to a non-empty string, it must be unique within
+// p.ScopeId.
+//
+// An attribute of p will be set to NULL in the database if the attribute in p
+// is the zero value and it is included in fieldMask. A NULL limit means the
+// policy does not limit that aspect of the sessions.
+func (r *Repository) UpdatePolicy(ctx context.Context, p *Policy, version uint32, fieldMask []string, _ ...Option) (*Policy, int, error) {
+	const op = "session.(Repository).UpdatePolicy"
+	if p == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil Policy")
+	}
+	if p.Policy == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Policy")
+	}
+	if p.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	if p.ScopeId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	if len(fieldMask) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	var dbMask, nullFields []string
+	for _, f := range fieldMask {
+		switch {
+		case strings.EqualFold("name", f) && p.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && p.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && p.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && p.Description != "":
+			dbMask = append(dbMask, "description")
+		case strings.EqualFold("MaxActiveSessionsPerUser", f) && p.MaxActiveSessionsPerUser == 0:
+			nullFields = append(nullFields, "MaxActiveSessionsPerUser")
+		case strings.EqualFold("MaxActiveSessionsPerUser", f) && p.MaxActiveSessionsPerUser != 0:
+			dbMask = append(dbMask, "MaxActiveSessionsPerUser")
+		case strings.EqualFold("MaxDailySessionSecondsPerUser", f) && p.MaxDailySessionSecondsPerUser == 0:
+			nullFields = append(nullFields, "MaxDailySessionSecondsPerUser")
+		case strings.EqualFold("MaxDailySessionSecondsPerUser", f) && p.MaxDailySessionSecondsPerUser != 0:
+			dbMask = append(dbMask, "MaxDailySessionSecondsPerUser")
+
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, p.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	p = p.clone()
+
+	metadata := newPolicyMetadata(p, oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedPolicy *Policy
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedPolicy = p.clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedPolicy,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, metadata),
+				db.WithVersion(&version),
+			)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s: name %s already exists", p.PublicId, p.Name)))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", p.PublicId)))
+	}
+
+	return returnedPolicy, rowsUpdated, nil
+}
+
+// LookupPolicy returns the Policy for id. Returns nil, nil if no Policy is
+// found for id.
+func (r *Repository) LookupPolicy(ctx context.Context, id string, _ ...Option) (*Policy, error) {
+	const op = "session.(Repository).LookupPolicy"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	p := allocPolicy()
+	p.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, p); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", id)))
+	}
+	return p, nil
+}
+
+// ListPolicies returns a slice of Policies for the scope IDs. WithLimit and
+// WithStartPageAfterItem are the only options supported.
+func (r *Repository) ListPolicies(ctx context.Context, scopeIds []string, opt ...Option) ([]*Policy, error) {
+	const op = "session.(Repository).ListPolicies"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	dbOpts := []db.Option{db.WithLimit(limit)}
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
+	var policies []*Policy
+	if err := r.reader.SearchWhere(ctx, &policies, "scope_id in (?)", []interface{}{scopeIds}, dbOpts...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return policies, nil
+}
+
+// DeletePolicy deletes id from the repository returning a count of the
+// number of records deleted.
+func (r *Repository) DeletePolicy(ctx context.Context, id string, _ ...Option) (int, error) {
+	const op = "session.(Repository).DeletePolicy"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+
+	p := allocPolicy()
+	p.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, p); err != nil {
+		if errors.IsNotFoundError(err) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+	if p.ScopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, p.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newPolicyMetadata(p, oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deletePolicy := p.clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, deletePolicy, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", p.PublicId)))
+	}
+
+	return rowsDeleted, nil
+}
+
+// policyUsage is a policy which applies to a project along with the sessions
+// a user has in the scope of the policy.
+type policyUsage struct {
+	PolicyId                      string
+	ScopeId                       string
+	MaxActiveSessionsPerUser      int64
+	MaxDailySessionSecondsPerUser int64
+	ActiveSessions                int64
+	DailySessionSeconds           int64
+}
+
+// CheckPolicies checks whether userId can authorize a new session on a target
+// in the project projectId under the policies of the project and of its org.
+// It returns an error with the code errors.SessionLimitExceeded if userId
+// already has the maximum number of active sessions allowed by a policy, and
+// an error with the code errors.SessionTimeLimitExceeded if userId has used
+// all the daily session time allowed by a policy.
+//
+// Otherwise it returns the session time userId has left for the day under the
+// most restrictive policy, or zero if no policy limits the daily session time.
+//
+// CheckPolicies does not reserve anything for userId: CreateSession checks the
+// policies again in the transaction inserting the session.
+func (r *Repository) CheckPolicies(ctx context.Context, userId, projectId string) (time.Duration, error) {
+	const op = "session.(Repository).CheckPolicies"
+	if userId == "" {
+		return 0, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	if projectId == "" {
+		return 0, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	remaining, err := checkPolicies(ctx, r.reader, userId, projectId)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	return remaining, nil
+}
+
+// checkPolicies implements CheckPolicies using reader, which is the reader of
+// a transaction when the policies are enforced by CreateSession.
+func checkPolicies(ctx context.Context, reader db.Reader, userId, projectId string) (time.Duration, error) {
+	const op = "session.checkPolicies"
+	rows, err := reader.Query(ctx, sessionPolicyUsageQuery, []interface{}{
+		sql.Named("scope_id", projectId),
+		sql.Named("user_id", userId),
+	})
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var remaining time.Duration
+	for rows.Next() {
+		var u policyUsage
+		if err := reader.ScanRows(rows, &u); err != nil {
+			return 0, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		if u.MaxActiveSessionsPerUser > 0 && u.ActiveSessions >= u.MaxActiveSessionsPerUser {
+			return 0, errors.New(ctx, errors.SessionLimitExceeded, op,
+				fmt.Sprintf("session policy %s allows %d active sessions per user", u.PolicyId, u.MaxActiveSessionsPerUser))
+		}
+		if u.MaxDailySessionSecondsPerUser > 0 {
+			left := u.MaxDailySessionSecondsPerUser - u.DailySessionSeconds
+			if left <= 0 {
+				return 0, errors.New(ctx, errors.SessionTimeLimitExceeded, op,
+					fmt.Sprintf("session policy %s allows %d session seconds per user per day", u.PolicyId, u.MaxDailySessionSecondsPerUser))
+			}
+			if d := time.Duration(left) * time.Second; remaining == 0 || d < remaining {
+				remaining = d
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	return remaining, nil
+}
@@ -0,0 +1,378 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/session/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRepository_CreatePolicy(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	tests := []struct {
+		name      string
+		in        *Policy
+		opts      []Option
+		want      *Policy
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "nil-policy",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil-embedded-policy",
+			in:        &Policy{},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "no-scope",
+			in:        &Policy{Policy: &store.Policy{}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "public-id-set",
+			in:        &Policy{Policy: &store.Policy{ScopeId: org.PublicId, PublicId: "sp_1234567890"}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "global-scope",
+			in:        &Policy{Policy: &store.Policy{ScopeId: "global"}},
+			wantIsErr: errors.Exception,
+		},
+		{
+			name: "valid-org",
+			in: &Policy{Policy: &store.Policy{
+				ScopeId:                  org.PublicId,
+				Name:                     "org-policy",
+				Description:              "org policy",
+				MaxActiveSessionsPerUser: 2,
+			}},
+			want: &Policy{Policy: &store.Policy{
+				ScopeId:                  org.PublicId,
+				Name:                     "org-policy",
+				Description:              "org policy",
+				MaxActiveSessionsPerUser: 2,
+			}},
+		},
+		{
+			name: "valid-project",
+			in: &Policy{Policy: &store.Policy{
+				ScopeId:                       proj.PublicId,
+				MaxDailySessionSecondsPerUser: 3600,
+			}},
+			want: &Policy{Policy: &store.Policy{
+				ScopeId:                       proj.PublicId,
+				MaxDailySessionSecondsPerUser: 3600,
+			}},
+		},
+		{
+			name: "valid-with-public-id",
+			in:   &Policy{Policy: &store.Policy{ScopeId: proj.PublicId}},
+			opts: []Option{WithPublicId("sp_1234567890")},
+			want: &Policy{Policy: &store.Policy{ScopeId: proj.PublicId, PublicId: "sp_1234567890"}},
+		},
+		{
+			name:      "invalid-public-id-prefix",
+			in:        &Policy{Policy: &store.Policy{ScopeId: proj.PublicId}},
+			opts:      []Option{WithPublicId("s_1234567890")},
+			wantIsErr: errors.InvalidPublicId,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreatePolicy(context.Background(), tt.in, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.NotSame(tt.in, got)
+			assert.True(len(got.PublicId) > len(PolicyPrefix))
+			if tt.want.PublicId != "" {
+				assert.Equal(tt.want.PublicId, got.PublicId)
+			}
+			assert.Equal(tt.want.ScopeId, got.ScopeId)
+			assert.Equal(tt.want.Name, got.Name)
+			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(tt.want.MaxActiveSessionsPerUser, got.MaxActiveSessionsPerUser)
+			assert.Equal(tt.want.MaxDailySessionSecondsPerUser, got.MaxDailySessionSecondsPerUser)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+
+	t.Run("duplicate-name", func(t *testing.T) {
+		assert := assert.New(t)
+		in := &Policy{Policy: &store.Policy{ScopeId: proj.PublicId, Name: "dup"}}
+		got, err := repo.CreatePolicy(context.Background(), in)
+		assert.NoError(err)
+		assert.NotNil(got)
+		got, err = repo.CreatePolicy(context.Background(), in)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err: %q got: %q", errors.NotUnique, err)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_UpdatePolicy(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	tests := []struct {
+		name      string
+		orig      []Option
+		update    *store.Policy
+		mask      []string
+		want      *store.Policy
+		wantCount int
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "empty-mask",
+			update:    &store.Policy{},
+			wantIsErr: errors.EmptyFieldMask,
+		},
+		{
+			name:      "invalid-mask",
+			update:    &store.Policy{},
+			mask:      []string{"ScopeId"},
+			wantIsErr: errors.InvalidFieldMask,
+		},
+		{
+			name:      "change-name",
+			orig:      []Option{WithName("orig")},
+			update:    &store.Policy{Name: "updated"},
+			mask:      []string{"Name"},
+			want:      &store.Policy{Name: "updated"},
+			wantCount: 1,
+		},
+		{
+			name:      "set-limits",
+			update:    &store.Policy{MaxActiveSessionsPerUser: 3, MaxDailySessionSecondsPerUser: 60},
+			mask:      []string{"MaxActiveSessionsPerUser", "MaxDailySessionSecondsPerUser"},
+			want:      &store.Policy{MaxActiveSessionsPerUser: 3, MaxDailySessionSecondsPerUser: 60},
+			wantCount: 1,
+		},
+		{
+			name:      "clear-limits",
+			orig:      []Option{WithDescription("desc"), WithMaxActiveSessionsPerUser(3), WithMaxDailySessionSecondsPerUser(60)},
+			update:    &store.Policy{Description: "desc"},
+			mask:      []string{"MaxActiveSessionsPerUser", "MaxDailySessionSecondsPerUser"},
+			want:      &store.Policy{Description: "desc"},
+			wantCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := TestPolicy(t, conn, proj.PublicId, tt.orig...)
+			in := orig.clone()
+			in.Name = tt.update.Name
+			in.Description = tt.update.Description
+			in.MaxActiveSessionsPerUser = tt.update.MaxActiveSessionsPerUser
+			in.MaxDailySessionSecondsPerUser = tt.update.MaxDailySessionSecondsPerUser
+			got, gotCount, err := repo.UpdatePolicy(context.Background(), in, orig.Version, tt.mask)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantCount, gotCount)
+			require.NotNil(got)
+			assert.Equal(tt.want.Name, got.Name)
+			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(tt.want.MaxActiveSessionsPerUser, got.MaxActiveSessionsPerUser)
+			assert.Equal(tt.want.MaxDailySessionSecondsPerUser, got.MaxDailySessionSecondsPerUser)
+
+			found, err := repo.LookupPolicy(context.Background(), orig.PublicId)
+			require.NoError(err)
+			assert.Equal(got.MaxActiveSessionsPerUser, found.MaxActiveSessionsPerUser)
+			assert.Equal(got.MaxDailySessionSecondsPerUser, found.MaxDailySessionSecondsPerUser)
+			assert.Equal(orig.Version+1, found.Version)
+		})
+	}
+}
+
+func TestRepository_LookupListDeletePolicy(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	orgPolicy := TestPolicy(t, conn, org.PublicId)
+	projPolicy := TestPolicy(t, conn, proj.PublicId)
+
+	got, err := repo.LookupPolicy(ctx, orgPolicy.PublicId)
+	require.NoError(err)
+	assert.Equal(orgPolicy.PublicId, got.PublicId)
+
+	got, err = repo.LookupPolicy(ctx, "sp_unknown")
+	require.NoError(err)
+	assert.Nil(got)
+
+	_, err = repo.ListPolicies(ctx, nil)
+	assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+	list, err := repo.ListPolicies(ctx, []string{proj.PublicId})
+	require.NoError(err)
+	require.Len(list, 1)
+	assert.Equal(projPolicy.PublicId, list[0].PublicId)
+
+	list, err = repo.ListPolicies(ctx, []string{org.PublicId, proj.PublicId})
+	require.NoError(err)
+	assert.Len(list, 2)
+
+	n, err := repo.DeletePolicy(ctx, projPolicy.PublicId)
+	require.NoError(err)
+	assert.Equal(1, n)
+	n, err = repo.DeletePolicy(ctx, projPolicy.PublicId)
+	require.NoError(err)
+	assert.Equal(0, n)
+	assert.NoError(db.TestVerifyOplog(t, rw, projPolicy.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second)))
+}
+
+func TestRepository_CheckPolicies(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	newSession := func(t *testing.T, c ComposedOf, d time.Duration) *Session {
+		c.ExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(d))}
+		return TestSession(t, conn, wrapper, c)
+	}
+
+	t.Run("invalid-parameters", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.CheckPolicies(ctx, "", "p_1234567890")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+		_, err = repo.CheckPolicies(ctx, "u_1234567890", "")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+	})
+
+	t.Run("no-policies", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := TestSessionParams(t, conn, wrapper, iamRepo)
+		newSession(t, c, time.Hour)
+		remaining, err := repo.CheckPolicies(ctx, c.UserId, c.ScopeId)
+		require.NoError(err)
+		assert.Zero(remaining)
+	})
+
+	t.Run("active-sessions", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := TestSessionParams(t, conn, wrapper, iamRepo)
+		proj, err := iamRepo.LookupScope(ctx, c.ScopeId)
+		require.NoError(err)
+		TestPolicy(t, conn, proj.ParentId, WithMaxActiveSessionsPerUser(2))
+
+		s := newSession(t, c, time.Hour)
+		_, err = repo.CheckPolicies(ctx, c.UserId, c.ScopeId)
+		require.NoError(err)
+
+		newSession(t, c, time.Hour)
+		_, err = repo.CheckPolicies(ctx, c.UserId, c.ScopeId)
+		assert.Truef(errors.Match(errors.T(errors.SessionLimitExceeded), err), "want err: %q got: %q", errors.SessionLimitExceeded, err)
+
+		// Terminated sessions are no longer active.
+		_, err = repo.TerminateSession(ctx, s.PublicId, s.Version, ClosedByUser)
+		require.NoError(err)
+		_, err = repo.CheckPolicies(ctx, c.UserId, c.ScopeId)
+		assert.NoError(err)
+	})
+
+	t.Run("create-session-enforces-active-sessions", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := TestSessionParams(t, conn, wrapper, iamRepo)
+		c.ExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(time.Hour))}
+		TestPolicy(t, conn, c.ScopeId, WithMaxActiveSessionsPerUser(1))
+
+		// The sessions are created concurrently, all after the same check
+		// of the policies, as when the user authorizes several sessions at
+		// once. Only one of them can be created.
+		_, err := repo.CheckPolicies(ctx, c.UserId, c.ScopeId)
+		require.NoError(err)
+		const attempts = 5
+		errs := make(chan error, attempts)
+		for i := 0; i < attempts; i++ {
+			go func() {
+				s, err := New(c)
+				if err != nil {
+					errs <- err
+					return
+				}
+				_, _, err = repo.CreateSession(ctx, wrapper, s)
+				errs <- err
+			}()
+		}
+		var created int
+		for i := 0; i < attempts; i++ {
+			err := <-errs
+			if err == nil {
+				created++
+				continue
+			}
+			assert.Truef(errors.Match(errors.T(errors.SessionLimitExceeded), err), "want err: %q got: %q", errors.SessionLimitExceeded, err)
+		}
+		assert.Equal(1, created)
+	})
+
+	t.Run("daily-session-time", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := TestSessionParams(t, conn, wrapper, iamRepo)
+		TestPolicy(t, conn, c.ScopeId, WithMaxDailySessionSecondsPerUser(2*60*60))
+		TestPolicy(t, conn, c.ScopeId, WithMaxDailySessionSecondsPerUser(10*60*60))
+
+		newSession(t, c, time.Hour)
+		remaining, err := repo.CheckPolicies(ctx, c.UserId, c.ScopeId)
+		require.NoError(err)
+		assert.InDelta(time.Hour.Seconds(), remaining.Seconds(), 60)
+
+		newSession(t, c, time.Hour)
+		_, err = repo.CheckPolicies(ctx, c.UserId, c.ScopeId)
+		assert.Truef(errors.Match(errors.T(errors.SessionTimeLimitExceeded), err), "want err: %q got: %q", errors.SessionTimeLimitExceeded, err)
+	})
+}
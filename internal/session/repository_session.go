@@ -21,6 +21,10 @@ import (
 // its State of "Pending".  The following fields must be empty when creating a
 // session: ServerId, ServerType, and PublicId.  No options are
 // currently supported.
+//
+// The session policies of the session's project and org are enforced in the
+// transaction inserting the session; see CheckPolicies for the errors returned
+// when a limit has been reached.
 func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.Wrapper, newSession *Session, _ ...Option) (*Session, ed25519.PrivateKey, error) {
 	const op = "session.(Repository).CreateSession"
 	if newSession == nil {
@@ -65,6 +69,9 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 	if newSession.ExpirationTime == nil || newSession.ExpirationTime.Timestamp.AsTime().IsZero() {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing expiration time")
 	}
+	if newSession.ApprovalExpirationTime != nil && newSession.ApprovalExpirationTime.Timestamp.AsTime().After(newSession.ExpirationTime.Timestamp.AsTime()) {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "approval expiration time is after expiration time")
+	}
 
 	id, err := newId()
 	if err != nil {
@@ -85,6 +92,15 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
+			// The session policies are enforced while the user's row is
+			// locked so concurrent requests cannot exceed their limits.
+			if _, err := w.Exec(ctx, lockUserSessionsQuery, []interface{}{sql.Named("user_id", newSession.UserId)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lock user"))
+			}
+			if _, err := checkPolicies(ctx, read, newSession.UserId, newSession.ScopeId); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
 			returnedSession = newSession.Clone().(*Session)
 			returnedSession.DynamicCredentials = nil
 			if err = w.Create(ctx, returnedSession); err != nil {
@@ -111,7 +127,8 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 			}
 
 			var foundStates []*State
-			// trigger will create new "Pending" state
+			// trigger will create new "Pending" state, or "Pending Approval"
+			// state if the session requires approval
 			if foundStates, err = fetchStates(ctx, read, returnedSession.PublicId); err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
@@ -122,7 +139,11 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 				return errors.New(ctx, errors.SessionNotFound, op, fmt.Sprintf("no states found for new session %s", returnedSession.PublicId))
 			}
 			returnedSession.States = foundStates
-			if returnedSession.States[0].Status != StatusPending {
+			wantStatus := StatusPending
+			if newSession.ApprovalExpirationTime != nil {
+				wantStatus = StatusPendingApproval
+			}
+			if returnedSession.States[0].Status != wantStatus {
 				return errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("new session %s state is not valid: %s", returnedSession.PublicId, returnedSession.States[0].Status))
 			}
 			return nil
@@ -166,6 +187,16 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, _ ...O
 			if len(creds) > 0 {
 				session.DynamicCredentials = creds
 			}
+
+			if session.ApprovalExpirationTime != nil {
+				var approvals []*approval
+				if err := read.SearchWhere(ctx, &approvals, "session_id = ?", []interface{}{sessionId}); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if len(approvals) > 0 {
+					session.ApproverId = approvals[0].ApproverId
+				}
+			}
 			return nil
 		},
 	)
@@ -195,7 +226,8 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, _ ...O
 	return &session, authzSummary, nil
 }
 
-// ListSessions will sessions.  Supports the WithLimit, WithScopeId, WithSessionIds, and WithServerId options.
+// ListSessions will sessions.  Supports the WithLimit, WithScopeId, WithSessionIds,
+// WithServerId, WithUserId, WithStatuses and WithStartPageAfterItem options.
 func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Session, error) {
 	const op = "session.(Repository).ListSessions"
 	opts := getOpts(opt...)
@@ -227,12 +259,30 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 			inClauseCnt += 1
 			idsInClause, args = append(idsInClause, fmt.Sprintf("@%d", inClauseCnt)), append(args, sql.Named(fmt.Sprintf("%d", inClauseCnt), id))
 		}
-		where = append(where, fmt.Sprintf("s.public_id in (%s)", strings.Join(idsInClause, ",")))
+		where = append(where, fmt.Sprintf("public_id in (%s)", strings.Join(idsInClause, ",")))
 	}
 	if opts.withServerId != "" {
 		inClauseCnt += 1
 		where, args = append(where, fmt.Sprintf("server_id = @%d", inClauseCnt)), append(args, sql.Named(fmt.Sprintf("%d", inClauseCnt), opts.withServerId))
 	}
+	if len(opts.withStatuses) > 0 {
+		statesInClause := make([]string, 0, len(opts.withStatuses))
+		for _, s := range opts.withStatuses {
+			inClauseCnt += 1
+			statesInClause, args = append(statesInClause, fmt.Sprintf("@%d", inClauseCnt)), append(args, sql.Named(fmt.Sprintf("%d", inClauseCnt), s.String()))
+		}
+		where = append(where, fmt.Sprintf(sessionsWithCurrentStates, strings.Join(statesInClause, ",")))
+	}
+
+	if opts.withStartPageAfterItem != nil {
+		if opts.withOrderByCreateTime != db.UnknownOrderBy {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "cannot order by create time when paging")
+		}
+		if *opts.withStartPageAfterItem != "" {
+			inClauseCnt += 1
+			where, args = append(where, fmt.Sprintf("public_id > @%d", inClauseCnt)), append(args, sql.Named(fmt.Sprintf("%d", inClauseCnt), *opts.withStartPageAfterItem))
+		}
+	}
 
 	var limit string
 	switch {
@@ -245,19 +295,26 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 	}
 
 	var withOrder string
-	switch opts.withOrderByCreateTime {
-	case db.AscendingOrderBy:
+	switch {
+	case opts.withStartPageAfterItem != nil:
+		// The sessions of a page are selected in order and the order must be
+		// kept when joining their states.
+		limit = "order by public_id " + limit
+		withOrder = "order by public_id"
+	case opts.withOrderByCreateTime == db.AscendingOrderBy:
 		withOrder = "order by create_time asc"
-	case db.DescendingOrderBy:
+	case opts.withOrderByCreateTime == db.DescendingOrderBy:
 		withOrder = "order by create_time"
 	}
 
+	// The filters are applied before the sessions are limited, otherwise the
+	// limit is applied to sessions which are not part of the result.
 	var whereClause string
 	if len(where) > 0 {
-		whereClause = " and " + strings.Join(where, " and ")
+		whereClause = "where " + strings.Join(where, " and ")
 	}
 	q := sessionList
-	query := fmt.Sprintf(q, limit, whereClause, withOrder)
+	query := fmt.Sprintf(q, whereClause, limit, withOrder)
 
 	rows, err := r.reader.Query(ctx, query, args)
 	if err != nil {
@@ -627,7 +684,10 @@ func (r *Repository) CloseConnections(ctx context.Context, closeWith []CloseWith
 // activated. States are ordered by start time descending. Returns an
 // InvalidSessionState error code if a connection cannot be made because the session
 // was canceled or terminated.
-func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sessionVersion uint32, serverId, serverType string, tofuToken []byte) (*Session, []*State, error) {
+//
+// The WithRecordingPath option is required if recording is enabled for the
+// session, and is otherwise ignored.
+func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sessionVersion uint32, serverId, serverType string, tofuToken []byte, opt ...Option) (*Session, []*State, error) {
 	const op = "session.(Repository).ActivateSession"
 	if sessionId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
@@ -645,6 +705,8 @@ func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sess
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing tofu token")
 	}
 
+	opts := getOpts(opt...)
+
 	updatedSession := AllocSession()
 	updatedSession.PublicId = sessionId
 	var returnedStates []*State
@@ -676,13 +738,22 @@ func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sess
 				return errors.New(ctx, errors.TokenMismatch, op, "tofu token mismatch")
 			}
 
+			fieldMask := []string{"CtTofuToken"}
+			if foundSession.RecordingEnabled {
+				if opts.withRecordingPath == "" {
+					return errors.New(ctx, errors.InvalidParameter, op, "missing recording path for a session with recording enabled")
+				}
+				updatedSession.RecordingPath = opts.withRecordingPath
+				fieldMask = append(fieldMask, "RecordingPath")
+			}
+
 			updatedSession.TofuToken = tofuToken
 			updatedSession.ServerId = serverId
 			updatedSession.ServerType = serverType
 			if err := updatedSession.encrypt(ctx, databaseWrapper); err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
-			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"CtTofuToken"}, nil)
+			rowsUpdated, err := w.Update(ctx, &updatedSession, fieldMask, nil)
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
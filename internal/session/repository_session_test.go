@@ -217,6 +217,26 @@ func TestRepository_ListSession(t *testing.T) {
 			}
 		}
 	})
+	t.Run("withStatuses", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(conn.Where("1=1").Delete(AllocSession()).Error)
+		pending := TestSession(t, conn, wrapper, composedOf)
+		active := TestSession(t, conn, wrapper, composedOf)
+		_ = TestState(t, conn, active.PublicId, StatusActive)
+		terminated := TestSession(t, conn, wrapper, composedOf)
+		_ = TestState(t, conn, terminated.PublicId, StatusTerminated)
+
+		got, err := repo.ListSessions(context.Background(), WithStatuses(StatusPending, StatusActive), WithOrderByCreateTime(db.AscendingOrderBy))
+		require.NoError(err)
+		require.Equal(2, len(got))
+		assert.Equal(pending.PublicId, got[0].PublicId)
+		assert.Equal(active.PublicId, got[1].PublicId)
+
+		got, err = repo.ListSessions(context.Background(), WithStatuses(StatusTerminated))
+		require.NoError(err)
+		require.Equal(1, len(got))
+		assert.Equal(terminated.PublicId, got[0].PublicId)
+	})
 }
 
 func TestRepository_ListSessions_Multiple_Scopes(t *testing.T) {
@@ -1657,7 +1677,7 @@ func testSessionCredentialParams(t *testing.T, conn *db.DB, wrapper wrapping.Wra
 	tcpTarget, _, _, err := targetRepo.LookupTarget(ctx, params.TargetId)
 	require.NoError(err)
 	require.NotNil(tcpTarget)
-	_, _, _, err = targetRepo.AddTargetCredentialSources(ctx, tcpTarget.GetPublicId(), tcpTarget.GetVersion(), []string{libs[0].PublicId, libs[1].PublicId})
+	_, _, _, err = targetRepo.AddTargetCredentialSources(ctx, tcpTarget.GetPublicId(), tcpTarget.GetVersion(), target.CredentialSources{ApplicationCredentialIds: []string{libs[0].PublicId, libs[1].PublicId}})
 	require.NoError(err)
 	creds := []*DynamicCredential{
 		NewDynamicCredential(libs[0].GetPublicId(), credential.ApplicationPurpose),
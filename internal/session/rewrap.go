@@ -0,0 +1,106 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	if err := kms.RegisterTableRewrapFn(defaultSessionTableName, rewrapSessions); err != nil {
+		panic(err)
+	}
+	if err := kms.RegisterTableRewrapFn("session_credential", rewrapSessionCredentials); err != nil {
+		panic(err)
+	}
+}
+
+// rewrapWrappers returns the database wrapper for the key version
+// dataKeyVersionId and the current database wrapper of scopeId.
+func rewrapWrappers(ctx context.Context, dataKeyVersionId, scopeId string, kmsCache *kms.Kms) (wrapping.Wrapper, wrapping.Wrapper, error) {
+	const op = "session.rewrapWrappers"
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for key version"))
+	}
+	wrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	return oldWrapper, wrapper, nil
+}
+
+// rewrapSessions encrypts the tofu tokens of the sessions encrypted by the
+// database key version dataKeyVersionId with the current database key version
+// of scopeId.
+func rewrapSessions(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "session.rewrapSessions"
+	var sessions []*Session
+	if err := reader.SearchWhere(ctx, &sessions, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+	oldWrapper, wrapper, err := rewrapWrappers(ctx, dataKeyVersionId, scopeId, kmsCache)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, s := range sessions {
+		if s.CtTofuToken != nil {
+			if err := s.decrypt(ctx, oldWrapper); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if err := s.encrypt(ctx, wrapper); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+		}
+		if _, err := writer.Exec(ctx, rewrapSessionQuery, []interface{}{
+			sql.Named("tofu_token", s.CtTofuToken),
+			sql.Named("key_id", wrapper.KeyID()),
+			sql.Named("public_id", s.PublicId),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update session"))
+		}
+	}
+	return nil
+}
+
+// rewrapSessionCredentials encrypts the session credentials encrypted by the
+// database key version dataKeyVersionId with the current database key version
+// of scopeId.
+func rewrapSessionCredentials(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "session.rewrapSessionCredentials"
+	var creds []*sessionCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if len(creds) == 0 {
+		return nil
+	}
+	oldWrapper, wrapper, err := rewrapWrappers(ctx, dataKeyVersionId, scopeId, kmsCache)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, c := range creds {
+		if err := c.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if _, err := writer.Exec(ctx, rewrapSessionCredentialQuery, []interface{}{
+			sql.Named("credential", c.CtCredential),
+			sql.Named("key_id", c.KeyId),
+			sql.Named("session_id", c.SessionId),
+			sql.Named("credential_sha256", c.CredentialSha256),
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update session credential"))
+		}
+	}
+	return nil
+}
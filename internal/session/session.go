@@ -52,6 +52,21 @@ type ComposedOf struct {
 	// DynamicCredentials are dynamic credentials that will be retrieved
 	// for the session. DynamicCredentials optional.
 	DynamicCredentials []*DynamicCredential
+	// RecordingEnabled requires the worker to record the connections of the
+	// session.
+	RecordingEnabled bool
+	// UploadRateLimit is the max rate, in bytes per second, at which the
+	// worker sends data from the client to the endpoint. 0 means unlimited.
+	UploadRateLimit uint32
+	// DownloadRateLimit is the max rate, in bytes per second, at which the
+	// worker sends data from the endpoint to the client. 0 means unlimited.
+	DownloadRateLimit uint32
+	// ApprovalExpirationTime is set when the session requires approval. The
+	// session must be approved and its authorization retrieved before then.
+	ApprovalExpirationTime *timestamp.Timestamp
+	// EndpointHostKey is the public key, in the authorized_keys format, which
+	// the endpoint must present as its ssh host key.
+	EndpointHostKey string
 }
 
 // Session contains information about a user's session with a target
@@ -98,6 +113,28 @@ type Session struct {
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
 	// Worker filter
 	WorkerFilter string `json:"-" gorm:"default:null"`
+	// RecordingEnabled requires the worker to record the connections of the
+	// session
+	RecordingEnabled bool `json:"recording_enabled,omitempty" gorm:"default:null"`
+	// RecordingPath is the path of the directory on the worker which the
+	// connections are recorded to. It is set when the session is activated.
+	RecordingPath string `json:"recording_path,omitempty" gorm:"default:null"`
+	// UploadRateLimit is the max rate, in bytes per second, at which the
+	// worker sends data from the client to the endpoint
+	UploadRateLimit uint32 `json:"upload_rate_limit,omitempty" gorm:"default:null"`
+	// DownloadRateLimit is the max rate, in bytes per second, at which the
+	// worker sends data from the endpoint to the client
+	DownloadRateLimit uint32 `json:"download_rate_limit,omitempty" gorm:"default:null"`
+	// ApprovalExpirationTime is set when the session requires approval. The
+	// session must be approved and its authorization retrieved before then.
+	ApprovalExpirationTime *timestamp.Timestamp `json:"approval_expiration_time,omitempty" gorm:"default:null"`
+	// EndpointHostKey is the public key, in the authorized_keys format, which
+	// the endpoint must present as its ssh host key. It is only set for the
+	// sessions of ssh targets.
+	EndpointHostKey string `json:"endpoint_host_key,omitempty" gorm:"default:null"`
+	// ApproverId is the user who approved or denied the session. It is read
+	// from the session's approval and ignored during write operations.
+	ApproverId string `json:"approver_id,omitempty" gorm:"-"`
 
 	// key_id is the key ID that was used for the encryption operation. It can be
 	// used to identify a specific version of the key needed to decrypt the value,
@@ -128,17 +165,22 @@ var (
 func New(c ComposedOf, _ ...Option) (*Session, error) {
 	const op = "session.New"
 	s := Session{
-		UserId:             c.UserId,
-		HostId:             c.HostId,
-		TargetId:           c.TargetId,
-		HostSetId:          c.HostSetId,
-		AuthTokenId:        c.AuthTokenId,
-		ScopeId:            c.ScopeId,
-		Endpoint:           c.Endpoint,
-		ExpirationTime:     c.ExpirationTime,
-		ConnectionLimit:    c.ConnectionLimit,
-		WorkerFilter:       c.WorkerFilter,
-		DynamicCredentials: c.DynamicCredentials,
+		UserId:                 c.UserId,
+		HostId:                 c.HostId,
+		TargetId:               c.TargetId,
+		HostSetId:              c.HostSetId,
+		AuthTokenId:            c.AuthTokenId,
+		ScopeId:                c.ScopeId,
+		Endpoint:               c.Endpoint,
+		ExpirationTime:         c.ExpirationTime,
+		ConnectionLimit:        c.ConnectionLimit,
+		WorkerFilter:           c.WorkerFilter,
+		DynamicCredentials:     c.DynamicCredentials,
+		RecordingEnabled:       c.RecordingEnabled,
+		UploadRateLimit:        c.UploadRateLimit,
+		DownloadRateLimit:      c.DownloadRateLimit,
+		ApprovalExpirationTime: c.ApprovalExpirationTime,
+		EndpointHostKey:        c.EndpointHostKey,
 	}
 	if err := s.validateNewSession(); err != nil {
 		return nil, errors.WrapDeprecated(err, op)
@@ -168,6 +210,12 @@ func (s *Session) Clone() interface{} {
 		Endpoint:          s.Endpoint,
 		ConnectionLimit:   s.ConnectionLimit,
 		WorkerFilter:      s.WorkerFilter,
+		RecordingEnabled:  s.RecordingEnabled,
+		RecordingPath:     s.RecordingPath,
+		UploadRateLimit:   s.UploadRateLimit,
+		DownloadRateLimit: s.DownloadRateLimit,
+		EndpointHostKey:   s.EndpointHostKey,
+		ApproverId:        s.ApproverId,
 		KeyId:             s.KeyId,
 	}
 	if len(s.States) > 0 {
@@ -204,6 +252,14 @@ func (s *Session) Clone() interface{} {
 			},
 		}
 	}
+	if s.ApprovalExpirationTime != nil {
+		clone.ApprovalExpirationTime = &timestamp.Timestamp{
+			Timestamp: &timestamppb.Timestamp{
+				Seconds: s.ApprovalExpirationTime.Timestamp.Seconds,
+				Nanos:   s.ApprovalExpirationTime.Timestamp.Nanos,
+			},
+		}
+	}
 	if s.CreateTime != nil {
 		clone.CreateTime = &timestamp.Timestamp{
 			Timestamp: &timestamppb.Timestamp{
@@ -269,6 +325,16 @@ func (s *Session) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType
 			return errors.New(ctx, errors.InvalidParameter, op, "worker filter is immutable")
 		case contains(opts.WithFieldMaskPaths, "DynamicCredentials"):
 			return errors.New(ctx, errors.InvalidParameter, op, "dynamic credentials are immutable")
+		case contains(opts.WithFieldMaskPaths, "RecordingEnabled"):
+			return errors.New(ctx, errors.InvalidParameter, op, "recording enabled is immutable")
+		case contains(opts.WithFieldMaskPaths, "UploadRateLimit"):
+			return errors.New(ctx, errors.InvalidParameter, op, "upload rate limit is immutable")
+		case contains(opts.WithFieldMaskPaths, "DownloadRateLimit"):
+			return errors.New(ctx, errors.InvalidParameter, op, "download rate limit is immutable")
+		case contains(opts.WithFieldMaskPaths, "ApprovalExpirationTime"):
+			return errors.New(ctx, errors.InvalidParameter, op, "approval expiration time is immutable")
+		case contains(opts.WithFieldMaskPaths, "EndpointHostKey"):
+			return errors.New(ctx, errors.InvalidParameter, op, "endpoint host key is immutable")
 		case contains(opts.WithFieldMaskPaths, "TerminationReason"):
 			if _, err := convertToReason(s.TerminationReason); err != nil {
 				return errors.Wrap(ctx, err, op)
@@ -335,6 +401,9 @@ func (s *Session) validateNewSession() error {
 	if s.CtTofuToken != nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "ct must be empty")
 	}
+	if s.RecordingPath != "" {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "recording path must be empty")
+	}
 	// It is okay for the worker filter to be empty, so it is not checked here.
 	return nil
 }
@@ -422,8 +491,17 @@ type sessionView struct {
 	Version           uint32               `json:"version,omitempty" gorm:"default:null"`
 	Endpoint          string               `json:"-" gorm:"default:null"`
 	ConnectionLimit   int32                `json:"connection_limit,omitempty" gorm:"default:null"`
+	RecordingEnabled  bool                 `json:"recording_enabled,omitempty" gorm:"default:null"`
+	RecordingPath     string               `json:"recording_path,omitempty" gorm:"default:null"`
+	UploadRateLimit   uint32               `json:"upload_rate_limit,omitempty" gorm:"default:null"`
+	DownloadRateLimit uint32               `json:"download_rate_limit,omitempty" gorm:"default:null"`
+	EndpointHostKey   string               `json:"endpoint_host_key,omitempty" gorm:"default:null"`
 	KeyId             string               `json:"key_id,omitempty" gorm:"not_null"`
 
+	// Approval fields
+	ApprovalExpirationTime *timestamp.Timestamp `json:"approval_expiration_time,omitempty" gorm:"default:null"`
+	ApproverId             string               `json:"approver_id,omitempty" gorm:"default:null"`
+
 	// State fields
 	Status          string               `json:"state,omitempty" gorm:"column:state"`
 	PreviousEndTime *timestamp.Timestamp `json:"previous_end_time,omitempty" gorm:"default:current_timestamp"`
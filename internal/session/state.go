@@ -23,6 +23,10 @@ const (
 	StatusActive     Status = "active"
 	StatusCanceling  Status = "canceling"
 	StatusTerminated Status = "terminated"
+
+	// StatusPendingApproval is the first state of a session which requires
+	// approval. An approved session moves to StatusPending.
+	StatusPendingApproval Status = "pending-approval"
 )
 
 // String representation of the state's status
@@ -108,6 +108,22 @@ func TestSession(t *testing.T, conn *db.DB, wrapper wrapping.Wrapper, c Composed
 	return s
 }
 
+// TestPolicy creates a test session policy for the scopeId in the repository.
+// Options are passed into NewPolicy.
+func TestPolicy(t *testing.T, conn *db.DB, scopeId string, opt ...Option) *Policy {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	p, err := NewPolicy(scopeId, opt...)
+	require.NoError(err)
+	id, err := newPolicyId()
+	require.NoError(err)
+	p.PublicId = id
+	err = rw.Create(context.Background(), p)
+	require.NoError(err)
+	return p
+}
+
 // TestDefaultSession creates a test session in the repository using defaults.
 func TestDefaultSession(t *testing.T, conn *db.DB, wrapper wrapping.Wrapper, iamRepo *iam.Repository, opt ...Option) *Session {
 	t.Helper()
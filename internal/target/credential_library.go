@@ -21,20 +21,28 @@ type CredentialLibrary struct {
 
 // NewCredentialLibrary creates a new in memory CredentialLibrary
 // representing the relationship between targetId and credentialLibraryId.
-func NewCredentialLibrary(targetId, credentialLibraryId string, _ ...Option) (*CredentialLibrary, error) {
+// WithCredentialPurpose is the only supported option, the purpose defaults
+// to credential.ApplicationPurpose.
+func NewCredentialLibrary(targetId, credentialLibraryId string, opt ...Option) (*CredentialLibrary, error) {
 	const op = "target.NewCredentialLibrary"
+	opts := getOpts(opt...)
 	if targetId == "" {
 		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "no target id")
 	}
 	if credentialLibraryId == "" {
 		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "no credential library id")
 	}
+	switch opts.withCredentialPurpose {
+	case credential.ApplicationPurpose, credential.EgressPurpose:
+	default:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unsupported credential purpose %q", opts.withCredentialPurpose))
+	}
 
 	t := &CredentialLibrary{
 		CredentialLibrary: &store.CredentialLibrary{
 			TargetId:            targetId,
 			CredentialLibraryId: credentialLibraryId,
-			CredentialPurpose:   string(credential.ApplicationPurpose), // application is the only purpose currently supported
+			CredentialPurpose:   string(opts.withCredentialPurpose),
 		},
 	}
 	return t, nil
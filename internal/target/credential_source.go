@@ -10,3 +10,41 @@ type CredentialSource interface {
 	CredentialPurpose() credential.Purpose
 	TargetId() string
 }
+
+// credentialSourceView represents the relationship between a target and a
+// credential library or a static credential and includes the id of the
+// credential store the source is a part of.
+//
+// It implements the target.CredentialSource interface.
+type credentialSourceView struct {
+	TargetPublicId     string `gorm:"column:target_id"`
+	CredentialSourceId string
+	Purpose            string `gorm:"column:credential_purpose"`
+	StoreId            string
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (cs *credentialSourceView) TableName() string {
+	return "target_credential_source"
+}
+
+// Id returns the ID of the credential source
+func (cs *credentialSourceView) Id() string {
+	return cs.CredentialSourceId
+}
+
+// CredentialStoreId returns the ID of the store containing the credential
+// source
+func (cs *credentialSourceView) CredentialStoreId() string {
+	return cs.StoreId
+}
+
+// CredentialPurpose returns the purpose of the credential
+func (cs *credentialSourceView) CredentialPurpose() credential.Purpose {
+	return credential.Purpose(cs.Purpose)
+}
+
+// TargetId returns the target linked to this credential source
+func (cs *credentialSourceView) TargetId() string {
+	return cs.TargetPublicId
+}
@@ -7,6 +7,8 @@ import (
 
 const (
 	TcpTargetPrefix = "ttcp"
+	SshTargetPrefix = "tssh"
+	UdpTargetPrefix = "tudp"
 )
 
 func newTcpTargetId() (string, error) {
@@ -17,3 +19,21 @@ func newTcpTargetId() (string, error) {
 	}
 	return id, nil
 }
+
+func newSshTargetId() (string, error) {
+	const op = "target.newSshTargetId"
+	id, err := db.NewPublicId(SshTargetPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, op)
+	}
+	return id, nil
+}
+
+func newUdpTargetId() (string, error) {
+	const op = "target.newUdpTargetId"
+	id, err := db.NewPublicId(UdpTargetPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, op)
+	}
+	return id, nil
+}
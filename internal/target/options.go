@@ -1,6 +1,10 @@
 package target
 
-import "time"
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/internal/credential"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -20,6 +24,7 @@ type options struct {
 	withDescription            string
 	withDefaultPort            uint32
 	withLimit                  int
+	withStartPageAfterItem     *string
 	withScopeId                string
 	withScopeIds               []string
 	withScopeName              string
@@ -31,6 +36,13 @@ type options struct {
 	withSessionConnectionLimit int32
 	withPublicId               string
 	withWorkerFilter           string
+	withCredentialPurpose      credential.Purpose
+	withSessionRecording       bool
+	withUploadRateLimit        uint32
+	withDownloadRateLimit      uint32
+	withApprovalRequired       bool
+	withApprovalWindowSeconds  uint32
+	withHostKey                string
 }
 
 func getDefaultOptions() options {
@@ -50,6 +62,13 @@ func getDefaultOptions() options {
 		withSessionConnectionLimit: 1,
 		withPublicId:               "",
 		withWorkerFilter:           "",
+		withCredentialPurpose:      credential.ApplicationPurpose,
+		withSessionRecording:       false,
+		withUploadRateLimit:        0,
+		withDownloadRateLimit:      0,
+		withApprovalRequired:       false,
+		withApprovalWindowSeconds:  0,
+		withHostKey:                "",
 	}
 }
 
@@ -76,6 +95,15 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithStartPageAfterItem provides an option to page through the results of a
+// list. The results are ordered by public id and start after the item with
+// publicId. An empty publicId starts with the first item.
+func WithStartPageAfterItem(publicId string) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = &publicId
+	}
+}
+
 // WithDefaultPort provides an option to specify the default target port.
 func WithDefaultPort(p uint32) Option {
 	return func(o *options) {
@@ -157,3 +185,63 @@ func WithWorkerFilter(filter string) Option {
 		o.withWorkerFilter = filter
 	}
 }
+
+// WithSessionRecordingEnabled provides an optional setting to record the
+// connections of the sessions created for a tcp target.
+func WithSessionRecordingEnabled(enabled bool) Option {
+	return func(o *options) {
+		o.withSessionRecording = enabled
+	}
+}
+
+// WithUploadRateLimit provides an optional max rate, in bytes per second, at
+// which data is sent from the client to the endpoint of a connection to a tcp
+// target.
+func WithUploadRateLimit(bytesPerSecond uint32) Option {
+	return func(o *options) {
+		o.withUploadRateLimit = bytesPerSecond
+	}
+}
+
+// WithDownloadRateLimit provides an optional max rate, in bytes per second, at
+// which data is sent from the endpoint to the client of a connection to a tcp
+// target.
+func WithDownloadRateLimit(bytesPerSecond uint32) Option {
+	return func(o *options) {
+		o.withDownloadRateLimit = bytesPerSecond
+	}
+}
+
+// WithSessionApprovalRequired provides an optional setting to require that
+// the sessions created for a tcp target are approved by a user other than the
+// one who requested them.
+func WithSessionApprovalRequired(required bool) Option {
+	return func(o *options) {
+		o.withApprovalRequired = required
+	}
+}
+
+// WithSessionApprovalWindowSeconds provides an optional time, in seconds, in
+// which a session created for a tcp target must be approved and its
+// authorization retrieved. 0 means until the session expires.
+func WithSessionApprovalWindowSeconds(seconds uint32) Option {
+	return func(o *options) {
+		o.withApprovalWindowSeconds = seconds
+	}
+}
+
+// WithHostKey provides an optional public key, in the authorized_keys format,
+// which the endpoint of an ssh target must present as its host key.
+func WithHostKey(key string) Option {
+	return func(o *options) {
+		o.withHostKey = key
+	}
+}
+
+// WithCredentialPurpose provides an optional purpose for a credential
+// source. The default is credential.ApplicationPurpose.
+func WithCredentialPurpose(p credential.Purpose) Option {
+	return func(o *options) {
+		o.withCredentialPurpose = p
+	}
+}
@@ -41,6 +41,14 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 1
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterItem("s_1234567890"))
+		testOpts := getDefaultOptions()
+		id := "s_1234567890"
+		testOpts.withStartPageAfterItem = &id
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithDefaultPort", func(t *testing.T) {
 		assert := assert.New(t)
 		// test default of 0
@@ -104,6 +112,48 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withWorkerFilter = `"/foo" == "bar"`
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithSessionRecordingEnabled", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionRecordingEnabled(true))
+		testOpts := getDefaultOptions()
+		testOpts.withSessionRecording = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUploadRateLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithUploadRateLimit(1024))
+		testOpts := getDefaultOptions()
+		testOpts.withUploadRateLimit = 1024
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDownloadRateLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDownloadRateLimit(2048))
+		testOpts := getDefaultOptions()
+		testOpts.withDownloadRateLimit = 2048
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionApprovalRequired", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionApprovalRequired(true))
+		testOpts := getDefaultOptions()
+		testOpts.withApprovalRequired = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionApprovalWindowSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionApprovalWindowSeconds(600))
+		testOpts := getDefaultOptions()
+		testOpts.withApprovalWindowSeconds = 600
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHostKey", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithHostKey("ssh-ed25519 AAAA"))
+		testOpts := getDefaultOptions()
+		testOpts.withHostKey = "ssh-ed25519 AAAA"
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithCredentialSources", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithCredentialSources([]string{"alice", "bob"}))
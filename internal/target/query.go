@@ -3,44 +3,50 @@ package target
 const (
 	setChangesQuery = `
 with
-set_libraries (library_id) as (
+set_sources (source_id, purpose) as (
   -- returns the SET list
-  select public_id
+  select public_id, purpose
     from credential_library
-   where public_id in (%s)
+    join (values %s) as requested (source_id, purpose)
+      on credential_library.public_id = requested.source_id
+   union
+  select public_id, purpose
+    from credential_static
+    join (values %s) as requested (source_id, purpose)
+      on credential_static.public_id = requested.source_id
 ),
-current_libraries (library_id) as (
+current_sources (source_id, purpose) as (
   -- returns the current list
-  select credential_library_id
-    from target_credential_library
+  select credential_source_id, credential_purpose
+    from target_credential_source
    where target_id = @target_id
 ),
-keep_libraries (library_id) as (
+keep_sources (source_id, purpose) as (
   -- returns the KEEP list
-  select library_id
-    from current_libraries
-   where library_id in (select * from set_libraries)
+  select source_id, purpose
+    from current_sources
+   where (source_id, purpose) in (select * from set_sources)
 ),
-delete_libraries (library_id) as (
+delete_sources (source_id, purpose) as (
   -- returns the DELETE list
-  select library_id
-    from current_libraries
-   where library_id not in (select * from set_libraries)
+  select source_id, purpose
+    from current_sources
+   where (source_id, purpose) not in (select * from set_sources)
 ),
-insert_libraries (library_id) as (
+insert_sources (source_id, purpose) as (
   -- returns the ADD list
-  select library_id
-    from set_libraries
-   where library_id not in (select * from keep_libraries)
+  select source_id, purpose
+    from set_sources
+   where (source_id, purpose) not in (select * from keep_sources)
 ),
-final (action, library_id) as (
-  select 'delete', library_id
-    from delete_libraries
+final (action, source_id, purpose) as (
+  select 'delete', source_id, purpose
+    from delete_sources
    union
-  select 'add', library_id
-    from insert_libraries
+  select 'add', source_id, purpose
+    from insert_sources
 )
 select * from final
-order by action, library_id;
+order by action, source_id, purpose;
 `
 )
@@ -137,7 +137,8 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 	return subtype, hostSources, credSources, nil
 }
 
-// ListTargets in targets in a scope.  Supports the WithScopeId, WithLimit, WithTargetType options.
+// ListTargets in targets in a scope.  Supports the WithScopeId, WithLimit, WithTargetType
+// and WithStartPageAfterItem options.
 func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target, error) {
 	const op = "target.(Repository).ListTargets"
 	opts := getOpts(opt...)
@@ -184,6 +185,9 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 		limit = opts.withLimit
 	}
 	dbOpts = append(dbOpts, db.WithLimit(limit))
+	if opts.withStartPageAfterItem != nil {
+		dbOpts = append(dbOpts, db.WithStartPageAfterItem(*opts.withStartPageAfterItem))
+	}
 	if err := r.reader.SearchWhere(ctx, resources, where, args, dbOpts...); err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
@@ -209,6 +213,16 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, _ ...Opt
 		tcpT.PublicId = publicId
 		deleteTarget = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = publicId
+		deleteTarget = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = publicId
+		deleteTarget = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_DELETE)
 	default:
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", publicId, t.Type))
 	}
@@ -6,17 +6,64 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/boundary/internal/credential"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 )
 
-// AddTargetCredentialSources adds the cIds to the targetId in the repository. The target
-// and the list of credential sources attached to the target, after cIds are added,
-// will be returned on success.
+// CredentialSources contains slices of credential source ids grouped by the
+// purpose they serve for a target.
+type CredentialSources struct {
+	ApplicationCredentialIds []string
+	EgressCredentialIds      []string
+}
+
+func (cs CredentialSources) empty() bool {
+	return len(cs.ApplicationCredentialIds) == 0 && len(cs.EgressCredentialIds) == 0
+}
+
+// credentialSources returns the in memory CredentialLibraries and
+// StaticCredentials for the ids in cs, each carrying the purpose it was
+// grouped under.
+func (cs CredentialSources) credentialSources(targetId string) ([]interface{}, error) {
+	const op = "target.(CredentialSources).credentialSources"
+	sources := make([]interface{}, 0, len(cs.ApplicationCredentialIds)+len(cs.EgressCredentialIds))
+	for _, id := range cs.ApplicationCredentialIds {
+		src, err := newCredentialSource(targetId, id, credential.ApplicationPurpose)
+		if err != nil {
+			return nil, errors.WrapDeprecated(err, op)
+		}
+		sources = append(sources, src)
+	}
+	for _, id := range cs.EgressCredentialIds {
+		src, err := newCredentialSource(targetId, id, credential.EgressPurpose)
+		if err != nil {
+			return nil, errors.WrapDeprecated(err, op)
+		}
+		sources = append(sources, src)
+	}
+	return sources, nil
+}
+
+// newCredentialSource returns a new in memory StaticCredential if id is
+// the id of a static credential and a new in memory CredentialLibrary
+// otherwise.
+func newCredentialSource(targetId, id string, purpose credential.Purpose) (interface{}, error) {
+	if credential.SubtypeFromId(id) == credstatic.Subtype {
+		return NewStaticCredential(targetId, id, WithCredentialPurpose(purpose))
+	}
+	return NewCredentialLibrary(targetId, id, WithCredentialPurpose(purpose))
+}
+
+// AddTargetCredentialSources adds the credential source ids in cs to the
+// targetId in the repository. The target and the list of credential sources
+// attached to the target, after the ids are added, will be returned on
+// success.
 // The targetVersion must match the current version of the targetId in the repository.
-func (r *Repository) AddTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, cIds []string, _ ...Option) (Target, []HostSource, []CredentialSource, error) {
+func (r *Repository) AddTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, cs CredentialSources, _ ...Option) (Target, []HostSource, []CredentialSource, error) {
 	const op = "target.(Repository).AddTargetCredentialSources"
 	if targetId == "" {
 		return nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
@@ -24,17 +71,13 @@ func (r *Repository) AddTargetCredentialSources(ctx context.Context, targetId st
 	if targetVersion == 0 {
 		return nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
 	}
-	if len(cIds) == 0 {
+	if cs.empty() {
 		return nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential source ids")
 	}
 
-	addCredLibs := make([]interface{}, 0, len(cIds))
-	for _, id := range cIds {
-		cl, err := NewCredentialLibrary(targetId, id)
-		if err != nil {
-			return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory credential library"))
-		}
-		addCredLibs = append(addCredLibs, cl)
+	addCredSources, err := cs.credentialSources(targetId)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory credential source"))
 	}
 
 	t := allocTargetView()
@@ -52,6 +95,20 @@ func (r *Repository) AddTargetCredentialSources(ctx context.Context, targetId st
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
 	default:
 		return nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", t.PublicId, t.Type))
 	}
@@ -88,11 +145,21 @@ func (r *Repository) AddTargetCredentialSources(ctx context.Context, targetId st
 			}
 			msgs = append(msgs, &targetOplogMsg)
 
-			credLibsOplogMsgs := make([]*oplog.Message, 0, len(addCredLibs))
-			if err := w.CreateItems(ctx, addCredLibs, db.NewOplogMsgs(&credLibsOplogMsgs)); err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create target credential sources"))
+			addLibs, addCreds := splitCredentialSources(addCredSources)
+			if len(addLibs) > 0 {
+				credLibsOplogMsgs := make([]*oplog.Message, 0, len(addLibs))
+				if err := w.CreateItems(ctx, addLibs, db.NewOplogMsgs(&credLibsOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create target credential libraries"))
+				}
+				msgs = append(msgs, credLibsOplogMsgs...)
+			}
+			if len(addCreds) > 0 {
+				staticCredsOplogMsgs := make([]*oplog.Message, 0, len(addCreds))
+				if err := w.CreateItems(ctx, addCreds, db.NewOplogMsgs(&staticCredsOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create target static credentials"))
+				}
+				msgs = append(msgs, staticCredsOplogMsgs...)
 			}
-			msgs = append(msgs, credLibsOplogMsgs...)
 
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
@@ -116,7 +183,7 @@ func (r *Repository) AddTargetCredentialSources(ctx context.Context, targetId st
 
 // DeleteTargetCredentialSources deletes credential sources from a target in the repository.
 // The target's current db version must match the targetVersion or an error will be returned.
-func (r *Repository) DeleteTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, csIds []string, _ ...Option) (int, error) {
+func (r *Repository) DeleteTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, cs CredentialSources, _ ...Option) (int, error) {
 	const op = "target.(Repository).DeleteTargetCredentialSources"
 	if targetId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
@@ -124,17 +191,13 @@ func (r *Repository) DeleteTargetCredentialSources(ctx context.Context, targetId
 	if targetVersion == 0 {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
 	}
-	if len(csIds) == 0 {
+	if cs.empty() {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential source ids")
 	}
 
-	deleteCredLibs := make([]interface{}, 0, len(csIds))
-	for _, id := range csIds {
-		cl, err := NewCredentialLibrary(targetId, id)
-		if err != nil {
-			return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory credential library"))
-		}
-		deleteCredLibs = append(deleteCredLibs, cl)
+	deleteCredSources, err := cs.credentialSources(targetId)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory credential source"))
 	}
 
 	t := allocTargetView()
@@ -152,6 +215,20 @@ func (r *Repository) DeleteTargetCredentialSources(ctx context.Context, targetId
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 	default:
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", t.PublicId, t.Type))
 	}
@@ -186,15 +263,23 @@ func (r *Repository) DeleteTargetCredentialSources(ctx context.Context, targetId
 			}
 			msgs = append(msgs, &targetOplogMsg)
 
-			credLibsOplogMsgs := make([]*oplog.Message, 0, len(deleteCredLibs))
-			rowsDeleted, err = w.DeleteItems(ctx, deleteCredLibs, db.NewOplogMsgs(&credLibsOplogMsgs))
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete target credential sources"))
+			rowsDeleted = 0
+			deleteLibs, deleteCreds := splitCredentialSources(deleteCredSources)
+			for _, items := range [][]interface{}{deleteLibs, deleteCreds} {
+				if len(items) == 0 {
+					continue
+				}
+				itemsOplogMsgs := make([]*oplog.Message, 0, len(items))
+				deleted, err := w.DeleteItems(ctx, items, db.NewOplogMsgs(&itemsOplogMsgs))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete target credential sources"))
+				}
+				rowsDeleted += deleted
+				msgs = append(msgs, itemsOplogMsgs...)
 			}
-			if rowsDeleted != len(deleteCredLibs) {
-				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("credential sources deleted %d did not match request for %d", rowsDeleted, len(deleteCredLibs)))
+			if rowsDeleted != len(deleteCredSources) {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("credential sources deleted %d did not match request for %d", rowsDeleted, len(deleteCredSources)))
 			}
-			msgs = append(msgs, credLibsOplogMsgs...)
 
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
@@ -210,8 +295,8 @@ func (r *Repository) DeleteTargetCredentialSources(ctx context.Context, targetId
 
 // SetTargetCredentialSources will set the target's credential sources. Set will add
 // and/or delete credential sources as need to reconcile the existing credential sources
-// with the request. If clIds is empty, all the credential sources will be cleared from the target.
-func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, csIds []string, _ ...Option) ([]HostSource, []CredentialSource, int, error) {
+// with the request. If cs is empty, all the credential sources will be cleared from the target.
+func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, cs CredentialSources, _ ...Option) ([]HostSource, []CredentialSource, int, error) {
 	const op = "target.(Repository).SetTargetCredentialSources"
 	if targetId == "" {
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
@@ -220,7 +305,7 @@ func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId st
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
 	}
 
-	changes, err := r.changes(ctx, targetId, csIds)
+	changes, err := r.changes(ctx, targetId, cs)
 	if err != nil {
 		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
@@ -237,17 +322,17 @@ func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId st
 		return hostSets, credSources, db.NoRowsAffected, nil
 	}
 
-	var deleteCredLibs, addCredLibs []interface{}
+	var deleteCredSources, addCredSources []interface{}
 	for _, c := range changes {
-		cl, err := NewCredentialLibrary(targetId, c.LibraryId)
+		src, err := newCredentialSource(targetId, c.SourceId, credential.Purpose(c.Purpose))
 		if err != nil {
-			return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory target credential library"))
+			return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory target credential source"))
 		}
 		switch c.Action {
 		case "delete":
-			deleteCredLibs = append(deleteCredLibs, cl)
+			deleteCredSources = append(deleteCredSources, src)
 		case "add":
-			addCredLibs = append(addCredLibs, cl)
+			addCredSources = append(addCredSources, src)
 		}
 	}
 
@@ -265,6 +350,18 @@ func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId st
 		tcpT.Version = targetVersion + 1
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 	default:
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", t.PublicId, t.Type))
 	}
@@ -300,29 +397,41 @@ func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId st
 			}
 			msgs = append(msgs, &targetOplogMsg)
 
-			// add new credential libraries
-			if len(addCredLibs) > 0 {
-				addMsgs := make([]*oplog.Message, 0, len(addCredLibs))
-				if err := w.CreateItems(ctx, addCredLibs, db.NewOplogMsgs(&addMsgs)); err != nil {
-					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add target credential libraries"))
+			// add new credential sources
+			if len(addCredSources) > 0 {
+				addLibs, addCreds := splitCredentialSources(addCredSources)
+				for _, items := range [][]interface{}{addLibs, addCreds} {
+					if len(items) == 0 {
+						continue
+					}
+					addMsgs := make([]*oplog.Message, 0, len(items))
+					if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&addMsgs)); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add target credential sources"))
+					}
+					rowsAffected += len(addMsgs)
+					msgs = append(msgs, addMsgs...)
 				}
-				rowsAffected += len(addMsgs)
-				msgs = append(msgs, addMsgs...)
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
 			}
 
-			// delete existing credential libraries not part of set
-			if len(deleteCredLibs) > 0 {
-				delMsgs := make([]*oplog.Message, 0, len(deleteCredLibs))
-				rowsDeleted, err := w.DeleteItems(ctx, deleteCredLibs, db.NewOplogMsgs(&delMsgs))
-				if err != nil {
-					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete target credential libraries"))
-				}
-				if rowsDeleted != len(delMsgs) {
-					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("target credential libraries deleted %d did not match request for %d", rowsDeleted, len(deleteCredLibs)))
+			// delete existing credential sources not part of set
+			if len(deleteCredSources) > 0 {
+				deleteLibs, deleteCreds := splitCredentialSources(deleteCredSources)
+				for _, items := range [][]interface{}{deleteLibs, deleteCreds} {
+					if len(items) == 0 {
+						continue
+					}
+					delMsgs := make([]*oplog.Message, 0, len(items))
+					rowsDeleted, err := w.DeleteItems(ctx, items, db.NewOplogMsgs(&delMsgs))
+					if err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete target credential sources"))
+					}
+					if rowsDeleted != len(delMsgs) {
+						return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("target credential sources deleted %d did not match request for %d", rowsDeleted, len(items)))
+					}
+					rowsAffected += rowsDeleted
+					msgs = append(msgs, delMsgs...)
 				}
-				rowsAffected += rowsDeleted
-				msgs = append(msgs, delMsgs...)
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 			}
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
@@ -347,28 +456,33 @@ func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId st
 }
 
 type change struct {
-	Action    string
-	LibraryId string
+	Action   string
+	SourceId string
+	Purpose  string
 }
 
-func (r *Repository) changes(ctx context.Context, targetId string, clIds []string) ([]*change, error) {
+func (r *Repository) changes(ctx context.Context, targetId string, cs CredentialSources) ([]*change, error) {
 	const op = "target.(Repository).changes"
-	var inClauseSpots []string
-	// starts at 2 because there is already a @1 in the query
-	for i := 2; i < len(clIds)+2; i++ {
-		inClauseSpots = append(inClauseSpots, fmt.Sprintf("@%d", i))
-	}
-	inClause := strings.Join(inClauseSpots, ",")
-	if inClause == "" {
-		inClause = "''"
-	}
-	query := fmt.Sprintf(setChangesQuery, inClause)
-
 	var params []interface{}
 	params = append(params, sql.Named("target_id", targetId))
-	for idx, id := range clIds {
-		params = append(params, sql.Named(fmt.Sprintf("%d", idx+2), id))
+
+	var valuesSpots []string
+	addValues := func(purpose credential.Purpose, ids []string) {
+		for _, id := range ids {
+			// starts at 2 because there is already a @1 in the query
+			idx := len(params) + 1
+			valuesSpots = append(valuesSpots, fmt.Sprintf("(@%d, @%d)", idx, idx+1))
+			params = append(params, sql.Named(fmt.Sprintf("%d", idx), id), sql.Named(fmt.Sprintf("%d", idx+1), string(purpose)))
+		}
+	}
+	addValues(credential.ApplicationPurpose, cs.ApplicationCredentialIds)
+	addValues(credential.EgressPurpose, cs.EgressCredentialIds)
+	valuesClause := strings.Join(valuesSpots, ",")
+	if valuesClause == "" {
+		valuesClause = "('', '')"
 	}
+	query := fmt.Sprintf(setChangesQuery, valuesClause, valuesClause)
+
 	rows, err := r.reader.Query(ctx, query, params)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("query failed"))
@@ -386,21 +500,33 @@ func (r *Repository) changes(ctx context.Context, targetId string, clIds []strin
 	return changes, nil
 }
 
+// splitCredentialSources splits the in memory credential sources returned
+// by credentialSources into CredentialLibraries and StaticCredentials. Each
+// is written to its own table so they cannot be written by the same call.
+func splitCredentialSources(sources []interface{}) (libs []interface{}, creds []interface{}) {
+	for _, src := range sources {
+		switch src.(type) {
+		case *StaticCredential:
+			creds = append(creds, src)
+		default:
+			libs = append(libs, src)
+		}
+	}
+	return libs, creds
+}
+
 func fetchCredentialSources(ctx context.Context, r db.Reader, targetId string) ([]CredentialSource, error) {
 	const op = "target.fetchCredentialSources"
-	var libraries []*TargetLibrary
-	if err := r.SearchWhere(ctx, &libraries, "target_id = ?", []interface{}{targetId}); err != nil {
+	var sources []*credentialSourceView
+	if err := r.SearchWhere(ctx, &sources, "target_id = ?", []interface{}{targetId}); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	// FIXME: When we have static creds, there will need to be an updated view
-	// that unions between libs and creds, at which point the type above will
-	// change. For now we just take the libraries and wrap them.
-	if len(libraries) == 0 {
+	if len(sources) == 0 {
 		return nil, nil
 	}
-	ret := make([]CredentialSource, len(libraries))
-	for i, lib := range libraries {
-		ret[i] = lib
+	ret := make([]CredentialSource, len(sources))
+	for i, src := range sources {
+		ret[i] = src
 	}
 	return ret, nil
 }
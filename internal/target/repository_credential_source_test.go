@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/credential"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -104,7 +106,7 @@ func TestRepository_AddTargetCredentialSources(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
 			projTarget := TestTcpTarget(t, conn, staticProj.PublicId, tt.name)
-			gotTarget, _, gotCredSources, err := repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, tt.args.targetVersion, tt.args.credLibIds)
+			gotTarget, _, gotCredSources, err := repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, tt.args.targetVersion, CredentialSources{ApplicationCredentialIds: tt.args.credLibIds})
 			if tt.wantErr {
 				require.Error(err)
 				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "unexpected error %s", err.Error())
@@ -144,18 +146,18 @@ func TestRepository_AddTargetCredentialSources(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
 		projTarget := TestTcpTarget(t, conn, staticProj.PublicId, "add-existing")
-		_, _, gotCredSources, err := repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 1, []string{lib1.PublicId})
+		_, _, gotCredSources, err := repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 1, CredentialSources{ApplicationCredentialIds: []string{lib1.PublicId}})
 		require.NoError(err)
 		assert.Len(gotCredSources, 1)
 		assert.Equal(lib1.PublicId, gotCredSources[0].Id())
 
 		// Adding lib1 again should error
-		_, _, _, err = repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 2, []string{lib1.PublicId})
+		_, _, _, err = repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 2, CredentialSources{ApplicationCredentialIds: []string{lib1.PublicId}})
 		require.Error(err)
 		assert.True(errors.Match(errors.T(errors.NotUnique), err))
 
 		// Adding multiple with lib1 in set should error
-		_, _, _, err = repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 2, []string{lib3.PublicId, lib2.PublicId, lib1.PublicId})
+		_, _, _, err = repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 2, CredentialSources{ApplicationCredentialIds: []string{lib3.PublicId, lib2.PublicId, lib1.PublicId}})
 		require.Error(err)
 		assert.True(errors.Match(errors.T(errors.NotUnique), err))
 
@@ -165,10 +167,77 @@ func TestRepository_AddTargetCredentialSources(t *testing.T) {
 		assert.Len(gotCredSources, 1)
 		assert.Equal(lib1.PublicId, gotCredSources[0].Id())
 	})
+	t.Run("add-egress", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		projTarget := TestSshTarget(t, conn, staticProj.PublicId, "add-egress")
+		_, _, gotCredSources, err := repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 1,
+			CredentialSources{
+				ApplicationCredentialIds: []string{lib1.PublicId},
+				EgressCredentialIds:      []string{lib1.PublicId, lib2.PublicId},
+			})
+		require.NoError(err)
+		assert.Len(gotCredSources, 3)
+
+		purposes := make(map[credential.Purpose][]string)
+		for _, cs := range gotCredSources {
+			purposes[cs.CredentialPurpose()] = append(purposes[cs.CredentialPurpose()], cs.Id())
+		}
+		assert.ElementsMatch([]string{lib1.PublicId}, purposes[credential.ApplicationPurpose])
+		assert.ElementsMatch([]string{lib1.PublicId, lib2.PublicId}, purposes[credential.EgressPurpose])
+	})
+	t.Run("add-static-credentials", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		staticStore := credstatic.TestCredentialStore(t, conn, wrapper, staticProj.GetPublicId())
+		upCred := credstatic.TestUsernamePasswordCredential(t, conn, wrapper, staticProj.GetPublicId(), staticStore.GetPublicId(), "user", "pass")
+		spkCred := credstatic.TestSshPrivateKeyCredential(t, conn, wrapper, staticProj.GetPublicId(), staticStore.GetPublicId(), "user", "key")
+
+		projTarget := TestSshTarget(t, conn, staticProj.PublicId, "add-static-credentials")
+		_, _, gotCredSources, err := repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 1,
+			CredentialSources{
+				ApplicationCredentialIds: []string{lib1.PublicId, upCred.GetPublicId()},
+				EgressCredentialIds:      []string{spkCred.GetPublicId()},
+			})
+		require.NoError(err)
+		assert.Len(gotCredSources, 3)
+
+		stores := make(map[string]string)
+		purposes := make(map[credential.Purpose][]string)
+		for _, cs := range gotCredSources {
+			stores[cs.Id()] = cs.CredentialStoreId()
+			purposes[cs.CredentialPurpose()] = append(purposes[cs.CredentialPurpose()], cs.Id())
+		}
+		assert.ElementsMatch([]string{lib1.PublicId, upCred.GetPublicId()}, purposes[credential.ApplicationPurpose])
+		assert.ElementsMatch([]string{spkCred.GetPublicId()}, purposes[credential.EgressPurpose])
+		assert.Equal(cs.GetPublicId(), stores[lib1.PublicId])
+		assert.Equal(staticStore.GetPublicId(), stores[upCred.GetPublicId()])
+
+		deleted, err := repo.DeleteTargetCredentialSources(context.Background(), projTarget.PublicId, 2,
+			CredentialSources{
+				ApplicationCredentialIds: []string{lib1.PublicId, upCred.GetPublicId()},
+			})
+		require.NoError(err)
+		assert.Equal(2, deleted)
+
+		gotCredSources, err = fetchCredentialSources(context.Background(), rw, projTarget.PublicId)
+		require.NoError(err)
+		require.Len(gotCredSources, 1)
+		assert.Equal(spkCred.GetPublicId(), gotCredSources[0].Id())
+
+		_, gotCredSources, _, err = repo.SetTargetCredentialSources(context.Background(), projTarget.PublicId, 3,
+			CredentialSources{
+				ApplicationCredentialIds: []string{upCred.GetPublicId()},
+			})
+		require.NoError(err)
+		require.Len(gotCredSources, 1)
+		assert.Equal(upCred.GetPublicId(), gotCredSources[0].Id())
+		assert.Equal(credential.ApplicationPurpose, gotCredSources[0].CredentialPurpose())
+	})
 	t.Run("target-not-found", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
-		_, _, _, err := repo.AddTargetCredentialSources(context.Background(), "fake-target-id", 1, []string{lib1.PublicId})
+		_, _, _, err := repo.AddTargetCredentialSources(context.Background(), "fake-target-id", 1, CredentialSources{ApplicationCredentialIds: []string{lib1.PublicId}})
 
 		require.Error(err)
 		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error %s", err.Error())
@@ -286,7 +355,7 @@ func TestRepository_DeleteTargetCredentialSources(t *testing.T) {
 					clIds = append(clIds, cl.PublicId)
 				}
 			}
-			_, _, addedCredSources, err := repo.AddTargetCredentialSources(context.Background(), target.GetPublicId(), 1, clIds)
+			_, _, addedCredSources, err := repo.AddTargetCredentialSources(context.Background(), target.GetPublicId(), 1, CredentialSources{ApplicationCredentialIds: clIds})
 			require.NoError(err)
 			assert.Equal(tt.args.createCnt, len(addedCredSources))
 
@@ -308,7 +377,7 @@ func TestRepository_DeleteTargetCredentialSources(t *testing.T) {
 			default:
 				targetVersion = 2
 			}
-			deletedRows, err := repo.DeleteTargetCredentialSources(context.Background(), targetId, targetVersion, deleteCredSources)
+			deletedRows, err := repo.DeleteTargetCredentialSources(context.Background(), targetId, targetVersion, CredentialSources{ApplicationCredentialIds: deleteCredSources})
 			if tt.wantErr {
 				require.Error(err)
 				assert.Equal(0, deletedRows)
@@ -335,18 +404,18 @@ func TestRepository_DeleteTargetCredentialSources(t *testing.T) {
 		lib3 := libs[2]
 
 		projTarget := TestTcpTarget(t, conn, proj.PublicId, "add-existing")
-		_, _, gotCredSources, err := repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 1, []string{lib1.PublicId, lib2.PublicId})
+		_, _, gotCredSources, err := repo.AddTargetCredentialSources(context.Background(), projTarget.PublicId, 1, CredentialSources{ApplicationCredentialIds: []string{lib1.PublicId, lib2.PublicId}})
 		require.NoError(err)
 		assert.Len(gotCredSources, 2)
 
 		// Deleting an unassociated source should return an error
-		delCount, err := repo.DeleteTargetCredentialSources(context.Background(), projTarget.PublicId, 2, []string{lib3.PublicId})
+		delCount, err := repo.DeleteTargetCredentialSources(context.Background(), projTarget.PublicId, 2, CredentialSources{ApplicationCredentialIds: []string{lib3.PublicId}})
 		require.Error(err)
 		assert.True(errors.Match(errors.T(errors.MultipleRecords), err))
 		assert.Equal(0, delCount)
 
 		// Deleting sources which includes an unassociated source should return an error
-		delCount, err = repo.DeleteTargetCredentialSources(context.Background(), projTarget.PublicId, 2, []string{lib1.PublicId, lib2.PublicId, lib3.PublicId})
+		delCount, err = repo.DeleteTargetCredentialSources(context.Background(), projTarget.PublicId, 2, CredentialSources{ApplicationCredentialIds: []string{lib1.PublicId, lib2.PublicId, lib3.PublicId}})
 		require.Error(err)
 		assert.True(errors.Match(errors.T(errors.MultipleRecords), err))
 		assert.Equal(0, delCount)
@@ -382,7 +451,7 @@ func TestRepository_SetTargetCredentialSources(t *testing.T) {
 			clIds = append(clIds, cl.PublicId)
 		}
 
-		_, _, created, err := repo.AddTargetCredentialSources(context.Background(), target.GetPublicId(), 1, clIds)
+		_, _, created, err := repo.AddTargetCredentialSources(context.Background(), target.GetPublicId(), 1, CredentialSources{ApplicationCredentialIds: clIds})
 		require.NoError(t, err)
 		require.Equal(t, 10, len(created))
 		return created
@@ -488,7 +557,7 @@ func TestRepository_SetTargetCredentialSources(t *testing.T) {
 			require.NoError(err)
 			assert.Equal(origCredSources, lookupCredSources)
 
-			_, got, affectedRows, err := repo.SetTargetCredentialSources(context.Background(), target.GetPublicId(), tt.args.targetVersion, tt.args.clIds)
+			_, got, affectedRows, err := repo.SetTargetCredentialSources(context.Background(), target.GetPublicId(), tt.args.targetVersion, CredentialSources{ApplicationCredentialIds: tt.args.clIds})
 			if tt.wantErr {
 				require.Error(err)
 				assert.Equal(0, affectedRows)
@@ -525,7 +594,7 @@ func TestRepository_SetTargetCredentialSources(t *testing.T) {
 	t.Run("missing-target-id", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
-		_, _, _, err := repo.SetTargetCredentialSources(context.Background(), "", 1, []string{lib1.PublicId})
+		_, _, _, err := repo.SetTargetCredentialSources(context.Background(), "", 1, CredentialSources{ApplicationCredentialIds: []string{lib1.PublicId}})
 
 		require.Error(err)
 		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %s", err.Error())
@@ -533,7 +602,7 @@ func TestRepository_SetTargetCredentialSources(t *testing.T) {
 	t.Run("target-not-found", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
-		_, _, _, err := repo.SetTargetCredentialSources(context.Background(), "fake-target-id", 1, []string{lib1.PublicId})
+		_, _, _, err := repo.SetTargetCredentialSources(context.Background(), "fake-target-id", 1, CredentialSources{ApplicationCredentialIds: []string{lib1.PublicId}})
 
 		require.Error(err)
 		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error %s", err.Error())
@@ -150,6 +150,11 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
 		case strings.EqualFold("workerfilter", f):
+		case strings.EqualFold("sessionrecordingenabled", f):
+		case strings.EqualFold("uploadratelimit", f):
+		case strings.EqualFold("downloadratelimit", f):
+		case strings.EqualFold("sessionapprovalrequired", f):
+		case strings.EqualFold("sessionapprovalwindowseconds", f):
 		default:
 			return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
@@ -157,15 +162,20 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":                   target.Name,
-			"Description":            target.Description,
-			"DefaultPort":            target.DefaultPort,
-			"SessionMaxSeconds":      target.SessionMaxSeconds,
-			"SessionConnectionLimit": target.SessionConnectionLimit,
-			"WorkerFilter":           target.WorkerFilter,
+			"Name":                         target.Name,
+			"Description":                  target.Description,
+			"DefaultPort":                  target.DefaultPort,
+			"SessionMaxSeconds":            target.SessionMaxSeconds,
+			"SessionConnectionLimit":       target.SessionConnectionLimit,
+			"WorkerFilter":                 target.WorkerFilter,
+			"SessionRecordingEnabled":      target.SessionRecordingEnabled,
+			"UploadRateLimit":              target.UploadRateLimit,
+			"DownloadRateLimit":            target.DownloadRateLimit,
+			"SessionApprovalRequired":      target.SessionApprovalRequired,
+			"SessionApprovalWindowSeconds": target.SessionApprovalWindowSeconds,
 		},
 		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "SessionRecordingEnabled", "UploadRateLimit", "DownloadRateLimit", "SessionApprovalRequired", "SessionApprovalWindowSeconds"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
@@ -0,0 +1,114 @@
+package target
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultSshTableName = "target_ssh"
+)
+
+// SshTarget is a target whose connections are terminated by the worker, which
+// then opens its own ssh session to the endpoint using the session's egress
+// credentials.
+type SshTarget struct {
+	*store.SshTarget
+	tableName string `gorm:"-"`
+}
+
+var (
+	_ Target                  = (*SshTarget)(nil)
+	_ db.VetForWriter         = (*SshTarget)(nil)
+	_ oplog.ReplayableMessage = (*SshTarget)(nil)
+)
+
+// NewSshTarget creates a new in memory ssh target.  WithName, WithDescription,
+// WithDefaultPort and WithHostKey options are supported
+func NewSshTarget(scopeId string, opt ...Option) (*SshTarget, error) {
+	const op = "target.NewSshTarget"
+	opts := getOpts(opt...)
+	if scopeId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing scope id")
+	}
+	t := &SshTarget{
+		SshTarget: &store.SshTarget{
+			ScopeId:                scopeId,
+			Name:                   opts.withName,
+			Description:            opts.withDescription,
+			DefaultPort:            opts.withDefaultPort,
+			SessionConnectionLimit: opts.withSessionConnectionLimit,
+			SessionMaxSeconds:      opts.withSessionMaxSeconds,
+			WorkerFilter:           opts.withWorkerFilter,
+			HostKey:                opts.withHostKey,
+		},
+	}
+	return t, nil
+}
+
+// allocSshTarget will allocate an ssh target
+func allocSshTarget() SshTarget {
+	return SshTarget{
+		SshTarget: &store.SshTarget{},
+	}
+}
+
+// Clone creates a clone of the SshTarget
+func (t *SshTarget) Clone() interface{} {
+	cp := proto.Clone(t.SshTarget)
+	return &SshTarget{
+		SshTarget: cp.(*store.SshTarget),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the ssh target
+// before it's written.
+func (t *SshTarget) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, _ ...db.Option) error {
+	const op = "target.(SshTarget).VetForWrite"
+	if t.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if opType == db.CreateOp {
+		if t.ScopeId == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+		}
+		if t.Name == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "missing name")
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *SshTarget) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return DefaultSshTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *SshTarget) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *SshTarget) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{t.PublicId},
+		"resource-type":      []string{"ssh target"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{t.ScopeId},
+	}
+	return metadata
+}
+
+func (t SshTarget) GetType() string {
+	return "ssh"
+}
@@ -0,0 +1,82 @@
+package target
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// A StaticCredential represents the relationship between a target and a
+// static credential.
+type StaticCredential struct {
+	*store.StaticCredential
+	tableName string `gorm:"-"`
+}
+
+// NewStaticCredential creates a new in memory StaticCredential
+// representing the relationship between targetId and credentialId.
+// WithCredentialPurpose is the only supported option, the purpose defaults
+// to credential.ApplicationPurpose.
+func NewStaticCredential(targetId, credentialId string, opt ...Option) (*StaticCredential, error) {
+	const op = "target.NewStaticCredential"
+	opts := getOpts(opt...)
+	if targetId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "no target id")
+	}
+	if credentialId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "no credential id")
+	}
+	switch opts.withCredentialPurpose {
+	case credential.ApplicationPurpose, credential.EgressPurpose:
+	default:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unsupported credential purpose %q", opts.withCredentialPurpose))
+	}
+
+	t := &StaticCredential{
+		StaticCredential: &store.StaticCredential{
+			TargetId:           targetId,
+			CredentialStaticId: credentialId,
+			CredentialPurpose:  string(opts.withCredentialPurpose),
+		},
+	}
+	return t, nil
+}
+
+func allocStaticCredential() *StaticCredential {
+	return &StaticCredential{
+		StaticCredential: &store.StaticCredential{},
+	}
+}
+
+func (t *StaticCredential) clone() *StaticCredential {
+	cp := proto.Clone(t.StaticCredential)
+	return &StaticCredential{
+		StaticCredential: cp.(*store.StaticCredential),
+	}
+}
+
+// TableName returns the table name.
+func (t *StaticCredential) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "target_static_credential"
+}
+
+// SetTableName sets the table name.
+func (t *StaticCredential) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *StaticCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{fmt.Sprintf("%s:%s:%s", t.TargetId, t.CredentialStaticId, t.CredentialPurpose)},
+		"resource-type":      []string{"target-static-credential"},
+		"op-type":            []string{op.String()},
+	}
+	return metadata
+}
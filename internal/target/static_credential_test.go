@@ -0,0 +1,93 @@
+package target
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticCredential_New(t *testing.T) {
+	type args struct {
+		targetId     string
+		credentialId string
+		opt          []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *StaticCredential
+		wantErr errors.Code
+	}{
+		{
+			name: "no-targetId",
+			args: args{
+				credentialId: "credup_0000000",
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "no-credentialId",
+			args: args{
+				targetId: "targ_0000000",
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "ingress-purpose",
+			args: args{
+				targetId:     "targ_0000000",
+				credentialId: "credup_0000000",
+				opt:          []Option{WithCredentialPurpose(credential.IngressPurpose)},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid",
+			args: args{
+				targetId:     "targ_0000000",
+				credentialId: "credup_0000000",
+			},
+			want: &StaticCredential{
+				StaticCredential: &store.StaticCredential{
+					TargetId:           "targ_0000000",
+					CredentialStaticId: "credup_0000000",
+					CredentialPurpose:  string(credential.ApplicationPurpose),
+				},
+			},
+		},
+		{
+			name: "valid-egress",
+			args: args{
+				targetId:     "targ_0000000",
+				credentialId: "credspk_0000000",
+				opt:          []Option{WithCredentialPurpose(credential.EgressPurpose)},
+			},
+			want: &StaticCredential{
+				StaticCredential: &store.StaticCredential{
+					TargetId:           "targ_0000000",
+					CredentialStaticId: "credspk_0000000",
+					CredentialPurpose:  string(credential.EgressPurpose),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewStaticCredential(tt.args.targetId, tt.args.credentialId, tt.args.opt...)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.EqualValues(tt.want, got)
+		})
+	}
+}
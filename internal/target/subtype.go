@@ -9,12 +9,18 @@ type Subtype int
 const (
 	UnknownSubtype Subtype = iota
 	TcpSubtype
+	SshSubtype
+	UdpSubtype
 )
 
 func (t Subtype) String() string {
 	switch t {
 	case TcpSubtype:
 		return "tcp"
+	case SshSubtype:
+		return "ssh"
+	case UdpSubtype:
+		return "udp"
 	}
 	return "unknown"
 }
@@ -25,6 +31,10 @@ func SubtypeFromType(t string) Subtype {
 	switch {
 	case strings.EqualFold(strings.TrimSpace(t), TcpSubtype.String()):
 		return TcpSubtype
+	case strings.EqualFold(strings.TrimSpace(t), SshSubtype.String()):
+		return SshSubtype
+	case strings.EqualFold(strings.TrimSpace(t), UdpSubtype.String()):
+		return UdpSubtype
 	}
 	return UnknownSubtype
 }
@@ -36,6 +46,10 @@ func SubtypeFromId(id string) Subtype {
 	switch {
 	case strings.HasPrefix(strings.TrimSpace(id), TcpTargetPrefix):
 		return TcpSubtype
+	case strings.HasPrefix(strings.TrimSpace(id), SshTargetPrefix):
+		return SshSubtype
+	case strings.HasPrefix(strings.TrimSpace(id), UdpTargetPrefix):
+		return UdpSubtype
 	}
 	return UnknownSubtype
 }
@@ -32,6 +32,8 @@ type TargetType uint32
 const (
 	UnknownTargetType TargetType = 0
 	TcpTargetType     TargetType = 1
+	SshTargetType     TargetType = 2
+	UdpTargetType     TargetType = 3
 )
 
 // String returns a string representation of the target type.
@@ -39,6 +41,8 @@ func (t TargetType) String() string {
 	return [...]string{
 		"unknown",
 		"tcp",
+		"ssh",
+		"udp",
 	}[t]
 }
 
@@ -96,7 +100,41 @@ func (t *targetView) targetSubtype() (Target, error) {
 		tcpTarget.SessionMaxSeconds = t.SessionMaxSeconds
 		tcpTarget.SessionConnectionLimit = t.SessionConnectionLimit
 		tcpTarget.WorkerFilter = t.WorkerFilter
+		tcpTarget.SessionRecordingEnabled = t.SessionRecordingEnabled
+		tcpTarget.UploadRateLimit = t.UploadRateLimit
+		tcpTarget.DownloadRateLimit = t.DownloadRateLimit
+		tcpTarget.SessionApprovalRequired = t.SessionApprovalRequired
+		tcpTarget.SessionApprovalWindowSeconds = t.SessionApprovalWindowSeconds
 		return &tcpTarget, nil
+	case SshTargetType.String():
+		sshTarget := allocSshTarget()
+		sshTarget.PublicId = t.PublicId
+		sshTarget.ScopeId = t.ScopeId
+		sshTarget.Name = t.Name
+		sshTarget.Description = t.Description
+		sshTarget.DefaultPort = t.DefaultPort
+		sshTarget.CreateTime = t.CreateTime
+		sshTarget.UpdateTime = t.UpdateTime
+		sshTarget.Version = t.Version
+		sshTarget.SessionMaxSeconds = t.SessionMaxSeconds
+		sshTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		sshTarget.WorkerFilter = t.WorkerFilter
+		sshTarget.HostKey = t.HostKey
+		return &sshTarget, nil
+	case UdpTargetType.String():
+		udpTarget := allocUdpTarget()
+		udpTarget.PublicId = t.PublicId
+		udpTarget.ScopeId = t.ScopeId
+		udpTarget.Name = t.Name
+		udpTarget.Description = t.Description
+		udpTarget.DefaultPort = t.DefaultPort
+		udpTarget.CreateTime = t.CreateTime
+		udpTarget.UpdateTime = t.UpdateTime
+		udpTarget.Version = t.Version
+		udpTarget.SessionMaxSeconds = t.SessionMaxSeconds
+		udpTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		udpTarget.WorkerFilter = t.WorkerFilter
+		return &udpTarget, nil
 	}
 	return nil, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("%s is an unknown target subtype of %s", t.PublicId, t.Type))
 }
@@ -25,8 +25,10 @@ var (
 	_ oplog.ReplayableMessage = (*TcpTarget)(nil)
 )
 
-// NewTcpTarget creates a new in memory tcp target.  WithName, WithDescription and
-// WithDefaultPort options are supported
+// NewTcpTarget creates a new in memory tcp target.  WithName, WithDescription,
+// WithDefaultPort, WithSessionRecordingEnabled, WithUploadRateLimit,
+// WithDownloadRateLimit, WithSessionApprovalRequired and
+// WithSessionApprovalWindowSeconds options are supported
 func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 	const op = "target.NewTcpTarget"
 	opts := getOpts(opt...)
@@ -35,13 +37,18 @@ func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 	}
 	t := &TcpTarget{
 		TcpTarget: &store.TcpTarget{
-			ScopeId:                scopeId,
-			Name:                   opts.withName,
-			Description:            opts.withDescription,
-			DefaultPort:            opts.withDefaultPort,
-			SessionConnectionLimit: opts.withSessionConnectionLimit,
-			SessionMaxSeconds:      opts.withSessionMaxSeconds,
-			WorkerFilter:           opts.withWorkerFilter,
+			ScopeId:                      scopeId,
+			Name:                         opts.withName,
+			Description:                  opts.withDescription,
+			DefaultPort:                  opts.withDefaultPort,
+			SessionConnectionLimit:       opts.withSessionConnectionLimit,
+			SessionMaxSeconds:            opts.withSessionMaxSeconds,
+			WorkerFilter:                 opts.withWorkerFilter,
+			SessionRecordingEnabled:      opts.withSessionRecording,
+			UploadRateLimit:              opts.withUploadRateLimit,
+			DownloadRateLimit:            opts.withDownloadRateLimit,
+			SessionApprovalRequired:      opts.withApprovalRequired,
+			SessionApprovalWindowSeconds: opts.withApprovalWindowSeconds,
 		},
 	}
 	return t, nil
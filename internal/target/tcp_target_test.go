@@ -55,6 +55,59 @@ func TestTcpTarget_Create(t *testing.T) {
 			}(),
 			create: true,
 		},
+		{
+			name: "valid-session-recording",
+			args: args{
+				scopeId: prj.PublicId,
+				opt:     []Option{WithName("valid-session-recording"), WithSessionRecordingEnabled(true)},
+			},
+			want: func() *TcpTarget {
+				t := allocTcpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-session-recording"
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				t.SessionRecordingEnabled = true
+				return &t
+			}(),
+			create: true,
+		},
+		{
+			name: "valid-rate-limits",
+			args: args{
+				scopeId: prj.PublicId,
+				opt:     []Option{WithName("valid-rate-limits"), WithUploadRateLimit(1024), WithDownloadRateLimit(2048)},
+			},
+			want: func() *TcpTarget {
+				t := allocTcpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-rate-limits"
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				t.UploadRateLimit = 1024
+				t.DownloadRateLimit = 2048
+				return &t
+			}(),
+			create: true,
+		},
+		{
+			name: "valid-session-approval",
+			args: args{
+				scopeId: prj.PublicId,
+				opt:     []Option{WithName("valid-session-approval"), WithSessionApprovalRequired(true), WithSessionApprovalWindowSeconds(600)},
+			},
+			want: func() *TcpTarget {
+				t := allocTcpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-session-approval"
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				t.SessionApprovalRequired = true
+				t.SessionApprovalWindowSeconds = 600
+				return &t
+			}(),
+			create: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -47,6 +47,80 @@ func TestTcpTarget(t *testing.T, conn *db.DB, scopeId, name string, opt ...Optio
 	return target
 }
 
+func TestSshTarget(t *testing.T, conn *db.DB, scopeId, name string, opt ...Option) *SshTarget {
+	t.Helper()
+	opt = append(opt, WithName(name))
+	opts := getOpts(opt...)
+	require := require.New(t)
+	rw := db.New(conn)
+	target, err := NewSshTarget(scopeId, opt...)
+	require.NoError(err)
+	id, err := newSshTargetId()
+	require.NoError(err)
+	target.PublicId = id
+	err = rw.Create(context.Background(), target)
+	require.NoError(err)
+
+	if len(opts.withHostSources) > 0 {
+		newHostSets := make([]interface{}, 0, len(opts.withHostSources))
+		for _, s := range opts.withHostSources {
+			hostSet, err := NewTargetHostSet(target.PublicId, s)
+			require.NoError(err)
+			newHostSets = append(newHostSets, hostSet)
+		}
+		err := rw.CreateItems(context.Background(), newHostSets)
+		require.NoError(err)
+	}
+	if len(opts.withCredentialSources) > 0 {
+		newCredLibs := make([]interface{}, 0, len(opts.withCredentialSources))
+		for _, cl := range opts.withCredentialSources {
+			newCl, err := NewCredentialLibrary(target.PublicId, cl)
+			require.NoError(err)
+			newCredLibs = append(newCredLibs, newCl)
+		}
+		err := rw.CreateItems(context.Background(), newCredLibs)
+		require.NoError(err)
+	}
+	return target
+}
+
+func TestUdpTarget(t *testing.T, conn *db.DB, scopeId, name string, opt ...Option) *UdpTarget {
+	t.Helper()
+	opt = append(opt, WithName(name))
+	opts := getOpts(opt...)
+	require := require.New(t)
+	rw := db.New(conn)
+	target, err := NewUdpTarget(scopeId, opt...)
+	require.NoError(err)
+	id, err := newUdpTargetId()
+	require.NoError(err)
+	target.PublicId = id
+	err = rw.Create(context.Background(), target)
+	require.NoError(err)
+
+	if len(opts.withHostSources) > 0 {
+		newHostSets := make([]interface{}, 0, len(opts.withHostSources))
+		for _, s := range opts.withHostSources {
+			hostSet, err := NewTargetHostSet(target.PublicId, s)
+			require.NoError(err)
+			newHostSets = append(newHostSets, hostSet)
+		}
+		err := rw.CreateItems(context.Background(), newHostSets)
+		require.NoError(err)
+	}
+	if len(opts.withCredentialSources) > 0 {
+		newCredLibs := make([]interface{}, 0, len(opts.withCredentialSources))
+		for _, cl := range opts.withCredentialSources {
+			newCl, err := NewCredentialLibrary(target.PublicId, cl)
+			require.NoError(err)
+			newCredLibs = append(newCredLibs, newCl)
+		}
+		err := rw.CreateItems(context.Background(), newCredLibs)
+		require.NoError(err)
+	}
+	return target
+}
+
 func testTargetName(t *testing.T, scopeId string) string {
 	t.Helper()
 	return fmt.Sprintf("%s-%s", scopeId, testId(t))
@@ -60,12 +134,12 @@ func testId(t *testing.T) string {
 }
 
 // TestCredentialLibrary creates a CredentialLibrary for targetId and
-// libraryId.
-func TestCredentialLibrary(t *testing.T, conn *db.DB, targetId, libraryId string) *CredentialLibrary {
+// libraryId. WithCredentialPurpose is the only supported option.
+func TestCredentialLibrary(t *testing.T, conn *db.DB, targetId, libraryId string, opt ...Option) *CredentialLibrary {
 	t.Helper()
 	require := require.New(t)
 	rw := db.New(conn)
-	lib, err := NewCredentialLibrary(targetId, libraryId)
+	lib, err := NewCredentialLibrary(targetId, libraryId, opt...)
 	require.NoError(err)
 	err = rw.Create(context.Background(), lib)
 	require.NoError(err)
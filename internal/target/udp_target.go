@@ -0,0 +1,115 @@
+package target
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultUdpTableName = "target_udp"
+)
+
+// UdpTarget is a target whose datagrams are proxied by the worker to the
+// endpoint. Each flow of datagrams from a client address is a connection of
+// the session, which is closed once the flow has been idle for a while.
+type UdpTarget struct {
+	*store.UdpTarget
+	tableName string `gorm:"-"`
+}
+
+var (
+	_ Target                  = (*UdpTarget)(nil)
+	_ db.VetForWriter         = (*UdpTarget)(nil)
+	_ oplog.ReplayableMessage = (*UdpTarget)(nil)
+)
+
+// NewUdpTarget creates a new in memory udp target.  WithName, WithDescription and
+// WithDefaultPort options are supported. Unlike other targets, the session
+// connection limit defaults to unlimited, since every flow of datagrams is a
+// connection which is closed once it is idle.
+func NewUdpTarget(scopeId string, opt ...Option) (*UdpTarget, error) {
+	const op = "target.NewUdpTarget"
+	opts := getOpts(append([]Option{WithSessionConnectionLimit(-1)}, opt...)...)
+	if scopeId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing scope id")
+	}
+	t := &UdpTarget{
+		UdpTarget: &store.UdpTarget{
+			ScopeId:                scopeId,
+			Name:                   opts.withName,
+			Description:            opts.withDescription,
+			DefaultPort:            opts.withDefaultPort,
+			SessionConnectionLimit: opts.withSessionConnectionLimit,
+			SessionMaxSeconds:      opts.withSessionMaxSeconds,
+			WorkerFilter:           opts.withWorkerFilter,
+		},
+	}
+	return t, nil
+}
+
+// allocUdpTarget will allocate a udp target
+func allocUdpTarget() UdpTarget {
+	return UdpTarget{
+		UdpTarget: &store.UdpTarget{},
+	}
+}
+
+// Clone creates a clone of the UdpTarget
+func (t *UdpTarget) Clone() interface{} {
+	cp := proto.Clone(t.UdpTarget)
+	return &UdpTarget{
+		UdpTarget: cp.(*store.UdpTarget),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the udp target
+// before it's written.
+func (t *UdpTarget) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, _ ...db.Option) error {
+	const op = "target.(UdpTarget).VetForWrite"
+	if t.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if opType == db.CreateOp {
+		if t.ScopeId == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+		}
+		if t.Name == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "missing name")
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *UdpTarget) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return DefaultUdpTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *UdpTarget) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *UdpTarget) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{t.PublicId},
+		"resource-type":      []string{"udp target"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{t.ScopeId},
+	}
+	return metadata
+}
+
+func (t UdpTarget) GetType() string {
+	return "udp"
+}
@@ -95,6 +95,58 @@ func TestAction(t *testing.T) {
 			action: NoOp,
 			want:   "no-op",
 		},
+		{
+			action: Explain,
+			want:   "explain",
+		},
+		{
+			action: EnrollTotp,
+			want:   "enroll-totp",
+		},
+		{
+			action: ConfirmTotp,
+			want:   "confirm-totp",
+		},
+		{
+			action: RemoveTotp,
+			want:   "remove-totp",
+		},
+		{
+			action: RotateKeys,
+			want:   "rotate-keys",
+		},
+		{
+			action: ListKeys,
+			want:   "list-keys",
+		},
+		{
+			action: DestroyKeyVersion,
+			want:   "destroy-key-version",
+		},
+		{
+			action: ListKeyVersionDestructionJobs,
+			want:   "list-key-version-destruction-jobs",
+		},
+		{
+			action: Approve,
+			want:   "approve",
+		},
+		{
+			action: Deny,
+			want:   "deny",
+		},
+		{
+			action: AddWorkerTags,
+			want:   "add-worker-tags",
+		},
+		{
+			action: SetWorkerTags,
+			want:   "set-worker-tags",
+		},
+		{
+			action: RemoveWorkerTags,
+			want:   "remove-worker-tags",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -25,6 +25,8 @@ const (
 	ManagedGroup
 	CredentialStore
 	CredentialLibrary
+	SessionPolicy
+	Credential
 	// NOTE: When adding a new type, be sure to update:
 	//
 	// * The Grant.validateType function and test
@@ -57,6 +59,8 @@ func (r Type) String() string {
 		"managed-group",
 		"credential-store",
 		"credential-library",
+		"session-policy",
+		"credential",
 	}[r]
 }
 
@@ -64,6 +68,8 @@ func (r Type) PluralString() string {
 	switch r {
 	case CredentialLibrary:
 		return "credential-libraries"
+	case SessionPolicy:
+		return "session-policies"
 	default:
 		return r.String() + "s"
 	}
@@ -89,4 +95,6 @@ var Map = map[string]Type{
 	ManagedGroup.String():      ManagedGroup,
 	CredentialStore.String():   CredentialStore,
 	CredentialLibrary.String(): CredentialLibrary,
+	SessionPolicy.String():     SessionPolicy,
+	Credential.String():        Credential,
 }
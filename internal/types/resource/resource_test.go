@@ -87,6 +87,14 @@ func Test_Resource(t *testing.T) {
 			typeString: "credential-library",
 			want:       CredentialLibrary,
 		},
+		{
+			typeString: "session-policy",
+			want:       SessionPolicy,
+		},
+		{
+			typeString: "credential",
+			want:       Credential,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
@@ -74,8 +74,10 @@ func main() {
 		role,
 		scope,
 		session,
+		sessionPolicy,
 		target,
 		user,
+		worker,
 	)
 
 	fileContents, err := ioutil.ReadFile(permsFile)
@@ -342,6 +344,30 @@ var account = &Resource{
 						"id=<pin>;type=<type>;actions=change-password",
 					},
 				},
+				&Action{
+					Name:        "enroll-totp",
+					Description: "Generate a TOTP secret for an account",
+					Examples: []string{
+						"id=<id>;actions=enroll-totp",
+						"id=<pin>;type=<type>;actions=enroll-totp",
+					},
+				},
+				&Action{
+					Name:        "confirm-totp",
+					Description: "Confirm the TOTP secret of an account with a code generated from it",
+					Examples: []string{
+						"id=<id>;actions=confirm-totp",
+						"id=<pin>;type=<type>;actions=confirm-totp",
+					},
+				},
+				&Action{
+					Name:        "remove-totp",
+					Description: "Remove the TOTP secret from an account",
+					Examples: []string{
+						"id=<id>;actions=remove-totp",
+						"id=<pin>;type=<type>;actions=remove-totp",
+					},
+				},
 			),
 		},
 	},
@@ -668,7 +694,37 @@ var scope = &Resource{
 				"ID":   "<id>",
 				"Type": "scope",
 			},
-			Actions: rudActions("a scope", false),
+			Actions: append(
+				rudActions("a scope", false),
+				&Action{
+					Name:        "rotate-keys",
+					Description: "Rotate the keys of a scope",
+					Examples: []string{
+						"id=<id>;actions=rotate-keys",
+					},
+				},
+				&Action{
+					Name:        "list-keys",
+					Description: "List the keys of a scope and their versions",
+					Examples: []string{
+						"id=<id>;actions=list-keys",
+					},
+				},
+				&Action{
+					Name:        "destroy-key-version",
+					Description: "Destroy a root or database key version of a scope",
+					Examples: []string{
+						"id=<id>;actions=destroy-key-version",
+					},
+				},
+				&Action{
+					Name:        "list-key-version-destruction-jobs",
+					Description: "List the database key version destruction jobs of a scope",
+					Examples: []string{
+						"id=<id>;actions=list-key-version-destruction-jobs",
+					},
+				},
+			),
 		},
 	},
 }
@@ -727,8 +783,59 @@ var session = &Resource{
 						"id=*;type=session;actions=cancel:self",
 					},
 				},
+				{
+					Name:        "approve",
+					Description: "Approve a session which is pending approval, which must not be associated with the calling user",
+					Examples: []string{
+						"id=*;type=session;actions=approve",
+					},
+				},
+				{
+					Name:        "deny",
+					Description: "Deny a session which is pending approval, which must not be associated with the calling user",
+					Examples: []string{
+						"id=*;type=session;actions=deny",
+					},
+				},
+			},
+		},
+	},
+}
+
+var sessionPolicy = &Resource{
+	Type:   "Session Policy",
+	Scopes: []string{"Org", "Project"},
+	Endpoints: []*Endpoint{
+		{
+			Path: "/session-policies",
+			Params: map[string]string{
+				"Type": "session-policy",
+			},
+			Actions: []*Action{
+				{
+					Name:        "create",
+					Description: "Create a session policy",
+					Examples: []string{
+						"type=<type>;actions=create",
+					},
+				},
+				{
+					Name:        "list",
+					Description: "List session policies",
+					Examples: []string{
+						"type=<type>;actions=list",
+					},
+				},
 			},
 		},
+		{
+			Path: "/session-policies/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "session-policy",
+			},
+			Actions: rudActions("a session policy", false),
+		},
 	},
 }
 
@@ -828,3 +935,61 @@ var user = &Resource{
 		},
 	},
 }
+
+var worker = &Resource{
+	Type:   "Worker",
+	Scopes: []string{"Global"},
+	Endpoints: []*Endpoint{
+		{
+			Path: "/workers",
+			Params: map[string]string{
+				"Type": "worker",
+			},
+			Actions: clActions("a worker"),
+		},
+		{
+			Path: "/workers/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "worker",
+			},
+			Actions: []*Action{
+				{
+					Name:        "read",
+					Description: "Read a worker",
+					Examples: []string{
+						"id=<id>;actions=read",
+					},
+				},
+				{
+					Name:        "delete",
+					Description: "Delete a worker, revoking its credentials",
+					Examples: []string{
+						"id=<id>;actions=delete",
+					},
+				},
+				{
+					Name:        "add-worker-tags",
+					Description: "Add API tags to a worker",
+					Examples: []string{
+						"id=<id>;actions=add-worker-tags",
+					},
+				},
+				{
+					Name:        "set-worker-tags",
+					Description: "Set the full set of API tags on a worker",
+					Examples: []string{
+						"id=<id>;actions=set-worker-tags",
+					},
+				},
+				{
+					Name:        "remove-worker-tags",
+					Description: "Remove API tags from a worker",
+					Examples: []string{
+						"id=<id>;actions=remove-worker-tags",
+					},
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,52 @@
+package credentials
+
+import (
+	"github.com/hashicorp/eventlogger/filters/encrypt"
+)
+
+// Tags implements the encrypt.Taggable interface which allows
+// Credential map fields to be classified for the encrypt filter.
+func (req *Credential) Tags() ([]encrypt.PointerTag, error) {
+	var tags []encrypt.PointerTag
+	if req.Attributes != nil {
+		switch req.Type {
+		case "username_password":
+			upTags := []encrypt.PointerTag{
+				// public fields
+				{
+					Pointer:        "/Attributes/Fields/username",
+					Classification: encrypt.PublicClassification,
+				},
+				{
+					Pointer:        "/Attributes/Fields/password_hmac",
+					Classification: encrypt.PublicClassification,
+				},
+				// secrets
+				{
+					Pointer:        "/Attributes/Fields/password",
+					Classification: encrypt.SecretClassification,
+				},
+			}
+			tags = append(tags, upTags...)
+		case "ssh_private_key":
+			spkTags := []encrypt.PointerTag{
+				// public fields
+				{
+					Pointer:        "/Attributes/Fields/username",
+					Classification: encrypt.PublicClassification,
+				},
+				{
+					Pointer:        "/Attributes/Fields/private_key_hmac",
+					Classification: encrypt.PublicClassification,
+				},
+				// secrets
+				{
+					Pointer:        "/Attributes/Fields/private_key",
+					Classification: encrypt.SecretClassification,
+				},
+			}
+			tags = append(tags, spkTags...)
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,131 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/eventlogger/filters/encrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestCredential_Tags(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	wrapper := wrapper.TestWrapper(t)
+	testEncryptingFilter := &encrypt.Filter{
+		Wrapper:  wrapper,
+		HmacSalt: []byte("salt"),
+		HmacInfo: []byte("info"),
+	}
+
+	tests := []struct {
+		name      string
+		testEvent *eventlogger.Event
+		wantEvent *eventlogger.Event
+	}{
+		{
+			name: "validate-username-password-filtering",
+			testEvent: &eventlogger.Event{
+				Type:      "test",
+				CreatedAt: now,
+				Payload: &Credential{
+					Id:                "id",
+					CredentialStoreId: "credential-store-id",
+					Name:              &wrapperspb.StringValue{Value: "name"},
+					Description:       &wrapperspb.StringValue{Value: "description"},
+					Type:              "username_password",
+					Attributes: &structpb.Struct{
+						Fields: map[string]*structpb.Value{
+							"username":      structpb.NewStringValue("public-username"),
+							"password_hmac": structpb.NewStringValue("public-password_hmac"),
+							"password":      structpb.NewStringValue("secret-password"),
+						},
+					},
+					AuthorizedActions: []string{"action-1", "action-2"},
+				},
+			},
+			wantEvent: &eventlogger.Event{
+				Type:      "test",
+				CreatedAt: now,
+				Payload: &Credential{
+					Id:                "id",
+					CredentialStoreId: "credential-store-id",
+					Name:              &wrapperspb.StringValue{Value: "name"},
+					Description:       &wrapperspb.StringValue{Value: "description"},
+					Type:              "username_password",
+					Attributes: &structpb.Struct{
+						Fields: map[string]*structpb.Value{
+							"username":      structpb.NewStringValue("public-username"),
+							"password_hmac": structpb.NewStringValue("public-password_hmac"),
+							"password":      structpb.NewStringValue("<REDACTED>"),
+						},
+					},
+					AuthorizedActions: []string{"action-1", "action-2"},
+				},
+			},
+		},
+		{
+			name: "validate-ssh-private-key-filtering",
+			testEvent: &eventlogger.Event{
+				Type:      "test",
+				CreatedAt: now,
+				Payload: &Credential{
+					Id:                "id",
+					CredentialStoreId: "credential-store-id",
+					Name:              &wrapperspb.StringValue{Value: "name"},
+					Description:       &wrapperspb.StringValue{Value: "description"},
+					Type:              "ssh_private_key",
+					Attributes: &structpb.Struct{
+						Fields: map[string]*structpb.Value{
+							"username":         structpb.NewStringValue("public-username"),
+							"private_key_hmac": structpb.NewStringValue("public-private_key_hmac"),
+							"private_key":      structpb.NewStringValue("secret-private_key"),
+						},
+					},
+					AuthorizedActions: []string{"action-1", "action-2"},
+				},
+			},
+			wantEvent: &eventlogger.Event{
+				Type:      "test",
+				CreatedAt: now,
+				Payload: &Credential{
+					Id:                "id",
+					CredentialStoreId: "credential-store-id",
+					Name:              &wrapperspb.StringValue{Value: "name"},
+					Description:       &wrapperspb.StringValue{Value: "description"},
+					Type:              "ssh_private_key",
+					Attributes: &structpb.Struct{
+						Fields: map[string]*structpb.Value{
+							"username":         structpb.NewStringValue("public-username"),
+							"private_key_hmac": structpb.NewStringValue("public-private_key_hmac"),
+							"private_key":      structpb.NewStringValue("<REDACTED>"),
+						},
+					},
+					AuthorizedActions: []string{"action-1", "action-2"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := testEncryptingFilter.Process(ctx, tt.testEvent)
+			require.NoError(err)
+			require.NotNil(got)
+			actualJson, err := json.Marshal(got)
+			require.NoError(err)
+			t.Log(string(actualJson))
+
+			wantJson, err := json.Marshal(tt.wantEvent)
+			require.NoError(err)
+			assert.JSONEq(string(wantJson), string(actualJson))
+		})
+	}
+}